@@ -71,6 +71,9 @@ var (
 	assessSchemaMappingManifest      string
 	assessSchemaMappingMinConfidence float64
 	assessSchemaMappingStrict        bool
+	assessSchemaCompatEnable         bool
+	assessSchemaCompatRoot           string
+	assessSchemaCompatPolicy         string
 	assessScope                      bool
 	assessHook                       string
 	assessHookManifest               string
@@ -107,6 +110,8 @@ var (
 	assessLintMakeExclude []string
 	// Extended output
 	assessExtended bool
+	// Performance baseline refresh
+	assessWriteBenchmarkBaseline bool
 )
 
 func init() {
@@ -146,6 +151,9 @@ func setupAssessCommandFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&assessSchemaMappingManifest, "schema-mapping-manifest", "", "Override schema mapping manifest path (default: .goneat/schema-mappings.yaml)")
 	cmd.Flags().Float64Var(&assessSchemaMappingMinConfidence, "schema-mapping-min-confidence", 0, "Override minimum confidence threshold for schema mappings (0-1 range)")
 	cmd.Flags().BoolVar(&assessSchemaMappingStrict, "schema-mapping-strict", false, "Fail assessment when config files cannot be mapped to schemas")
+	cmd.Flags().BoolVar(&assessSchemaCompatEnable, "schema-compat", false, "Check that changes between schemas/<area>/vX.Y.Z/ directories match their semver bump")
+	cmd.Flags().StringVar(&assessSchemaCompatRoot, "schema-compat-root", "", "Directory scanned for versioned schema directories (default: <repo>/schemas)")
+	cmd.Flags().StringVar(&assessSchemaCompatPolicy, "schema-compat-policy", "writers", "Which schema changes are breaking: writers, readers or both")
 	// Scoped discovery
 	cmd.Flags().BoolVar(&assessScope, "scope", false, "Limit traversal scope to include paths and force-include anchors")
 	// Lint controls
@@ -180,6 +188,7 @@ func setupAssessCommandFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&assessBenchmark, "benchmark", false, "Run benchmark comparison")
 	cmd.Flags().IntVar(&assessBenchmarkIterations, "iterations", 5, "Number of benchmark iterations")
 	cmd.Flags().StringVar(&assessBenchmarkOutput, "benchmark-output", "benchmark.json", "Benchmark output file")
+	cmd.Flags().BoolVar(&assessWriteBenchmarkBaseline, "write-benchmark-baseline", false, "Record the performance category's benchmark run as the new baseline instead of comparing")
 
 	// Add shorthand flags for modes
 	cmd.Flags().Bool("no-op", false, "Run in no-op mode (assessment only)")
@@ -255,11 +264,15 @@ func runAssess(cmd *cobra.Command, args []string) error {
 	assessSchemaMappingManifest, _ = flags.GetString("schema-mapping-manifest")
 	assessSchemaMappingMinConfidence, _ = flags.GetFloat64("schema-mapping-min-confidence")
 	assessSchemaMappingStrict, _ = flags.GetBool("schema-mapping-strict")
+	assessSchemaCompatEnable, _ = flags.GetBool("schema-compat")
+	assessSchemaCompatRoot, _ = flags.GetString("schema-compat-root")
+	assessSchemaCompatPolicy, _ = flags.GetString("schema-compat-policy")
 	assessScope, _ = flags.GetBool("scope")
 	assessCISummary, _ = flags.GetBool("ci-summary")
 	assessProfile, _ = flags.GetString("profile")
 	assessPackageMode, _ = flags.GetBool("package-mode")
 	assessExtended, _ = flags.GetBool("extended")
+	assessWriteBenchmarkBaseline, _ = flags.GetBool("write-benchmark-baseline")
 
 	// Validate mode value
 	switch assessMode {
@@ -340,6 +353,11 @@ func runAssess(cmd *cobra.Command, args []string) error {
 			MinConfidence: assessSchemaMappingMinConfidence,
 			Strict:        assessSchemaMappingStrict,
 		},
+		SchemaCompat: assess.SchemaCompatConfig{
+			Enabled: assessSchemaCompatEnable,
+			Root:    strings.TrimSpace(assessSchemaCompatRoot),
+			Policy:  assessSchemaCompatPolicy,
+		},
 		Scope:                 assessScope,
 		PackageMode:           assessPackageMode,
 		Extended:              assessExtended,
@@ -363,6 +381,8 @@ func runAssess(cmd *cobra.Command, args []string) error {
 		LintMakeEnabled:       assessLintMake,
 		LintMakePaths:         assessLintMakePaths,
 		LintMakeExclude:       assessLintMakeExclude,
+
+		WriteBenchmarkBaseline: assessWriteBenchmarkBaseline,
 	}
 
 	// Warn if --new-issues-base is set without --new-issues-only (no-op scenario)
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/goneat/internal/ops"
+	"github.com/fulmenhq/goneat/pkg/logger"
+	"github.com/fulmenhq/goneat/pkg/propagation"
+	"github.com/fulmenhq/goneat/pkg/release"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/cobra"
+)
+
+// changelogCmd represents the changelog command
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Generate changelog sections and release notes from git history",
+	Long:  `Generate Keep a Changelog sections and release notes from Conventional Commits.`,
+}
+
+var changelogGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Insert a changelog section for the commits since a release tag",
+	Long: `Group the Conventional Commits since a release tag into Keep a Changelog
+sections (Breaking Changes, Added, Changed, Deprecated, Removed, Fixed,
+Security), grouped by scope, and insert a dated section at the top of
+CHANGELOG.md below [Unreleased]. Entries already under [Unreleased] move
+into the new section, leaving [Unreleased] empty.
+
+BREAKING CHANGE footers and ! commits are collected under Breaking Changes.
+Issue references (#123, Refs/Closes/Fixes footers) are linked with
+--issue-url, a template where {id} is the issue number; it defaults to the
+issues page of a GitHub or GitLab origin remote.
+
+--since defaults to the latest release tag and --version to the next version
+after --since, inferred as in 'goneat version next'. The release date may not be older than
+the newest changelog entry, keeping the dates monotonic check green.
+
+--check fails when commits since the last tag would produce changelog entries
+but CHANGELOG.md has no [Unreleased] entries or newer release section.
+
+Examples:
+  goneat changelog generate                            # Next version, today's date
+  goneat changelog generate --since v1.2.0 --version v1.3.0 --dry-run
+  goneat changelog generate --release-notes docs/releases/v1.3.0.md
+  goneat changelog generate --check                    # CI: require a changelog entry`,
+	Args: cobra.NoArgs,
+	RunE: runChangelogGenerate,
+}
+
+func init() {
+	rootCmd.AddCommand(changelogCmd)
+
+	capabilities := ops.GetDefaultCapabilities(ops.GroupWorkflow, ops.CategoryManagement)
+	if err := ops.RegisterCommandWithTaxonomy("changelog", ops.GroupWorkflow, ops.CategoryManagement, capabilities, changelogCmd, "Generate changelog sections and release notes from git history"); err != nil {
+		panic(fmt.Sprintf("Failed to register changelog command: %v", err))
+	}
+
+	changelogCmd.AddCommand(changelogGenerateCmd)
+	changelogGenerateCmd.Flags().String("since", "", "Release tag to start from (default: latest release tag)")
+	changelogGenerateCmd.Flags().String("version", "", "Version heading for the new section (default: inferred next version)")
+	changelogGenerateCmd.Flags().String("date", "", "Release date YYYY-MM-DD (default: today)")
+	changelogGenerateCmd.Flags().String("file", "CHANGELOG.md", "Changelog file to update")
+	changelogGenerateCmd.Flags().String("issue-url", "", "Issue link template with {id} (default: derived from the origin remote)")
+	changelogGenerateCmd.Flags().String("release-notes", "", "Also write release notes for the section to this file")
+	changelogGenerateCmd.Flags().String("policy", "", "Path to version policy file (default: .goneat/version-policy.yaml)")
+	changelogGenerateCmd.Flags().Bool("dry-run", false, "Print the section instead of updating the changelog")
+	changelogGenerateCmd.Flags().Bool("check", false, "Fail when commits since the last tag have no changelog entry")
+}
+
+func runChangelogGenerate(cmd *cobra.Command, _ []string) error {
+	since, _ := cmd.Flags().GetString("since")
+	version, _ := cmd.Flags().GetString("version")
+	dateFlag, _ := cmd.Flags().GetString("date")
+	file, _ := cmd.Flags().GetString("file")
+	issueURL, _ := cmd.Flags().GetString("issue-url")
+	notesPath, _ := cmd.Flags().GetString("release-notes")
+	policyPath, _ := cmd.Flags().GetString("policy")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	check, _ := cmd.Flags().GetBool("check")
+	out := cmd.OutOrStdout()
+
+	policy, err := propagation.NewPolicyLoader().LoadPolicy(policyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load version policy: %w", err)
+	}
+	history, err := release.OpenHistory(".")
+	if err != nil {
+		return err
+	}
+
+	sinceHash := plumbing.ZeroHash
+	sinceVersion := ""
+	if since != "" {
+		if sinceHash, err = history.ResolveTag(since); err != nil {
+			return err
+		}
+		sinceVersion = since
+	} else if latest, ok, err := history.LatestRelease(policy.Version.Scheme); err != nil {
+		return err
+	} else if ok {
+		sinceHash, sinceVersion = latest.Hash, latest.Name
+	}
+	commits, err := history.CommitsSince(sinceHash)
+	if err != nil {
+		return err
+	}
+
+	content, err := readChangelog(file)
+	if err != nil {
+		return err
+	}
+
+	if check {
+		notable := release.NotableCommits(commits)
+		if len(notable) == 0 || release.HasPendingChangelogEntries(content, sinceVersion) {
+			_, _ = fmt.Fprintf(out, "✅ %s is up to date (%d notable commits since %s)\n", file, len(notable), nonEmpty(sinceVersion, "the first commit"))
+			return nil
+		}
+		_, _ = fmt.Fprintf(out, "❌ %d notable commits since %s have no entry in %s:\n", len(notable), nonEmpty(sinceVersion, "the first commit"), file)
+		for _, c := range notable {
+			_, _ = fmt.Fprintf(out, "  • %s %s\n", c.Hash[:7], c.Subject)
+		}
+		return fmt.Errorf("changelog check failed: add an [Unreleased] entry or run 'goneat changelog generate'")
+	}
+
+	if version == "" {
+		current, _ := readVersionFromFile("VERSION")
+		plan, err := release.PlanNext(history, release.NextOptions{Scheme: policy.Version.Scheme, Current: current, Since: since})
+		if err != nil {
+			return err
+		}
+		if plan.TagName == "" {
+			return fmt.Errorf("no releasable commits since %s; pass --version to generate a section anyway", nonEmpty(sinceVersion, "the first commit"))
+		}
+		version = plan.TagName
+	}
+	date := time.Now()
+	if dateFlag != "" {
+		if date, err = time.Parse("2006-01-02", dateFlag); err != nil {
+			return fmt.Errorf("invalid --date %q (want YYYY-MM-DD): %w", dateFlag, err)
+		}
+	}
+	if issueURL == "" {
+		issueURL = release.IssueURLFromRemote(history.RemoteURL("origin"))
+	}
+	opts := release.ChangelogOptions{Version: version, Date: date, IssueURL: issueURL}
+	section := release.RenderChangelogSection(commits, opts)
+
+	if dryRun {
+		_, _ = fmt.Fprint(out, section)
+		return nil
+	}
+	updated, err := release.InsertChangelogSection(content, section, version, date)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(file, []byte(updated), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	logger.Info(fmt.Sprintf("Added %s section to %s (%d commits)", version, file, len(commits)))
+	_, _ = fmt.Fprintf(out, "✅ Added [%s] - %s to %s\n", version, date.Format("2006-01-02"), file)
+
+	if notesPath != "" {
+		notes := release.RenderReleaseNotes(detectProjectName(), commits, opts)
+		if err := os.MkdirAll(filepath.Dir(notesPath), 0o750); err != nil {
+			return fmt.Errorf("failed to create release notes directory: %w", err)
+		}
+		if err := os.WriteFile(notesPath, []byte(notes), 0o600); err != nil {
+			return fmt.Errorf("failed to write release notes: %w", err)
+		}
+		_, _ = fmt.Fprintf(out, "✅ Release notes written: %s\n", notesPath)
+	}
+	return nil
+}
+
+func readChangelog(file string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(file)) // #nosec G304 - changelog path is a user-specified CLI flag
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "# Changelog\n", nil
+		}
+		return "", fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return strings.ReplaceAll(string(data), "\r\n", "\n"), nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/spf13/pflag"
+)
+
+func TestChangelogGenerateCmd(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working dir: %v", err)
+	}
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldWd) }()
+
+	repo, err := git.PlainInit(tempDir, false)
+	if err != nil {
+		t.Fatalf("git init: %v", err)
+	}
+	cfg, _ := repo.Config()
+	cfg.User.Name, cfg.User.Email = "goneat", "ci@goneat.dev"
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatalf("set config: %v", err)
+	}
+	wt, _ := repo.Worktree()
+	commit := func(file, message string) {
+		if err := os.WriteFile(file, []byte(message), 0o600); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if _, err := wt.Add(file); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+		if _, err := wt.Commit(message, &git.CommitOptions{}); err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+	}
+	changelog := "# Changelog\n\n## [Unreleased]\n\n## [v1.0.0] - 2026-01-05\n\n### Added\n\n- first release\n"
+	commit("CHANGELOG.md", changelog)
+	head, _ := repo.Head()
+	if _, err := repo.CreateTag("v1.0.0", head.Hash(), nil); err != nil {
+		t.Fatalf("tag: %v", err)
+	}
+	commit("a.go", "feat(cli): add changelog command")
+	commit("b.go", "fix: handle missing tag\n\nFixes #9")
+
+	run := func(flags map[string]string) (string, error) {
+		for name, value := range flags {
+			if err := changelogGenerateCmd.Flags().Set(name, value); err != nil {
+				t.Fatalf("set %s: %v", name, err)
+			}
+		}
+		defer func() {
+			changelogGenerateCmd.Flags().VisitAll(func(f *pflag.Flag) {
+				_ = f.Value.Set(f.DefValue)
+				f.Changed = false
+			})
+		}()
+		var buf bytes.Buffer
+		changelogGenerateCmd.SetOut(&buf)
+		err := runChangelogGenerate(changelogGenerateCmd, nil)
+		return buf.String(), err
+	}
+
+	out, err := run(map[string]string{"check": "true"})
+	if err == nil || !strings.Contains(out, "2 notable commits since v1.0.0") {
+		t.Fatalf("expected check failure, got %v\n%s", err, out)
+	}
+
+	if _, err := run(map[string]string{"date": "2026-02-01", "issue-url": "https://example.com/issues/{id}"}); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	data, _ := os.ReadFile("CHANGELOG.md")
+	want := "## [Unreleased]\n\n## [v1.1.0] - 2026-02-01\n\n### Added\n\n- **cli**: add changelog command\n\n### Fixed\n\n- handle missing tag ([#9](https://example.com/issues/9))\n\n## [v1.0.0]"
+	if !strings.Contains(string(data), want) {
+		t.Fatalf("CHANGELOG.md:\n%s", data)
+	}
+
+	if out, err := run(map[string]string{"check": "true"}); err != nil {
+		t.Fatalf("check after generate: %v\n%s", err, out)
+	}
+	if _, err := run(map[string]string{"version": "v1.0.1", "date": "2026-01-01"}); err == nil || !strings.Contains(err.Error(), "older than") {
+		t.Errorf("expected monotonic date error, got %v", err)
+	}
+}
@@ -10,13 +10,17 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/fulmenhq/goneat/internal/ops"
+	"github.com/fulmenhq/goneat/pkg/buildinfo"
 	"github.com/fulmenhq/goneat/pkg/config"
 	"github.com/fulmenhq/goneat/pkg/dependencies"
+	"github.com/fulmenhq/goneat/pkg/dependencies/policy"
 	"github.com/fulmenhq/goneat/pkg/logger"
+	"github.com/fulmenhq/goneat/pkg/registry"
 	"github.com/fulmenhq/goneat/pkg/safeio"
 	"github.com/fulmenhq/goneat/pkg/sbom"
 	"github.com/spf13/cobra"
@@ -44,21 +48,26 @@ func init() {
 	dependenciesCmd.Flags().Bool("cooling", false, "Check package cooling policy")
 	dependenciesCmd.Flags().Bool("sbom", false, "Generate SBOM artifact")
 	dependenciesCmd.Flags().Bool("vuln", false, "Generate vulnerability report (SBOM + grype)")
+	dependenciesCmd.Flags().Bool("supply-chain", false, "Check lockfiles for typosquats, dependency confusion and npm install scripts")
 
 	// Policy and output
 	dependenciesCmd.Flags().String("policy", ".goneat/dependencies.yaml", "Policy file path")
+	dependenciesCmd.Flags().String("policy-dir", policy.DefaultBundleDir, "Directory of Rego policy modules (*.rego) evaluated after analysis")
 	dependenciesCmd.Flags().String("format", "text", "Output format (text, json, markdown, html)")
 	dependenciesCmd.Flags().String("output", "", "Output file (default: stdout)")
 	dependenciesCmd.Flags().Bool("quiet", false, "Suppress goneat logs (best-effort)")
+	dependenciesCmd.Flags().Bool("offline", false, "Serve registry metadata only from the on-disk cache; cache misses are reported explicitly")
 
 	// SBOM-specific
-	dependenciesCmd.Flags().String("sbom-format", "cyclonedx-json", "SBOM format (cyclonedx-json)")
-	dependenciesCmd.Flags().String("sbom-output", "", "SBOM output file path (default: sbom/goneat-<timestamp>.cdx.json)")
-	dependenciesCmd.Flags().String("sbom-input", "", "Use an existing SBOM file (skips syft) for vulnerability scanning")
+	dependenciesCmd.Flags().String("sbom-format", sbom.FormatCycloneDXJSON, "SBOM format ("+strings.Join(sbom.Formats, ", ")+")")
+	dependenciesCmd.Flags().String("sbom-engine", dependencies.SBOMEngineAuto, "SBOM engine: auto (syft when installed, else native), native (goneat lockfile/module-graph generators), syft")
+	dependenciesCmd.Flags().String("sbom-output", "", "SBOM output file path (default: sbom/goneat-<timestamp>.<format extension>)")
+	dependenciesCmd.Flags().String("sbom-input", "", "Use an existing CycloneDX or SPDX SBOM (skips syft); with --sbom it is converted to --sbom-format")
 	dependenciesCmd.Flags().Bool("sbom-stdout", false, "Output SBOM to stdout instead of file")
 	dependenciesCmd.Flags().String("sbom-platform", "", "Target platform for SBOM (e.g., linux/amd64)")
 	dependenciesCmd.Flags().Bool("no-ignore", false, "Disable .goneatignore/.gitignore excludes for fallback vulnerability scans")
 	dependenciesCmd.Flags().StringSlice("force-include", []string{}, "Force-include paths or globs even if ignored during fallback vulnerability scans")
+	dependenciesCmd.Flags().String("vex-dir", dependencies.DefaultVEXDir, "Directory of OpenVEX/CycloneDX VEX documents applied to vulnerability findings")
 
 	// Failure controls
 	dependenciesCmd.Flags().String("fail-on", "critical", "Fail on severity (critical, high, medium, low)")
@@ -134,6 +143,36 @@ func shouldFailDependencies(result *dependencies.AnalysisResult, failOn string)
 	return false
 }
 
+// applyRegistryOfflineMode switches the shared registry disk cache to offline
+// mode. GONEAT_OFFLINE enables offline mode even without the flag.
+func applyRegistryOfflineMode(offline bool) {
+	if !offline {
+		return
+	}
+	if disk := registry.SharedDiskCache(); disk != nil {
+		disk.SetOffline(true)
+	} else {
+		logger.Warn("offline mode requested but the goneat home directory is unavailable; registry lookups will fail")
+	}
+}
+
+// newDependenciesAnalyzer selects the analyzer for a detected language.
+func newDependenciesAnalyzer(lang dependencies.Language) (dependencies.Analyzer, error) {
+	switch lang {
+	case dependencies.LanguageRust:
+		return dependencies.NewRustAnalyzer(), nil
+	case dependencies.LanguageGo:
+		return dependencies.NewGoAnalyzer(), nil
+	case dependencies.LanguageTypeScript:
+		return dependencies.NewTypeScriptAnalyzer(), nil
+	case dependencies.LanguagePython:
+		return dependencies.NewPythonAnalyzer(), nil
+	case dependencies.LanguageCSharp:
+		return dependencies.NewCSharpAnalyzer(), nil
+	}
+	return nil, fmt.Errorf("no analyzer available for language: %s", lang)
+}
+
 func runDependencies(cmd *cobra.Command, args []string) error {
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	if quiet {
@@ -144,23 +183,30 @@ func runDependencies(cmd *cobra.Command, args []string) error {
 		_ = logger.Initialize(logger.Config{Level: logger.ErrorLevel, UseColor: !noColor, JSON: logJSON, Component: "goneat", NoOp: noOp})
 	}
 
+	offline, _ := cmd.Flags().GetBool("offline")
+	applyRegistryOfflineMode(offline)
+
 	licensesFlag, _ := cmd.Flags().GetBool("licenses")
 	coolingFlag, _ := cmd.Flags().GetBool("cooling")
 	sbomFlag, _ := cmd.Flags().GetBool("sbom")
 	vulnFlag, _ := cmd.Flags().GetBool("vuln")
+	supplyChainFlag, _ := cmd.Flags().GetBool("supply-chain")
 
 	runAnalysis := licensesFlag || coolingFlag
 	runVuln := vulnFlag
 	runSBOM := sbomFlag
 
-	if !runAnalysis && !runVuln && !runSBOM {
+	if !runAnalysis && !runVuln && !runSBOM && !supplyChainFlag {
 		if err := cmd.Help(); err != nil {
 			return fmt.Errorf("failed to show help: %w", err)
 		}
 		return nil
 	}
 
-	if runAnalysis || runVuln {
+	// License results from the analyzers enrich SBOM license fields.
+	var analyzedDeps []dependencies.Dependency
+
+	if runAnalysis || runVuln || supplyChainFlag {
 		// Determine target directory (default to current directory)
 		target := "."
 		if len(args) > 0 {
@@ -184,6 +230,7 @@ func runDependencies(cmd *cobra.Command, args []string) error {
 			Passed:       true,
 		}
 
+		var languages []dependencies.Language
 		if runAnalysis {
 			detector := dependencies.NewDetector(&depsCfg)
 
@@ -195,21 +242,11 @@ func runDependencies(cmd *cobra.Command, args []string) error {
 				return errors.New("no supported language detected")
 			}
 
-			// Select the appropriate analyzer based on detected language
-			var analyzer dependencies.Analyzer
-			switch lang {
-			case dependencies.LanguageRust:
-				analyzer = dependencies.NewRustAnalyzer()
-			case dependencies.LanguageGo:
-				analyzer = dependencies.NewGoAnalyzer()
-			case dependencies.LanguageTypeScript:
-				analyzer = dependencies.NewTypeScriptAnalyzer()
-			case dependencies.LanguagePython:
-				analyzer = dependencies.NewPythonAnalyzer()
-			case dependencies.LanguageCSharp:
-				analyzer = dependencies.NewCSharpAnalyzer()
-			default:
-				return fmt.Errorf("no analyzer available for language: %s", lang)
+			languages = []dependencies.Language{lang}
+
+			analyzer, err := newDependenciesAnalyzer(lang)
+			if err != nil {
+				return err
 			}
 
 			analysisConfig := dependencies.AnalysisConfig{
@@ -227,6 +264,7 @@ func runDependencies(cmd *cobra.Command, args []string) error {
 				return err
 			}
 			result = analysisResult
+			analyzedDeps = analysisResult.Dependencies
 			if result.Dependencies == nil {
 				result.Dependencies = []dependencies.Dependency{}
 			}
@@ -236,13 +274,18 @@ func runDependencies(cmd *cobra.Command, args []string) error {
 		}
 
 		// Vulnerability scan is orchestrated here (SBOM + grype) because it is language-agnostic.
+		var vulnResult *dependencies.VulnerabilityScanResult
 		if runVuln {
 			sbomInput, _ := cmd.Flags().GetString("sbom-input")
 			noIgnore, _ := cmd.Flags().GetBool("no-ignore")
 			forceInclude, _ := cmd.Flags().GetStringSlice("force-include")
-			vulnResult, vulnIssues, vErr := dependencies.RunVulnerabilityScanWithOptions(context.Background(), target, policyPath, sbomInput, 10*time.Minute, dependencies.VulnerabilityScanOptions{
+			vexDir, _ := cmd.Flags().GetString("vex-dir")
+			var vulnIssues []dependencies.Issue
+			var vErr error
+			vulnResult, vulnIssues, vErr = dependencies.RunVulnerabilityScanWithOptions(context.Background(), target, policyPath, sbomInput, 10*time.Minute, dependencies.VulnerabilityScanOptions{
 				NoIgnore:     noIgnore,
 				ForceInclude: forceInclude,
+				VEXDir:       vexDir,
 			})
 			if vErr != nil {
 				return vErr
@@ -260,6 +303,38 @@ func runDependencies(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		// Supply-chain heuristics read lockfiles only and need no network.
+		if supplyChainFlag {
+			supplyIssues, err := dependencies.RunSupplyChainChecks(target, policyPath)
+			if err != nil {
+				return err
+			}
+			for _, si := range supplyIssues {
+				result.Issues = append(result.Issues, si)
+				if si.Severity != "info" {
+					result.Passed = false
+				}
+			}
+		}
+
+		// Rego policy bundles see the combined analysis and vulnerability results.
+		policyDir, _ := cmd.Flags().GetString("policy-dir")
+		policyIssues, err := dependencies.EvaluatePolicyBundle(context.Background(), target, policyDir, dependencies.PolicyInputOptions{
+			PolicyPath:    policyPath,
+			Languages:     languages,
+			Dependencies:  result.Dependencies,
+			Vulnerability: vulnResult,
+		})
+		if err != nil {
+			return err
+		}
+		for _, pi := range policyIssues {
+			result.Issues = append(result.Issues, pi)
+			if pi.Severity != "info" {
+				result.Passed = false
+			}
+		}
+
 		// Output
 		output, _ := cmd.Flags().GetString("output")
 		format, _ := cmd.Flags().GetString("format")
@@ -307,18 +382,19 @@ func runDependencies(cmd *cobra.Command, args []string) error {
 			target = args[0]
 		}
 
-		invoker, err := sbom.NewSyftInvoker()
-		if err != nil {
-			return fmt.Errorf("failed to initialize SBOM generator: %w\n\n"+
-				"To install syft, run:\n"+
-				"  goneat doctor tools --scope sbom --install --yes\n\n"+
-				"Or install syft manually from: https://github.com/anchore/syft#installation", err)
-		}
-
 		sbomFormat, _ := cmd.Flags().GetString("sbom-format")
 		sbomOutput, _ := cmd.Flags().GetString("sbom-output")
 		sbomStdout, _ := cmd.Flags().GetBool("sbom-stdout")
 		sbomPlatform, _ := cmd.Flags().GetString("sbom-platform")
+		sbomInput, _ := cmd.Flags().GetString("sbom-input")
+		sbomEngine, _ := cmd.Flags().GetString("sbom-engine")
+		if !sbom.ValidFormat(sbomFormat) {
+			return fmt.Errorf("unsupported --sbom-format %q (supported: %s)", sbomFormat, strings.Join(sbom.Formats, ", "))
+		}
+
+		if !licensesFlag && sbomInput == "" {
+			analyzedDeps = analyzeSBOMLicenses(target)
+		}
 
 		sbomConfig := sbom.Config{
 			TargetPath: target,
@@ -326,11 +402,20 @@ func runDependencies(cmd *cobra.Command, args []string) error {
 			Format:     sbomFormat,
 			Stdout:     sbomStdout,
 			Platform:   sbomPlatform,
+			Namespace:  sbom.ProjectNamespace(target),
+			Licenses:   dependencies.SBOMLicenses(analyzedDeps),
 		}
 
-		result, err := invoker.Generate(context.Background(), sbomConfig)
+		var result *sbom.Result
+		var err error
+		switch {
+		case sbomInput != "":
+			result, err = convertSBOMInput(sbomInput, sbomConfig)
+		default:
+			result, err = generateDependenciesSBOM(context.Background(), sbomEngine, sbomConfig)
+		}
 		if err != nil {
-			return fmt.Errorf("SBOM generation failed: %w", err)
+			return err
 		}
 
 		if sbomStdout {
@@ -349,3 +434,155 @@ func runDependencies(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// analyzeSBOMLicenses runs the license analyzer for SBOM license fields when
+// --licenses was not requested. Failures only leave the fields empty.
+func analyzeSBOMLicenses(target string) []dependencies.Dependency {
+	cfg, err := config.LoadProjectConfig()
+	if err != nil {
+		logger.Warn("sbom: failed to load project config; SBOM license fields left empty", logger.Err(err))
+		return nil
+	}
+	depsCfg := cfg.GetDependenciesConfig()
+	lang, _, err := dependencies.NewDetector(&depsCfg).Detect(target)
+	if err != nil || lang == "" {
+		logger.Warn("sbom: no supported language detected; SBOM license fields left empty", logger.String("target", target))
+		return nil
+	}
+	analyzer, err := newDependenciesAnalyzer(lang)
+	if err != nil {
+		logger.Warn("sbom: license analysis unavailable; SBOM license fields left empty", logger.Err(err))
+		return nil
+	}
+	result, err := analyzer.Analyze(context.Background(), target, dependencies.AnalysisConfig{
+		EngineType:    depsCfg.Engine.Type,
+		Languages:     []dependencies.Language{lang},
+		Target:        target,
+		CheckLicenses: true,
+		Config:        &depsCfg,
+	})
+	if err != nil {
+		logger.Warn("sbom: license analysis failed; SBOM license fields left empty", logger.Err(err))
+		return nil
+	}
+	return result.Dependencies
+}
+
+// generateDependenciesSBOM runs the selected SBOM engine. In auto mode syft
+// is used when installed and goneat's native generators otherwise.
+func generateDependenciesSBOM(ctx context.Context, engine string, cfg sbom.Config) (*sbom.Result, error) {
+	switch engine {
+	case dependencies.SBOMEngineAuto, dependencies.SBOMEngineSyft, dependencies.SBOMEngineNative:
+	default:
+		return nil, fmt.Errorf("unsupported --sbom-engine %q (supported: auto, native, syft)", engine)
+	}
+
+	if engine != dependencies.SBOMEngineNative {
+		invoker, err := sbom.NewSyftInvoker()
+		if err == nil {
+			result, genErr := invoker.Generate(ctx, cfg)
+			if genErr != nil {
+				return nil, fmt.Errorf("SBOM generation failed: %w", genErr)
+			}
+			return result, nil
+		}
+		if engine == dependencies.SBOMEngineSyft || !dependencies.NativeSBOMSupported(cfg.TargetPath) {
+			return nil, fmt.Errorf("failed to initialize SBOM generator: %w\n\n"+
+				"To install syft, run:\n"+
+				"  goneat doctor tools --scope sbom --install --yes\n\n"+
+				"Or install syft manually from: https://github.com/anchore/syft#installation\n\n"+
+				"Or use --sbom-engine native for Go, Rust, npm/pnpm/yarn and Python lockfiles", err)
+		}
+		logger.Info("sbom: syft not available, using native generator", logger.String("target", cfg.TargetPath))
+	}
+
+	start := time.Now()
+	outputPath := sbomOutputPath(cfg, start)
+	if cfg.Stdout {
+		tmpDir, err := os.MkdirTemp("", "goneat-sbom-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temp directory: %w", err)
+		}
+		defer func() { _ = os.RemoveAll(tmpDir) }()
+		outputPath = filepath.Join(tmpDir, "sbom"+sbom.FileExtension(cfg.Format))
+	}
+	count, err := dependencies.GenerateNativeSBOM(ctx, cfg.TargetPath, outputPath, dependencies.NativeSBOMOptions{
+		Format:    cfg.Format,
+		Namespace: cfg.Namespace,
+		Licenses:  cfg.Licenses,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("SBOM generation failed: %w", err)
+	}
+	return sbomFileResult(outputPath, cfg, start, "goneat "+buildinfo.BinaryVersion+" (native)", count)
+}
+
+// convertSBOMInput re-encodes an existing CycloneDX or SPDX SBOM in the
+// requested format, applying analyzer license results.
+func convertSBOMInput(inputPath string, cfg sbom.Config) (*sbom.Result, error) {
+	start := time.Now()
+	doc, inputFormat, err := sbom.LoadDocument(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Namespace != "" && !strings.HasPrefix(doc.Namespace, "https://") {
+		doc.Namespace = cfg.Namespace
+	}
+	doc.ApplyLicenses(cfg.Licenses)
+	data, err := doc.Encode(cfg.Format)
+	if err != nil {
+		return nil, err
+	}
+	result := &sbom.Result{
+		Format:          cfg.Format,
+		GeneratedAt:     start,
+		ToolVersion:     "converted from " + inputFormat,
+		PackageCount:    len(doc.Packages),
+		SBOMContent:     data,
+		DependencyGraph: doc.Graph(),
+	}
+	if !cfg.Stdout {
+		result.OutputPath = sbomOutputPath(cfg, start)
+		if err := os.MkdirAll(filepath.Dir(result.OutputPath), 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if err := os.WriteFile(result.OutputPath, data, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write SBOM: %w", err)
+		}
+	}
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+func sbomOutputPath(cfg sbom.Config, now time.Time) string {
+	if cfg.OutputPath != "" {
+		return cfg.OutputPath
+	}
+	return filepath.Join("sbom", fmt.Sprintf("goneat-%s%s", now.Format("20060102-150405"), sbom.FileExtension(cfg.Format)))
+}
+
+// sbomFileResult loads a generated SBOM back for the summary (or stdout).
+func sbomFileResult(outputPath string, cfg sbom.Config, start time.Time, toolVersion string, count int) (*sbom.Result, error) {
+	content, err := os.ReadFile(outputPath) // #nosec G304 - path was just written by goneat
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generated SBOM: %w", err)
+	}
+	graph, err := sbom.LoadDependencyGraphFromFile(outputPath)
+	if err != nil {
+		logger.Warn("sbom: failed to extract dependency graph", logger.String("error", err.Error()))
+	}
+	result := &sbom.Result{
+		OutputPath:      outputPath,
+		Format:          cfg.Format,
+		GeneratedAt:     start,
+		ToolVersion:     toolVersion,
+		Duration:        time.Since(start),
+		PackageCount:    count,
+		SBOMContent:     content,
+		DependencyGraph: graph,
+	}
+	if cfg.Stdout {
+		result.OutputPath = ""
+	}
+	return result, nil
+}
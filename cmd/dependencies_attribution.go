@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/fulmenhq/goneat/pkg/config"
+	"github.com/fulmenhq/goneat/pkg/dependencies"
+	"github.com/spf13/cobra"
+)
+
+var dependenciesAttributionCmd = &cobra.Command{
+	Use:   "attribution [target]",
+	Short: "Generate a third-party license and NOTICE bundle",
+	Long: `Aggregate the full license texts and NOTICE files of every dependency into
+a single attribution bundle.
+
+Identical texts are deduplicated by SHA-256 (after normalizing line endings
+and trailing whitespace), packages are grouped by license expression, and
+everything is sorted. The bundle carries no timestamps or local paths, so the
+output is reproducible and can be committed; --check verifies a committed file
+in CI.
+
+Go modules are read from the module cache; Rust crates from the cargo
+registry source cache (run "cargo fetch" first).
+
+Examples:
+  goneat dependencies attribution                                   # Text to stdout
+  goneat dependencies attribution --format markdown --output THIRD_PARTY_NOTICES.md
+  goneat dependencies attribution --format markdown --output THIRD_PARTY_NOTICES.md --check`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDependenciesAttribution,
+}
+
+func init() {
+	dependenciesCmd.AddCommand(dependenciesAttributionCmd)
+	dependenciesAttributionCmd.Flags().String("format", "text", "Output format (text, markdown, html, json)")
+	dependenciesAttributionCmd.Flags().String("output", "", "Output file (default: stdout)")
+	dependenciesAttributionCmd.Flags().Bool("check", false, "Verify that --output is up to date instead of writing it")
+	dependenciesAttributionCmd.Flags().Bool("offline", false, "Serve registry metadata only from the on-disk cache")
+}
+
+func runDependenciesAttribution(cmd *cobra.Command, args []string) error {
+	target := "."
+	if len(args) > 0 {
+		target = args[0]
+	}
+	format, _ := cmd.Flags().GetString("format")
+	output, _ := cmd.Flags().GetString("output")
+	check, _ := cmd.Flags().GetBool("check")
+	offline, _ := cmd.Flags().GetBool("offline")
+	if check && output == "" {
+		return errors.New("--check requires --output")
+	}
+	applyRegistryOfflineMode(offline)
+
+	cfg, err := config.LoadProjectConfig()
+	if err != nil {
+		return err
+	}
+	depsCfg := cfg.GetDependenciesConfig()
+	lang, _, err := dependencies.NewDetector(&depsCfg).Detect(target)
+	if err != nil {
+		return err
+	}
+	if lang == "" {
+		return errors.New("no supported language detected")
+	}
+	analyzer, err := newDependenciesAnalyzer(lang)
+	if err != nil {
+		return err
+	}
+	result, err := analyzer.Analyze(context.Background(), target, dependencies.AnalysisConfig{
+		EngineType:    depsCfg.Engine.Type,
+		Languages:     []dependencies.Language{lang},
+		Target:        target,
+		CheckLicenses: true,
+		Config:        &depsCfg,
+	})
+	if err != nil {
+		return err
+	}
+
+	bundle, err := dependencies.BuildAttribution(result.Dependencies)
+	if err != nil {
+		return err
+	}
+	data, err := dependencies.RenderAttribution(bundle, format)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case check:
+		existing, err := os.ReadFile(output) // #nosec G304 - user-specified output file
+		if err != nil {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("attribution bundle %s cannot be read: %w", output, err)
+		}
+		if !bytes.Equal(existing, data) {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("attribution bundle %s is out of date (expected %s); regenerate with: goneat dependencies attribution --format %s --output %s", output, bundle.Digest, format, output)
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "✅ %s is up to date (%s)\n", output, bundle.Digest)
+	case output != "":
+		if err := os.WriteFile(output, data, 0600); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "✅ Attribution written: %s\n", output)
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "   Packages: %d  Licenses: %d  Texts: %d\n", bundle.Summary.Packages, bundle.Summary.Licenses, bundle.Summary.UniqueTexts)
+		if bundle.Summary.MissingTexts > 0 {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "   ⚠️  %d package(s) without license text\n", bundle.Summary.MissingTexts)
+		}
+	default:
+		_, _ = cmd.OutOrStdout().Write(data)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/config"
+	"github.com/fulmenhq/goneat/pkg/dependencies"
+	"github.com/spf13/cobra"
+)
+
+var dependenciesDiffCmd = &cobra.Command{
+	Use:   "diff <base> [head]",
+	Short: "Show supply-chain changes between two git refs",
+	Long: `Build the dependency set at two git refs and report what changed: added,
+removed, upgraded and downgraded packages, license changes, new cooling
+violations and (with --vuln) newly introduced vulnerabilities.
+
+The repository tree at each ref is written from git objects into a
+temporary directory, so sources, go.work members and local replace targets
+are available to the analyzers and the working tree is never touched. When head is omitted the
+working tree is compared against base.
+
+By default the license and cooling analyzers run at both refs; --lockfile-only
+reads the dependency set from the module graph or lockfiles instead, without
+registry lookups.
+
+Examples:
+  goneat dependencies diff origin/main                      # Markdown for a PR comment
+  goneat dependencies diff v1.2.0 v1.3.0 --format json
+  goneat dependencies diff origin/main HEAD --vuln --output deps-diff.md`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runDependenciesDiff,
+}
+
+func init() {
+	dependenciesCmd.AddCommand(dependenciesDiffCmd)
+	dependenciesDiffCmd.Flags().String("target", ".", "Project directory inside the git repository")
+	dependenciesDiffCmd.Flags().String("format", "markdown", "Output format ("+strings.Join(dependencies.DependencyDiffFormats, ", ")+")")
+	dependenciesDiffCmd.Flags().String("output", "", "Output file (default: stdout)")
+	dependenciesDiffCmd.Flags().String("policy", ".goneat/dependencies.yaml", "Policy file path")
+	dependenciesDiffCmd.Flags().Bool("lockfile-only", false, "Skip the license and cooling analyzers and compare lockfile/module-graph versions only")
+	dependenciesDiffCmd.Flags().Bool("vuln", false, "Scan both refs with grype and report newly introduced vulnerabilities")
+	dependenciesDiffCmd.Flags().String("vex-dir", dependencies.DefaultVEXDir, "Directory of OpenVEX/CycloneDX VEX documents applied to vulnerability findings")
+	dependenciesDiffCmd.Flags().Bool("offline", false, "Serve registry metadata only from the on-disk cache")
+}
+
+func runDependenciesDiff(cmd *cobra.Command, args []string) error {
+	target, _ := cmd.Flags().GetString("target")
+	format, _ := cmd.Flags().GetString("format")
+	output, _ := cmd.Flags().GetString("output")
+	policyPath, _ := cmd.Flags().GetString("policy")
+	lockfileOnly, _ := cmd.Flags().GetBool("lockfile-only")
+	vuln, _ := cmd.Flags().GetBool("vuln")
+	vexDir, _ := cmd.Flags().GetString("vex-dir")
+	offline, _ := cmd.Flags().GetBool("offline")
+	applyRegistryOfflineMode(offline)
+
+	// Snapshots are analyzed in temporary directories, so the policy must not
+	// be resolved relative to them.
+	if policyPath != "" && !filepath.IsAbs(policyPath) {
+		abs, err := filepath.Abs(policyPath)
+		if err != nil {
+			return fmt.Errorf("failed to resolve policy path: %w", err)
+		}
+		policyPath = abs
+	}
+
+	opts := dependencies.DependencyDiffOptions{
+		Base:            args[0],
+		Vulnerabilities: vuln,
+		PolicyPath:      policyPath,
+		VEXDir:          vexDir,
+		Timeout:         10 * time.Minute,
+	}
+	if len(args) > 1 {
+		opts.Head = args[1]
+	}
+	if !lockfileOnly {
+		cfg, err := config.LoadProjectConfig()
+		if err != nil {
+			return err
+		}
+		depsCfg := cfg.GetDependenciesConfig()
+		opts.Analyze = func(ctx context.Context, dir string) (*dependencies.AnalysisResult, error) {
+			lang, _, err := dependencies.NewDetector(&depsCfg).Detect(dir)
+			if err != nil {
+				return nil, err
+			}
+			if lang == "" {
+				return &dependencies.AnalysisResult{Passed: true}, nil
+			}
+			analyzer, err := newDependenciesAnalyzer(lang)
+			if err != nil {
+				return nil, err
+			}
+			return analyzer.Analyze(ctx, dir, dependencies.AnalysisConfig{
+				PolicyPath:    policyPath,
+				EngineType:    depsCfg.Engine.Type,
+				Languages:     []dependencies.Language{lang},
+				Target:        dir,
+				CheckLicenses: true,
+				CheckCooling:  true,
+				Config:        &depsCfg,
+			})
+		}
+	}
+
+	diff, err := dependencies.DiffDependencyRefs(context.Background(), target, opts)
+	if err != nil {
+		return err
+	}
+	data, err := dependencies.RenderDependencyDiff(diff, format)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		_, _ = cmd.OutOrStdout().Write(data)
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0o750); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(output, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "✅ Dependency diff written: %s\n", output)
+	return nil
+}
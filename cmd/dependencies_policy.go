@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/dependencies/policy"
+	"github.com/spf13/cobra"
+)
+
+var dependenciesPolicyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Work with Rego dependency policy bundles",
+}
+
+var dependenciesPolicyTestCmd = &cobra.Command{
+	Use:   "test [dir]",
+	Short: "Run Rego policy unit tests (*_test.rego) offline",
+	Long: `Run the OPA test runner over a Rego policy bundle.
+
+Every rule named test_* in the directory (default: .goneat/policies) is
+evaluated, including rules in *_test.rego files. JSON and YAML files in the
+directory are loaded as data documents. No network access or analysis run is
+required, so policy authors can iterate on rules with fixture inputs:
+
+  package goneat.policies.licenses_test
+
+  import data.goneat.policies.licenses
+
+  test_denies_gpl if {
+    licenses.deny with input as {"dependencies": [{"module": {"name": "x"}, "license": {"type": "GPL-3.0"}}]}
+  }
+
+Examples:
+  goneat dependencies policy test                     # Test .goneat/policies
+  goneat dependencies policy test policies/ --verbose # Show passing tests too`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDependenciesPolicyTest,
+}
+
+var dependenciesPolicyTestVerbose bool
+
+func init() {
+	dependenciesCmd.AddCommand(dependenciesPolicyCmd)
+	dependenciesPolicyCmd.AddCommand(dependenciesPolicyTestCmd)
+	dependenciesPolicyTestCmd.Flags().BoolVarP(&dependenciesPolicyTestVerbose, "verbose", "v", false, "Show passing tests as well as failures")
+}
+
+func runDependenciesPolicyTest(cmd *cobra.Command, args []string) error {
+	dir := policy.DefaultBundleDir
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	results, err := policy.RunTests(context.Background(), dir)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	passed, failed, skipped := 0, 0, 0
+	for _, r := range results {
+		switch {
+		case r.Skip:
+			skipped++
+		case r.Pass():
+			passed++
+		default:
+			failed++
+		}
+		if !r.Pass() || dependenciesPolicyTestVerbose {
+			_, _ = fmt.Fprintf(out, "%s.%s: %s\n", strings.TrimPrefix(r.Package, "data."), r.Name, policyTestOutcome(r.Skip, r.Pass(), r.Error))
+			if len(r.Output) > 0 && (dependenciesPolicyTestVerbose || !r.Pass()) {
+				_, _ = fmt.Fprintf(out, "  %s\n", strings.ReplaceAll(strings.TrimSpace(string(r.Output)), "\n", "\n  "))
+			}
+		}
+	}
+
+	if len(results) == 0 {
+		_, _ = fmt.Fprintf(out, "No policy tests found in %s\n", dir)
+		return nil
+	}
+	_, _ = fmt.Fprintf(out, "PASS: %d/%d", passed, len(results))
+	if skipped > 0 {
+		_, _ = fmt.Fprintf(out, " (skipped: %d)", skipped)
+	}
+	_, _ = fmt.Fprintln(out)
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d policy test(s) failed", failed)
+	}
+	return nil
+}
+
+func policyTestOutcome(skip, pass bool, err error) string {
+	switch {
+	case skip:
+		return "SKIPPED"
+	case err != nil:
+		return "ERROR: " + err.Error()
+	case pass:
+		return "PASS"
+	default:
+		return "FAIL"
+	}
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fulmenhq/goneat/pkg/sbom"
+	"github.com/spf13/cobra"
+)
+
+const mitLicenseText = `MIT License
+
+Copyright (c) 2024 Example
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+`
+
+func TestDependencies_SBOMWithoutLicensesFlagIncludesLicenses(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	// Serve example.com/lib from a file-based module proxy so the analyzer
+	// finds it in a private module cache without network access.
+	dir := t.TempDir()
+	proxy := filepath.Join(dir, "proxy", "example.com", "lib", "@v")
+	if err := os.MkdirAll(proxy, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	for name, content := range map[string]string{
+		"go.mod":  "module example.com/lib\n\ngo 1.21\n",
+		"lib.go":  "package lib\n\nfunc Hello() {}\n",
+		"LICENSE": mitLicenseText,
+	} {
+		w, err := zw.Create("example.com/lib@v0.1.0/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	app := filepath.Join(dir, "app")
+	files := map[string]string{
+		filepath.Join(proxy, "list"):        "v0.1.0\n",
+		filepath.Join(proxy, "v0.1.0.info"): `{"Version":"v0.1.0","Time":"2024-01-01T00:00:00Z"}`,
+		filepath.Join(proxy, "v0.1.0.mod"):  "module example.com/lib\n\ngo 1.21\n",
+		filepath.Join(proxy, "v0.1.0.zip"):  zipBuf.String(),
+		filepath.Join(app, "go.mod"):        "module example.com/app\n\ngo 1.21\n\nrequire example.com/lib v0.1.0\n",
+		filepath.Join(app, "main.go"):       "package main\n\nimport \"example.com/lib\"\n\nfunc main() { lib.Hello() }\n",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("GOWORK", "off")
+	t.Setenv("GOFLAGS", "-mod=mod -modcacherw")
+	t.Setenv("GOPROXY", "file://"+filepath.ToSlash(filepath.Join(dir, "proxy")))
+	t.Setenv("GONOSUMDB", "example.com")
+	t.Setenv("GOMODCACHE", filepath.Join(dir, "modcache"))
+	t.Setenv("GONEAT_HOME", t.TempDir())
+	t.Setenv("GONEAT_OFFLINE", "1")
+
+	cmd := &cobra.Command{Use: "dependencies", RunE: runDependencies}
+	cmd.Flags().Bool("sbom", false, "")
+	cmd.Flags().String("sbom-format", sbom.FormatSPDXJSON, "")
+	cmd.Flags().String("sbom-engine", "native", "")
+	cmd.Flags().String("sbom-output", "", "")
+	cmd.Flags().Bool("sbom-stdout", false, "")
+	cmd.Flags().String("sbom-platform", "", "")
+	cmd.Flags().String("sbom-input", "", "")
+	cmd.Flags().Bool("licenses", false, "")
+	cmd.Flags().Bool("cooling", false, "")
+	output := filepath.Join(dir, "sbom.spdx.json")
+	if err := cmd.Flags().Set("sbom", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Flags().Set("sbom-output", output); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cmd.RunE(cmd, []string{app}); err != nil {
+		t.Fatalf("dependencies --sbom: %v", err)
+	}
+	doc, _, err := sbom.LoadDocument(output)
+	if err != nil {
+		t.Fatalf("LoadDocument: %v", err)
+	}
+	var found bool
+	for _, pkg := range doc.Packages {
+		if strings.Contains(pkg.Name, "example.com/lib") {
+			found = true
+			if pkg.LicenseConcluded != "MIT" {
+				t.Errorf("example.com/lib concluded license = %q, want MIT", pkg.LicenseConcluded)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("example.com/lib missing from SBOM: %+v", doc.Packages)
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fulmenhq/goneat/pkg/dependencies"
+	"github.com/spf13/cobra"
+)
+
+var dependenciesVexCmd = &cobra.Command{
+	Use:   "vex",
+	Short: "Exchange vulnerability triage as VEX documents",
+}
+
+var dependenciesVexExportCmd = &cobra.Command{
+	Use:   "export [target]",
+	Short: "Export vulnerability allow-list decisions as OpenVEX",
+	Long: `Convert the vulnerabilities.allow entries of the dependencies policy into an
+OpenVEX document so consumers of the SBOM see the same triage.
+
+Each unexpired entry becomes a statement about the product (default: the Go
+module in the target, as pkg:golang/<module>). Entry "products" PURLs are
+listed as subcomponents. Entries without a status are accepted risks and
+become affected; "fixed" and "under_investigation" are kept. not_affected
+(or "false_positive") requires an OpenVEX justification and uses the
+analysis or reason as impact statement. Unknown statuses fail the export.
+
+Examples:
+  goneat dependencies vex export --output vex/goneat.openvex.json
+  goneat dependencies vex export --product pkg:oci/app@sha256:abc --author security@example.com`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDependenciesVexExport,
+}
+
+func init() {
+	dependenciesCmd.AddCommand(dependenciesVexCmd)
+	dependenciesVexCmd.AddCommand(dependenciesVexExportCmd)
+	dependenciesVexExportCmd.Flags().String("policy", ".goneat/dependencies.yaml", "Policy file path")
+	dependenciesVexExportCmd.Flags().String("product", "", "Product PURL the statements apply to (default: pkg:golang/<module>)")
+	dependenciesVexExportCmd.Flags().String("author", "", "Document author (default: goneat)")
+	dependenciesVexExportCmd.Flags().String("output", "", "Output file (default: stdout)")
+}
+
+func runDependenciesVexExport(cmd *cobra.Command, args []string) error {
+	target := "."
+	if len(args) > 0 {
+		target = args[0]
+	}
+	policyPath, _ := cmd.Flags().GetString("policy")
+	product, _ := cmd.Flags().GetString("product")
+	author, _ := cmd.Flags().GetString("author")
+	output, _ := cmd.Flags().GetString("output")
+
+	if product == "" {
+		if module := parseGoModuleName(filepath.Join(target, "go.mod")); module != "" {
+			product = "pkg:golang/" + module
+		}
+	}
+
+	policy, err := dependencies.LoadVulnerabilityPolicy(policyPath)
+	if err != nil {
+		return err
+	}
+	data, err := dependencies.ExportOpenVEX(policy, dependencies.OpenVEXExportOptions{Product: product, Author: author})
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, _ = cmd.OutOrStdout().Write(data)
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0o750); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(output, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "✅ OpenVEX written: %s\n", output)
+	return nil
+}
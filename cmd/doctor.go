@@ -21,6 +21,7 @@ import (
 	"github.com/fulmenhq/goneat/internal/ops"
 	"github.com/fulmenhq/goneat/pkg/buildinfo"
 	"github.com/fulmenhq/goneat/pkg/logger"
+	"github.com/fulmenhq/goneat/pkg/registry"
 	"github.com/fulmenhq/goneat/pkg/tools"
 	"github.com/fulmenhq/goneat/pkg/tools/metadata"
 	"github.com/spf13/cobra"
@@ -48,6 +49,10 @@ Package Manager Installation:
 - Requires --yes for non-interactive installation
 - Package managers are installed before tools to ensure PATH is updated
 
+Lockfile:
+- goneat doctor tools lock: pin installed versions and digests to .goneat/tools.lock
+- --frozen: fail when installed tools do not match .goneat/tools.lock
+
 PATH Troubleshooting:
 If tools are installed but not found, check your PATH:
 - Go installs tools to $GOPATH/bin or $GOBIN (default: ~/go/bin)
@@ -100,6 +105,8 @@ var (
 	flagDoctorValidateConfig    bool
 	flagDoctorDryRun            bool
 	flagDoctorNoCooling         bool
+	flagDoctorFrozen            bool
+	flagDoctorOffline           bool
 )
 
 func init() {
@@ -132,6 +139,8 @@ func init() {
 	doctorToolsCmd.Flags().BoolVar(&flagDoctorValidateConfig, "validate-config", false, "Validate configuration file and exit")
 	doctorToolsCmd.Flags().BoolVar(&flagDoctorDryRun, "dry-run", false, "Show what would be installed without installing")
 	doctorToolsCmd.Flags().BoolVar(&flagDoctorNoCooling, "no-cooling", false, "Disable package cooling policy checks (for offline/air-gapped environments)")
+	doctorToolsCmd.Flags().BoolVar(&flagDoctorOffline, "offline", false, "Serve tool release metadata only from the on-disk cache; cache misses fail cooling checks explicitly")
+	doctorToolsCmd.Flags().BoolVar(&flagDoctorFrozen, "frozen", false, "Fail when installed tools do not match .goneat/tools.lock")
 
 	// Flags for versions subcommand
 	doctorVersionsCmd.Flags().BoolVar(&flagDoctorVersionsPurge, "purge", false, "Remove stale global installation from GOPATH/bin")
@@ -153,6 +162,9 @@ func runDoctorTools(cmd *cobra.Command, _ []string) error {
 	if flagDoctorInstall && flagDoctorUpgrade {
 		return fmt.Errorf("--install and --upgrade are mutually exclusive")
 	}
+	if flagDoctorFrozen && (flagDoctorInstall || flagDoctorUpgrade) {
+		return fmt.Errorf("--frozen cannot be combined with --install or --upgrade")
+	}
 
 	// Dry-run for install mode (non-upgrade); upgrade handles dry-run internally
 	if flagDoctorDryRun && !flagDoctorUpgrade {
@@ -196,6 +208,11 @@ func runDoctorTools(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
+	// Frozen mode: verify installed tools against .goneat/tools.lock and exit
+	if flagDoctorFrozen {
+		return checkFrozenTools(cmd, selected)
+	}
+
 	// Auto-install missing package managers if --install flag set (before tool checks) using actual selection.
 	if flagDoctorInstall && !flagDoctorDryRun {
 		if err := autoInstallPackageManagers(cmd, selected); err != nil {
@@ -205,8 +222,10 @@ func runDoctorTools(cmd *cobra.Command, _ []string) error {
 
 	// Initialize shared metadata registry for cooling policy checks
 	// This registry is reused across all tool checks to benefit from 24-hour cache
+	// and persists publish dates to the on-disk cache shared across runs
 	// Prevents redundant GitHub API calls and reduces rate-limit risk
-	metadataRegistry := metadata.NewRegistry(24 * time.Hour)
+	applyRegistryOfflineMode(flagDoctorOffline)
+	metadataRegistry := metadata.NewPersistentRegistry(24*time.Hour, registry.SharedDiskCache())
 	githubFetcher := metadata.NewGitHubFetcher(
 		os.Getenv("GITHUB_TOKEN"),
 		30*time.Second,
@@ -214,6 +233,8 @@ func runDoctorTools(cmd *cobra.Command, _ []string) error {
 	metadataRegistry.RegisterFetcher("github", githubFetcher)
 	pypiFetcher := metadata.NewPyPIFetcher(30 * time.Second)
 	metadataRegistry.RegisterFetcher("pypi", pypiFetcher)
+	goModuleFetcher := metadata.NewGoModuleFetcher(30 * time.Second)
+	metadataRegistry.RegisterFetcher("gomodule", goModuleFetcher)
 
 	// Foundation scope validation - proactive checks for common issues
 	if flagDoctorScope == "foundation" {
@@ -673,71 +694,7 @@ func selectToolsFromConfig(config *intdoctor.ToolsConfig) ([]intdoctor.Tool, err
 
 // convertToolConfigToTool converts ToolConfig to legacy Tool format
 func convertToolConfigToTool(toolConfig intdoctor.ToolConfig) (intdoctor.Tool, error) {
-	tool := intdoctor.Tool{
-		Name:           toolConfig.Name,
-		Kind:           toolConfig.Kind,
-		InstallPackage: toolConfig.InstallPackage,
-		VersionArgs:    toolConfig.VersionArgs,
-		CheckArgs:      toolConfig.CheckArgs,
-		Description:    toolConfig.Description,
-		Platforms:      toolConfig.Platforms,
-		DetectCommand:  toolConfig.DetectCommand,
-	}
-
-	if policy, err := toolConfig.VersionPolicy(); err != nil {
-		return intdoctor.Tool{}, err
-	} else {
-		tool.VersionPolicy = policy
-	}
-
-	if len(toolConfig.InstallCommands) > 0 {
-		tool.InstallCommands = make(map[string]string, len(toolConfig.InstallCommands))
-		tool.InstallMethods = make(map[string]intdoctor.InstallMethod)
-		for key, command := range toolConfig.InstallCommands {
-			tool.InstallCommands[key] = command
-			switch key {
-			case "darwin", "linux", "windows", "all":
-				cmdCopy := command
-				detectCmd := toolConfig.DetectCommand
-				tool.InstallMethods[key] = intdoctor.InstallMethod{
-					Detector: func() (string, bool) {
-						parts := strings.Fields(detectCmd)
-						if len(parts) == 0 {
-							return "", false
-						}
-						return intdoctor.TryCommand(parts[0], parts[1:]...)
-					},
-					Installer: func() error {
-						return intdoctor.ExecuteInstallCommand(cmdCopy)
-					},
-					Instructions: command,
-				}
-			}
-		}
-	}
-
-	if len(toolConfig.InstallerPriority) > 0 {
-		tool.InstallerPriority = make(map[string][]string, len(toolConfig.InstallerPriority))
-		for platform, priorities := range toolConfig.InstallerPriority {
-			tool.InstallerPriority[platform] = append([]string(nil), priorities...)
-		}
-	}
-
-	if toolConfig.Artifacts != nil {
-		tool.Artifacts = toolConfig.Artifacts
-	}
-
-	// Copy cooling policy configuration
-	if toolConfig.Cooling != nil {
-		tool.Cooling = toolConfig.Cooling
-	}
-
-	// Copy recommended version for metadata fetching
-	if toolConfig.RecommendedVersion != "" {
-		tool.RecommendedVersion = toolConfig.RecommendedVersion
-	}
-
-	return tool, nil
+	return intdoctor.ToolFromConfig(toolConfig)
 }
 
 // handleListScopes handles the --list-scopes flag
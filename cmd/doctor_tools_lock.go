@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fulmenhq/goneat/internal/doctor"
+	"github.com/fulmenhq/goneat/pkg/tools"
+	"github.com/spf13/cobra"
+)
+
+var toolsLockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Pin installed tool versions and digests to .goneat/tools.lock",
+	Long: `Record the exact installation of every configured tool in .goneat/tools.lock.
+
+For each tool in each scope of .goneat/tools.yaml, the lock records:
+- the resolved version
+- the install method (go-install, brew, mise, artifact, ...)
+- the binary path and its SHA256 digest
+- the artifact SHA256 for tools installed from an artifacts manifest
+
+Commit the lockfile so every developer and CI runner resolves the same tools.
+Use 'goneat doctor tools --frozen' to fail when installed binaries drift from
+the lock, and the 'tools' assessment category to report drift as issues.
+
+Binary digests are platform-specific; when the lock was generated on a
+different platform only versions and artifact digests are compared.
+
+Examples:
+  goneat doctor tools lock                  # Lock all scopes
+  goneat doctor tools lock --scope go       # Lock a single scope
+  goneat doctor tools lock --output my.lock # Write to a custom path`,
+	RunE: runToolsLock,
+}
+
+var (
+	toolsLockScopes []string
+	toolsLockOutput string
+)
+
+func init() {
+	doctorToolsCmd.AddCommand(toolsLockCmd)
+	toolsLockCmd.Flags().StringSliceVar(&toolsLockScopes, "scope", nil, "Scopes to lock (default: all defined scopes)")
+	toolsLockCmd.Flags().StringVar(&toolsLockOutput, "output", tools.LockFileName, "Path to write the lockfile")
+}
+
+func runToolsLock(cmd *cobra.Command, _ []string) error {
+	config, err := loadToolsConfiguration()
+	if err != nil {
+		return fmt.Errorf("failed to load tools configuration: %w", err)
+	}
+
+	scopes := toolsLockScopes
+	if len(scopes) == 0 {
+		for name := range config.Scopes {
+			scopes = append(scopes, name)
+		}
+		sort.Strings(scopes)
+	}
+	if len(scopes) == 0 {
+		return fmt.Errorf("no scopes defined in tools configuration")
+	}
+
+	lock := tools.NewLock()
+	resolved := make(map[string]tools.LockedTool)
+	var missing []string
+
+	for _, scope := range scopes {
+		toolConfigs, err := config.GetToolsForScope(scope)
+		if err != nil {
+			return fmt.Errorf("failed to get tools for scope '%s': %w", scope, err)
+		}
+		entries := []tools.LockedTool{}
+		for _, toolConfig := range toolConfigs {
+			tool, err := convertToolConfigToTool(toolConfig)
+			if err != nil {
+				return fmt.Errorf("failed to parse tool definition for %s: %w", toolConfig.Name, err)
+			}
+			if !doctor.SupportsCurrentPlatform(tool) {
+				continue
+			}
+			entry, ok := resolved[tool.Name]
+			if !ok {
+				entry, err = doctor.ResolveLockEntry(tool)
+				if err != nil {
+					missing = append(missing, tool.Name)
+					continue
+				}
+				resolved[tool.Name] = entry
+			}
+			entries = append(entries, entry)
+		}
+		lock.Scopes[scope] = entries
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("cannot lock tools that are not installed: %s (run 'goneat doctor tools --install' first)", strings.Join(missing, ", "))
+	}
+
+	outputPath := filepath.Clean(toolsLockOutput)
+	if err := tools.WriteLock(outputPath, lock); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "🔒 Locked %d tools across %d scopes for %s\n", len(resolved), len(lock.Scopes), lock.Platform)
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "   Wrote %s\n", outputPath)
+	return nil
+}
+
+// checkFrozenTools fails when installed tools drift from .goneat/tools.lock.
+func checkFrozenTools(cmd *cobra.Command, selected []doctor.Tool) error {
+	lock, lockPath, err := doctor.LoadToolsLock()
+	if err != nil {
+		return fmt.Errorf("--frozen requires %s: %w", tools.LockFileName, err)
+	}
+
+	drifts := doctor.CheckLockDrift(lock, flagDoctorScope, selected)
+	if len(drifts) == 0 {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "🔒 All %d tool(s) match %s\n", len(selected), lockPath)
+		return nil
+	}
+
+	for _, drift := range drifts {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "❌ %s\n", drift)
+	}
+	return fmt.Errorf("%d tool(s) drifted from %s", len(drifts), lockPath)
+}
@@ -57,6 +57,38 @@ func execRoot(t *testing.T, args []string) (string, error) {
 	validateSuiteFormat = "markdown"
 	validateSuiteFailOnUnmapped = true
 	validateSuiteSchemaResolution = "prefer-id"
+	validateSuiteLoader = "local"
+	validateSuiteSource = ""
+	validateSuiteRef = ""
+	validateSuggestManifestPath = ".goneat/schema-mappings.yaml"
+	validateSuggestWrite = false
+	validateSuggestFormat = "yaml"
+	validateSuggestMinConfidence = 0
+	validateSuggestMaxSuggestions = 0
+	validateSuggestExclusions = false
+	validateSuggestNoCache = false
+	validateSuggestCacheFile = ""
+	validateSuggestNoIgnore = false
+	validateSuggestExclude = nil
+
+	// Reset schema validate-schema flags
+	schemaValidateSchemaID = ""
+	schemaValidateSchemaRecursive = false
+	schemaValidateLoader = "local"
+	schemaValidateSource = ""
+	schemaValidateRef = ""
+
+	// Reset schema diff flags
+	schemaDiffRefDirs = nil
+	schemaDiffPolicy = "writers"
+	schemaDiffFormat = "text"
+	schemaDiffOldVersion = ""
+	schemaDiffNewVersion = ""
+	schemaDiffFailOnBreaking = false
+
+	// Reset schema test flags
+	schemaTestRefDirs = nil
+	schemaTestFormat = "text"
 
 	// Reset validate data flags to avoid cross-test bleed
 	validateDataSchema = ""
@@ -3,13 +3,16 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/fulmenhq/goneat/internal/ops"
 	"github.com/fulmenhq/goneat/pkg/logger"
 	"github.com/fulmenhq/goneat/pkg/pathfinder"
+	_ "github.com/fulmenhq/goneat/pkg/pathfinder/loaders" // registers local, tar, zip and git loaders
 	"github.com/spf13/cobra"
 )
 
@@ -37,7 +40,7 @@ func init() {
 		panic(fmt.Sprintf("Failed to register pathfinder command: %v", err))
 	}
 
-	pathfinderFindCmd.Flags().String("path", ".", "Root path or loader source to search")
+	pathfinderFindCmd.Flags().String("path", ".", "Root path, archive file or git repository to search")
 	pathfinderFindCmd.Flags().StringSlice("include", nil, "Glob patterns to include (doublestar supported)")
 	pathfinderFindCmd.Flags().StringSlice("exclude", nil, "Glob patterns to exclude")
 	pathfinderFindCmd.Flags().StringSlice("skip-dir", nil, "Directory substrings to skip during traversal")
@@ -51,11 +54,18 @@ func init() {
 	pathfinderFindCmd.Flags().String("strip-prefix", "", "Strip prefix from relative path when producing logical path")
 	pathfinderFindCmd.Flags().String("logical-prefix", "", "Prepend prefix to logical path output")
 	pathfinderFindCmd.Flags().Bool("flatten", false, "Use base filename as logical path (overrides strip-prefix)")
-	pathfinderFindCmd.Flags().String("loader", "local", "Loader type to use (local, s3, gcs, etc.)")
+	pathfinderFindCmd.Flags().String("loader", "local", "Loader type to use (local, tar, zip, git)")
+	pathfinderFindCmd.Flags().String("ref", "", "Git ref to read with --loader git (default HEAD)")
 	pathfinderFindCmd.Flags().Bool("schemas", false, "Enable schema signature discovery mode")
 	pathfinderFindCmd.Flags().StringSlice("schema-id", nil, "Filter schema matches by signature id or alias")
 	pathfinderFindCmd.Flags().StringSlice("schema-category", nil, "Filter schema matches by category (e.g., json-schema, openapi)")
 	pathfinderFindCmd.Flags().Bool("schema-metadata", false, "Include signature metadata (match details, docs links)")
+	pathfinderFindCmd.Flags().Bool("audit", false, "Persist an audit record for this run")
+	pathfinderFindCmd.Flags().String("audit-dir", pathfinder.DefaultAuditDir, "Directory for persisted audit trails")
+	pathfinderFindCmd.Flags().StringSlice("audit-sink", []string{"store"}, "Audit sinks: file, store, syslog")
+	pathfinderFindCmd.Flags().String("audit-syslog", "", "Syslog target for the syslog sink (udp://host:514, tcp://host:6514, unix:///dev/log)")
+	pathfinderFindCmd.Flags().Int("audit-retention-days", 90, "Days the segmented store keeps audit records")
+	pathfinderFindCmd.Flags().String("audit-compliance", "none", "Compliance mode: none, HIPAA, SOC2, PCI-DSS, GDPR")
 }
 
 func runPathfinderFind(cmd *cobra.Command, _ []string) error {
@@ -74,6 +84,7 @@ func runPathfinderFind(cmd *cobra.Command, _ []string) error {
 	logicalPrefix, _ := cmd.Flags().GetString("logical-prefix")
 	flatten, _ := cmd.Flags().GetBool("flatten")
 	loaderType, _ := cmd.Flags().GetString("loader")
+	ref, _ := cmd.Flags().GetString("ref")
 	schemaMode, _ := cmd.Flags().GetBool("schemas")
 	schemaIDs, _ := cmd.Flags().GetStringSlice("schema-id")
 	schemaCategories, _ := cmd.Flags().GetStringSlice("schema-category")
@@ -96,10 +107,26 @@ func runPathfinderFind(cmd *cobra.Command, _ []string) error {
 		rootPath = cleaned
 	}
 
-	facade := pathfinder.NewFinderFacade(pathfinder.NewPathFinder(), pathfinder.FinderConfig{
+	auditLogger, err := newFindAuditLogger(readFindAuditSettings(cmd))
+	if err != nil {
+		return fmt.Errorf("failed to set up audit: %w", err)
+	}
+	if auditLogger != nil {
+		defer func() {
+			if closeErr := auditLogger.Close(); closeErr != nil {
+				logger.Warn(fmt.Sprintf("Failed to close audit sinks: %v", closeErr))
+			}
+		}()
+	}
+
+	finderConfig := pathfinder.FinderConfig{
 		MaxWorkers: workers,
 		LoaderType: loaderType,
-	})
+	}
+	if auditLogger != nil {
+		finderConfig.AuditLogger = auditLogger
+	}
+	facade := pathfinder.NewFinderFacade(pathfinder.NewPathFinder(), finderConfig)
 
 	query := pathfinder.FindQuery{
 		Root:                  rootPath,
@@ -115,15 +142,31 @@ func runPathfinderFind(cmd *cobra.Command, _ []string) error {
 		SchemaCategories:      schemaCategories,
 		IncludeSchemaMetadata: schemaMetadata,
 		IncludeHidden:         includeHidden,
+		Ref:                   ref,
 	}
 
 	query.Transform = buildTransform(stripPrefix, logicalPrefix, flatten)
 
+	start := time.Now()
+	audit := func(found int, findErr error) error {
+		if auditLogger == nil {
+			return nil
+		}
+		return recordFindAudit(auditLogger, query, loaderType, found, findErr, time.Since(start))
+	}
+
 	if stream && output == "text" {
-		return streamTextResults(cmd, facade, query, showSource)
+		found, err := streamTextResults(cmd, facade, query, showSource)
+		if auditErr := audit(found, err); auditErr != nil && err == nil {
+			err = auditErr
+		}
+		return err
 	}
 
 	results, err := facade.Find(query)
+	if auditErr := audit(len(results), err); auditErr != nil && err == nil {
+		err = auditErr
+	}
 	if err != nil {
 		return err
 	}
@@ -143,15 +186,59 @@ func runPathfinderFind(cmd *cobra.Command, _ []string) error {
 	}
 }
 
-func streamTextResults(cmd *cobra.Command, facade *pathfinder.FinderFacade, query pathfinder.FindQuery, showSource bool) error {
+// newSourceLoader creates a pathfinder loader for a non-local source: an
+// archive file (tar, zip) or a git repository read at ref
+func newSourceLoader(loaderType, source, ref string) (pathfinder.SourceLoader, error) {
+	if strings.TrimSpace(source) == "" {
+		return nil, fmt.Errorf("--source is required with --loader %s", loaderType)
+	}
+	pf := pathfinder.NewPathFinder()
+	if err := pf.ValidatePath(source); err != nil {
+		return nil, fmt.Errorf("invalid --source %q: %w", source, err)
+	}
+	loader, err := pf.CreateLoader(loaderType, pathfinder.LoaderConfig{
+		Type:    loaderType,
+		Enabled: true,
+		Config:  map[string]interface{}{"path": source, "ref": ref},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := loader.Validate(); err != nil {
+		return nil, err
+	}
+	return loader, nil
+}
+
+// readLoaderFile reads a whole file through a source loader
+func readLoaderFile(loader pathfinder.SourceLoader, rel string) ([]byte, error) {
+	reader, err := loader.Open(rel)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	return io.ReadAll(reader)
+}
+
+// loaderSourcePath labels a file read through a loader for reports
+func loaderSourcePath(loader pathfinder.SourceLoader, rel string) string {
+	if locator, ok := loader.(pathfinder.SourceLocator); ok {
+		return locator.SourcePath(rel)
+	}
+	return rel
+}
+
+func streamTextResults(cmd *cobra.Command, facade *pathfinder.FinderFacade, query pathfinder.FindQuery, showSource bool) (int, error) {
 	resultCh, errCh := facade.FindStream(query)
+	found := 0
 	for res := range resultCh {
 		writeSingleTextResult(cmd, res, showSource)
+		found++
 	}
 	if err := <-errCh; err != nil {
-		return err
+		return found, err
 	}
-	return nil
+	return found, nil
 }
 
 func writeResultsJSON(cmd *cobra.Command, results []pathfinder.PathResult) error {
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/pathfinder"
+	"github.com/spf13/cobra"
+)
+
+var pathfinderAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Inspect persisted pathfinder audit trails",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return cmd.Help()
+	},
+}
+
+var pathfinderAuditQueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Query audit records stored under .goneat/audit",
+	Long: `Query audit records persisted by the file sink (audit.jsonl and its rotated
+files) and the segmented store (store/*.seg). Records written to both are
+reported once. Results are newest first. Records older than --retention-days
+are skipped even when their files have not been pruned yet.
+
+Time filters accept RFC3339 timestamps, dates (2006-01-02) or a lookback
+duration such as 90m, 24h or 7d.`,
+	RunE: runPathfinderAuditQuery,
+}
+
+func init() {
+	pathfinderCmd.AddCommand(pathfinderAuditCmd)
+	pathfinderAuditCmd.AddCommand(pathfinderAuditQueryCmd)
+
+	pathfinderAuditQueryCmd.Flags().String("dir", pathfinder.DefaultAuditDir, "Audit directory to read")
+	pathfinderAuditQueryCmd.Flags().String("operation", "", "Filter by operation (open, list, discover, ...)")
+	pathfinderAuditQueryCmd.Flags().String("path", "", "Filter by path substring")
+	pathfinderAuditQueryCmd.Flags().String("loader", "", "Filter by source loader (local, tar, zip, git)")
+	pathfinderAuditQueryCmd.Flags().String("result", "", "Filter by result status (success, failure, denied)")
+	pathfinderAuditQueryCmd.Flags().String("since", "", "Only records at or after this time")
+	pathfinderAuditQueryCmd.Flags().String("until", "", "Only records at or before this time")
+	pathfinderAuditQueryCmd.Flags().Int("limit", 100, "Maximum records to return (0 for all)")
+	pathfinderAuditQueryCmd.Flags().Int("offset", 0, "Records to skip before the first result")
+	pathfinderAuditQueryCmd.Flags().String("format", "text", "Output format: text|json|csv|syslog")
+	pathfinderAuditQueryCmd.Flags().Int("retention-days", 90, "Skip records older than this many days (-1 keeps all)")
+}
+
+func runPathfinderAuditQuery(cmd *cobra.Command, _ []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+	operation, _ := cmd.Flags().GetString("operation")
+	pathFilter, _ := cmd.Flags().GetString("path")
+	loaderFilter, _ := cmd.Flags().GetString("loader")
+	resultFilter, _ := cmd.Flags().GetString("result")
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	limit, _ := cmd.Flags().GetInt("limit")
+	offset, _ := cmd.Flags().GetInt("offset")
+	format, _ := cmd.Flags().GetString("format")
+	retentionDays, _ := cmd.Flags().GetInt("retention-days")
+
+	format = strings.ToLower(format)
+	switch format {
+	case "text", "json", "csv", "syslog":
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	now := time.Now()
+	query := pathfinder.AuditQuery{Limit: limit, Offset: offset}
+	if operation != "" {
+		op := pathfinder.PathOperation(operation)
+		query.Operation = &op
+	}
+	if pathFilter != "" {
+		query.Path = &pathFilter
+	}
+	if loaderFilter != "" {
+		query.SourceLoader = &loaderFilter
+	}
+	if resultFilter != "" {
+		query.Result = &resultFilter
+	}
+	if since != "" {
+		start, err := parseAuditTime(since, now)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		query.StartTime = &start
+	}
+	if until != "" {
+		end, err := parseAuditTime(until, now)
+		if err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+		query.EndTime = &end
+	}
+
+	records, err := pathfinder.QueryAuditDir(dir, query, pathfinder.AuditReadOptions{RetentionDays: retentionDays})
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	switch format {
+	case "json":
+		if records == nil {
+			records = []pathfinder.AuditRecord{}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	case "csv", "syslog":
+		data, err := pathfinder.ExportAuditRecords(records, pathfinder.ExportFormat(format))
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	default:
+		if len(records) == 0 {
+			_, _ = fmt.Fprintln(out, "No audit records found")
+			return nil
+		}
+		for _, record := range records {
+			line := fmt.Sprintf("%s  %-8s %-8s %3d  %-5s %s",
+				record.Timestamp.Format(time.RFC3339),
+				record.Operation,
+				record.Result.Status,
+				record.Result.Code,
+				record.SourceLoader,
+				record.Path,
+			)
+			if record.Result.Message != "" {
+				line += "  (" + record.Result.Message + ")"
+			}
+			_, _ = fmt.Fprintln(out, line)
+		}
+		return nil
+	}
+}
+
+// parseAuditTime accepts an RFC3339 timestamp, a date, or a lookback duration
+// (with a d suffix for days) relative to now
+func parseAuditTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if ts, err := time.Parse(time.RFC3339, value); err == nil {
+		return ts, nil
+	}
+	if ts, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return ts, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not a timestamp, date or duration", value)
+}
+
+// findAuditSettings holds the --audit flags of pathfinder find
+type findAuditSettings struct {
+	enabled       bool
+	dir           string
+	sinks         []string
+	syslogTarget  string
+	retentionDays int
+	compliance    string
+}
+
+func readFindAuditSettings(cmd *cobra.Command) findAuditSettings {
+	var s findAuditSettings
+	s.enabled, _ = cmd.Flags().GetBool("audit")
+	s.dir, _ = cmd.Flags().GetString("audit-dir")
+	s.sinks, _ = cmd.Flags().GetStringSlice("audit-sink")
+	s.syslogTarget, _ = cmd.Flags().GetString("audit-syslog")
+	s.retentionDays, _ = cmd.Flags().GetInt("audit-retention-days")
+	s.compliance, _ = cmd.Flags().GetString("audit-compliance")
+	return s
+}
+
+// newFindAuditLogger builds an audit logger wired to the requested durable
+// sinks, or returns nil when auditing is off
+func newFindAuditLogger(s findAuditSettings) (*pathfinder.AuditLoggerImpl, error) {
+	if !s.enabled {
+		return nil, nil
+	}
+	if s.dir == "" {
+		s.dir = pathfinder.DefaultAuditDir
+	}
+
+	var sinks []pathfinder.AuditSink
+	closeAll := func() {
+		for _, sink := range sinks {
+			_ = sink.Close()
+		}
+	}
+	for _, name := range s.sinks {
+		var (
+			sink pathfinder.AuditSink
+			err  error
+		)
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "file":
+			sink, err = pathfinder.NewAuditFileSink(pathfinder.AuditFileSinkOptions{Dir: s.dir})
+		case "store":
+			sink, err = pathfinder.NewAuditStore(pathfinder.AuditStoreOptions{
+				Dir:           pathfinder.AuditStoreDir(s.dir),
+				RetentionDays: s.retentionDays,
+			})
+		case "syslog":
+			if s.syslogTarget == "" {
+				err = errors.New("--audit-syslog is required for the syslog sink")
+				break
+			}
+			network, address, parseErr := pathfinder.ParseSyslogTarget(s.syslogTarget)
+			if parseErr != nil {
+				err = parseErr
+				break
+			}
+			sink, err = pathfinder.NewAuditSyslogSink(pathfinder.AuditSyslogOptions{Network: network, Address: address})
+		default:
+			err = fmt.Errorf("unknown audit sink %q (want file, store or syslog)", name)
+		}
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	logger := pathfinder.NewAuditLogger()
+	mode := pathfinder.ComplianceMode(s.compliance)
+	if strings.EqualFold(s.compliance, "none") {
+		mode = pathfinder.ComplianceNone
+	}
+	if err := logger.Configure(pathfinder.AuditConfig{
+		Enabled:        true,
+		ComplianceMode: mode,
+		RetentionDays:  s.retentionDays,
+		Sinks:          sinks,
+	}); err != nil {
+		closeAll()
+		return nil, err
+	}
+	return logger, nil
+}
+
+// recordFindAudit logs the outcome of a find run
+func recordFindAudit(logger *pathfinder.AuditLoggerImpl, query pathfinder.FindQuery, loaderType string, found int, findErr error, duration time.Duration) error {
+	record := pathfinder.AuditRecord{
+		Operation:    pathfinder.OpDiscover,
+		Path:         query.Root,
+		SourceLoader: loaderType,
+		Duration:     duration,
+		UserContext:  map[string]string{"command": "pathfinder find"},
+		Result: pathfinder.OperationResult{
+			Status:  "success",
+			Code:    200,
+			Message: fmt.Sprintf("Found %d files", found),
+		},
+	}
+	if query.Ref != "" {
+		record.UserContext["ref"] = query.Ref
+	}
+	if len(query.Include) > 0 {
+		record.UserContext["include"] = strings.Join(query.Include, ",")
+	}
+	if findErr != nil {
+		record.Result = pathfinder.OperationResult{Status: "failure", Code: 500, Message: findErr.Error()}
+		record.ErrorDetails = &pathfinder.ErrorDetail{Type: "FindError", Message: findErr.Error()}
+	}
+	return logger.LogOperation(record)
+}
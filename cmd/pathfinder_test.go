@@ -6,6 +6,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 type pathfinderResult struct {
@@ -218,3 +221,138 @@ func TestPathfinderFindSchemas(t *testing.T) {
 		t.Fatalf("unexpected schema id for proto include: %v", schemaMetaProto["id"])
 	}
 }
+
+func TestPathfinderFindTarLoader(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "release.tar.gz")
+	writeTarGz(t, archive, map[string]string{
+		"schemas/a.json":        `{"type":"object"}`,
+		"schemas/deep/b.json":   `{"type":"string"}`,
+		"schemas/.cache/c.json": `{}`,
+		"README.md":             "# release\n",
+	})
+
+	// Command flags persist across execRoot calls
+	t.Cleanup(func() { _ = pathfinderFindCmd.Flags().Set("loader", "local") })
+
+	out, err := execRoot(t, []string{"pathfinder", "find", "--loader", "tar", "--path", archive, "--include", "**/*.json", "--max-depth", "1", "--schemas=false", "--flatten=false", "--output", "text", "--show-source"})
+	if err != nil {
+		t.Fatalf("pathfinder find --loader tar failed: %v\n%s", err, out)
+	}
+	want := "schemas/a.json -> " + archive + "!/schemas/a.json\n"
+	if out != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestPathfinderAuditQuery(t *testing.T) {
+	root := t.TempDir()
+	mustWrite(t, filepath.Join(root, "data", "a.json"))
+	mustWrite(t, filepath.Join(root, "data", "b.json"))
+	auditDir := filepath.Join(t.TempDir(), "audit")
+	resetCommandFlags(pathfinderFindCmd)
+	resetCommandFlags(pathfinderAuditQueryCmd)
+	t.Cleanup(func() {
+		resetCommandFlags(pathfinderFindCmd)
+		resetCommandFlags(pathfinderAuditQueryCmd)
+	})
+
+	out, err := execRoot(t, []string{"pathfinder", "find", "--path", root, "--include", "**/*.json", "--schemas=false", "--flatten=false", "--output", "text",
+		"--audit", "--audit-dir", auditDir, "--audit-sink", "file,store"})
+	if err != nil {
+		t.Fatalf("pathfinder find --audit failed: %v\n%s", err, out)
+	}
+
+	out, err = execRoot(t, []string{"pathfinder", "audit", "query", "--dir", auditDir, "--operation", "discover", "--since", "1h", "--format", "json"})
+	if err != nil {
+		t.Fatalf("pathfinder audit query failed: %v\n%s", err, out)
+	}
+	var records []struct {
+		Operation    string            `json:"operation"`
+		Path         string            `json:"path"`
+		SourceLoader string            `json:"source_loader"`
+		UserContext  map[string]string `json:"user_context"`
+		Result       struct {
+			Status  string `json:"status"`
+			Message string `json:"message"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(out), &records); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	// Written to both the file sink and the store, reported once
+	if len(records) != 1 {
+		t.Fatalf("expected 1 audit record, got %d (%s)", len(records), out)
+	}
+	if records[0].Path != root || records[0].SourceLoader != "local" || records[0].Result.Message != "Found 2 files" {
+		t.Errorf("unexpected audit record: %+v", records[0])
+	}
+	if records[0].UserContext["command"] != "pathfinder find" {
+		t.Errorf("user context = %v", records[0].UserContext)
+	}
+
+	resetCommandFlags(pathfinderAuditQueryCmd)
+	out, err = execRoot(t, []string{"pathfinder", "audit", "query", "--dir", auditDir, "--result", "denied", "--format", "text"})
+	if err != nil {
+		t.Fatalf("pathfinder audit query (text) failed: %v\n%s", err, out)
+	}
+	if strings.TrimSpace(out) != "No audit records found" {
+		t.Errorf("expected no denied records, got %q", out)
+	}
+
+	if _, err := execRoot(t, []string{"pathfinder", "audit", "query", "--dir", auditDir, "--since", "yesterday-ish"}); err == nil {
+		t.Error("expected invalid --since to fail")
+	}
+}
+
+func TestPathfinderAuditRecordsLoaderOperations(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "release.tar.gz")
+	writeTarGz(t, archive, map[string]string{
+		"schemas/a.json": `{"type":"object"}`,
+		"README.md":      "# release\n",
+	})
+	auditDir := filepath.Join(t.TempDir(), "audit")
+	resetCommandFlags(pathfinderFindCmd)
+	resetCommandFlags(pathfinderAuditQueryCmd)
+	t.Cleanup(func() {
+		resetCommandFlags(pathfinderFindCmd)
+		resetCommandFlags(pathfinderAuditQueryCmd)
+	})
+
+	out, err := execRoot(t, []string{"pathfinder", "find", "--loader", "tar", "--path", archive, "--include", "**/*.json", "--schemas=false", "--output", "text",
+		"--audit", "--audit-dir", auditDir, "--audit-sink", "store"})
+	if err != nil {
+		t.Fatalf("pathfinder find --loader tar --audit failed: %v\n%s", err, out)
+	}
+
+	// The loader's own records are persisted alongside the run summary
+	out, err = execRoot(t, []string{"pathfinder", "audit", "query", "--dir", auditDir, "--operation", "list", "--format", "json"})
+	if err != nil {
+		t.Fatalf("pathfinder audit query failed: %v\n%s", err, out)
+	}
+	var records []struct {
+		SourceLoader string `json:"source_loader"`
+	}
+	if err := json.Unmarshal([]byte(out), &records); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if len(records) != 1 || records[0].SourceLoader != "tar.gz" {
+		t.Fatalf("expected the tar loader list record, got %s", out)
+	}
+}
+
+// resetCommandFlags restores a command's flags to their defaults; cobra keeps
+// flag values between executions of the shared root command
+func resetCommandFlags(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			var defaults []string
+			if def := strings.Trim(f.DefValue, "[]"); def != "" {
+				defaults = strings.Split(def, ",")
+			}
+			_ = slice.Replace(defaults)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
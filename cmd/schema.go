@@ -11,8 +11,11 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/fulmenhq/goneat/pkg/pathfinder"
 	"github.com/fulmenhq/goneat/pkg/safeio"
 	"github.com/fulmenhq/goneat/pkg/schema"
+	"github.com/fulmenhq/goneat/pkg/schema/apispec"
 	"github.com/fulmenhq/goneat/pkg/schema/signature"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
@@ -23,6 +26,9 @@ var (
 	schemaValidateFormat          string
 	schemaValidateWorkers         int
 	schemaValidateSchemaRecursive bool
+	schemaValidateLoader          string
+	schemaValidateSource          string
+	schemaValidateRef             string
 )
 
 var schemaCmd = &cobra.Command{
@@ -36,7 +42,7 @@ var schemaCmd = &cobra.Command{
 var schemaValidateSchemaCmd = &cobra.Command{
 	Use:   "validate-schema [files...]",
 	Short: "Validate schema files against embedded meta-schemas",
-	Long:  "Validate schema files (e.g., JSON Schema drafts) against embedded meta-schemas to ensure structural correctness.",
+	Long:  "Validate schema files (e.g., JSON Schema drafts) against embedded meta-schemas to ensure structural correctness. OpenAPI 3.0/3.1 and AsyncAPI 2.x/3.x documents are also checked for $ref resolution, example values, operationId uniqueness and path/channel parameter consistency.",
 	Args:  cobra.MinimumNArgs(1),
 	RunE:  runSchemaValidateSchema,
 }
@@ -49,6 +55,9 @@ func init() {
 	schemaValidateSchemaCmd.Flags().StringVar(&schemaValidateFormat, "format", "text", "Output format: text|json")
 	schemaValidateSchemaCmd.Flags().IntVar(&schemaValidateWorkers, "workers", 0, "Number of parallel workers (0=auto)")
 	schemaValidateSchemaCmd.Flags().BoolVar(&schemaValidateSchemaRecursive, "recursive", false, "If a directory is provided, recursively validate schema files within")
+	schemaValidateSchemaCmd.Flags().StringVar(&schemaValidateLoader, "loader", "local", "Read schema files through a loader: local, tar, zip, git")
+	schemaValidateSchemaCmd.Flags().StringVar(&schemaValidateSource, "source", "", "Archive file or git repository to read from (with --loader tar|zip|git)")
+	schemaValidateSchemaCmd.Flags().StringVar(&schemaValidateRef, "ref", "", "Git ref to read with --loader git (default HEAD)")
 }
 
 type schemaValidateResult struct {
@@ -97,6 +106,64 @@ func expandSchemaValidateInputs(args []string, recursive bool) ([]string, error)
 	return expanded, nil
 }
 
+// expandLoaderSchemaInputs expands args against the files of a non-local source.
+// Globs use doublestar syntax; directories ("." for the root) need --recursive.
+func expandLoaderSchemaInputs(loader pathfinder.SourceLoader, args []string, recursive bool) ([]string, error) {
+	all, err := loader.ListFiles("", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	present := make(map[string]bool, len(all))
+	for _, f := range all {
+		present[f] = true
+	}
+
+	var expanded []string
+	for _, arg := range args {
+		arg = strings.TrimPrefix(filepath.ToSlash(arg), "./")
+		if containsGlob(arg) {
+			var matches []string
+			for _, f := range all {
+				if ok, _ := doublestar.Match(arg, f); ok {
+					matches = append(matches, f)
+				}
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("glob pattern matched no files in %s: %s", loader.SourceDescription(), arg)
+			}
+			expanded = append(expanded, matches...)
+			continue
+		}
+		if present[arg] {
+			expanded = append(expanded, arg)
+			continue
+		}
+
+		prefix := strings.TrimSuffix(arg, "/") + "/"
+		if arg == "" || arg == "." {
+			prefix = ""
+		}
+		var files []string
+		for _, f := range all {
+			if strings.HasPrefix(f, prefix) && isSchemaFileExt(f) {
+				files = append(files, f)
+			}
+		}
+		if len(files) == 0 {
+			// Not a directory either; reading reports the missing file
+			expanded = append(expanded, arg)
+			continue
+		}
+		if !recursive {
+			return nil, fmt.Errorf("%s is a directory (use --recursive)", arg)
+		}
+		expanded = append(expanded, files...)
+	}
+
+	sort.Strings(expanded)
+	return expanded, nil
+}
+
 func containsGlob(value string) bool {
 	return strings.ContainsAny(value, "*?[")
 }
@@ -110,9 +177,7 @@ func collectSchemaFiles(root string) ([]string, error) {
 		if d.IsDir() {
 			return nil
 		}
-		ext := strings.ToLower(filepath.Ext(path))
-		switch ext {
-		case ".json", ".yaml", ".yml":
+		if isSchemaFileExt(path) {
 			files = append(files, path)
 		}
 		return nil
@@ -123,6 +188,14 @@ func collectSchemaFiles(root string) ([]string, error) {
 	return files, nil
 }
 
+func isSchemaFileExt(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
 func runSchemaValidateSchema(cmd *cobra.Command, args []string) error {
 	format := strings.ToLower(schemaValidateFormat)
 	if format != "text" && format != "json" {
@@ -152,7 +225,16 @@ func runSchemaValidateSchema(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	inputs, err := expandSchemaValidateInputs(args, schemaValidateSchemaRecursive)
+	var loader pathfinder.SourceLoader
+	var inputs []string
+	if schemaValidateLoader != "" && schemaValidateLoader != "local" {
+		if loader, err = newSourceLoader(schemaValidateLoader, schemaValidateSource, schemaValidateRef); err != nil {
+			return err
+		}
+		inputs, err = expandLoaderSchemaInputs(loader, args, schemaValidateSchemaRecursive)
+	} else {
+		inputs, err = expandSchemaValidateInputs(args, schemaValidateSchemaRecursive)
+	}
 	if err != nil {
 		return err
 	}
@@ -188,16 +270,23 @@ func runSchemaValidateSchema(cmd *cobra.Command, args []string) error {
 			default:
 			}
 
-			cleanPath, err := safeio.CleanUserPath(input)
-			if err != nil {
-				mu.Lock()
-				failures++
-				results[idx] = schemaValidateResult{File: input, Valid: false, Errors: []string{fmt.Sprintf("invalid path: %v", err)}}
-				mu.Unlock()
-				return nil
+			var cleanPath string
+			var schemaBytes []byte
+			var err error
+			if loader != nil {
+				cleanPath = loaderSourcePath(loader, input)
+				schemaBytes, err = readLoaderFile(loader, input)
+			} else {
+				cleanPath, err = safeio.CleanUserPath(input)
+				if err != nil {
+					mu.Lock()
+					failures++
+					results[idx] = schemaValidateResult{File: input, Valid: false, Errors: []string{fmt.Sprintf("invalid path: %v", err)}}
+					mu.Unlock()
+					return nil
+				}
+				schemaBytes, err = os.ReadFile(cleanPath) // #nosec G304 -- cleanPath sanitized with safeio.CleanUserPath
 			}
-
-			schemaBytes, err := os.ReadFile(cleanPath) // #nosec G304 -- cleanPath sanitized with safeio.CleanUserPath
 			if err != nil {
 				mu.Lock()
 				failures++
@@ -221,7 +310,20 @@ func runSchemaValidateSchema(cmd *cobra.Command, args []string) error {
 				schemaID = match.Signature.ID
 			}
 
-			valid, validationErrs, verr := validateSchemaByIDCached(schemaID, schemaBytes, cache)
+			var valid bool
+			var validationErrs []string
+			var verr error
+			if isAPISpecSchemaID(schemaID) {
+				apiOpts := apispec.Options{}
+				apiPath := cleanPath
+				if loader != nil {
+					apiPath = input
+					apiOpts.ReadFile = func(p string) ([]byte, error) { return readLoaderFile(loader, filepath.ToSlash(p)) }
+				}
+				valid, validationErrs, verr = validateAPISpec(apiPath, schemaBytes, apiOpts)
+			} else {
+				valid, validationErrs, verr = validateSchemaByIDCached(schemaID, schemaBytes, cache)
+			}
 			if verr != nil {
 				mu.Lock()
 				failures++
@@ -340,6 +442,37 @@ func validateSchemaByIDCached(schemaID string, schemaBytes []byte, cache *metaSc
 	}
 }
 
+// isAPISpecSchemaID reports whether schemaID names an OpenAPI or AsyncAPI signature
+func isAPISpecSchemaID(schemaID string) bool {
+	switch schemaID {
+	case "openapi-3", "asyncapi-2", "asyncapi-3":
+		return true
+	}
+	return false
+}
+
+// validateAPISpec runs the OpenAPI/AsyncAPI checks and formats issues as
+// "line N: [rule] pointer: message", prefixed with the file for issues found
+// in documents pulled in through $ref
+func validateAPISpec(path string, data []byte, opts apispec.Options) (bool, []string, error) {
+	result, err := apispec.Validate(path, data, opts)
+	if err != nil {
+		return false, nil, err
+	}
+	if result.Valid() {
+		return true, nil, nil
+	}
+	errs := make([]string, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		msg := fmt.Sprintf("line %d: [%s] %s: %s", issue.Line, issue.Rule, issue.Pointer, issue.Message)
+		if issue.File != filepath.Clean(path) {
+			msg = issue.File + " " + msg
+		}
+		errs = append(errs, msg)
+	}
+	return false, errs, nil
+}
+
 func validateJSONSchemaDraftCached(draft string, schemaBytes []byte, cache *metaSchemaDraftCache) (bool, []string, error) {
 	validator, err := cache.Get(draft)
 	if err != nil {
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -62,6 +64,31 @@ func TestSchemaValidateSchema_BadDraft07(t *testing.T) {
 	}
 }
 
+func TestSchemaValidateSchema_APISpecs(t *testing.T) {
+	out, err := execRoot(t, []string{
+		"schema", "validate-schema",
+		"tests/fixtures/schemas/openapi-3/good/split/api.yaml",
+		"tests/fixtures/schemas/asyncapi-3/good/user-events.yaml",
+	})
+	if err != nil {
+		t.Fatalf("expected API documents to validate: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "(openapi-3)") || !strings.Contains(out, "(asyncapi-3)") {
+		t.Fatalf("expected detected signatures in output, got: %s", out)
+	}
+
+	out, err = execRoot(t, []string{
+		"schema", "validate-schema",
+		"tests/fixtures/schemas/asyncapi-2/bad/channel-issues.yaml",
+	})
+	if err == nil {
+		t.Fatalf("expected validate-schema to fail for AsyncAPI issues\n%s", out)
+	}
+	if !strings.Contains(out, "line 17: [operation-id]") {
+		t.Fatalf("expected line-accurate operationId issue, got: %s", out)
+	}
+}
+
 func TestSchemaValidateSchema_GoodDraft2019_09(t *testing.T) {
 	out, err := execRoot(t, []string{
 		"schema", "validate-schema",
@@ -173,3 +200,102 @@ func TestSchemaValidateSchema_DirectoryRecursive(t *testing.T) {
 		t.Fatalf("expected multiple validated schemas, got: %s", out)
 	}
 }
+
+func TestSchemaValidateSchema_ArchiveLoader(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "schemas.tar.gz")
+	writeTarGz(t, archive, map[string]string{
+		"v1/good.json":      `{"$schema":"http://json-schema.org/draft-07/schema#","type":"object"}`,
+		"v1/nested/ok.json": `{"$schema":"http://json-schema.org/draft-07/schema#","type":"string"}`,
+		"NOTES.txt":         "not a schema",
+	})
+
+	out, err := execRoot(t, []string{
+		"schema", "validate-schema",
+		"--loader", "tar",
+		"--source", archive,
+		"--recursive",
+		"v1",
+	})
+	if err != nil {
+		t.Fatalf("schema validate-schema --loader tar failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "schemas.tar.gz!/v1/good.json") || !strings.Contains(out, "schemas.tar.gz!/v1/nested/ok.json") {
+		t.Fatalf("expected archive paths in output, got: %s", out)
+	}
+
+	out, err = execRoot(t, []string{"schema", "validate-schema", "--loader", "tar", "--source", archive, "v1"})
+	if err == nil || !strings.Contains(err.Error(), "use --recursive") {
+		t.Fatalf("expected directory error without --recursive, got %v\n%s", err, out)
+	}
+}
+
+func TestSchemaDiff_VersionDirectories(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("v1.0.0/widget.json", `{"type":"object","properties":{"size":{"enum":["s","m"]}}}`)
+	write("v1.1.0/widget.json", `{"type":"object","properties":{"size":{"enum":["s","m","l"]}}}`)
+	write("v1.2.0/widget.json", `{"type":"object","properties":{"size":{"enum":["s","m","l"]}},"required":["size"]}`)
+
+	out, err := execRoot(t, []string{"schema", "diff", filepath.Join(root, "v1.0.0"), filepath.Join(root, "v1.1.0")})
+	if err != nil {
+		t.Fatalf("schema diff (additive minor) failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "enum-widened") || !strings.Contains(out, "✅") {
+		t.Fatalf("expected widened enum and success marker, got: %s", out)
+	}
+
+	out, err = execRoot(t, []string{"schema", "diff", filepath.Join(root, "v1.0.0"), filepath.Join(root, "v1.1.0"), "--policy", "readers"})
+	if err == nil || !strings.Contains(out, "❌") {
+		t.Fatalf("expected readers policy to reject the minor bump, got %v\n%s", err, out)
+	}
+
+	out, err = execRoot(t, []string{"schema", "diff", "--format", "json", filepath.Join(root, "v1.1.0"), filepath.Join(root, "v1.2.0")})
+	if err == nil {
+		t.Fatalf("expected breaking change under a minor bump to fail\n%s", out)
+	}
+	var report struct {
+		Required  string `json:"required_bump"`
+		Actual    string `json:"actual_bump"`
+		Compliant bool   `json:"compliant"`
+	}
+	if jerr := json.NewDecoder(strings.NewReader(out)).Decode(&report); jerr != nil {
+		t.Fatalf("decode JSON output: %v\n%s", jerr, out)
+	}
+	if report.Required != "major" || report.Actual != "minor" || report.Compliant {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestSchemaTest_Fixtures(t *testing.T) {
+	out, err := execRoot(t, []string{"schema", "test", "tests/fixtures/schematest/passing"})
+	if err != nil {
+		t.Fatalf("schema test (passing) failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "5 passed, 0 failed") || !strings.Contains(out, "not exercised: property /properties/nickname") {
+		t.Fatalf("unexpected output: %s", out)
+	}
+
+	out, err = execRoot(t, []string{"schema", "test", "--format", "json", "tests/fixtures/schematest/failing/tag.schema.yaml"})
+	if err == nil {
+		t.Fatalf("expected failing cases to fail the command\n%s", out)
+	}
+	var report struct {
+		Passed int `json:"passed"`
+		Failed int `json:"failed"`
+	}
+	if jerr := json.NewDecoder(strings.NewReader(out)).Decode(&report); jerr != nil {
+		t.Fatalf("decode JSON output: %v\n%s", jerr, out)
+	}
+	if report.Failed != 4 || report.Passed != 0 {
+		t.Fatalf("expected 4 failed cases, got %+v", report)
+	}
+}
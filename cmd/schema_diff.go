@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/schema/compat"
+	"github.com/spf13/cobra"
+)
+
+var (
+	schemaDiffRefDirs        []string
+	schemaDiffPolicy         string
+	schemaDiffFormat         string
+	schemaDiffOldVersion     string
+	schemaDiffNewVersion     string
+	schemaDiffFailOnBreaking bool
+)
+
+var schemaDiffCmd = &cobra.Command{
+	Use:   "diff <old> <new>",
+	Short: "Classify changes between two schema versions",
+	Long: `Compare two schema files or two version directories (schemas/<area>/vX.Y.Z/)
+and classify each change as breaking or non-breaking for writers (producers of
+documents) and readers (consumers of documents).
+
+When both versions are known, from vX.Y.Z directory names or --old-version and
+--new-version, the command fails if the version bump is smaller than the
+changes require: breaking changes need a major bump, additive changes a minor
+bump, and annotation-only changes a patch bump.
+
+$ref targets are resolved within each document and, for absolute URLs, through
+the schemas found in --ref-dir (and in each compared directory).`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSchemaDiff,
+}
+
+func init() {
+	schemaCmd.AddCommand(schemaDiffCmd)
+
+	schemaDiffCmd.Flags().StringSliceVar(&schemaDiffRefDirs, "ref-dir", []string{}, "Directory tree of schema files used to resolve absolute $ref URLs offline (repeatable)")
+	schemaDiffCmd.Flags().StringVar(&schemaDiffPolicy, "policy", string(compat.PolicyWriters), "Which changes are breaking: writers (backward), readers (forward) or both")
+	schemaDiffCmd.Flags().StringVar(&schemaDiffFormat, "format", "text", "Output format: text|json")
+	schemaDiffCmd.Flags().StringVar(&schemaDiffOldVersion, "old-version", "", "Version of <old> (default: vX.Y.Z directory name)")
+	schemaDiffCmd.Flags().StringVar(&schemaDiffNewVersion, "new-version", "", "Version of <new> (default: vX.Y.Z directory name)")
+	schemaDiffCmd.Flags().BoolVar(&schemaDiffFailOnBreaking, "fail-on-breaking", false, "Fail on breaking changes even when the versions are unknown")
+}
+
+func runSchemaDiff(cmd *cobra.Command, args []string) error {
+	format := strings.ToLower(schemaDiffFormat)
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported format: %s", schemaDiffFormat)
+	}
+	policy, err := compat.ParsePolicy(schemaDiffPolicy)
+	if err != nil {
+		return err
+	}
+	if (schemaDiffOldVersion == "") != (schemaDiffNewVersion == "") {
+		return fmt.Errorf("--old-version and --new-version must be used together")
+	}
+
+	report, err := compat.Compare(args[0], args[1], compat.CompareOptions{
+		RefDirs:    schemaDiffRefDirs,
+		Policy:     policy,
+		OldVersion: schemaDiffOldVersion,
+		NewVersion: schemaDiffNewVersion,
+	})
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("encode JSON output: %w", err)
+		}
+	} else {
+		writeSchemaDiffText(cmd, report)
+	}
+
+	if !report.Compliant {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("version bump %s (%s -> %s) is smaller than the %s bump the changes require",
+			report.Actual, report.OldVersion, report.NewVersion, report.Required)
+	}
+	if schemaDiffFailOnBreaking && report.Required == compat.BumpMajor {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("breaking changes found under the %s policy", report.Policy)
+	}
+	return nil
+}
+
+func writeSchemaDiffText(cmd *cobra.Command, report *compat.Report) {
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(out, "Schema diff: %s -> %s (policy: %s)\n", report.Old, report.New, report.Policy)
+	for _, file := range report.Files {
+		if file.Status == compat.FileUnchanged {
+			continue
+		}
+		_, _ = fmt.Fprintf(out, "\n%s (%s)\n", file.Path, file.Status)
+		for _, change := range file.Changes {
+			marker := "•"
+			if change.Breaking(report.Policy) {
+				marker = "✖"
+			}
+			_, _ = fmt.Fprintf(out, "  %s %s %s: %s%s\n", marker, change.Path, change.Kind, change.Message, schemaDiffImpact(change))
+		}
+	}
+	if len(report.Changes()) == 0 {
+		_, _ = fmt.Fprintln(out, "No changes")
+	}
+
+	_, _ = fmt.Fprintln(out)
+	if !report.Versioned() {
+		_, _ = fmt.Fprintf(out, "Required bump: %s\n", report.Required)
+		return
+	}
+	_, _ = fmt.Fprintf(out, "Required bump: %s; actual: %s (%s -> %s)\n", report.Required, report.Actual, report.OldVersion, report.NewVersion)
+	if report.Compliant {
+		_, _ = fmt.Fprintln(out, "✅ Version bump matches the changes")
+	} else {
+		_, _ = fmt.Fprintln(out, "❌ Version bump is too small for the changes")
+	}
+}
+
+func schemaDiffImpact(change compat.Change) string {
+	switch {
+	case change.BreaksReaders && change.BreaksWriters:
+		return " [breaks readers and writers]"
+	case change.BreaksReaders:
+		return " [breaks readers]"
+	case change.BreaksWriters:
+		return " [breaks writers]"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/schema/schematest"
+	"github.com/spf13/cobra"
+)
+
+var (
+	schemaTestRefDirs []string
+	schemaTestFormat  string
+)
+
+var schemaTestCmd = &cobra.Command{
+	Use:   "test [paths...]",
+	Short: "Run example-driven test cases against schemas",
+	Long: `Run the valid and invalid example cases kept next to each schema.
+
+Cases are discovered from:
+  examples/<stem>/valid/** and examples/<stem>/invalid/**  (<stem> is the schema
+                           file name without its extension and ".schema" suffix)
+  examples/valid/** and examples/invalid/**  (when the schema is alone in its directory)
+  the schema's root "examples" array         (valid cases)
+  the schema's "x-invalid-examples" array    (entries of {value, path, keyword, description})
+
+Valid cases must pass. Invalid cases must fail with an error at the expected
+instance path and/or for the expected keyword. For case files, declare them in
+a sidecar named <case>.expect.yaml (or .yml/.json):
+
+  path: /contact
+  keyword: oneOf
+
+Directories are searched for schemas (files declaring $schema) that have
+cases; schema files given directly are always run. The report lists which
+properties and oneOf/anyOf/if branches at least one case exercised.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runSchemaTest,
+}
+
+func init() {
+	schemaCmd.AddCommand(schemaTestCmd)
+
+	schemaTestCmd.Flags().StringSliceVar(&schemaTestRefDirs, "ref-dir", []string{}, "Directory tree of schema files used to resolve absolute $ref URLs offline (repeatable)")
+	schemaTestCmd.Flags().StringVar(&schemaTestFormat, "format", "text", "Output format: text|json")
+}
+
+func runSchemaTest(cmd *cobra.Command, args []string) error {
+	format := strings.ToLower(schemaTestFormat)
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported format: %s", schemaTestFormat)
+	}
+	paths := args
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	report, err := schematest.Run(paths, schematest.Options{RefDirs: schemaTestRefDirs})
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("encode JSON output: %w", err)
+		}
+	} else {
+		writeSchemaTestText(cmd, report)
+	}
+
+	if !report.OK() {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d schema test case(s) failed", report.Failed)
+	}
+	return nil
+}
+
+func writeSchemaTestText(cmd *cobra.Command, report *schematest.Report) {
+	out := cmd.OutOrStdout()
+	if len(report.Schemas) == 0 {
+		_, _ = fmt.Fprintln(out, "No schemas with test cases found")
+		return
+	}
+	for _, sr := range report.Schemas {
+		_, _ = fmt.Fprintf(out, "%s\n", sr.Schema)
+		if len(sr.Cases) == 0 {
+			_, _ = fmt.Fprintln(out, "  (no cases)")
+		}
+		for _, c := range sr.Cases {
+			if c.Passed {
+				_, _ = fmt.Fprintf(out, "  ✅ %s (%s)\n", c.Name, c.Kind)
+				continue
+			}
+			_, _ = fmt.Fprintf(out, "  ❌ %s (%s): %s\n", c.Name, c.Kind, c.Message)
+		}
+		cov := sr.Coverage
+		_, _ = fmt.Fprintf(out, "  Coverage: %d/%d properties and branches (%.0f%%)\n", cov.Covered, cov.Total, cov.Percent())
+		for _, item := range cov.Items {
+			if !item.Covered {
+				_, _ = fmt.Fprintf(out, "    • not exercised: %s\n", item)
+			}
+		}
+		_, _ = fmt.Fprintln(out)
+	}
+	_, _ = fmt.Fprintf(out, "%d passed, %d failed across %d schema(s)\n", report.Passed, report.Failed, len(report.Schemas))
+}
@@ -4,7 +4,9 @@ Copyright © 2025 3 Leaps <info@3leaps.net>
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/fulmenhq/goneat/internal/ops"
 	"github.com/fulmenhq/goneat/pkg/logger"
@@ -34,6 +36,18 @@ Examples:
   # Dry run to see what would be synced
   goneat ssot sync --dry-run
 
+  # Reproduce exactly the locked commits (CI)
+  goneat ssot sync --frozen
+
+  # Refresh the lock to the current tip of each source's ref
+  goneat ssot update
+
+  # Detect local edits to synced files
+  goneat ssot verify
+
+  # Show local edits to synced files as a diff
+  goneat ssot status
+
 Configuration Priority:
   1. Command-line flags (--local-path takes precedence, --force-remote disables detection)
   2. .goneat/ssot-consumer.local.yaml (gitignored, for local dev)
@@ -58,7 +72,16 @@ The sync process:
   1. Load .goneat/ssot-consumer.yaml
   2. Merge .goneat/ssot-consumer.local.yaml if present
   3. Apply command-line flag overrides
-  4. Copy assets from source to destination directories
+  4. Check out the commit pinned in .goneat/ssot.lock (if the source is locked)
+  5. Copy assets from source to destination directories
+  6. Record the resolved commit and file digests in .goneat/ssot.lock
+
+With --frozen, every source must be locked, the lock is never rewritten and any
+file whose SHA-256 differs from the lock fails the sync before files are written.
+
+Files edited locally since the last sync follow strategy.on_conflict: overwrite
+(default), skip, error, or merge (three-way merge with the previously synced
+version as base; conflicts exit non-zero).
 
 Exit Codes:
   0 - Success
@@ -68,11 +91,45 @@ Exit Codes:
 	RunE: runSSOTSync,
 }
 
+var ssotUpdateCmd = &cobra.Command{
+	Use:   "update [source...]",
+	Short: "Refresh the SSOT lock to the latest commit of each ref",
+	Long: `Re-resolve each source's ref, sync its assets and rewrite its entry in
+.goneat/ssot.lock with the new commit and file digests.
+
+Without arguments every configured source is updated. Naming sources updates
+only those entries and leaves the rest of the lock untouched.`,
+	RunE: runSSOTUpdate,
+}
+
+var ssotVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Detect local edits to synced SSOT files",
+	Long: `Compare every file recorded in .goneat/ssot.lock against its locked SHA-256
+digest and report files that were modified or deleted since the last sync.
+
+Exits non-zero when any synced file has drifted.`,
+	Args: cobra.NoArgs,
+	RunE: runSSOTVerify,
+}
+
+var ssotStatusCmd = &cobra.Command{
+	Use:   "status [source...]",
+	Short: "List locally modified SSOT files with a diff",
+	Long: `List synced files that were edited or deleted since the last sync, showing
+a unified diff from the synced version (at the commit in .goneat/ssot.lock) to
+the local file.
+
+Use it to review local patches before a sync with strategy.on_conflict: merge.`,
+	RunE: runSSOTStatus,
+}
+
 var (
 	flagSSOTLocalPath   string
 	flagSSOTForceRemote bool
 	flagSSOTDryRun      bool
 	flagSSOTVerbose     bool
+	flagSSOTFrozen      bool
 )
 
 func init() {
@@ -87,15 +144,91 @@ func init() {
 
 	// Subcommands
 	ssotCmd.AddCommand(ssotSyncCmd)
+	ssotCmd.AddCommand(ssotUpdateCmd)
+	ssotCmd.AddCommand(ssotVerifyCmd)
+	ssotCmd.AddCommand(ssotStatusCmd)
 
-	// Flags for sync subcommand
-	ssotSyncCmd.Flags().StringVar(&flagSSOTLocalPath, "local-path", "", "Local path to source repository (overrides config)")
-	ssotSyncCmd.Flags().BoolVar(&flagSSOTForceRemote, "force-remote", false, "Force remote sync, ignore local auto-detection (v0.3.4+)")
-	ssotSyncCmd.Flags().BoolVar(&flagSSOTDryRun, "dry-run", false, "Show what would be synced without performing sync")
-	ssotSyncCmd.Flags().BoolVar(&flagSSOTVerbose, "verbose", false, "Show verbose output including file-level operations")
+	// Flags shared by sync and update
+	for _, c := range []*cobra.Command{ssotSyncCmd, ssotUpdateCmd} {
+		c.Flags().StringVar(&flagSSOTLocalPath, "local-path", "", "Local path to source repository (overrides config)")
+		c.Flags().BoolVar(&flagSSOTForceRemote, "force-remote", false, "Force remote sync, ignore local auto-detection (v0.3.4+)")
+		c.Flags().BoolVar(&flagSSOTDryRun, "dry-run", false, "Show what would be synced without performing sync")
+		c.Flags().BoolVar(&flagSSOTVerbose, "verbose", false, "Show verbose output including file-level operations")
+	}
+	ssotSyncCmd.Flags().BoolVar(&flagSSOTFrozen, "frozen", false, "Sync exactly the commits in .goneat/ssot.lock and fail on digest mismatches")
 }
 
 func runSSOTSync(cmd *cobra.Command, args []string) error {
+	return performSSOTSync(flagSSOTFrozen, false, nil)
+}
+
+func runSSOTUpdate(cmd *cobra.Command, args []string) error {
+	return performSSOTSync(false, true, args)
+}
+
+func runSSOTVerify(cmd *cobra.Command, _ []string) error {
+	out := cmd.OutOrStdout()
+
+	lock, err := ssot.LoadLock(ssot.LockFilePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%s not found; run 'goneat ssot update' first", ssot.LockFilePath)
+		}
+		return err
+	}
+
+	report, err := ssot.VerifyLock(lock)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(out, "Checked: %d synced files\n", report.Checked)
+	for _, d := range report.Drift {
+		_, _ = fmt.Fprintf(out, "  ✗ %s %s (%s)\n", d.Path, d.Status, d.Source)
+	}
+
+	if len(report.Drift) > 0 {
+		return fmt.Errorf("%d synced file(s) differ from %s; run 'goneat ssot sync' to restore them", len(report.Drift), ssot.LockFilePath)
+	}
+
+	logger.Info("SSOT verify completed: synced files match the lock")
+	return nil
+}
+
+func runSSOTStatus(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+
+	config, err := ssot.LoadSyncConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	statuses, err := ssot.Status(config, ssot.LockFilePath, args)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%s not found; run 'goneat ssot update' first", ssot.LockFilePath)
+		}
+		return err
+	}
+
+	if len(statuses) == 0 {
+		_, _ = fmt.Fprintln(out, "No local modifications to synced files")
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(out, "Locally modified synced files: %d\n", len(statuses))
+	for _, st := range statuses {
+		_, _ = fmt.Fprintf(out, "  %s: %s (%s)\n", st.Status, st.Path, st.Source)
+	}
+	for _, st := range statuses {
+		if st.Diff != "" {
+			_, _ = fmt.Fprintf(out, "\n%s", st.Diff)
+		}
+	}
+	return nil
+}
+
+func performSSOTSync(frozen, update bool, only []string) error {
 	// Validate flag conflicts
 	if flagSSOTLocalPath != "" && flagSSOTForceRemote {
 		return fmt.Errorf("cannot use --local-path and --force-remote together (mutually exclusive)")
@@ -143,6 +276,9 @@ func runSSOTSync(cmd *cobra.Command, args []string) error {
 		DryRun:        flagSSOTDryRun,
 		Verbose:       flagSSOTVerbose,
 		ForceRemoteBy: forceRemoteBy,
+		Frozen:        frozen,
+		Update:        update,
+		Only:          only,
 	}
 
 	result, err := ssot.PerformSync(opts)
@@ -151,6 +287,19 @@ func runSSOTSync(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("sync operation failed: %w", err)
 	}
 
+	for _, path := range result.Merged {
+		logger.Info(fmt.Sprintf("Merged local changes: %s", path))
+	}
+	for _, path := range result.Skipped {
+		logger.Info(fmt.Sprintf("Kept local changes: %s", path))
+	}
+	if len(result.Conflicts) > 0 {
+		for _, path := range result.Conflicts {
+			logger.Error(fmt.Sprintf("Merge conflict: %s", path))
+		}
+		return fmt.Errorf("sync completed with %d merge conflict(s); resolve them and re-run 'goneat ssot sync'", len(result.Conflicts))
+	}
+
 	// Report results
 	if flagSSOTDryRun {
 		logger.Info("Dry run completed")
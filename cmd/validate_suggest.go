@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/schema/mapping"
+	"github.com/fulmenhq/goneat/pkg/schema/signature"
+	"github.com/spf13/cobra"
+)
+
+var (
+	validateSuggestManifestPath   string
+	validateSuggestWrite          bool
+	validateSuggestFormat         string
+	validateSuggestMinConfidence  float64
+	validateSuggestMaxSuggestions int
+	validateSuggestExclusions     bool
+	validateSuggestNoCache        bool
+	validateSuggestCacheFile      string
+	validateSuggestNoIgnore       bool
+	validateSuggestExclude        []string
+)
+
+var validateSuggestCmd = &cobra.Command{
+	Use:   "suggest-mappings [paths...]",
+	Short: "Suggest schema mapping rules for unmapped config files",
+	Long: `Detect the document type of JSON/YAML files that no schema mapping covers
+and propose mapping rules for .goneat/schema-mappings.yaml.
+
+Files whose best signature names an embedded schema (e.g. a JSON Schema
+draft) get a mapping rule; other detected documents (OpenAPI, AsyncAPI, ...)
+get an exclusion rule when exclusion suggestions are enabled. Files in one
+directory that share an extension and a proposal are grouped under a glob.
+Suggestions are ranked by confidence and filtered by the manifest's
+min_confidence and max_suggestions settings.
+
+Inferences are cached by file content hash under $GONEAT_HOME/cache, so
+repeated runs only run detection for new or changed files.
+
+The patch is printed as YAML by default; --write merges it into the manifest,
+keeping existing rules and comments.`,
+	Args:         cobra.ArbitraryArgs,
+	SilenceUsage: true,
+	RunE:         runValidateSuggest,
+}
+
+type validateSuggestResult struct {
+	RepoRoot     string                    `json:"repo_root"`
+	ManifestPath string                    `json:"manifest_path"`
+	Written      bool                      `json:"written"`
+	Added        int                       `json:"added"`
+	Report       *mapping.SuggestionReport `json:"report"`
+}
+
+func init() {
+	validateCmd.AddCommand(validateSuggestCmd)
+
+	validateSuggestCmd.Flags().StringVar(&validateSuggestManifestPath, "manifest", mapping.DefaultManifestRelativePath, "Schema mapping manifest path (defaults to .goneat/schema-mappings.yaml)")
+	validateSuggestCmd.Flags().BoolVar(&validateSuggestWrite, "write", false, "Merge the suggested rules into the manifest")
+	validateSuggestCmd.Flags().StringVar(&validateSuggestFormat, "format", "yaml", "Output format (yaml, json)")
+	validateSuggestCmd.Flags().Float64Var(&validateSuggestMinConfidence, "min-confidence", 0, "Minimum detection confidence (overrides config.min_confidence)")
+	validateSuggestCmd.Flags().IntVar(&validateSuggestMaxSuggestions, "max-suggestions", 0, "Maximum number of suggestions (overrides config.max_suggestions)")
+	validateSuggestCmd.Flags().BoolVar(&validateSuggestExclusions, "exclusions", false, "Suggest exclusion rules for detected non-data documents (overrides config.auto_suggest_exclusions)")
+	validateSuggestCmd.Flags().BoolVar(&validateSuggestNoCache, "no-cache", false, "Do not read or write the inference cache")
+	validateSuggestCmd.Flags().StringVar(&validateSuggestCacheFile, "cache-file", "", "Inference cache file (default $GONEAT_HOME/cache/schema-inferences.json)")
+	validateSuggestCmd.Flags().BoolVar(&validateSuggestNoIgnore, "no-ignore", false, "Disable .goneatignore/.gitignore for discovery")
+	validateSuggestCmd.Flags().StringSliceVar(&validateSuggestExclude, "exclude", []string{}, "Exclude paths or globs (repeatable)")
+}
+
+func runValidateSuggest(cmd *cobra.Command, args []string) error {
+	format := strings.ToLower(validateSuggestFormat)
+	if format != "yaml" && format != "json" {
+		return fmt.Errorf("unsupported format: %s", validateSuggestFormat)
+	}
+	paths := args
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	repoRoot, err := inferSuiteRepoRoot(paths[0])
+	if err != nil {
+		return err
+	}
+	manifestPath, err := mapping.ManifestPath(mapping.LoadOptions{RepoRoot: repoRoot, ManifestPath: validateSuggestManifestPath})
+	if err != nil {
+		return err
+	}
+	files, err := discoverSuggestFiles(repoRoot, paths, manifestPath)
+	if err != nil {
+		return err
+	}
+
+	_, loadResult, err := loadSuiteMapping(repoRoot, validateSuggestManifestPath)
+	if err != nil {
+		return err
+	}
+	manifest := loadResult.Effective
+	flags := cmd.Flags()
+	if flags.Changed("min-confidence") {
+		manifest.Config.MinConfidence = &validateSuggestMinConfidence
+	}
+	if flags.Changed("max-suggestions") {
+		manifest.Config.MaxSuggestions = &validateSuggestMaxSuggestions
+	}
+	if flags.Changed("exclusions") {
+		manifest.Config.AutoSuggestExclusions = &validateSuggestExclusions
+	}
+
+	sigManifest, err := signature.LoadDefaultManifest()
+	if err != nil {
+		return fmt.Errorf("load signature manifest: %w", err)
+	}
+	detector, err := signature.NewDetector(sigManifest)
+	if err != nil {
+		return fmt.Errorf("init signature detector: %w", err)
+	}
+
+	var cache *mapping.InferenceCache
+	useCache := !validateSuggestNoCache && (manifest.Config.CacheInferences == nil || *manifest.Config.CacheInferences)
+	if useCache {
+		cachePath := validateSuggestCacheFile
+		if cachePath == "" {
+			if cachePath, err = mapping.DefaultInferenceCachePath(); err != nil {
+				return fmt.Errorf("resolve inference cache: %w", err)
+			}
+		}
+		if cache, err = mapping.LoadInferenceCache(cachePath, mapping.ManifestFingerprint(sigManifest)); err != nil {
+			return err
+		}
+	}
+
+	report, err := mapping.Suggest(manifest, mapping.SuggestOptions{
+		RepoRoot: repoRoot,
+		Files:    files,
+		Detector: detector,
+		Cache:    cache,
+	})
+	if err != nil {
+		return err
+	}
+	if cache != nil {
+		if err := cache.Save(); err != nil {
+			return err
+		}
+	}
+
+	result := validateSuggestResult{RepoRoot: repoRoot, ManifestPath: manifestPath, Report: report}
+	if validateSuggestWrite && len(report.Suggestions) > 0 {
+		added, err := writeSuggestedMappings(manifestPath, report.Suggestions)
+		if err != nil {
+			return err
+		}
+		result.Written, result.Added = added > 0, added
+	}
+
+	out := cmd.OutOrStdout()
+	if format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("encode JSON output: %w", err)
+		}
+		return nil
+	}
+	return writeValidateSuggestYAML(cmd, result)
+}
+
+// discoverSuggestFiles lists the JSON/YAML files under paths relative to
+// repoRoot, leaving out the mapping manifest itself
+func discoverSuggestFiles(repoRoot string, paths []string, manifestPath string) ([]string, error) {
+	seen := make(map[string]bool)
+	if rel, err := filepath.Rel(repoRoot, manifestPath); err == nil {
+		seen[filepath.ToSlash(rel)] = true
+	}
+	var files []string
+	for _, p := range paths {
+		// paths are relative to the working directory, not the repo root
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return nil, fmt.Errorf("resolve %s: %w", p, err)
+		}
+		found, err := discoverDataFiles(repoRoot, abs, validateSuggestNoIgnore, nil, validateSuggestExclude)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range found {
+			if filepath.IsAbs(f) {
+				rel, err := filepath.Rel(repoRoot, f)
+				if err != nil {
+					return nil, fmt.Errorf("resolve %s: %w", f, err)
+				}
+				f = rel
+			}
+			f = filepath.ToSlash(strings.TrimPrefix(filepath.Clean(f), "./"))
+			if strings.HasPrefix(f, "../") || seen[f] {
+				continue
+			}
+			seen[f] = true
+			files = append(files, f)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// writeSuggestedMappings merges suggestions into the manifest at path and
+// returns how many rules were added. The merged manifest is validated before
+// it replaces the file.
+func writeSuggestedMappings(path string, suggestions []mapping.Suggestion) (int, error) {
+	existing, err := os.ReadFile(filepath.Clean(path)) // #nosec G304 -- path checked by mapping.ManifestPath
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return 0, fmt.Errorf("read manifest %s: %w", path, err)
+	}
+	merged, added, err := mapping.MergeSuggestions(existing, suggestions)
+	if err != nil {
+		return 0, fmt.Errorf("merge suggestions into %s: %w", path, err)
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	mgr, err := mapping.NewManager()
+	if err != nil {
+		return 0, fmt.Errorf("init schema mapping manager: %w", err)
+	}
+	if err := mgr.Validate(merged); err != nil {
+		return 0, fmt.Errorf("merged manifest %s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return 0, fmt.Errorf("create manifest directory: %w", err)
+	}
+	if err := os.WriteFile(path, merged, 0o600); err != nil {
+		return 0, fmt.Errorf("write manifest %s: %w", path, err)
+	}
+	return added, nil
+}
+
+func writeValidateSuggestYAML(cmd *cobra.Command, result validateSuggestResult) error {
+	out := cmd.OutOrStdout()
+	report := result.Report
+	m := report.Metrics
+	_, _ = fmt.Fprintf(out, "# %d file(s): %d mapped, %d excluded, %d unmapped\n", m.FilesEvaluated, m.Mapped, m.Excluded, m.Unmapped)
+	_, _ = fmt.Fprintf(out, "# inference cache: %d hit(s), %d miss(es)\n", report.CacheHits, report.CacheMisses)
+	if len(report.Undetected) > 0 {
+		_, _ = fmt.Fprintf(out, "# no signature matched %d unmapped file(s):\n", len(report.Undetected))
+		for _, f := range report.Undetected {
+			_, _ = fmt.Fprintf(out, "#   %s\n", f)
+		}
+	}
+	if report.BelowConfidence > 0 {
+		_, _ = fmt.Fprintf(out, "# %d suggestion(s) below min_confidence\n", report.BelowConfidence)
+	}
+	if report.Truncated > 0 {
+		_, _ = fmt.Fprintf(out, "# %d suggestion(s) over max_suggestions\n", report.Truncated)
+	}
+	if len(report.Suggestions) == 0 {
+		_, _ = fmt.Fprintln(out, "# no mapping suggestions")
+		return nil
+	}
+	if result.Written {
+		_, _ = fmt.Fprintf(out, "# added %d rule(s) to %s\n", result.Added, result.ManifestPath)
+	} else if validateSuggestWrite {
+		_, _ = fmt.Fprintf(out, "# %s already declares every suggested pattern\n", result.ManifestPath)
+	}
+
+	patch, _, err := mapping.MergeSuggestions(nil, report.Suggestions)
+	if err != nil {
+		return err
+	}
+	_, _ = out.Write(patch)
+	return nil
+}
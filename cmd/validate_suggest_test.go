@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateSuggestMappings_WriteMergesIntoManifest(t *testing.T) {
+	repo := t.TempDir()
+	for _, dir := range []string{".git", ".goneat", "schemas"} {
+		if err := os.MkdirAll(filepath.Join(repo, dir), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", dir, err)
+		}
+	}
+	manifestPath := filepath.Join(repo, ".goneat", "schema-mappings.yaml")
+	if err := os.WriteFile(manifestPath, []byte("# team mappings\nversion: \"1.0.0\"\n"), 0o644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	schemaDoc := `{"$schema":"http://json-schema.org/draft-07/schema#","type":"object"}`
+	for _, name := range []string{"a.json", "b.json"} {
+		if err := os.WriteFile(filepath.Join(repo, "schemas", name), []byte(schemaDoc), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	cacheFile := filepath.Join(t.TempDir(), "inferences.json")
+
+	stdout, stderr, err := execRootSplit(t, []string{
+		"validate", "suggest-mappings", repo,
+		"--cache-file", cacheFile,
+		"--write",
+	})
+	if err != nil {
+		t.Fatalf("suggest-mappings failed: %v\nstdout:\n%s\nstderr:\n%s", err, stdout, stderr)
+	}
+	if !strings.Contains(stdout, "pattern: schemas/*.json") || !strings.Contains(stdout, "added 1 rule(s)") {
+		t.Fatalf("expected grouped suggestion in output, got:\n%s", stdout)
+	}
+	written, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	if !strings.Contains(string(written), "# team mappings") || !strings.Contains(string(written), "schema_id: json-schema-draft-07") {
+		t.Fatalf("expected merged manifest, got:\n%s", written)
+	}
+
+	// The rerun finds the files mapped and answers from the cache
+	stdout, stderr, err = execRootSplit(t, []string{
+		"validate", "suggest-mappings", repo,
+		"--cache-file", cacheFile,
+		"--format", "json",
+	})
+	if err != nil {
+		t.Fatalf("suggest-mappings rerun failed: %v\nstderr:\n%s", err, stderr)
+	}
+	var res validateSuggestResult
+	if uerr := json.Unmarshal([]byte(stdout), &res); uerr != nil {
+		t.Fatalf("expected stdout JSON output, got parse error: %v\nstdout:\n%s", uerr, stdout)
+	}
+	if len(res.Report.Suggestions) != 0 || res.Report.Metrics.Mapped != 2 {
+		t.Fatalf("expected no suggestions after write, got %+v", res.Report)
+	}
+	if _, err := os.Stat(cacheFile); err != nil {
+		t.Fatalf("expected inference cache to be written: %v", err)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/bmatcuk/doublestar/v4"
 	"github.com/fulmenhq/goneat/internal/assess"
 	"github.com/fulmenhq/goneat/pkg/buildinfo"
+	"github.com/fulmenhq/goneat/pkg/pathfinder"
 	"github.com/fulmenhq/goneat/pkg/safeio"
 	"github.com/fulmenhq/goneat/pkg/schema"
 	"github.com/fulmenhq/goneat/pkg/schema/mapping"
@@ -41,6 +42,9 @@ var (
 	validateSuiteTimeout          time.Duration
 	validateSuiteFailOnUnmapped   bool
 	validateSuiteSchemaResolution string
+	validateSuiteLoader           string
+	validateSuiteSource           string
+	validateSuiteRef              string
 )
 
 var validateSuiteCmd = &cobra.Command{
@@ -58,6 +62,9 @@ type validateSuiteMetadata struct {
 	Duration         string   `json:"duration"`
 	RepoRoot         string   `json:"repo_root"`
 	DataRoot         string   `json:"data_root"`
+	Loader           string   `json:"loader,omitempty"`
+	Source           string   `json:"source,omitempty"`
+	Ref              string   `json:"ref,omitempty"`
 	SchemasRoot      string   `json:"schemas_root,omitempty"`
 	ManifestPath     string   `json:"manifest_path"`
 	RefDirs          []string `json:"ref_dirs,omitempty"`
@@ -132,12 +139,31 @@ func init() {
 	validateSuiteCmd.Flags().IntVar(&validateSuiteMaxWorkers, "workers", runtime.NumCPU(), "Max parallel workers")
 	validateSuiteCmd.Flags().DurationVar(&validateSuiteTimeout, "timeout", 3*time.Minute, "Validation timeout")
 	validateSuiteCmd.Flags().StringVar(&validateSuiteFormat, "format", "markdown", "Output format (markdown, json)")
+
+	validateSuiteCmd.Flags().StringVar(&validateSuiteLoader, "loader", "local", "Read data and local schemas through a loader: local, tar, zip, git")
+	validateSuiteCmd.Flags().StringVar(&validateSuiteSource, "source", "", "Archive file or git repository to read from (with --loader tar|zip|git); --data is a directory inside it")
+	validateSuiteCmd.Flags().StringVar(&validateSuiteRef, "ref", "", "Git ref to read with --loader git (default HEAD)")
 }
 
 func runValidateSuite(cmd *cobra.Command, _ []string) error {
 	start := time.Now()
 
-	repoRoot, err := inferSuiteRepoRoot(validateSuiteDataRoot)
+	var loader pathfinder.SourceLoader
+	localRoot := validateSuiteDataRoot
+	if validateSuiteLoader != "" && validateSuiteLoader != "local" {
+		if validateSuiteEnableMeta {
+			return fmt.Errorf("--enable-meta is not supported with --loader %s; use 'goneat schema validate-schema --loader %s'", validateSuiteLoader, validateSuiteLoader)
+		}
+		l, err := newSourceLoader(validateSuiteLoader, validateSuiteSource, validateSuiteRef)
+		if err != nil {
+			return err
+		}
+		loader = l
+		// Mappings still come from the local repository
+		localRoot = "."
+	}
+
+	repoRoot, err := inferSuiteRepoRoot(localRoot)
 	if err != nil {
 		return err
 	}
@@ -154,7 +180,12 @@ func runValidateSuite(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	files, err := discoverSuiteFiles(repoRoot, validateSuiteDataRoot)
+	var files []string
+	if loader != nil {
+		files, err = discoverLoaderSuiteFiles(loader, validateSuiteDataRoot)
+	} else {
+		files, err = discoverSuiteFiles(repoRoot, validateSuiteDataRoot)
+	}
 	if err != nil {
 		return err
 	}
@@ -193,7 +224,7 @@ func runValidateSuite(cmd *cobra.Command, _ []string) error {
 	for _, file := range files {
 		file := file
 		g.Go(func() error {
-			res := validateSuiteOne(gctx, repoRoot, file, loadResult, resolver, idIndex, validateSuiteSchemaResolution)
+			res := validateSuiteOne(gctx, repoRoot, loader, file, loadResult, resolver, idIndex, validateSuiteSchemaResolution)
 
 			resultsMu.Lock()
 			results = append(results, res)
@@ -230,6 +261,11 @@ func runValidateSuite(cmd *cobra.Command, _ []string) error {
 
 	sort.Slice(results, func(i, j int) bool { return results[i].Path < results[j].Path })
 
+	loaderType := ""
+	if loader != nil {
+		loaderType = loader.SourceType()
+	}
+
 	suiteRes := validateSuiteResult{
 		Metadata: validateSuiteMetadata{
 			Tool:             "goneat",
@@ -238,6 +274,9 @@ func runValidateSuite(cmd *cobra.Command, _ []string) error {
 			Duration:         time.Since(start).String(),
 			RepoRoot:         repoRoot,
 			DataRoot:         validateSuiteDataRoot,
+			Loader:           loaderType,
+			Source:           validateSuiteSource,
+			Ref:              validateSuiteRef,
 			SchemasRoot:      validateSuiteSchemasRoot,
 			ManifestPath:     validateSuiteManifestPath,
 			RefDirs:          append([]string(nil), validateSuiteRefDirs...),
@@ -329,6 +368,12 @@ func loadSuiteMapping(repoRoot, manifestPath string) (*mapping.Resolver, *mappin
 }
 
 func discoverSuiteFiles(repoRoot, dataRoot string) ([]string, error) {
+	return discoverDataFiles(repoRoot, dataRoot, validateSuiteNoIgnore, validateSuiteForceInclude, validateSuiteExclude)
+}
+
+// discoverDataFiles lists the JSON/YAML files under dataRoot, honouring
+// ignore files unless noIgnore is set.
+func discoverDataFiles(repoRoot, dataRoot string, noIgnore bool, forceInclude, exclude []string) ([]string, error) {
 	clean, err := safeio.CleanUserPath(dataRoot)
 	if err != nil {
 		return nil, fmt.Errorf("invalid data root %q: %w", dataRoot, err)
@@ -362,8 +407,8 @@ func discoverSuiteFiles(repoRoot, dataRoot string) ([]string, error) {
 		Paths:                paths,
 		ExecutionStrategy:    "parallel",
 		IgnoreFile:           ".goneatignore",
-		NoIgnore:             validateSuiteNoIgnore,
-		ForceIncludePatterns: append([]string(nil), validateSuiteForceInclude...),
+		NoIgnore:             noIgnore,
+		ForceIncludePatterns: append([]string(nil), forceInclude...),
 	})
 	manifest, err := planner.GenerateManifest()
 	if err != nil {
@@ -376,7 +421,7 @@ func discoverSuiteFiles(repoRoot, dataRoot string) ([]string, error) {
 		low := strings.ToLower(p)
 		if strings.HasSuffix(low, ".yaml") || strings.HasSuffix(low, ".yml") || strings.HasSuffix(low, ".json") {
 			// Apply exclude patterns
-			if matchAny(validateSuiteExclude, filepath.ToSlash(p)) {
+			if matchAny(exclude, filepath.ToSlash(p)) {
 				continue
 			}
 			out = append(out, p)
@@ -385,6 +430,32 @@ func discoverSuiteFiles(repoRoot, dataRoot string) ([]string, error) {
 	return out, nil
 }
 
+// discoverLoaderSuiteFiles lists JSON/YAML files under dataRoot inside a
+// non-local source. Ignore files do not apply; --exclude still does.
+func discoverLoaderSuiteFiles(loader pathfinder.SourceLoader, dataRoot string) ([]string, error) {
+	root := strings.Trim(filepath.ToSlash(filepath.Clean(dataRoot)), "/")
+	listed, err := loader.ListFiles(root, []string{"**/*.json", "**/*.yaml", "**/*.yml"}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("data root %s in %s: %w", dataRoot, loader.SourceDescription(), err)
+	}
+
+	var out []string
+	for _, rel := range listed {
+		p := rel
+		if root != "." && root != "" {
+			p = root + "/" + rel
+		}
+		if matchAny(validateSuiteExclude, p) {
+			continue
+		}
+		out = append(out, p)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no data files found under %s in %s", dataRoot, loader.SourceDescription())
+	}
+	return out, nil
+}
+
 func runSuiteMetaValidation(ctx context.Context, repoRoot, schemasRoot string) *assess.AssessmentResult {
 	// Reuse the schema assessment runner for offline meta-validation.
 	runner := assess.NewSchemaAssessmentRunner()
@@ -405,7 +476,7 @@ func runSuiteMetaValidation(ctx context.Context, repoRoot, schemasRoot string) *
 	return res
 }
 
-func validateSuiteOne(ctx context.Context, repoRoot, file string, loadResult *mapping.LoadResult, resolver *mapping.Resolver, idIndex *schema.IDIndex, schemaResolution string) validateSuiteFileResult {
+func validateSuiteOne(ctx context.Context, repoRoot string, loader pathfinder.SourceLoader, file string, loadResult *mapping.LoadResult, resolver *mapping.Resolver, idIndex *schema.IDIndex, schemaResolution string) validateSuiteFileResult {
 	start := time.Now()
 
 	normPath := filepath.Clean(file)
@@ -416,7 +487,10 @@ func validateSuiteOne(ctx context.Context, repoRoot, file string, loadResult *ma
 	fullPath = filepath.Clean(fullPath)
 
 	rel := filepath.ToSlash(strings.TrimPrefix(normPath, "./"))
-	if repoRoot != "" {
+	if loader != nil {
+		// Paths inside a loader source are already relative to its root
+		fullPath = rel
+	} else if repoRoot != "" {
 		if r, err := filepath.Rel(repoRoot, fullPath); err == nil {
 			rel = filepath.ToSlash(strings.TrimPrefix(r, "./"))
 		}
@@ -455,7 +529,9 @@ func validateSuiteOne(ctx context.Context, repoRoot, file string, loadResult *ma
 			return validateSuiteFileResult{Path: rel, Schema: &validateSuiteSchemaRef{ID: resolution.SchemaID, Source: string(source)}, Status: "fail", Valid: false, Error: err.Error(), Duration: time.Since(start).String()}
 		}
 		schemaPath = clean
-		if repoRoot != "" && !filepath.IsAbs(schemaPath) {
+		if loader != nil {
+			schemaPath = filepath.ToSlash(schemaPath)
+		} else if repoRoot != "" && !filepath.IsAbs(schemaPath) {
 			schemaPath = filepath.Join(repoRoot, schemaPath)
 		}
 		schemaPath = filepath.Clean(schemaPath)
@@ -463,7 +539,13 @@ func validateSuiteOne(ctx context.Context, repoRoot, file string, loadResult *ma
 
 	schemaRef := &validateSuiteSchemaRef{ID: resolution.SchemaID, Source: string(source), Path: schemaPath}
 
-	dataBytes, err := os.ReadFile(fullPath) // #nosec G304 -- path comes from work planner output
+	var dataBytes []byte
+	var err error
+	if loader != nil {
+		dataBytes, err = readLoaderFile(loader, rel)
+	} else {
+		dataBytes, err = os.ReadFile(fullPath) // #nosec G304 -- path comes from work planner output
+	}
 	if err != nil {
 		return validateSuiteFileResult{Path: rel, Schema: schemaRef, Status: "fail", Valid: false, Error: err.Error(), Duration: time.Since(start).String()}
 	}
@@ -484,7 +566,12 @@ func validateSuiteOne(ctx context.Context, repoRoot, file string, loadResult *ma
 		if schemaPath == "" {
 			return validateSuiteFileResult{Path: rel, Schema: schemaRef, Status: "fail", Valid: false, Error: fmt.Sprintf("local schema path not resolved for %q", resolution.SchemaID), Duration: time.Since(start).String()}
 		}
-		schemaBytes, err := os.ReadFile(filepath.Clean(schemaPath)) // #nosec G304 -- schemaPath sanitized
+		var schemaBytes []byte
+		if loader != nil {
+			schemaBytes, err = readLoaderFile(loader, schemaPath)
+		} else {
+			schemaBytes, err = os.ReadFile(filepath.Clean(schemaPath)) // #nosec G304 -- schemaPath sanitized
+		}
 		if err != nil {
 			return validateSuiteFileResult{Path: rel, Schema: schemaRef, Status: "fail", Valid: false, Error: err.Error(), Duration: time.Since(start).String()}
 		}
@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -120,6 +123,9 @@ func execRootSplit(t *testing.T, args []string) (string, string, error) {
 	validateSuiteFormat = "markdown"
 	validateSuiteFailOnUnmapped = true
 	validateSuiteSchemaResolution = "prefer-id"
+	validateSuiteLoader = "local"
+	validateSuiteSource = ""
+	validateSuiteRef = ""
 	validateSchemaRefDirs = nil
 
 	cmd := newRootCommand()
@@ -360,3 +366,72 @@ mappings:
 		t.Fatalf("expected schema.path to include root.schema.json, got %q", res.Files[0].Schema.Path)
 	}
 }
+
+func TestValidateSuite_TarLoader(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatalf("create fake .git: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "schema-mappings.yaml"), []byte(`version: "1.0.0"
+mappings:
+  - pattern: "**/*.yaml"
+    schema_path: schemas/root.schema.json
+`), 0o644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+
+	// Data and schemas only exist inside the packaged archive
+	archive := filepath.Join(repo, "bundle.tar.gz")
+	writeTarGz(t, archive, map[string]string{
+		"schemas/root.schema.json": `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","required":["name"]}`,
+		"examples/good.yaml":       "name: ok\n",
+		"examples/bad.yaml":        "other: 1\n",
+		"README.md":                "# bundle\n",
+	})
+	t.Chdir(repo)
+
+	stdout, stderr, err := execRootSplit(t, []string{
+		"validate", "suite",
+		"--loader", "tar",
+		"--source", "bundle.tar.gz",
+		"--data", "examples",
+		"--manifest", "schema-mappings.yaml",
+		"--expect-fail", "**/bad.yaml",
+		"--format", "json",
+	})
+	if err != nil {
+		t.Fatalf("expected suite to pass, got error: %v\nstdout:\n%s\nstderr:\n%s", err, stdout, stderr)
+	}
+
+	var res validateSuiteResult
+	if uerr := json.Unmarshal([]byte(stdout), &res); uerr != nil {
+		t.Fatalf("expected JSON output, got parse error: %v\n%s", uerr, stdout)
+	}
+	if res.Summary.Total != 2 || res.Summary.Passed != 1 || res.Summary.ExpectedFail != 1 {
+		t.Fatalf("unexpected summary: %+v", res.Summary)
+	}
+	if res.Metadata.Loader != "tar.gz" || res.Files[0].Path != "examples/bad.yaml" {
+		t.Fatalf("unexpected loader metadata or paths: %+v %+v", res.Metadata, res.Files)
+	}
+}
+
+func writeTarGz(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create archive: %v", err)
+	}
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatalf("write header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("write entry: %v", err)
+		}
+	}
+	if err := errors.Join(tw.Close(), gz.Close(), file.Close()); err != nil {
+		t.Fatalf("close archive: %v", err)
+	}
+}
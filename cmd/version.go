@@ -67,6 +67,9 @@ func init() {
 	versionPropagateCmd.Flags().Bool("generate-policy", false, "Generate a sample version policy file")
 	versionPropagateCmd.Flags().String("policy", "", "Path to version policy file (default: .goneat/version-policy.yaml)")
 
+	// Check-consistency command flags
+	versionCheckConsistencyCmd.Flags().String("policy", "", "Path to version policy file (default: .goneat/version-policy.yaml)")
+
 	// Add root version command flags
 	versionCmd.Flags().Bool("project", false, "Show project version information")
 	versionCmd.Flags().Bool("extended", false, "Show extended version information")
@@ -494,8 +497,12 @@ Examples:
 var versionCheckConsistencyCmd = &cobra.Command{
 	Use:   "check-consistency",
 	Short: "Check version consistency across sources",
-	Long:  `Check that version is consistent across all configured sources.`,
-	RunE:  runVersionCheckConsistency,
+	Long: `Check that version is consistent across all configured sources.
+
+Every file selected by the version policy is compared with VERSION: package
+manager files and custom regex/path targets. Each mismatch is reported with its
+file and, for custom targets, line number.`,
+	RunE: runVersionCheckConsistency,
 }
 
 func runVersionCheckConsistency(cmd *cobra.Command, _ []string) error {
@@ -516,10 +523,32 @@ func runVersionCheckConsistency(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
+	policyPath, _ := cmd.Flags().GetString("policy")
+	report, err := newVersionPropagator().CheckConsistency(version, policyPath)
+	if err != nil {
+		return err
+	}
+
 	_, _ = fmt.Fprintf(out, "Version Consistency Check\n")
 	_, _ = fmt.Fprintf(out, "========================\n")
 	_, _ = fmt.Fprintf(out, "Source: VERSION\n")
 	_, _ = fmt.Fprintf(out, "Version: %s ✓\n", version)
+	_, _ = fmt.Fprintf(out, "Checked: %d locations\n", report.Checked)
+
+	for _, loc := range report.Mismatches {
+		location := loc.File
+		if loc.Line > 0 {
+			location = fmt.Sprintf("%s:%d", loc.File, loc.Line)
+		}
+		_, _ = fmt.Fprintf(out, "  ✗ %s has %s (%s)\n", location, loc.Version, loc.Target)
+	}
+	for _, e := range report.Errors {
+		_, _ = fmt.Fprintf(out, "  ⚠️  %s: %s\n", e.File, e.Message)
+	}
+
+	if len(report.Mismatches) > 0 || len(report.Errors) > 0 {
+		return fmt.Errorf("version %s is inconsistent: %d mismatches, %d errors", version, len(report.Mismatches), len(report.Errors))
+	}
 
 	logger.Info("Version consistency check completed")
 	return nil
@@ -531,8 +560,9 @@ var versionPropagateCmd = &cobra.Command{
 	Short: "Propagate version to package manager files",
 	Long: `Propagate the version from VERSION file to configured package manager files.
 
-This command automatically updates version fields in package.json, pyproject.toml, and other
-supported package manager files according to the version policy.
+This command automatically updates version fields in package.json, pyproject.toml, Cargo.toml,
+Chart.yaml, pom.xml, Gradle builds, .csproj/Directory.Build.props and Dockerfile OCI labels
+according to the version policy.
 
 Examples:
   goneat version propagate                    # Propagate to all detected files
@@ -571,14 +601,7 @@ func runVersionPropagate(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to get current version: %w", err)
 	}
 
-	// Create registry and register managers
-	registry := propagation.NewRegistry()
-	registry.Register(managers.NewJavaScriptManager())
-	registry.Register(managers.NewPythonManager())
-	registry.Register(managers.NewGoManager())
-
-	// Create propagator
-	propagator := propagation.NewPropagator(registry)
+	propagator := newVersionPropagator()
 
 	// Prepare options
 	opts := propagation.PropagateOptions{
@@ -617,6 +640,21 @@ func runVersionPropagate(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// newVersionPropagator creates a propagator with all supported package managers registered
+func newVersionPropagator() *propagation.Propagator {
+	registry := propagation.NewRegistry()
+	registry.Register(managers.NewJavaScriptManager())
+	registry.Register(managers.NewPythonManager())
+	registry.Register(managers.NewGoManager())
+	registry.Register(managers.NewCargoManager())
+	registry.Register(managers.NewHelmManager())
+	registry.Register(managers.NewMavenManager())
+	registry.Register(managers.NewGradleManager())
+	registry.Register(managers.NewDotNetManager())
+	registry.Register(managers.NewDockerfileManager())
+	return propagation.NewPropagator(registry)
+}
+
 // Helper functions
 
 func readVersionFromFile(filename string) (string, error) {
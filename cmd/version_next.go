@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/logger"
+	"github.com/fulmenhq/goneat/pkg/propagation"
+	"github.com/fulmenhq/goneat/pkg/release"
+	"github.com/spf13/cobra"
+)
+
+// versionNextCmd represents the version next command
+var versionNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Infer the next version from Conventional Commits",
+	Long: `Walk the commits since the last release tag and infer the next version from
+Conventional Commits: a breaking change (` + "`!`" + ` or a BREAKING CHANGE footer) bumps
+major (minor before 1.0.0), feat bumps minor, fix and perf bump patch. Other
+commit types do not trigger a release.
+
+The version scheme comes from the version policy: semver, or calver as
+YYYY.MM.PATCH where PATCH resets when the month rolls over. --channel produces a
+prerelease (1.3.0-beta.2) and must be listed in rules.allowed_channels when the
+policy restricts channels.
+
+Without --apply the command only reports. --apply writes VERSION, --propagate
+also updates package manager files, and when the policy sets
+rules.require_release_tag (or with --tag) the changes are committed and an
+annotated release tag is created.
+
+Examples:
+  goneat version next                        # Show the inferred next version
+  goneat version next --channel beta --json
+  goneat version next --apply --propagate    # Write VERSION and package files`,
+	Args: cobra.NoArgs,
+	RunE: runVersionNext,
+}
+
+func init() {
+	versionCmd.AddCommand(versionNextCmd)
+	versionNextCmd.Flags().Bool("apply", false, "Write the next version to VERSION")
+	versionNextCmd.Flags().String("channel", "", "Release channel (stable or a prerelease channel such as beta; default: policy version.channel)")
+	versionNextCmd.Flags().Bool("propagate", false, "With --apply, propagate the new version to package manager files")
+	versionNextCmd.Flags().Bool("tag", false, "With --apply, commit the release and create an annotated tag even if the policy does not require one")
+	versionNextCmd.Flags().String("policy", "", "Path to version policy file (default: .goneat/version-policy.yaml)")
+	versionNextCmd.Flags().Bool("json", false, "Output in JSON format")
+}
+
+func runVersionNext(cmd *cobra.Command, _ []string) error {
+	apply, _ := cmd.Flags().GetBool("apply")
+	channel, _ := cmd.Flags().GetString("channel")
+	propagate, _ := cmd.Flags().GetBool("propagate")
+	tag, _ := cmd.Flags().GetBool("tag")
+	policyPath, _ := cmd.Flags().GetString("policy")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	policy, err := propagation.NewPolicyLoader().LoadPolicy(policyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load version policy: %w", err)
+	}
+	if channel == "" {
+		channel = policy.Version.Channel
+	}
+	if err := validateReleaseChannel(policy, channel); err != nil {
+		return err
+	}
+
+	current, _ := readVersionFromFile("VERSION")
+	history, err := release.OpenHistory(".")
+	if err != nil {
+		return err
+	}
+	plan, err := release.PlanNext(history, release.NextOptions{
+		Scheme:  policy.Version.Scheme,
+		Channel: channel,
+		Current: current,
+	})
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if jsonOutput {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		_, _ = fmt.Fprintln(out, string(data))
+	} else {
+		printReleasePlan(cmd, plan)
+	}
+	if !apply || plan.Next == "" {
+		return nil
+	}
+
+	// The release commit takes the whole index, so refuse before touching any
+	// file if something else is already staged.
+	commitRelease := tag || policy.Rules.RequireReleaseTag
+	if commitRelease {
+		if err := history.CheckStaged(nil); err != nil {
+			return err
+		}
+	}
+
+	// Propagation guards (clean worktree, branch) run before VERSION changes.
+	files := []string{"VERSION"}
+	if propagate {
+		result, err := newVersionPropagator().Propagate(cmd.Context(), plan.Next, propagation.PropagateOptions{PolicyPath: policyPath})
+		if err != nil {
+			return fmt.Errorf("propagation failed: %w", err)
+		}
+		if len(result.Errors) > 0 {
+			for _, perr := range result.Errors {
+				_, _ = fmt.Fprintf(out, "  • %s: %s\n", perr.File, perr.Message)
+			}
+			return fmt.Errorf("propagation completed with %d errors", len(result.Errors))
+		}
+		for _, change := range result.Changes {
+			files = append(files, change.File)
+		}
+	}
+
+	// Keep the VERSION file's existing "v" prefix convention.
+	newVersion := plan.Next
+	if strings.HasPrefix(current, "v") {
+		newVersion = "v" + newVersion
+	}
+	if err := writeVersionToFile("VERSION", newVersion); err != nil {
+		return fmt.Errorf("failed to write new version: %w", err)
+	}
+	logger.Info(fmt.Sprintf("Set version to %s (%s bump)", newVersion, plan.Bump))
+
+	if commitRelease {
+		if _, err := history.CommitRelease(files, plan.TagName); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "Created release commit and tag %s\n", plan.TagName)
+	}
+	return nil
+}
+
+// validateReleaseChannel checks a requested channel against the version policy
+func validateReleaseChannel(policy *propagation.VersionPolicy, channel string) error {
+	if channel == "" {
+		channel = release.StableChannel
+	}
+	if len(policy.Rules.AllowedChannels) > 0 {
+		allowed := false
+		for _, c := range policy.Rules.AllowedChannels {
+			if c == channel {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("channel %s is not in rules.allowed_channels %v", channel, policy.Rules.AllowedChannels)
+		}
+	}
+	if channel != release.StableChannel && !policy.Version.AllowExtended {
+		return fmt.Errorf("prerelease channel %s requires version.allow_extended", channel)
+	}
+	return nil
+}
+
+func printReleasePlan(cmd *cobra.Command, plan *release.Plan) {
+	out := cmd.OutOrStdout()
+	base := plan.BaseTag
+	if base == "" {
+		base = "no release tag"
+	}
+	_, _ = fmt.Fprintf(out, "Current: %s (%s)\n", nonEmpty(plan.Current, "none"), base)
+
+	counts := map[string]int{}
+	breaking := 0
+	for _, c := range plan.Commits {
+		if c.Breaking {
+			breaking++
+		}
+		if c.Conventional() {
+			counts[c.Type]++
+		} else {
+			counts["other"]++
+		}
+	}
+	var parts []string
+	for _, t := range []string{"feat", "fix", "perf"} {
+		if counts[t] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", t, counts[t]))
+		}
+	}
+	if breaking > 0 {
+		parts = append(parts, fmt.Sprintf("breaking %d", breaking))
+	}
+	summary := ""
+	if len(parts) > 0 {
+		summary = " (" + strings.Join(parts, ", ") + ")"
+	}
+	_, _ = fmt.Fprintf(out, "Commits: %d%s\n", len(plan.Commits), summary)
+	_, _ = fmt.Fprintf(out, "Bump: %s\n", plan.Bump)
+	if plan.Next == "" {
+		_, _ = fmt.Fprintln(out, "Next: no release needed")
+		return
+	}
+	_, _ = fmt.Fprintf(out, "Next: %s (channel %s)\n", plan.Next, plan.Channel)
+}
@@ -7,7 +7,9 @@ import (
 	"strings"
 	"testing"
 
+	git "github.com/go-git/go-git/v5"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // TestVersionValidateCmd tests the version validate command
@@ -694,3 +696,100 @@ func TestVersionCmdPathTraversalProtection(t *testing.T) {
 		t.Error("readVersionFromFile should reject path traversal attempts")
 	}
 }
+
+// TestVersionNextCmd tests commit-driven version inference and --apply with release tagging
+func TestVersionNextCmd(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working dir: %v", err)
+	}
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldWd) }()
+
+	repo, err := git.PlainInit(tempDir, false)
+	if err != nil {
+		t.Fatalf("git init: %v", err)
+	}
+	cfg, _ := repo.Config()
+	cfg.User.Name, cfg.User.Email = "goneat", "ci@goneat.dev"
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatalf("set config: %v", err)
+	}
+	wt, _ := repo.Worktree()
+	commit := func(message string) {
+		if _, err := wt.Add("."); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+		if _, err := wt.Commit(message, &git.CommitOptions{}); err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+	}
+	if err := writeVersionToFile("VERSION", "v1.4.0"); err != nil {
+		t.Fatalf("write VERSION: %v", err)
+	}
+	if err := os.MkdirAll(".goneat", 0o750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	policy := "version:\n  scheme: semver\n  allow_extended: true\npropagation:\n  defaults:\n    include: []\n    exclude: []\nrules:\n  require_release_tag: true\n  allowed_channels: [stable, beta]\n"
+	if err := os.WriteFile(".goneat/version-policy.yaml", []byte(policy), 0o600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+	commit("chore: initial")
+	head, _ := repo.Head()
+	if _, err := repo.CreateTag("v1.4.0", head.Hash(), nil); err != nil {
+		t.Fatalf("tag: %v", err)
+	}
+	if err := os.WriteFile("main.go", []byte("package main\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	commit("feat(cli): add flag")
+
+	run := func(flags map[string]string) (string, error) {
+		for name, value := range flags {
+			if err := versionNextCmd.Flags().Set(name, value); err != nil {
+				t.Fatalf("set %s: %v", name, err)
+			}
+		}
+		defer func() {
+			versionNextCmd.Flags().VisitAll(func(f *pflag.Flag) {
+				_ = f.Value.Set(f.DefValue)
+				f.Changed = false
+			})
+		}()
+		var buf bytes.Buffer
+		versionNextCmd.SetOut(&buf)
+		err := runVersionNext(versionNextCmd, nil)
+		return buf.String(), err
+	}
+
+	out, err := run(nil)
+	if err != nil {
+		t.Fatalf("version next: %v", err)
+	}
+	if !strings.Contains(out, "Bump: minor") || !strings.Contains(out, "Next: 1.5.0 (channel stable)") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	if _, err := run(map[string]string{"channel": "nightly"}); err == nil || !strings.Contains(err.Error(), "allowed_channels") {
+		t.Errorf("expected channel error, got %v", err)
+	}
+
+	if _, err := run(map[string]string{"apply": "true"}); err != nil {
+		t.Fatalf("version next --apply: %v", err)
+	}
+	if v, _ := readVersionFromFile("VERSION"); v != "v1.5.0" {
+		t.Errorf("VERSION = %s, want v1.5.0", v)
+	}
+	ref, err := repo.Tag("v1.5.0")
+	if err != nil {
+		t.Fatalf("expected release tag: %v", err)
+	}
+	if _, err := repo.TagObject(ref.Hash()); err != nil {
+		t.Errorf("expected annotated tag: %v", err)
+	}
+	if status, _ := wt.Status(); !status.IsClean() {
+		t.Errorf("expected clean worktree after release commit, got %v", status)
+	}
+}
@@ -0,0 +1,361 @@
+package assess
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// coverageFormat identifies a coverage report format.
+type coverageFormat string
+
+const (
+	coverageFormatGo        coverageFormat = "go"
+	coverageFormatLCOV      coverageFormat = "lcov"
+	coverageFormatCobertura coverageFormat = "cobertura"
+)
+
+// fileCoverage is the normalized coverage of a single source file. Go
+// profiles count statements; LCOV and Cobertura count lines.
+type fileCoverage struct {
+	Covered int
+	Total   int
+	// Lines maps each coverable line to whether any execution covered it.
+	Lines map[int]bool
+}
+
+// coverageSet accumulates coverage from one or more reports, keyed by
+// target-relative slash paths.
+type coverageSet struct {
+	files    map[string]*fileCoverage
+	goBlocks map[goCoverBlock]int
+	sources  []string
+}
+
+type goCoverBlock struct {
+	File      string
+	StartLine int
+	StartCol  int
+	EndLine   int
+	EndCol    int
+	NumStmts  int
+}
+
+func newCoverageSet() *coverageSet {
+	return &coverageSet{
+		files:    make(map[string]*fileCoverage),
+		goBlocks: make(map[goCoverBlock]int),
+	}
+}
+
+func (s *coverageSet) file(path string) *fileCoverage {
+	fc, ok := s.files[path]
+	if !ok {
+		fc = &fileCoverage{Lines: make(map[int]bool)}
+		s.files[path] = fc
+	}
+	return fc
+}
+
+// addLine records a line-based hit count, merging with earlier reports.
+func (s *coverageSet) addLine(path string, line int, hits int64) {
+	fc := s.file(path)
+	fc.Lines[line] = fc.Lines[line] || hits > 0
+}
+
+// finalize converts accumulated Go blocks into statement counts and derives
+// line-based totals for files that only came from LCOV or Cobertura. Blank,
+// comment and brace-only lines inside a Go block are not coverable.
+func (s *coverageSet) finalize(resolver coveragePathResolver) {
+	stmtFiles := make(map[string]bool)
+	skipLines := make(map[string]map[int]bool)
+	for block, count := range s.goBlocks {
+		fc := s.file(block.File)
+		if !stmtFiles[block.File] {
+			stmtFiles[block.File] = true
+			skipLines[block.File] = nonExecutableGoLines(filepath.Join(resolver.targetAbs, filepath.FromSlash(block.File)))
+		}
+		fc.Total += block.NumStmts
+		if count > 0 {
+			fc.Covered += block.NumStmts
+		}
+		skip := skipLines[block.File]
+		for l := block.StartLine; l <= block.EndLine; l++ {
+			if skip[l] {
+				continue
+			}
+			fc.Lines[l] = fc.Lines[l] || count > 0
+		}
+	}
+	for path, fc := range s.files {
+		if stmtFiles[path] {
+			continue
+		}
+		fc.Total, fc.Covered = len(fc.Lines), 0
+		for _, covered := range fc.Lines {
+			if covered {
+				fc.Covered++
+			}
+		}
+	}
+	s.goBlocks = make(map[goCoverBlock]int)
+}
+
+// nonExecutableGoLines returns the blank, comment-only and closing-brace lines
+// of a Go source file. An unreadable file yields no lines, so every line of
+// its blocks stays coverable.
+func nonExecutableGoLines(path string) map[int]bool {
+	data, err := os.ReadFile(path) // #nosec G304 - source file named by a cover profile under the target
+	if err != nil {
+		return nil
+	}
+	skip := make(map[int]bool)
+	inComment := false
+	for i, line := range strings.Split(string(data), "\n") {
+		code := strings.TrimSpace(line)
+		for {
+			if inComment {
+				end := strings.Index(code, "*/")
+				if end < 0 {
+					code = ""
+					break
+				}
+				code, inComment = strings.TrimSpace(code[end+2:]), false
+				continue
+			}
+			if strings.HasPrefix(code, "/*") {
+				code, inComment = code[2:], true
+				continue
+			}
+			break
+		}
+		if code == "" || strings.HasPrefix(code, "//") || strings.Trim(code, "}),;") == "" {
+			skip[i+1] = true
+		}
+	}
+	return skip
+}
+
+// coveragePathResolver maps report paths to target-relative slash paths.
+type coveragePathResolver struct {
+	targetAbs  string
+	modulePath string
+}
+
+func newCoveragePathResolver(target string) coveragePathResolver {
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		abs = target
+	}
+	return coveragePathResolver{targetAbs: abs, modulePath: readModulePath(filepath.Join(abs, "go.mod"))}
+}
+
+// goFile resolves an import-path style file from a Go cover profile.
+func (r coveragePathResolver) goFile(name string) string {
+	if r.modulePath != "" && strings.HasPrefix(name, r.modulePath+"/") {
+		return strings.TrimPrefix(name, r.modulePath+"/")
+	}
+	return r.relative(name)
+}
+
+// reportFile resolves a file named in an LCOV or Cobertura report. Relative
+// names are tried against the declared source roots, the report's directory
+// and its parent (tools typically write reports to <project>/coverage/).
+func (r coveragePathResolver) reportFile(name, reportDir string, sourceRoots []string) string {
+	if filepath.IsAbs(name) {
+		return r.relative(name)
+	}
+	candidates := make([]string, 0, len(sourceRoots)+3)
+	for _, root := range sourceRoots {
+		if !filepath.IsAbs(root) {
+			root = filepath.Join(r.targetAbs, root)
+		}
+		candidates = append(candidates, filepath.Join(root, name))
+	}
+	candidates = append(candidates, filepath.Join(r.targetAbs, name), filepath.Join(reportDir, name), filepath.Join(filepath.Dir(reportDir), name))
+	for _, c := range candidates {
+		if _, err := os.Stat(c); err == nil {
+			return r.relative(c)
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(name))
+}
+
+func (r coveragePathResolver) relative(path string) string {
+	if filepath.IsAbs(path) {
+		if rel, err := filepath.Rel(r.targetAbs, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+func readModulePath(goModPath string) string {
+	data, err := os.ReadFile(goModPath) // #nosec G304 - go.mod at the assessment target
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module")), `"`)
+		}
+	}
+	return ""
+}
+
+// detectCoverageFormat sniffs the report format from its contents.
+func detectCoverageFormat(data []byte) (coverageFormat, error) {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("mode:")):
+		return coverageFormatGo, nil
+	case bytes.HasPrefix(trimmed, []byte("<")) && bytes.Contains(trimmed, []byte("<coverage")):
+		return coverageFormatCobertura, nil
+	case bytes.HasPrefix(trimmed, []byte("TN:")) || bytes.HasPrefix(trimmed, []byte("SF:")) || bytes.Contains(trimmed, []byte("\nSF:")):
+		return coverageFormatLCOV, nil
+	}
+	return "", fmt.Errorf("unrecognized coverage format (expected Go cover profile, LCOV, or Cobertura XML)")
+}
+
+// loadCoverageReport parses a coverage report into the set and records it
+// under source, or under its path and format when source is empty.
+func (s *coverageSet) loadCoverageReport(path, source string, resolver coveragePathResolver) error {
+	data, err := os.ReadFile(path) // #nosec G304 - report paths come from repo-owned assess.yaml
+	if err != nil {
+		return err
+	}
+	format, err := detectCoverageFormat(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	reportDir := filepath.Dir(path)
+	if abs, err := filepath.Abs(reportDir); err == nil {
+		reportDir = abs
+	}
+	switch format {
+	case coverageFormatGo:
+		err = s.parseGoCoverProfile(data, resolver)
+	case coverageFormatLCOV:
+		err = s.parseLCOV(data, resolver, reportDir)
+	case coverageFormatCobertura:
+		err = s.parseCobertura(data, resolver, reportDir)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if source == "" {
+		source = fmt.Sprintf("%s (%s)", resolver.relative(path), format)
+	}
+	s.sources = append(s.sources, source)
+	return nil
+}
+
+// parseGoCoverProfile reads `go test -coverprofile` output. Blocks repeated
+// across profiles (e.g. with -coverpkg) are merged by taking the highest count.
+func (s *coverageSet) parseGoCoverProfile(data []byte, resolver coveragePathResolver) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		// file.go:startLine.startCol,endLine.endCol numStmts count
+		colon := strings.LastIndex(line, ":")
+		if colon < 0 {
+			return fmt.Errorf("line %d: malformed cover profile entry", lineNo)
+		}
+		var block goCoverBlock
+		var count int
+		if _, err := fmt.Sscanf(line[colon+1:], "%d.%d,%d.%d %d %d", &block.StartLine, &block.StartCol, &block.EndLine, &block.EndCol, &block.NumStmts, &count); err != nil {
+			return fmt.Errorf("line %d: malformed cover profile entry: %w", lineNo, err)
+		}
+		block.File = resolver.goFile(line[:colon])
+		if prev, ok := s.goBlocks[block]; !ok || count > prev {
+			s.goBlocks[block] = count
+		}
+	}
+	return scanner.Err()
+}
+
+// parseLCOV reads SF/DA records from an LCOV tracefile.
+func (s *coverageSet) parseLCOV(data []byte, resolver coveragePathResolver, reportDir string) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	current := ""
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			current = resolver.reportFile(strings.TrimPrefix(line, "SF:"), reportDir, nil)
+		case strings.HasPrefix(line, "DA:") && current != "":
+			parts := strings.Split(strings.TrimPrefix(line, "DA:"), ",")
+			if len(parts) < 2 {
+				continue
+			}
+			lineNum, err1 := strconv.Atoi(parts[0])
+			hits, err2 := strconv.ParseInt(parts[1], 10, 64)
+			if err1 != nil || err2 != nil {
+				continue
+			}
+			s.addLine(current, lineNum, hits)
+		case line == "end_of_record":
+			current = ""
+		}
+	}
+	return scanner.Err()
+}
+
+type coberturaReport struct {
+	Sources  []string `xml:"sources>source"`
+	Packages []struct {
+		Classes []struct {
+			Filename string `xml:"filename,attr"`
+			Lines    []struct {
+				Number int    `xml:"number,attr"`
+				Hits   string `xml:"hits,attr"`
+			} `xml:"lines>line"`
+		} `xml:"classes>class"`
+	} `xml:"packages>package"`
+}
+
+// parseCobertura reads class line hits from a Cobertura XML report (coverage.py, jest, ...).
+func (s *coverageSet) parseCobertura(data []byte, resolver coveragePathResolver, reportDir string) error {
+	var report coberturaReport
+	if err := xml.Unmarshal(data, &report); err != nil {
+		return fmt.Errorf("invalid Cobertura XML: %w", err)
+	}
+	for _, pkg := range report.Packages {
+		for _, class := range pkg.Classes {
+			file := resolver.reportFile(class.Filename, reportDir, report.Sources)
+			for _, l := range class.Lines {
+				// coverage.py writes integer hits; some tools write floats
+				hits, err := strconv.ParseFloat(strings.TrimSpace(l.Hits), 64)
+				if err != nil {
+					continue
+				}
+				s.addLine(file, l.Number, int64(hits))
+			}
+		}
+	}
+	return nil
+}
+
+// sortedFiles returns the set's file paths in sorted order.
+func (s *coverageSet) sortedFiles() []string {
+	files := make([]string, 0, len(s.files))
+	for f := range s.files {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,387 @@
+package assess
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/fulmenhq/goneat/internal/gitctx"
+	"github.com/fulmenhq/goneat/pkg/logger"
+)
+
+// coverageOverrides configures the coverage category in .goneat/assess.yaml.
+type coverageOverrides struct {
+	Enabled      *bool              `yaml:"enabled"`
+	Go           *goCoverageConfig  `yaml:"go"`
+	Reports      []string           `yaml:"reports"`
+	Threshold    float64            `yaml:"threshold"`
+	Minimums     map[string]float64 `yaml:"minimums"`
+	ChangedLines bool               `yaml:"changed_lines"`
+	Exclude      []string           `yaml:"exclude"`
+}
+
+type goCoverageConfig struct {
+	Enabled  *bool    `yaml:"enabled"`
+	Packages []string `yaml:"packages"`
+}
+
+// CoverageAssessmentRunner enforces test coverage thresholds from Go cover
+// profiles and LCOV/Cobertura reports produced by other toolchains.
+type CoverageAssessmentRunner struct {
+	commandName  string
+	runGo        goCommandFunc
+	changedLines func(target string) map[string][]int
+}
+
+// NewCoverageAssessmentRunner creates a new coverage assessment runner
+func NewCoverageAssessmentRunner() *CoverageAssessmentRunner {
+	return &CoverageAssessmentRunner{
+		commandName:  "coverage",
+		runGo:        runGoCommand,
+		changedLines: collectChangedLines,
+	}
+}
+
+// Assess implements AssessmentRunner.Assess
+func (r *CoverageAssessmentRunner) Assess(ctx context.Context, target string, config AssessmentConfig) (*AssessmentResult, error) {
+	startTime := time.Now()
+
+	overrides := loadAssessOverrides(target)
+	if overrides == nil || overrides.Coverage == nil {
+		return r.skippedResult(startTime, "coverage not configured in assess.yaml"), nil
+	}
+	cov := overrides.Coverage
+	if !boolWithDefault(cov.Enabled, true) {
+		return r.skippedResult(startTime, "coverage disabled via assess.yaml"), nil
+	}
+
+	resolver := newCoveragePathResolver(target)
+	set := newCoverageSet()
+
+	if r.shouldRunGo(target, cov) {
+		profile, err := r.runGoCoverage(ctx, target, cov)
+		if err != nil {
+			return r.errorResult(startTime, err.Error()), nil
+		}
+		defer func() { _ = os.Remove(profile) }()
+		if err := set.loadCoverageReport(profile, "go test -coverprofile", resolver); err != nil {
+			return r.errorResult(startTime, err.Error()), nil
+		}
+	}
+
+	for _, report := range cov.Reports {
+		reportPath := report
+		if !filepath.IsAbs(reportPath) {
+			reportPath = filepath.Join(target, reportPath)
+		}
+		if err := set.loadCoverageReport(reportPath, "", resolver); err != nil {
+			if os.IsNotExist(err) {
+				logger.Warn(fmt.Sprintf("coverage report %s not found; skipping", report))
+				continue
+			}
+			return r.errorResult(startTime, fmt.Sprintf("failed to read coverage report: %v", err)), nil
+		}
+	}
+
+	if len(set.sources) == 0 {
+		return r.skippedResult(startTime, "no coverage sources (no go.mod and no reports found)"), nil
+	}
+
+	set.finalize(resolver)
+	for _, file := range set.sortedFiles() {
+		if isCoverageExcluded(file, cov.Exclude) {
+			delete(set.files, file)
+		}
+	}
+
+	var issues []Issue
+	covered, total := set.totals(func(string) bool { return true })
+	totalPct := coveragePercent(covered, total)
+	if cov.Threshold > 0 && totalPct < cov.Threshold {
+		issues = append(issues, Issue{
+			File:        ".",
+			Severity:    SeverityHigh,
+			Message:     fmt.Sprintf("Total coverage %.1f%% is below the %.1f%% threshold", totalPct, cov.Threshold),
+			Category:    CategoryCoverage,
+			SubCategory: "threshold",
+		})
+	}
+
+	patterns := make([]string, 0, len(cov.Minimums))
+	for pattern := range cov.Minimums {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	minimumResults := make(map[string]float64, len(patterns))
+	for _, pattern := range patterns {
+		minimum := cov.Minimums[pattern]
+		c, t := set.totals(func(file string) bool { return matchCoverageDir(pattern, path.Dir(file)) })
+		if t == 0 {
+			logger.Warn(fmt.Sprintf("coverage minimum for %q matched no covered files", pattern))
+			continue
+		}
+		pct := coveragePercent(c, t)
+		minimumResults[pattern] = pct
+		if pct < minimum {
+			issues = append(issues, Issue{
+				File:        strings.TrimSuffix(strings.TrimSuffix(pattern, "..."), "/"),
+				Severity:    SeverityMedium,
+				Message:     fmt.Sprintf("Coverage for %s is %.1f%%, below the %.1f%% minimum", pattern, pct, minimum),
+				Category:    CategoryCoverage,
+				SubCategory: "minimum",
+			})
+		}
+	}
+
+	uncoveredChanged := 0
+	changedChecked := 0
+	if cov.ChangedLines {
+		changed := r.changedLines(target)
+		for _, file := range sortedKeys(changed) {
+			fc, ok := set.files[filepath.ToSlash(file)]
+			if !ok {
+				continue
+			}
+			var uncovered []int
+			for _, line := range changed[file] {
+				covered, coverable := fc.Lines[line]
+				if !coverable {
+					continue
+				}
+				changedChecked++
+				if !covered {
+					uncovered = append(uncovered, line)
+				}
+			}
+			uncoveredChanged += len(uncovered)
+			issues = append(issues, uncoveredLineIssues(filepath.ToSlash(file), uncovered)...)
+		}
+	}
+
+	logger.Info(fmt.Sprintf("coverage completed: %.1f%% total across %d files, %d issues", totalPct, len(set.files), len(issues)))
+	return &AssessmentResult{
+		CommandName:   r.commandName,
+		Category:      CategoryCoverage,
+		Success:       true,
+		Issues:        issues,
+		ExecutionTime: HumanReadableDuration(time.Since(startTime)),
+		Metrics: map[string]interface{}{
+			"total_percent":           roundPercent(totalPct),
+			"covered":                 covered,
+			"coverable":               total,
+			"files":                   len(set.files),
+			"sources":                 set.sources,
+			"directories":             set.directoryPercents(),
+			"minimums":                minimumResults,
+			"changed_lines_checked":   changedChecked,
+			"uncovered_changed_lines": uncoveredChanged,
+		},
+	}, nil
+}
+
+// CanRunInParallel implements AssessmentRunner.CanRunInParallel
+func (r *CoverageAssessmentRunner) CanRunInParallel() bool {
+	return true
+}
+
+// GetCategory implements AssessmentRunner.GetCategory
+func (r *CoverageAssessmentRunner) GetCategory() AssessmentCategory {
+	return CategoryCoverage
+}
+
+// GetEstimatedTime implements AssessmentRunner.GetEstimatedTime
+func (r *CoverageAssessmentRunner) GetEstimatedTime(target string) time.Duration {
+	return 2 * time.Minute
+}
+
+// IsAvailable implements AssessmentRunner.IsAvailable
+func (r *CoverageAssessmentRunner) IsAvailable() bool {
+	// Reports from other toolchains can be consumed without go installed
+	return true
+}
+
+// shouldRunGo reports whether go test -coverprofile should run: by default
+// whenever the target is a Go module.
+func (r *CoverageAssessmentRunner) shouldRunGo(target string, cov *coverageOverrides) bool {
+	if cov.Go != nil && cov.Go.Enabled != nil {
+		return *cov.Go.Enabled
+	}
+	_, err := os.Stat(filepath.Join(target, "go.mod"))
+	return err == nil
+}
+
+func (r *CoverageAssessmentRunner) runGoCoverage(ctx context.Context, target string, cov *coverageOverrides) (string, error) {
+	tmp, err := os.CreateTemp("", "goneat-coverage-*.out")
+	if err != nil {
+		return "", fmt.Errorf("failed to create coverage profile: %w", err)
+	}
+	profile := tmp.Name()
+	_ = tmp.Close()
+
+	packages := []string{"./..."}
+	if cov.Go != nil && len(cov.Go.Packages) > 0 {
+		packages = cov.Go.Packages
+	}
+	args := append([]string{"test", "-coverprofile=" + profile}, packages...)
+	logger.Info(fmt.Sprintf("Running coverage: go %s", strings.Join(args, " ")))
+	out, err := r.runGo(ctx, target, args)
+	if err != nil {
+		_ = os.Remove(profile)
+		return "", fmt.Errorf("go test -coverprofile failed: %v\n%s", err, tailLines(string(out), 40))
+	}
+	return profile, nil
+}
+
+func (r *CoverageAssessmentRunner) skippedResult(start time.Time, reason string) *AssessmentResult {
+	return &AssessmentResult{
+		CommandName:   r.commandName,
+		Category:      CategoryCoverage,
+		Success:       true,
+		ExecutionTime: HumanReadableDuration(time.Since(start)),
+		Issues:        []Issue{},
+		Metrics: map[string]interface{}{
+			"status": "skipped",
+			"reason": reason,
+		},
+	}
+}
+
+func (r *CoverageAssessmentRunner) errorResult(start time.Time, msg string) *AssessmentResult {
+	return &AssessmentResult{
+		CommandName:   r.commandName,
+		Category:      CategoryCoverage,
+		Success:       false,
+		Issues:        []Issue{},
+		Metrics:       map[string]interface{}{},
+		ExecutionTime: HumanReadableDuration(time.Since(start)),
+		Error:         msg,
+	}
+}
+
+// totals sums covered and coverable units over files accepted by keep.
+func (s *coverageSet) totals(keep func(file string) bool) (int, int) {
+	covered, total := 0, 0
+	for file, fc := range s.files {
+		if keep(file) {
+			covered += fc.Covered
+			total += fc.Total
+		}
+	}
+	return covered, total
+}
+
+// directoryPercents reports coverage per source directory (Go package).
+func (s *coverageSet) directoryPercents() map[string]float64 {
+	type counts struct{ covered, total int }
+	dirs := make(map[string]*counts)
+	for file, fc := range s.files {
+		dir := path.Dir(file)
+		if dirs[dir] == nil {
+			dirs[dir] = &counts{}
+		}
+		dirs[dir].covered += fc.Covered
+		dirs[dir].total += fc.Total
+	}
+	out := make(map[string]float64, len(dirs))
+	for dir, c := range dirs {
+		out[dir] = roundPercent(coveragePercent(c.covered, c.total))
+	}
+	return out
+}
+
+// matchCoverageDir matches a directory against a minimum pattern: "pkg/parser"
+// matches that directory only, "pkg/..." matches it and everything below.
+func matchCoverageDir(pattern, dir string) bool {
+	pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+	if pattern == "..." || (pattern == "." && dir == ".") {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+		return dir == prefix || strings.HasPrefix(dir, prefix+"/")
+	}
+	return dir == strings.TrimSuffix(pattern, "/")
+}
+
+func isCoverageExcluded(file string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := doublestar.Match(pattern, file); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// uncoveredLineIssues groups consecutive uncovered lines into one issue per range.
+func uncoveredLineIssues(file string, lines []int) []Issue {
+	sort.Ints(lines)
+	var issues []Issue
+	for i := 0; i < len(lines); {
+		j := i
+		for j+1 < len(lines) && lines[j+1] == lines[j]+1 {
+			j++
+		}
+		msg := fmt.Sprintf("Changed line %d is not covered by tests", lines[i])
+		if j > i {
+			msg = fmt.Sprintf("Changed lines %d-%d are not covered by tests", lines[i], lines[j])
+		}
+		issues = append(issues, Issue{
+			File:          file,
+			Line:          lines[i],
+			Severity:      SeverityMedium,
+			Message:       msg,
+			Category:      CategoryCoverage,
+			SubCategory:   "changed_lines",
+			ChangeRelated: true,
+			LinesModified: append([]int(nil), lines[i:j+1]...),
+		})
+		i = j + 1
+	}
+	return issues
+}
+
+func collectChangedLines(target string) map[string][]int {
+	_, _, lines, err := gitctx.CollectWithLines(target)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("changed-line coverage skipped: %v", err))
+		return nil
+	}
+	return lines
+}
+
+func coveragePercent(covered, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(covered) / float64(total) * 100
+}
+
+func roundPercent(v float64) float64 {
+	return float64(int(v*10+0.5)) / 10
+}
+
+func sortedKeys(m map[string][]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func init() {
+	RegisterAssessmentRunner(CategoryCoverage, NewCoverageAssessmentRunner())
+}
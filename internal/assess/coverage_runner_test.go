@@ -0,0 +1,231 @@
+package assess
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleGoProfile = `mode: set
+example.com/demo/parser/parse.go:3.20,5.2 2 1
+example.com/demo/parser/parse.go:7.20,9.2 2 0
+example.com/demo/util/util.go:3.15,4.2 1 1
+`
+
+const sampleLCOV = `TN:
+SF:src/app.ts
+DA:1,1
+DA:2,0
+DA:3,4
+end_of_record
+`
+
+const sampleCobertura = `<?xml version="1.0" ?>
+<coverage version="7.4.0">
+  <sources><source>.</source></sources>
+  <packages>
+    <package name="pkg">
+      <classes>
+        <class name="mod.py" filename="py/mod.py">
+          <lines>
+            <line number="1" hits="1"/>
+            <line number="2" hits="0"/>
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>
+`
+
+func TestDetectCoverageFormat(t *testing.T) {
+	cases := map[string]coverageFormat{
+		sampleGoProfile: coverageFormatGo,
+		sampleLCOV:      coverageFormatLCOV,
+		sampleCobertura: coverageFormatCobertura,
+	}
+	for input, want := range cases {
+		got, err := detectCoverageFormat([]byte(input))
+		if err != nil || got != want {
+			t.Fatalf("detectCoverageFormat() = %q, %v; want %q", got, err, want)
+		}
+	}
+	if _, err := detectCoverageFormat([]byte("not coverage")); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
+
+func TestCoverageSet_MergesFormats(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "go.mod"), "module example.com/demo\n")
+	writeTestFile(t, filepath.Join(dir, "cover.out"), sampleGoProfile)
+	writeTestFile(t, filepath.Join(dir, "web", "coverage", "lcov.info"), sampleLCOV)
+	writeTestFile(t, filepath.Join(dir, "web", "src", "app.ts"), "")
+	writeTestFile(t, filepath.Join(dir, "coverage.xml"), sampleCobertura)
+
+	resolver := newCoveragePathResolver(dir)
+	set := newCoverageSet()
+	for _, report := range []string{"cover.out", "web/coverage/lcov.info", "coverage.xml"} {
+		if err := set.loadCoverageReport(filepath.Join(dir, report), "", resolver); err != nil {
+			t.Fatalf("load %s: %v", report, err)
+		}
+	}
+	set.finalize(resolver)
+
+	parse := set.files["parser/parse.go"]
+	if parse == nil || parse.Covered != 2 || parse.Total != 4 {
+		t.Fatalf("unexpected Go statement coverage: %+v", parse)
+	}
+	if !parse.Lines[4] || parse.Lines[8] {
+		t.Fatalf("unexpected Go line coverage: %v", parse.Lines)
+	}
+	// LCOV paths resolve relative to the project that owns the report directory
+	app := set.files["web/src/app.ts"]
+	if app == nil || app.Covered != 2 || app.Total != 3 {
+		t.Fatalf("unexpected LCOV coverage: %+v (files %v)", app, set.sortedFiles())
+	}
+	mod := set.files["py/mod.py"]
+	if mod == nil || mod.Covered != 1 || mod.Total != 2 {
+		t.Fatalf("unexpected Cobertura coverage: %+v", mod)
+	}
+}
+
+func TestCoverageSet_GoBlocksSkipNonExecutableLines(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "go.mod"), "module example.com/demo\n")
+	writeTestFile(t, filepath.Join(dir, "parser", "parse.go"), `package parser
+
+func A() int {
+	return 1
+}
+
+func B() int {
+	// not executable
+
+	/* nor
+	   this */
+	return 2
+}
+`)
+	writeTestFile(t, filepath.Join(dir, "cover.out"), `mode: set
+example.com/demo/parser/parse.go:3.14,5.2 1 1
+example.com/demo/parser/parse.go:7.14,13.2 1 0
+`)
+
+	resolver := newCoveragePathResolver(dir)
+	set := newCoverageSet()
+	if err := set.loadCoverageReport(filepath.Join(dir, "cover.out"), "go test -coverprofile", resolver); err != nil {
+		t.Fatal(err)
+	}
+	set.finalize(resolver)
+
+	lines := set.files["parser/parse.go"].Lines
+	for _, l := range []int{5, 8, 9, 10, 11, 13} {
+		if _, ok := lines[l]; ok {
+			t.Errorf("line %d should not be coverable: %v", l, lines)
+		}
+	}
+	if covered, ok := lines[12]; !ok || covered || !lines[4] {
+		t.Fatalf("unexpected statement lines: %v", lines)
+	}
+	if len(set.sources) != 1 || set.sources[0] != "go test -coverprofile" {
+		t.Fatalf("unexpected sources: %v", set.sources)
+	}
+}
+
+func TestMatchCoverageDir(t *testing.T) {
+	cases := []struct {
+		pattern, dir string
+		want         bool
+	}{
+		{"pkg/parser", "pkg/parser", true},
+		{"pkg/parser", "pkg/parser/sub", false},
+		{"pkg/...", "pkg/parser/sub", true},
+		{"./pkg/...", "pkg", true},
+		{"pkg/...", "pkgx", false},
+		{"...", "anything", true},
+	}
+	for _, tc := range cases {
+		if got := matchCoverageDir(tc.pattern, tc.dir); got != tc.want {
+			t.Errorf("matchCoverageDir(%q, %q) = %v, want %v", tc.pattern, tc.dir, got, tc.want)
+		}
+	}
+}
+
+func TestUncoveredLineIssues_GroupsRanges(t *testing.T) {
+	issues := uncoveredLineIssues("a.go", []int{12, 10, 11, 20})
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 grouped issues, got %d", len(issues))
+	}
+	if issues[0].Line != 10 || !strings.Contains(issues[0].Message, "10-12") || len(issues[0].LinesModified) != 3 {
+		t.Fatalf("unexpected first issue: %+v", issues[0])
+	}
+	if issues[1].Line != 20 || !issues[1].ChangeRelated {
+		t.Fatalf("unexpected second issue: %+v", issues[1])
+	}
+}
+
+func TestCoverageRunner_ThresholdsAndChangedLines(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "go.mod"), "module example.com/demo\n")
+	writeTestFile(t, filepath.Join(dir, ".goneat", "assess.yaml"), `version: 1
+coverage:
+  threshold: 80
+  minimums:
+    parser: 75
+    util: 50
+  changed_lines: true
+  exclude: ["**/*_gen.go"]
+`)
+
+	runner := NewCoverageAssessmentRunner()
+	var goArgs []string
+	runner.runGo = func(_ context.Context, _ string, args []string) ([]byte, error) {
+		goArgs = args
+		profile := strings.TrimPrefix(args[1], "-coverprofile=")
+		return nil, os.WriteFile(profile, []byte(sampleGoProfile+"example.com/demo/parser/zz_gen.go:1.1,2.2 5 0\n"), 0o600)
+	}
+	runner.changedLines = func(string) map[string][]int {
+		return map[string][]int{
+			"parser/parse.go": {1, 4, 8, 9},
+			"README.md":       {3},
+		}
+	}
+
+	result, err := runner.Assess(context.Background(), dir, DefaultAssessmentConfig())
+	if err != nil || result.Error != "" {
+		t.Fatalf("Assess failed: %v %s", err, result.Error)
+	}
+	if goArgs[0] != "test" || goArgs[len(goArgs)-1] != "./..." {
+		t.Fatalf("unexpected go args: %v", goArgs)
+	}
+
+	bySub := make(map[string][]Issue)
+	for _, issue := range result.Issues {
+		bySub[issue.SubCategory] = append(bySub[issue.SubCategory], issue)
+	}
+	// 3 of 5 statements covered (generated file excluded)
+	if len(bySub["threshold"]) != 1 || !strings.Contains(bySub["threshold"][0].Message, "60.0%") {
+		t.Fatalf("expected total threshold issue, got %+v", bySub["threshold"])
+	}
+	if len(bySub["minimum"]) != 1 || bySub["minimum"][0].File != "parser" {
+		t.Fatalf("expected parser minimum issue only, got %+v", bySub["minimum"])
+	}
+	changed := bySub["changed_lines"]
+	if len(changed) != 1 || changed[0].File != "parser/parse.go" || changed[0].Line != 8 {
+		t.Fatalf("expected uncovered changed lines 8-9, got %+v", changed)
+	}
+	if result.Metrics["uncovered_changed_lines"] != 2 || result.Metrics["changed_lines_checked"] != 3 {
+		t.Fatalf("unexpected changed-line metrics: %v", result.Metrics)
+	}
+}
+
+func TestCoverageRunner_SkipsWithoutConfig(t *testing.T) {
+	runner := NewCoverageAssessmentRunner()
+	result, err := runner.Assess(context.Background(), t.TempDir(), DefaultAssessmentConfig())
+	if err != nil || !result.Success || result.Metrics["status"] != "skipped" {
+		t.Fatalf("expected skipped result, got %+v (%v)", result, err)
+	}
+}
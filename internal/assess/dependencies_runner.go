@@ -103,6 +103,15 @@ func (r *DependenciesRunner) Assess(ctx context.Context, target string, assessCo
 		}, nil
 	}
 
+	// With --new-issues-only, only packages introduced since the base ref are flagged
+	var baseline *dependencies.DependencySnapshot
+	if assessConfig.NewIssuesOnly {
+		baseline = r.loadBaseline(ctx, target, assessConfig.NewIssuesBase)
+		if baseline != nil {
+			result.Issues = dependencies.FilterIssuesToNewDependencies(result.Issues, baseline, nil)
+		}
+	}
+
 	// Convert to assessment issues
 	issues := r.convertToAssessmentIssues(result)
 
@@ -119,13 +128,40 @@ func (r *DependenciesRunner) Assess(ctx context.Context, target string, assessCo
 	}
 
 	// Vulnerability scanning (SBOM + grype) is policy-driven via .goneat/dependencies.yaml
-	if _, vulnIssues, vErr := dependencies.RunVulnerabilityScanWithOptions(ctx, target, depsCfg.PolicyPath, "", assessConfig.Timeout, dependencies.VulnerabilityScanOptions{
+	vulnResult, vulnIssues, vErr := dependencies.RunVulnerabilityScanWithOptions(ctx, target, depsCfg.PolicyPath, "", assessConfig.Timeout, dependencies.VulnerabilityScanOptions{
 		NoIgnore:     assessConfig.NoIgnore,
 		ForceInclude: append([]string(nil), assessConfig.ForceInclude...),
-	}); vErr != nil {
+	})
+	if vErr != nil {
 		logger.Warn(fmt.Sprintf("vulnerability scan failed: %v", vErr))
-	} else if len(vulnIssues) > 0 {
-		for _, depIssue := range vulnIssues {
+	}
+	if baseline != nil && vulnResult != nil {
+		vulnIssues = dependencies.FilterIssuesToNewDependencies(vulnIssues, baseline, vulnResult.Findings)
+	}
+
+	// Supply-chain heuristics (typosquats, dependency confusion, install scripts) read lockfiles only
+	supplyIssues, sErr := dependencies.RunSupplyChainChecks(target, depsCfg.PolicyPath)
+	if sErr != nil {
+		logger.Warn(fmt.Sprintf("supply-chain checks failed: %v", sErr))
+	}
+	if baseline != nil {
+		supplyIssues = dependencies.FilterIssuesToNewDependencies(supplyIssues, baseline, nil)
+	}
+
+	// Rego policy bundles (.goneat/policies/*.rego) see the analysis and scan results
+	policyIssues, pErr := dependencies.EvaluatePolicyBundle(ctx, target, "", dependencies.PolicyInputOptions{
+		PolicyPath:    depsCfg.PolicyPath,
+		Languages:     []dependencies.Language{lang},
+		Dependencies:  result.Dependencies,
+		Vulnerability: vulnResult,
+	})
+	if pErr != nil {
+		logger.Warn(fmt.Sprintf("policy bundle evaluation failed: %v", pErr))
+	}
+
+	extra := append(append(vulnIssues, supplyIssues...), policyIssues...)
+	if len(extra) > 0 {
+		for _, depIssue := range extra {
 			issues = append(issues, Issue{
 				File:          "",
 				Severity:      r.mapSeverity(depIssue.Severity, depIssue.Type),
@@ -160,6 +196,21 @@ func (r *DependenciesRunner) Assess(ctx context.Context, target string, assessCo
 	}, nil
 }
 
+// loadBaseline reads the dependency set at the --new-issues-base ref. It
+// returns nil (no filtering) when the base cannot be read.
+func (r *DependenciesRunner) loadBaseline(ctx context.Context, target string, base string) *dependencies.DependencySnapshot {
+	if base == "" {
+		base = "HEAD~"
+	}
+	snapshot, err := dependencies.LoadDependencySnapshot(ctx, target, base, dependencies.DependencyDiffOptions{})
+	if err != nil {
+		logger.Warn(fmt.Sprintf("dependencies: cannot read baseline at %s, reporting all issues: %v", base, err))
+		return nil
+	}
+	logger.Debug(fmt.Sprintf("dependencies: baseline %s has %d packages", base, len(snapshot.Dependencies)))
+	return snapshot
+}
+
 // selectAnalyzer returns the appropriate analyzer for the detected language
 func (r *DependenciesRunner) selectAnalyzer(lang dependencies.Language) dependencies.Analyzer {
 	switch lang {
@@ -233,6 +284,10 @@ func (r *DependenciesRunner) estimateRemediationTime(issueType string) HumanRead
 		return HumanReadableDuration(15 * time.Minute) // Add exception or wait for package maturity
 	case "vulnerability":
 		return HumanReadableDuration(45 * time.Minute) // Research + update + test
+	case "typosquat", "dependency_confusion":
+		return HumanReadableDuration(30 * time.Minute) // Verify package provenance + pin registry
+	case "install_script":
+		return HumanReadableDuration(10 * time.Minute) // Review script + allow or disable
 	default:
 		return HumanReadableDuration(20 * time.Minute) // Generic fallback
 	}
@@ -282,12 +337,15 @@ func (r *DependenciesRunner) updateMetricsFromIssues(metrics map[string]interfac
 	// Count license and bans violations from assessment issues
 	licenseCount := 0
 	bansCount := 0
+	supplyChainCount := 0
 	for _, issue := range issues {
 		switch issue.SubCategory {
 		case "license", "rust:cargo-deny:license":
 			licenseCount++
 		case "rust:cargo-deny:bans":
 			bansCount++
+		case "typosquat", "dependency_confusion", "install_script":
+			supplyChainCount++
 		}
 	}
 
@@ -295,6 +353,7 @@ func (r *DependenciesRunner) updateMetricsFromIssues(metrics map[string]interfac
 	// Note: license_violations is overwritten to include cargo-deny license issues
 	metrics["license_violations"] = licenseCount
 	metrics["bans_violations"] = bansCount
+	metrics["supply_chain_findings"] = supplyChainCount
 	// analysis_passed intentionally NOT updated - preserves original analyzer result
 	// Assessment threshold pass/fail is captured in AssessmentResult.Success
 }
@@ -0,0 +1,584 @@
+package assess
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/logger"
+)
+
+const (
+	// DefaultBenchmarkBaselinePath is where benchmark baselines are stored, relative to the target.
+	DefaultBenchmarkBaselinePath = ".goneat/benchmarks/baseline.json"
+
+	benchmarkBaselineVersion   = 1
+	defaultBenchmarkCount      = 6
+	defaultBenchmarkThreshold  = 10.0
+	defaultBenchmarkAlpha      = 0.05
+	benchmarkConfidence        = 0.95
+	defaultBenchmarkPattern    = "."
+	defaultBenchmarkRegression = "ns/op"
+)
+
+// performanceOverrides configures the Go benchmark regression gate in .goneat/assess.yaml.
+type performanceOverrides struct {
+	Enabled          *bool    `yaml:"enabled"`
+	Packages         []string `yaml:"packages"`
+	Bench            string   `yaml:"bench"`
+	Count            int      `yaml:"count"`
+	Benchtime        string   `yaml:"benchtime"`
+	ThresholdPercent *float64 `yaml:"threshold_percent"`
+	Alpha            *float64 `yaml:"alpha"`
+	Units            []string `yaml:"units"`
+	Baseline         string   `yaml:"baseline"`
+}
+
+// benchmarkSettings is the resolved performance configuration with defaults applied.
+type benchmarkSettings struct {
+	Packages         []string
+	Bench            string
+	Count            int
+	Benchtime        string
+	ThresholdPercent float64
+	Alpha            float64
+	Units            []string
+	Baseline         string
+}
+
+func (o *performanceOverrides) settings() benchmarkSettings {
+	s := benchmarkSettings{
+		Packages:         append([]string(nil), o.Packages...),
+		Bench:            strings.TrimSpace(o.Bench),
+		Count:            o.Count,
+		Benchtime:        strings.TrimSpace(o.Benchtime),
+		ThresholdPercent: defaultBenchmarkThreshold,
+		Alpha:            defaultBenchmarkAlpha,
+		Units:            append([]string(nil), o.Units...),
+		Baseline:         strings.TrimSpace(o.Baseline),
+	}
+	if s.Bench == "" {
+		s.Bench = defaultBenchmarkPattern
+	}
+	if s.Count <= 0 {
+		s.Count = defaultBenchmarkCount
+	}
+	if o.ThresholdPercent != nil {
+		s.ThresholdPercent = *o.ThresholdPercent
+	}
+	if o.Alpha != nil {
+		s.Alpha = *o.Alpha
+	}
+	if len(s.Units) == 0 {
+		s.Units = []string{defaultBenchmarkRegression}
+	}
+	if s.Baseline == "" {
+		s.Baseline = DefaultBenchmarkBaselinePath
+	}
+	return s
+}
+
+// benchmarkBaseline is the on-disk record of benchmark samples used for comparison.
+type benchmarkBaseline struct {
+	Version     int                             `json:"version"`
+	GeneratedAt time.Time                       `json:"generated_at"`
+	GOOS        string                          `json:"goos,omitempty"`
+	GOARCH      string                          `json:"goarch,omitempty"`
+	CPU         string                          `json:"cpu,omitempty"`
+	Count       int                             `json:"count"`
+	Benchmarks  map[string]map[string][]float64 `json:"benchmarks"`
+}
+
+// benchmarkRun holds samples parsed from `go test -bench` output, keyed by
+// "<import path>.<benchmark name>" and then by unit.
+type benchmarkRun struct {
+	GOOS       string
+	GOARCH     string
+	CPU        string
+	Benchmarks map[string]map[string][]float64
+}
+
+// benchmarkComparison captures the statistical comparison of one benchmark unit.
+type benchmarkComparison struct {
+	Benchmark    string       `json:"benchmark"`
+	Unit         string       `json:"unit"`
+	Baseline     benchSummary `json:"baseline"`
+	Current      benchSummary `json:"current"`
+	DeltaPercent float64      `json:"delta_percent"`
+	PValue       float64      `json:"p_value"`
+	Significant  bool         `json:"significant"`
+	Regression   bool         `json:"regression"`
+}
+
+// goCommandFunc runs `go <args>` in dir and returns its combined output.
+type goCommandFunc func(ctx context.Context, dir string, args []string) ([]byte, error)
+
+// PerformanceAssessmentRunner gates Go benchmark regressions against a stored baseline.
+type PerformanceAssessmentRunner struct {
+	commandName string
+	runGo       goCommandFunc
+	procs       int // value passed to -cpu; go test suffixes names with it
+}
+
+// NewPerformanceAssessmentRunner creates a new performance assessment runner
+func NewPerformanceAssessmentRunner() *PerformanceAssessmentRunner {
+	return &PerformanceAssessmentRunner{commandName: "go test -bench", runGo: runGoCommand, procs: runtime.GOMAXPROCS(0)}
+}
+
+// Assess implements AssessmentRunner.Assess
+func (r *PerformanceAssessmentRunner) Assess(ctx context.Context, target string, config AssessmentConfig) (*AssessmentResult, error) {
+	startTime := time.Now()
+
+	overrides := loadAssessOverrides(target)
+	if overrides == nil || overrides.Performance == nil || len(overrides.Performance.Packages) == 0 {
+		return r.skippedResult(startTime, "no benchmark packages configured in assess.yaml"), nil
+	}
+	if !boolWithDefault(overrides.Performance.Enabled, true) {
+		return r.skippedResult(startTime, "performance disabled via assess.yaml"), nil
+	}
+	settings := overrides.Performance.settings()
+
+	// Pin -cpu so the procs suffix go test appends to names is known.
+	args := []string{"test", "-run", "^$", "-bench", settings.Bench, "-benchmem", "-count", strconv.Itoa(settings.Count), "-cpu", strconv.Itoa(r.procs)}
+	if settings.Benchtime != "" {
+		args = append(args, "-benchtime", settings.Benchtime)
+	}
+	args = append(args, settings.Packages...)
+
+	logger.Info(fmt.Sprintf("Running benchmarks: go %s", strings.Join(args, " ")))
+	out, err := r.runGo(ctx, target, args)
+	if err != nil {
+		return r.errorResult(startTime, fmt.Sprintf("benchmark run failed: %v\n%s", err, strings.TrimSpace(string(out)))), nil
+	}
+
+	run := parseBenchmarkOutput(out, r.procs)
+	if len(run.Benchmarks) == 0 {
+		result := r.skippedResult(startTime, fmt.Sprintf("no benchmarks matched %q", settings.Bench))
+		result.Metrics["benchmarks_run"] = 0
+		return result, nil
+	}
+
+	baselinePath := settings.Baseline
+	if !filepath.IsAbs(baselinePath) {
+		baselinePath = filepath.Join(target, baselinePath)
+	}
+
+	if config.WriteBenchmarkBaseline {
+		if err := writeBenchmarkBaseline(baselinePath, run, settings.Count); err != nil {
+			return r.errorResult(startTime, err.Error()), nil
+		}
+		logger.Info(fmt.Sprintf("Wrote benchmark baseline with %d benchmarks to %s", len(run.Benchmarks), baselinePath))
+		return &AssessmentResult{
+			CommandName:   r.commandName,
+			Category:      CategoryPerformance,
+			Success:       true,
+			Issues:        []Issue{},
+			ExecutionTime: HumanReadableDuration(time.Since(startTime)),
+			Metrics: map[string]interface{}{
+				"benchmarks_run":   len(run.Benchmarks),
+				"baseline_written": filepath.ToSlash(settings.Baseline),
+			},
+		}, nil
+	}
+
+	baseline, err := loadBenchmarkBaseline(baselinePath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return r.errorResult(startTime, err.Error()), nil
+		}
+		return &AssessmentResult{
+			CommandName: r.commandName,
+			Category:    CategoryPerformance,
+			Success:     true,
+			Issues: []Issue{{
+				File:        filepath.ToSlash(settings.Baseline),
+				Severity:    SeverityInfo,
+				Message:     "No benchmark baseline found; run 'goneat assess --categories performance --write-benchmark-baseline' to record one",
+				Category:    CategoryPerformance,
+				SubCategory: "baseline",
+			}},
+			ExecutionTime: HumanReadableDuration(time.Since(startTime)),
+			Metrics: map[string]interface{}{
+				"benchmarks_run": len(run.Benchmarks),
+				"status":         "no_baseline",
+			},
+		}, nil
+	}
+
+	comparisons, missing, added := compareBenchmarks(baseline, run, settings)
+
+	var issues []Issue
+	if baseline.GOOS != run.GOOS || baseline.GOARCH != run.GOARCH || (baseline.CPU != "" && run.CPU != "" && baseline.CPU != run.CPU) {
+		issues = append(issues, Issue{
+			File:        filepath.ToSlash(settings.Baseline),
+			Severity:    SeverityInfo,
+			Message:     fmt.Sprintf("Benchmark baseline was recorded on %s/%s (%s); current run is %s/%s (%s) so deltas may reflect hardware differences", baseline.GOOS, baseline.GOARCH, baseline.CPU, run.GOOS, run.GOARCH, run.CPU),
+			Category:    CategoryPerformance,
+			SubCategory: "baseline",
+		})
+	}
+
+	var locator *benchmarkLocator
+	regressions := 0
+	for _, c := range comparisons {
+		if !c.Regression {
+			continue
+		}
+		regressions++
+		if locator == nil {
+			locator = newBenchmarkLocator(ctx, r.runGo, target, settings.Packages)
+		}
+		file, line := locator.locate(c.Benchmark)
+		if file == "" {
+			file = filepath.ToSlash(settings.Baseline)
+		}
+		severity := SeverityMedium
+		if c.DeltaPercent >= 2*settings.ThresholdPercent {
+			severity = SeverityHigh
+		}
+		issues = append(issues, Issue{
+			File:     file,
+			Line:     line,
+			Severity: severity,
+			Message: fmt.Sprintf("%s %s regressed %+.1f%% (%s → %s, p=%.3f n=%d+%d; threshold %.1f%%)",
+				c.Benchmark, c.Unit, c.DeltaPercent,
+				formatBenchValue(c.Baseline.Median, c.Unit), formatBenchValue(c.Current.Median, c.Unit),
+				c.PValue, c.Baseline.N, c.Current.N, settings.ThresholdPercent),
+			Category:    CategoryPerformance,
+			SubCategory: "regression",
+		})
+	}
+	for _, name := range missing {
+		issues = append(issues, Issue{
+			File:        filepath.ToSlash(settings.Baseline),
+			Severity:    SeverityLow,
+			Message:     fmt.Sprintf("Benchmark %s is in the baseline but did not run; refresh with --write-benchmark-baseline if it was removed", name),
+			Category:    CategoryPerformance,
+			SubCategory: "baseline",
+		})
+	}
+
+	perBenchmark := make(map[string]map[string]benchmarkComparison)
+	for _, c := range comparisons {
+		if perBenchmark[c.Benchmark] == nil {
+			perBenchmark[c.Benchmark] = make(map[string]benchmarkComparison)
+		}
+		perBenchmark[c.Benchmark][c.Unit] = c
+	}
+
+	logger.Info(fmt.Sprintf("performance completed: %d benchmarks, %d regressions", len(run.Benchmarks), regressions))
+	return &AssessmentResult{
+		CommandName:   r.commandName,
+		Category:      CategoryPerformance,
+		Success:       true,
+		Issues:        issues,
+		ExecutionTime: HumanReadableDuration(time.Since(startTime)),
+		Metrics: map[string]interface{}{
+			"benchmarks_run":     len(run.Benchmarks),
+			"regressions":        regressions,
+			"missing_benchmarks": missing,
+			"new_benchmarks":     added,
+			"threshold_percent":  settings.ThresholdPercent,
+			"baseline":           filepath.ToSlash(settings.Baseline),
+			"benchmarks":         perBenchmark,
+		},
+	}, nil
+}
+
+// CanRunInParallel implements AssessmentRunner.CanRunInParallel.
+// Benchmarks need the machine to themselves to produce stable numbers.
+func (r *PerformanceAssessmentRunner) CanRunInParallel() bool {
+	return false
+}
+
+// GetCategory implements AssessmentRunner.GetCategory
+func (r *PerformanceAssessmentRunner) GetCategory() AssessmentCategory {
+	return CategoryPerformance
+}
+
+// GetEstimatedTime implements AssessmentRunner.GetEstimatedTime
+func (r *PerformanceAssessmentRunner) GetEstimatedTime(target string) time.Duration {
+	return 2 * time.Minute
+}
+
+// IsAvailable implements AssessmentRunner.IsAvailable
+func (r *PerformanceAssessmentRunner) IsAvailable() bool {
+	_, err := exec.LookPath("go")
+	return err == nil
+}
+
+func (r *PerformanceAssessmentRunner) skippedResult(start time.Time, reason string) *AssessmentResult {
+	return &AssessmentResult{
+		CommandName:   r.commandName,
+		Category:      CategoryPerformance,
+		Success:       true,
+		ExecutionTime: HumanReadableDuration(time.Since(start)),
+		Issues:        []Issue{},
+		Metrics: map[string]interface{}{
+			"status": "skipped",
+			"reason": reason,
+		},
+	}
+}
+
+func (r *PerformanceAssessmentRunner) errorResult(start time.Time, msg string) *AssessmentResult {
+	return &AssessmentResult{
+		CommandName:   r.commandName,
+		Category:      CategoryPerformance,
+		Success:       false,
+		Issues:        []Issue{},
+		Metrics:       map[string]interface{}{},
+		ExecutionTime: HumanReadableDuration(time.Since(start)),
+		Error:         msg,
+	}
+}
+
+func runGoCommand(ctx context.Context, dir string, args []string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "go", args...) // #nosec G204 - args built from repo-owned assess.yaml
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
+
+// parseBenchmarkOutput extracts samples from `go test -bench` text output.
+// go test appends -<procs> to benchmark names only when procs is not 1, so
+// just that suffix is stripped; numeric sub-benchmark names such as
+// size-1024 are kept.
+func parseBenchmarkOutput(out []byte, procs int) benchmarkRun {
+	procsSuffix := ""
+	if procs > 1 {
+		procsSuffix = "-" + strconv.Itoa(procs)
+	}
+	run := benchmarkRun{Benchmarks: make(map[string]map[string][]float64)}
+	pkg := ""
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "goos:"):
+			run.GOOS = strings.TrimSpace(strings.TrimPrefix(line, "goos:"))
+			continue
+		case strings.HasPrefix(line, "goarch:"):
+			run.GOARCH = strings.TrimSpace(strings.TrimPrefix(line, "goarch:"))
+			continue
+		case strings.HasPrefix(line, "cpu:"):
+			run.CPU = strings.TrimSpace(strings.TrimPrefix(line, "cpu:"))
+			continue
+		case strings.HasPrefix(line, "pkg:"):
+			pkg = strings.TrimSpace(strings.TrimPrefix(line, "pkg:"))
+			continue
+		case !strings.HasPrefix(line, "Benchmark"):
+			continue
+		}
+
+		fields := strings.Fields(line)
+		// name, iterations, then value/unit pairs
+		if len(fields) < 4 || len(fields)%2 != 0 {
+			continue
+		}
+		if _, err := strconv.ParseInt(fields[1], 10, 64); err != nil {
+			continue
+		}
+		name := fields[0]
+		if procsSuffix != "" {
+			name = strings.TrimSuffix(name, procsSuffix)
+		}
+		if pkg != "" {
+			name = pkg + "." + name
+		}
+		units := run.Benchmarks[name]
+		if units == nil {
+			units = make(map[string][]float64)
+			run.Benchmarks[name] = units
+		}
+		for i := 2; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				continue
+			}
+			units[fields[i+1]] = append(units[fields[i+1]], value)
+		}
+	}
+	return run
+}
+
+// compareBenchmarks compares the current run against the baseline for the
+// configured units. It also returns benchmarks that disappeared from or were
+// added since the baseline.
+func compareBenchmarks(baseline *benchmarkBaseline, run benchmarkRun, settings benchmarkSettings) ([]benchmarkComparison, []string, []string) {
+	var comparisons []benchmarkComparison
+	var missing, added []string
+
+	for name := range baseline.Benchmarks {
+		if _, ok := run.Benchmarks[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	for name, units := range run.Benchmarks {
+		baseUnits, ok := baseline.Benchmarks[name]
+		if !ok {
+			added = append(added, name)
+			continue
+		}
+		for _, unit := range settings.Units {
+			current, old := units[unit], baseUnits[unit]
+			if len(current) == 0 || len(old) == 0 {
+				continue
+			}
+			c := benchmarkComparison{
+				Benchmark: name,
+				Unit:      unit,
+				Baseline:  summarizeSamples(old, benchmarkConfidence),
+				Current:   summarizeSamples(current, benchmarkConfidence),
+				PValue:    mannWhitneyUTest(old, current),
+			}
+			if c.Baseline.Median != 0 {
+				c.DeltaPercent = (c.Current.Median - c.Baseline.Median) / c.Baseline.Median * 100
+			}
+			c.Significant = c.PValue <= settings.Alpha
+			worse := c.DeltaPercent
+			if higherIsBetter(unit) {
+				worse = -worse
+			}
+			c.Regression = c.Significant && worse > settings.ThresholdPercent
+			comparisons = append(comparisons, c)
+		}
+	}
+
+	sort.Slice(comparisons, func(i, j int) bool {
+		if comparisons[i].Benchmark != comparisons[j].Benchmark {
+			return comparisons[i].Benchmark < comparisons[j].Benchmark
+		}
+		return comparisons[i].Unit < comparisons[j].Unit
+	})
+	sort.Strings(missing)
+	sort.Strings(added)
+	return comparisons, missing, added
+}
+
+// higherIsBetter reports whether larger values of a unit are improvements (throughput units like MB/s).
+func higherIsBetter(unit string) bool {
+	return strings.HasSuffix(unit, "/s")
+}
+
+func formatBenchValue(v float64, unit string) string {
+	if unit == "ns/op" {
+		return time.Duration(v).String()
+	}
+	return strconv.FormatFloat(v, 'g', 4, 64) + " " + unit
+}
+
+func loadBenchmarkBaseline(path string) (*benchmarkBaseline, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - baseline path comes from repo-owned assess.yaml
+	if err != nil {
+		return nil, err
+	}
+	var baseline benchmarkBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse benchmark baseline %s: %w", path, err)
+	}
+	if baseline.Version != benchmarkBaselineVersion {
+		return nil, fmt.Errorf("unsupported benchmark baseline version %d in %s", baseline.Version, path)
+	}
+	return &baseline, nil
+}
+
+func writeBenchmarkBaseline(path string, run benchmarkRun, count int) error {
+	baseline := benchmarkBaseline{
+		Version:     benchmarkBaselineVersion,
+		GeneratedAt: time.Now().UTC(),
+		GOOS:        run.GOOS,
+		GOARCH:      run.GOARCH,
+		CPU:         run.CPU,
+		Count:       count,
+		Benchmarks:  run.Benchmarks,
+	}
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode benchmark baseline: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create benchmark baseline directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write benchmark baseline: %w", err)
+	}
+	return nil
+}
+
+// benchmarkLocator maps benchmark names back to their source declarations.
+type benchmarkLocator struct {
+	target  string
+	pkgDirs map[string]string
+}
+
+func newBenchmarkLocator(ctx context.Context, runGo goCommandFunc, target string, packages []string) *benchmarkLocator {
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		absTarget = target
+	}
+	l := &benchmarkLocator{target: absTarget, pkgDirs: make(map[string]string)}
+	args := append([]string{"list", "-f", "{{.ImportPath}}\t{{.Dir}}"}, packages...)
+	out, err := runGo(ctx, target, args)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("go list failed; benchmark issues will reference the baseline: %v", err))
+		return l
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if importPath, dir, ok := strings.Cut(strings.TrimSpace(line), "\t"); ok {
+			l.pkgDirs[importPath] = dir
+		}
+	}
+	return l
+}
+
+// locate returns the target-relative file and line declaring the benchmark
+// function, or an empty path when it cannot be found.
+func (l *benchmarkLocator) locate(benchmark string) (string, int) {
+	// Import paths contain slashes and dots, so match against known packages
+	// rather than splitting the name.
+	importPath := ""
+	for candidate := range l.pkgDirs {
+		if strings.HasPrefix(benchmark, candidate+".") && len(candidate) > len(importPath) {
+			importPath = candidate
+		}
+	}
+	if importPath == "" {
+		return "", 0
+	}
+	dir := l.pkgDirs[importPath]
+	funcName := strings.SplitN(strings.TrimPrefix(benchmark, importPath+"."), "/", 2)[0]
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	decl := "func " + funcName + "("
+	for _, file := range files {
+		data, err := os.ReadFile(file) // #nosec G304 - test files reported by go list
+		if err != nil {
+			continue
+		}
+		for i, line := range strings.Split(string(data), "\n") {
+			if strings.HasPrefix(line, decl) {
+				rel := file
+				if r, err := filepath.Rel(l.target, file); err == nil {
+					rel = r
+				}
+				return filepath.ToSlash(rel), i + 1
+			}
+		}
+	}
+	return "", 0
+}
+
+func init() {
+	RegisterAssessmentRunner(CategoryPerformance, NewPerformanceAssessmentRunner())
+}
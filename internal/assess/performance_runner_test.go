@@ -0,0 +1,233 @@
+package assess
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleBenchOutput = `goos: linux
+goarch: amd64
+pkg: example.com/demo/parser
+cpu: Example CPU @ 3.00GHz
+BenchmarkParse-8          	   10000	    100000 ns/op	    2048 B/op	      12 allocs/op
+BenchmarkParse-8          	   10000	    101000 ns/op	    2048 B/op	      12 allocs/op
+BenchmarkParse/large-8    	     100	   5000000 ns/op	   65536 B/op	     300 allocs/op
+BenchmarkThroughput-8     	    5000	    200000 ns/op	  512.00 MB/s
+PASS
+ok  	example.com/demo/parser	3.210s
+`
+
+func TestParseBenchmarkOutput(t *testing.T) {
+	run := parseBenchmarkOutput([]byte(sampleBenchOutput), 8)
+
+	if run.GOOS != "linux" || run.GOARCH != "amd64" || run.CPU != "Example CPU @ 3.00GHz" {
+		t.Fatalf("unexpected platform: %+v", run)
+	}
+	parse, ok := run.Benchmarks["example.com/demo/parser.BenchmarkParse"]
+	if !ok {
+		t.Fatalf("expected BenchmarkParse with procs suffix stripped, got %v", run.Benchmarks)
+	}
+	if got := parse["ns/op"]; len(got) != 2 || got[0] != 100000 || got[1] != 101000 {
+		t.Fatalf("unexpected ns/op samples: %v", got)
+	}
+	if got := parse["allocs/op"]; len(got) != 2 {
+		t.Fatalf("expected allocs/op samples, got %v", got)
+	}
+	if _, ok := run.Benchmarks["example.com/demo/parser.BenchmarkParse/large"]; !ok {
+		t.Fatalf("expected sub-benchmark to be parsed")
+	}
+	if got := run.Benchmarks["example.com/demo/parser.BenchmarkThroughput"]["MB/s"]; len(got) != 1 || got[0] != 512 {
+		t.Fatalf("unexpected MB/s samples: %v", got)
+	}
+}
+
+func TestParseBenchmarkOutput_SingleProcKeepsNumericNames(t *testing.T) {
+	// With -cpu 1 go test adds no suffix, so numeric sub-benchmark names stay intact.
+	out := `pkg: example.com/demo/buf
+BenchmarkCopy/size-1024   	  100000	     10000 ns/op
+BenchmarkCopy/size-2048   	   50000	     20000 ns/op
+`
+	run := parseBenchmarkOutput([]byte(out), 1)
+	for _, name := range []string{"example.com/demo/buf.BenchmarkCopy/size-1024", "example.com/demo/buf.BenchmarkCopy/size-2048"} {
+		if _, ok := run.Benchmarks[name]; !ok {
+			t.Fatalf("expected %s, got %v", name, run.Benchmarks)
+		}
+	}
+
+	// Only the exact procs suffix is stripped.
+	run = parseBenchmarkOutput([]byte("BenchmarkCopy/size-1024-4   	  100000	     10000 ns/op\n"), 4)
+	if _, ok := run.Benchmarks["BenchmarkCopy/size-1024"]; !ok {
+		t.Fatalf("expected procs suffix stripped, got %v", run.Benchmarks)
+	}
+}
+
+func TestSummarizeSamples(t *testing.T) {
+	samples := []float64{10, 12, 11, 13, 9, 14, 10, 11, 12, 100}
+	s := summarizeSamples(samples, 0.95)
+	if s.N != 10 || s.Median != 11.5 {
+		t.Fatalf("unexpected summary: %+v", s)
+	}
+	// With n=10 the 95% interval is [x(2), x(7)], which excludes the outlier
+	if s.Low != 10 || s.High != 13 {
+		t.Fatalf("unexpected confidence interval: [%v, %v]", s.Low, s.High)
+	}
+
+	small := summarizeSamples([]float64{3, 1, 2}, 0.95)
+	if small.Low != 1 || small.High != 3 || small.Median != 2 {
+		t.Fatalf("small samples should use the full range: %+v", small)
+	}
+}
+
+func TestMannWhitneyUTest(t *testing.T) {
+	same := []float64{1, 2, 3, 4, 5, 6}
+	if p := mannWhitneyUTest(same, same); p < 0.9 {
+		t.Fatalf("identical samples should not be significant, p=%v", p)
+	}
+
+	// Fully separated 6+6 samples: exact two-sided p = 2/924
+	low := []float64{1, 2, 3, 4, 5, 6}
+	high := []float64{7, 8, 9, 10, 11, 12}
+	if p := mannWhitneyUTest(low, high); math.Abs(p-2.0/924.0) > 1e-9 {
+		t.Fatalf("unexpected exact p-value: %v", p)
+	}
+
+	// Too few samples can never reach significance
+	if p := mannWhitneyUTest([]float64{1}, []float64{2}); p < 0.05 {
+		t.Fatalf("single samples should not be significant, p=%v", p)
+	}
+
+	// Ties fall back to the normal approximation
+	tiedLow := []float64{1, 1, 2, 2, 3, 3, 4, 4}
+	tiedHigh := []float64{10, 10, 11, 11, 12, 12, 13, 13}
+	if p := mannWhitneyUTest(tiedLow, tiedHigh); p > 0.01 {
+		t.Fatalf("separated tied samples should be significant, p=%v", p)
+	}
+}
+
+func TestCompareBenchmarks(t *testing.T) {
+	baseline := &benchmarkBaseline{
+		Version: benchmarkBaselineVersion,
+		Benchmarks: map[string]map[string][]float64{
+			"pkg.BenchmarkSlow":    {"ns/op": {100, 101, 99, 100, 102, 98}},
+			"pkg.BenchmarkNoise":   {"ns/op": {100, 120, 90, 110, 95, 105}},
+			"pkg.BenchmarkRemoved": {"ns/op": {1, 1, 1}},
+			"pkg.BenchmarkThru":    {"MB/s": {500, 510, 505, 495, 500, 502}},
+		},
+	}
+	run := benchmarkRun{Benchmarks: map[string]map[string][]float64{
+		"pkg.BenchmarkSlow":  {"ns/op": {130, 131, 129, 130, 132, 128}},
+		"pkg.BenchmarkNoise": {"ns/op": {115, 92, 108, 101, 97, 119}},
+		"pkg.BenchmarkThru":  {"MB/s": {400, 405, 398, 402, 401, 399}},
+		"pkg.BenchmarkNew":   {"ns/op": {5, 5, 5}},
+	}}
+	settings := (&performanceOverrides{Units: []string{"ns/op", "MB/s"}}).settings()
+
+	comparisons, missing, added := compareBenchmarks(baseline, run, settings)
+
+	byName := make(map[string]benchmarkComparison)
+	for _, c := range comparisons {
+		byName[c.Benchmark] = c
+	}
+	if slow := byName["pkg.BenchmarkSlow"]; !slow.Regression || math.Abs(slow.DeltaPercent-30) > 0.01 {
+		t.Fatalf("expected 30%% regression for BenchmarkSlow, got %+v", slow)
+	}
+	if noise := byName["pkg.BenchmarkNoise"]; noise.Regression || noise.Significant {
+		t.Fatalf("noisy benchmark should not be flagged, got %+v", noise)
+	}
+	if thru := byName["pkg.BenchmarkThru"]; !thru.Regression {
+		t.Fatalf("throughput drop should be a regression, got %+v", thru)
+	}
+	if len(missing) != 1 || missing[0] != "pkg.BenchmarkRemoved" {
+		t.Fatalf("unexpected missing benchmarks: %v", missing)
+	}
+	if len(added) != 1 || added[0] != "pkg.BenchmarkNew" {
+		t.Fatalf("unexpected new benchmarks: %v", added)
+	}
+}
+
+func TestPerformanceRunner_SkipsWithoutConfig(t *testing.T) {
+	dir := t.TempDir()
+	runner := NewPerformanceAssessmentRunner()
+	runner.runGo = func(context.Context, string, []string) ([]byte, error) {
+		t.Fatal("go should not run without performance config")
+		return nil, nil
+	}
+
+	result, err := runner.Assess(context.Background(), dir, DefaultAssessmentConfig())
+	if err != nil {
+		t.Fatalf("Assess returned error: %v", err)
+	}
+	if !result.Success || result.Metrics["status"] != "skipped" {
+		t.Fatalf("expected skipped result, got %+v", result)
+	}
+}
+
+func TestPerformanceRunner_BaselineRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, ".goneat", "assess.yaml"), "version: 1\nperformance:\n  packages: [\"./parser\"]\n  count: 2\n")
+
+	testFile := filepath.Join(dir, "parser", "parse_test.go")
+	writeTestFile(t, testFile, "package parser\n\nimport \"testing\"\n\nfunc BenchmarkParse(b *testing.B) {}\n")
+
+	output := sampleBenchOutput
+	var benchArgs []string
+	runner := NewPerformanceAssessmentRunner()
+	runner.procs = 8
+	runner.runGo = func(_ context.Context, _ string, args []string) ([]byte, error) {
+		if args[0] == "list" {
+			return []byte("example.com/demo/parser\t" + filepath.Dir(testFile) + "\n"), nil
+		}
+		benchArgs = args
+		return []byte(output), nil
+	}
+
+	cfg := DefaultAssessmentConfig()
+	cfg.WriteBenchmarkBaseline = true
+	result, err := runner.Assess(context.Background(), dir, cfg)
+	if err != nil || !result.Success {
+		t.Fatalf("baseline write failed: %v %+v", err, result)
+	}
+	if got := strings.Join(benchArgs, " "); got != "test -run ^$ -bench . -benchmem -count 2 -cpu 8 ./parser" {
+		t.Fatalf("unexpected go test args: %s", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, DefaultBenchmarkBaselinePath)); err != nil {
+		t.Fatalf("expected baseline file: %v", err)
+	}
+
+	// Same numbers: no regressions
+	cfg.WriteBenchmarkBaseline = false
+	result, err = runner.Assess(context.Background(), dir, cfg)
+	if err != nil || !result.Success {
+		t.Fatalf("comparison failed: %v %+v", err, result)
+	}
+	if result.Metrics["regressions"] != 0 {
+		t.Fatalf("expected no regressions, got %v", result.Metrics["regressions"])
+	}
+
+	// Much slower numbers: BenchmarkParse regresses and points at its declaration
+	slow := strings.NewReplacer("100000 ns/op", "300000 ns/op", "101000 ns/op", "301000 ns/op").Replace(sampleBenchOutput)
+	output = slow + slow + slow
+	if err := writeBenchmarkBaseline(filepath.Join(dir, DefaultBenchmarkBaselinePath), parseBenchmarkOutput([]byte(sampleBenchOutput+sampleBenchOutput+sampleBenchOutput), 8), 6); err != nil {
+		t.Fatal(err)
+	}
+	result, err = runner.Assess(context.Background(), dir, cfg)
+	if err != nil {
+		t.Fatalf("comparison failed: %v", err)
+	}
+	var regression *Issue
+	for i := range result.Issues {
+		if result.Issues[i].SubCategory == "regression" {
+			regression = &result.Issues[i]
+		}
+	}
+	if regression == nil {
+		t.Fatalf("expected regression issue, got %+v", result.Issues)
+	}
+	if regression.File != "parser/parse_test.go" || regression.Line != 5 || regression.Severity != SeverityHigh {
+		t.Fatalf("unexpected regression issue: %+v", regression)
+	}
+}
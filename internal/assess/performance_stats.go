@@ -0,0 +1,189 @@
+package assess
+
+import (
+	"math"
+	"sort"
+)
+
+// benchSummary summarizes a benchmark sample the way benchstat does: the
+// median with a distribution-free confidence interval derived from order
+// statistics.
+type benchSummary struct {
+	N      int     `json:"n"`
+	Median float64 `json:"median"`
+	Low    float64 `json:"ci_low"`
+	High   float64 `json:"ci_high"`
+}
+
+// summarizeSamples computes the median and the tightest order-statistic
+// interval around it with at least the requested confidence. Small samples
+// fall back to the full sample range.
+func summarizeSamples(samples []float64, confidence float64) benchSummary {
+	if len(samples) == 0 {
+		return benchSummary{}
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+
+	summary := benchSummary{N: n, Median: median(sorted), Low: sorted[0], High: sorted[n-1]}
+
+	// Interval [x(k), x(n-1-k)] covers the median with probability
+	// 1 - 2*P(B <= k-1) where B ~ Binomial(n, 0.5).
+	for k := 1; k < n-1-k; k++ {
+		if 1-2*binomialCDF(k-1, n) < confidence {
+			break
+		}
+		summary.Low = sorted[k]
+		summary.High = sorted[n-1-k]
+	}
+	return summary
+}
+
+func median(sorted []float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// binomialCDF returns P(B <= k) for B ~ Binomial(n, 0.5).
+func binomialCDF(k, n int) float64 {
+	if k < 0 {
+		return 0
+	}
+	if k >= n {
+		return 1
+	}
+	total := 0.0
+	for i := 0; i <= k; i++ {
+		total += math.Exp(logChoose(n, i) - float64(n)*math.Ln2)
+	}
+	return math.Min(total, 1)
+}
+
+func logChoose(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+// mannWhitneyExactLimit bounds the sample size for which the exact U
+// distribution is enumerated; larger samples use the normal approximation.
+const mannWhitneyExactLimit = 30
+
+// mannWhitneyUTest returns the two-sided p-value of the Mann-Whitney U test
+// for the hypothesis that x and y come from the same distribution. This is
+// the significance test benchstat uses to decide whether a delta is noise.
+func mannWhitneyUTest(x, y []float64) float64 {
+	n1, n2 := len(x), len(y)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	type obs struct {
+		value float64
+		fromX bool
+	}
+	all := make([]obs, 0, n1+n2)
+	for _, v := range x {
+		all = append(all, obs{value: v, fromX: true})
+	}
+	for _, v := range y {
+		all = append(all, obs{value: v})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].value < all[j].value })
+
+	// Rank with ties averaged, tracking tie groups for the variance correction
+	rankSumX := 0.0
+	tieCorrection := 0.0
+	hasTies := false
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].value == all[i].value {
+			j++
+		}
+		avgRank := float64(i+j+1) / 2 // ranks are 1-based: (i+1 + j) / 2
+		for k := i; k < j; k++ {
+			if all[k].fromX {
+				rankSumX += avgRank
+			}
+		}
+		if t := float64(j - i); t > 1 {
+			hasTies = true
+			tieCorrection += t*t*t - t
+		}
+		i = j
+	}
+
+	u := rankSumX - float64(n1*(n1+1))/2
+
+	if !hasTies && n1 <= mannWhitneyExactLimit && n2 <= mannWhitneyExactLimit {
+		return mannWhitneyExactP(int(math.Round(u)), n1, n2)
+	}
+
+	nTotal := float64(n1 + n2)
+	mean := float64(n1*n2) / 2
+	variance := float64(n1*n2) / 12 * ((nTotal + 1) - tieCorrection/(nTotal*(nTotal-1)))
+	if variance <= 0 {
+		return 1
+	}
+	// Continuity correction toward the mean
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		z = 0
+	}
+	return math.Min(1, math.Erfc(z/math.Sqrt2))
+}
+
+// mannWhitneyExactP computes the exact two-sided p-value for U by counting
+// the rank arrangements that produce each U statistic.
+func mannWhitneyExactP(u, n1, n2 int) float64 {
+	maxU := n1 * n2
+	// counts[j][v] holds the number of arrangements of i x-values and j
+	// y-values with statistic v, built up row by row over i.
+	prev := make([][]float64, n2+1)
+	for j := range prev {
+		prev[j] = make([]float64, maxU+1)
+		prev[j][0] = 1
+	}
+	for i := 1; i <= n1; i++ {
+		cur := make([][]float64, n2+1)
+		for j := range cur {
+			cur[j] = make([]float64, maxU+1)
+		}
+		cur[0][0] = 1
+		for j := 1; j <= n2; j++ {
+			for v := 0; v <= i*j; v++ {
+				// Largest value is a y: U unchanged. Largest value is an x: it
+				// beats all j y-values.
+				cur[j][v] = cur[j-1][v]
+				if v >= j {
+					cur[j][v] += prev[j][v-j]
+				}
+			}
+		}
+		prev = cur
+	}
+
+	dist := prev[n2]
+	total := 0.0
+	for _, c := range dist {
+		total += c
+	}
+	lower, upper := 0.0, 0.0
+	for v, c := range dist {
+		if v <= u {
+			lower += c
+		}
+		if v >= u {
+			upper += c
+		}
+	}
+	return math.Min(1, 2*math.Min(lower, upper)/total)
+}
@@ -19,6 +19,7 @@ var DefaultPriorities = map[AssessmentCategory]int{
 	CategoryDependencies:   2, // High priority (supply-chain risk)
 	CategoryStaticAnalysis: 3, // Code correctness, potential bugs
 	CategoryTypecheck:      3, // Type correctness, API contracts
+	CategoryCoverage:       4, // Test adequacy; runs the test suite
 	CategoryLint:           4, // Code quality, variable effort
 	CategoryPerformance:    5, // Optimization, may be deferred
 }
@@ -47,6 +47,14 @@ type SchemaMappingConfig struct {
 	Strict        bool    `json:"strict,omitempty"`
 }
 
+// SchemaCompatConfig configures the breaking-change check across versioned
+// schema directories (schemas/<area>/vX.Y.Z/).
+type SchemaCompatConfig struct {
+	Enabled bool   `json:"enabled,omitempty"`
+	Root    string `json:"root,omitempty"`   // Directory scanned for version series (default: <repo>/schemas)
+	Policy  string `json:"policy,omitempty"` // writers (default), readers or both
+}
+
 // AssessmentConfig contains configuration for running assessments
 type AssessmentConfig struct {
 	Mode         AssessmentMode `json:"mode"`          // Operation mode
@@ -81,6 +89,7 @@ type AssessmentConfig struct {
 	SchemaPatterns      []string            `json:"schema_patterns,omitempty"`       // Custom glob patterns for schema files
 	SchemaDiscoveryMode string              `json:"schema_discovery_mode,omitempty"` // Discovery mode: "schemas-dir" (default) or "all"
 	SchemaMapping       SchemaMappingConfig `json:"schema_mapping,omitempty"`
+	SchemaCompat        SchemaCompatConfig  `json:"schema_compat,omitempty"`
 
 	// Scoped discovery (limits traversal to include dirs and force-include anchors)
 	Scope bool `json:"scope,omitempty"`
@@ -120,6 +129,9 @@ type AssessmentConfig struct {
 	LintMakeEnabled       bool     `json:"lint_make_enabled,omitempty"`
 	LintMakePaths         []string `json:"lint_make_paths,omitempty"`
 	LintMakeExclude       []string `json:"lint_make_exclude,omitempty"`
+
+	// Performance: record the current benchmark run as the new baseline instead of comparing
+	WriteBenchmarkBaseline bool `json:"write_benchmark_baseline,omitempty"`
 }
 
 // DefaultAssessmentConfig returns default assessment configuration
@@ -0,0 +1,48 @@
+package assess
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/schema/apispec"
+)
+
+// validateAPISpecBytes runs the OpenAPI/AsyncAPI checks on a document that
+// declares a top-level openapi or asyncapi version. Issues use the spec
+// family ("openapi" or "asyncapi") as sub-category.
+func (r *SchemaAssessmentRunner) validateAPISpecBytes(path string, data []byte) []Issue {
+	result, err := apispec.Validate(path, data, apispec.Options{ReadFile: safeReadFile})
+	if err != nil {
+		return []Issue{{
+			File:          path,
+			Severity:      SeverityHigh,
+			Message:       fmt.Sprintf("API description validation failed: %v", err),
+			Category:      CategorySchema,
+			SubCategory:   "schema_analysis",
+			AutoFixable:   false,
+			EstimatedTime: HumanReadableDuration(1 * time.Minute),
+		}}
+	}
+
+	issues := make([]Issue, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		severity := SeverityHigh
+		estimate := 3 * time.Minute
+		if issue.Rule == apispec.RuleExample {
+			severity = SeverityMedium
+			estimate = 2 * time.Minute
+		}
+		issues = append(issues, Issue{
+			File:          issue.File,
+			Line:          issue.Line,
+			Column:        issue.Column,
+			Severity:      severity,
+			Message:       fmt.Sprintf("[%s] %s: %s", issue.Rule, issue.Pointer, issue.Message),
+			Category:      CategorySchema,
+			SubCategory:   result.Family,
+			AutoFixable:   false,
+			EstimatedTime: HumanReadableDuration(estimate),
+		})
+	}
+	return issues
+}
@@ -0,0 +1,88 @@
+package assess
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/schema/compat"
+)
+
+// checkSchemaCompat compares consecutive vX.Y.Z schema directories and
+// reports every change that needs a larger version bump than the one made
+func (r *SchemaAssessmentRunner) checkSchemaCompat(repoRoot string, config AssessmentConfig) ([]Issue, map[string]interface{}, error) {
+	policy, err := compat.ParsePolicy(config.SchemaCompat.Policy)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := config.SchemaCompat.Root
+	if root == "" {
+		root = filepath.Join(repoRoot, "schemas")
+	} else if !filepath.IsAbs(root) {
+		root = filepath.Join(repoRoot, root)
+	}
+
+	series, err := compat.DiscoverVersionSeries(root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var issues []Issue
+	pairs, violations := 0, 0
+	for _, s := range series {
+		reports, err := compat.CheckVersionSeries(s, compat.CompareOptions{Policy: policy})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, report := range reports {
+			pairs++
+			if report.Compliant {
+				continue
+			}
+			violations++
+			issues = append(issues, schemaCompatIssues(report)...)
+		}
+	}
+
+	metrics := map[string]interface{}{
+		"schema_compat_series":     len(series),
+		"schema_compat_pairs":      pairs,
+		"schema_compat_violations": violations,
+		"schema_compat_policy":     string(policy),
+	}
+	return issues, metrics, nil
+}
+
+func schemaCompatIssues(report *compat.Report) []Issue {
+	var issues []Issue
+	for _, file := range report.Files {
+		path := filepath.Join(report.New, filepath.FromSlash(file.Path))
+		if file.Status == compat.FileRemoved {
+			path = filepath.Join(report.Old, filepath.FromSlash(file.Path))
+		}
+		for _, change := range file.Changes {
+			required := change.RequiredBump(report.Policy)
+			if required <= report.Actual {
+				continue
+			}
+			severity := SeverityLow
+			switch required {
+			case compat.BumpMajor:
+				severity = SeverityHigh
+			case compat.BumpMinor:
+				severity = SeverityMedium
+			}
+			issues = append(issues, Issue{
+				File:     path,
+				Severity: severity,
+				Message: fmt.Sprintf("%s -> %s is a %s bump but %s %s requires %s: %s",
+					report.OldVersion, report.NewVersion, report.Actual, change.Path, change.Kind, required, change.Message),
+				Category:      CategorySchema,
+				SubCategory:   "schema_compat",
+				AutoFixable:   false,
+				EstimatedTime: HumanReadableDuration(5 * time.Minute),
+			})
+		}
+	}
+	return issues
+}
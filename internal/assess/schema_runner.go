@@ -15,6 +15,7 @@ import (
 	"encoding/json"
 
 	"github.com/fulmenhq/goneat/pkg/schema"
+	"github.com/fulmenhq/goneat/pkg/schema/apispec"
 	"github.com/fulmenhq/goneat/pkg/schema/mapping"
 	"github.com/fulmenhq/goneat/pkg/work"
 	"golang.org/x/sync/errgroup"
@@ -157,6 +158,23 @@ func (r *SchemaAssessmentRunner) Assess(ctx context.Context, target string, conf
 		}
 	}
 
+	if config.SchemaCompat.Enabled {
+		compatIssues, compatMetrics, err := r.checkSchemaCompat(repoRoot, config)
+		if err != nil {
+			return &AssessmentResult{
+				CommandName:   r.commandName,
+				Category:      CategorySchema,
+				Success:       false,
+				ExecutionTime: HumanReadableDuration(time.Since(start)),
+				Error:         fmt.Sprintf("schema compatibility check failed: %v", err),
+			}, nil
+		}
+		issues = append(issues, compatIssues...)
+		for k, v := range compatMetrics {
+			metrics[k] = v
+		}
+	}
+
 	if len(issues) > 1 {
 		sortIssuesDeterministic(issues)
 	}
@@ -297,6 +315,11 @@ func (r *SchemaAssessmentRunner) validateSchemaCandidate(ctx context.Context, pa
 		}
 	}
 
+	// OpenAPI and AsyncAPI documents get their own structural and semantic checks
+	if _, _, isAPISpec := apispec.Detect(data); isAPISpec {
+		return r.validateAPISpecBytes(path, data), true
+	}
+
 	isSchema, draft, err := r.detectSchemaInfoFromBytes(data)
 	if err != nil {
 		return []Issue{{
@@ -910,7 +933,7 @@ func (r *SchemaAssessmentRunner) isSchemaCandidate(path string, config Assessmen
 		// Original behavior: only files under directories named "schemas"
 		return isUnderSchemas(path)
 	case "all":
-		// Enhanced behavior: check if file has $schema field
+		// Enhanced behavior: check if file has $schema field or is an API description
 		return r.hasSchemaField(path)
 	default:
 		// Unknown mode, fall back to schemas-dir
@@ -918,7 +941,8 @@ func (r *SchemaAssessmentRunner) isSchemaCandidate(path string, config Assessmen
 	}
 }
 
-// hasSchemaField checks if a JSON/YAML file contains a $schema field
+// hasSchemaField checks if a JSON/YAML file contains a $schema field or is an
+// OpenAPI/AsyncAPI document
 func (r *SchemaAssessmentRunner) hasSchemaField(path string) bool {
 	data, err := safeReadFile(path)
 	if err != nil {
@@ -934,9 +958,11 @@ func (r *SchemaAssessmentRunner) hasSchemaField(path string) bool {
 		}
 	}
 
-	// Check for $schema field
+	// Check for $schema field, or an OpenAPI/AsyncAPI version
 	_, hasSchema := doc["$schema"]
-	return hasSchema
+	_, isOpenAPI := doc["openapi"]
+	_, isAsyncAPI := doc["asyncapi"]
+	return hasSchema || isOpenAPI || isAsyncAPI
 }
 
 // extractDraftFromURL extracts the draft version from a $schema URL
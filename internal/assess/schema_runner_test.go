@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -147,3 +148,106 @@ func TestSchemaRunner_ConfigMappingFailure(t *testing.T) {
 		t.Fatalf("expected schema mapping issue, got %+v", res.Issues)
 	}
 }
+
+func TestSchemaRunner_SchemaCompat(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir .git: %v", err)
+	}
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+	write("schemas/widgets/v1.0.0/widget.json", `{"type":"object","properties":{"name":{"type":"string"}}}`)
+	write("schemas/widgets/v1.1.0/widget.json", `{"type":"object","properties":{"name":{"type":"string"}},"required":["name"]}`)
+	write("schemas/gadgets/v1.0.0/gadget.json", `{"type":"object","properties":{"size":{"enum":["s"]}}}`)
+	write("schemas/gadgets/v1.1.0/gadget.json", `{"type":"object","properties":{"size":{"enum":["s","m"]}}}`)
+
+	r := NewSchemaAssessmentRunner()
+	cfg := AssessmentConfig{
+		Mode:         AssessmentModeCheck,
+		Timeout:      30 * time.Second,
+		SchemaCompat: SchemaCompatConfig{Enabled: true},
+	}
+	res, err := r.Assess(context.Background(), root, cfg)
+	if err != nil {
+		t.Fatalf("assess returned error: %v", err)
+	}
+	if !res.Success {
+		t.Fatalf("expected success flag, got error %q", res.Error)
+	}
+
+	var compatIssues []Issue
+	for _, issue := range res.Issues {
+		if issue.SubCategory == "schema_compat" {
+			compatIssues = append(compatIssues, issue)
+		}
+	}
+	if len(compatIssues) != 1 {
+		t.Fatalf("expected 1 schema_compat issue, got %+v", compatIssues)
+	}
+	if compatIssues[0].Severity != SeverityHigh || filepath.Base(compatIssues[0].File) != "widget.json" {
+		t.Fatalf("unexpected issue: %+v", compatIssues[0])
+	}
+	if pairs, _ := res.Metrics["schema_compat_pairs"].(int); pairs != 2 {
+		t.Fatalf("expected 2 compared version pairs, got %+v", res.Metrics)
+	}
+
+	cfg.SchemaCompat.Policy = "readers"
+	res, err = r.Assess(context.Background(), root, cfg)
+	if err != nil {
+		t.Fatalf("assess returned error: %v", err)
+	}
+	if violations, _ := res.Metrics["schema_compat_violations"].(int); violations != 1 {
+		t.Fatalf("readers policy: expected only the widened enum to violate, got %+v", res.Metrics)
+	}
+}
+
+func TestSchemaRunner_APISpecs(t *testing.T) {
+	r := NewSchemaAssessmentRunner()
+	cfg := AssessmentConfig{Mode: AssessmentModeCheck, Timeout: 30 * time.Second}
+	base := filepath.Join("..", "..", "tests", "fixtures", "schemas")
+
+	cfg.IncludeFiles = []string{filepath.Join(base, "openapi-3", "good", "split", "api.yaml")}
+	res, err := r.Assess(context.Background(), ".", cfg)
+	if err != nil {
+		t.Fatalf("assess returned error: %v", err)
+	}
+	if len(res.Issues) != 0 {
+		t.Fatalf("expected no issues for split OpenAPI document, got %+v", res.Issues)
+	}
+
+	cfg.IncludeFiles = []string{
+		filepath.Join(base, "openapi-3", "bad", "petstore-issues.yaml"),
+		filepath.Join(base, "asyncapi-3", "bad", "missing-action.yaml"),
+	}
+	res, err = r.Assess(context.Background(), ".", cfg)
+	if err != nil {
+		t.Fatalf("assess returned error: %v", err)
+	}
+	got := make(map[string]Issue)
+	for _, issue := range res.Issues {
+		got[issue.SubCategory+":"+filepath.Base(issue.File)+":"+strconv.Itoa(issue.Line)] = issue
+	}
+	for _, key := range []string{
+		"openapi:petstore-issues.yaml:24", // {petId} not declared
+		"openapi:petstore-issues.yaml:25", // duplicate operationId
+		"asyncapi:missing-action.yaml:21", // operation without action
+	} {
+		if got[key].Severity != SeverityHigh {
+			t.Errorf("expected high severity issue %s in %+v", key, res.Issues)
+		}
+	}
+	if example := got["asyncapi:missing-action.yaml:18"]; example.Severity != SeverityMedium || example.Column == 0 {
+		t.Errorf("expected positioned medium severity example issue, got %+v", example)
+	}
+	if len(res.Issues) != 10 {
+		t.Fatalf("expected 10 issues, got %d: %+v", len(res.Issues), res.Issues)
+	}
+}
@@ -5,12 +5,15 @@ package assess
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	intdoctor "github.com/fulmenhq/goneat/internal/doctor"
 	"github.com/fulmenhq/goneat/pkg/logger"
+	pkgtools "github.com/fulmenhq/goneat/pkg/tools"
 	"github.com/fulmenhq/goneat/pkg/versioning"
 )
 
@@ -85,11 +88,13 @@ func (r *ToolsRunner) Assess(ctx context.Context, target string, config Assessme
 	var presentCount int
 	var missingTools []string
 	var policyViolations []Issue
+	var installed []intdoctor.Tool
 
 	for _, tool := range selected {
 		status := intdoctor.CheckTool(tool)
 		if status.Present {
 			presentCount++
+			installed = append(installed, tool)
 			logger.Debug(fmt.Sprintf("Tool %s is present (version: %s)", tool.Name, status.Version))
 
 			// Apply policy if present
@@ -133,8 +138,31 @@ func (r *ToolsRunner) Assess(ctx context.Context, target string, config Assessme
 		}
 	}
 
+	// Report drift from .goneat/tools.lock when the repository has one
+	var lockDrifts []Issue
+	if lock, _, err := intdoctor.LoadToolsLock(); err == nil {
+		// Missing tools are already reported above; only installed ones can drift
+		for _, drift := range intdoctor.CheckLockDrift(lock, "foundation", installed) {
+			severity := SeverityMedium
+			if drift.Kind == intdoctor.LockDriftBinary || drift.Kind == intdoctor.LockDriftArtifact {
+				severity = SeverityHigh
+			}
+			lockDrifts = append(lockDrifts, Issue{
+				File:        pkgtools.LockFileName,
+				Line:        0,
+				Severity:    severity,
+				Message:     fmt.Sprintf("Tool lock drift: %s", drift),
+				Category:    r.GetCategory(),
+				SubCategory: "lock_drift",
+			})
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		logger.Warn(fmt.Sprintf("tools lock check skipped: %v", err))
+	}
+
 	// Combine issues
 	issues = append(issues, policyViolations...)
+	issues = append(issues, lockDrifts...)
 
 	// Determine overall success
 	success := len(issues) == 0
@@ -146,6 +174,7 @@ func (r *ToolsRunner) Assess(ctx context.Context, target string, config Assessme
 		"tools_missing":     len(missingTools),
 		"missing_tools":     missingTools,
 		"policy_violations": len(policyViolations),
+		"lock_drift":        len(lockDrifts),
 	}
 
 	return &AssessmentResult{
@@ -191,10 +220,15 @@ func convertToolsConfigToLegacy(toolConfigs []intdoctor.ToolConfig) ([]intdoctor
 			Description:   tc.Description,
 			Kind:          tc.Kind,
 			DetectCommand: tc.DetectCommand,
+			VersionArgs:   tc.VersionArgs,
+			CheckArgs:     tc.CheckArgs,
+			Platforms:     tc.Platforms,
+			Artifacts:     tc.Artifacts,
 		}
 
-		// Parse DetectCommand to set VersionArgs for version detection
-		if tc.DetectCommand != "" {
+		// Parse DetectCommand to set VersionArgs for version detection, as
+		// doctor does when the config declares none
+		if len(tool.VersionArgs) == 0 && tc.DetectCommand != "" {
 			parts := strings.Fields(tc.DetectCommand)
 			if len(parts) > 1 {
 				// Assume the command is "tool --version" or similar
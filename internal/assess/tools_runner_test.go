@@ -2,6 +2,8 @@ package assess
 
 import (
 	"context"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/fulmenhq/goneat/internal/doctor"
@@ -126,3 +128,45 @@ func TestConvertToolsConfigToLegacy(t *testing.T) {
 		t.Error("Install methods should not be empty")
 	}
 }
+
+func TestToolsRunner_Assess_MissingLockedToolReportedOnce(t *testing.T) {
+	repo := t.TempDir()
+	writeTestFile(t, filepath.Join(repo, ".goneat", "tools.yaml"), `scopes:
+  foundation:
+    description: Foundation tools
+    tools:
+      - gone-tool-12345
+tools:
+  gone-tool-12345:
+    name: gone-tool-12345
+    description: A tool that is not installed
+    kind: system
+    detect_command: gone-tool-12345 --version
+    install_commands:
+      linux: echo install
+`)
+	writeTestFile(t, filepath.Join(repo, ".goneat", "tools.lock"), `version: 1
+platform: linux/amd64
+scopes:
+  foundation:
+    - name: gone-tool-12345
+      version: 1.0.0
+      install_method: system
+`)
+	t.Chdir(repo)
+	t.Setenv("PATH", t.TempDir())
+
+	result, err := NewToolsRunner().Assess(context.Background(), ".", AssessmentConfig{})
+	if err != nil {
+		t.Fatalf("Assess returned error: %v", err)
+	}
+	if result.Error != "" {
+		t.Fatalf("Assess failed: %s", result.Error)
+	}
+	if len(result.Issues) != 1 {
+		t.Fatalf("expected a single issue for the missing tool, got %+v", result.Issues)
+	}
+	if !strings.Contains(result.Issues[0].Message, "is not installed") {
+		t.Fatalf("expected the not-installed issue, got %q", result.Issues[0].Message)
+	}
+}
@@ -95,6 +95,7 @@ const (
 	CategoryRepoStatus     AssessmentCategory = "repo-status"
 	CategoryDependencies   AssessmentCategory = "dependencies"
 	CategoryTypecheck      AssessmentCategory = "typecheck"
+	CategoryCoverage       AssessmentCategory = "coverage"
 )
 
 // IssueSeverity represents the severity level of an assessment issue
@@ -21,9 +21,11 @@ import (
 )
 
 type assessOverrides struct {
-	Version   int                 `yaml:"version"`
-	Lint      *lintOverrides      `yaml:"lint"`
-	Typecheck *typecheckOverrides `yaml:"typecheck"`
+	Version     int                   `yaml:"version"`
+	Lint        *lintOverrides        `yaml:"lint"`
+	Typecheck   *typecheckOverrides   `yaml:"typecheck"`
+	Performance *performanceOverrides `yaml:"performance"`
+	Coverage    *coverageOverrides    `yaml:"coverage"`
 }
 
 type lintOverrides struct {
@@ -47,6 +47,7 @@ func GetSchemaNames() []SchemaInfo {
 		"schema-mapping-manifest-v1.0.0": "embedded_schemas/schemas/config-mapping/v1.0.0/schema-mapping-manifest.yaml",
 		"version-policy-v1.0.0":          "embedded_schemas/schemas/crucible-go/config/goneat/v1.0.0/version-policy.schema.yaml",
 		"dependency-analysis-v1.0.0":     "embedded_schemas/schemas/dependencies/v1.0.0/dependency-analysis.schema.json",
+		"dependency-policy-input-v1.0.0": "embedded_schemas/schemas/dependencies/v1.0.0/policy-input.schema.json",
 		"ssot-provenance-v1":             "embedded_schemas/schemas/crucible-go/content/ssot-provenance/v1.0.0/ssot-provenance.schema.json",
 		"ssot-source-metadata-v1":        "embedded_schemas/schemas/ssot/source-metadata.v1.json",
 		"json-schema-draft-04":           "embedded_schemas/schemas/meta/draft-04/schema.json",
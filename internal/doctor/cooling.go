@@ -205,7 +205,14 @@ func fetchToolMetadata(tool Tool, reg *metadata.Registry) (*metadata.Metadata, e
 		repo, version = extractRepoFromArtifacts(tool.Artifacts)
 	}
 
-	// Infer repo from install identity
+	// Infer repo from install identity. Go-installed tools resolve through the
+	// module proxy, so their publish dates come from GOPROXY rather than GitHub.
+	if repo == "" && tool.Kind == "go" {
+		repo = metadata.GoModuleRepoFromInstallPackage(tool.InstallPackage)
+		if _, pinned, ok := strings.Cut(tool.InstallPackage, "@"); ok && repo != "" && pinned != "latest" {
+			version = pinned
+		}
+	}
 	if repo == "" {
 		if goRepo := inferRepoFromGoInstallPackage(tool.InstallPackage); goRepo != "" {
 			repo = goRepo
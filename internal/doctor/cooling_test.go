@@ -1,6 +1,12 @@
 package doctor
 
-import "testing"
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/tools/metadata"
+)
 
 func TestInferRepoFromGoInstallPackage(t *testing.T) {
 	if got := inferRepoFromGoInstallPackage("github.com/rhysd/actionlint/cmd/actionlint@latest"); got != "rhysd/actionlint" {
@@ -29,3 +35,43 @@ func TestInferRepoFromPythonInstall(t *testing.T) {
 		t.Fatalf("expected pypi:yamllint, got %q", got)
 	}
 }
+
+type recordingFetcher struct {
+	repo, version string
+}
+
+func (f *recordingFetcher) FetchMetadata(repo, version string) (*metadata.Metadata, error) {
+	f.repo, f.version = repo, version
+	return metadata.ManualMetadata(version, time.Now()), nil
+}
+
+func (f *recordingFetcher) FetchLatestMetadata(repo string) (*metadata.Metadata, error) {
+	f.repo, f.version = repo, "latest"
+	return metadata.ManualMetadata("latest", time.Now()), nil
+}
+
+func (f *recordingFetcher) SupportsRepo(repo string) bool {
+	return strings.HasPrefix(repo, metadata.GoModuleRepoPrefix)
+}
+
+func TestFetchToolMetadata_GoToolUsesModuleProxy(t *testing.T) {
+	fetcher := &recordingFetcher{}
+	reg := metadata.NewRegistry(time.Hour)
+	reg.RegisterFetcher("gomodule", fetcher)
+
+	tool := Tool{Name: "go-licenses", Kind: "go", InstallPackage: "github.com/google/go-licenses/v2@v2.0.1"}
+	if _, err := fetchToolMetadata(tool, &reg); err != nil {
+		t.Fatalf("fetchToolMetadata failed: %v", err)
+	}
+	if fetcher.repo != "gomod/github.com/google/go-licenses/v2" || fetcher.version != "v2.0.1" {
+		t.Fatalf("unexpected lookup %s@%s", fetcher.repo, fetcher.version)
+	}
+
+	tool = Tool{Name: "govulncheck", Kind: "go", InstallPackage: "golang.org/x/vuln/cmd/govulncheck@latest"}
+	if _, err := fetchToolMetadata(tool, &reg); err != nil {
+		t.Fatalf("fetchToolMetadata failed: %v", err)
+	}
+	if fetcher.repo != "gomod/golang.org/x/vuln/cmd/govulncheck" || fetcher.version != "latest" {
+		t.Fatalf("unexpected lookup %s@%s", fetcher.repo, fetcher.version)
+	}
+}
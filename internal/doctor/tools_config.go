@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	pkgtools "github.com/fulmenhq/goneat/pkg/tools"
 )
@@ -92,3 +93,72 @@ func ParseConfig(configBytes []byte) (*ToolsConfig, error) {
 func ValidateConfig(configPath string) error {
 	return pkgtools.ValidateFile(configPath)
 }
+
+// ToolFromConfig converts a ToolConfig into the Tool form used by CheckTool and InstallTool.
+func ToolFromConfig(toolConfig ToolConfig) (Tool, error) {
+	tool := Tool{
+		Name:           toolConfig.Name,
+		Kind:           toolConfig.Kind,
+		InstallPackage: toolConfig.InstallPackage,
+		VersionArgs:    toolConfig.VersionArgs,
+		CheckArgs:      toolConfig.CheckArgs,
+		Description:    toolConfig.Description,
+		Platforms:      toolConfig.Platforms,
+		DetectCommand:  toolConfig.DetectCommand,
+	}
+
+	if policy, err := toolConfig.VersionPolicy(); err != nil {
+		return Tool{}, err
+	} else {
+		tool.VersionPolicy = policy
+	}
+
+	if len(toolConfig.InstallCommands) > 0 {
+		tool.InstallCommands = make(map[string]string, len(toolConfig.InstallCommands))
+		tool.InstallMethods = make(map[string]InstallMethod)
+		for key, command := range toolConfig.InstallCommands {
+			tool.InstallCommands[key] = command
+			switch key {
+			case "darwin", "linux", "windows", "all":
+				cmdCopy := command
+				detectCmd := toolConfig.DetectCommand
+				tool.InstallMethods[key] = InstallMethod{
+					Detector: func() (string, bool) {
+						parts := strings.Fields(detectCmd)
+						if len(parts) == 0 {
+							return "", false
+						}
+						return TryCommand(parts[0], parts[1:]...)
+					},
+					Installer: func() error {
+						return ExecuteInstallCommand(cmdCopy)
+					},
+					Instructions: command,
+				}
+			}
+		}
+	}
+
+	if len(toolConfig.InstallerPriority) > 0 {
+		tool.InstallerPriority = make(map[string][]string, len(toolConfig.InstallerPriority))
+		for platform, priorities := range toolConfig.InstallerPriority {
+			tool.InstallerPriority[platform] = append([]string(nil), priorities...)
+		}
+	}
+
+	if toolConfig.Artifacts != nil {
+		tool.Artifacts = toolConfig.Artifacts
+	}
+
+	// Copy cooling policy configuration
+	if toolConfig.Cooling != nil {
+		tool.Cooling = toolConfig.Cooling
+	}
+
+	// Copy recommended version for metadata fetching
+	if toolConfig.RecommendedVersion != "" {
+		tool.RecommendedVersion = toolConfig.RecommendedVersion
+	}
+
+	return tool, nil
+}
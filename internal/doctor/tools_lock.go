@@ -0,0 +1,238 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/tools"
+)
+
+// ToolsLock aliases the lockfile model from pkg/tools.
+type ToolsLock = tools.Lock
+
+// LockDriftKind classifies how an installed tool deviates from the lockfile.
+type LockDriftKind string
+
+const (
+	LockDriftMissing       LockDriftKind = "missing"        // locked tool is not installed
+	LockDriftUnlocked      LockDriftKind = "unlocked"       // configured tool has no lock entry
+	LockDriftVersion       LockDriftKind = "version"        // installed version differs from lock
+	LockDriftInstallMethod LockDriftKind = "install_method" // installed via a different method
+	LockDriftBinary        LockDriftKind = "binary"         // binary digest differs from lock
+	LockDriftArtifact      LockDriftKind = "artifact"       // configured artifact digest differs from lock
+)
+
+// LockDrift describes a single mismatch between the lockfile and the installed tool.
+type LockDrift struct {
+	Scope    string
+	Tool     string
+	Kind     LockDriftKind
+	Expected string
+	Actual   string
+}
+
+// String renders the drift as a human-readable message.
+func (d LockDrift) String() string {
+	switch d.Kind {
+	case LockDriftMissing:
+		return fmt.Sprintf("%s is locked at %s but not installed", d.Tool, d.Expected)
+	case LockDriftUnlocked:
+		return fmt.Sprintf("%s is configured in scope '%s' but missing from %s (run 'goneat doctor tools lock')", d.Tool, d.Scope, tools.LockFileName)
+	case LockDriftVersion:
+		return fmt.Sprintf("%s version %s does not match locked version %s", d.Tool, d.Actual, d.Expected)
+	case LockDriftInstallMethod:
+		return fmt.Sprintf("%s installed via %s but locked to %s", d.Tool, d.Actual, d.Expected)
+	case LockDriftBinary:
+		return fmt.Sprintf("%s binary sha256 %s does not match locked %s", d.Tool, shortDigest(d.Actual), shortDigest(d.Expected))
+	case LockDriftArtifact:
+		return fmt.Sprintf("%s artifact sha256 %s does not match locked %s", d.Tool, shortDigest(d.Actual), shortDigest(d.Expected))
+	default:
+		return fmt.Sprintf("%s drifted from lock (%s)", d.Tool, d.Kind)
+	}
+}
+
+// FindToolsLock searches up the directory tree for .goneat/tools.lock.
+// Returns os.ErrNotExist when no lockfile is present.
+func FindToolsLock() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		lockPath := filepath.Join(dir, tools.LockFileName)
+		if _, err := os.Stat(lockPath); err == nil {
+			return lockPath, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", os.ErrNotExist
+		}
+		dir = parent
+	}
+}
+
+// LoadToolsLock loads the repository lockfile, or returns os.ErrNotExist if none exists.
+func LoadToolsLock() (*ToolsLock, string, error) {
+	lockPath, err := FindToolsLock()
+	if err != nil {
+		return nil, "", err
+	}
+	lock, err := tools.LoadLock(lockPath)
+	if err != nil {
+		return nil, lockPath, fmt.Errorf("failed to load %s: %w", lockPath, err)
+	}
+	return lock, lockPath, nil
+}
+
+// ResolveLockEntry inspects the installed tool and returns the entry that
+// `goneat doctor tools lock` would record for it.
+func ResolveLockEntry(t Tool) (tools.LockedTool, error) {
+	status := CheckTool(t)
+	if !status.Present {
+		return tools.LockedTool{}, fmt.Errorf("%s is not installed", t.Name)
+	}
+
+	entry := tools.LockedTool{
+		Name:    t.Name,
+		Version: status.Version,
+	}
+
+	binaryPath, err := resolveInstalledBinary(t)
+	if err != nil {
+		// Detect-command only tools (e.g. bundled toolchain components) have no
+		// resolvable binary; record what we know.
+		entry.InstallMethod = detectInstallMethod(t, "")
+		return entry, nil
+	}
+	entry.InstallMethod = detectInstallMethod(t, binaryPath)
+
+	digest, err := tools.FileSHA256(binaryPath)
+	if err != nil {
+		return tools.LockedTool{}, fmt.Errorf("failed to hash %s: %w", binaryPath, err)
+	}
+	entry.BinarySHA256 = digest
+
+	if t.Artifacts != nil {
+		if artifact, err := t.Artifacts.ArtifactForVersion(status.Version); err == nil {
+			entry.ArtifactSHA256 = artifact.SHA256
+		}
+	}
+
+	return entry, nil
+}
+
+// CheckLockDrift compares the installed tools against their lock entries for a scope.
+// Platform-specific digests are only compared when the lock was generated on
+// the current platform.
+func CheckLockDrift(lock *ToolsLock, scope string, selected []Tool) []LockDrift {
+	var drifts []LockDrift
+	for _, t := range selected {
+		if !SupportsCurrentPlatform(t) {
+			continue
+		}
+		locked, ok := lock.Find(scope, t.Name)
+		if !ok {
+			// Scope "all" and ad-hoc --tools selections may not match a locked scope name.
+			locked, ok = lock.FindAny(t.Name)
+		}
+		if !ok {
+			drifts = append(drifts, LockDrift{Scope: scope, Tool: t.Name, Kind: LockDriftUnlocked})
+			continue
+		}
+		drifts = append(drifts, compareLockEntry(lock, scope, t, locked)...)
+	}
+	return drifts
+}
+
+func compareLockEntry(lock *ToolsLock, scope string, t Tool, locked tools.LockedTool) []LockDrift {
+	actual, err := ResolveLockEntry(t)
+	if err != nil {
+		return []LockDrift{{Scope: scope, Tool: t.Name, Kind: LockDriftMissing, Expected: locked.Version}}
+	}
+
+	var drifts []LockDrift
+	if actual.Version != locked.Version {
+		drifts = append(drifts, LockDrift{Scope: scope, Tool: t.Name, Kind: LockDriftVersion, Expected: locked.Version, Actual: actual.Version})
+	}
+	if locked.ArtifactSHA256 != "" && actual.ArtifactSHA256 != "" && actual.ArtifactSHA256 != locked.ArtifactSHA256 {
+		drifts = append(drifts, LockDrift{Scope: scope, Tool: t.Name, Kind: LockDriftArtifact, Expected: locked.ArtifactSHA256, Actual: actual.ArtifactSHA256})
+	}
+	if !lock.SamePlatform() {
+		return drifts
+	}
+	if locked.InstallMethod != "" && actual.InstallMethod != locked.InstallMethod {
+		drifts = append(drifts, LockDrift{Scope: scope, Tool: t.Name, Kind: LockDriftInstallMethod, Expected: locked.InstallMethod, Actual: actual.InstallMethod})
+	}
+	if locked.BinarySHA256 != "" && actual.BinarySHA256 != locked.BinarySHA256 {
+		drifts = append(drifts, LockDrift{Scope: scope, Tool: t.Name, Kind: LockDriftBinary, Expected: locked.BinarySHA256, Actual: actual.BinarySHA256})
+	}
+	return drifts
+}
+
+// resolveInstalledBinary finds the binary that CheckTool considers active.
+func resolveInstalledBinary(t Tool) (string, error) {
+	if t.Artifacts != nil {
+		if path, err := resolveToolPath(t.Name); err == nil {
+			return path, nil
+		}
+	}
+	for _, name := range candidateBinaryNames(t) {
+		if path, err := exec.LookPath(name); err == nil {
+			if resolved, err := filepath.EvalSymlinks(path); err == nil {
+				return resolved, nil
+			}
+			return path, nil
+		}
+	}
+	return "", errors.New("binary not found")
+}
+
+// detectInstallMethod infers how a tool was installed from its kind and binary location.
+func detectInstallMethod(t Tool, binaryPath string) string {
+	if t.Kind == "bundled-go" {
+		return "bundled-go"
+	}
+	if binaryPath == "" {
+		return string(installerManual)
+	}
+	if t.Artifacts != nil {
+		if binDir, err := tools.GetBinDir(); err == nil && strings.HasPrefix(binaryPath, binDir) {
+			return "artifact"
+		}
+	}
+
+	slashed := filepath.ToSlash(binaryPath)
+	switch {
+	case strings.Contains(slashed, "/mise/"):
+		return string(installerMise)
+	case strings.Contains(slashed, "/Cellar/") || strings.Contains(slashed, "/homebrew/") || strings.Contains(slashed, "/linuxbrew/"):
+		return string(installerBrew)
+	case strings.Contains(strings.ToLower(slashed), "/scoop/"):
+		return string(installerScoop)
+	case strings.Contains(slashed, "/.bun/"):
+		return string(installerBun)
+	case strings.Contains(slashed, "/.cargo/bin/"):
+		return string(installerCargoInstall)
+	}
+	if goBin := getGoBinPath(); goBin != "" && strings.HasPrefix(binaryPath, goBin) {
+		return string(installerGoInstall)
+	}
+	if t.Kind == "go" {
+		return string(installerGoInstall)
+	}
+	return "system"
+}
+
+func shortDigest(digest string) string {
+	if len(digest) > 12 {
+		return digest[:12]
+	}
+	if digest == "" {
+		return "(none)"
+	}
+	return digest
+}
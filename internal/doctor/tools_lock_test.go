@@ -0,0 +1,91 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/fulmenhq/goneat/pkg/tools"
+)
+
+// writeFakeTool creates an executable script that prints the given version.
+func writeFakeTool(t *testing.T, dir, name, version string) {
+	t.Helper()
+	script := "#!/bin/sh\necho \"" + name + " " + version + "\"\n"
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755); err != nil { // #nosec G306 - test executable
+		t.Fatalf("failed to write fake tool: %v", err)
+	}
+}
+
+func TestResolveLockEntryAndDrift(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script fixtures are not executable on Windows")
+	}
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir)
+	t.Setenv("GONEAT_HOME", t.TempDir())
+	writeFakeTool(t, binDir, "locktool", "1.2.3")
+
+	tool := Tool{Name: "locktool", Kind: "system", VersionArgs: []string{"--version"}}
+
+	entry, err := ResolveLockEntry(tool)
+	if err != nil {
+		t.Fatalf("ResolveLockEntry failed: %v", err)
+	}
+	if entry.Version != "1.2.3" {
+		t.Errorf("expected version 1.2.3, got %q", entry.Version)
+	}
+	if entry.BinarySHA256 == "" {
+		t.Errorf("expected binary digest, got %+v", entry)
+	}
+	if entry.InstallMethod != "system" {
+		t.Errorf("expected install method system, got %q", entry.InstallMethod)
+	}
+
+	lock := tools.NewLock()
+	lock.Scopes["foundation"] = []tools.LockedTool{entry}
+
+	if drifts := CheckLockDrift(lock, "foundation", []Tool{tool}); len(drifts) != 0 {
+		t.Fatalf("expected no drift, got %v", drifts)
+	}
+
+	// Replace the binary with a different version: both version and digest drift
+	writeFakeTool(t, binDir, "locktool", "1.3.0")
+	drifts := CheckLockDrift(lock, "foundation", []Tool{tool})
+	kinds := map[LockDriftKind]bool{}
+	for _, d := range drifts {
+		kinds[d.Kind] = true
+	}
+	if !kinds[LockDriftVersion] || !kinds[LockDriftBinary] {
+		t.Errorf("expected version and binary drift, got %v", drifts)
+	}
+
+	// Locks from another platform only compare versions
+	lock.Platform = "plan9/mips"
+	drifts = CheckLockDrift(lock, "foundation", []Tool{tool})
+	if len(drifts) != 1 || drifts[0].Kind != LockDriftVersion {
+		t.Errorf("expected only version drift for foreign platform lock, got %v", drifts)
+	}
+}
+
+func TestCheckLockDrift_MissingAndUnlocked(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	lock := tools.NewLock()
+	lock.Scopes["foundation"] = []tools.LockedTool{{Name: "gone-tool-12345", Version: "1.0.0", InstallMethod: "system"}}
+
+	drifts := CheckLockDrift(lock, "foundation", []Tool{
+		{Name: "gone-tool-12345", Kind: "system"},
+		{Name: "new-tool-12345", Kind: "system"},
+	})
+	if len(drifts) != 2 {
+		t.Fatalf("expected 2 drifts, got %v", drifts)
+	}
+	if drifts[0].Kind != LockDriftMissing {
+		t.Errorf("expected missing drift, got %s", drifts[0].Kind)
+	}
+	if drifts[1].Kind != LockDriftUnlocked {
+		t.Errorf("expected unlocked drift, got %s", drifts[1].Kind)
+	}
+}
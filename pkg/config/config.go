@@ -435,6 +435,28 @@ type CoolingException struct {
 	ApprovedBy string `mapstructure:"approved_by"`
 }
 
+// SupplyChainConfig configures the supply-chain heuristics (typosquats,
+// dependency confusion and npm install scripts).
+type SupplyChainConfig struct {
+	Enabled           bool                `mapstructure:"enabled"`
+	Typosquat         TyposquatConfig     `mapstructure:"typosquat"`
+	PrivateNamespaces []string            `mapstructure:"private_namespaces"`
+	PublicRegistries  []string            `mapstructure:"public_registries"`
+	InstallScripts    InstallScriptConfig `mapstructure:"install_scripts"`
+}
+
+type TyposquatConfig struct {
+	Enabled     bool     `mapstructure:"enabled"`
+	MaxDistance int      `mapstructure:"max_distance"`
+	Allow       []string `mapstructure:"allow"`
+}
+
+type InstallScriptConfig struct {
+	Enabled  bool     `mapstructure:"enabled"`
+	Severity string   `mapstructure:"severity"`
+	Allow    []string `mapstructure:"allow"`
+}
+
 type SBOMConfig struct {
 	Format string `mapstructure:"format"`
 	Enrich bool   `mapstructure:"enrich"`
@@ -171,6 +171,8 @@ func TestParseLicenseExpression(t *testing.T) {
 		{"complex", "(MIT OR Apache-2.0) AND BSD-3-Clause", []string{"MIT", "Apache-2.0", "BSD-3-Clause"}},
 		{"empty", "", nil},
 		{"unlicense_or_mit", "Unlicense OR MIT", []string{"Unlicense", "MIT"}},
+		{"with_exception", "Apache-2.0 WITH LLVM-exception OR MIT", []string{"Apache-2.0", "MIT"}},
+		{"lowercase_operators", "MIT or Apache-2.0", []string{"MIT", "Apache-2.0"}},
 	}
 
 	for _, tt := range tests {
@@ -225,6 +227,15 @@ func TestConvertCratesToDependencies(t *testing.T) {
 	}
 }
 
+func TestConvertCratesToDependencies_PrefersExpression(t *testing.T) {
+	deps := convertCratesToDependencies([]CargoCrateLicense{
+		{Name: "ring", Version: "0.17.0", Licenses: []string{"MIT", "ISC"}, Expression: "MIT AND ISC"},
+	})
+	if deps[0].License == nil || deps[0].License.Type != "MIT AND ISC" {
+		t.Fatalf("expected SPDX expression to be preserved, got %+v", deps[0].License)
+	}
+}
+
 // TestRustAnalyzerInterface verifies RustAnalyzer implements Analyzer interface
 func TestRustAnalyzerInterface(t *testing.T) {
 	var _ Analyzer = (*RustAnalyzer)(nil)
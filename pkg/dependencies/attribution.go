@@ -0,0 +1,322 @@
+package dependencies
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/safeio"
+)
+
+// AttributionSchemaVersion is the version of the JSON attribution bundle.
+const AttributionSchemaVersion = "v1"
+
+// Attribution is a reproducible third-party notice bundle. Packages, license
+// groups and texts are sorted, texts are deduplicated by content hash, and no
+// timestamps or machine-specific paths are recorded, so the rendered output
+// only changes when the dependency set or its license files change.
+type Attribution struct {
+	SchemaVersion string                 `json:"schema_version"`
+	Digest        string                 `json:"digest"`
+	Packages      []AttributionPackage   `json:"packages"`
+	Groups        []AttributionGroup     `json:"groups"`
+	Texts         []AttributionText      `json:"texts"`
+	Missing       []string               `json:"missing,omitempty"`
+	Summary       AttributionSummaryInfo `json:"summary"`
+}
+
+// AttributionSummaryInfo counts the bundle contents.
+type AttributionSummaryInfo struct {
+	Packages      int `json:"packages"`
+	Licenses      int `json:"licenses"`
+	UniqueTexts   int `json:"unique_texts"`
+	NoticeFiles   int `json:"notice_files"`
+	MissingTexts  int `json:"missing_texts"`
+	DuplicateRefs int `json:"duplicate_refs"`
+}
+
+// AttributionPackage lists the license and NOTICE files found for a package.
+type AttributionPackage struct {
+	Name     string           `json:"name"`
+	Version  string           `json:"version"`
+	Language string           `json:"language"`
+	License  string           `json:"license"`
+	Files    []AttributionRef `json:"files"`
+}
+
+// AttributionRef points at a deduplicated text by hash. Path is relative to
+// the package root.
+type AttributionRef struct {
+	Path   string `json:"path"`
+	Kind   string `json:"kind"`
+	SHA256 string `json:"sha256"`
+}
+
+// AttributionGroup collects the packages distributed under one license
+// expression together with the texts they ship.
+type AttributionGroup struct {
+	License  string   `json:"license"`
+	Packages []string `json:"packages"`
+	Texts    []string `json:"texts"`
+}
+
+// AttributionText is a normalized license or NOTICE text.
+type AttributionText struct {
+	SHA256  string `json:"sha256"`
+	Kind    string `json:"kind"`
+	Content string `json:"content"`
+}
+
+const (
+	attributionKindLicense = "license"
+	attributionKindNotice  = "notice"
+)
+
+// BuildAttribution aggregates license and NOTICE texts for the given
+// dependencies. Local (replaced or main) modules are skipped; packages whose
+// sources or license files cannot be found are listed in Missing.
+func BuildAttribution(deps []Dependency) (*Attribution, error) {
+	bundle := &Attribution{SchemaVersion: AttributionSchemaVersion}
+	texts := map[string]*AttributionText{}
+	groups := map[string]*AttributionGroup{}
+	refs := 0
+
+	for _, dep := range deps {
+		if local, _ := dep.Metadata["is_local"].(bool); local {
+			continue
+		}
+		pkg := AttributionPackage{
+			Name:     dep.Name,
+			Version:  dep.Version,
+			Language: string(dep.Language),
+			License:  "Unknown",
+			Files:    []AttributionRef{},
+		}
+		if dep.License != nil && strings.TrimSpace(dep.License.Type) != "" {
+			pkg.License = parseLicenseNode(dep.License.Type).String()
+		}
+
+		if dir := attributionSourceDir(dep); dir != "" {
+			files, err := attributionFiles(dir)
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range files {
+				data, err := safeio.ReadFileContained(dir, filepath.Join(dir, f.Path))
+				if err != nil {
+					return nil, fmt.Errorf("failed to read %s for %s: %w", f.Path, dep.Name, err)
+				}
+				content := normalizeAttributionText(string(data))
+				if content == "" {
+					continue
+				}
+				sum := sha256.Sum256([]byte(content))
+				f.SHA256 = hex.EncodeToString(sum[:])
+				if _, ok := texts[f.SHA256]; ok {
+					refs++
+				} else {
+					texts[f.SHA256] = &AttributionText{SHA256: f.SHA256, Kind: f.Kind, Content: content}
+				}
+				pkg.Files = append(pkg.Files, f)
+			}
+		}
+
+		hasLicense := false
+		for _, f := range pkg.Files {
+			if f.Kind == attributionKindLicense {
+				hasLicense = true
+			}
+		}
+		id := packageID(pkg.Name, pkg.Version)
+		if !hasLicense {
+			bundle.Missing = append(bundle.Missing, id)
+		}
+
+		group, ok := groups[pkg.License]
+		if !ok {
+			group = &AttributionGroup{License: pkg.License, Packages: []string{}, Texts: []string{}}
+			groups[pkg.License] = group
+		}
+		group.Packages = append(group.Packages, id)
+		for _, f := range pkg.Files {
+			group.Texts = appendUnique(group.Texts, f.SHA256)
+		}
+		bundle.Packages = append(bundle.Packages, pkg)
+	}
+
+	sort.Slice(bundle.Packages, func(i, j int) bool {
+		a, b := bundle.Packages[i], bundle.Packages[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Version < b.Version
+	})
+	sort.Strings(bundle.Missing)
+
+	bundle.Groups = make([]AttributionGroup, 0, len(groups))
+	for _, g := range groups {
+		sort.Strings(g.Packages)
+		sort.Strings(g.Texts)
+		bundle.Groups = append(bundle.Groups, *g)
+	}
+	sort.Slice(bundle.Groups, func(i, j int) bool { return bundle.Groups[i].License < bundle.Groups[j].License })
+
+	bundle.Texts = make([]AttributionText, 0, len(texts))
+	notices := 0
+	for _, t := range texts {
+		if t.Kind == attributionKindNotice {
+			notices++
+		}
+		bundle.Texts = append(bundle.Texts, *t)
+	}
+	sort.Slice(bundle.Texts, func(i, j int) bool { return bundle.Texts[i].SHA256 < bundle.Texts[j].SHA256 })
+	if bundle.Packages == nil {
+		bundle.Packages = []AttributionPackage{}
+	}
+
+	bundle.Summary = AttributionSummaryInfo{
+		Packages:      len(bundle.Packages),
+		Licenses:      len(bundle.Groups),
+		UniqueTexts:   len(bundle.Texts),
+		NoticeFiles:   notices,
+		MissingTexts:  len(bundle.Missing),
+		DuplicateRefs: refs,
+	}
+
+	digest, err := attributionDigest(bundle)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Digest = digest
+	return bundle, nil
+}
+
+// TextsFor returns the texts referenced by a group in hash order.
+func (a *Attribution) TextsFor(group AttributionGroup) []AttributionText {
+	byHash := make(map[string]AttributionText, len(a.Texts))
+	for _, t := range a.Texts {
+		byHash[t.SHA256] = t
+	}
+	out := make([]AttributionText, 0, len(group.Texts))
+	for _, h := range group.Texts {
+		if t, ok := byHash[h]; ok {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// attributionDigest hashes the bundle contents (excluding the digest itself).
+func attributionDigest(a *Attribution) (string, error) {
+	clone := *a
+	clone.Digest = ""
+	data, err := json.Marshal(clone)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode attribution: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// attributionFiles lists top-level license and NOTICE files in dir, sorted by
+// name.
+func attributionFiles(dir string) ([]AttributionRef, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	var files []AttributionRef
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if kind := attributionFileKind(e.Name()); kind != "" {
+			files = append(files, AttributionRef{Path: e.Name(), Kind: kind})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+func attributionFileKind(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".go", ".rs", ".py", ".js", ".ts", ".json", ".yaml", ".yml", ".html", ".toml":
+		// Source files such as license.go are not license texts.
+		return ""
+	}
+	upper := strings.ToUpper(name)
+	switch {
+	case strings.HasPrefix(upper, "NOTICE"):
+		return attributionKindNotice
+	case strings.HasPrefix(upper, "LICENSE"), strings.HasPrefix(upper, "LICENCE"),
+		strings.HasPrefix(upper, "COPYING"), strings.HasPrefix(upper, "UNLICENSE"):
+		return attributionKindLicense
+	}
+	return ""
+}
+
+// normalizeAttributionText makes identical texts hash identically regardless
+// of line endings or trailing whitespace.
+func normalizeAttributionText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " \t")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// attributionSourceDir locates a dependency's unpacked sources: the Go module
+// directory, or the cargo registry cache for crates.
+func attributionSourceDir(dep Dependency) string {
+	if dir, _ := dep.Metadata["module_dir"].(string); dir != "" {
+		return dir
+	}
+	if dep.Language == LanguageRust {
+		return cargoCrateDir(dep.Name, dep.Version)
+	}
+	return ""
+}
+
+// cargoCrateDir finds an unpacked crate in the cargo registry source cache.
+func cargoCrateDir(name, version string) string {
+	home := os.Getenv("CARGO_HOME")
+	if home == "" {
+		userHome, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		home = filepath.Join(userHome, ".cargo")
+	}
+	matches, _ := filepath.Glob(filepath.Join(home, "registry", "src", "*", name+"-"+version))
+	if len(matches) == 0 {
+		return ""
+	}
+	sort.Strings(matches)
+	return matches[0]
+}
+
+func packageID(name, version string) string {
+	if version == "" {
+		return name
+	}
+	return name + "@" + version
+}
+
+func appendUnique(list []string, v string) []string {
+	for _, existing := range list {
+		if existing == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
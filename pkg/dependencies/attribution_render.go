@@ -0,0 +1,130 @@
+package dependencies
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// AttributionFormats lists the supported attribution output formats.
+var AttributionFormats = []string{"text", "markdown", "html", "json"}
+
+// RenderAttribution renders the bundle. Output is byte-for-byte stable for a
+// given bundle so it can be committed and compared in CI.
+func RenderAttribution(a *Attribution, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(a, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal attribution: %w", err)
+		}
+		return append(data, '\n'), nil
+	case "text", "":
+		return []byte(renderAttributionText(a)), nil
+	case "markdown", "md":
+		return []byte(renderAttributionMarkdown(a)), nil
+	case "html":
+		var buf bytes.Buffer
+		if err := attributionHTML.Execute(&buf, a); err != nil {
+			return nil, fmt.Errorf("failed to render attribution: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("unsupported attribution format %q (supported: %s)", format, strings.Join(AttributionFormats, ", "))
+}
+
+func renderAttributionText(a *Attribution) string {
+	var b strings.Builder
+	rule := strings.Repeat("=", 78)
+	b.WriteString("THIRD-PARTY SOFTWARE NOTICES\n")
+	b.WriteString(rule + "\n")
+	fmt.Fprintf(&b, "Digest: %s\n", a.Digest)
+	fmt.Fprintf(&b, "Packages: %d  Licenses: %d  Texts: %d\n", a.Summary.Packages, a.Summary.Licenses, a.Summary.UniqueTexts)
+	for _, g := range a.Groups {
+		b.WriteString("\n" + rule + "\n")
+		fmt.Fprintf(&b, "%s\n", g.License)
+		b.WriteString(rule + "\n\n")
+		for _, p := range g.Packages {
+			fmt.Fprintf(&b, "  - %s\n", p)
+		}
+		for _, t := range a.TextsFor(g) {
+			fmt.Fprintf(&b, "\n--- %s sha256:%s ---\n\n%s\n", t.Kind, t.SHA256, t.Content)
+		}
+	}
+	if len(a.Missing) > 0 {
+		b.WriteString("\n" + rule + "\n")
+		b.WriteString("Packages without license text\n")
+		b.WriteString(rule + "\n\n")
+		for _, m := range a.Missing {
+			fmt.Fprintf(&b, "  - %s\n", m)
+		}
+	}
+	return b.String()
+}
+
+func renderAttributionMarkdown(a *Attribution) string {
+	var b strings.Builder
+	b.WriteString("# Third-Party Software Notices\n\n")
+	fmt.Fprintf(&b, "Digest: `%s`\n\n", a.Digest)
+	fmt.Fprintf(&b, "| Packages | Licenses | Texts |\n|---|---|---|\n| %d | %d | %d |\n", a.Summary.Packages, a.Summary.Licenses, a.Summary.UniqueTexts)
+	for _, g := range a.Groups {
+		fmt.Fprintf(&b, "\n## %s\n\n", g.License)
+		for _, p := range g.Packages {
+			fmt.Fprintf(&b, "- `%s`\n", p)
+		}
+		for _, t := range a.TextsFor(g) {
+			fmt.Fprintf(&b, "\n<details>\n<summary>%s (sha256:%s)</summary>\n\n```text\n%s\n```\n\n</details>\n", t.Kind, t.SHA256[:12], t.Content)
+		}
+	}
+	if len(a.Missing) > 0 {
+		b.WriteString("\n## Packages without license text\n\n")
+		for _, m := range a.Missing {
+			fmt.Fprintf(&b, "- `%s`\n", m)
+		}
+	}
+	return b.String()
+}
+
+var attributionHTML = template.Must(template.New("attribution").Funcs(template.FuncMap{
+	"short": func(s string) string {
+		if len(s) > 12 {
+			return s[:12]
+		}
+		return s
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Third-Party Software Notices</title>
+<style>body{font-family:sans-serif;max-width:960px;margin:2em auto}pre{white-space:pre-wrap;background:#f6f8fa;padding:1em}</style>
+</head>
+<body>
+<h1>Third-Party Software Notices</h1>
+<p>Digest: <code>{{.Digest}}</code> &middot; Packages: {{.Summary.Packages}} &middot; Licenses: {{.Summary.Licenses}} &middot; Texts: {{.Summary.UniqueTexts}}</p>
+{{- range $g := .Groups}}
+<h2>{{$g.License}}</h2>
+<ul>
+{{- range $g.Packages}}
+<li><code>{{.}}</code></li>
+{{- end}}
+</ul>
+{{- range $.TextsFor $g}}
+<details><summary>{{.Kind}} (sha256:{{short .SHA256}})</summary>
+<pre>{{.Content}}</pre>
+</details>
+{{- end}}
+{{- end}}
+{{- if .Missing}}
+<h2>Packages without license text</h2>
+<ul>
+{{- range .Missing}}
+<li><code>{{.}}</code></li>
+{{- end}}
+</ul>
+{{- end}}
+</body>
+</html>
+`))
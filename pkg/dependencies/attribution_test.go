@@ -0,0 +1,149 @@
+package dependencies
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const mitText = "MIT License\n\nCopyright (c) Example\n\nPermission is hereby granted..."
+
+func writeModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func attributionDeps(t *testing.T) []Dependency {
+	a := writeModule(t, map[string]string{"LICENSE": mitText, "main.go": "package a"})
+	// Same text with CRLF line endings and trailing spaces must deduplicate.
+	b := writeModule(t, map[string]string{"LICENSE.txt": strings.ReplaceAll(mitText, "\n", "  \r\n")})
+	c := writeModule(t, map[string]string{"LICENSE": "Apache License\nVersion 2.0", "NOTICE": "Copyright Example Corp", "license.go": "package c"})
+	return []Dependency{
+		{Module: Module{Name: "example.com/c", Version: "v1.0.0", Language: LanguageGo}, License: &License{Type: "Apache-2.0"}, Metadata: map[string]interface{}{"module_dir": c}},
+		{Module: Module{Name: "example.com/b", Version: "v0.2.0", Language: LanguageGo}, License: &License{Type: "MIT"}, Metadata: map[string]interface{}{"module_dir": b}},
+		{Module: Module{Name: "example.com/a", Version: "v1.2.3", Language: LanguageGo}, License: &License{Type: "MIT"}, Metadata: map[string]interface{}{"module_dir": a}},
+		{Module: Module{Name: "example.com/nolicense", Version: "v0.0.1", Language: LanguageGo}, Metadata: map[string]interface{}{"module_dir": t.TempDir()}},
+		{Module: Module{Name: "example.com/self", Language: LanguageGo}, Metadata: map[string]interface{}{"is_local": true}},
+	}
+}
+
+func TestBuildAttribution_GroupsAndDeduplicates(t *testing.T) {
+	bundle, err := BuildAttribution(attributionDeps(t))
+	if err != nil {
+		t.Fatalf("BuildAttribution: %v", err)
+	}
+
+	if bundle.Summary.Packages != 4 {
+		t.Errorf("packages = %d, want 4 (local module skipped)", bundle.Summary.Packages)
+	}
+	if bundle.Summary.UniqueTexts != 3 {
+		t.Errorf("unique texts = %d, want 3 (MIT deduplicated)", bundle.Summary.UniqueTexts)
+	}
+	if bundle.Summary.NoticeFiles != 1 || bundle.Summary.DuplicateRefs != 1 {
+		t.Errorf("summary = %+v", bundle.Summary)
+	}
+	if got := bundle.Packages[0].Name; got != "example.com/a" {
+		t.Errorf("packages not sorted: first = %s", got)
+	}
+
+	var licenses []string
+	for _, g := range bundle.Groups {
+		licenses = append(licenses, g.License)
+	}
+	if strings.Join(licenses, ",") != "Apache-2.0,MIT,Unknown" {
+		t.Errorf("groups = %v", licenses)
+	}
+	mit := bundle.Groups[1]
+	if strings.Join(mit.Packages, ",") != "example.com/a@v1.2.3,example.com/b@v0.2.0" || len(mit.Texts) != 1 {
+		t.Errorf("MIT group = %+v", mit)
+	}
+	for _, f := range bundle.Packages[1].Files {
+		if f.Path != "LICENSE.txt" {
+			t.Errorf("unexpected file %s", f.Path)
+		}
+	}
+	if strings.Join(bundle.Missing, ",") != "example.com/nolicense@v0.0.1" {
+		t.Errorf("missing = %v", bundle.Missing)
+	}
+}
+
+func TestBuildAttribution_Reproducible(t *testing.T) {
+	deps := attributionDeps(t)
+	first, err := BuildAttribution(deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reversed := make([]Dependency, len(deps))
+	for i := range deps {
+		reversed[len(deps)-1-i] = deps[i]
+	}
+	second, err := BuildAttribution(reversed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Digest != second.Digest || !strings.HasPrefix(first.Digest, "sha256:") {
+		t.Fatalf("digest not stable: %s vs %s", first.Digest, second.Digest)
+	}
+
+	for _, format := range AttributionFormats {
+		a, err := RenderAttribution(first, format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		b, _ := RenderAttribution(second, format)
+		if !bytes.Equal(a, b) {
+			t.Errorf("%s output differs between runs", format)
+		}
+		if !bytes.Contains(a, []byte("Copyright Example Corp")) {
+			t.Errorf("%s output missing NOTICE text", format)
+		}
+	}
+}
+
+func TestRenderAttribution_JSONRoundTrip(t *testing.T) {
+	bundle, err := BuildAttribution(attributionDeps(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := RenderAttribution(bundle, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Attribution
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if digest, _ := attributionDigest(&decoded); digest != bundle.Digest {
+		t.Errorf("recomputed digest %s != %s", digest, bundle.Digest)
+	}
+	if _, err := RenderAttribution(bundle, "pdf"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestAttributionFileKind(t *testing.T) {
+	cases := map[string]string{
+		"LICENSE":        "license",
+		"LICENSE-MIT":    "license",
+		"LICENSE.APACHE": "license",
+		"COPYING.txt":    "license",
+		"UNLICENSE":      "license",
+		"NOTICE.md":      "notice",
+		"license.go":     "",
+		"README.md":      "",
+	}
+	for name, want := range cases {
+		if got := attributionFileKind(name); got != want {
+			t.Errorf("attributionFileKind(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"regexp"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/fulmenhq/goneat/pkg/logger"
 )
@@ -742,6 +743,9 @@ type CargoCrateLicense struct {
 	Name     string   // Crate name
 	Version  string   // Crate version
 	Licenses []string // List of licenses (e.g., ["MIT", "Apache-2.0"])
+	// Expression is the SPDX expression as reported by cargo-deny
+	// (e.g., "MIT OR Apache-2.0"); empty when unavailable.
+	Expression string
 }
 
 // RunCargoDenyList executes cargo deny list to get dependency license information.
@@ -883,9 +887,10 @@ func parseCargoDenyList(out []byte) []CargoCrateLicense {
 			licenses := parseLicenseExpression(licenseExpr)
 
 			deps = append(deps, CargoCrateLicense{
-				Name:     name,
-				Version:  version,
-				Licenses: licenses,
+				Name:       name,
+				Version:    version,
+				Licenses:   licenses,
+				Expression: licenseExpr,
 			})
 		}
 	}
@@ -893,33 +898,34 @@ func parseCargoDenyList(out []byte) []CargoCrateLicense {
 	return deps
 }
 
-// parseLicenseExpression parses an SPDX-like license expression into individual licenses.
+// parseLicenseExpression returns the distinct license identifiers referenced by
+// an SPDX license expression, in order of appearance. Exception identifiers
+// (after WITH) are omitted; use the spdx package for canonical forms and
+// policy evaluation.
 // Examples:
 //   - "MIT" -> ["MIT"]
 //   - "MIT OR Apache-2.0" -> ["MIT", "Apache-2.0"]
 //   - "MIT AND Apache-2.0" -> ["MIT", "Apache-2.0"]
 //   - "(MIT OR Apache-2.0) AND BSD-3-Clause" -> ["MIT", "Apache-2.0", "BSD-3-Clause"]
+//   - "Apache-2.0 WITH LLVM-exception" -> ["Apache-2.0"]
 func parseLicenseExpression(expr string) []string {
-	if expr == "" {
-		return nil
-	}
-
-	// Remove parentheses
-	expr = strings.ReplaceAll(expr, "(", "")
-	expr = strings.ReplaceAll(expr, ")", "")
-
-	// Split on OR and AND (case insensitive)
-	// Use regex to handle " OR " and " AND " with surrounding spaces
-	separatorPattern := regexp.MustCompile(`\s+(?:OR|AND)\s+`)
-	parts := separatorPattern.Split(expr, -1)
+	tokens := strings.FieldsFunc(expr, func(r rune) bool {
+		return r == '(' || r == ')' || unicode.IsSpace(r)
+	})
 
 	var licenses []string
 	seen := make(map[string]bool)
-	for _, p := range parts {
-		p = strings.TrimSpace(p)
-		if p != "" && !seen[p] {
-			seen[p] = true
-			licenses = append(licenses, p)
+	for i := 0; i < len(tokens); i++ {
+		switch strings.ToUpper(tokens[i]) {
+		case "AND", "OR":
+			continue
+		case "WITH":
+			i++ // skip the exception identifier
+			continue
+		}
+		if !seen[tokens[i]] {
+			seen[tokens[i]] = true
+			licenses = append(licenses, tokens[i])
 		}
 	}
 
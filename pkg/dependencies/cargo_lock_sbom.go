@@ -0,0 +1,107 @@
+package dependencies
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/sbom"
+	"github.com/pelletier/go-toml/v2"
+)
+
+type cargoLockFile struct {
+	Package []cargoLockPackage `toml:"package"`
+}
+
+type cargoLockPackage struct {
+	Name         string   `toml:"name"`
+	Version      string   `toml:"version"`
+	Source       string   `toml:"source"`
+	Checksum     string   `toml:"checksum"`
+	Dependencies []string `toml:"dependencies"`
+}
+
+type cargoManifest struct {
+	Package struct {
+		Name    string `toml:"name"`
+		Version string `toml:"version"`
+	} `toml:"package"`
+}
+
+// cargoLockDocument builds an SBOM document from Cargo.lock. Packages without
+// a source are workspace members; the crate at the project root (or the
+// workspace itself) is the described package.
+func cargoLockDocument(project *RustProject, lockPath string) (*sbom.Document, error) {
+	data, err := os.ReadFile(lockPath) // #nosec G304 - Cargo.lock located via DetectRustProject
+	if err != nil {
+		return nil, fmt.Errorf("read Cargo.lock: %w", err)
+	}
+	var lock cargoLockFile
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("parse Cargo.lock: %w", err)
+	}
+
+	// Dependencies name a crate as "name" when only one version is locked and
+	// as "name version" otherwise.
+	byName := map[string][]string{}
+	byNameVersion := map[string]string{}
+	doc := &sbom.Document{}
+	for _, p := range lock.Package {
+		purl := cargoPURL(p.Name, p.Version)
+		pkg := sbom.Package{
+			Ref:        purl,
+			Name:       p.Name,
+			Version:    p.Version,
+			Type:       "library",
+			PURL:       purl,
+			Properties: nativeSourceProperties("cargo-lock", "Cargo.lock"),
+		}
+		if p.Source == "" {
+			pkg.Properties = append(pkg.Properties, sbom.Property{Name: "goneat:workspace_member", Value: "true"})
+		}
+		doc.Packages = append(doc.Packages, pkg)
+		byName[p.Name] = append(byName[p.Name], purl)
+		byNameVersion[p.Name+" "+p.Version] = purl
+	}
+	for _, p := range lock.Package {
+		from := cargoPURL(p.Name, p.Version)
+		for _, dep := range p.Dependencies {
+			fields := strings.Fields(dep)
+			if len(fields) == 0 {
+				continue
+			}
+			var to string
+			if len(fields) >= 2 {
+				to = byNameVersion[fields[0]+" "+fields[1]]
+			} else if refs := byName[fields[0]]; len(refs) == 1 {
+				to = refs[0]
+			}
+			if to != "" {
+				doc.Relationships = append(doc.Relationships, sbom.Relationship{From: from, To: to})
+			}
+		}
+	}
+
+	var manifest cargoManifest
+	if project.CargoTomlPath != "" {
+		if raw, err := os.ReadFile(project.CargoTomlPath); err == nil { // #nosec G304 - Cargo.toml located via DetectRustProject
+			_ = toml.Unmarshal(raw, &manifest)
+		}
+	}
+	if name := manifest.Package.Name; name != "" {
+		purl := cargoPURL(name, manifest.Package.Version)
+		attachRoot(doc, sbom.Package{Ref: purl, Name: name, Version: manifest.Package.Version, PURL: purl})
+	} else {
+		name := filepath.Base(project.EffectiveRoot())
+		attachRoot(doc, sbom.Package{Ref: "workspace:" + name, Name: name, Properties: nativeSourceProperties("cargo-lock", "Cargo.toml")})
+	}
+	return doc, nil
+}
+
+func cargoPURL(name, version string) string {
+	if version == "" {
+		return "pkg:cargo/" + name
+	}
+	return "pkg:cargo/" + name + "@" + version
+}
@@ -0,0 +1,586 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/logger"
+	"github.com/fulmenhq/goneat/pkg/sbom"
+	"github.com/fulmenhq/goneat/pkg/versioning"
+	"github.com/fulmenhq/goneat/pkg/vulnerabilities"
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// dependencyManifests are the module manifests and lockfiles the snapshot of
+// a ref needs at least one of; a ref without any yields an empty snapshot.
+var dependencyManifests = []string{
+	"go.mod", "go.work",
+	"Cargo.toml", "Cargo.lock",
+	"package.json", "package-lock.json", "npm-shrinkwrap.json", "pnpm-lock.yaml", "yarn.lock",
+	"pyproject.toml", "uv.lock", "poetry.lock", "Pipfile", "Pipfile.lock", "requirements.txt",
+}
+
+// DependencyDiffOptions controls how the dependency set is rebuilt at each ref.
+type DependencyDiffOptions struct {
+	// Base is the git ref compared against.
+	Base string
+	// Head is the git ref with the changes; empty means the working tree.
+	Head string
+	// Analyze runs the dependency analyzers (licenses and cooling) on the
+	// directory holding a snapshot. When nil the dependency set is read from
+	// the module graph or lockfiles by the native SBOM generators.
+	Analyze func(ctx context.Context, dir string) (*AnalysisResult, error)
+	// Vulnerabilities scans both snapshots with grype.
+	Vulnerabilities bool
+	PolicyPath      string
+	VEXDir          string
+	Timeout         time.Duration
+}
+
+// DependencySnapshot is the dependency set of a project at one git ref.
+type DependencySnapshot struct {
+	Ref          string
+	Dependencies []Dependency
+	Issues       []Issue
+	Findings     []vulnerabilities.Finding
+}
+
+// DependencyChange describes one package that differs between two refs.
+type DependencyChange struct {
+	Name        string   `json:"name"`
+	Language    Language `json:"language,omitempty"`
+	FromVersion string   `json:"from_version,omitempty"`
+	ToVersion   string   `json:"to_version,omitempty"`
+	FromLicense string   `json:"from_license,omitempty"`
+	ToLicense   string   `json:"to_license,omitempty"`
+}
+
+// DependencyDiffIssue is an analyzer finding that only exists at the head ref.
+type DependencyDiffIssue struct {
+	Name     string `json:"name"`
+	Version  string `json:"version,omitempty"`
+	Type     string `json:"type"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// DependencyDiff is the supply-chain change between two refs.
+type DependencyDiff struct {
+	Base               string                    `json:"base"`
+	Head               string                    `json:"head"`
+	Added              []DependencyChange        `json:"added"`
+	Removed            []DependencyChange        `json:"removed"`
+	Upgraded           []DependencyChange        `json:"upgraded"`
+	Downgraded         []DependencyChange        `json:"downgraded"`
+	LicenseChanges     []DependencyChange        `json:"license_changes"`
+	CoolingViolations  []DependencyDiffIssue     `json:"cooling_violations"`
+	NewVulnerabilities []vulnerabilities.Finding `json:"new_vulnerabilities"`
+}
+
+// Empty reports whether the two refs have the same dependency set and the
+// head introduces no findings.
+func (d *DependencyDiff) Empty() bool {
+	return len(d.Added)+len(d.Removed)+len(d.Upgraded)+len(d.Downgraded)+len(d.LicenseChanges)+len(d.CoolingViolations)+len(d.NewVulnerabilities) == 0
+}
+
+// DiffDependencyRefs builds the dependency set of target at opts.Base and
+// opts.Head and compares them.
+func DiffDependencyRefs(ctx context.Context, target string, opts DependencyDiffOptions) (*DependencyDiff, error) {
+	if strings.TrimSpace(opts.Base) == "" {
+		return nil, errors.New("base ref is required")
+	}
+	base, err := LoadDependencySnapshot(ctx, target, opts.Base, opts)
+	if err != nil {
+		return nil, fmt.Errorf("base %s: %w", opts.Base, err)
+	}
+	head, err := LoadDependencySnapshot(ctx, target, opts.Head, opts)
+	if err != nil {
+		return nil, fmt.Errorf("head %s: %w", refLabel(opts.Head), err)
+	}
+	return DiffDependencySnapshots(base, head), nil
+}
+
+// LoadDependencySnapshot rebuilds the dependency set of target at ref. The
+// whole repository tree at ref is written from git objects into a temporary
+// directory, so sources, workspace members and `replace ../` modules are
+// there for the analyzers and the working tree is left untouched; an empty
+// ref uses the working tree itself. A ref without any supported manifest
+// yields an empty snapshot.
+func LoadDependencySnapshot(ctx context.Context, target string, ref string, opts DependencyDiffOptions) (*DependencySnapshot, error) {
+	snapshot := &DependencySnapshot{Ref: refLabel(ref), Dependencies: []Dependency{}}
+	dir := target
+	if ref != "" {
+		tmp, err := os.MkdirTemp("", "goneat-deps-diff-*")
+		if err != nil {
+			return nil, fmt.Errorf("create snapshot directory: %w", err)
+		}
+		defer func() { _ = os.RemoveAll(tmp) }()
+		dir, err = ExtractGitTree(target, ref, tmp)
+		if err != nil {
+			return nil, err
+		}
+		if !hasDependencyManifest(dir) {
+			logger.Debug(fmt.Sprintf("dependencies diff: no manifests at %s", ref))
+			return snapshot, nil
+		}
+	}
+
+	if opts.Analyze != nil {
+		result, err := opts.Analyze(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range result.Dependencies {
+			if local, _ := dep.Metadata["is_local"].(bool); local {
+				continue
+			}
+			snapshot.Dependencies = append(snapshot.Dependencies, dep)
+		}
+		snapshot.Issues = result.Issues
+	} else {
+		doc, err := NativeSBOMDocument(ctx, dir)
+		if errors.Is(err, ErrNoNativeSBOMSource) {
+			return snapshot, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Dependencies = sbomDependencies(doc)
+	}
+
+	if opts.Vulnerabilities {
+		vexDir := opts.VEXDir
+		if vexDir == "" {
+			vexDir = DefaultVEXDir
+		}
+		if !filepath.IsAbs(vexDir) {
+			vexDir = filepath.Join(target, vexDir)
+		}
+		scan, _, err := RunVulnerabilityScanWithOptions(ctx, dir, opts.PolicyPath, "", opts.Timeout, VulnerabilityScanOptions{VEXDir: vexDir})
+		if err != nil {
+			return nil, err
+		}
+		if scan != nil {
+			snapshot.Findings = scan.Findings
+		}
+	}
+	return snapshot, nil
+}
+
+// ExtractGitTree writes the files of the repository containing target, as
+// they are at ref, into dest and returns the directory in dest that
+// corresponds to target. Symlinks and submodules are skipped.
+func ExtractGitTree(target string, ref string, dest string) (string, error) {
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve target: %w", err)
+	}
+	repo, err := git.PlainOpenWithOptions(absTarget, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return "", fmt.Errorf("open git repository: %w", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("open git worktree: %w", err)
+	}
+	rel, err := repoRelativePath(wt.Filesystem.Root(), absTarget)
+	if err != nil {
+		return "", err
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", ref, err)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return "", fmt.Errorf("read commit %s: %w", ref, err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return "", fmt.Errorf("read tree %s: %w", ref, err)
+	}
+
+	err = tree.Files().ForEach(func(f *object.File) error {
+		perm := os.FileMode(0o600)
+		switch f.Mode {
+		case filemode.Regular, filemode.Deprecated:
+		case filemode.Executable:
+			perm = 0o700
+		default:
+			return nil
+		}
+		out := filepath.Join(dest, filepath.FromSlash(f.Name))
+		if !strings.HasPrefix(out, filepath.Clean(dest)+string(filepath.Separator)) {
+			return fmt.Errorf("unsafe path %q at %s", f.Name, ref)
+		}
+		contents, err := f.Contents()
+		if err != nil {
+			return fmt.Errorf("read %s at %s: %w", f.Name, ref, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(out), 0o750); err != nil {
+			return fmt.Errorf("create directory for %s: %w", f.Name, err)
+		}
+		if err := os.WriteFile(out, []byte(contents), perm); err != nil {
+			return fmt.Errorf("write %s: %w", f.Name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dest, filepath.FromSlash(rel)), nil
+}
+
+func hasDependencyManifest(dir string) bool {
+	for _, name := range dependencyManifests {
+		if fileExists(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func repoRelativePath(root string, target string) (string, error) {
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(target); err == nil {
+		target = resolved
+	}
+	rel, err := filepath.Rel(root, target)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is outside the git repository %s", target, root)
+	}
+	if rel == "." {
+		return "", nil
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func refLabel(ref string) string {
+	if ref == "" {
+		return "working tree"
+	}
+	return ref
+}
+
+// sbomDependencies converts the packages of a native SBOM document, minus the
+// described project and the per-ecosystem project roots, into dependencies.
+func sbomDependencies(doc *sbom.Document) []Dependency {
+	deps := make([]Dependency, 0, len(doc.Packages))
+	for _, p := range doc.Packages {
+		if p.Ref == doc.Root || p.Type == "application" {
+			continue
+		}
+		dep := Dependency{
+			Module:   Module{Name: p.Name, Version: p.Version, Language: purlLanguage(p.PURL)},
+			Metadata: map[string]interface{}{"purl": p.PURL},
+		}
+		if p.LicenseDeclared != "" {
+			dep.License = &License{Name: p.LicenseDeclared, Type: p.LicenseDeclared}
+		}
+		deps = append(deps, dep)
+	}
+	return deps
+}
+
+func purlLanguage(purl string) Language {
+	switch {
+	case strings.HasPrefix(purl, "pkg:golang/"):
+		return LanguageGo
+	case strings.HasPrefix(purl, "pkg:cargo/"):
+		return LanguageRust
+	case strings.HasPrefix(purl, "pkg:npm/"):
+		return LanguageTypeScript
+	case strings.HasPrefix(purl, "pkg:pypi/"):
+		return LanguagePython
+	case strings.HasPrefix(purl, "pkg:nuget/"):
+		return LanguageCSharp
+	}
+	return ""
+}
+
+// purlNameVersion splits a package URL into the package name (including any
+// namespace) and version.
+func purlNameVersion(purl string) (string, string) {
+	rest, ok := strings.CutPrefix(purl, "pkg:")
+	if !ok {
+		return "", ""
+	}
+	if i := strings.IndexAny(rest, "?#"); i >= 0 {
+		rest = rest[:i]
+	}
+	_, rest, ok = strings.Cut(rest, "/")
+	if !ok {
+		return "", ""
+	}
+	version := ""
+	if i := strings.LastIndex(rest, "@"); i >= 0 {
+		rest, version = rest[:i], rest[i+1:]
+	}
+	if name, err := url.PathUnescape(rest); err == nil {
+		rest = name
+	}
+	if v, err := url.PathUnescape(version); err == nil {
+		version = v
+	}
+	return rest, version
+}
+
+// dependencyKey identifies a package across refs and ecosystems. Names are
+// compared PEP 503-normalized so Python spellings match.
+func dependencyKey(name string) string {
+	return normalizePythonName(name)
+}
+
+func dependencyLicense(dep Dependency) string {
+	if dep.License == nil {
+		return ""
+	}
+	if dep.License.Type != "" {
+		return dep.License.Type
+	}
+	return dep.License.Name
+}
+
+type dependencyVersions struct {
+	name     string
+	language Language
+	versions map[string]Dependency
+}
+
+func indexDependencies(deps []Dependency) map[string]*dependencyVersions {
+	index := map[string]*dependencyVersions{}
+	for _, dep := range deps {
+		key := dependencyKey(dep.Name)
+		entry, ok := index[key]
+		if !ok {
+			entry = &dependencyVersions{name: dep.Name, language: dep.Language, versions: map[string]Dependency{}}
+			index[key] = entry
+		}
+		entry.versions[dep.Version] = dep
+	}
+	return index
+}
+
+// DiffDependencySnapshots compares two snapshots. A package locked at a
+// single version on both sides that changed version is an upgrade or
+// downgrade; otherwise versions only present on one side are added or
+// removed. License changes are reported for versions present on both sides
+// and for upgrades and downgrades.
+func DiffDependencySnapshots(base, head *DependencySnapshot) *DependencyDiff {
+	diff := &DependencyDiff{
+		Base:               base.Ref,
+		Head:               head.Ref,
+		Added:              []DependencyChange{},
+		Removed:            []DependencyChange{},
+		Upgraded:           []DependencyChange{},
+		Downgraded:         []DependencyChange{},
+		LicenseChanges:     []DependencyChange{},
+		CoolingViolations:  []DependencyDiffIssue{},
+		NewVulnerabilities: []vulnerabilities.Finding{},
+	}
+	before := indexDependencies(base.Dependencies)
+	after := indexDependencies(head.Dependencies)
+
+	for _, key := range sortedKeys(after) {
+		h := after[key]
+		b, ok := before[key]
+		if !ok {
+			for _, v := range sortedKeys(h.versions) {
+				diff.Added = append(diff.Added, DependencyChange{Name: h.name, Language: h.language, ToVersion: v, ToLicense: dependencyLicense(h.versions[v])})
+			}
+			continue
+		}
+		if len(b.versions) == 1 && len(h.versions) == 1 {
+			from, to := sortedKeys(b.versions)[0], sortedKeys(h.versions)[0]
+			change := DependencyChange{
+				Name:        h.name,
+				Language:    h.language,
+				FromVersion: from,
+				ToVersion:   to,
+				FromLicense: dependencyLicense(b.versions[from]),
+				ToLicense:   dependencyLicense(h.versions[to]),
+			}
+			switch c := compareDependencyVersions(from, to); {
+			case c < 0:
+				diff.Upgraded = append(diff.Upgraded, change)
+			case c > 0:
+				diff.Downgraded = append(diff.Downgraded, change)
+			}
+			if change.FromLicense != change.ToLicense {
+				diff.LicenseChanges = append(diff.LicenseChanges, change)
+			}
+			continue
+		}
+		for _, v := range sortedKeys(h.versions) {
+			if old, ok := b.versions[v]; ok {
+				if from, to := dependencyLicense(old), dependencyLicense(h.versions[v]); from != to {
+					diff.LicenseChanges = append(diff.LicenseChanges, DependencyChange{Name: h.name, Language: h.language, FromVersion: v, ToVersion: v, FromLicense: from, ToLicense: to})
+				}
+				continue
+			}
+			diff.Added = append(diff.Added, DependencyChange{Name: h.name, Language: h.language, ToVersion: v, ToLicense: dependencyLicense(h.versions[v])})
+		}
+		for _, v := range sortedKeys(b.versions) {
+			if _, ok := h.versions[v]; !ok {
+				diff.Removed = append(diff.Removed, DependencyChange{Name: b.name, Language: b.language, FromVersion: v, FromLicense: dependencyLicense(b.versions[v])})
+			}
+		}
+	}
+	for _, key := range sortedKeys(before) {
+		if _, ok := after[key]; ok {
+			continue
+		}
+		b := before[key]
+		for _, v := range sortedKeys(b.versions) {
+			diff.Removed = append(diff.Removed, DependencyChange{Name: b.name, Language: b.language, FromVersion: v, FromLicense: dependencyLicense(b.versions[v])})
+		}
+	}
+
+	baseCooling := map[string]bool{}
+	for _, issue := range base.Issues {
+		if isCoolingIssue(issue) && issue.Dependency != nil {
+			baseCooling[dependencyKey(issue.Dependency.Name)+"@"+issue.Dependency.Version+"|"+issue.Type] = true
+		}
+	}
+	for _, issue := range head.Issues {
+		if !isCoolingIssue(issue) || issue.Dependency == nil {
+			continue
+		}
+		if baseCooling[dependencyKey(issue.Dependency.Name)+"@"+issue.Dependency.Version+"|"+issue.Type] {
+			continue
+		}
+		diff.CoolingViolations = append(diff.CoolingViolations, DependencyDiffIssue{
+			Name:     issue.Dependency.Name,
+			Version:  issue.Dependency.Version,
+			Type:     issue.Type,
+			Severity: issue.Severity,
+			Message:  issue.Message,
+		})
+	}
+
+	baseFindings := map[string]bool{}
+	for _, f := range base.Findings {
+		baseFindings[findingKey(f)] = true
+	}
+	for _, f := range head.Findings {
+		if f.Suppressed || baseFindings[findingKey(f)] {
+			continue
+		}
+		diff.NewVulnerabilities = append(diff.NewVulnerabilities, f)
+	}
+	sort.SliceStable(diff.NewVulnerabilities, func(i, j int) bool {
+		a, b := diff.NewVulnerabilities[i], diff.NewVulnerabilities[j]
+		if a.Severity != b.Severity {
+			return !vulnerabilities.SeverityMeetsOrExceeds(b.Severity, a.Severity)
+		}
+		return a.ID < b.ID
+	})
+	return diff
+}
+
+func isCoolingIssue(issue Issue) bool {
+	switch issue.Type {
+	case "cooling", "age_violation", "download_violation":
+		return true
+	}
+	return false
+}
+
+// findingKey identifies a vulnerability by advisory and affected package
+// names, so a version bump that keeps a package vulnerable is not new.
+func findingKey(f vulnerabilities.Finding) string {
+	names := append([]string(nil), f.PackageNames...)
+	sort.Strings(names)
+	return f.ID + "|" + strings.Join(names, ",")
+}
+
+// compareDependencyVersions orders versions as SemVer when both parse and by
+// dot-separated segments (numerically where possible) otherwise.
+func compareDependencyVersions(a, b string) int {
+	if c, err := versioning.Compare(versioning.SchemeSemverFull, a, b); err == nil {
+		switch c {
+		case versioning.ComparisonLess:
+			return -1
+		case versioning.ComparisonGreater:
+			return 1
+		case versioning.ComparisonEqual:
+			return 0
+		}
+	}
+	split := func(s string) []string {
+		return strings.FieldsFunc(strings.TrimPrefix(s, "v"), func(r rune) bool { return r == '.' || r == '-' || r == '+' })
+	}
+	as, bs := split(a), split(b)
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+		case as[i] != bs[i]:
+			if as[i] < bs[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
+
+// FilterIssuesToNewDependencies drops issues about packages that were
+// already present, at the same version, in the base snapshot. Issues not tied
+// to a package are kept. Vulnerability policy issues are matched to their
+// findings by advisory ID and kept when any affected package is new.
+func FilterIssuesToNewDependencies(issues []Issue, base *DependencySnapshot, findings []vulnerabilities.Finding) []Issue {
+	existing := map[string]bool{}
+	for _, dep := range base.Dependencies {
+		existing[dependencyKey(dep.Name)+"@"+dep.Version] = true
+	}
+	newFinding := map[string]bool{}
+	for _, f := range findings {
+		for _, purl := range f.PURLs {
+			if name, version := purlNameVersion(purl); name != "" && !existing[dependencyKey(name)+"@"+version] {
+				newFinding[f.ID] = true
+			}
+		}
+	}
+
+	filtered := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		if issue.Dependency != nil {
+			if existing[dependencyKey(issue.Dependency.Name)+"@"+issue.Dependency.Version] {
+				continue
+			}
+		} else if issue.Type == "vulnerability" && issue.Severity != "info" {
+			if id, _, ok := strings.Cut(issue.Message, ":"); ok && !strings.Contains(id, " ") && !newFinding[id] {
+				continue
+			}
+		}
+		filtered = append(filtered, issue)
+	}
+	return filtered
+}
@@ -0,0 +1,99 @@
+package dependencies
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DependencyDiffFormats lists the supported dependency diff output formats.
+var DependencyDiffFormats = []string{"markdown", "json"}
+
+// RenderDependencyDiff renders a diff as a markdown PR comment or as JSON.
+func RenderDependencyDiff(d *DependencyDiff, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal dependency diff: %w", err)
+		}
+		return append(data, '\n'), nil
+	case "markdown", "md", "":
+		return []byte(renderDependencyDiffMarkdown(d)), nil
+	}
+	return nil, fmt.Errorf("unsupported diff format %q (supported: %s)", format, strings.Join(DependencyDiffFormats, ", "))
+}
+
+func renderDependencyDiffMarkdown(d *DependencyDiff) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Dependency changes: `%s` → `%s`\n\n", d.Base, d.Head)
+	if d.Empty() {
+		b.WriteString("No dependency changes.\n")
+		return b.String()
+	}
+	b.WriteString("| Added | Removed | Upgraded | Downgraded | License changes | Cooling violations | New vulnerabilities |\n")
+	b.WriteString("|---|---|---|---|---|---|---|\n")
+	fmt.Fprintf(&b, "| %d | %d | %d | %d | %d | %d | %d |\n",
+		len(d.Added), len(d.Removed), len(d.Upgraded), len(d.Downgraded),
+		len(d.LicenseChanges), len(d.CoolingViolations), len(d.NewVulnerabilities))
+
+	if len(d.NewVulnerabilities) > 0 {
+		b.WriteString("\n### New vulnerabilities\n\n| ID | Severity | Packages | Fixed in |\n|---|---|---|---|\n")
+		for _, f := range d.NewVulnerabilities {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", f.ID, f.Severity, codeList(f.PackageNames), markdownCell(strings.Join(f.FixVersions, ", ")))
+		}
+	}
+	if len(d.CoolingViolations) > 0 {
+		b.WriteString("\n### New cooling violations\n\n| Package | Version | Severity | Detail |\n|---|---|---|---|\n")
+		for _, v := range d.CoolingViolations {
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", v.Name, markdownCell(v.Version), v.Severity, markdownCell(v.Message))
+		}
+	}
+	if len(d.LicenseChanges) > 0 {
+		b.WriteString("\n### License changes\n\n| Package | Version | From | To |\n|---|---|---|---|\n")
+		for _, c := range d.LicenseChanges {
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", c.Name, versionSpan(c), markdownCell(c.FromLicense), markdownCell(c.ToLicense))
+		}
+	}
+	writeChanges := func(title string, changes []DependencyChange, version func(DependencyChange) string, license func(DependencyChange) string) {
+		if len(changes) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n| Package | Version | License |\n|---|---|---|\n", title)
+		for _, c := range changes {
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", c.Name, markdownCell(version(c)), markdownCell(license(c)))
+		}
+	}
+	to := func(c DependencyChange) string { return c.ToVersion }
+	from := func(c DependencyChange) string { return c.FromVersion }
+	toLicense := func(c DependencyChange) string { return c.ToLicense }
+	fromLicense := func(c DependencyChange) string { return c.FromLicense }
+	writeChanges("Added", d.Added, to, toLicense)
+	writeChanges("Upgraded", d.Upgraded, versionSpan, toLicense)
+	writeChanges("Downgraded", d.Downgraded, versionSpan, toLicense)
+	writeChanges("Removed", d.Removed, from, fromLicense)
+	return b.String()
+}
+
+func versionSpan(c DependencyChange) string {
+	if c.FromVersion == c.ToVersion {
+		return c.ToVersion
+	}
+	return c.FromVersion + " → " + c.ToVersion
+}
+
+func codeList(values []string) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, "`"+v+"`")
+	}
+	return markdownCell(strings.Join(parts, ", "))
+}
+
+// markdownCell escapes a value for a markdown table cell.
+func markdownCell(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", `\|`), "\n", " ")
+}
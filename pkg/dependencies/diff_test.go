@@ -0,0 +1,289 @@
+package dependencies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/vulnerabilities"
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func commitAll(t *testing.T, repo *git.Repository, message string) {
+	t.Helper()
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+	if _, err := wt.Add("."); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if _, err := wt.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{Name: "goneat", Email: "ci@goneat.dev", When: time.Now()},
+	}); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+}
+
+func dep(name, version, license string) Dependency {
+	d := Dependency{Module: Module{Name: name, Version: version, Language: LanguagePython}}
+	if license != "" {
+		d.License = &License{Type: license}
+	}
+	return d
+}
+
+func TestDiffDependencySnapshots(t *testing.T) {
+	lodash := dep("lodash", "4.17.21", "MIT")
+	base := &DependencySnapshot{
+		Ref: "main",
+		Dependencies: []Dependency{
+			dep("requests", "2.31.0", "Apache-2.0"),
+			dep("Django", "5.0.1", "BSD-3-Clause"),
+			dep("idna", "3.7", "BSD-3-Clause"),
+			dep("gone", "1.0.0", "MIT"),
+			dep("multi", "1.0.0", "MIT"),
+			dep("multi", "2.0.0", "MIT"),
+		},
+		Findings: []vulnerabilities.Finding{{ID: "GHSA-old", Severity: vulnerabilities.SeverityHigh, PackageNames: []string{"django"}}},
+	}
+	head := &DependencySnapshot{
+		Ref: "HEAD",
+		Dependencies: []Dependency{
+			dep("requests", "2.32.0", "Apache-2.0"),
+			dep("django", "4.2.0", "BSD-3-Clause"),
+			dep("idna", "3.7", "MIT"),
+			dep("multi", "2.0.0", "MIT"),
+			dep("multi", "3.0.0", "MIT"),
+			lodash,
+		},
+		Issues: []Issue{{Type: "age_violation", Severity: "high", Message: "too new", Dependency: &lodash}},
+		Findings: []vulnerabilities.Finding{
+			{ID: "GHSA-old", Severity: vulnerabilities.SeverityHigh, PackageNames: []string{"django"}},
+			{ID: "GHSA-low", Severity: vulnerabilities.SeverityLow, PackageNames: []string{"lodash"}},
+			{ID: "GHSA-new", Severity: vulnerabilities.SeverityCritical, PackageNames: []string{"lodash"}},
+			{ID: "GHSA-vex", Severity: vulnerabilities.SeverityCritical, PackageNames: []string{"lodash"}, Suppressed: true},
+		},
+	}
+
+	diff := DiffDependencySnapshots(base, head)
+	names := func(changes []DependencyChange) string {
+		var out []string
+		for _, c := range changes {
+			out = append(out, c.Name+"@"+c.FromVersion+">"+c.ToVersion)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := names(diff.Added); got != "lodash@>4.17.21,multi@>3.0.0" {
+		t.Errorf("added = %s", got)
+	}
+	if got := names(diff.Removed); got != "multi@1.0.0>,gone@1.0.0>" {
+		t.Errorf("removed = %s", got)
+	}
+	if got := names(diff.Upgraded); got != "requests@2.31.0>2.32.0" {
+		t.Errorf("upgraded = %s", got)
+	}
+	if got := names(diff.Downgraded); got != "django@5.0.1>4.2.0" {
+		t.Errorf("downgraded = %s", got)
+	}
+	if len(diff.LicenseChanges) != 1 || diff.LicenseChanges[0].Name != "idna" || diff.LicenseChanges[0].ToLicense != "MIT" {
+		t.Errorf("license changes = %+v", diff.LicenseChanges)
+	}
+	if len(diff.CoolingViolations) != 1 || diff.CoolingViolations[0].Name != "lodash" {
+		t.Errorf("cooling violations = %+v", diff.CoolingViolations)
+	}
+	if len(diff.NewVulnerabilities) != 2 || diff.NewVulnerabilities[0].ID != "GHSA-new" || diff.NewVulnerabilities[1].ID != "GHSA-low" {
+		t.Errorf("new vulnerabilities = %+v", diff.NewVulnerabilities)
+	}
+}
+
+func TestCompareDependencyVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.2.3", "v1.10.0", -1},
+		{"2.10", "2.9", 1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"v0.0.0-20240101000000-abcdef", "v0.0.0-20230101000000-abcdef", 1},
+		{"3.7", "3.7", 0},
+	}
+	for _, tc := range cases {
+		if got := compareDependencyVersions(tc.a, tc.b); got != tc.want {
+			t.Errorf("compare(%s, %s) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestDiffDependencyRefs_Lockfiles(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	project := filepath.Join(dir, "svc")
+	writeTestFile(t, filepath.Join(project, "requirements.txt"), "requests==2.31.0\nidna==3.6\n")
+	commitAll(t, repo, "initial")
+	writeTestFile(t, filepath.Join(project, "requirements.txt"), "requests==2.32.0\nurllib3==2.2.1\n")
+	commitAll(t, repo, "bump")
+	// Uncommitted working tree change compared when head is empty.
+	writeTestFile(t, filepath.Join(project, "requirements.txt"), "requests==2.32.0\nurllib3==2.2.1\ncertifi==2024.2.2\n")
+
+	diff, err := DiffDependencyRefs(context.Background(), project, DependencyDiffOptions{Base: "HEAD~", Head: "HEAD"})
+	if err != nil {
+		t.Fatalf("DiffDependencyRefs: %v", err)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].Name != "urllib3" {
+		t.Errorf("added = %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Name != "idna" {
+		t.Errorf("removed = %+v", diff.Removed)
+	}
+	if len(diff.Upgraded) != 1 || diff.Upgraded[0].ToVersion != "2.32.0" {
+		t.Errorf("upgraded = %+v", diff.Upgraded)
+	}
+
+	diff, err = DiffDependencyRefs(context.Background(), project, DependencyDiffOptions{Base: "HEAD"})
+	if err != nil {
+		t.Fatalf("DiffDependencyRefs working tree: %v", err)
+	}
+	if diff.Head != "working tree" || len(diff.Added) != 1 || diff.Added[0].Name != "certifi" {
+		t.Errorf("working tree diff = %+v", diff)
+	}
+}
+
+func TestDiffDependencyRefs_AnalyzesFullTree(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	t.Setenv("GOWORK", "off")
+	t.Setenv("GOFLAGS", "-mod=mod")
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	// Packages live under src/ and the dependency is a `replace ../` module,
+	// so analyzing manifests alone finds nothing.
+	app := filepath.Join(dir, "app")
+	writeTestFile(t, filepath.Join(app, "go.mod"), "module example.com/app\n\ngo 1.21\n\nrequire example.com/lib v0.1.0\n\nreplace example.com/lib => ../lib\n")
+	writeTestFile(t, filepath.Join(app, "go.sum"), "")
+	writeTestFile(t, filepath.Join(app, "src", "main.go"), "package main\n\nimport \"example.com/lib\"\n\nfunc main() { lib.Hello() }\n")
+	writeTestFile(t, filepath.Join(dir, "lib", "go.mod"), "module example.com/lib\n\ngo 1.21\n")
+	writeTestFile(t, filepath.Join(dir, "lib", "lib.go"), "package lib\n\nfunc Hello() {}\n")
+	commitAll(t, repo, "initial")
+	writeTestFile(t, filepath.Join(app, "README.md"), "docs only\n")
+	commitAll(t, repo, "docs")
+
+	analyze := func(ctx context.Context, dir string) (*AnalysisResult, error) {
+		cmd := exec.CommandContext(ctx, "go", "list", "-deps", "-f", "{{with .Module}}{{if not .Main}}{{.Path}}{{end}}{{end}}", "./...")
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("go list: %w", err)
+		}
+		result := &AnalysisResult{}
+		for _, name := range strings.Fields(string(out)) {
+			result.Dependencies = append(result.Dependencies, Dependency{Module: Module{Name: name, Version: "v0.1.0", Language: LanguageGo}})
+		}
+		return result, nil
+	}
+
+	base, err := LoadDependencySnapshot(context.Background(), app, "HEAD~", DependencyDiffOptions{Analyze: analyze})
+	if err != nil {
+		t.Fatalf("LoadDependencySnapshot: %v", err)
+	}
+	if len(base.Dependencies) != 1 || base.Dependencies[0].Name != "example.com/lib" {
+		t.Fatalf("base snapshot = %+v", base.Dependencies)
+	}
+	diff, err := DiffDependencyRefs(context.Background(), app, DependencyDiffOptions{Base: "HEAD~", Head: "HEAD", Analyze: analyze})
+	if err != nil {
+		t.Fatalf("DiffDependencyRefs: %v", err)
+	}
+	if !diff.Empty() {
+		t.Fatalf("expected empty diff for identical go.mod/go.sum, got %+v", diff)
+	}
+}
+
+func TestLoadDependencySnapshot_RefWithoutManifests(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	writeTestFile(t, filepath.Join(dir, "README.md"), "hello\n")
+	commitAll(t, repo, "initial")
+
+	snapshot, err := LoadDependencySnapshot(context.Background(), dir, "HEAD", DependencyDiffOptions{})
+	if err != nil {
+		t.Fatalf("LoadDependencySnapshot: %v", err)
+	}
+	if len(snapshot.Dependencies) != 0 {
+		t.Fatalf("expected empty snapshot, got %+v", snapshot.Dependencies)
+	}
+}
+
+func TestFilterIssuesToNewDependencies(t *testing.T) {
+	old := dep("requests", "2.31.0", "")
+	bumped := dep("requests", "2.32.0", "")
+	base := &DependencySnapshot{Dependencies: []Dependency{old, dep("@scope/a", "1.0.0", "")}}
+	findings := []vulnerabilities.Finding{
+		{ID: "GHSA-old", PURLs: []string{"pkg:npm/%40scope/a@1.0.0"}},
+		{ID: "GHSA-new", PURLs: []string{"pkg:pypi/requests@2.32.0"}},
+	}
+	issues := []Issue{
+		{Type: "license", Severity: "high", Message: "old", Dependency: &old},
+		{Type: "age_violation", Severity: "high", Message: "bumped", Dependency: &bumped},
+		{Type: "vulnerability", Severity: "info", Message: "Vulnerability report generated: x"},
+		{Type: "vulnerability", Severity: "high", Message: "GHSA-old: high affects 1 package(s)"},
+		{Type: "vulnerability", Severity: "critical", Message: "GHSA-new: critical affects 1 package(s)"},
+		{Type: "policy", Severity: "critical", Message: "denied"},
+	}
+	var got []string
+	for _, issue := range FilterIssuesToNewDependencies(issues, base, findings) {
+		got = append(got, issue.Message)
+	}
+	want := "bumped|Vulnerability report generated: x|GHSA-new: critical affects 1 package(s)|denied"
+	if strings.Join(got, "|") != want {
+		t.Fatalf("filtered = %q", got)
+	}
+}
+
+func TestRenderDependencyDiff(t *testing.T) {
+	diff := &DependencyDiff{
+		Base:     "main",
+		Head:     "HEAD",
+		Added:    []DependencyChange{{Name: "lodash", ToVersion: "4.17.21", ToLicense: "MIT"}},
+		Upgraded: []DependencyChange{{Name: "requests", FromVersion: "2.31.0", ToVersion: "2.32.0"}},
+	}
+	md, err := RenderDependencyDiff(diff, "markdown")
+	if err != nil {
+		t.Fatalf("markdown: %v", err)
+	}
+	for _, want := range []string{"## Dependency changes: `main` → `HEAD`", "| 1 | 0 | 1 | 0 | 0 | 0 | 0 |", "| `lodash` | 4.17.21 | MIT |", "2.31.0 → 2.32.0"} {
+		if !strings.Contains(string(md), want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	data, err := RenderDependencyDiff(diff, "json")
+	if err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	var decoded DependencyDiff
+	if err := json.Unmarshal(data, &decoded); err != nil || len(decoded.Added) != 1 {
+		t.Fatalf("json round trip: %v %+v", err, decoded)
+	}
+	empty, _ := RenderDependencyDiff(&DependencyDiff{Base: "a", Head: "b"}, "markdown")
+	if !strings.Contains(string(empty), "No dependency changes.") {
+		t.Errorf("empty diff = %s", empty)
+	}
+	if _, err := RenderDependencyDiff(diff, "xml"); err == nil {
+		t.Errorf("expected error for unsupported format")
+	}
+}
@@ -26,7 +26,11 @@ import (
 )
 
 // GoAnalyzer implements Analyzer for Go dependencies.
-type GoAnalyzer struct{}
+type GoAnalyzer struct {
+	// registryClient overrides the module proxy client used for cooling
+	// metadata; nil uses registry.NewGoClient.
+	registryClient registry.Client
+}
 
 type goListModule struct {
 	Path    string `json:"Path"`
@@ -87,7 +91,10 @@ func (a *GoAnalyzer) Analyze(ctx context.Context, target string, cfg AnalysisCon
 	}
 
 	// Create registry client for cooling metadata
-	registryClient := registry.NewGoClient(24 * time.Hour)
+	registryClient := a.registryClient
+	if registryClient == nil {
+		registryClient = registry.NewGoClient(24 * time.Hour)
+	}
 
 	deps := make([]Dependency, 0, len(modules))
 	for _, mod := range modules {
@@ -111,6 +118,10 @@ func (a *GoAnalyzer) Analyze(ctx context.Context, target string, cfg AnalysisCon
 				dep.Metadata["publish_date"] = metadata.PublishDate
 				dep.Metadata["total_downloads"] = metadata.TotalDownloads
 				dep.Metadata["recent_downloads"] = metadata.RecentDownloads
+			} else if errors.Is(err, registry.ErrOfflineCacheMiss) {
+				// No age is recorded so the miss cannot pass cooling silently.
+				dep.Metadata["registry_error"] = err.Error()
+				dep.Metadata["offline_cache_miss"] = true
 			} else {
 				dep.Metadata["age_days"] = 365
 				dep.Metadata["registry_error"] = err.Error()
@@ -199,7 +210,7 @@ func (a *GoAnalyzer) Analyze(ctx context.Context, target string, cfg AnalysisCon
 				}
 				if checkLicenses {
 					if licenseCfg, err := policy.ParseLicenseConfig(policyConfig); err == nil {
-						licenseIssues, licensePassed := evaluateForbiddenLicenses(deps, licenseCfg, time.Now())
+						licenseIssues, licensePassed := evaluateLicensePolicy(deps, licenseCfg, time.Now())
 						issues = append(issues, licenseIssues...)
 						if !licensePassed {
 							passed = false
@@ -213,7 +224,14 @@ func (a *GoAnalyzer) Analyze(ctx context.Context, target string, cfg AnalysisCon
 						for i := range deps {
 							dep := &deps[i]
 							coolingResult, err := coolingChecker.Check(dep)
-							if err != nil {
+							if err != nil || coolingResult.IsException {
+								continue
+							}
+							if miss, _ := dep.Metadata["offline_cache_miss"].(bool); miss {
+								issues = append(issues, Issue{Type: "cooling", Severity: "high", Message: fmt.Sprintf("Cannot verify cooling for %s (%s): %v", dep.Name, dep.Version, dep.Metadata["registry_error"]), Dependency: dep})
+								if !coolCfg.AlertOnly {
+									passed = false
+								}
 								continue
 							}
 							if !coolingResult.Passed {
@@ -234,14 +252,18 @@ func (a *GoAnalyzer) Analyze(ctx context.Context, target string, cfg AnalysisCon
 
 		engine := policy.NewOPAEngine()
 		if err := engine.LoadPolicy(cfg.PolicyPath); err == nil {
-			input := map[string]interface{}{"dependencies": deps, "policy": policyConfig}
-			if result, err := engine.Evaluate(ctx, input); err == nil {
-				if denials, ok := result["data.goneat.dependencies.deny"].([]interface{}); ok {
-					for _, denial := range denials {
-						if msg, ok := denial.(string); ok {
-							issues = append(issues, Issue{Type: "policy", Severity: "critical", Message: msg, Dependency: nil})
-							passed = false
-						}
+			input, err := BuildPolicyInput(ctx, PolicyInputOptions{
+				Target:       absTarget,
+				PolicyPath:   cfg.PolicyPath,
+				Languages:    []Language{LanguageGo},
+				Dependencies: deps,
+				SkipGraph:    true,
+			})
+			if err == nil {
+				if result, err := engine.Evaluate(ctx, input); err == nil {
+					for _, msg := range regoDenials(result, checkLicenses, checkCooling) {
+						issues = append(issues, Issue{Type: "policy", Severity: "critical", Message: msg, Dependency: nil})
+						passed = false
 					}
 				}
 			}
@@ -251,6 +273,38 @@ func (a *GoAnalyzer) Analyze(ctx context.Context, target string, cfg AnalysisCon
 	return &AnalysisResult{Dependencies: deps, Issues: issues, Passed: passed, Duration: time.Since(start)}, nil
 }
 
+// regoDenials returns the built-in Rego denials not already reported by the
+// Go license and cooling checks, which also apply exceptions.
+func regoDenials(result map[string]interface{}, checkLicenses, checkCooling bool) []string {
+	covered := make(map[string]bool)
+	if checkLicenses {
+		for _, v := range asList(result["data.goneat.dependencies.license_deny"]) {
+			if msg, ok := v.(string); ok {
+				covered[msg] = true
+			}
+		}
+	}
+	if checkCooling {
+		for _, v := range asList(result["data.goneat.dependencies.cooling_deny"]) {
+			if msg, ok := v.(string); ok {
+				covered[msg] = true
+			}
+		}
+	}
+	var denials []string
+	for _, v := range asList(result["data.goneat.dependencies.deny"]) {
+		if msg, ok := v.(string); ok && !covered[msg] {
+			denials = append(denials, msg)
+		}
+	}
+	return denials
+}
+
+func asList(v interface{}) []interface{} {
+	list, _ := v.([]interface{})
+	return list
+}
+
 func (a *GoAnalyzer) DetectLanguages(target string) ([]Language, error) {
 	detector := NewDetector(&config.DependenciesConfig{})
 	lang, _, err := detector.Detect(target)
@@ -12,42 +12,9 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
-	"time"
-)
-
-type goModuleGraphSBOM struct {
-	BOMFormat    string                   `json:"bomFormat"`
-	SpecVersion  string                   `json:"specVersion"`
-	Version      int                      `json:"version"`
-	Metadata     goModuleSBOMMetadata     `json:"metadata"`
-	Components   []goModuleSBOMComponent  `json:"components"`
-	Dependencies []goModuleSBOMDependency `json:"dependencies,omitempty"`
-}
-
-type goModuleSBOMMetadata struct {
-	Timestamp string                         `json:"timestamp"`
-	Component goModuleSBOMComponent          `json:"component"`
-	Tools     map[string][]map[string]string `json:"tools,omitempty"`
-}
-
-type goModuleSBOMComponent struct {
-	BOMRef     string                 `json:"bom-ref,omitempty"`
-	Type       string                 `json:"type"`
-	Name       string                 `json:"name"`
-	Version    string                 `json:"version,omitempty"`
-	PURL       string                 `json:"purl,omitempty"`
-	Properties []goModuleSBOMProperty `json:"properties,omitempty"`
-}
 
-type goModuleSBOMProperty struct {
-	Name  string `json:"name"`
-	Value string `json:"value"`
-}
-
-type goModuleSBOMDependency struct {
-	Ref       string   `json:"ref"`
-	DependsOn []string `json:"dependsOn"`
-}
+	"github.com/fulmenhq/goneat/pkg/sbom"
+)
 
 type goListModuleGraphEntry struct {
 	Path    string `json:"Path"`
@@ -62,16 +29,25 @@ type goListModuleGraphEntry struct {
 }
 
 func generateGoModuleGraphSBOM(ctx context.Context, target string, outputPath string) (int, error) {
-	if _, err := os.Stat(filepath.Join(target, "go.mod")); err != nil {
+	doc, err := goModuleDocument(ctx, target)
+	if err != nil {
 		return 0, err
 	}
+	return writeNativeSBOM(doc, target, outputPath, NativeSBOMOptions{})
+}
+
+// goModuleDocument builds an SBOM document from `go list -m all`.
+func goModuleDocument(ctx context.Context, target string) (*sbom.Document, error) {
+	if _, err := os.Stat(filepath.Join(target, "go.mod")); err != nil {
+		return nil, err
+	}
 
 	modules, err := listGoModules(ctx, target)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	if len(modules) == 0 {
-		return 0, fmt.Errorf("go module graph is empty")
+		return nil, fmt.Errorf("go module graph is empty")
 	}
 
 	sort.Slice(modules, func(i, j int) bool {
@@ -85,7 +61,7 @@ func generateGoModuleGraphSBOM(ctx context.Context, target string, outputPath st
 	})
 
 	mainRef := ""
-	components := make([]goModuleSBOMComponent, 0, len(modules))
+	doc := &sbom.Document{Name: modules[0].Path}
 	depRefs := make([]string, 0, len(modules))
 	for _, mod := range modules {
 		ref := goModuleBOMRef(mod)
@@ -97,64 +73,28 @@ func generateGoModuleGraphSBOM(ctx context.Context, target string, outputPath st
 			depRefs = append(depRefs, ref)
 		}
 
-		component := goModuleSBOMComponent{
-			BOMRef:  ref,
-			Type:    componentType,
-			Name:    mod.Path,
-			Version: mod.Version,
-			PURL:    goModulePURL(mod),
-			Properties: []goModuleSBOMProperty{
-				{Name: "goneat:source_type", Value: "go-module-graph"},
-				{Name: "goneat:source_path", Value: "go.mod"},
-			},
+		pkg := sbom.Package{
+			Ref:        ref,
+			Type:       componentType,
+			Name:       mod.Path,
+			Version:    mod.Version,
+			PURL:       goModulePURL(mod),
+			Properties: nativeSourceProperties("go-module-graph", "go.mod"),
 		}
 		if mod.Replace != nil {
-			component.Properties = append(component.Properties, goModuleSBOMProperty{Name: "goneat:replace_path", Value: mod.Replace.Path})
+			pkg.Properties = append(pkg.Properties, sbom.Property{Name: "goneat:replace_path", Value: mod.Replace.Path})
 		}
-		components = append(components, component)
+		doc.Packages = append(doc.Packages, pkg)
 	}
 
 	if mainRef == "" {
-		mainRef = components[0].BOMRef
-	}
-
-	bom := goModuleGraphSBOM{
-		BOMFormat:   "CycloneDX",
-		SpecVersion: "1.5",
-		Version:     1,
-		Metadata: goModuleSBOMMetadata{
-			Timestamp: time.Now().UTC().Format(time.RFC3339),
-			Component: goModuleSBOMComponent{
-				BOMRef:  mainRef,
-				Type:    "application",
-				Name:    modules[0].Path,
-				Version: modules[0].Version,
-				PURL:    goModulePURL(modules[0]),
-			},
-			Tools: map[string][]map[string]string{
-				"components": {
-					{"type": "application", "name": "goneat", "version": "unknown"},
-				},
-			},
-		},
-		Components: components,
-		Dependencies: []goModuleSBOMDependency{
-			{Ref: mainRef, DependsOn: depRefs},
-		},
-	}
-
-	data, err := json.MarshalIndent(bom, "", "  ")
-	if err != nil {
-		return 0, fmt.Errorf("marshal go module graph sbom: %w", err)
-	}
-	if err := os.MkdirAll(filepath.Dir(outputPath), 0o750); err != nil {
-		return 0, fmt.Errorf("create sbom directory: %w", err)
+		mainRef = doc.Packages[0].Ref
 	}
-	if err := os.WriteFile(outputPath, data, 0o600); err != nil {
-		return 0, fmt.Errorf("write go module graph sbom: %w", err)
+	doc.Root = mainRef
+	for _, ref := range depRefs {
+		doc.Relationships = append(doc.Relationships, sbom.Relationship{From: mainRef, To: ref})
 	}
-
-	return len(components), nil
+	return doc, nil
 }
 
 func listGoModules(ctx context.Context, target string) ([]goListModuleGraphEntry, error) {
@@ -2,23 +2,28 @@ package dependencies
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/fulmenhq/goneat/pkg/config"
+	"github.com/fulmenhq/goneat/pkg/dependencies/policy"
+	"github.com/fulmenhq/goneat/pkg/dependencies/spdx"
+	"gopkg.in/yaml.v3"
 )
 
-func evaluateForbiddenLicenses(deps []Dependency, licenseCfg *config.LicensePolicyConfig, now time.Time) ([]Issue, bool) {
-	if licenseCfg == nil || len(licenseCfg.Forbidden) == 0 {
+// evaluateLicensePolicy checks each dependency's license expression against
+// the allowed and forbidden lists. Expressions are parsed as SPDX (AND/OR/WITH,
+// `+`, -only/-or-later, deprecated IDs) and a dependency passes when some
+// choice of licenses is acceptable; the chosen branch is recorded in
+// Metadata["license_choice"] when it differs from the declared expression.
+func evaluateLicensePolicy(deps []Dependency, licenseCfg *config.LicensePolicyConfig, now time.Time) ([]Issue, bool) {
+	if licenseCfg == nil {
 		return nil, true
 	}
-
-	forbidden := make(map[string]struct{}, len(licenseCfg.Forbidden))
-	for _, license := range licenseCfg.Forbidden {
-		license = strings.TrimSpace(license)
-		if license != "" {
-			forbidden[license] = struct{}{}
-		}
+	licensePolicy := spdx.NewPolicy(append(append([]string{}, licenseCfg.Allowed...), licenseCfg.Allow...), licenseCfg.Forbidden)
+	if licensePolicy.Empty() {
+		return nil, true
 	}
 
 	issues := []Issue{}
@@ -26,12 +31,19 @@ func evaluateForbiddenLicenses(deps []Dependency, licenseCfg *config.LicensePoli
 
 	for i := range deps {
 		dep := &deps[i]
-		if dep.License == nil {
+		if dep.License == nil || strings.TrimSpace(dep.License.Type) == "" {
 			continue
 		}
 
-		licenseType := strings.TrimSpace(dep.License.Type)
-		if _, ok := forbidden[licenseType]; !ok {
+		node := parseLicenseNode(dep.License.Type)
+		decision := licensePolicy.Evaluate(node)
+		if decision.Acceptable {
+			if choice := decision.ChoiceString(); choice != node.String() {
+				if dep.Metadata == nil {
+					dep.Metadata = map[string]interface{}{}
+				}
+				dep.Metadata["license_choice"] = choice
+			}
 			continue
 		}
 
@@ -39,18 +51,68 @@ func evaluateForbiddenLicenses(deps []Dependency, licenseCfg *config.LicensePoli
 			continue
 		}
 
-		issues = append(issues, Issue{
-			Type:       "license",
-			Severity:   "critical",
-			Message:    fmt.Sprintf("Package %s uses forbidden license: %s", dep.Name, dep.License.Type),
-			Dependency: dep,
-		})
+		issues = append(issues, licensePolicyIssue(dep, node, decision))
 		passed = false
 	}
 
 	return issues, passed
 }
 
+// licensePolicyIssue reports a dependency with no acceptable license choice.
+// Forbidden licenses are critical; licenses merely outside the allowed list
+// are high.
+func licensePolicyIssue(dep *Dependency, node spdx.Node, decision spdx.Decision) Issue {
+	forbidden := false
+	for _, r := range decision.Rejected {
+		if strings.HasPrefix(r.Reason, "is forbidden") {
+			forbidden = true
+		}
+	}
+	issue := Issue{Type: "license", Severity: "high", Dependency: dep}
+	if forbidden {
+		issue.Severity = "critical"
+		issue.Message = fmt.Sprintf("Package %s uses forbidden license: %s", dep.Name, dep.License.Type)
+	} else {
+		issue.Message = fmt.Sprintf("Package %s license %s is not in the allowed list", dep.Name, dep.License.Type)
+	}
+	// Explain the rejected choices unless the expression was a single license
+	// rejected verbatim.
+	if _, single := node.(spdx.Term); !single || strings.Contains(decision.Reason(), "(matches") {
+		issue.Message += fmt.Sprintf(" (no acceptable choice: %s)", decision.Reason())
+	}
+	return issue
+}
+
+// parseLicenseNode parses a license expression, treating unparseable values
+// (free-form names, "Unknown") as a single opaque license identifier.
+func parseLicenseNode(expr string) spdx.Node {
+	if node, err := spdx.Parse(expr); err == nil {
+		return node
+	}
+	return spdx.Term{ID: strings.TrimSpace(expr)}
+}
+
+// loadLicensePolicyConfig reads the license section of a dependencies policy
+// file; it returns nil when the file or section is absent.
+func loadLicensePolicyConfig(policyPath string) *config.LicensePolicyConfig {
+	if policyPath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(policyPath) // #nosec G304 - user-specified policy file
+	if err != nil {
+		return nil
+	}
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+	licenseCfg, err := policy.ParseLicenseConfig(raw)
+	if err != nil {
+		return nil
+	}
+	return licenseCfg
+}
+
 func matchesLicenseException(dep Dependency, exceptions []config.LicenseException, now time.Time) bool {
 	if dep.License == nil {
 		return false
@@ -86,12 +148,13 @@ func licenseExceptionMatchesLicense(exc config.LicenseException, license string)
 		return false
 	}
 
-	if strings.TrimSpace(exc.License) == license {
+	canonical := parseLicenseNode(license).String()
+	if sameLicense(exc.License, license, canonical) {
 		return true
 	}
 
 	for _, allowed := range exc.Licenses {
-		if strings.TrimSpace(allowed) == license {
+		if sameLicense(allowed, license, canonical) {
 			return true
 		}
 	}
@@ -99,6 +162,16 @@ func licenseExceptionMatchesLicense(exc config.LicenseException, license string)
 	return false
 }
 
+// sameLicense compares an exception's license to a dependency license either
+// verbatim or after SPDX canonicalization (GPL-3.0 == GPL-3.0-only).
+func sameLicense(candidate, license, canonical string) bool {
+	candidate = strings.TrimSpace(candidate)
+	if candidate == "" {
+		return false
+	}
+	return candidate == license || parseLicenseNode(candidate).String() == canonical
+}
+
 func licenseExceptionActive(exc config.LicenseException, now time.Time) bool {
 	currentDate := policyDateOnly(now)
 
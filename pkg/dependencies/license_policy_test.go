@@ -1,13 +1,17 @@
 package dependencies
 
 import (
+	"context"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/fulmenhq/goneat/pkg/config"
+	"github.com/fulmenhq/goneat/pkg/dependencies/policy"
 )
 
-func TestEvaluateForbiddenLicenses(t *testing.T) {
+func TestEvaluateLicensePolicy(t *testing.T) {
 	now := time.Date(2026, time.March, 30, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
@@ -84,7 +88,7 @@ func TestEvaluateForbiddenLicenses(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			deps := []Dependency{testForbiddenLicenseDependency()}
 
-			issues, passed := evaluateForbiddenLicenses(deps, tt.licenseCfg, now)
+			issues, passed := evaluateLicensePolicy(deps, tt.licenseCfg, now)
 			if len(issues) != tt.wantIssues {
 				t.Fatalf("expected %d issues, got %d", tt.wantIssues, len(issues))
 			}
@@ -111,3 +115,114 @@ func testForbiddenLicenseDependency() Dependency {
 		Metadata: map[string]interface{}{},
 	}
 }
+
+func TestEvaluateLicensePolicy_Expressions(t *testing.T) {
+	now := time.Date(2026, time.March, 30, 12, 0, 0, 0, time.UTC)
+	dep := func(name, license string) Dependency {
+		return Dependency{Module: Module{Name: name}, License: &License{Type: license}}
+	}
+
+	deps := []Dependency{
+		dep("dual", "MIT OR GPL-3.0"),
+		dep("later", "GPL-3.0-or-later"),
+		dep("llvm", "Apache-2.0 WITH LLVM-exception"),
+		dep("both", "MIT AND GPL-3.0+"),
+		dep("unknown", "Unknown"),
+	}
+	issues, passed := evaluateLicensePolicy(deps, &config.LicensePolicyConfig{
+		Allowed:   []string{"MIT", "Apache-2.0", "GPL-3.0"},
+		Forbidden: []string{"GPL-3.0"},
+	}, now)
+	if passed {
+		t.Fatal("expected policy to fail")
+	}
+
+	got := map[string]Issue{}
+	for _, issue := range issues {
+		got[issue.Dependency.Name] = issue
+	}
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %+v", issues)
+	}
+	if got["later"].Severity != "critical" || got["both"].Severity != "critical" {
+		t.Fatalf("forbidden expressions should be critical: %+v", issues)
+	}
+	if got["unknown"].Severity != "high" {
+		t.Fatalf("licenses outside the allowed list should be high: %+v", got["unknown"])
+	}
+	if deps[0].Metadata["license_choice"] != "MIT" {
+		t.Fatalf("expected chosen branch to be recorded, got %v", deps[0].Metadata)
+	}
+	if _, ok := deps[2].Metadata["license_choice"]; ok {
+		t.Fatalf("unchanged expressions should not record a choice: %v", deps[2].Metadata)
+	}
+}
+
+func TestLicenseExceptionMatchesCanonicalLicense(t *testing.T) {
+	exc := config.LicenseException{Package: "pkg", License: "GPL-3.0"}
+	if !licenseExceptionMatchesLicense(exc, "GPL-3.0-only") {
+		t.Fatal("deprecated and canonical identifiers should match")
+	}
+	if licenseExceptionMatchesLicense(exc, "GPL-3.0-or-later") {
+		t.Fatal("-only exception should not cover -or-later")
+	}
+}
+
+func TestRegoDenials_SkipsFindingsOfGoChecks(t *testing.T) {
+	t.Setenv("GONEAT_OFFLINE_SCHEMA_VALIDATION", "true")
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "dependencies.yaml")
+	writeTestFile(t, policyPath, "version: v1\nlicenses:\n  forbidden: [GPL-3.0]\ncooling:\n  enabled: true\n  min_age_days: 30\n")
+
+	engine := policy.NewOPAEngine()
+	if err := engine.LoadPolicy(policyPath); err != nil {
+		t.Fatalf("LoadPolicy: %v", err)
+	}
+	deps := []Dependency{{
+		Module:   Module{Name: "later", Version: "v1.0.0"},
+		License:  &License{Type: "GPL-3.0-or-later"},
+		Metadata: map[string]interface{}{"age_days": 5},
+	}}
+	input, err := BuildPolicyInput(context.Background(), PolicyInputOptions{Target: dir, PolicyPath: policyPath, Dependencies: deps, SkipGraph: true})
+	if err != nil {
+		t.Fatalf("BuildPolicyInput: %v", err)
+	}
+	result, err := engine.Evaluate(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+
+	// The solver decision in the input rejects the -or-later expression
+	all := regoDenials(result, false, false)
+	if len(all) != 2 {
+		t.Fatalf("expected license and cooling denials, got %v", all)
+	}
+	if got := regoDenials(result, true, false); len(got) != 1 || !strings.Contains(got[0], "violates cooling policy: 5 days old") {
+		t.Fatalf("license denials should be left to the Go check, got %v", got)
+	}
+	if got := regoDenials(result, true, true); len(got) != 0 {
+		t.Fatalf("expected no duplicate denials, got %v", got)
+	}
+}
+
+func TestRustAnalysisResult_AppliesLicensePolicyWithoutCargoDenyResult(t *testing.T) {
+	policyPath := filepath.Join(t.TempDir(), "dependencies.yaml")
+	writeTestFile(t, policyPath, "version: v1\nlicenses:\n  forbidden:\n    - GPL-3.0\n")
+	deps := []Dependency{
+		{Module: Module{Name: "serde", Language: LanguageRust}, License: &License{Type: "MIT OR Apache-2.0"}},
+		{Module: Module{Name: "gpl-crate", Language: LanguageRust}, License: &License{Type: "GPL-3.0-only"}},
+	}
+
+	result := rustAnalysisResult(deps, nil, AnalysisConfig{PolicyPath: policyPath, CheckLicenses: true}, time.Now())
+	if result.Passed {
+		t.Error("forbidden crate license should fail the analysis")
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Dependency == nil || result.Issues[0].Dependency.Name != "gpl-crate" {
+		t.Fatalf("issues = %+v", result.Issues)
+	}
+
+	result = rustAnalysisResult(deps, nil, AnalysisConfig{PolicyPath: policyPath}, time.Now())
+	if !result.Passed || len(result.Issues) != 0 {
+		t.Errorf("license policy applied without --licenses: %+v", result.Issues)
+	}
+}
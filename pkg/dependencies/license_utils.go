@@ -1,6 +1,11 @@
 package dependencies
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/dependencies/spdx"
+	"github.com/fulmenhq/goneat/pkg/sbom"
+)
 
 // detectLicenseType returns license type from name or content
 func detectLicenseType(nameOrContent string) string {
@@ -70,3 +75,44 @@ func getLicenseURL(licenseType string) string {
 		return ""
 	}
 }
+
+// SBOMLicenses maps analyzed dependencies to SBOM license fields keyed by
+// "name@version". The declared license is the analyzer's license name when it
+// is a valid SPDX expression (e.g. Cargo metadata); the concluded license is
+// the license type goneat detected.
+func SBOMLicenses(deps []Dependency) map[string]sbom.PackageLicense {
+	out := make(map[string]sbom.PackageLicense, len(deps))
+	for _, dep := range deps {
+		if dep.License == nil {
+			continue
+		}
+		concluded := strings.TrimSpace(dep.License.Type)
+		if strings.EqualFold(concluded, "unknown") {
+			concluded = ""
+		}
+		declared := concluded
+		if isSPDXExpression(dep.License.Name) {
+			declared = strings.TrimSpace(dep.License.Name)
+		}
+		if declared == "" && concluded == "" {
+			continue
+		}
+		out[dep.Name+"@"+dep.Version] = sbom.PackageLicense{Declared: declared, Concluded: concluded}
+	}
+	return out
+}
+
+// isSPDXExpression reports whether every identifier in expr is on the SPDX
+// license list, so license file names are not mistaken for expressions.
+func isSPDXExpression(expr string) bool {
+	node, err := spdx.Parse(expr)
+	if err != nil {
+		return false
+	}
+	for _, id := range spdx.IDs(node) {
+		if !spdx.Known(id) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,213 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/buildinfo"
+	"github.com/fulmenhq/goneat/pkg/sbom"
+)
+
+// SBOM engines selectable with `dependencies --sbom-engine`.
+const (
+	// SBOMEngineAuto uses syft when it is installed and the native
+	// generators otherwise.
+	SBOMEngineAuto   = "auto"
+	SBOMEngineNative = "native"
+	SBOMEngineSyft   = "syft"
+)
+
+// ErrNoNativeSBOMSource is returned when a target has no module graph or
+// lockfile the native generators understand.
+var ErrNoNativeSBOMSource = errors.New("no go.mod, Cargo.lock, npm/pnpm/yarn or Python lockfile found for native SBOM generation")
+
+// NativeSBOMOptions controls SBOM generation from module graphs and
+// lockfiles without syft.
+type NativeSBOMOptions struct {
+	// Format is one of sbom.Formats (default: cyclonedx-json).
+	Format string
+	// Namespace is the SPDX document namespace (default: derived from the
+	// repository and VERSION file).
+	Namespace string
+	// Licenses enriches packages with license analyzer results.
+	Licenses map[string]sbom.PackageLicense
+}
+
+// GenerateNativeSBOM writes an SBOM for target built by goneat itself from the
+// Go module graph, Cargo.lock, npm/pnpm/yarn lockfiles or Python lockfiles,
+// and returns the number of packages it contains.
+func GenerateNativeSBOM(ctx context.Context, target string, outputPath string, opts NativeSBOMOptions) (int, error) {
+	doc, err := NativeSBOMDocument(ctx, target)
+	if err != nil {
+		return 0, err
+	}
+	return writeNativeSBOM(doc, target, outputPath, opts)
+}
+
+// NativeSBOMDocument builds the dependency document for target from every
+// ecosystem detected there, in the same order as language detection. A
+// project with several (say a Go service with an npm front end) gets one
+// document whose root depends on the root of each ecosystem.
+func NativeSBOMDocument(ctx context.Context, target string) (*sbom.Document, error) {
+	var docs []*sbom.Document
+	if fileExists(filepath.Join(target, "go.mod")) {
+		doc, err := goModuleDocument(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	if project := DetectRustProject(target); project != nil {
+		lockPath := filepath.Join(project.EffectiveRoot(), "Cargo.lock")
+		if fileExists(lockPath) {
+			doc, err := cargoLockDocument(project, lockPath)
+			if err != nil {
+				return nil, err
+			}
+			docs = append(docs, doc)
+		}
+	}
+	if lockPath := findNPMLockfile(target); lockPath != "" {
+		doc, err := npmLockDocument(target, lockPath)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	if lockPath := findPythonLockfile(target); lockPath != "" {
+		doc, err := pythonLockDocument(target, lockPath)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+
+	switch len(docs) {
+	case 0:
+		return nil, ErrNoNativeSBOMSource
+	case 1:
+		return docs[0], nil
+	}
+	return mergeNativeDocuments(target, docs), nil
+}
+
+// mergeNativeDocuments combines per-ecosystem documents under a generic
+// project package named after target and its VERSION file.
+func mergeNativeDocuments(target string, docs []*sbom.Document) *sbom.Document {
+	merged := &sbom.Document{}
+	seenPackages := make(map[string]bool)
+	seenEdges := make(map[sbom.Relationship]bool)
+	for _, doc := range docs {
+		for _, p := range doc.Packages {
+			if !seenPackages[p.Ref] {
+				seenPackages[p.Ref] = true
+				merged.Packages = append(merged.Packages, p)
+			}
+		}
+		for _, r := range doc.Relationships {
+			if !seenEdges[r] {
+				seenEdges[r] = true
+				merged.Relationships = append(merged.Relationships, r)
+			}
+		}
+	}
+
+	name := target
+	if abs, err := filepath.Abs(target); err == nil {
+		name = filepath.Base(abs)
+	}
+	version := ""
+	if data, err := os.ReadFile(filepath.Join(target, "VERSION")); err == nil { // #nosec G304 - VERSION file inside the scanned project
+		version = strings.TrimSpace(string(data))
+	}
+	purl := "pkg:generic/" + url.PathEscape(name)
+	if version != "" {
+		purl += "@" + url.PathEscape(version)
+	}
+	attachRoot(merged, sbom.Package{Ref: purl, Name: name, Version: version, PURL: purl, Properties: nativeSourceProperties("project", ".")})
+	return merged
+}
+
+// NativeSBOMSupported reports whether the native generators can handle target.
+func NativeSBOMSupported(target string) bool {
+	if fileExists(filepath.Join(target, "go.mod")) || findNPMLockfile(target) != "" || findPythonLockfile(target) != "" {
+		return true
+	}
+	project := DetectRustProject(target)
+	return project != nil && fileExists(filepath.Join(project.EffectiveRoot(), "Cargo.lock"))
+}
+
+func writeNativeSBOM(doc *sbom.Document, target string, outputPath string, opts NativeSBOMOptions) (int, error) {
+	doc.Created = time.Now().UTC()
+	doc.ToolName = "goneat"
+	doc.ToolVersion = buildinfo.BinaryVersion
+	doc.Namespace = opts.Namespace
+	if doc.Namespace == "" {
+		doc.Namespace = sbom.ProjectNamespace(target)
+	}
+	doc.ApplyLicenses(opts.Licenses)
+
+	format := opts.Format
+	if format == "" {
+		format = sbom.FormatCycloneDXJSON
+	}
+	data, err := doc.Encode(format)
+	if err != nil {
+		return 0, fmt.Errorf("encode native sbom: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o750); err != nil {
+		return 0, fmt.Errorf("create sbom directory: %w", err)
+	}
+	if err := os.WriteFile(outputPath, data, 0o600); err != nil {
+		return 0, fmt.Errorf("write native sbom: %w", err)
+	}
+	return len(doc.Packages), nil
+}
+
+func nativeSourceProperties(sourceType, sourcePath string) []sbom.Property {
+	return []sbom.Property{
+		{Name: "goneat:source_type", Value: sourceType},
+		{Name: "goneat:source_path", Value: sourcePath},
+	}
+}
+
+// attachRoot makes root the described package. When the lockfile does not
+// list the project itself, root is added and linked to every package nothing
+// else depends on.
+func attachRoot(doc *sbom.Document, root sbom.Package) {
+	if root.Type == "" {
+		root.Type = "application"
+	}
+	if doc.Name == "" {
+		doc.Name = root.Name
+	}
+	if existing := doc.Package(root.Ref); existing != nil {
+		existing.Type = root.Type
+		doc.Root = root.Ref
+		return
+	}
+	incoming := make(map[string]bool, len(doc.Relationships))
+	for _, r := range doc.Relationships {
+		incoming[r.To] = true
+	}
+	var edges []sbom.Relationship
+	for _, p := range doc.Packages {
+		if !incoming[p.Ref] {
+			edges = append(edges, sbom.Relationship{From: root.Ref, To: p.Ref})
+		}
+	}
+	doc.Packages = append([]sbom.Package{root}, doc.Packages...)
+	doc.Relationships = append(edges, doc.Relationships...)
+	doc.Root = root.Ref
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
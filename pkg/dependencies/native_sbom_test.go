@@ -0,0 +1,299 @@
+package dependencies
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/fulmenhq/goneat/pkg/sbom"
+)
+
+func nativeDocument(t *testing.T, dir string) *sbom.Document {
+	t.Helper()
+	doc, err := NativeSBOMDocument(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("NativeSBOMDocument: %v", err)
+	}
+	return doc
+}
+
+func dependsOn(doc *sbom.Document, from string) []string {
+	var out []string
+	for _, r := range doc.Relationships {
+		if r.From == from {
+			out = append(out, r.To)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func assertEdges(t *testing.T, doc *sbom.Document, from string, want ...string) {
+	t.Helper()
+	got := dependsOn(doc, from)
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Fatalf("%s depends on %v, want %v", from, got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("%s depends on %v, want %v", from, got, want)
+		}
+	}
+}
+
+func TestNativeSBOM_CargoLock(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "Cargo.toml"), "[package]\nname = \"app\"\nversion = \"0.1.0\"\n")
+	writeTestFile(t, filepath.Join(dir, "Cargo.lock"), `version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = ["serde", "rand 0.8.5"]
+
+[[package]]
+name = "rand"
+version = "0.8.5"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "rand"
+version = "0.7.3"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "serde"
+version = "1.0.200"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+dependencies = ["rand 0.7.3"]
+`)
+	doc := nativeDocument(t, dir)
+	if doc.Root != "pkg:cargo/app@0.1.0" || len(doc.Packages) != 4 {
+		t.Fatalf("root = %q, packages = %d", doc.Root, len(doc.Packages))
+	}
+	if doc.Package(doc.Root).Type != "application" {
+		t.Fatalf("root type = %q", doc.Package(doc.Root).Type)
+	}
+	assertEdges(t, doc, "pkg:cargo/app@0.1.0", "pkg:cargo/serde@1.0.200", "pkg:cargo/rand@0.8.5")
+	assertEdges(t, doc, "pkg:cargo/serde@1.0.200", "pkg:cargo/rand@0.7.3")
+}
+
+func TestNativeSBOM_PackageLock(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "package.json"), `{"name":"web","version":"1.0.0"}`)
+	writeTestFile(t, filepath.Join(dir, "package-lock.json"), `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "web", "version": "1.0.0", "dependencies": {"@scope/a": "^1.0.0"}, "devDependencies": {"b": "^2.0.0"}},
+    "node_modules/@scope/a": {"version": "1.2.0", "dependencies": {"b": "^1.0.0"}},
+    "node_modules/@scope/a/node_modules/b": {"version": "1.5.0"},
+    "node_modules/b": {"version": "2.0.1", "dev": true}
+  }
+}`)
+	doc := nativeDocument(t, dir)
+	if doc.Root != "pkg:npm/web@1.0.0" {
+		t.Fatalf("root = %q", doc.Root)
+	}
+	assertEdges(t, doc, "pkg:npm/web@1.0.0", "pkg:npm/%40scope/a@1.2.0", "pkg:npm/b@2.0.1")
+	assertEdges(t, doc, "pkg:npm/%40scope/a@1.2.0", "pkg:npm/b@1.5.0")
+}
+
+func TestNativeSBOM_PNPMLock(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "package.json"), `{"name":"web","version":"1.0.0"}`)
+	writeTestFile(t, filepath.Join(dir, "pnpm-lock.yaml"), `lockfileVersion: '9.0'
+importers:
+  .:
+    dependencies:
+      react-dom:
+        specifier: ^18.0.0
+        version: 18.2.0(react@18.2.0)
+packages:
+  react@18.2.0:
+    resolution: {integrity: sha512-x}
+  react-dom@18.2.0:
+    resolution: {integrity: sha512-y}
+snapshots:
+  react@18.2.0: {}
+  react-dom@18.2.0(react@18.2.0):
+    dependencies:
+      react: 18.2.0
+`)
+	doc := nativeDocument(t, dir)
+	assertEdges(t, doc, "pkg:npm/web@1.0.0", "pkg:npm/react-dom@18.2.0")
+	assertEdges(t, doc, "pkg:npm/react-dom@18.2.0", "pkg:npm/react@18.2.0")
+}
+
+func TestNativeSBOM_YarnLock(t *testing.T) {
+	for name, lock := range map[string]string{
+		"classic": `# yarn lockfile v1
+
+"@babel/highlight@^7.10.4":
+  version "7.10.4"
+  dependencies:
+    js-tokens "^4.0.0"
+
+js-tokens@^4.0.0:
+  version "4.0.0"
+`,
+		"berry": `__metadata:
+  version: 6
+
+"@babel/highlight@npm:^7.10.4":
+  version: 7.10.4
+  dependencies:
+    js-tokens: "npm:^4.0.0"
+
+"js-tokens@npm:^4.0.0":
+  version: 4.0.0
+
+"web@workspace:.":
+  version: 0.0.0-use.local
+`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			rng := "^7.10.4"
+			if name == "berry" {
+				rng = "npm:^7.10.4"
+			}
+			writeTestFile(t, filepath.Join(dir, "package.json"), `{"name":"web","version":"1.0.0","dependencies":{"@babel/highlight":"`+rng+`"}}`)
+			writeTestFile(t, filepath.Join(dir, "yarn.lock"), lock)
+			doc := nativeDocument(t, dir)
+			if len(doc.Packages) != 3 {
+				t.Fatalf("expected 3 packages, got %+v", doc.Packages)
+			}
+			assertEdges(t, doc, "pkg:npm/web@1.0.0", "pkg:npm/%40babel/highlight@7.10.4")
+			assertEdges(t, doc, "pkg:npm/%40babel/highlight@7.10.4", "pkg:npm/js-tokens@4.0.0")
+		})
+	}
+}
+
+func TestNativeSBOM_UVLock(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "pyproject.toml"), "[project]\nname = \"svc\"\n")
+	writeTestFile(t, filepath.Join(dir, "uv.lock"), `version = 1
+
+[[package]]
+name = "svc"
+version = "0.2.0"
+source = { editable = "." }
+dependencies = [{ name = "requests" }]
+
+[package.dev-dependencies]
+dev = [{ name = "pytest" }]
+
+[[package]]
+name = "requests"
+version = "2.32.0"
+source = { registry = "https://pypi.org/simple" }
+dependencies = [{ name = "charset_normalizer" }]
+
+[[package]]
+name = "charset-normalizer"
+version = "3.3.2"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "pytest"
+version = "8.0.0"
+source = { registry = "https://pypi.org/simple" }
+`)
+	doc := nativeDocument(t, dir)
+	if doc.Root != "pkg:pypi/svc@0.2.0" || len(doc.Packages) != 4 {
+		t.Fatalf("root = %q, packages = %d", doc.Root, len(doc.Packages))
+	}
+	assertEdges(t, doc, "pkg:pypi/svc@0.2.0", "pkg:pypi/requests@2.32.0", "pkg:pypi/pytest@8.0.0")
+	assertEdges(t, doc, "pkg:pypi/requests@2.32.0", "pkg:pypi/charset-normalizer@3.3.2")
+}
+
+func TestNativeSBOM_Requirements(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "requirements.txt"), "# pinned\nDjango==5.0.1\nrequests[socks]==2.31.0 ; python_version >= '3.8'\nflask>=2\n-r requirements/base.txt\n-c constraints.txt\n-e ./local-pkg\n")
+	writeTestFile(t, filepath.Join(dir, "requirements", "base.txt"), "idna==3.7\nclick \\\n  >=8\n-r ../requirements.txt\n")
+	writeTestFile(t, filepath.Join(dir, "constraints.txt"), "click==8.1.7\nnot-required==1.0\n")
+	doc := nativeDocument(t, dir)
+	if len(doc.Packages) != 6 {
+		t.Fatalf("expected root + 5 requirements, got %+v", doc.Packages)
+	}
+	// Unpinned requirements are kept without a version; constraints pin included ones
+	assertEdges(t, doc, doc.Root, "pkg:pypi/django@5.0.1", "pkg:pypi/requests@2.31.0", "pkg:pypi/flask", "pkg:pypi/idna@3.7", "pkg:pypi/click@8.1.7")
+	if p := doc.Package("pkg:pypi/flask"); p == nil || p.Version != "" {
+		t.Fatalf("expected unversioned flask, got %+v", p)
+	}
+
+	writeTestFile(t, filepath.Join(dir, "requirements.txt"), "-r missing.txt\n")
+	if _, err := NativeSBOMDocument(context.Background(), dir); err == nil {
+		t.Fatal("expected an error for a missing -r include")
+	}
+}
+
+func TestNativeSBOM_MergesEcosystems(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "VERSION"), "2.0.0\n")
+	writeTestFile(t, filepath.Join(dir, "package.json"), `{"name":"web","version":"1.0.0"}`)
+	writeTestFile(t, filepath.Join(dir, "package-lock.json"), `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "web", "version": "1.0.0", "dependencies": {"b": "^2.0.0"}},
+    "node_modules/b": {"version": "2.0.1"}
+  }
+}`)
+	writeTestFile(t, filepath.Join(dir, "requirements.txt"), "idna==3.7\n")
+
+	doc := nativeDocument(t, dir)
+	root := "pkg:generic/" + filepath.Base(dir) + "@2.0.0"
+	if doc.Root != root {
+		t.Fatalf("root = %q, want %q", doc.Root, root)
+	}
+	pythonRoot := "pkg:pypi/" + normalizePythonName(filepath.Base(dir))
+	assertEdges(t, doc, root, "pkg:npm/web@1.0.0", pythonRoot)
+	assertEdges(t, doc, "pkg:npm/web@1.0.0", "pkg:npm/b@2.0.1")
+	assertEdges(t, doc, pythonRoot, "pkg:pypi/idna@3.7")
+
+	// The ecosystem roots are projects, not dependencies
+	deps := sbomDependencies(doc)
+	if len(deps) != 2 {
+		t.Fatalf("expected b and idna as dependencies, got %+v", deps)
+	}
+}
+
+func TestGenerateNativeSBOM_NoSource(t *testing.T) {
+	dir := t.TempDir()
+	if NativeSBOMSupported(dir) {
+		t.Fatalf("empty directory should not be supported")
+	}
+	if _, err := GenerateNativeSBOM(context.Background(), dir, filepath.Join(dir, "sbom.json"), NativeSBOMOptions{}); err != ErrNoNativeSBOMSource {
+		t.Fatalf("expected ErrNoNativeSBOMSource, got %v", err)
+	}
+}
+
+func TestGenerateNativeSBOM_WritesRequestedFormat(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "requirements.txt"), "idna==3.7\n")
+	out := filepath.Join(dir, "sbom", "out.spdx.json")
+	count, err := GenerateNativeSBOM(context.Background(), dir, out, NativeSBOMOptions{
+		Format:   sbom.FormatSPDXJSON,
+		Licenses: map[string]sbom.PackageLicense{"idna@3.7": {Declared: "BSD-3-Clause", Concluded: "BSD-3-Clause"}},
+	})
+	if err != nil {
+		t.Fatalf("GenerateNativeSBOM: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("count = %d, want 2", count)
+	}
+	doc, format, err := sbom.LoadDocument(out)
+	if err != nil {
+		t.Fatalf("LoadDocument: %v", err)
+	}
+	if format != sbom.FormatSPDXJSON {
+		t.Fatalf("format = %q", format)
+	}
+	for _, p := range doc.Packages {
+		if p.Name == "idna" && p.LicenseConcluded != "BSD-3-Clause" {
+			t.Fatalf("idna concluded license = %q", p.LicenseConcluded)
+		}
+	}
+}
@@ -0,0 +1,502 @@
+package dependencies
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/sbom"
+	"gopkg.in/yaml.v3"
+)
+
+// npmLockfiles lists the JavaScript lockfiles in detection order.
+var npmLockfiles = []string{"package-lock.json", "npm-shrinkwrap.json", "pnpm-lock.yaml", "yarn.lock"}
+
+func findNPMLockfile(target string) string {
+	for _, name := range npmLockfiles {
+		if path := filepath.Join(target, name); fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+type packageJSONManifest struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+func readPackageJSON(target string) packageJSONManifest {
+	var manifest packageJSONManifest
+	data, err := os.ReadFile(filepath.Join(target, "package.json")) // #nosec G304 - package.json inside the scanned project
+	if err == nil {
+		_ = json.Unmarshal(data, &manifest)
+	}
+	if manifest.Name == "" {
+		if abs, err := filepath.Abs(target); err == nil {
+			manifest.Name = filepath.Base(abs)
+		}
+	}
+	return manifest
+}
+
+// directDependencies returns the manifest's dependency ranges by name.
+func (m packageJSONManifest) directDependencies() map[string]string {
+	out := map[string]string{}
+	for _, deps := range []map[string]string{m.DevDependencies, m.OptionalDependencies, m.Dependencies} {
+		for name, rng := range deps {
+			out[name] = rng
+		}
+	}
+	return out
+}
+
+func npmLockDocument(target, lockPath string) (*sbom.Document, error) {
+	data, err := os.ReadFile(lockPath) // #nosec G304 - lockfile found by findNPMLockfile
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", filepath.Base(lockPath), err)
+	}
+	manifest := readPackageJSON(target)
+	var b *npmDocumentBuilder
+	switch filepath.Base(lockPath) {
+	case "pnpm-lock.yaml":
+		b, err = pnpmLockDocument(data)
+	case "yarn.lock":
+		b, err = yarnLockDocument(data, manifest)
+	default:
+		b, err = packageLockDocument(data, filepath.Base(lockPath))
+	}
+	if err != nil {
+		return nil, err
+	}
+	purl := npmPURL(manifest.Name, manifest.Version)
+	root := sbom.Package{Ref: purl, Name: manifest.Name, Version: manifest.Version, PURL: purl}
+	if len(b.direct) > 0 {
+		root.Type = "application"
+		b.doc.Packages = append([]sbom.Package{root}, b.doc.Packages...)
+		for _, ref := range b.direct {
+			b.link(purl, ref)
+		}
+	}
+	attachRoot(b.doc, root)
+	return b.doc, nil
+}
+
+func npmPURL(name, version string) string {
+	name = strings.ReplaceAll(name, "@", "%40")
+	if version == "" {
+		return "pkg:npm/" + name
+	}
+	return "pkg:npm/" + name + "@" + version
+}
+
+// npmDocumentBuilder accumulates packages keyed by name@version and the
+// project's direct dependencies.
+type npmDocumentBuilder struct {
+	doc      *sbom.Document
+	seen     map[string]bool
+	edges    map[[2]string]bool
+	direct   []string
+	lockfile string
+}
+
+func newNPMDocumentBuilder(lockfile string) *npmDocumentBuilder {
+	return &npmDocumentBuilder{doc: &sbom.Document{}, seen: map[string]bool{}, edges: map[[2]string]bool{}, lockfile: lockfile}
+}
+
+func (b *npmDocumentBuilder) add(name, version string, dev bool) string {
+	ref := npmPURL(name, version)
+	if b.seen[ref] {
+		return ref
+	}
+	b.seen[ref] = true
+	pkg := sbom.Package{Ref: ref, Name: name, Version: version, Type: "library", PURL: ref, Properties: nativeSourceProperties("npm-lockfile", b.lockfile)}
+	if dev {
+		pkg.Properties = append(pkg.Properties, sbom.Property{Name: "goneat:scope", Value: "dev"})
+	}
+	b.doc.Packages = append(b.doc.Packages, pkg)
+	return ref
+}
+
+func (b *npmDocumentBuilder) addDirect(ref string) {
+	if ref != "" {
+		b.direct = append(b.direct, ref)
+	}
+}
+
+func (b *npmDocumentBuilder) link(from, to string) {
+	if from == "" || to == "" || from == to || b.edges[[2]string{from, to}] {
+		return
+	}
+	b.edges[[2]string{from, to}] = true
+	b.doc.Relationships = append(b.doc.Relationships, sbom.Relationship{From: from, To: to})
+}
+
+type packageLock struct {
+	LockfileVersion int                           `json:"lockfileVersion"`
+	Packages        map[string]packageLockEntry   `json:"packages"`
+	Dependencies    map[string]packageLockV1Entry `json:"dependencies"`
+}
+
+type packageLockEntry struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Dev                  bool              `json:"dev"`
+	Link                 bool              `json:"link"`
+	Resolved             string            `json:"resolved"`
+	HasInstallScript     bool              `json:"hasInstallScript"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+type packageLockV1Entry struct {
+	Version      string                        `json:"version"`
+	Dev          bool                          `json:"dev"`
+	Resolved     string                        `json:"resolved"`
+	Requires     map[string]string             `json:"requires"`
+	Dependencies map[string]packageLockV1Entry `json:"dependencies"`
+}
+
+// packageLockDocument reads package-lock.json / npm-shrinkwrap.json. Version 2
+// and 3 lockfiles carry a flat "packages" map keyed by node_modules path;
+// version 1 nests "dependencies".
+func packageLockDocument(data []byte, lockfile string) (*npmDocumentBuilder, error) {
+	var lock packageLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", lockfile, err)
+	}
+	b := newNPMDocumentBuilder(lockfile)
+	if len(lock.Packages) > 0 {
+		refs := map[string]string{}
+		paths := make([]string, 0, len(lock.Packages))
+		for path := range lock.Packages {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			entry := lock.Packages[path]
+			if path == "" || entry.Link {
+				continue
+			}
+			name := entry.Name
+			if name == "" {
+				name = npmNameFromPath(path)
+			}
+			refs[path] = b.add(name, entry.Version, entry.Dev)
+		}
+		for _, path := range paths {
+			from, ok := refs[path]
+			if !ok && path != "" {
+				continue
+			}
+			entry := lock.Packages[path]
+			for _, deps := range []map[string]string{entry.Dependencies, entry.DevDependencies, entry.OptionalDependencies, entry.PeerDependencies} {
+				for _, name := range sortedKeys(deps) {
+					to := refs[resolveNodeModulesPath(lock.Packages, path, name)]
+					if path == "" {
+						b.addDirect(to)
+					} else {
+						b.link(from, to)
+					}
+				}
+			}
+		}
+		return b, nil
+	}
+	var walk func(scope []map[string]packageLockV1Entry, deps map[string]packageLockV1Entry)
+	walk = func(scope []map[string]packageLockV1Entry, deps map[string]packageLockV1Entry) {
+		scope = append([]map[string]packageLockV1Entry{deps}, scope...)
+		for _, name := range sortedKeys(deps) {
+			entry := deps[name]
+			from := b.add(name, entry.Version, entry.Dev)
+			if len(entry.Dependencies) > 0 {
+				walk(scope, entry.Dependencies)
+			}
+			nested := append([]map[string]packageLockV1Entry{entry.Dependencies}, scope...)
+			for _, req := range sortedKeys(entry.Requires) {
+				for _, level := range nested {
+					if dep, ok := level[req]; ok {
+						b.link(from, npmPURL(req, dep.Version))
+						break
+					}
+				}
+			}
+		}
+	}
+	walk(nil, lock.Dependencies)
+	return b, nil
+}
+
+// npmNameFromPath extracts the package name from "node_modules/a/node_modules/@s/b".
+func npmNameFromPath(path string) string {
+	idx := strings.LastIndex(path, "node_modules/")
+	if idx < 0 {
+		return path
+	}
+	return path[idx+len("node_modules/"):]
+}
+
+// resolveNodeModulesPath applies Node's resolution: the nearest
+// node_modules/<name> walking up from the requiring package.
+func resolveNodeModulesPath(packages map[string]packageLockEntry, from, name string) string {
+	dir := from
+	for {
+		candidate := "node_modules/" + name
+		if dir != "" {
+			candidate = dir + "/node_modules/" + name
+		}
+		if _, ok := packages[candidate]; ok {
+			return candidate
+		}
+		if dir == "" {
+			return ""
+		}
+		idx := strings.LastIndex(dir, "/node_modules/")
+		if idx < 0 {
+			dir = ""
+		} else {
+			dir = dir[:idx]
+		}
+	}
+}
+
+type pnpmLock struct {
+	Importers map[string]pnpmImporter `yaml:"importers"`
+	// Single-project lockfiles before v9 list direct dependencies at the top.
+	pnpmImporter `yaml:",inline"`
+	Packages     map[string]pnpmPackage `yaml:"packages"`
+	Snapshots    map[string]pnpmPackage `yaml:"snapshots"`
+}
+
+// pnpmImporter maps direct dependency names to either a resolved version
+// (lockfile v5) or {specifier, version} (v6+).
+type pnpmImporter struct {
+	Dependencies         map[string]any `yaml:"dependencies"`
+	DevDependencies      map[string]any `yaml:"devDependencies"`
+	OptionalDependencies map[string]any `yaml:"optionalDependencies"`
+}
+
+type pnpmPackage struct {
+	Dev                  bool              `yaml:"dev"`
+	RequiresBuild        bool              `yaml:"requiresBuild"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+	Resolution           struct {
+		Tarball string `yaml:"tarball"`
+	} `yaml:"resolution"`
+}
+
+// pnpmLockDocument reads pnpm-lock.yaml (v6 "/name@version" keys and v9
+// "name@version" keys with dependency edges under "snapshots").
+func pnpmLockDocument(data []byte) (*npmDocumentBuilder, error) {
+	var lock pnpmLock
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("parse pnpm-lock.yaml: %w", err)
+	}
+	b := newNPMDocumentBuilder("pnpm-lock.yaml")
+	entries := lock.Snapshots
+	if len(entries) == 0 {
+		entries = lock.Packages
+	}
+	keys := make([]string, 0, len(entries)+len(lock.Packages))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	for key := range lock.Packages {
+		if _, ok := entries[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	refs := map[string]string{}
+	for _, key := range keys {
+		name, version := pnpmSplitKey(key)
+		if name == "" {
+			continue
+		}
+		refs[name+"@"+version] = b.add(name, version, lock.Packages[key].Dev)
+	}
+	for _, key := range keys {
+		name, version := pnpmSplitKey(key)
+		from := refs[name+"@"+version]
+		entry := entries[key]
+		for _, deps := range []map[string]string{entry.Dependencies, entry.OptionalDependencies} {
+			for _, dep := range sortedKeys(deps) {
+				b.link(from, refs[dep+"@"+pnpmVersion(deps[dep])])
+			}
+		}
+	}
+	importer := lock.pnpmImporter
+	if root, ok := lock.Importers["."]; ok {
+		importer = root
+	}
+	for _, deps := range []map[string]any{importer.Dependencies, importer.DevDependencies, importer.OptionalDependencies} {
+		for _, name := range sortedKeys(deps) {
+			version := ""
+			switch v := deps[name].(type) {
+			case string:
+				version = v
+			case map[string]any:
+				version, _ = v["version"].(string)
+			}
+			b.addDirect(refs[name+"@"+pnpmVersion(version)])
+		}
+	}
+	return b, nil
+}
+
+// pnpmSplitKey turns "/@scope/name@1.2.3(peer@1)" or "/name/1.2.3" into name
+// and version.
+func pnpmSplitKey(key string) (string, string) {
+	key = strings.TrimPrefix(key, "/")
+	if idx := strings.Index(key, "("); idx >= 0 {
+		key = key[:idx]
+	}
+	if idx := strings.LastIndex(key, "@"); idx > 0 {
+		return key[:idx], key[idx+1:]
+	}
+	if idx := strings.LastIndex(key, "/"); idx > 0 {
+		return key[:idx], key[idx+1:]
+	}
+	return "", ""
+}
+
+// pnpmVersion strips peer-dependency suffixes from a resolved version.
+func pnpmVersion(v string) string {
+	if idx := strings.Index(v, "("); idx >= 0 {
+		v = v[:idx]
+	}
+	return strings.TrimSpace(v)
+}
+
+type yarnLockEntry struct {
+	keys    []string
+	version string
+	deps    map[string]string
+}
+
+// yarnLockDocument reads yarn.lock in both the classic v1 format
+// (`version "1.0.0"`) and the Berry YAML format (`version: 1.0.0`).
+func yarnLockDocument(data []byte, manifest packageJSONManifest) (*npmDocumentBuilder, error) {
+	var entries []*yarnLockEntry
+	var current *yarnLockEntry
+	inDeps := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case indent == 0:
+			inDeps = false
+			current = nil
+			header := strings.TrimSuffix(trimmed, ":")
+			if strings.HasPrefix(header, "__metadata") {
+				continue
+			}
+			current = &yarnLockEntry{deps: map[string]string{}}
+			for _, key := range strings.Split(header, ",") {
+				current.keys = append(current.keys, strings.Trim(strings.TrimSpace(key), `"`))
+			}
+			entries = append(entries, current)
+		case current == nil:
+			continue
+		case indent == 2:
+			field, value := yarnField(trimmed)
+			inDeps = field == "dependencies" || field == "optionalDependencies"
+			if field == "version" {
+				current.version = value
+			}
+		case indent >= 4 && inDeps:
+			name, rng := yarnField(trimmed)
+			current.deps[name] = rng
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("parse yarn.lock: %w", err)
+	}
+
+	b := newNPMDocumentBuilder("yarn.lock")
+	byKey := map[string]string{}
+	for _, e := range entries {
+		// Berry lists workspace packages ("app@workspace:."); the project
+		// root is taken from package.json instead.
+		if strings.Contains(e.keys[0], "@workspace:") {
+			continue
+		}
+		name := yarnKeyName(e.keys[0])
+		ref := b.add(name, e.version, false)
+		for _, key := range e.keys {
+			byKey[key] = ref
+		}
+	}
+	for _, e := range entries {
+		from, ok := byKey[e.keys[0]]
+		if !ok {
+			continue
+		}
+		for _, name := range sortedKeys(e.deps) {
+			to, ok := byKey[name+"@"+e.deps[name]]
+			if !ok {
+				to = byKey[name+"@npm:"+e.deps[name]]
+			}
+			b.link(from, to)
+		}
+	}
+	// yarn.lock has no root entry; link the manifest's direct dependencies.
+	direct := manifest.directDependencies()
+	for _, name := range sortedKeys(direct) {
+		to, ok := byKey[name+"@"+direct[name]]
+		if !ok {
+			to = byKey[name+"@npm:"+direct[name]]
+		}
+		b.addDirect(to)
+	}
+	return b, nil
+}
+
+// yarnField splits `name "value"` (classic) or `name: value` (Berry).
+func yarnField(s string) (string, string) {
+	var name, value string
+	if strings.HasPrefix(s, `"`) {
+		if end := strings.Index(s[1:], `"`); end >= 0 {
+			name, value = s[1:end+1], s[end+2:]
+		}
+	} else if idx := strings.IndexAny(s, " :"); idx >= 0 {
+		name, value = s[:idx], s[idx:]
+	} else {
+		name = s
+	}
+	value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), ":"))
+	return strings.TrimSuffix(name, ":"), strings.Trim(value, `"`)
+}
+
+// yarnKeyName extracts the package name from "@scope/name@^1.0.0".
+func yarnKeyName(key string) string {
+	if idx := strings.LastIndex(key, "@"); idx > 0 {
+		return key[:idx]
+	}
+	return key
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,248 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/open-policy-agent/opa/v1/tester"
+)
+
+// DefaultBundleDir is where repository-authored Rego policies live.
+const DefaultBundleDir = ".goneat/policies"
+
+// BuiltinModuleName is the module name the transpiled YAML policy is
+// compiled under when it is evaluated together with a bundle.
+const BuiltinModuleName = "goneat/dependencies.rego"
+
+// BundlePackagePrefix is the package namespace bundle modules must declare,
+// e.g. `package goneat.policies.licenses`.
+const BundlePackagePrefix = "goneat.policies"
+
+// DecisionLevel names a rule set evaluated in each bundle package.
+type DecisionLevel string
+
+const (
+	DecisionDeny DecisionLevel = "deny"
+	DecisionWarn DecisionLevel = "warn"
+	DecisionInfo DecisionLevel = "info"
+)
+
+var decisionLevels = []DecisionLevel{DecisionDeny, DecisionWarn, DecisionInfo}
+
+// Severity maps a decision level to a dependency issue severity. deny keeps
+// the critical severity used by the generated YAML policy rules.
+func (l DecisionLevel) Severity() string {
+	switch l {
+	case DecisionDeny:
+		return "critical"
+	case DecisionWarn:
+		return "medium"
+	default:
+		return "info"
+	}
+}
+
+// Decision is a single message produced by a bundle rule.
+type Decision struct {
+	Level      DecisionLevel `json:"level"`
+	Package    string        `json:"package"`
+	Message    string        `json:"message"`
+	Dependency string        `json:"dependency,omitempty"`
+}
+
+// Bundle is a set of Rego modules loaded from a policy directory.
+type Bundle struct {
+	Dir      string
+	modules  map[string]string
+	packages [][]string
+	builtin  string
+}
+
+// LoadBundle parses every *.rego file in dir, skipping *_test.rego. It returns
+// (nil, nil) when the directory does not exist so callers can treat bundles
+// as optional.
+func LoadBundle(dir string) (*Bundle, error) {
+	files, err := bundleFiles(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	b := &Bundle{Dir: dir, modules: make(map[string]string, len(files))}
+	seen := make(map[string]bool)
+	for _, file := range files {
+		data, err := os.ReadFile(file) // #nosec G304 - files enumerated from the policy directory
+		if err != nil {
+			return nil, fmt.Errorf("failed to read policy module: %w", err)
+		}
+		module, err := ast.ParseModuleWithOpts(file, string(data), ast.ParserOptions{RegoVersion: ast.RegoV1})
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse policy module: %w", err)
+		}
+		path, err := bundlePackagePath(module.Package.Path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		b.modules[file] = string(data)
+		if key := strings.Join(path, "."); !seen[key] {
+			seen[key] = true
+			b.packages = append(b.packages, path)
+		}
+	}
+	return b, nil
+}
+
+// Files returns the loaded module paths in sorted order.
+func (b *Bundle) Files() []string {
+	files := make([]string, 0, len(b.modules))
+	for f := range b.modules {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// WithBuiltinPolicy compiles module (the transpiled YAML policy, see
+// BuiltinModule) alongside the bundle so rules can reference
+// data.goneat.dependencies.
+func (b *Bundle) WithBuiltinPolicy(module string) *Bundle {
+	if b != nil {
+		b.builtin = module
+	}
+	return b
+}
+
+// Evaluate runs the deny, warn and info rule sets of every bundle package
+// against input. Rule values may be plain strings or objects carrying
+// `msg` (or `message`) and an optional `dependency`.
+func (b *Bundle) Evaluate(ctx context.Context, input interface{}) ([]Decision, error) {
+	if b == nil || len(b.modules) == 0 {
+		return nil, nil
+	}
+
+	opts := []func(*rego.Rego){
+		rego.Query("data." + BundlePackagePrefix),
+		rego.Input(input),
+	}
+	if b.builtin != "" {
+		opts = append(opts, rego.Module(BuiltinModuleName, b.builtin))
+	}
+	for _, file := range b.Files() {
+		opts = append(opts, rego.Module(file, b.modules[file]))
+	}
+	rs, err := rego.New(opts...).Eval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("policy bundle evaluation failed: %w", err)
+	}
+	if len(rs) == 0 || len(rs[0].Expressions) == 0 {
+		return nil, nil
+	}
+	root, _ := rs[0].Expressions[0].Value.(map[string]interface{})
+
+	var decisions []Decision
+	for _, path := range b.packages {
+		doc := lookupPackage(root, path)
+		if doc == nil {
+			continue
+		}
+		pkg := BundlePackagePrefix + "." + strings.Join(path, ".")
+		for _, level := range decisionLevels {
+			values, _ := doc[string(level)].([]interface{})
+			for _, v := range values {
+				if d, ok := toDecision(level, pkg, v); ok {
+					decisions = append(decisions, d)
+				}
+			}
+		}
+	}
+	return decisions, nil
+}
+
+// RunTests executes the OPA test rules (test_*) in dir, including *_test.rego
+// files and any JSON/YAML data documents next to them.
+func RunTests(ctx context.Context, dir string) ([]*tester.Result, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("policy directory not accessible: %w", err)
+	}
+	results, err := tester.Run(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("policy tests failed to run: %w", err)
+	}
+	return results, nil
+}
+
+// bundleFiles lists the non-test .rego files in dir (non-recursive).
+func bundleFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".rego" {
+			continue
+		}
+		if strings.HasSuffix(name, "_test.rego") {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// bundlePackagePath returns the package path below BundlePackagePrefix.
+func bundlePackagePath(ref ast.Ref) ([]string, error) {
+	var parts []string
+	for _, term := range ref[1:] {
+		s, ok := term.Value.(ast.String)
+		if !ok {
+			return nil, fmt.Errorf("unsupported package path %s", ref)
+		}
+		parts = append(parts, string(s))
+	}
+	prefix := strings.Split(BundlePackagePrefix, ".")
+	if len(parts) <= len(prefix) || strings.Join(parts[:len(prefix)], ".") != BundlePackagePrefix {
+		return nil, fmt.Errorf("package %s must be declared under %s (e.g. package %s.licenses)", strings.Join(parts, "."), BundlePackagePrefix, BundlePackagePrefix)
+	}
+	return parts[len(prefix):], nil
+}
+
+func lookupPackage(root map[string]interface{}, path []string) map[string]interface{} {
+	doc := root
+	for _, part := range path {
+		next, ok := doc[part].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		doc = next
+	}
+	return doc
+}
+
+func toDecision(level DecisionLevel, pkg string, value interface{}) (Decision, bool) {
+	d := Decision{Level: level, Package: pkg}
+	switch v := value.(type) {
+	case string:
+		d.Message = v
+	case map[string]interface{}:
+		if msg, ok := v["msg"].(string); ok {
+			d.Message = msg
+		} else if msg, ok := v["message"].(string); ok {
+			d.Message = msg
+		}
+		d.Dependency, _ = v["dependency"].(string)
+	default:
+		d.Message = fmt.Sprintf("%v", v)
+	}
+	return d, d.Message != ""
+}
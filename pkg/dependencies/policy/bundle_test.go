@@ -0,0 +1,138 @@
+package policy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const licensesModule = `package goneat.policies.licenses
+
+deny contains {"msg": sprintf("%s uses %s", [dep.module.name, dep.license.type]), "dependency": dep.module.name} if {
+	some dep in input.dependencies
+	dep.license.type == "GPL-3.0"
+}
+
+warn contains "no vulnerability findings supplied" if count(input.vulnerabilities) == 0
+
+info contains sprintf("%d dependencies evaluated", [count(input.dependencies)])
+`
+
+const licensesTestModule = `package goneat.policies.licenses_test
+
+import data.goneat.policies.licenses
+
+test_denies_gpl if {
+	count(licenses.deny) == 1 with input as {"dependencies": [{"module": {"name": "x"}, "license": {"type": "GPL-3.0"}}], "vulnerabilities": []}
+}
+
+test_allows_mit if {
+	count(licenses.deny) == 0 with input as {"dependencies": [{"module": {"name": "x"}, "license": {"type": "MIT"}}], "vulnerabilities": []}
+}
+`
+
+func writePolicyFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestLoadBundle_MissingDirectory(t *testing.T) {
+	b, err := LoadBundle(filepath.Join(t.TempDir(), "absent"))
+	if err != nil || b != nil {
+		t.Fatalf("expected (nil, nil) for missing directory, got %v, %v", b, err)
+	}
+}
+
+func TestLoadBundle_RejectsForeignPackage(t *testing.T) {
+	dir := t.TempDir()
+	writePolicyFile(t, filepath.Join(dir, "bad.rego"), "package example\n\ndeny contains \"x\" if true\n")
+	_, err := LoadBundle(dir)
+	if err == nil || !strings.Contains(err.Error(), BundlePackagePrefix) {
+		t.Fatalf("expected package namespace error, got %v", err)
+	}
+}
+
+func TestBundleEvaluate_MapsDecisionLevels(t *testing.T) {
+	dir := t.TempDir()
+	writePolicyFile(t, filepath.Join(dir, "licenses.rego"), licensesModule)
+	writePolicyFile(t, filepath.Join(dir, "licenses_test.rego"), licensesTestModule)
+
+	b, err := LoadBundle(dir)
+	if err != nil {
+		t.Fatalf("LoadBundle: %v", err)
+	}
+	if files := b.Files(); len(files) != 1 || filepath.Base(files[0]) != "licenses.rego" {
+		t.Fatalf("test modules should be excluded, got %v", files)
+	}
+
+	input := map[string]interface{}{
+		"dependencies": []interface{}{
+			map[string]interface{}{"module": map[string]interface{}{"name": "gpl/pkg"}, "license": map[string]interface{}{"type": "GPL-3.0"}},
+			map[string]interface{}{"module": map[string]interface{}{"name": "mit/pkg"}, "license": map[string]interface{}{"type": "MIT"}},
+		},
+		"vulnerabilities": []interface{}{},
+	}
+	decisions, err := b.Evaluate(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if len(decisions) != 3 {
+		t.Fatalf("expected deny, warn and info decisions, got %+v", decisions)
+	}
+	deny := decisions[0]
+	if deny.Level != DecisionDeny || deny.Dependency != "gpl/pkg" || deny.Message != "gpl/pkg uses GPL-3.0" || deny.Package != "goneat.policies.licenses" {
+		t.Fatalf("unexpected deny decision: %+v", deny)
+	}
+	if decisions[1].Level != DecisionWarn || decisions[2].Message != "2 dependencies evaluated" {
+		t.Fatalf("unexpected warn/info decisions: %+v", decisions[1:])
+	}
+	if DecisionDeny.Severity() != "critical" || DecisionWarn.Severity() != "medium" || DecisionInfo.Severity() != "info" {
+		t.Fatal("unexpected severity mapping")
+	}
+}
+
+func TestRunTests(t *testing.T) {
+	dir := t.TempDir()
+	writePolicyFile(t, filepath.Join(dir, "licenses.rego"), licensesModule)
+	writePolicyFile(t, filepath.Join(dir, "licenses_test.rego"), licensesTestModule)
+
+	results, err := RunTests(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("RunTests: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if !r.Pass() {
+			t.Fatalf("expected %s to pass: %v", r.Name, r)
+		}
+	}
+}
+
+func TestBundleEvaluate_SeesBuiltinPolicy(t *testing.T) {
+	dir := t.TempDir()
+	writePolicyFile(t, filepath.Join(dir, "escalate.rego"), `package goneat.policies.escalate
+
+deny contains msg if {
+	some msg in data.goneat.dependencies.deny
+}
+`)
+	builtin := "package goneat.dependencies\n\ndeny contains \"built-in\" if true\n"
+
+	b, err := LoadBundle(dir)
+	if err != nil {
+		t.Fatalf("LoadBundle: %v", err)
+	}
+	decisions, err := b.WithBuiltinPolicy(builtin).Evaluate(context.Background(), map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if len(decisions) != 1 || decisions[0].Message != "built-in" || decisions[0].Package != "goneat.policies.escalate" {
+		t.Fatalf("expected the built-in deny escalated by the bundle, got %+v", decisions)
+	}
+}
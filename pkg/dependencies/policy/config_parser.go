@@ -74,6 +74,47 @@ func ParseCoolingConfig(policyData map[string]interface{}) (*config.CoolingConfi
 	return &cfg, nil
 }
 
+// ParseSupplyChainConfig extracts the supply-chain heuristics configuration.
+// The checks are on by default: a policy without a supply_chain section gets
+// typosquat and install-script detection; dependency confusion needs
+// private_namespaces to be configured.
+func ParseSupplyChainConfig(policyData map[string]interface{}) (*config.SupplyChainConfig, error) {
+	cfg := config.SupplyChainConfig{
+		Enabled:        true,
+		Typosquat:      config.TyposquatConfig{Enabled: true, MaxDistance: 2},
+		InstallScripts: config.InstallScriptConfig{Enabled: true, Severity: "low"},
+	}
+	raw, ok := policyData["supply_chain"].(map[string]interface{})
+	if !ok {
+		return &cfg, nil
+	}
+	if enabled, ok := raw["enabled"].(bool); ok && !enabled {
+		return &config.SupplyChainConfig{Enabled: false}, nil
+	}
+	cfg.PrivateNamespaces = parseStringArray(raw["private_namespaces"])
+	cfg.PublicRegistries = parseStringArray(raw["public_registries"])
+
+	if typo, ok := raw["typosquat"].(map[string]interface{}); ok {
+		if enabled, ok := typo["enabled"].(bool); ok {
+			cfg.Typosquat.Enabled = enabled
+		}
+		if maxDistance, ok := typo["max_distance"].(int); ok {
+			cfg.Typosquat.MaxDistance = maxDistance
+		}
+		cfg.Typosquat.Allow = parseStringArray(typo["allow"])
+	}
+	if scripts, ok := raw["install_scripts"].(map[string]interface{}); ok {
+		if enabled, ok := scripts["enabled"].(bool); ok {
+			cfg.InstallScripts.Enabled = enabled
+		}
+		if severity := readString(scripts["severity"]); severity != "" {
+			cfg.InstallScripts.Severity = severity
+		}
+		cfg.InstallScripts.Allow = parseStringArray(scripts["allow"])
+	}
+	return &cfg, nil
+}
+
 // parseExceptions extracts cooling exception rules from raw policy data
 func parseExceptions(exceptions []interface{}) []config.CoolingException {
 	var result []config.CoolingException
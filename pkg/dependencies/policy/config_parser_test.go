@@ -370,3 +370,35 @@ func TestParseExceptions_AllFieldsPopulated(t *testing.T) {
 		t.Errorf("Expected approved_by 'test-approver', got '%s'", exc.ApprovedBy)
 	}
 }
+
+func TestParseSupplyChainConfig(t *testing.T) {
+	cfg, err := ParseSupplyChainConfig(map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("ParseSupplyChainConfig failed: %v", err)
+	}
+	if !cfg.Enabled || !cfg.Typosquat.Enabled || cfg.Typosquat.MaxDistance != 2 || cfg.InstallScripts.Severity != "low" {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+
+	cfg, err = ParseSupplyChainConfig(map[string]interface{}{
+		"supply_chain": map[string]interface{}{
+			"private_namespaces": []interface{}{"@ourco/*", "ourco-*"},
+			"typosquat":          map[string]interface{}{"max_distance": 1, "allow": []interface{}{"lodahs"}},
+			"install_scripts":    map[string]interface{}{"severity": "high", "allow": []interface{}{"esbuild"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ParseSupplyChainConfig failed: %v", err)
+	}
+	if len(cfg.PrivateNamespaces) != 2 || cfg.Typosquat.MaxDistance != 1 || cfg.Typosquat.Allow[0] != "lodahs" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if cfg.InstallScripts.Severity != "high" || cfg.InstallScripts.Allow[0] != "esbuild" {
+		t.Errorf("unexpected install script config: %+v", cfg.InstallScripts)
+	}
+
+	cfg, _ = ParseSupplyChainConfig(map[string]interface{}{"supply_chain": map[string]interface{}{"enabled": false}})
+	if cfg.Enabled {
+		t.Error("Expected Enabled=false")
+	}
+}
@@ -29,6 +29,10 @@ func NewOPAEngine() Engine {
 	return &OPAEngine{}
 }
 
+// Evaluate returns every rule of package goneat.dependencies keyed by its
+// full reference, e.g. "data.goneat.dependencies.deny". license_deny and
+// cooling_deny hold the subsets of deny produced by the license and cooling
+// rules.
 func (e *OPAEngine) Evaluate(ctx context.Context, input interface{}) (map[string]interface{}, error) {
 	if e.regoCode == "" {
 		return nil, fmt.Errorf("no policy loaded")
@@ -36,7 +40,7 @@ func (e *OPAEngine) Evaluate(ctx context.Context, input interface{}) (map[string
 
 	// Create evaluation with input
 	rs, err := rego.New(
-		rego.Query("data.goneat.dependencies.deny"),
+		rego.Query("data.goneat.dependencies"),
 		rego.Input(input),
 		rego.Module("policy.rego", e.regoCode),
 	).Eval(ctx)
@@ -48,13 +52,29 @@ func (e *OPAEngine) Evaluate(ctx context.Context, input interface{}) (map[string
 	result := map[string]interface{}{}
 	for _, re := range rs {
 		for _, expr := range re.Expressions {
-			result[expr.Text] = expr.Value
+			rules, ok := expr.Value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for name, value := range rules {
+				result[expr.Text+"."+name] = value
+			}
 		}
 	}
 
 	return result, nil
 }
 
+// BuiltinModule returns the Rego module (package goneat.dependencies) the
+// YAML policy at path transpiles to.
+func BuiltinModule(path string) (string, error) {
+	e := &OPAEngine{}
+	if err := e.LoadPolicy(path); err != nil {
+		return "", err
+	}
+	return e.regoCode, nil
+}
+
 func (e *OPAEngine) LoadPolicy(source string) error {
 	// Path validation to prevent directory traversal
 	cleanPath := filepath.Clean(source)
@@ -116,16 +136,34 @@ func transpileYAMLToRego(yamlData []byte) string {
 
 	buf.WriteString("package goneat.dependencies\n\n")
 
-	// Transpile forbidden licenses
+	// Transpile forbidden licenses. Inputs built by goneat carry the SPDX
+	// solver result in dep.license.decision (OR/AND/WITH, -or-later, aliases);
+	// plain inputs fall back to comparing the license identifier.
 	if licenses, ok := policy["licenses"].(map[string]interface{}); ok {
-		if forbidden, ok := licenses["forbidden"].([]interface{}); ok {
-			buf.WriteString("deny contains msg if {\n")
+		_, hasAllowed := licenses["allowed"].([]interface{})
+		_, hasAllow := licenses["allow"].([]interface{})
+		forbidden, hasForbidden := licenses["forbidden"].([]interface{})
+		if hasForbidden || hasAllowed || hasAllow {
+			buf.WriteString("license_deny contains msg if {\n")
 			buf.WriteString("  dep := input.dependencies[_]\n")
+			buf.WriteString("  dep.license.decision.acceptable == false\n")
+			buf.WriteString("  msg := sprintf(\"Package %s license %s has no acceptable choice: %s\", [dep.module.name, dep.license.type, dep.license.decision.reason])\n")
+			buf.WriteString("}\n\n")
+		}
+		if hasForbidden {
+			buf.WriteString("license_deny contains msg if {\n")
+			buf.WriteString("  dep := input.dependencies[_]\n")
+			buf.WriteString("  not dep.license.decision\n")
 			buf.WriteString("  forbidden := ")
 			buf.WriteString(formatRegoArray(forbidden))
 			buf.WriteString("\n")
 			buf.WriteString("  forbidden[_] == dep.license.type\n")
-			buf.WriteString("  msg := sprintf(\"Package %%s uses forbidden license: %%s\", [dep.module.name, dep.license.type])\n")
+			buf.WriteString("  msg := sprintf(\"Package %s uses forbidden license: %s\", [dep.module.name, dep.license.type])\n")
+			buf.WriteString("}\n\n")
+		}
+		if hasForbidden || hasAllowed || hasAllow {
+			buf.WriteString("deny contains msg if {\n")
+			buf.WriteString("  license_deny[msg]\n")
 			buf.WriteString("}\n\n")
 		}
 	}
@@ -134,7 +172,7 @@ func transpileYAMLToRego(yamlData []byte) string {
 	if cooling, ok := policy["cooling"].(map[string]interface{}); ok {
 		if enabled, ok := cooling["enabled"].(bool); ok && enabled {
 			buf.WriteString("# Cooling policy rules\n")
-			buf.WriteString("deny contains msg if {\n")
+			buf.WriteString("cooling_deny contains msg if {\n")
 			buf.WriteString("  dep := input.dependencies[_]\n")
 
 			if minAge, ok := cooling["min_age_days"].(int); ok {
@@ -142,9 +180,12 @@ func transpileYAMLToRego(yamlData []byte) string {
 			}
 
 			buf.WriteString("  not is_cooling_exception(dep.module.name)\n")
-			buf.WriteString("  msg := sprintf(\"Package %%s (%%s) violates cooling policy: %%d days old\", ")
+			buf.WriteString("  msg := sprintf(\"Package %s (%s) violates cooling policy: %d days old\", ")
 			buf.WriteString("[dep.module.name, dep.module.version, dep.metadata.age_days])\n")
 			buf.WriteString("}\n\n")
+			buf.WriteString("deny contains msg if {\n")
+			buf.WriteString("  cooling_deny[msg]\n")
+			buf.WriteString("}\n\n")
 
 			// Helper function for exceptions with glob pattern matching
 			if exceptions, ok := cooling["exceptions"].([]interface{}); ok && len(exceptions) > 0 {
@@ -238,3 +238,40 @@ func containsAll(s string, substrings ...string) bool {
 	}
 	return true
 }
+
+func TestOPALicenseDecisionDeny(t *testing.T) {
+	tmpDir := t.TempDir()
+	policyPath := filepath.Join(tmpDir, "policy.yaml")
+	if err := os.WriteFile(policyPath, []byte("version: v1\nlicenses:\n  forbidden:\n    - GPL-3.0\n"), 0644); err != nil {
+		t.Fatalf("Failed to create policy file: %v", err)
+	}
+	engine := NewOPAEngine()
+	if err := engine.LoadPolicy(policyPath); err != nil {
+		t.Fatalf("Failed to load policy: %v", err)
+	}
+
+	// Solver decisions take precedence over identifier equality
+	dep := func(name, licenseType string, acceptable bool) map[string]interface{} {
+		return map[string]interface{}{
+			"module": map[string]interface{}{"name": name},
+			"license": map[string]interface{}{
+				"type":     licenseType,
+				"decision": map[string]interface{}{"acceptable": acceptable, "choice": "", "reason": "GPL-3.0-only is forbidden"},
+			},
+		}
+	}
+	input := map[string]interface{}{
+		"dependencies": []interface{}{
+			dep("dual/licensed", "GPL-3.0", true),
+			dep("later/licensed", "GPL-3.0-or-later", false),
+		},
+	}
+	result, err := engine.Evaluate(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Policy evaluation failed: %v", err)
+	}
+	denials, _ := result["data.goneat.dependencies.deny"].([]interface{})
+	if len(denials) != 1 || denials[0] != "Package later/licensed license GPL-3.0-or-later has no acceptable choice: GPL-3.0-only is forbidden" {
+		t.Fatalf("expected a single solver-based denial, got %v", denials)
+	}
+}
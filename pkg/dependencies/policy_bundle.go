@@ -0,0 +1,247 @@
+package dependencies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fulmenhq/goneat/internal/gitctx"
+	"github.com/fulmenhq/goneat/pkg/dependencies/policy"
+	"github.com/fulmenhq/goneat/pkg/dependencies/spdx"
+	"github.com/fulmenhq/goneat/pkg/logger"
+	"github.com/fulmenhq/goneat/pkg/sbom"
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyInputSchemaVersion is the version of the Rego input document
+// described by schemas/dependencies/v1.0.0/policy-input.schema.json.
+const PolicyInputSchemaVersion = "v1"
+
+// PolicyInputOptions carries the context assembled into the Rego input document.
+type PolicyInputOptions struct {
+	Target        string
+	PolicyPath    string
+	Languages     []Language
+	Dependencies  []Dependency
+	Vulnerability *VulnerabilityScanResult
+	// SkipGraph leaves the graph empty instead of generating one natively
+	// when no vulnerability SBOM is available.
+	SkipGraph bool
+}
+
+// BuildPolicyInput assembles the documented input document for Rego policy
+// bundles: dependencies, graph edges, SBOM metadata, vulnerability findings,
+// repository metadata and the parsed YAML policy. The graph comes from the
+// vulnerability scan SBOM when there is one and from the native generators
+// otherwise. The result contains only JSON-compatible values.
+func BuildPolicyInput(ctx context.Context, opts PolicyInputOptions) (map[string]interface{}, error) {
+	absTarget, err := filepath.Abs(opts.Target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve target: %w", err)
+	}
+
+	policyDoc := loadPolicyDocument(opts.PolicyPath)
+	var licensePolicy spdx.Policy
+	if licenseCfg, err := policy.ParseLicenseConfig(policyDoc); err == nil && licenseCfg != nil {
+		licensePolicy = spdx.NewPolicy(append(append([]string{}, licenseCfg.Allowed...), licenseCfg.Allow...), licenseCfg.Forbidden)
+	}
+
+	deps := make([]map[string]interface{}, 0, len(opts.Dependencies))
+	for _, dep := range opts.Dependencies {
+		entry := map[string]interface{}{
+			"module": map[string]interface{}{
+				"name":     dep.Name,
+				"version":  dep.Version,
+				"language": string(dep.Language),
+			},
+			"metadata": dep.Metadata,
+		}
+		if dep.License != nil {
+			license := map[string]interface{}{
+				"name": dep.License.Name,
+				"type": dep.License.Type,
+				"url":  dep.License.URL,
+			}
+			if strings.TrimSpace(dep.License.Type) != "" {
+				node := parseLicenseNode(dep.License.Type)
+				license["expression"] = node.String()
+				license["ids"] = spdx.IDs(node)
+				if !licensePolicy.Empty() {
+					decision := licensePolicy.Evaluate(node)
+					license["decision"] = map[string]interface{}{
+						"acceptable": decision.Acceptable,
+						"choice":     decision.ChoiceString(),
+						"reason":     decision.Reason(),
+					}
+				}
+			}
+			entry["license"] = license
+		}
+		if dep.Metadata == nil {
+			entry["metadata"] = map[string]interface{}{}
+		}
+		deps = append(deps, entry)
+	}
+
+	languages := make([]string, 0, len(opts.Languages))
+	for _, lang := range opts.Languages {
+		languages = append(languages, string(lang))
+	}
+
+	repo := map[string]interface{}{
+		"root":      absTarget,
+		"languages": languages,
+	}
+	if changeCtx, _, err := gitctx.Collect(absTarget); err == nil && changeCtx != nil {
+		repo["git"] = map[string]interface{}{
+			"sha":    changeCtx.GitSHA,
+			"branch": changeCtx.Branch,
+		}
+	}
+
+	input := map[string]interface{}{
+		"schema_version":  PolicyInputSchemaVersion,
+		"dependencies":    deps,
+		"graph":           map[string]interface{}{"roots": []string{}, "edges": []map[string]string{}},
+		"vulnerabilities": []interface{}{},
+		"repo":            repo,
+		"policy":          policyDoc,
+	}
+
+	graphLoaded := false
+	if vr := opts.Vulnerability; vr != nil {
+		sbomFormat := vr.SBOMFormat
+		if sbomFormat == "" {
+			sbomFormat = sbom.FormatCycloneDXJSON
+		}
+		input["sbom"] = map[string]interface{}{
+			"format":        sbomFormat,
+			"path":          vr.SBOMPath,
+			"source_type":   vr.SourceType,
+			"package_count": vr.PackagesScanned,
+		}
+		if vr.Findings != nil {
+			input["vulnerabilities"] = vr.Findings
+		}
+		if vr.SBOMPath != "" {
+			graph, err := sbom.LoadDependencyGraphFromFile(vr.SBOMPath)
+			if err != nil {
+				logger.Debug(fmt.Sprintf("dependencies: policy input graph unavailable from SBOM: %v", err))
+			} else if graph != nil {
+				input["graph"] = policyGraph(graph)
+				graphLoaded = true
+			}
+		}
+	}
+	if !graphLoaded && !opts.SkipGraph {
+		if doc, err := NativeSBOMDocument(ctx, absTarget); err != nil {
+			logger.Debug(fmt.Sprintf("dependencies: policy input graph unavailable: %v", err))
+		} else if graph := doc.Graph(); graph != nil {
+			input["graph"] = policyGraph(graph)
+		}
+	}
+
+	// Normalize through JSON so rules see the same shapes as the schema.
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode policy input: %w", err)
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to decode policy input: %w", err)
+	}
+	return normalized, nil
+}
+
+// EvaluatePolicyBundle evaluates the Rego bundle in dir (relative paths are
+// resolved against target) and maps deny/warn/info decisions to issues. The
+// YAML policy is compiled into the same evaluation, so bundle rules can use
+// data.goneat.dependencies. A missing directory yields no issues.
+func EvaluatePolicyBundle(ctx context.Context, target, dir string, opts PolicyInputOptions) ([]Issue, error) {
+	if dir == "" {
+		dir = policy.DefaultBundleDir
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(target, dir)
+	}
+	bundle, err := policy.LoadBundle(dir)
+	if err != nil || bundle == nil {
+		return nil, err
+	}
+
+	if opts.Target == "" {
+		opts.Target = target
+	}
+	if opts.PolicyPath != "" {
+		if module, err := policy.BuiltinModule(opts.PolicyPath); err != nil {
+			// YAML policy errors surface from the analyzers
+			logger.Debug(fmt.Sprintf("dependencies: built-in policy not available to bundle: %v", err))
+		} else {
+			bundle.WithBuiltinPolicy(module)
+		}
+	}
+	input, err := BuildPolicyInput(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	decisions, err := bundle.Evaluate(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*Dependency, len(opts.Dependencies))
+	for i := range opts.Dependencies {
+		byName[opts.Dependencies[i].Name] = &opts.Dependencies[i]
+	}
+
+	issues := make([]Issue, 0, len(decisions))
+	for _, d := range decisions {
+		issues = append(issues, Issue{
+			Type:       "policy",
+			Severity:   d.Level.Severity(),
+			Message:    d.Message,
+			Dependency: byName[d.Dependency],
+			SourceType: "rego",
+			SourcePath: d.Package,
+		})
+	}
+	return issues, nil
+}
+
+func policyGraph(graph *sbom.DependencyGraph) map[string]interface{} {
+	roots := append([]string{}, graph.Roots...)
+	sort.Strings(roots)
+	edges := []map[string]string{}
+	refs := make([]string, 0, len(graph.Nodes))
+	for ref := range graph.Nodes {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		for _, child := range graph.Nodes[ref].Dependencies {
+			edges = append(edges, map[string]string{"from": ref, "to": child})
+		}
+	}
+	return map[string]interface{}{"roots": roots, "edges": edges}
+}
+
+// loadPolicyDocument returns the parsed YAML policy, or an empty object when
+// the file is absent or unreadable (YAML policy errors surface elsewhere).
+func loadPolicyDocument(path string) map[string]interface{} {
+	doc := map[string]interface{}{}
+	if path == "" {
+		return doc
+	}
+	data, err := os.ReadFile(path) // #nosec G304 - user-specified policy file
+	if err != nil {
+		return doc
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil || doc == nil {
+		return map[string]interface{}{}
+	}
+	return doc
+}
@@ -0,0 +1,165 @@
+package dependencies
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fulmenhq/goneat/pkg/schema"
+	"github.com/fulmenhq/goneat/pkg/vulnerabilities"
+)
+
+const sampleCycloneDX = `{
+  "bomFormat": "CycloneDX",
+  "components": [
+    {"bom-ref": "app", "name": "app", "version": "1.0.0"},
+    {"bom-ref": "lib", "name": "lib", "version": "2.0.0"}
+  ],
+  "dependencies": [{"ref": "app", "dependsOn": ["lib"]}]
+}`
+
+func TestBuildPolicyInput_MatchesSchema(t *testing.T) {
+	t.Setenv("GONEAT_OFFLINE_SCHEMA_VALIDATION", "true")
+	dir := t.TempDir()
+	sbomPath := filepath.Join(dir, "sbom.cdx.json")
+	policyPath := filepath.Join(dir, "dependencies.yaml")
+	if err := os.WriteFile(sbomPath, []byte(sampleCycloneDX), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(policyPath, []byte("version: v1\nlicenses:\n  forbidden: [GPL-3.0]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	input, err := BuildPolicyInput(context.Background(), PolicyInputOptions{
+		Target:     dir,
+		PolicyPath: policyPath,
+		Languages:  []Language{LanguageGo},
+		Dependencies: []Dependency{{
+			Module:   Module{Name: "lib", Version: "2.0.0", Language: LanguageGo},
+			License:  &License{Name: "LICENSE", Type: "MIT"},
+			Metadata: map[string]interface{}{"age_days": 42},
+		}},
+		Vulnerability: &VulnerabilityScanResult{
+			SBOMPath:        sbomPath,
+			SourceType:      "sbom-file",
+			PackagesScanned: 2,
+			Findings: []vulnerabilities.Finding{{
+				ID:           "CVE-2024-0001",
+				Severity:     vulnerabilities.SeverityHigh,
+				PackageNames: []string{"lib"},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BuildPolicyInput: %v", err)
+	}
+
+	validator, err := schema.NewValidatorFromEmbeddedPath("embedded_schemas/schemas/dependencies/v1.0.0/policy-input.schema.json")
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+	result, err := validator.Validate(input)
+	if err != nil || !result.Valid {
+		t.Fatalf("input does not match schema: %v %+v", err, result)
+	}
+
+	edges := input["graph"].(map[string]interface{})["edges"].([]interface{})
+	if len(edges) != 1 || edges[0].(map[string]interface{})["to"] != "lib" {
+		t.Fatalf("unexpected graph edges: %v", edges)
+	}
+	dep := input["dependencies"].([]interface{})[0].(map[string]interface{})
+	if dep["license"].(map[string]interface{})["type"] != "MIT" || dep["metadata"].(map[string]interface{})["age_days"] != float64(42) {
+		t.Fatalf("unexpected dependency entry: %v", dep)
+	}
+	decision := dep["license"].(map[string]interface{})["decision"].(map[string]interface{})
+	if decision["acceptable"] != true || decision["choice"] != "MIT" {
+		t.Fatalf("unexpected license decision: %v", decision)
+	}
+	forbidden := input["policy"].(map[string]interface{})["licenses"].(map[string]interface{})["forbidden"].([]interface{})
+	if len(forbidden) != 1 || forbidden[0] != "GPL-3.0" {
+		t.Fatalf("unexpected policy document: %v", input["policy"])
+	}
+}
+
+func TestEvaluatePolicyBundle_MapsIssues(t *testing.T) {
+	dir := t.TempDir()
+	policyDir := filepath.Join(dir, ".goneat", "policies")
+	if err := os.MkdirAll(policyDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	module := `package goneat.policies.age
+
+deny contains {"msg": sprintf("%s is too new", [dep.module.name]), "dependency": dep.module.name} if {
+	some dep in input.dependencies
+	dep.metadata.age_days < 7
+}
+
+warn contains "no SBOM graph" if count(input.graph.edges) == 0
+`
+	if err := os.WriteFile(filepath.Join(policyDir, "age.rego"), []byte(module), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	deps := []Dependency{
+		{Module: Module{Name: "fresh", Version: "0.1.0"}, Metadata: map[string]interface{}{"age_days": 1}},
+		{Module: Module{Name: "mature", Version: "1.0.0"}, Metadata: map[string]interface{}{"age_days": 400}},
+	}
+	issues, err := EvaluatePolicyBundle(context.Background(), dir, "", PolicyInputOptions{Dependencies: deps})
+	if err != nil {
+		t.Fatalf("EvaluatePolicyBundle: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %+v", issues)
+	}
+	if issues[0].Severity != "critical" || issues[0].Dependency == nil || issues[0].Dependency.Name != "fresh" || issues[0].SourcePath != "goneat.policies.age" {
+		t.Fatalf("unexpected deny issue: %+v", issues[0])
+	}
+	if issues[1].Severity != "medium" || issues[1].Type != "policy" {
+		t.Fatalf("unexpected warn issue: %+v", issues[1])
+	}
+
+	// No bundle directory: nothing to evaluate
+	if issues, err := EvaluatePolicyBundle(context.Background(), t.TempDir(), "", PolicyInputOptions{}); err != nil || len(issues) != 0 {
+		t.Fatalf("expected no issues without bundle, got %v %v", issues, err)
+	}
+}
+
+func TestEvaluatePolicyBundle_SharesBuiltinPolicyAndGraph(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "package.json"), `{"name":"web","version":"1.0.0"}`)
+	writeTestFile(t, filepath.Join(dir, "package-lock.json"), `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "web", "version": "1.0.0", "dependencies": {"gpl-lib": "^1.0.0"}},
+    "node_modules/gpl-lib": {"version": "1.0.0"}
+  }
+}`)
+	policyPath := filepath.Join(dir, ".goneat", "dependencies.yaml")
+	writeTestFile(t, policyPath, "version: v1\nlicenses:\n  forbidden: [GPL-3.0]\n")
+	writeTestFile(t, filepath.Join(dir, ".goneat", "policies", "combined.rego"), `package goneat.policies.combined
+
+warn contains sprintf("built-in: %s", [msg]) if {
+	some msg in data.goneat.dependencies.deny
+}
+
+info contains sprintf("%d edges", [count(input.graph.edges)])
+`)
+
+	deps := []Dependency{{Module: Module{Name: "gpl-lib", Version: "1.0.0"}, License: &License{Type: "GPL-3.0-or-later"}}}
+	issues, err := EvaluatePolicyBundle(context.Background(), dir, "", PolicyInputOptions{PolicyPath: policyPath, Dependencies: deps})
+	if err != nil {
+		t.Fatalf("EvaluatePolicyBundle: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected built-in warn and graph info, got %+v", issues)
+	}
+	if !strings.HasPrefix(issues[0].Message, "built-in: ") || !strings.Contains(issues[0].Message, "GPL-3.0-or-later is forbidden") {
+		t.Fatalf("bundle did not see the built-in policy: %+v", issues[0])
+	}
+	// The graph comes from the lockfile without a vulnerability scan
+	if issues[1].Message != "1 edges" {
+		t.Fatalf("expected the native dependency graph, got %+v", issues[1])
+	}
+}
@@ -0,0 +1,396 @@
+package dependencies
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/logger"
+	"github.com/fulmenhq/goneat/pkg/sbom"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// pythonLockfiles lists the Python lockfiles in detection order.
+// requirements.txt comes last because it may not pin every version.
+var pythonLockfiles = []string{"uv.lock", "poetry.lock", "Pipfile.lock", "requirements.txt"}
+
+func findPythonLockfile(target string) string {
+	for _, name := range pythonLockfiles {
+		if path := filepath.Join(target, name); fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+type pythonLockPackage struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	// uv.lock
+	Source struct {
+		Registry string `toml:"registry"`
+		Editable string `toml:"editable"`
+		Virtual  string `toml:"virtual"`
+		Git      string `toml:"git"`
+		Path     string `toml:"path"`
+		// poetry.lock
+		Type string `toml:"type"`
+		URL  string `toml:"url"`
+	} `toml:"source"`
+	UVOptionalDependencies map[string][]uvDependency `toml:"optional-dependencies"`
+	UVDevDependencies      map[string][]uvDependency `toml:"dev-dependencies"`
+	// Dependencies is a list of {name = ...} tables in uv.lock and a
+	// name -> constraint table in poetry.lock.
+	Dependencies any `toml:"dependencies"`
+}
+
+type uvDependency struct {
+	Name string `toml:"name"`
+}
+
+type pythonLockFile struct {
+	Package []pythonLockPackage `toml:"package"`
+}
+
+type pyprojectManifest struct {
+	Project struct {
+		Name    string `toml:"name"`
+		Version string `toml:"version"`
+	} `toml:"project"`
+	Tool struct {
+		Poetry struct {
+			Name    string `toml:"name"`
+			Version string `toml:"version"`
+		} `toml:"poetry"`
+	} `toml:"tool"`
+}
+
+func pythonLockDocument(target, lockPath string) (*sbom.Document, error) {
+	data, err := os.ReadFile(lockPath) // #nosec G304 - lockfile found by findPythonLockfile
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", filepath.Base(lockPath), err)
+	}
+	lockfile := filepath.Base(lockPath)
+	var doc *sbom.Document
+	switch lockfile {
+	case "uv.lock", "poetry.lock":
+		doc, err = pythonTOMLLockDocument(data, lockfile)
+	case "Pipfile.lock":
+		doc, err = pipfileLockDocument(data)
+	default:
+		doc, err = requirementsDocument(lockPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	name, version := pythonProjectIdentity(target)
+	purl := pypiPURL(name, version)
+	// uv.lock lists the project itself; prefer its locked entry.
+	for _, p := range doc.Packages {
+		if p.Type == "application" && normalizePythonName(p.Name) == normalizePythonName(name) {
+			purl, version = p.Ref, p.Version
+			break
+		}
+	}
+	attachRoot(doc, sbom.Package{Ref: purl, Name: name, Version: version, PURL: purl})
+	return doc, nil
+}
+
+func pythonProjectIdentity(target string) (string, string) {
+	var manifest pyprojectManifest
+	if data, err := os.ReadFile(filepath.Join(target, "pyproject.toml")); err == nil { // #nosec G304 - pyproject.toml inside the scanned project
+		_ = toml.Unmarshal(data, &manifest)
+	}
+	if manifest.Project.Name != "" {
+		return manifest.Project.Name, manifest.Project.Version
+	}
+	if manifest.Tool.Poetry.Name != "" {
+		return manifest.Tool.Poetry.Name, manifest.Tool.Poetry.Version
+	}
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return target, ""
+	}
+	return filepath.Base(abs), ""
+}
+
+// pythonTOMLLockDocument reads uv.lock and poetry.lock, which share the
+// [[package]] layout but differ in how dependencies are listed.
+func pythonTOMLLockDocument(data []byte, lockfile string) (*sbom.Document, error) {
+	var lock pythonLockFile
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", lockfile, err)
+	}
+	doc := &sbom.Document{}
+	byName := map[string]string{}
+	for _, p := range lock.Package {
+		purl := pypiPURL(p.Name, p.Version)
+		pkg := sbom.Package{Ref: purl, Name: p.Name, Version: p.Version, Type: "library", PURL: purl, Properties: nativeSourceProperties("python-lockfile", lockfile)}
+		if p.Source.Virtual != "" || p.Source.Editable != "" {
+			pkg.Type = "application"
+		}
+		doc.Packages = append(doc.Packages, pkg)
+		byName[normalizePythonName(p.Name)] = purl
+	}
+	for _, p := range lock.Package {
+		from := pypiPURL(p.Name, p.Version)
+		var names []string
+		switch deps := p.Dependencies.(type) {
+		case []any:
+			for _, d := range deps {
+				if table, ok := d.(map[string]any); ok {
+					if name, ok := table["name"].(string); ok {
+						names = append(names, name)
+					}
+				}
+			}
+		case map[string]any:
+			names = append(names, sortedKeys(deps)...)
+		}
+		for _, group := range []map[string][]uvDependency{p.UVOptionalDependencies, p.UVDevDependencies} {
+			for _, key := range sortedKeys(group) {
+				for _, d := range group[key] {
+					names = append(names, d.Name)
+				}
+			}
+		}
+		for _, name := range names {
+			if to, ok := byName[normalizePythonName(name)]; ok && to != from {
+				doc.Relationships = append(doc.Relationships, sbom.Relationship{From: from, To: to})
+			}
+		}
+	}
+	return doc, nil
+}
+
+type pipfileLock struct {
+	Meta struct {
+		Sources []struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"sources"`
+	} `json:"_meta"`
+	Default map[string]pipfileLockEntry `json:"default"`
+	Develop map[string]pipfileLockEntry `json:"develop"`
+}
+
+type pipfileLockEntry struct {
+	Version string `json:"version"`
+	Index   string `json:"index"`
+}
+
+// pipfileLockDocument reads Pipfile.lock. It records no dependency edges, so
+// every package is attached directly to the project.
+func pipfileLockDocument(data []byte) (*sbom.Document, error) {
+	var lock pipfileLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("parse Pipfile.lock: %w", err)
+	}
+	doc := &sbom.Document{}
+	sections := []struct {
+		scope   string
+		entries map[string]pipfileLockEntry
+	}{{"", lock.Default}, {"dev", lock.Develop}}
+	for _, section := range sections {
+		scope := section.scope
+		for _, name := range sortedKeys(section.entries) {
+			version := strings.TrimPrefix(section.entries[name].Version, "==")
+			purl := pypiPURL(name, version)
+			if doc.Package(purl) != nil {
+				continue
+			}
+			pkg := sbom.Package{Ref: purl, Name: name, Version: version, Type: "library", PURL: purl, Properties: nativeSourceProperties("python-lockfile", "Pipfile.lock")}
+			if scope != "" {
+				pkg.Properties = append(pkg.Properties, sbom.Property{Name: "goneat:scope", Value: scope})
+			}
+			doc.Packages = append(doc.Packages, pkg)
+		}
+	}
+	return doc, nil
+}
+
+var (
+	requirementPinPattern  = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(?:\[[^\]]*\])?\s*===?\s*([^\s;#]+)`)
+	requirementNamePattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(?:\[[^\]]*\])?\s*(?:[<>=!~;@]|$)`)
+)
+
+// requirementsFile accumulates the requirements of a requirements file and
+// the files it includes with -r, plus version pins from -c constraint files.
+type requirementsFile struct {
+	names    []string
+	versions map[string]string
+	pins     map[string]string
+	display  map[string]string
+	visited  map[string]bool
+	skipped  []string
+}
+
+// requirementsDocument reads a requirements file, following -r includes and
+// applying -c constraints. Exact pins (name==version) give versioned
+// packages; other requirements (flask>=2) are kept without a version and
+// reported in a warning, and editable installs, URLs and local paths are
+// skipped with a warning.
+func requirementsDocument(path string) (*sbom.Document, error) {
+	lockfile := filepath.Base(path)
+	reqs := &requirementsFile{
+		versions: map[string]string{},
+		pins:     map[string]string{},
+		display:  map[string]string{},
+		visited:  map[string]bool{},
+	}
+	if err := reqs.read(path, false); err != nil {
+		return nil, err
+	}
+	if len(reqs.names) == 0 {
+		return nil, fmt.Errorf("%s has no requirements", lockfile)
+	}
+
+	doc := &sbom.Document{}
+	var unpinned []string
+	for _, key := range reqs.names {
+		version := reqs.versions[key]
+		if version == "" {
+			version = reqs.pins[key]
+		}
+		if version == "" {
+			unpinned = append(unpinned, reqs.display[key])
+		}
+		purl := pypiPURL(reqs.display[key], version)
+		doc.Packages = append(doc.Packages, sbom.Package{Ref: purl, Name: reqs.display[key], Version: version, Type: "library", PURL: purl, Properties: nativeSourceProperties("python-lockfile", lockfile)})
+	}
+	if len(unpinned) > 0 {
+		logger.Warn(fmt.Sprintf("dependencies: %s does not pin %d requirement(s); they are listed without a version: %s", lockfile, len(unpinned), strings.Join(unpinned, ", ")))
+	}
+	if len(reqs.skipped) > 0 {
+		logger.Warn(fmt.Sprintf("dependencies: %s entries without a package name were skipped: %s", lockfile, strings.Join(reqs.skipped, ", ")))
+	}
+	return doc, nil
+}
+
+func (r *requirementsFile) read(path string, constraints bool) error {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if r.visited[path] {
+		return nil
+	}
+	r.visited[path] = true
+	data, err := os.ReadFile(path) // #nosec G304 - requirements file in the scanned project or included by one
+	if err != nil {
+		return fmt.Errorf("read %s: %w", filepath.Base(path), err)
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	pending := ""
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Backslash continues a requirement on the next line
+		if strings.HasSuffix(line, "\\") {
+			pending += strings.TrimSuffix(line, "\\") + " "
+			continue
+		}
+		lines = append(lines, pending+line)
+		pending = ""
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	if pending != "" {
+		lines = append(lines, pending)
+	}
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if include, nested, ok := requirementsInclude(line); ok {
+			if !filepath.IsAbs(include) {
+				include = filepath.Join(filepath.Dir(path), include)
+			}
+			if err := r.read(include, constraints || nested); err != nil {
+				return err
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "-") {
+			// --index-url, --hash and other options; -e installs are not packages
+			if strings.HasPrefix(line, "-e") || strings.HasPrefix(line, "--editable") {
+				r.skipped = append(r.skipped, line)
+			}
+			continue
+		}
+		if m := requirementPinPattern.FindStringSubmatch(line); m != nil {
+			r.add(m[1], m[2], constraints)
+			continue
+		}
+		if m := requirementNamePattern.FindStringSubmatch(line); m != nil && !strings.Contains(line, "://") {
+			r.add(m[1], "", constraints)
+			continue
+		}
+		r.skipped = append(r.skipped, line)
+	}
+	return nil
+}
+
+func (r *requirementsFile) add(name, version string, constraint bool) {
+	key := normalizePythonName(name)
+	if constraint {
+		if version != "" {
+			r.pins[key] = version
+		}
+		return
+	}
+	if _, ok := r.display[key]; !ok {
+		r.names = append(r.names, key)
+		r.display[key] = name
+	}
+	if version != "" {
+		r.versions[key] = version
+	}
+}
+
+// requirementsInclude parses `-r file`/`--requirement file` (ok, !constraint)
+// and `-c file`/`--constraint file` (ok, constraint).
+func requirementsInclude(line string) (string, bool, bool) {
+	for _, opt := range []struct {
+		prefix     string
+		constraint bool
+	}{{"--requirement", false}, {"--constraint", true}, {"-r", false}, {"-c", true}} {
+		rest, ok := strings.CutPrefix(line, opt.prefix)
+		if !ok {
+			continue
+		}
+		rest = strings.TrimPrefix(strings.TrimSpace(rest), "=")
+		rest = strings.TrimSpace(rest)
+		if rest == "" {
+			return "", false, false
+		}
+		return rest, opt.constraint, true
+	}
+	return "", false, false
+}
+
+// normalizePythonName applies PEP 503 normalization.
+func normalizePythonName(name string) string {
+	name = strings.ToLower(name)
+	return strings.NewReplacer("_", "-", ".", "-").Replace(name)
+}
+
+func pypiPURL(name, version string) string {
+	purl := "pkg:pypi/" + normalizePythonName(name)
+	if version == "" {
+		return purl
+	}
+	return purl + "@" + version
+}
@@ -94,34 +94,43 @@ func (a *RustAnalyzer) Analyze(ctx context.Context, target string, cfg AnalysisC
 		return nil, fmt.Errorf("cargo-deny analysis failed: %w", err)
 	}
 
-	if result == nil {
-		return &AnalysisResult{
-			Dependencies: dependencies,
-			Issues:       []Issue{},
-			Passed:       true,
-			Duration:     time.Since(start),
-		}, nil
-	}
+	return rustAnalysisResult(dependencies, result, cfg, start), nil
+}
 
-	// Convert findings to issues
-	issues := make([]Issue, 0, len(result.Findings))
-	for _, finding := range result.Findings {
-		severity := mapFindingSeverityString(finding)
-		issueType := "rust:cargo-deny"
-		if finding.IsLicenseFinding() {
-			issueType = "rust:cargo-deny:license"
-		} else if finding.IsBanFinding() {
-			issueType = "rust:cargo-deny:bans"
-		}
+// rustAnalysisResult combines the goneat license policy with the cargo-deny
+// findings. The license policy applies even when cargo-deny returns no result.
+func rustAnalysisResult(dependencies []Dependency, result *CargoDenyResult, cfg AnalysisConfig, start time.Time) *AnalysisResult {
+	issues := []Issue{}
+	passed := true
 
-		issues = append(issues, Issue{
-			Type:     issueType,
-			Severity: severity,
-			Message:  finding.FormatMessage(),
-		})
+	// Apply the goneat license policy to the crate expressions as well, so
+	// allowed/forbidden lists behave the same as for other languages.
+	if cfg.CheckLicenses {
+		licenseIssues, licensePassed := evaluateLicensePolicy(dependencies, loadLicensePolicyConfig(cfg.PolicyPath), time.Now())
+		issues = append(issues, licenseIssues...)
+		passed = licensePassed
+	}
+
+	duration := time.Since(start)
+	if result != nil {
+		duration = result.Duration
+		for _, finding := range result.Findings {
+			severity := mapFindingSeverityString(finding)
+			issueType := "rust:cargo-deny"
+			if finding.IsLicenseFinding() {
+				issueType = "rust:cargo-deny:license"
+			} else if finding.IsBanFinding() {
+				issueType = "rust:cargo-deny:bans"
+			}
+
+			issues = append(issues, Issue{
+				Type:     issueType,
+				Severity: severity,
+				Message:  finding.FormatMessage(),
+			})
+		}
 	}
 
-	passed := true
 	for _, issue := range issues {
 		if issue.Severity == "high" || issue.Severity == "critical" {
 			passed = false
@@ -135,8 +144,8 @@ func (a *RustAnalyzer) Analyze(ctx context.Context, target string, cfg AnalysisC
 		Dependencies: dependencies,
 		Issues:       issues,
 		Passed:       passed,
-		Duration:     result.Duration,
-	}, nil
+		Duration:     duration,
+	}
 }
 
 // convertCratesToDependencies converts cargo deny list results to the unified Dependency format.
@@ -148,7 +157,13 @@ func convertCratesToDependencies(crates []CargoCrateLicense) []Dependency {
 	for _, crate := range crates {
 		// Create license info - join multiple licenses with " OR " for SPDX-like expression
 		var license *License
-		if len(crate.Licenses) > 0 {
+		if crate.Expression != "" {
+			// Keep the SPDX expression so AND/WITH semantics survive policy evaluation
+			license = &License{
+				Name: crate.Expression,
+				Type: crate.Expression,
+			}
+		} else if len(crate.Licenses) > 0 {
 			// Use the first license as the primary, but store all in the Type field
 			licenseType := crate.Licenses[0]
 			if len(crate.Licenses) > 1 {
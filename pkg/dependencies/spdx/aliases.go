@@ -0,0 +1,129 @@
+package spdx
+
+import "strings"
+
+// deprecatedAliases maps deprecated SPDX identifiers (lower-cased) to their
+// current equivalents.
+var deprecatedAliases = map[string]Term{
+	"gpl-1.0":                          {ID: "GPL-1.0-only"},
+	"gpl-2.0":                          {ID: "GPL-2.0-only"},
+	"gpl-3.0":                          {ID: "GPL-3.0-only"},
+	"lgpl-2.0":                         {ID: "LGPL-2.0-only"},
+	"lgpl-2.1":                         {ID: "LGPL-2.1-only"},
+	"lgpl-3.0":                         {ID: "LGPL-3.0-only"},
+	"agpl-1.0":                         {ID: "AGPL-1.0-only"},
+	"agpl-3.0":                         {ID: "AGPL-3.0-only"},
+	"gfdl-1.1":                         {ID: "GFDL-1.1-only"},
+	"gfdl-1.2":                         {ID: "GFDL-1.2-only"},
+	"gfdl-1.3":                         {ID: "GFDL-1.3-only"},
+	"gpl-2.0-with-classpath-exception": {ID: "GPL-2.0-only", Exception: "Classpath-exception-2.0"},
+	"gpl-2.0-with-autoconf-exception":  {ID: "GPL-2.0-only", Exception: "Autoconf-exception-2.0"},
+	"gpl-2.0-with-bison-exception":     {ID: "GPL-2.0-only", Exception: "Bison-exception-2.2"},
+	"gpl-2.0-with-font-exception":      {ID: "GPL-2.0-only", Exception: "Font-exception-2.0"},
+	"gpl-2.0-with-gcc-exception":       {ID: "GPL-2.0-only", Exception: "GCC-exception-2.0"},
+	"gpl-3.0-with-autoconf-exception":  {ID: "GPL-3.0-only", Exception: "Autoconf-exception-3.0"},
+	"gpl-3.0-with-gcc-exception":       {ID: "GPL-3.0-only", Exception: "GCC-exception-3.1"},
+	"bsd-2-clause-freebsd":             {ID: "BSD-2-Clause"},
+	"bsd-2-clause-netbsd":              {ID: "BSD-2-Clause"},
+	"standardml-nj":                    {ID: "SMLNJ"},
+	"wxwindows":                        {ID: "LGPL-2.0-or-later", OrLater: true, Exception: "WxWindows-exception-3.1"},
+}
+
+// knownIDs canonicalizes the case of commonly used SPDX identifiers.
+var knownIDs = func() map[string]string {
+	ids := []string{
+		"0BSD", "AFL-3.0", "Apache-1.0", "Apache-1.1", "Apache-2.0", "Artistic-2.0",
+		"BlueOak-1.0.0", "BSD-1-Clause", "BSD-2-Clause", "BSD-3-Clause", "BSD-3-Clause-Clear", "BSD-4-Clause",
+		"BSL-1.0", "CC-BY-3.0", "CC-BY-4.0", "CC-BY-SA-3.0", "CC-BY-SA-4.0", "CC0-1.0",
+		"CDDL-1.0", "CDDL-1.1", "EPL-1.0", "EPL-2.0", "EUPL-1.1", "EUPL-1.2", "ISC",
+		"MIT", "MIT-0", "MPL-1.0", "MPL-1.1", "MPL-2.0", "MPL-2.0-no-copyleft-exception",
+		"NCSA", "OFL-1.1", "OpenSSL", "PostgreSQL", "Python-2.0", "SMLNJ", "Unicode-3.0",
+		"Unicode-DFS-2016", "Unlicense", "Zlib", "zlib-acknowledgement", "BSD-2-Clause-Patent", "PSF-2.0",
+		"WTFPL", "X11", "curl", "Ruby", "ICU", "W3C", "Artistic-1.0", "OFL-1.0", "ODbL-1.0",
+		"GPL-1.0-only", "GPL-1.0-or-later", "GPL-2.0-only", "GPL-2.0-or-later", "GPL-3.0-only", "GPL-3.0-or-later",
+		"LGPL-2.0-only", "LGPL-2.0-or-later", "LGPL-2.1-only", "LGPL-2.1-or-later", "LGPL-3.0-only", "LGPL-3.0-or-later",
+		"AGPL-1.0-only", "AGPL-1.0-or-later", "AGPL-3.0-only", "AGPL-3.0-or-later",
+		"GFDL-1.1-only", "GFDL-1.1-or-later", "GFDL-1.2-only", "GFDL-1.2-or-later", "GFDL-1.3-only", "GFDL-1.3-or-later",
+	}
+	m := make(map[string]string, len(ids))
+	for _, id := range ids {
+		m[strings.ToLower(id)] = id
+	}
+	return m
+}()
+
+// Known reports whether id is a recognized SPDX license identifier (after
+// canonicalization), e.g. for deciding when a LicenseRef- is needed.
+func Known(id string) bool {
+	_, ok := knownIDs[strings.ToLower(ParseTerm(id).ID)]
+	return ok
+}
+
+// licenseFamilies lists the versions of licenses that offer an "or later"
+// option, oldest first. GNU families use -only suffixed identifiers.
+var licenseFamilies = map[string][]string{
+	"GPL":    {"1.0", "2.0", "3.0"},
+	"LGPL":   {"2.0", "2.1", "3.0"},
+	"AGPL":   {"1.0", "3.0"},
+	"GFDL":   {"1.1", "1.2", "1.3"},
+	"Apache": {"1.0", "1.1", "2.0"},
+	"MPL":    {"1.0", "1.1", "2.0"},
+	"EPL":    {"1.0", "2.0"},
+	"CDDL":   {"1.0", "1.1"},
+	"EUPL":   {"1.0", "1.1", "1.2"},
+	"CC-BY":  {"1.0", "2.0", "2.5", "3.0", "4.0"},
+}
+
+var gnuFamilies = map[string]bool{"GPL": true, "LGPL": true, "AGPL": true, "GFDL": true}
+
+// splitFamily splits "GPL-2.0-or-later" into ("GPL", "2.0"); ok is false for
+// identifiers outside licenseFamilies.
+func splitFamily(id string) (family, version string, ok bool) {
+	base := strings.TrimSuffix(strings.TrimSuffix(id, "-or-later"), "-only")
+	idx := strings.LastIndex(base, "-")
+	if idx <= 0 {
+		return "", "", false
+	}
+	family, version = base[:idx], base[idx+1:]
+	for _, v := range licenseFamilies[family] {
+		if v == version {
+			return family, version, true
+		}
+	}
+	return "", "", false
+}
+
+func isGNUFamily(id string) bool {
+	family, _, ok := splitFamily(id)
+	return ok && gnuFamilies[family]
+}
+
+func familyID(family, version string) string {
+	if gnuFamilies[family] {
+		return family + "-" + version + "-only"
+	}
+	return family + "-" + version
+}
+
+// Versions expands a term into the concrete license identifiers a licensee may
+// choose from: "GPL-2.0-or-later" yields GPL-2.0-only and GPL-3.0-only.
+func (t Term) Versions() []string {
+	family, version, ok := splitFamily(t.ID)
+	if !ok {
+		return []string{t.ID}
+	}
+	if !t.OrLater {
+		return []string{familyID(family, version)}
+	}
+	var out []string
+	later := false
+	for _, v := range licenseFamilies[family] {
+		if v == version {
+			later = true
+		}
+		if later {
+			out = append(out, familyID(family, v))
+		}
+	}
+	return out
+}
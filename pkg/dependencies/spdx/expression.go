@@ -0,0 +1,278 @@
+// Package spdx parses SPDX license expressions and decides whether an
+// expression can be satisfied under allowed/forbidden license lists.
+package spdx
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Node is a parsed SPDX license expression.
+type Node interface {
+	String() string
+	// Terms returns the license terms in the order they appear.
+	Terms() []Term
+}
+
+// Term is a single license identifier, optionally "or later" and with an
+// exception. IDs are canonicalized: deprecated GNU identifiers map to their
+// -only/-or-later forms and `+` is folded into OrLater.
+type Term struct {
+	ID        string
+	OrLater   bool
+	Exception string
+}
+
+// And requires every operand to be satisfied.
+type And struct{ Operands []Node }
+
+// Or requires at least one operand to be satisfied.
+type Or struct{ Operands []Node }
+
+func (t Term) String() string {
+	s := t.ID
+	if t.OrLater && !strings.HasSuffix(s, "-or-later") {
+		s += "+"
+	}
+	if t.Exception != "" {
+		s += " WITH " + t.Exception
+	}
+	return s
+}
+
+func (t Term) Terms() []Term { return []Term{t} }
+
+func (a And) String() string { return joinNodes(a.Operands, " AND ") }
+func (o Or) String() string  { return joinNodes(o.Operands, " OR ") }
+
+func (a And) Terms() []Term { return collectTerms(a.Operands) }
+func (o Or) Terms() []Term  { return collectTerms(o.Operands) }
+
+func joinNodes(nodes []Node, sep string) string {
+	parts := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		s := n.String()
+		switch n.(type) {
+		case And, Or:
+			s = "(" + s + ")"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, sep)
+}
+
+func collectTerms(nodes []Node) []Term {
+	var terms []Term
+	for _, n := range nodes {
+		terms = append(terms, n.Terms()...)
+	}
+	return terms
+}
+
+// IDs returns the distinct canonical license IDs referenced by an expression.
+func IDs(n Node) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, t := range n.Terms() {
+		if !seen[t.ID] {
+			seen[t.ID] = true
+			ids = append(ids, t.ID)
+		}
+	}
+	return ids
+}
+
+// Parse parses an SPDX license expression. Operators are case-insensitive;
+// AND binds tighter than OR, and WITH binds tighter than both.
+func Parse(expr string) (Node, error) {
+	tokens := tokenize(expr)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty license expression")
+	}
+	p := &parser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid license expression %q: %w", expr, err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("invalid license expression %q: unexpected %q", expr, p.tokens[p.pos])
+	}
+	return node, nil
+}
+
+func tokenize(expr string) []string {
+	var tokens []string
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+	for _, r := range expr {
+		switch {
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) peekOperator(op string) bool {
+	return p.pos < len(p.tokens) && strings.EqualFold(p.tokens[p.pos], op)
+}
+
+func (p *parser) parseOr() (Node, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	operands := []Node{first}
+	for p.peekOperator("OR") {
+		p.pos++
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, next)
+	}
+	if len(operands) == 1 {
+		return first, nil
+	}
+	return Or{Operands: flatten(operands, true)}, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	first, err := p.parseWith()
+	if err != nil {
+		return nil, err
+	}
+	operands := []Node{first}
+	for p.peekOperator("AND") {
+		p.pos++
+		next, err := p.parseWith()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, next)
+	}
+	if len(operands) == 1 {
+		return first, nil
+	}
+	return And{Operands: flatten(operands, false)}, nil
+}
+
+func (p *parser) parseWith() (Node, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.peekOperator("WITH") {
+		return node, nil
+	}
+	p.pos++
+	term, ok := node.(Term)
+	if !ok || term.Exception != "" {
+		return nil, fmt.Errorf("WITH must follow a single license identifier")
+	}
+	if p.pos >= len(p.tokens) || isOperatorToken(p.tokens[p.pos]) {
+		return nil, fmt.Errorf("missing exception after WITH")
+	}
+	term.Exception = p.tokens[p.pos]
+	p.pos++
+	return term, nil
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	tok := p.tokens[p.pos]
+	switch {
+	case tok == "(":
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos] != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return node, nil
+	case isOperatorToken(tok):
+		return nil, fmt.Errorf("unexpected %q", tok)
+	}
+	p.pos++
+	return ParseTerm(tok), nil
+}
+
+func isOperatorToken(tok string) bool {
+	switch strings.ToUpper(tok) {
+	case "AND", "OR", "WITH", "(", ")":
+		return true
+	}
+	return false
+}
+
+// flatten merges nested operands of the same kind: (A OR (B OR C)) -> A OR B OR C.
+func flatten(nodes []Node, or bool) []Node {
+	out := make([]Node, 0, len(nodes))
+	for _, n := range nodes {
+		switch v := n.(type) {
+		case Or:
+			if or {
+				out = append(out, v.Operands...)
+				continue
+			}
+		case And:
+			if !or {
+				out = append(out, v.Operands...)
+				continue
+			}
+		}
+		out = append(out, n)
+	}
+	return out
+}
+
+// ParseTerm canonicalizes a single license identifier (no operators).
+func ParseTerm(id string) Term {
+	id = strings.TrimSpace(id)
+	if alias, ok := deprecatedAliases[strings.ToLower(id)]; ok {
+		return alias
+	}
+	t := Term{}
+	if strings.HasSuffix(id, "+") {
+		t.OrLater = true
+		id = strings.TrimSuffix(id, "+")
+		if alias, ok := deprecatedAliases[strings.ToLower(id)]; ok {
+			id = alias.ID
+		}
+		if strings.HasSuffix(id, "-only") {
+			id = strings.TrimSuffix(id, "-only") + "-or-later"
+		} else if isGNUFamily(id) {
+			id += "-or-later"
+		}
+	}
+	if canonical, ok := knownIDs[strings.ToLower(id)]; ok {
+		id = canonical
+	}
+	if strings.HasSuffix(id, "-or-later") {
+		t.OrLater = true
+	}
+	t.ID = id
+	return t
+}
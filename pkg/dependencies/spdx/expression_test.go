@@ -0,0 +1,64 @@
+package spdx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"MIT", "MIT"},
+		{"mit or Apache-2.0", "MIT OR Apache-2.0"},
+		{"MIT AND BSD-3-Clause OR Apache-2.0", "(MIT AND BSD-3-Clause) OR Apache-2.0"},
+		{"MIT AND (BSD-3-Clause OR Apache-2.0)", "MIT AND (BSD-3-Clause OR Apache-2.0)"},
+		{"(MIT OR (Apache-2.0 OR ISC))", "MIT OR Apache-2.0 OR ISC"},
+		{"Apache-2.0 WITH LLVM-exception", "Apache-2.0 WITH LLVM-exception"},
+		{"GPL-2.0+", "GPL-2.0-or-later"},
+		{"GPL-3.0", "GPL-3.0-only"},
+		{"LGPL-2.1+ WITH Classpath-exception-2.0", "LGPL-2.1-or-later WITH Classpath-exception-2.0"},
+		{"GPL-2.0-with-classpath-exception", "GPL-2.0-only WITH Classpath-exception-2.0"},
+		{"Apache-1.1+", "Apache-1.1+"},
+		{"LicenseRef-Proprietary", "LicenseRef-Proprietary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			node, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.expr, err)
+			}
+			if got := node.String(); got != tt.want {
+				t.Fatalf("Parse(%q) = %q, want %q", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, expr := range []string{"", "MIT OR", "(MIT", "MIT)", "WITH LLVM-exception", "MIT WITH", "(MIT OR ISC) WITH LLVM-exception", "AND MIT"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) expected error", expr)
+		}
+	}
+}
+
+func TestIDsAndVersions(t *testing.T) {
+	node, err := Parse("(MIT OR GPL-2.0+) AND MIT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := IDs(node); !reflect.DeepEqual(got, []string{"MIT", "GPL-2.0-or-later"}) {
+		t.Fatalf("IDs = %v", got)
+	}
+	if got := ParseTerm("GPL-2.0-or-later").Versions(); !reflect.DeepEqual(got, []string{"GPL-2.0-only", "GPL-3.0-only"}) {
+		t.Fatalf("GPL-2.0-or-later versions = %v", got)
+	}
+	if got := ParseTerm("MPL-1.1+").Versions(); !reflect.DeepEqual(got, []string{"MPL-1.1", "MPL-2.0"}) {
+		t.Fatalf("MPL-1.1+ versions = %v", got)
+	}
+	if got := ParseTerm("GPL-3.0").Versions(); !reflect.DeepEqual(got, []string{"GPL-3.0-only"}) {
+		t.Fatalf("GPL-3.0 versions = %v", got)
+	}
+}
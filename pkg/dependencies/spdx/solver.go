@@ -0,0 +1,178 @@
+package spdx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Policy holds allowed and forbidden license lists. An empty Allowed list
+// accepts every license that is not forbidden.
+//
+// A list entry without an exception matches the license with or without any
+// exception; an entry with an exception ("GPL-2.0-only WITH
+// Classpath-exception-2.0") matches only that combination. An allowed entry
+// naming an exception overrides a bare forbidden entry for that combination.
+type Policy struct {
+	Allowed   []Term
+	Forbidden []Term
+}
+
+// NewPolicy parses policy list entries. Entries may use deprecated IDs,
+// `+`, and `WITH`; compound entries contribute each of their terms.
+func NewPolicy(allowed, forbidden []string) Policy {
+	return Policy{Allowed: parseEntries(allowed), Forbidden: parseEntries(forbidden)}
+}
+
+func parseEntries(entries []string) []Term {
+	var terms []Term
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		if node, err := Parse(e); err == nil {
+			terms = append(terms, node.Terms()...)
+		} else {
+			terms = append(terms, ParseTerm(e))
+		}
+	}
+	return terms
+}
+
+// Empty reports whether the policy has no allowed or forbidden entries.
+func (p Policy) Empty() bool {
+	return len(p.Allowed) == 0 && len(p.Forbidden) == 0
+}
+
+// Rejection explains why a license term could not be chosen.
+type Rejection struct {
+	Term   Term
+	Reason string
+}
+
+// Decision is the outcome of evaluating an expression against a Policy.
+type Decision struct {
+	Acceptable bool
+	// Choice lists the concrete licenses selected to satisfy the expression
+	// (one branch of every OR, every operand of every AND).
+	Choice []Term
+	// Rejected lists terms that could not be chosen, with reasons.
+	Rejected []Rejection
+}
+
+// ChoiceString renders the chosen licenses as an SPDX AND expression.
+func (d Decision) ChoiceString() string {
+	parts := make([]string, 0, len(d.Choice))
+	for _, t := range d.Choice {
+		parts = append(parts, t.String())
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// Reason summarizes the rejections, e.g. "GPL-3.0-only is forbidden".
+func (d Decision) Reason() string {
+	parts := make([]string, 0, len(d.Rejected))
+	for _, r := range d.Rejected {
+		parts = append(parts, fmt.Sprintf("%s %s", r.Term, r.Reason))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Evaluate decides whether some choice of licenses satisfies the policy. OR
+// operands are tried left to right and the first acceptable one is chosen.
+func (p Policy) Evaluate(n Node) Decision {
+	switch v := n.(type) {
+	case Term:
+		return p.evaluateTerm(v)
+	case And:
+		d := Decision{Acceptable: true}
+		for _, op := range v.Operands {
+			sub := p.Evaluate(op)
+			if !sub.Acceptable {
+				d.Acceptable = false
+				d.Choice = nil
+				d.Rejected = append(d.Rejected, sub.Rejected...)
+			} else if d.Acceptable {
+				d.Choice = append(d.Choice, sub.Choice...)
+			}
+		}
+		return d
+	case Or:
+		d := Decision{}
+		for _, op := range v.Operands {
+			sub := p.Evaluate(op)
+			if sub.Acceptable {
+				return sub
+			}
+			d.Rejected = append(d.Rejected, sub.Rejected...)
+		}
+		return d
+	}
+	return Decision{}
+}
+
+// EvaluateExpression parses expr and evaluates it.
+func (p Policy) EvaluateExpression(expr string) (Decision, error) {
+	node, err := Parse(expr)
+	if err != nil {
+		return Decision{}, err
+	}
+	return p.Evaluate(node), nil
+}
+
+func (p Policy) evaluateTerm(t Term) Decision {
+	var reason string
+	for _, version := range t.Versions() {
+		explicit := matchesAny(p.Allowed, version, t.Exception, true)
+		if forbidden := matchingEntry(p.Forbidden, version, t.Exception); forbidden != nil && !explicit {
+			if reason == "" {
+				reason = "is forbidden"
+				if forbidden.String() != t.String() {
+					reason = fmt.Sprintf("is forbidden (matches %s)", forbidden)
+				}
+			}
+			continue
+		}
+		if len(p.Allowed) > 0 && !matchesAny(p.Allowed, version, t.Exception, false) {
+			if reason == "" {
+				reason = "is not in the allowed list"
+			}
+			continue
+		}
+		return Decision{Acceptable: true, Choice: []Term{{ID: version, Exception: t.Exception}}}
+	}
+	return Decision{Rejected: []Rejection{{Term: t, Reason: reason}}}
+}
+
+func matchingEntry(entries []Term, version, exception string) *Term {
+	for i := range entries {
+		if entryMatches(entries[i], version, exception) {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+func matchesAny(entries []Term, version, exception string, requireException bool) bool {
+	for _, e := range entries {
+		if requireException && e.Exception == "" {
+			continue
+		}
+		if entryMatches(e, version, exception) {
+			return true
+		}
+	}
+	return false
+}
+
+func entryMatches(entry Term, version, exception string) bool {
+	if entry.Exception != "" && !strings.EqualFold(entry.Exception, exception) {
+		return false
+	}
+	for _, v := range entry.Versions() {
+		if strings.EqualFold(v, version) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package spdx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPolicyEvaluate(t *testing.T) {
+	tests := []struct {
+		name       string
+		allowed    []string
+		forbidden  []string
+		expr       string
+		acceptable bool
+		choice     string
+		reason     string
+	}{
+		{name: "or picks acceptable branch", forbidden: []string{"GPL-3.0"}, expr: "MIT OR GPL-3.0", acceptable: true, choice: "MIT"},
+		{name: "or prefers left branch", expr: "Apache-2.0 OR MIT", acceptable: true, choice: "Apache-2.0"},
+		{name: "or-later caught by deprecated ban", forbidden: []string{"GPL-3.0"}, expr: "GPL-3.0-or-later", reason: "GPL-3.0-or-later is forbidden (matches GPL-3.0-only)"},
+		{name: "plus caught by ban", forbidden: []string{"GPL-3.0-only"}, expr: "GPL-3.0+"},
+		{name: "or-later may choose older version", forbidden: []string{"GPL-3.0"}, expr: "GPL-2.0-or-later", acceptable: true, choice: "GPL-2.0-only"},
+		{name: "or-later may choose newer allowed version", allowed: []string{"GPL-3.0-only"}, expr: "GPL-2.0+", acceptable: true, choice: "GPL-3.0-only"},
+		{name: "and requires every operand", forbidden: []string{"GPL-3.0"}, expr: "MIT AND GPL-3.0", reason: "GPL-3.0-only is forbidden"},
+		{name: "with exception matches bare license", forbidden: []string{"Apache-2.0"}, expr: "Apache-2.0 WITH LLVM-exception"},
+		{name: "with exception allowed", allowed: []string{"Apache-2.0"}, expr: "Apache-2.0 WITH LLVM-exception", acceptable: true, choice: "Apache-2.0 WITH LLVM-exception"},
+		{name: "explicit exception allow overrides bare ban", allowed: []string{"GPL-2.0-only WITH Classpath-exception-2.0"}, forbidden: []string{"GPL-2.0"}, expr: "GPL-2.0-with-classpath-exception", acceptable: true, choice: "GPL-2.0-only WITH Classpath-exception-2.0"},
+		{name: "exception entry does not match other exceptions", allowed: []string{"Apache-2.0 WITH LLVM-exception"}, expr: "Apache-2.0", reason: "Apache-2.0 is not in the allowed list"},
+		{name: "allowed list rejects unknown", allowed: []string{"MIT", "Apache-2.0"}, expr: "(BSD-3-Clause OR ISC) AND MIT", reason: "BSD-3-Clause is not in the allowed list; ISC is not in the allowed list"},
+		{name: "case insensitive ids", forbidden: []string{"agpl-3.0"}, expr: "AGPL-3.0-only"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewPolicy(tt.allowed, tt.forbidden).EvaluateExpression(tt.expr)
+			if err != nil {
+				t.Fatalf("EvaluateExpression: %v", err)
+			}
+			if d.Acceptable != tt.acceptable {
+				t.Fatalf("acceptable = %v, want %v (%s)", d.Acceptable, tt.acceptable, d.Reason())
+			}
+			if tt.choice != "" && d.ChoiceString() != tt.choice {
+				t.Fatalf("choice = %q, want %q", d.ChoiceString(), tt.choice)
+			}
+			if tt.reason != "" && d.Reason() != tt.reason {
+				t.Fatalf("reason = %q, want %q", d.Reason(), tt.reason)
+			}
+			if !d.Acceptable && (len(d.Choice) != 0 || len(d.Rejected) == 0) {
+				t.Fatalf("rejected decision should carry reasons only: %+v", d)
+			}
+		})
+	}
+}
+
+func TestNewPolicy_SkipsBlankEntries(t *testing.T) {
+	p := NewPolicy([]string{" "}, []string{"", "MIT OR ISC"})
+	if len(p.Allowed) != 0 || len(p.Forbidden) != 2 || p.Empty() {
+		t.Fatalf("unexpected policy: %+v", p)
+	}
+	if !strings.HasPrefix(p.Forbidden[1].ID, "ISC") {
+		t.Fatalf("compound entries should contribute each term: %+v", p.Forbidden)
+	}
+}
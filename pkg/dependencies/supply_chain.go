@@ -0,0 +1,452 @@
+package dependencies
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/dependencies/policy"
+	"github.com/fulmenhq/goneat/pkg/supplychain"
+	"github.com/fulmenhq/goneat/pkg/tools/metadata"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// RunSupplyChainChecks applies the supply-chain heuristics (typosquats,
+// dependency confusion, npm install scripts) configured under supply_chain in
+// the policy file to the packages resolved by the lockfiles in target.
+func RunSupplyChainChecks(target string, policyPath string) ([]Issue, error) {
+	policyData := map[string]interface{}{}
+	if data, err := os.ReadFile(policyPath); err == nil { // #nosec G304 -- policyPath is user-specified config file, intentional for CLI tool
+		if err := yaml.Unmarshal(data, &policyData); err != nil {
+			return nil, fmt.Errorf("failed to parse dependencies policy: %w", err)
+		}
+	}
+	cfg, err := policy.ParseSupplyChainConfig(policyData)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	pkgs, err := SupplyChainPackages(target)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := supplychain.NewChecker(*cfg).Check(pkgs)
+	issues := make([]Issue, 0, len(findings))
+	for _, f := range findings {
+		dep := &Dependency{
+			Module:   Module{Name: f.Package.Name, Version: f.Package.Version, Language: ecosystemLanguage(f.Package.Ecosystem)},
+			Metadata: map[string]interface{}{"source": f.Package.Source},
+		}
+		if f.Similar != "" {
+			dep.Metadata["similar_to"] = f.Similar
+		}
+		issues = append(issues, Issue{
+			Type:       string(f.Type),
+			Severity:   f.Severity,
+			Message:    f.Message,
+			Dependency: dep,
+			SourceType: "lockfile",
+			SourcePath: f.Package.SourcePath,
+		})
+	}
+	return issues, nil
+}
+
+func ecosystemLanguage(ecosystem string) Language {
+	switch ecosystem {
+	case supplychain.EcosystemGo:
+		return LanguageGo
+	case supplychain.EcosystemCargo:
+		return LanguageRust
+	case supplychain.EcosystemNPM:
+		return LanguageTypeScript
+	case supplychain.EcosystemPyPI:
+		return LanguagePython
+	}
+	return ""
+}
+
+// SupplyChainPackages reads the resolved packages, with the registry or URL
+// each one comes from, from go.sum, Cargo.lock and the npm/pnpm/yarn and
+// Python lockfiles in target. Workspace, path and file dependencies are
+// skipped because they are not fetched from a registry.
+func SupplyChainPackages(target string) ([]supplychain.Package, error) {
+	var pkgs []supplychain.Package
+	if sumPath := filepath.Join(target, "go.sum"); fileExists(sumPath) {
+		found, err := goSumPackages(sumPath)
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, found...)
+	}
+	if project := DetectRustProject(target); project != nil {
+		if lockPath := filepath.Join(project.EffectiveRoot(), "Cargo.lock"); fileExists(lockPath) {
+			found, err := cargoLockPackages(lockPath)
+			if err != nil {
+				return nil, err
+			}
+			pkgs = append(pkgs, found...)
+		}
+	}
+	if lockPath := findNPMLockfile(target); lockPath != "" {
+		found, err := npmLockPackages(target, lockPath)
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, found...)
+	}
+	if lockPath := findPythonLockfile(target); lockPath != "" {
+		found, err := pythonLockPackages(lockPath)
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, found...)
+	}
+	return pkgs, nil
+}
+
+func goSumPackages(sumPath string) ([]supplychain.Package, error) {
+	data, err := os.ReadFile(sumPath) // #nosec G304 - go.sum inside the scanned project
+	if err != nil {
+		return nil, fmt.Errorf("read go.sum: %w", err)
+	}
+	env := metadata.LoadGoEnv()
+	seen := map[string]bool{}
+	var pkgs []supplychain.Package
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasSuffix(fields[1], "/go.mod") || seen[fields[0]+"@"+fields[1]] {
+			continue
+		}
+		seen[fields[0]+"@"+fields[1]] = true
+		pkgs = append(pkgs, supplychain.Package{
+			Ecosystem:  supplychain.EcosystemGo,
+			Name:       fields[0],
+			Version:    fields[1],
+			Source:     goModuleSource(env, fields[0]),
+			SourcePath: "go.sum",
+		})
+	}
+	return pkgs, nil
+}
+
+// goModuleSource reports where the go command fetches a module from: "direct"
+// for GONOPROXY/GOPRIVATE modules, otherwise the first GOPROXY entry.
+func goModuleSource(env metadata.GoEnv, modulePath string) string {
+	if env.NoProxy(modulePath) {
+		return "direct"
+	}
+	proxies := strings.FieldsFunc(env.GOPROXY, func(r rune) bool { return r == ',' || r == '|' })
+	if len(proxies) == 0 {
+		return supplychain.DefaultRegistry(supplychain.EcosystemGo)
+	}
+	return proxies[0]
+}
+
+func cargoLockPackages(lockPath string) ([]supplychain.Package, error) {
+	data, err := os.ReadFile(lockPath) // #nosec G304 - Cargo.lock located via DetectRustProject
+	if err != nil {
+		return nil, fmt.Errorf("read Cargo.lock: %w", err)
+	}
+	var lock cargoLockFile
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("parse Cargo.lock: %w", err)
+	}
+	var pkgs []supplychain.Package
+	for _, p := range lock.Package {
+		if p.Source == "" {
+			continue
+		}
+		pkgs = append(pkgs, supplychain.Package{Ecosystem: supplychain.EcosystemCargo, Name: p.Name, Version: p.Version, Source: p.Source, SourcePath: "Cargo.lock"})
+	}
+	return pkgs, nil
+}
+
+// npmRegistries holds the default and per-scope registries from .npmrc.
+type npmRegistries struct {
+	defaultRegistry string
+	scopes          map[string]string
+}
+
+func readNPMRegistries(target string) npmRegistries {
+	regs := npmRegistries{defaultRegistry: supplychain.DefaultRegistry(supplychain.EcosystemNPM), scopes: map[string]string{}}
+	data, err := os.ReadFile(filepath.Join(target, ".npmrc")) // #nosec G304 - .npmrc inside the scanned project
+	if err != nil {
+		return regs
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if key == "registry" {
+			regs.defaultRegistry = value
+		} else if scope, ok := strings.CutSuffix(key, ":registry"); ok && strings.HasPrefix(scope, "@") {
+			regs.scopes[scope] = value
+		}
+	}
+	return regs
+}
+
+func (r npmRegistries) registryFor(name string) string {
+	if strings.HasPrefix(name, "@") {
+		scope, _, _ := strings.Cut(name, "/")
+		if registry, ok := r.scopes[scope]; ok {
+			return registry
+		}
+	}
+	return r.defaultRegistry
+}
+
+// npmInstallScripts names the lifecycle scripts of an installed package when
+// node_modules is present; lockfiles only record that one exists.
+func npmInstallScripts(target, installPath string) []string {
+	var manifest struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	data, err := os.ReadFile(filepath.Join(target, filepath.FromSlash(installPath), "package.json")) // #nosec G304 - package.json inside the scanned project's node_modules
+	if err == nil && json.Unmarshal(data, &manifest) == nil {
+		var scripts []string
+		for _, name := range []string{"preinstall", "install", "postinstall"} {
+			if manifest.Scripts[name] != "" {
+				scripts = append(scripts, name)
+			}
+		}
+		if len(scripts) > 0 {
+			return scripts
+		}
+	}
+	return []string{"install"}
+}
+
+func isLocalNPMSource(source string) bool {
+	for _, prefix := range []string{"file:", "link:", "portal:", "workspace:"} {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func npmLockPackages(target, lockPath string) ([]supplychain.Package, error) {
+	data, err := os.ReadFile(lockPath) // #nosec G304 - lockfile found by findNPMLockfile
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", filepath.Base(lockPath), err)
+	}
+	lockfile := filepath.Base(lockPath)
+	regs := readNPMRegistries(target)
+	var pkgs []supplychain.Package
+	add := func(name, version, source string, scripts []string) {
+		if isLocalNPMSource(source) {
+			return
+		}
+		if source == "" {
+			source = regs.registryFor(name)
+		}
+		pkgs = append(pkgs, supplychain.Package{Ecosystem: supplychain.EcosystemNPM, Name: name, Version: version, Source: source, InstallScripts: scripts, SourcePath: lockfile})
+	}
+
+	switch lockfile {
+	case "pnpm-lock.yaml":
+		var lock pnpmLock
+		if err := yaml.Unmarshal(data, &lock); err != nil {
+			return nil, fmt.Errorf("parse pnpm-lock.yaml: %w", err)
+		}
+		for _, key := range sortedKeys(lock.Packages) {
+			name, version := pnpmSplitKey(key)
+			if name == "" {
+				continue
+			}
+			entry := lock.Packages[key]
+			var scripts []string
+			if entry.RequiresBuild {
+				scripts = npmInstallScripts(target, "node_modules/"+name)
+			}
+			add(name, version, entry.Resolution.Tarball, scripts)
+		}
+	case "yarn.lock":
+		for _, e := range yarnLockSources(data) {
+			if e.key == "__metadata" || strings.Contains(e.key, "@workspace:") || strings.Contains(e.key, "@patch:") {
+				continue
+			}
+			source := e.resolved
+			if source == "" {
+				if _, rng, ok := strings.Cut(strings.TrimPrefix(e.key, "@"), "@"); ok && isLocalNPMSource(rng) {
+					continue
+				}
+				source = yarnBerryRegistry(target)
+			}
+			add(yarnKeyName(e.key), e.version, source, nil)
+		}
+	default:
+		var lock packageLock
+		if err := json.Unmarshal(data, &lock); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", lockfile, err)
+		}
+		for _, installPath := range sortedKeys(lock.Packages) {
+			entry := lock.Packages[installPath]
+			if installPath == "" || entry.Link || !strings.Contains(installPath, "node_modules/") {
+				continue
+			}
+			name := entry.Name
+			if name == "" {
+				name = npmNameFromPath(installPath)
+			}
+			var scripts []string
+			if entry.HasInstallScript {
+				scripts = npmInstallScripts(target, installPath)
+			}
+			add(name, entry.Version, entry.Resolved, scripts)
+		}
+		var walk func(deps map[string]packageLockV1Entry)
+		walk = func(deps map[string]packageLockV1Entry) {
+			for _, name := range sortedKeys(deps) {
+				add(name, deps[name].Version, deps[name].Resolved, nil)
+				walk(deps[name].Dependencies)
+			}
+		}
+		if len(lock.Packages) == 0 {
+			walk(lock.Dependencies)
+		}
+	}
+	return pkgs, nil
+}
+
+type yarnSourceEntry struct {
+	key      string
+	version  string
+	resolved string
+}
+
+// yarnLockSources reads the first key, version and (classic) resolved URL of
+// every yarn.lock entry.
+func yarnLockSources(data []byte) []yarnSourceEntry {
+	var entries []yarnSourceEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case indent == 0:
+			header := strings.TrimSuffix(trimmed, ":")
+			first, _, _ := strings.Cut(header, ",")
+			entries = append(entries, yarnSourceEntry{key: strings.Trim(strings.TrimSpace(first), `"`)})
+		case indent == 2 && len(entries) > 0:
+			field, value := yarnField(trimmed)
+			switch field {
+			case "version":
+				entries[len(entries)-1].version = value
+			case "resolved":
+				entries[len(entries)-1].resolved = value
+			}
+		}
+	}
+	return entries
+}
+
+// yarnBerryRegistry returns npmRegistryServer from .yarnrc.yml.
+func yarnBerryRegistry(target string) string {
+	var rc struct {
+		NPMRegistryServer string `yaml:"npmRegistryServer"`
+	}
+	if data, err := os.ReadFile(filepath.Join(target, ".yarnrc.yml")); err == nil { // #nosec G304 - .yarnrc.yml inside the scanned project
+		_ = yaml.Unmarshal(data, &rc)
+	}
+	if rc.NPMRegistryServer != "" {
+		return rc.NPMRegistryServer
+	}
+	return "https://registry.yarnpkg.com"
+}
+
+func pythonLockPackages(lockPath string) ([]supplychain.Package, error) {
+	data, err := os.ReadFile(lockPath) // #nosec G304 - lockfile found by findPythonLockfile
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", filepath.Base(lockPath), err)
+	}
+	lockfile := filepath.Base(lockPath)
+	pypi := supplychain.DefaultRegistry(supplychain.EcosystemPyPI)
+	pkg := func(name, version, source string) supplychain.Package {
+		return supplychain.Package{Ecosystem: supplychain.EcosystemPyPI, Name: normalizePythonName(name), Version: version, Source: source, SourcePath: lockfile}
+	}
+	var pkgs []supplychain.Package
+
+	switch lockfile {
+	case "uv.lock", "poetry.lock":
+		var lock pythonLockFile
+		if err := toml.Unmarshal(data, &lock); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", lockfile, err)
+		}
+		for _, p := range lock.Package {
+			src := p.Source
+			if src.Editable != "" || src.Virtual != "" || src.Path != "" || src.Type == "directory" || src.Type == "file" {
+				continue
+			}
+			source := pypi
+			switch {
+			case src.Registry != "":
+				source = src.Registry
+			case src.Git != "":
+				source = "git+" + src.Git
+			case src.URL != "":
+				source = src.URL
+			}
+			pkgs = append(pkgs, pkg(p.Name, p.Version, source))
+		}
+	case "Pipfile.lock":
+		var lock pipfileLock
+		if err := json.Unmarshal(data, &lock); err != nil {
+			return nil, fmt.Errorf("parse Pipfile.lock: %w", err)
+		}
+		indexes := map[string]string{}
+		for _, s := range lock.Meta.Sources {
+			indexes[s.Name] = s.URL
+		}
+		for _, section := range []map[string]pipfileLockEntry{lock.Default, lock.Develop} {
+			for _, name := range sortedKeys(section) {
+				entry := section[name]
+				source := pypi
+				if url, ok := indexes[entry.Index]; ok && url != "" {
+					source = url
+				}
+				pkgs = append(pkgs, pkg(name, strings.TrimPrefix(entry.Version, "=="), source))
+			}
+		}
+	default:
+		// requirements.txt: --index-url replaces PyPI; --extra-index-url
+		// only adds an index, so packages may still come from PyPI.
+		source := pypi
+		var pins [][]string
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			for _, flag := range []string{"--index-url", "-i"} {
+				if rest, ok := strings.CutPrefix(line, flag); ok && (strings.HasPrefix(rest, " ") || strings.HasPrefix(rest, "=")) {
+					source = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(rest), "="))
+				}
+			}
+			if m := requirementPinPattern.FindStringSubmatch(line); m != nil {
+				pins = append(pins, m)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", lockfile, err)
+		}
+		for _, m := range pins {
+			pkgs = append(pkgs, pkg(m[1], m[2], source))
+		}
+	}
+	return pkgs, nil
+}
@@ -0,0 +1,123 @@
+package dependencies
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunSupplyChainChecks_PackageLock(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "package-lock.json"), `{
+  "name": "app",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0"},
+    "node_modules/@ourco/utils": {"version": "1.0.0"},
+    "node_modules/@ourco/auth": {"version": "2.0.0", "resolved": "https://npm.ourco.internal/@ourco/auth/-/auth-2.0.0.tgz"},
+    "node_modules/lodahs": {"version": "4.17.21", "resolved": "https://registry.npmjs.org/lodahs/-/lodahs-4.17.21.tgz"},
+    "node_modules/native-thing": {"version": "3.1.0", "hasInstallScript": true},
+    "node_modules/local-lib": {"resolved": "packages/local-lib", "link": true}
+  }
+}`)
+	// @ourco/utils has no resolved URL, so the .npmrc default registry applies.
+	writeTestFile(t, filepath.Join(dir, ".npmrc"), "registry=https://registry.npmjs.org/\n@ourco:registry=https://npm.ourco.internal/\n")
+	writeTestFile(t, filepath.Join(dir, "node_modules", "native-thing", "package.json"), `{"scripts": {"postinstall": "node build.js", "test": "jest"}}`)
+	policyPath := filepath.Join(dir, "dependencies.yaml")
+	writeTestFile(t, policyPath, "version: v1\nsupply_chain:\n  private_namespaces: [\"@ourco/*\"]\n")
+
+	issues, err := RunSupplyChainChecks(dir, policyPath)
+	if err != nil {
+		t.Fatalf("RunSupplyChainChecks: %v", err)
+	}
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.Type+":"+issue.Dependency.Name+":"+issue.Severity)
+		if issue.SourcePath != "package-lock.json" {
+			t.Errorf("%s: source path = %s", issue.Dependency.Name, issue.SourcePath)
+		}
+	}
+	want := "typosquat:lodahs:high|install_script:native-thing:low"
+	if strings.Join(got, "|") != want {
+		t.Fatalf("issues = %v, want %s", got, want)
+	}
+	if !strings.Contains(issues[1].Message, "(postinstall)") {
+		t.Errorf("install script message = %q", issues[1].Message)
+	}
+
+	// Without the scope registry the private package resolves from npmjs.
+	writeTestFile(t, filepath.Join(dir, ".npmrc"), "registry=https://registry.npmjs.org/\n")
+	issues, err = RunSupplyChainChecks(dir, policyPath)
+	if err != nil {
+		t.Fatalf("RunSupplyChainChecks: %v", err)
+	}
+	if len(issues) != 3 || issues[0].Type != "dependency_confusion" || issues[0].Dependency.Name != "@ourco/utils" {
+		t.Fatalf("issues = %+v", issues)
+	}
+}
+
+func TestRunSupplyChainChecks_RequirementsIndexURL(t *testing.T) {
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "dependencies.yaml")
+	writeTestFile(t, policyPath, "version: v1\nsupply_chain:\n  private_namespaces: [\"ourco-*\"]\n")
+
+	writeTestFile(t, filepath.Join(dir, "requirements.txt"), "ourco-billing==1.2.0\nrequests==2.32.0\n")
+	issues, err := RunSupplyChainChecks(dir, policyPath)
+	if err != nil {
+		t.Fatalf("RunSupplyChainChecks: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Type != "dependency_confusion" || issues[0].Severity != "critical" {
+		t.Fatalf("issues = %+v", issues)
+	}
+
+	writeTestFile(t, filepath.Join(dir, "requirements.txt"), "--index-url https://pypi.ourco.internal/simple\nourco-billing==1.2.0\nrequests==2.32.0\n")
+	issues, err = RunSupplyChainChecks(dir, policyPath)
+	if err != nil {
+		t.Fatalf("RunSupplyChainChecks: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("private index: issues = %+v", issues)
+	}
+
+	writeTestFile(t, policyPath, "version: v1\nsupply_chain:\n  enabled: false\n")
+	writeTestFile(t, filepath.Join(dir, "requirements.txt"), "ourco-billing==1.2.0\nreqeusts==2.32.0\n")
+	if issues, err := RunSupplyChainChecks(dir, policyPath); err != nil || len(issues) != 0 {
+		t.Fatalf("disabled: %v %+v", err, issues)
+	}
+}
+
+func TestRunSupplyChainChecks_GoEnvPrivateModules(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "dependencies.yaml")
+	writeTestFile(t, policyPath, "version: v1\nsupply_chain:\n  private_namespaces: [\"github.com/ourco/*\"]\n")
+	writeTestFile(t, filepath.Join(dir, "go.sum"), "github.com/ourco/billing v1.2.0 h1:abc=\n")
+	// GOPRIVATE persisted with `go env -w` lives in the GOENV file.
+	goEnvFile := filepath.Join(dir, "goenv")
+	writeTestFile(t, goEnvFile, "GOPRIVATE=github.com/ourco/*\n")
+	t.Setenv("GOENV", goEnvFile)
+	t.Setenv("GOPRIVATE", "")
+	t.Setenv("GONOPROXY", "")
+	t.Setenv("GOPROXY", "")
+
+	issues, err := RunSupplyChainChecks(dir, policyPath)
+	if err != nil {
+		t.Fatalf("RunSupplyChainChecks: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("go env GOPRIVATE: issues = %+v", issues)
+	}
+
+	// A set GONOPROXY overrides GOPRIVATE for proxy selection.
+	t.Setenv("GONOPROXY", "none")
+	issues, err = RunSupplyChainChecks(dir, policyPath)
+	if err != nil {
+		t.Fatalf("RunSupplyChainChecks: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Type != "dependency_confusion" {
+		t.Fatalf("GONOPROXY=none: issues = %+v", issues)
+	}
+}
@@ -0,0 +1,212 @@
+package dependencies
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/vulnerabilities"
+)
+
+// OpenVEXContext is the OpenVEX specification version emitted by ExportOpenVEX.
+const OpenVEXContext = "https://openvex.dev/ns/v0.2.0"
+
+// OpenVEXExportOptions controls the exported document.
+type OpenVEXExportOptions struct {
+	// Product is the PURL of the product the statements are about (for
+	// example pkg:golang/github.com/org/app). Allow-list products become
+	// subcomponents of it.
+	Product string
+	Author  string
+	// Timestamp defaults to the current time.
+	Timestamp time.Time
+}
+
+type openVEXDocument struct {
+	Context    string             `json:"@context"`
+	ID         string             `json:"@id"`
+	Author     string             `json:"author"`
+	Timestamp  string             `json:"timestamp"`
+	Version    int                `json:"version"`
+	Tooling    string             `json:"tooling,omitempty"`
+	Statements []openVEXStatement `json:"statements"`
+}
+
+type openVEXStatement struct {
+	Vulnerability   openVEXVulnerability `json:"vulnerability"`
+	Products        []openVEXProduct     `json:"products"`
+	Status          string               `json:"status"`
+	Justification   string               `json:"justification,omitempty"`
+	ImpactStatement string               `json:"impact_statement,omitempty"`
+	ActionStatement string               `json:"action_statement,omitempty"`
+	Timestamp       string               `json:"timestamp,omitempty"`
+}
+
+type openVEXVulnerability struct {
+	Name string `json:"name"`
+}
+
+type openVEXProduct struct {
+	ID            string             `json:"@id"`
+	Subcomponents []openVEXComponent `json:"subcomponents,omitempty"`
+}
+
+type openVEXComponent struct {
+	ID string `json:"@id"`
+}
+
+// ExportOpenVEX converts the vulnerability allow-list into an OpenVEX
+// document. Expired entries are skipped. Entries without a status are
+// accepted risks and export as affected with the reason as action statement.
+// not_affected (or "false_positive") requires an OpenVEX justification, and
+// unknown statuses are rejected rather than guessed.
+// The document @id is derived from the statements, so unchanged decisions
+// produce the same ID.
+func ExportOpenVEX(policy *VulnerabilityPolicy, opts OpenVEXExportOptions) ([]byte, error) {
+	if policy == nil {
+		return nil, errors.New("no vulnerabilities policy found")
+	}
+	ts := opts.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	author := opts.Author
+	if author == "" {
+		author = "goneat"
+	}
+
+	entries := append([]VulnerabilityAllow(nil), policy.Allow...)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	statements := []openVEXStatement{}
+	for _, a := range entries {
+		if expired(a.Until, ts) {
+			continue
+		}
+		products, err := openVEXProducts(opts.Product, a.Products)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", a.ID, err)
+		}
+		status, err := allowVEXStatus(a.Status, a.Justification)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", a.ID, err)
+		}
+		st := openVEXStatement{
+			Vulnerability: openVEXVulnerability{Name: a.ID},
+			Products:      products,
+			Status:        status,
+			Timestamp:     approvedTimestamp(a.ApprovedDate),
+		}
+		rationale := strings.TrimSpace(a.Analysis)
+		if rationale == "" {
+			rationale = strings.TrimSpace(a.Reason)
+		}
+		switch st.Status {
+		case vulnerabilities.VEXNotAffected:
+			st.Justification = a.Justification
+			st.ImpactStatement = rationale
+		case vulnerabilities.VEXAffected:
+			st.ActionStatement = rationale
+		default:
+			st.ImpactStatement = rationale
+		}
+		statements = append(statements, st)
+	}
+
+	body, err := json.Marshal(statements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode VEX statements: %w", err)
+	}
+	sum := sha256.Sum256(append([]byte(opts.Product+"\n"), body...))
+	doc := openVEXDocument{
+		Context:    OpenVEXContext,
+		ID:         "urn:goneat:vex:sha256:" + hex.EncodeToString(sum[:]),
+		Author:     author,
+		Timestamp:  ts.UTC().Format(time.RFC3339),
+		Version:    1,
+		Tooling:    "goneat",
+		Statements: statements,
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode VEX document: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// LoadVulnerabilityPolicy reads the vulnerabilities section of a dependencies
+// policy file; it returns nil when the file or section is absent.
+func LoadVulnerabilityPolicy(policyPath string) (*VulnerabilityPolicy, error) {
+	return loadVulnerabilityPolicy(policyPath)
+}
+
+func openVEXProducts(product string, purls []string) ([]openVEXProduct, error) {
+	switch {
+	case product != "":
+		p := openVEXProduct{ID: product}
+		for _, purl := range purls {
+			p.Subcomponents = append(p.Subcomponents, openVEXComponent{ID: purl})
+		}
+		return []openVEXProduct{p}, nil
+	case len(purls) > 0:
+		out := make([]openVEXProduct, 0, len(purls))
+		for _, purl := range purls {
+			out = append(out, openVEXProduct{ID: purl})
+		}
+		return out, nil
+	}
+	return nil, errors.New("no product: set a product PURL or list products on the allow entry")
+}
+
+// openVEXJustifications are the justifications OpenVEX allows for not_affected.
+var openVEXJustifications = map[string]bool{
+	"component_not_present":                             true,
+	"vulnerable_code_not_present":                       true,
+	"vulnerable_code_not_in_execute_path":               true,
+	"vulnerable_code_cannot_be_controlled_by_adversary": true,
+	"inline_mitigations_already_exist":                  true,
+}
+
+// allowVEXStatus maps an allow-list triage status to an OpenVEX status. An
+// empty status is an accepted risk; not_affected needs a valid justification.
+func allowVEXStatus(status, justification string) (string, error) {
+	switch s := strings.ToLower(strings.TrimSpace(status)); s {
+	case vulnerabilities.VEXFixed:
+		return vulnerabilities.VEXFixed, nil
+	case vulnerabilities.VEXUnderInvestigation:
+		return vulnerabilities.VEXUnderInvestigation, nil
+	case "", vulnerabilities.VEXAffected, "accepted", "accepted_risk", "risk_accepted":
+		return vulnerabilities.VEXAffected, nil
+	case vulnerabilities.VEXNotAffected, "false_positive":
+		if justification == "" {
+			return "", fmt.Errorf("status %s requires a justification", s)
+		}
+		if !openVEXJustifications[justification] {
+			return "", fmt.Errorf("unknown OpenVEX justification %q", justification)
+		}
+		return vulnerabilities.VEXNotAffected, nil
+	default:
+		return "", fmt.Errorf("unknown VEX status %q", status)
+	}
+}
+
+func expired(until string, now time.Time) bool {
+	if strings.TrimSpace(until) == "" {
+		return false
+	}
+	t, err := time.Parse("2006-01-02", strings.TrimSpace(until))
+	return err == nil && now.After(t)
+}
+
+func approvedTimestamp(date string) string {
+	t, err := time.Parse("2006-01-02", strings.TrimSpace(date))
+	if err != nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
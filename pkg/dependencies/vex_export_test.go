@@ -0,0 +1,107 @@
+package dependencies
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/vulnerabilities"
+)
+
+func TestExportOpenVEX(t *testing.T) {
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "dependencies.yaml")
+	writeTestFile(t, policyPath, `vulnerabilities:
+  enabled: true
+  allow:
+    - id: GHSA-2222
+      status: false_positive
+      justification: vulnerable_code_not_in_execute_path
+      reason: "SSH server code unused"
+      products: ["pkg:golang/golang.org/x/crypto"]
+      approved_date: "2026-03-01"
+    - id: CVE-1111
+      status: accepted_risk
+      reason: "Upgrade blocked until Q3"
+    - id: CVE-0000
+      until: "2020-01-01"
+      reason: "expired"
+`)
+	policy, err := LoadVulnerabilityPolicy(policyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	data, err := ExportOpenVEX(policy, OpenVEXExportOptions{Product: "pkg:golang/example.com/app", Timestamp: ts})
+	if err != nil {
+		t.Fatalf("ExportOpenVEX: %v", err)
+	}
+	again, _ := ExportOpenVEX(policy, OpenVEXExportOptions{Product: "pkg:golang/example.com/app", Timestamp: ts})
+	if string(data) != string(again) {
+		t.Error("export is not deterministic")
+	}
+	if strings.Contains(string(data), "CVE-0000") {
+		t.Error("expired allow entry was exported")
+	}
+
+	// The export must round-trip through the VEX loader used by scans.
+	statements, err := vulnerabilities.ParseVEX(data)
+	if err != nil {
+		t.Fatalf("ParseVEX(export): %v", err)
+	}
+	if len(statements) != 2 {
+		t.Fatalf("statements = %+v", statements)
+	}
+	accepted, fp := statements[0], statements[1]
+	if accepted.Vulnerability != "CVE-1111" || accepted.Status != vulnerabilities.VEXAffected || accepted.ActionStatement != "Upgrade blocked until Q3" {
+		t.Errorf("accepted-risk statement = %+v", accepted)
+	}
+	if fp.Status != vulnerabilities.VEXNotAffected || fp.Justification != "vulnerable_code_not_in_execute_path" ||
+		fp.Products[0] != "pkg:golang/golang.org/x/crypto" {
+		t.Errorf("false-positive statement = %+v", fp)
+	}
+
+	findings := []vulnerabilities.Finding{{ID: "GHSA-2222", PURLs: []string{"pkg:golang/golang.org/x/crypto@v0.17.0"}}}
+	if n := vulnerabilities.ApplyVEX(findings, statements); n != 1 {
+		t.Errorf("exported statement did not suppress matching finding: %+v", findings[0])
+	}
+}
+
+func TestExportOpenVEX_RequiresProduct(t *testing.T) {
+	policy := &VulnerabilityPolicy{Enabled: true, Allow: []VulnerabilityAllow{{ID: "CVE-1", Reason: "x"}}}
+	if _, err := ExportOpenVEX(policy, OpenVEXExportOptions{}); err == nil {
+		t.Fatal("expected error without product")
+	}
+	if _, err := ExportOpenVEX(nil, OpenVEXExportOptions{Product: "pkg:generic/x"}); err == nil {
+		t.Fatal("expected error without policy")
+	}
+}
+
+func TestExportOpenVEX_Statuses(t *testing.T) {
+	opts := OpenVEXExportOptions{Product: "pkg:generic/app"}
+	policy := &VulnerabilityPolicy{Enabled: true, Allow: []VulnerabilityAllow{{ID: "CVE-1", Reason: "Accepted until the next release"}}}
+	data, err := ExportOpenVEX(policy, opts)
+	if err != nil {
+		t.Fatalf("ExportOpenVEX: %v", err)
+	}
+	statements, err := vulnerabilities.ParseVEX(data)
+	if err != nil {
+		t.Fatalf("ParseVEX: %v", err)
+	}
+	if len(statements) != 1 || statements[0].Status != vulnerabilities.VEXAffected {
+		t.Errorf("entry without status should export as affected, got %+v", statements)
+	}
+
+	rejected := []VulnerabilityAllow{
+		{ID: "CVE-2", Status: "not_afected", Justification: "component_not_present"},
+		{ID: "CVE-3", Status: "not_affected"},
+		{ID: "CVE-4", Status: "false_positive", Justification: "not_reachable"},
+	}
+	for _, a := range rejected {
+		policy := &VulnerabilityPolicy{Enabled: true, Allow: []VulnerabilityAllow{a}}
+		if _, err := ExportOpenVEX(policy, opts); err == nil || !strings.Contains(err.Error(), a.ID) {
+			t.Errorf("%s: expected an error naming the entry, got %v", a.ID, err)
+		}
+	}
+}
@@ -27,12 +27,16 @@ type VulnerabilityPolicy struct {
 }
 
 type VulnerabilityAllow struct {
-	ID           string
-	Until        string
-	Reason       string
-	ApprovedBy   string
-	ApprovedDate string
-	Ticket       string
+	ID            string
+	Status        string
+	Justification string
+	Products      []string
+	Until         string
+	Reason        string
+	Analysis      string
+	ApprovedBy    string
+	ApprovedDate  string
+	Ticket        string
 }
 
 type VulnerabilityScanResult struct {
@@ -42,13 +46,22 @@ type VulnerabilityScanResult struct {
 	PackagesScanned int
 	SourceType      string
 	SourcePath      string
+	SBOMPath        string
+	SBOMFormat      string
+	Findings        []vulnerabilities.Finding
 }
 
 type VulnerabilityScanOptions struct {
 	NoIgnore     bool
 	ForceInclude []string
+	// VEXDir holds OpenVEX/CycloneDX VEX documents applied to findings
+	// (default: .goneat/vex, relative to the target).
+	VEXDir string
 }
 
+// DefaultVEXDir is where VEX documents are read from when no directory is set.
+const DefaultVEXDir = ".goneat/vex"
+
 func RunVulnerabilityScan(ctx context.Context, target string, policyPath string, sbomInputPath string, timeout time.Duration) (*VulnerabilityScanResult, []Issue, error) {
 	return RunVulnerabilityScanWithOptions(ctx, target, policyPath, sbomInputPath, timeout, VulnerabilityScanOptions{})
 }
@@ -91,6 +104,7 @@ func RunVulnerabilityScanWithOptions(ctx context.Context, target string, policyP
 	normalizedPath := filepath.Join(absTarget, "sbom", fmt.Sprintf("vuln-%s.json", timestamp))
 
 	var sbomSource string
+	sbomFormat := sbom.FormatCycloneDXJSON
 	var packageCount int
 	sourceType := "file-walk"
 	sourcePath := absTarget
@@ -105,8 +119,26 @@ func RunVulnerabilityScanWithOptions(ctx context.Context, target string, policyP
 		sbomSource = candidate
 		sourceType = "sbom-file"
 		sourcePath = candidate
-		if count, err := sbom.CountCycloneDXPackagesFromFile(candidate); err == nil {
-			packageCount = count
+		doc, format, err := sbom.LoadDocument(candidate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("sbom input: %w", err)
+		}
+		packageCount = len(doc.Packages)
+		sbomFormat = format
+		// grype reads CycloneDX and SPDX 2.x but not SPDX 3.0 JSON-LD.
+		if format == sbom.FormatSPDX3JSON {
+			data, err := doc.Encode(sbom.FormatCycloneDXJSON)
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := os.MkdirAll(filepath.Dir(sbomPath), 0o750); err != nil {
+				return nil, nil, fmt.Errorf("create sbom directory: %w", err)
+			}
+			if err := os.WriteFile(sbomPath, data, 0o600); err != nil {
+				return nil, nil, fmt.Errorf("write converted sbom: %w", err)
+			}
+			sbomSource = sbomPath
+			sbomFormat = sbom.FormatCycloneDXJSON
 		}
 	} else if _, err := os.Stat(filepath.Join(absTarget, "go.mod")); err == nil {
 		packageCount, err = generateGoModuleGraphSBOM(ctx, absTarget, sbomPath)
@@ -116,15 +148,22 @@ func RunVulnerabilityScanWithOptions(ctx context.Context, target string, policyP
 		sbomSource = sbomPath
 		sourceType = "go-module-graph"
 		sourcePath = "go.mod"
-	} else {
-		sbomInvoker, err := sbom.NewSyftInvoker()
+	} else if sbomInvoker, syftErr := sbom.NewSyftInvoker(); syftErr != nil {
+		if !NativeSBOMSupported(absTarget) {
+			return nil, nil, fmt.Errorf("syft not available: %w", syftErr)
+		}
+		packageCount, err = GenerateNativeSBOM(ctx, absTarget, sbomPath, NativeSBOMOptions{})
 		if err != nil {
-			return nil, nil, fmt.Errorf("syft not available: %w", err)
+			return nil, nil, fmt.Errorf("native sbom generation failed: %w", err)
 		}
+		sbomSource = sbomPath
+		sourceType = "lockfile"
+		sourcePath = absTarget
+	} else {
 		sbomResult, err := sbomInvoker.Generate(ctx, sbom.Config{
 			TargetPath:      absTarget,
 			OutputPath:      sbomPath,
-			Format:          "cyclonedx-json",
+			Format:          sbom.FormatCycloneDXJSON,
 			ExcludePatterns: vulnerabilityScanExcludePatterns(absTarget, opts),
 		})
 		if err != nil {
@@ -150,6 +189,24 @@ func RunVulnerabilityScanWithOptions(ctx context.Context, target string, policyP
 	}
 	annotateVulnerabilityFindings(findings, sourceType, sourcePath)
 
+	vexDir := opts.VEXDir
+	if vexDir == "" {
+		vexDir = DefaultVEXDir
+	}
+	if !filepath.IsAbs(vexDir) {
+		vexDir = filepath.Join(absTarget, vexDir)
+	}
+	vexStatements, skippedVEX, err := vulnerabilities.LoadVEXDir(vexDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, skipErr := range skippedVEX {
+		logger.Warn(fmt.Sprintf("dependencies: skipping VEX document: %v", skipErr))
+	}
+	if n := vulnerabilities.ApplyVEX(findings, vexStatements); n > 0 {
+		logger.Info("dependencies: VEX statements suppressed findings", logger.Int("count", n), logger.String("dir", vexDir))
+	}
+
 	matchCount := 0
 	for _, v := range counts {
 		matchCount += v
@@ -207,7 +264,7 @@ func RunVulnerabilityScanWithOptions(ctx context.Context, target string, policyP
 		),
 	}}, issues...)
 
-	return &VulnerabilityScanResult{ReportPath: normalizedPath, RawReportPath: grypeRawPath, Summary: summary, PackagesScanned: packageCount, SourceType: sourceType, SourcePath: sourcePath}, issues, nil
+	return &VulnerabilityScanResult{ReportPath: normalizedPath, RawReportPath: grypeRawPath, Summary: summary, PackagesScanned: packageCount, SourceType: sourceType, SourcePath: sourcePath, SBOMPath: sbomSource, SBOMFormat: sbomFormat, Findings: findings}, issues, nil
 }
 
 // rawFindingsCount was used during early prototyping and is intentionally removed.
@@ -245,11 +302,21 @@ func loadVulnerabilityPolicy(policyPath string) (*VulnerabilityPolicy, error) {
 			}
 			entry := VulnerabilityAllow{}
 			entry.ID, _ = m["id"].(string)
+			entry.Status, _ = m["status"].(string)
+			entry.Justification, _ = m["justification"].(string)
 			entry.Until, _ = m["until"].(string)
 			entry.Reason, _ = m["reason"].(string)
+			entry.Analysis, _ = m["analysis"].(string)
 			entry.ApprovedBy, _ = m["approved_by"].(string)
 			entry.ApprovedDate, _ = m["approved_date"].(string)
 			entry.Ticket, _ = m["ticket"].(string)
+			if products, ok := m["products"].([]interface{}); ok {
+				for _, p := range products {
+					if purl, ok := p.(string); ok && strings.TrimSpace(purl) != "" {
+						entry.Products = append(entry.Products, strings.TrimSpace(purl))
+					}
+				}
+			}
 			if entry.ID != "" {
 				allow = append(allow, entry)
 			}
@@ -329,6 +396,11 @@ func evaluateVulnerabilityPolicy(policy *VulnerabilityPolicy, findings *[]vulner
 
 	for i := range *findings {
 		f := &(*findings)[i]
+		// Findings cleared by VEX statements are already suppressed.
+		if f.Suppressed {
+			suppressed++
+			continue
+		}
 		if allowEntry, ok := allowed[f.ID]; ok {
 			shouldAllow := true
 			if strings.TrimSpace(allowEntry.Until) != "" {
@@ -124,6 +124,56 @@ func TestRunVulnerabilityScan_SBOMInputSource(t *testing.T) {
 	}
 }
 
+func TestRunVulnerabilityScan_AppliesVEXDocuments(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script test helper is POSIX-only")
+	}
+
+	repo := t.TempDir()
+	inputPath := filepath.Join(repo, "input.cdx.json")
+	writeTestFile(t, inputPath, `{"bomFormat":"CycloneDX","specVersion":"1.5","version":1,"components":[{"type":"library","name":"left-pad","version":"1.0.0"}]}`)
+	policyPath := filepath.Join(repo, ".goneat", "dependencies.yaml")
+	writeTestFile(t, policyPath, "vulnerabilities:\n  enabled: true\n  tool: grype\n  fail_on: low\n")
+	writeTestFile(t, filepath.Join(repo, ".goneat", "vex", "triage.openvex.json"), `{
+  "@context": "https://openvex.dev/ns/v0.2.0",
+  "statements": [{
+    "vulnerability": {"name": "CVE-2099-0002"},
+    "products": [{"@id": "pkg:npm/left-pad"}],
+    "status": "not_affected",
+    "justification": "vulnerable_code_not_present"
+  }]
+}`)
+
+	grypePath := writeFakeGrype(t, `{
+  "matches": [
+    {
+      "artifact": {"name": "left-pad", "version": "1.0.0", "purl": "pkg:npm/left-pad@1.0.0"},
+      "vulnerability": {"id": "GHSA-0000-0000-0002", "severity": "High", "fix": {"versions": [], "state": "not-fixed", "available": []}},
+      "relatedVulnerabilities": [{"id": "CVE-2099-0002"}]
+    }
+  ]
+}`)
+	t.Setenv("GONEAT_TOOL_GRYPE", grypePath)
+	t.Setenv("GONEAT_TOOL_SYFT", filepath.Join(t.TempDir(), "missing-syft"))
+
+	result, issues, err := RunVulnerabilityScanWithOptions(context.Background(), repo, policyPath, inputPath, time.Minute, VulnerabilityScanOptions{})
+	if err != nil {
+		t.Fatalf("RunVulnerabilityScanWithOptions failed: %v", err)
+	}
+	if result.Summary.Violations != 0 || result.Summary.Suppressed != 1 {
+		t.Fatalf("summary = %+v, want VEX suppression", result.Summary)
+	}
+	for _, issue := range issues {
+		if issue.Severity != "info" {
+			t.Fatalf("unexpected issue: %+v", issue)
+		}
+	}
+	f := result.Findings[0]
+	if f.VEXStatus != vulnerabilities.VEXNotAffected || f.SuppressReason != "vex not_affected: vulnerable_code_not_present" {
+		t.Fatalf("finding = %+v", f)
+	}
+}
+
 func TestRunVulnerabilityScan_FallbackSyftExcludesIgnoredPaths(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("shell script test helper is POSIX-only")
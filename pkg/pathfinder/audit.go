@@ -6,6 +6,7 @@ import (
 	"encoding/csv"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -23,6 +24,9 @@ type AuditLoggerImpl struct {
 	exportFormats  []ExportFormat
 	deterministic  bool   // Use deterministic ID generation for testing/replay
 	seed           string // Seed for deterministic generation
+	sinks          []AuditSink
+	sinkFailures   int   // sink writes that failed since the last Close
+	firstSinkErr   error // first of those failures, reported by Close
 }
 
 // NewAuditLogger creates a new audit logger with default settings
@@ -97,24 +101,73 @@ func (a *AuditLoggerImpl) LogOperation(record AuditRecord) error {
 		a.records = a.records[keep:]
 	}
 
+	// Persist to durable sinks; the in-memory copy is kept even if a sink fails
+	var sinkErrs []error
+	for _, sink := range a.sinks {
+		if err := sink.Write(record); err != nil {
+			sinkErrs = append(sinkErrs, err)
+		}
+	}
+	if len(sinkErrs) > 0 {
+		err := fmt.Errorf("audit sink write failed: %w", errors.Join(sinkErrs...))
+		if a.sinkFailures == 0 {
+			a.firstSinkErr = err
+		}
+		a.sinkFailures++
+		return err
+	}
+
 	return nil
 }
 
+// AddSink attaches a durable sink that receives every record logged from now on
+func (a *AuditLoggerImpl) AddSink(sink AuditSink) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sinks = append(a.sinks, sink)
+}
+
+// Close flushes and closes all attached sinks. It also reports sink writes
+// that failed since the last Close, since callers such as loaders cannot act
+// on the error returned by LogOperation.
+func (a *AuditLoggerImpl) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var errs []error
+	if a.sinkFailures > 0 {
+		errs = append(errs, fmt.Errorf("%d audit record(s) were not persisted: %w", a.sinkFailures, a.firstSinkErr))
+		a.sinkFailures, a.firstSinkErr = 0, nil
+	}
+	for _, sink := range a.sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	a.sinks = nil
+	return errors.Join(errs...)
+}
+
 // Query retrieves audit records based on constraints
 func (a *AuditLoggerImpl) Query(query AuditQuery) ([]AuditRecord, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 
+	return filterAuditRecords(a.records, query), nil
+}
+
+// filterAuditRecords returns the records matching query, newest first and paginated
+func filterAuditRecords(records []AuditRecord, query AuditQuery) []AuditRecord {
 	var results []AuditRecord
 
-	for _, record := range a.records {
-		if a.matchesQuery(record, query) {
+	for _, record := range records {
+		if matchesQuery(record, query) {
 			results = append(results, record)
 		}
 	}
 
 	// Sort by timestamp (newest first)
-	sort.Slice(results, func(i, j int) bool {
+	sort.SliceStable(results, func(i, j int) bool {
 		return results[i].Timestamp.After(results[j].Timestamp)
 	})
 
@@ -129,12 +182,12 @@ func (a *AuditLoggerImpl) Query(query AuditQuery) ([]AuditRecord, error) {
 			end = len(results)
 		}
 		if offset >= len(results) {
-			return []AuditRecord{}, nil
+			return []AuditRecord{}
 		}
 		results = results[offset:end]
 	}
 
-	return results, nil
+	return results
 }
 
 // Export exports audit records in the specified format
@@ -142,13 +195,18 @@ func (a *AuditLoggerImpl) Export(format ExportFormat) ([]byte, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 
+	return ExportAuditRecords(a.records, format)
+}
+
+// ExportAuditRecords renders records in the given export format
+func ExportAuditRecords(records []AuditRecord, format ExportFormat) ([]byte, error) {
 	switch format {
 	case ExportJSON:
-		return a.exportJSON()
+		return exportJSON(records)
 	case ExportCSV:
-		return a.exportCSV()
+		return exportCSV(records)
 	case ExportSyslog:
-		return a.exportSyslog()
+		return exportSyslog(records)
 	default:
 		return nil, fmt.Errorf("unsupported export format: %s", format)
 	}
@@ -159,10 +217,17 @@ func (a *AuditLoggerImpl) SetComplianceMode(mode ComplianceMode) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	// Validate compliance mode
+	if err := validateComplianceMode(mode); err != nil {
+		return err
+	}
+	a.complianceMode = mode
+	return nil
+}
+
+// validateComplianceMode rejects unknown compliance modes
+func validateComplianceMode(mode ComplianceMode) error {
 	switch mode {
 	case ComplianceNone, ComplianceHIPAA, ComplianceSOC2, CompliancePCIDSS, ComplianceGDPR:
-		a.complianceMode = mode
 		return nil
 	default:
 		return fmt.Errorf("unsupported compliance mode: %s", mode)
@@ -174,9 +239,15 @@ func (a *AuditLoggerImpl) Configure(config AuditConfig) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	if err := a.SetComplianceMode(config.ComplianceMode); err != nil {
+	// An empty mode means no compliance rules
+	mode := config.ComplianceMode
+	if mode == "" {
+		mode = ComplianceNone
+	}
+	if err := validateComplianceMode(mode); err != nil {
 		return err
 	}
+	a.complianceMode = mode
 
 	a.retentionDays = config.RetentionDays
 	if a.retentionDays <= 0 {
@@ -188,11 +259,13 @@ func (a *AuditLoggerImpl) Configure(config AuditConfig) error {
 		a.exportFormats = []ExportFormat{ExportJSON}
 	}
 
+	a.sinks = append(a.sinks, config.Sinks...)
+
 	return nil
 }
 
 // matchesQuery checks if a record matches the query constraints
-func (a *AuditLoggerImpl) matchesQuery(record AuditRecord, query AuditQuery) bool {
+func matchesQuery(record AuditRecord, query AuditQuery) bool {
 	if query.StartTime != nil && record.Timestamp.Before(*query.StartTime) {
 		return false
 	}
@@ -281,13 +354,16 @@ func (a *AuditLoggerImpl) cleanupOldRecords() {
 }
 
 // exportJSON exports records as JSON
-func (a *AuditLoggerImpl) exportJSON() ([]byte, error) {
-	return json.MarshalIndent(a.records, "", "  ")
+func exportJSON(records []AuditRecord) ([]byte, error) {
+	if records == nil {
+		records = []AuditRecord{}
+	}
+	return json.MarshalIndent(records, "", "  ")
 }
 
 // exportCSV exports records as CSV
-func (a *AuditLoggerImpl) exportCSV() ([]byte, error) {
-	if len(a.records) == 0 {
+func exportCSV(records []AuditRecord) ([]byte, error) {
+	if len(records) == 0 {
 		return []byte{}, nil
 	}
 
@@ -305,7 +381,7 @@ func (a *AuditLoggerImpl) exportCSV() ([]byte, error) {
 	}
 
 	// Write records
-	for _, record := range a.records {
+	for _, record := range records {
 		row := []string{
 			record.ID,
 			record.Timestamp.Format(time.RFC3339),
@@ -344,10 +420,10 @@ func (a *AuditLoggerImpl) exportCSV() ([]byte, error) {
 }
 
 // exportSyslog exports records in syslog format
-func (a *AuditLoggerImpl) exportSyslog() ([]byte, error) {
+func exportSyslog(records []AuditRecord) ([]byte, error) {
 	var buf strings.Builder
 
-	for _, record := range a.records {
+	for _, record := range records {
 		line := fmt.Sprintf("<%d>%s %s pathfinder[%s]: operation=%s path=%s result=%s",
 			6, // INFO level
 			record.Timestamp.Format(time.RFC3339),
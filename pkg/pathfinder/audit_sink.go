@@ -0,0 +1,326 @@
+package pathfinder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultAuditDir is where goneat persists audit trails, relative to the repository root
+const DefaultAuditDir = ".goneat/audit"
+
+const (
+	auditFileName      = "audit.jsonl"
+	auditRotatedPrefix = "audit-"
+	auditRotatedSuffix = ".jsonl"
+	auditRotatedLayout = "20060102T150405.000000000Z"
+)
+
+// AuditSink receives audit records for durable storage or forwarding.
+// Implementations must be safe for use by a single AuditLogger.
+type AuditSink interface {
+	Write(record AuditRecord) error
+	Close() error
+}
+
+// AuditFileSinkOptions configures a rotating JSONL audit file
+type AuditFileSinkOptions struct {
+	// Dir holds the active and rotated files (default DefaultAuditDir)
+	Dir string
+	// MaxBytes rotates the active file before it grows past this size (default 10MB)
+	MaxBytes int64
+	// MaxBackups is the number of rotated files kept (default 10, negative keeps all)
+	MaxBackups int
+}
+
+// AuditFileSink appends records as JSON lines to <dir>/audit.jsonl and rotates
+// the file to audit-<utc timestamp>.jsonl when it reaches MaxBytes.
+type AuditFileSink struct {
+	mu   sync.Mutex
+	opts AuditFileSinkOptions
+	file *os.File
+	size int64
+}
+
+// NewAuditFileSink opens (or creates) the active audit file
+func NewAuditFileSink(opts AuditFileSinkOptions) (*AuditFileSink, error) {
+	if opts.Dir == "" {
+		opts.Dir = DefaultAuditDir
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = 10 * 1024 * 1024
+	}
+	if opts.MaxBackups == 0 {
+		opts.MaxBackups = 10
+	}
+	if err := os.MkdirAll(opts.Dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create audit directory: %w", err)
+	}
+
+	sink := &AuditFileSink{opts: opts}
+	if err := sink.open(); err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+// Path returns the active audit file
+func (s *AuditFileSink) Path() string {
+	return filepath.Join(s.opts.Dir, auditFileName)
+}
+
+func (s *AuditFileSink) open() error {
+	if err := repairTornTail(s.Path()); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(s.Path(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) // #nosec G304 -- audit dir is chosen by the caller
+	if err != nil {
+		return fmt.Errorf("failed to open audit file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat audit file: %w", err)
+	}
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+// Write appends a record, rotating first if the active file would exceed MaxBytes
+func (s *AuditFileSink) Write(record AuditRecord) error {
+	line, err := marshalAuditLine(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return fmt.Errorf("audit file sink is closed")
+	}
+	if s.size > 0 && s.size+int64(len(line)) > s.opts.MaxBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write audit file: %w", err)
+	}
+	return nil
+}
+
+// rotate renames the active file aside, reopens a fresh one and prunes old backups
+func (s *AuditFileSink) rotate() error {
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit file: %w", err)
+	}
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit file: %w", err)
+	}
+	s.file = nil
+
+	stamp := time.Now().UTC().Format(auditRotatedLayout)
+	target := filepath.Join(s.opts.Dir, auditRotatedPrefix+stamp+auditRotatedSuffix)
+	for i := 1; fileExists(target); i++ {
+		target = filepath.Join(s.opts.Dir, fmt.Sprintf("%s%s-%d%s", auditRotatedPrefix, stamp, i, auditRotatedSuffix))
+	}
+	if err := os.Rename(s.Path(), target); err != nil {
+		return fmt.Errorf("failed to rotate audit file: %w", err)
+	}
+	if err := s.open(); err != nil {
+		return err
+	}
+	return s.prune()
+}
+
+func (s *AuditFileSink) prune() error {
+	if s.opts.MaxBackups < 0 {
+		return nil
+	}
+	backups, err := rotatedAuditFiles(s.opts.Dir)
+	if err != nil {
+		return err
+	}
+	for len(backups) > s.opts.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return fmt.Errorf("failed to prune audit file: %w", err)
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// Close syncs and closes the active file
+func (s *AuditFileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := errors.Join(s.file.Sync(), s.file.Close())
+	s.file = nil
+	return err
+}
+
+// rotatedAuditFiles lists rotated audit files, oldest first
+func rotatedAuditFiles(dir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, auditRotatedPrefix+"*"+auditRotatedSuffix))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// AuditReadOptions bounds the records read back from an audit directory
+type AuditReadOptions struct {
+	// RetentionDays skips records older than this (default 90, negative keeps all)
+	RetentionDays int
+	// Now overrides the clock used for retention (tests)
+	Now func() time.Time
+}
+
+// ReadAuditDir loads the records persisted under dir by the file sink and the
+// segmented store that are inside the retention window. Records written to
+// both are returned once.
+func ReadAuditDir(dir string, opts AuditReadOptions) ([]AuditRecord, error) {
+	if dir == "" {
+		dir = DefaultAuditDir
+	}
+	if opts.RetentionDays == 0 {
+		opts.RetentionDays = 90
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	var cutoff time.Time
+	if opts.RetentionDays > 0 {
+		cutoff = opts.Now().AddDate(0, 0, -opts.RetentionDays)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("audit directory not readable: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("audit path %s is not a directory", dir)
+	}
+
+	files, err := rotatedAuditFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, filepath.Join(dir, auditFileName))
+	segments, err := listAuditSegments(AuditStoreDir(dir))
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range segments {
+		files = append(files, segment.path)
+	}
+
+	seen := make(map[string]bool)
+	var records []AuditRecord
+	for _, file := range files {
+		loaded, err := readAuditJSONL(file)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range loaded {
+			if record.Timestamp.Before(cutoff) {
+				continue
+			}
+			if record.ID != "" {
+				if seen[record.ID] {
+					continue
+				}
+				seen[record.ID] = true
+			}
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// QueryAuditDir runs query over the records persisted under dir that are
+// inside the retention window
+func QueryAuditDir(dir string, query AuditQuery, opts AuditReadOptions) ([]AuditRecord, error) {
+	records, err := ReadAuditDir(dir, opts)
+	if err != nil {
+		return nil, err
+	}
+	return filterAuditRecords(records, query), nil
+}
+
+func marshalAuditLine(record AuditRecord) ([]byte, error) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	return append(line, '\n'), nil
+}
+
+// readAuditJSONL decodes a JSON lines file. A torn final line (a crash
+// mid-append) is ignored; corruption anywhere else is an error.
+func readAuditJSONL(path string) ([]AuditRecord, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- audit files live under the caller's audit dir
+	if err != nil {
+		return nil, err
+	}
+
+	lines := bytes.Split(data, []byte("\n"))
+	var records []AuditRecord
+	for i, line := range lines {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var record AuditRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			if i == len(lines)-1 {
+				break
+			}
+			return nil, fmt.Errorf("%s:%d: invalid audit record: %w", path, i+1, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// repairTornTail drops a partial final line left by a crash mid-append so the
+// next record starts on its own line
+func repairTornTail(path string) error {
+	data, err := os.ReadFile(path) // #nosec G304 -- audit files live under the caller's audit dir
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(data) == 0 || data[len(data)-1] == '\n' {
+		return nil
+	}
+	keep := bytes.LastIndexByte(data, '\n') + 1
+	if err := os.Truncate(path, int64(keep)); err != nil {
+		return fmt.Errorf("failed to repair %s: %w", path, err)
+	}
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
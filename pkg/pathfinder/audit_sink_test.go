@@ -0,0 +1,436 @@
+package pathfinder
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testAuditRecord(id string, ts time.Time, op PathOperation, status string) AuditRecord {
+	return AuditRecord{
+		ID:           id,
+		Timestamp:    ts,
+		Operation:    op,
+		Path:         "schemas/" + id + ".json",
+		SourceLoader: "tar",
+		Result:       OperationResult{Status: status, Code: 200},
+	}
+}
+
+func TestAuditFileSink_RotatesAndPrunes(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewAuditFileSink(AuditFileSinkOptions{Dir: dir, MaxBytes: 400, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("NewAuditFileSink() error = %v", err)
+	}
+
+	now := time.Now()
+	for i := 0; i < 12; i++ {
+		if err := sink.Write(testAuditRecord(fmt.Sprintf("r%02d", i), now.Add(time.Duration(i)*time.Second), OpOpen, "success")); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := sink.Write(testAuditRecord("late", now, OpOpen, "success")); err == nil {
+		t.Error("Write() after Close() should fail")
+	}
+
+	backups, err := rotatedAuditFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 {
+		t.Errorf("rotated files = %d, want 2 (MaxBackups)", len(backups))
+	}
+	info, err := os.Stat(sink.Path())
+	if err != nil {
+		t.Fatalf("active file missing: %v", err)
+	}
+	if info.Size() > 400 {
+		t.Errorf("active file size = %d, exceeds MaxBytes", info.Size())
+	}
+
+	records, err := ReadAuditDir(dir, AuditReadOptions{})
+	if err != nil {
+		t.Fatalf("ReadAuditDir() error = %v", err)
+	}
+	if len(records) == 0 || len(records) >= 12 {
+		t.Fatalf("ReadAuditDir() returned %d records, want the unpruned tail", len(records))
+	}
+	if records[len(records)-1].ID != "r11" {
+		t.Errorf("newest persisted record = %s, want r11", records[len(records)-1].ID)
+	}
+}
+
+func TestAuditFileSink_RepairsTornTail(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewAuditFileSink(AuditFileSinkOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Write(testAuditRecord("a", time.Now(), OpOpen, "success")); err != nil {
+		t.Fatal(err)
+	}
+	_ = sink.Close()
+
+	// Simulate a crash mid-append
+	f, err := os.OpenFile(sink.Path(), os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"id":"torn","timest`)
+	_ = f.Close()
+
+	records, err := ReadAuditDir(dir, AuditReadOptions{})
+	if err != nil || len(records) != 1 {
+		t.Fatalf("ReadAuditDir() with torn tail = %d records, err %v", len(records), err)
+	}
+
+	sink, err = NewAuditFileSink(AuditFileSinkOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Write(testAuditRecord("b", time.Now(), OpOpen, "success")); err != nil {
+		t.Fatal(err)
+	}
+	_ = sink.Close()
+
+	records, err = ReadAuditDir(dir, AuditReadOptions{})
+	if err != nil {
+		t.Fatalf("ReadAuditDir() after repair error = %v", err)
+	}
+	if len(records) != 2 || records[1].ID != "b" {
+		t.Errorf("records after repair = %+v", records)
+	}
+}
+
+func TestAuditStore_SegmentsAndRetention(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, 0, -45)
+	clock := old
+	opts := AuditStoreOptions{Dir: dir, SegmentBytes: 300, RetentionDays: 30, Now: func() time.Time { return clock }}
+
+	store, err := NewAuditStore(opts)
+	if err != nil {
+		t.Fatalf("NewAuditStore() error = %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		if err := store.Write(testAuditRecord(fmt.Sprintf("old%d", i), old.Add(time.Duration(i)*time.Minute), OpOpen, "success")); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := listAuditSegments(dir)
+	if len(before) != 4 {
+		t.Fatalf("expected one segment per record, got %d", len(before))
+	}
+
+	// 45 days later: every segment has expired, including the active one
+	clock = now
+	store, err = NewAuditStore(opts)
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	after, _ := listAuditSegments(dir)
+	if len(after) != 0 {
+		t.Errorf("segments after retention = %d, want none", len(after))
+	}
+	expired, err := store.Query(AuditQuery{})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(expired) != 0 {
+		t.Errorf("Query() returned %d expired records", len(expired))
+	}
+
+	// Appends continue the sequence
+	for i := 0; i < 4; i++ {
+		if err := store.Write(testAuditRecord(fmt.Sprintf("new%d", i), now.Add(-time.Duration(i)*time.Hour), OpList, "denied")); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	final, _ := listAuditSegments(dir)
+	if final[0].seq <= before[3].seq {
+		t.Errorf("segment numbering restarted at %d", final[0].seq)
+	}
+
+	all, err := store.Query(AuditQuery{})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(all) != 4 || all[0].ID != "new0" {
+		t.Errorf("Query() = %+v, want 4 records newest first", all)
+	}
+	op := OpOpen
+	if opens, _ := store.Query(AuditQuery{Operation: &op}); len(opens) != 0 {
+		t.Errorf("Query(operation=open) = %d, want 0", len(opens))
+	}
+	if removed, err := store.EnforceRetention(); err != nil || removed != 0 {
+		t.Errorf("EnforceRetention() = %d, %v", removed, err)
+	}
+}
+
+func TestAuditStore_ExpiresActiveSegment(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := start
+	now := func() time.Time { return clock }
+	store, err := NewAuditStore(AuditStoreOptions{Dir: AuditStoreDir(dir), RetentionDays: 30, Now: now})
+	if err != nil {
+		t.Fatalf("NewAuditStore() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	sink, err := NewAuditFileSink(AuditFileSinkOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = sink.Close() }()
+	for i, ts := range []time.Time{start, start.Add(time.Hour)} {
+		record := testAuditRecord(fmt.Sprintf("old%d", i), ts, OpOpen, "success")
+		if err := errors.Join(store.Write(record), sink.Write(record)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	// The default 4MB segment never fills; expiry alone seals and removes it
+	clock = start.AddDate(0, 0, 31)
+	if err := store.Write(testAuditRecord("new", clock, OpOpen, "success")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	segments, _ := listAuditSegments(AuditStoreDir(dir))
+	for _, segment := range segments {
+		data, err := os.ReadFile(segment.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), `"old`) {
+			t.Errorf("expired records still on disk in %s", segment.path)
+		}
+	}
+
+	records, err := QueryAuditDir(dir, AuditQuery{}, AuditReadOptions{RetentionDays: 30, Now: now})
+	if err != nil {
+		t.Fatalf("QueryAuditDir() error = %v", err)
+	}
+	if len(records) != 1 || records[0].ID != "new" {
+		t.Errorf("QueryAuditDir() = %+v, want only the unexpired record", records)
+	}
+	if all, _ := ReadAuditDir(dir, AuditReadOptions{RetentionDays: -1, Now: now}); len(all) != 3 {
+		t.Errorf("ReadAuditDir(keep all) = %d records, want 3", len(all))
+	}
+}
+
+func TestAuditLogger_Sinks(t *testing.T) {
+	dir := t.TempDir()
+	fileSink, err := NewAuditFileSink(AuditFileSinkOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewAuditStore(AuditStoreOptions{Dir: filepath.Join(dir, auditStoreDirName)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pf := NewPathFinder()
+	if err := pf.EnableAudit(AuditConfig{Enabled: true, ComplianceMode: ComplianceHIPAA, Sinks: []AuditSink{fileSink, store}}); err != nil {
+		t.Fatalf("EnableAudit() error = %v", err)
+	}
+	logger := pf.(*pathfinderImpl).auditLogger.(*AuditLoggerImpl)
+	if err := logger.LogOperation(AuditRecord{Operation: OpOpen, Path: "data/phi/p1.json", Result: OperationResult{Status: "success", Code: 200}}); err != nil {
+		t.Fatalf("LogOperation() error = %v", err)
+	}
+	if err := logger.LogOperation(AuditRecord{Operation: OpOpen, Path: "../etc/passwd", Result: OperationResult{Status: "denied", Code: 403}}); err != nil {
+		t.Fatalf("LogOperation() error = %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Both sinks hold the same records; the directory query returns them once
+	records, err := ReadAuditDir(dir, AuditReadOptions{})
+	if err != nil {
+		t.Fatalf("ReadAuditDir() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("ReadAuditDir() = %d records, want 2", len(records))
+	}
+	if len(records[0].SecurityFlags) == 0 || records[0].SecurityFlags[0] != "HIPAA_PHI_ACCESS" {
+		t.Errorf("compliance flags not persisted: %+v", records[0])
+	}
+
+	status := "denied"
+	denied, err := QueryAuditDir(dir, AuditQuery{Result: &status}, AuditReadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(denied) != 1 || denied[0].Path != "../etc/passwd" {
+		t.Errorf("QueryAuditDir(result=denied) = %+v", denied)
+	}
+
+	// A failing sink surfaces an error but the in-memory trail keeps the record
+	if err := logger.LogOperation(AuditRecord{Operation: OpOpen, Path: "x"}); err != nil {
+		t.Errorf("LogOperation() without sinks error = %v", err)
+	}
+	logger.AddSink(fileSink) // closed
+	if err := logger.LogOperation(AuditRecord{Operation: OpOpen, Path: "y"}); err == nil {
+		t.Error("LogOperation() should report a closed sink")
+	}
+	if got, _ := logger.Query(AuditQuery{}); len(got) != 4 {
+		t.Errorf("in-memory trail = %d records, want 4", len(got))
+	}
+	// Close reports the lost record for callers that ignore LogOperation errors
+	if err := logger.Close(); err == nil || !strings.Contains(err.Error(), "1 audit record(s) were not persisted") {
+		t.Errorf("Close() error = %v, want the failed sink write", err)
+	}
+}
+
+func TestFormatRFC5424(t *testing.T) {
+	record := AuditRecord{
+		ID:            "abc",
+		Timestamp:     time.Date(2026, 3, 4, 5, 6, 7, 123456000, time.UTC),
+		Operation:     OpOpen,
+		Path:          `dir/"quoted]\x`,
+		SourceLoader:  "zip",
+		Result:        OperationResult{Status: "denied", Code: 403, Message: "path traversal"},
+		SecurityFlags: []string{"SOC2_ACCESS_DENIED"},
+	}
+	got := FormatRFC5424(record, 13, "build host", "goneat", "42")
+	want := `<108>1 2026-03-04T05:06:07.123456Z buildhost goneat 42 open [pathfinder@32473 id="abc" operation="open" path="dir/\"quoted\]\\x" loader="zip" status="denied" code="403" duration_ms="0" flags="SOC2_ACCESS_DENIED"] open dir/"quoted]\x denied: path traversal`
+	if got != want {
+		t.Errorf("FormatRFC5424() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestParseSyslogTarget(t *testing.T) {
+	tests := []struct {
+		target, network, address string
+		wantErr                  bool
+	}{
+		{"udp://127.0.0.1:514", "udp", "127.0.0.1:514", false},
+		{"tcp://collector:6514", "tcp", "collector:6514", false},
+		{"unix:///dev/log", "unix", "/dev/log", false},
+		{"127.0.0.1:514", "", "", true},
+		{"http://collector", "", "", true},
+		{"tcp://", "", "", true},
+	}
+	for _, tt := range tests {
+		network, address, err := ParseSyslogTarget(tt.target)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSyslogTarget(%q) error = %v", tt.target, err)
+			continue
+		}
+		if network != tt.network || address != tt.address {
+			t.Errorf("ParseSyslogTarget(%q) = %s %s", tt.target, network, address)
+		}
+	}
+}
+
+func TestAuditSyslogSink_UDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("udp unavailable: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	sink, err := NewAuditSyslogSink(AuditSyslogOptions{Network: "udp", Address: conn.LocalAddr().String(), Hostname: "host"})
+	if err != nil {
+		t.Fatalf("NewAuditSyslogSink() error = %v", err)
+	}
+	defer func() { _ = sink.Close() }()
+	if err := sink.Write(testAuditRecord("u1", time.Now(), OpOpen, "success")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("read datagram: %v", err)
+	}
+	msg := string(buf[:n])
+	if !strings.HasPrefix(msg, "<110>1 ") || !strings.Contains(msg, ` id="u1"`) {
+		t.Errorf("datagram = %q", msg)
+	}
+}
+
+func TestAuditSyslogSink_Stream(t *testing.T) {
+	shortDir, err := os.MkdirTemp("", "sl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(shortDir) }()
+
+	for _, network := range []string{"tcp", "unix"} {
+		t.Run(network, func(t *testing.T) {
+			address := "127.0.0.1:0"
+			if network == "unix" {
+				address = filepath.Join(shortDir, "log.sock")
+			}
+			ln, err := net.Listen(network, address)
+			if err != nil {
+				t.Skipf("%s listener unavailable: %v", network, err)
+			}
+			defer func() { _ = ln.Close() }()
+
+			received := make(chan []string, 1)
+			go func() {
+				c, err := ln.Accept()
+				if err != nil {
+					received <- nil
+					return
+				}
+				defer func() { _ = c.Close() }()
+				_ = c.SetReadDeadline(time.Now().Add(2 * time.Second))
+				reader := bufio.NewReader(c)
+				var msgs []string
+				for len(msgs) < 2 {
+					// Octet-counting framing: "<len> <message>"
+					prefix, err := reader.ReadString(' ')
+					if err != nil {
+						break
+					}
+					size, err := strconv.Atoi(strings.TrimSpace(prefix))
+					if err != nil {
+						break
+					}
+					body := make([]byte, size)
+					if _, err := io.ReadFull(reader, body); err != nil {
+						break
+					}
+					msgs = append(msgs, string(body))
+				}
+				received <- msgs
+			}()
+
+			sink, err := NewAuditSyslogSink(AuditSyslogOptions{Network: network, Address: ln.Addr().String()})
+			if err != nil {
+				t.Fatalf("NewAuditSyslogSink() error = %v", err)
+			}
+			defer func() { _ = sink.Close() }()
+			_ = sink.Write(testAuditRecord("s1", time.Now(), OpOpen, "success"))
+			_ = sink.Write(testAuditRecord("s2", time.Now(), OpOpen, "failure"))
+
+			msgs := <-received
+			if len(msgs) != 2 {
+				t.Fatalf("received %d framed messages, want 2: %q", len(msgs), msgs)
+			}
+			if !strings.HasPrefix(msgs[0], "<110>1 ") || !strings.HasPrefix(msgs[1], "<107>1 ") {
+				t.Errorf("unexpected priorities: %q", msgs)
+			}
+		})
+	}
+}
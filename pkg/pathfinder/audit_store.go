@@ -0,0 +1,329 @@
+package pathfinder
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	auditStoreDirName     = "store"
+	auditSegmentExtension = ".seg"
+)
+
+// AuditStoreOptions configures a segmented audit store
+type AuditStoreOptions struct {
+	// Dir holds the segment files (default DefaultAuditDir/store)
+	Dir string
+	// SegmentBytes seals the active segment before it grows past this size (default 4MB)
+	SegmentBytes int64
+	// RetentionDays drops sealed segments whose newest record is older than
+	// this (default 90). The active segment is sealed once its oldest record
+	// is past the window, so expired records do not wait for a full segment.
+	RetentionDays int
+	// Now overrides the clock used for retention (tests)
+	Now func() time.Time
+}
+
+// AuditStoreDir returns where the segmented store lives inside an audit directory
+func AuditStoreDir(auditDir string) string {
+	return filepath.Join(auditDir, auditStoreDirName)
+}
+
+// auditSegment describes one segment file and the time span of its records
+type auditSegment struct {
+	seq    int
+	path   string
+	size   int64
+	first  time.Time
+	last   time.Time
+	sealed bool
+}
+
+// AuditStore is an append-only store of audit records split into numbered
+// JSONL segments. Records are never rewritten: the active segment is appended
+// to and fsynced per record, full or expiring segments are sealed, and
+// retention removes whole sealed segments once every record in them has
+// expired.
+type AuditStore struct {
+	mu       sync.Mutex
+	opts     AuditStoreOptions
+	segments []*auditSegment
+	active   *os.File
+	// seq is the highest segment number used, kept when retention removes
+	// every segment so numbering never restarts
+	seq int
+}
+
+// NewAuditStore opens the store, indexes existing segments and enforces retention
+func NewAuditStore(opts AuditStoreOptions) (*AuditStore, error) {
+	if opts.Dir == "" {
+		opts.Dir = AuditStoreDir(DefaultAuditDir)
+	}
+	if opts.SegmentBytes <= 0 {
+		opts.SegmentBytes = 4 * 1024 * 1024
+	}
+	if opts.RetentionDays <= 0 {
+		opts.RetentionDays = 90
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if err := os.MkdirAll(opts.Dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create audit store: %w", err)
+	}
+
+	segments, err := listAuditSegments(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	store := &AuditStore{opts: opts, segments: segments}
+	for i, segment := range segments {
+		if i == len(segments)-1 {
+			if err := repairTornTail(segment.path); err != nil {
+				return nil, err
+			}
+		} else {
+			segment.sealed = true
+		}
+		if err := segment.scan(); err != nil {
+			return nil, err
+		}
+		store.seq = segment.seq
+	}
+	store.sealExpired()
+	if _, err := store.enforceRetention(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// Write appends a record to the active segment, sealing it first if full
+func (s *AuditStore) Write(record AuditRecord) error {
+	line, err := marshalAuditLine(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sealExpired()
+	segment := s.current()
+	if segment == nil || segment.sealed || (segment.size > 0 && segment.size+int64(len(line)) > s.opts.SegmentBytes) {
+		if segment, err = s.roll(); err != nil {
+			return err
+		}
+	}
+	if s.active == nil {
+		file, err := os.OpenFile(segment.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) // #nosec G304 -- segment path is built from the store dir
+		if err != nil {
+			return fmt.Errorf("failed to open audit segment: %w", err)
+		}
+		s.active = file
+	}
+
+	n, err := s.active.Write(line)
+	segment.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to append audit record: %w", err)
+	}
+	if err := s.active.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit segment: %w", err)
+	}
+	segment.observe(record.Timestamp)
+	return nil
+}
+
+// Query returns stored records matching query, newest first. Records past the
+// retention window are never returned, even before their segment is removed.
+func (s *AuditStore) Query(query AuditQuery) ([]AuditRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := s.cutoff()
+	var records []AuditRecord
+	for _, segment := range s.segments {
+		if !segment.last.IsZero() && segment.last.Before(cutoff) {
+			continue
+		}
+		if query.StartTime != nil && !segment.last.IsZero() && segment.last.Before(*query.StartTime) {
+			continue
+		}
+		if query.EndTime != nil && !segment.first.IsZero() && segment.first.After(*query.EndTime) {
+			continue
+		}
+		loaded, err := readAuditJSONL(segment.path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range loaded {
+			if !record.Timestamp.Before(cutoff) {
+				records = append(records, record)
+			}
+		}
+	}
+	return filterAuditRecords(records, query), nil
+}
+
+// EnforceRetention seals the active segment once its oldest record has
+// expired, removes sealed segments whose records have all expired and returns
+// how many were removed
+func (s *AuditStore) EnforceRetention() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sealExpired() {
+		if err := s.closeActive(); err != nil {
+			return 0, err
+		}
+	}
+	return s.enforceRetention()
+}
+
+// Close syncs and closes the active segment
+func (s *AuditStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active == nil {
+		return nil
+	}
+	err := errors.Join(s.active.Sync(), s.active.Close())
+	s.active = nil
+	return err
+}
+
+func (s *AuditStore) cutoff() time.Time {
+	return s.opts.Now().AddDate(0, 0, -s.opts.RetentionDays)
+}
+
+func (s *AuditStore) current() *auditSegment {
+	if len(s.segments) == 0 {
+		return nil
+	}
+	return s.segments[len(s.segments)-1]
+}
+
+// sealExpired seals the active segment once its oldest record is past the
+// retention window, so retention can remove it when the rest expires too.
+// It reports whether the segment was sealed.
+func (s *AuditStore) sealExpired() bool {
+	segment := s.current()
+	if segment == nil || segment.sealed || segment.first.IsZero() || !segment.first.Before(s.cutoff()) {
+		return false
+	}
+	segment.sealed = true
+	return true
+}
+
+func (s *AuditStore) closeActive() error {
+	if s.active == nil {
+		return nil
+	}
+	err := errors.Join(s.active.Sync(), s.active.Close())
+	s.active = nil
+	if err != nil {
+		return fmt.Errorf("failed to seal audit segment: %w", err)
+	}
+	return nil
+}
+
+// roll seals the active segment and starts the next one
+func (s *AuditStore) roll() (*auditSegment, error) {
+	if segment := s.current(); segment != nil {
+		segment.sealed = true
+	}
+	if err := s.closeActive(); err != nil {
+		return nil, err
+	}
+
+	s.seq++
+	segment := &auditSegment{
+		seq:  s.seq,
+		path: filepath.Join(s.opts.Dir, fmt.Sprintf("%016d%s", s.seq, auditSegmentExtension)),
+	}
+	s.segments = append(s.segments, segment)
+	if _, err := s.enforceRetention(); err != nil {
+		return nil, err
+	}
+	return segment, nil
+}
+
+func (s *AuditStore) enforceRetention() (int, error) {
+	cutoff := s.cutoff()
+	removed := 0
+	kept := s.segments[:0]
+	for _, segment := range s.segments {
+		if segment.sealed && !segment.last.IsZero() && segment.last.Before(cutoff) {
+			if err := os.Remove(segment.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return removed, fmt.Errorf("failed to remove expired audit segment: %w", err)
+			}
+			removed++
+			continue
+		}
+		kept = append(kept, segment)
+	}
+	s.segments = kept
+	return removed, nil
+}
+
+// scan records the size and time span of an existing segment
+func (seg *auditSegment) scan() error {
+	info, err := os.Stat(seg.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat audit segment: %w", err)
+	}
+	seg.size = info.Size()
+	records, err := readAuditJSONL(seg.path)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		seg.observe(record.Timestamp)
+	}
+	return nil
+}
+
+func (seg *auditSegment) observe(ts time.Time) {
+	if seg.first.IsZero() || ts.Before(seg.first) {
+		seg.first = ts
+	}
+	if ts.After(seg.last) {
+		seg.last = ts
+	}
+}
+
+// listAuditSegments returns the segments in dir ordered by sequence number
+func listAuditSegments(dir string) ([]*auditSegment, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit store: %w", err)
+	}
+
+	var segments []*auditSegment
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, auditSegmentExtension) {
+			continue
+		}
+		seq, err := strconv.Atoi(strings.TrimSuffix(name, auditSegmentExtension))
+		if err != nil {
+			continue
+		}
+		segments = append(segments, &auditSegment{seq: seq, path: filepath.Join(dir, name)})
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].seq < segments[j].seq })
+	return segments, nil
+}
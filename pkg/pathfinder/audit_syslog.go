@@ -0,0 +1,262 @@
+package pathfinder
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// syslogFacilityLogAudit is the RFC 5424 "log audit" facility
+const syslogFacilityLogAudit = 13
+
+// syslogEnterpriseID is the private enterprise number reserved for
+// documentation (RFC 5612), used to scope goneat's structured data
+const syslogEnterpriseID = 32473
+
+const syslogTimestampLayout = "2006-01-02T15:04:05.000000Z07:00"
+
+// AuditSyslogOptions configures an RFC 5424 syslog sink
+type AuditSyslogOptions struct {
+	// Network is udp, tcp, unix (stream or datagram, detected) or unixgram
+	Network string
+	// Address is host:port for udp/tcp or a socket path for unix
+	Address string
+	// Facility is the syslog facility code (default 13, log audit)
+	Facility int
+	// AppName identifies the sender (default "goneat")
+	AppName string
+	// Hostname overrides the reported host (default os.Hostname)
+	Hostname string
+	// Timeout bounds dialing and each write (default 5s)
+	Timeout time.Duration
+}
+
+// AuditSyslogSink forwards records to a syslog collector as RFC 5424 messages.
+// Stream transports use octet-counting framing (RFC 6587); datagram transports
+// send one message per packet. A failed write reconnects once before erroring.
+type AuditSyslogSink struct {
+	mu     sync.Mutex
+	opts   AuditSyslogOptions
+	conn   net.Conn
+	stream bool
+	procID string
+}
+
+// ParseSyslogTarget splits a target such as udp://127.0.0.1:514,
+// tcp://collector:6514 or unix:///dev/log into network and address
+func ParseSyslogTarget(target string) (string, string, error) {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme == "" {
+		return "", "", fmt.Errorf("invalid syslog target %q (want udp://host:port, tcp://host:port or unix:///path)", target)
+	}
+	switch u.Scheme {
+	case "udp", "tcp":
+		if u.Host == "" {
+			return "", "", fmt.Errorf("syslog target %q is missing host:port", target)
+		}
+		return u.Scheme, u.Host, nil
+	case "unix", "unixgram":
+		if u.Path == "" {
+			return "", "", fmt.Errorf("syslog target %q is missing a socket path", target)
+		}
+		return u.Scheme, u.Path, nil
+	default:
+		return "", "", fmt.Errorf("unsupported syslog network %q", u.Scheme)
+	}
+}
+
+// NewAuditSyslogSink connects to the syslog collector
+func NewAuditSyslogSink(opts AuditSyslogOptions) (*AuditSyslogSink, error) {
+	if opts.Address == "" {
+		return nil, fmt.Errorf("syslog address is required")
+	}
+	if opts.Network == "" {
+		opts.Network = "udp"
+	}
+	if opts.Facility == 0 {
+		opts.Facility = syslogFacilityLogAudit
+	}
+	if opts.Facility < 0 || opts.Facility > 23 {
+		return nil, fmt.Errorf("invalid syslog facility %d", opts.Facility)
+	}
+	if opts.AppName == "" {
+		opts.AppName = "goneat"
+	}
+	if opts.Hostname == "" {
+		opts.Hostname, _ = os.Hostname()
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	sink := &AuditSyslogSink{opts: opts, procID: strconv.Itoa(os.Getpid())}
+	if err := sink.connect(); err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+func (s *AuditSyslogSink) connect() error {
+	var networks []string
+	switch s.opts.Network {
+	case "udp", "tcp", "unixgram":
+		networks = []string{s.opts.Network}
+	case "unix":
+		// /dev/log is usually a datagram socket; fall back to a stream socket
+		networks = []string{"unixgram", "unix"}
+	default:
+		return fmt.Errorf("unsupported syslog network %q", s.opts.Network)
+	}
+
+	var lastErr error
+	for _, network := range networks {
+		conn, err := net.DialTimeout(network, s.opts.Address, s.opts.Timeout)
+		if err == nil {
+			s.conn = conn
+			s.stream = network == "tcp" || network == "unix"
+			return nil
+		}
+		lastErr = err
+	}
+	return fmt.Errorf("failed to connect to syslog %s://%s: %w", s.opts.Network, s.opts.Address, lastErr)
+}
+
+// Write sends a record to the collector
+func (s *AuditSyslogSink) Write(record AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		if err := s.connect(); err != nil {
+			return err
+		}
+	}
+	message := FormatRFC5424(record, s.opts.Facility, s.opts.Hostname, s.opts.AppName, s.procID)
+	if err := s.send(message); err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		if err := s.connect(); err != nil {
+			return err
+		}
+		if err := s.send(message); err != nil {
+			return fmt.Errorf("failed to send audit record to syslog: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *AuditSyslogSink) send(message string) error {
+	if s.stream {
+		message = strconv.Itoa(len(message)) + " " + message
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(s.opts.Timeout)); err != nil {
+		return err
+	}
+	_, err := s.conn.Write([]byte(message))
+	return err
+}
+
+// Close closes the collector connection
+func (s *AuditSyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// FormatRFC5424 renders a record as an RFC 5424 syslog message. The record
+// fields travel as structured data so collectors can index them.
+func FormatRFC5424(record AuditRecord, facility int, hostname, appName, procID string) string {
+	timestamp := "-"
+	if !record.Timestamp.IsZero() {
+		timestamp = record.Timestamp.Format(syslogTimestampLayout)
+	}
+
+	params := [][2]string{
+		{"id", record.ID},
+		{"operation", string(record.Operation)},
+		{"path", record.Path},
+		{"loader", record.SourceLoader},
+		{"status", record.Result.Status},
+		{"code", strconv.Itoa(record.Result.Code)},
+		{"duration_ms", strconv.FormatInt(record.Duration.Milliseconds(), 10)},
+	}
+	if record.Constraint != "" {
+		params = append(params, [2]string{"constraint", record.Constraint})
+	}
+	if len(record.SecurityFlags) > 0 {
+		params = append(params, [2]string{"flags", strings.Join(record.SecurityFlags, ",")})
+	}
+
+	var sd strings.Builder
+	fmt.Fprintf(&sd, "[pathfinder@%d", syslogEnterpriseID)
+	for _, param := range params {
+		if param[1] == "" {
+			continue
+		}
+		fmt.Fprintf(&sd, " %s=\"%s\"", param[0], escapeSDParam(param[1]))
+	}
+	sd.WriteString("]")
+
+	msg := fmt.Sprintf("%s %s %s", record.Operation, record.Path, record.Result.Status)
+	if record.Result.Message != "" {
+		msg += ": " + record.Result.Message
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s %s %s %s %s %s",
+		facility*8+syslogSeverity(record.Result.Status),
+		timestamp,
+		syslogHeaderField(hostname, 255),
+		syslogHeaderField(appName, 48),
+		syslogHeaderField(procID, 128),
+		syslogHeaderField(string(record.Operation), 32),
+		sd.String(),
+		msg,
+	)
+}
+
+// syslogSeverity maps an operation result to a syslog severity
+func syslogSeverity(status string) int {
+	switch status {
+	case "success":
+		return 6 // informational
+	case "denied", "rate_limited":
+		return 4 // warning
+	default:
+		return 3 // error
+	}
+}
+
+// syslogHeaderField returns "-" for empty values and strips characters
+// RFC 5424 does not allow in header fields (printable US-ASCII only)
+func syslogHeaderField(value string, maxLen int) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r > 32 && r < 127 {
+			b.WriteRune(r)
+		}
+	}
+	out := b.String()
+	if len(out) > maxLen {
+		out = out[:maxLen]
+	}
+	if out == "" {
+		return "-"
+	}
+	return out
+}
+
+// escapeSDParam escapes the characters RFC 5424 reserves in PARAM-VALUE
+func escapeSDParam(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
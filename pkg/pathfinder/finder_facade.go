@@ -41,6 +41,8 @@ type FinderConfig struct {
 	CacheTTL     time.Duration
 	Constraint   PathConstraint
 	LoaderType   string
+	// AuditLogger receives the per-file records of non-local loaders.
+	AuditLogger AuditLogger
 }
 
 // FindQuery specifies the parameters for discovery.
@@ -60,6 +62,8 @@ type FindQuery struct {
 	SchemaCategories      []string
 	IncludeSchemaMetadata bool
 	IncludeHidden         bool
+	// Ref selects the revision to read for the git loader (default HEAD).
+	Ref string
 }
 
 // FinderFacade provides a simplified API on top of the full PathFinder interface.
@@ -118,7 +122,15 @@ func (f *FinderFacade) Find(query FindQuery) ([]PathResult, error) {
 	}
 
 	opts := f.buildDiscoveryOptions(query)
-	files, err := f.pf.DiscoverFiles(query.Root, opts)
+	loaderType := f.effectiveLoaderType(query)
+	var loader SourceLoader
+	var files []string
+	var err error
+	if loaderType == "local" {
+		files, err = f.pf.DiscoverFiles(query.Root, opts)
+	} else {
+		loader, files, err = f.listFromLoader(loaderType, query, opts)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -136,11 +148,19 @@ func (f *FinderFacade) Find(query FindQuery) ([]PathResult, error) {
 			RelativePath: normalizedRel,
 			SourcePath:   toSlash(absPath),
 			LogicalPath:  normalizedRel,
-			LoaderType:   f.effectiveLoaderType(query),
+			LoaderType:   loaderType,
+		}
+		if locator, ok := loader.(SourceLocator); ok {
+			result.SourcePath = locator.SourcePath(normalizedRel)
 		}
 
 		if query.SchemaMode && detector != nil {
-			snippet, err := readSnippet(absPath, schemaPeekLimit)
+			var snippet []byte
+			if loader != nil {
+				snippet, err = readLoaderSnippet(loader, normalizedRel, schemaPeekLimit)
+			} else {
+				snippet, err = readSnippet(absPath, schemaPeekLimit)
+			}
 			if err != nil {
 				continue
 			}
@@ -220,6 +240,72 @@ func (f *FinderFacade) buildDiscoveryOptions(query FindQuery) DiscoveryOptions {
 	return opts
 }
 
+// listFromLoader lists files through a registered SourceLoader (archives, git
+// trees), applying the depth, hidden and skip-dir filters DiscoverFiles handles
+// for local roots. The root itself is still a local path validated by Find.
+func (f *FinderFacade) listFromLoader(loaderType string, query FindQuery, opts DiscoveryOptions) (SourceLoader, []string, error) {
+	loader, err := f.pf.CreateLoader(loaderType, LoaderConfig{
+		Type:    loaderType,
+		Enabled: true,
+		Config: map[string]interface{}{
+			"path":            query.Root,
+			"ref":             query.Ref,
+			"follow_symlinks": query.FollowSymlinks,
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if f.config.AuditLogger != nil {
+		loader.SetAuditLogger(f.config.AuditLogger)
+	}
+	if err := loader.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	listed, err := loader.ListFiles("", opts.IncludePatterns, opts.ExcludePatterns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := listed[:0]
+	for _, rel := range listed {
+		if opts.MaxDepth > 0 && calculateDepth(rel, false) > opts.MaxDepth {
+			continue
+		}
+		if !opts.IncludeHidden && isHiddenEntry(rel) {
+			continue
+		}
+		if inSkippedDir(rel, opts.SkipDirs) {
+			continue
+		}
+		files = append(files, rel)
+	}
+	return loader, files, nil
+}
+
+func isHiddenEntry(rel string) bool {
+	for _, part := range strings.Split(rel, "/") {
+		if strings.HasPrefix(part, ".") && len(part) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func inSkippedDir(rel string, skipDirs []string) bool {
+	dir := path.Dir(rel)
+	if dir == "." {
+		return false
+	}
+	for _, skip := range skipDirs {
+		if skip != "" && strings.Contains(dir, skip) {
+			return true
+		}
+	}
+	return false
+}
+
 func (f *FinderFacade) effectiveLoaderType(query FindQuery) string {
 	if f.config.LoaderType != "" {
 		return f.config.LoaderType
@@ -239,17 +325,29 @@ func passthroughTransform(result PathResult) PathResult {
 }
 
 func readSnippet(path string, limit int) ([]byte, error) {
-	if limit <= 0 {
-		limit = schemaPeekLimit
-	}
 	file, err := os.Open(path) // #nosec G304 -- path validated by finder discovery process
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = file.Close() }()
+	return readSnippetFrom(file, limit)
+}
+
+func readLoaderSnippet(loader SourceLoader, rel string, limit int) ([]byte, error) {
+	reader, err := loader.Open(rel)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	return readSnippetFrom(reader, limit)
+}
 
+func readSnippetFrom(reader io.Reader, limit int) ([]byte, error) {
+	if limit <= 0 {
+		limit = schemaPeekLimit
+	}
 	buf := make([]byte, limit)
-	n, err := io.ReadFull(file, buf)
+	n, err := io.ReadFull(reader, buf)
 	if err != nil {
 		if err == io.ErrUnexpectedEOF || err == io.EOF {
 			if n <= 0 {
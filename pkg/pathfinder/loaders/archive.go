@@ -0,0 +1,299 @@
+package loaders
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fulmenhq/goneat/pkg/pathfinder"
+)
+
+// Archive formats recognised by ArchiveLoader
+const (
+	FormatTar   = "tar"
+	FormatTarGz = "tar.gz"
+	FormatZip   = "zip"
+)
+
+// Tar entries up to tarCacheEntryBytes are kept in memory while indexing, up
+// to tarCacheBytes per archive, so opening them does not rescan the stream.
+const (
+	tarCacheEntryBytes = 1 << 20
+	tarCacheBytes      = 64 << 20
+)
+
+// ArchiveLoader reads files from tar, tar.gz and zip archives without extracting them.
+// The archive is indexed when the loader is created; entries with traversal or
+// absolute names reject the whole archive.
+type ArchiveLoader struct {
+	treeLoader
+	archivePath string
+	format      string
+}
+
+func init() {
+	// "tar" covers both plain and gzip-compressed tarballs
+	for _, family := range []string{FormatTar, FormatZip} {
+		pathfinder.RegisterLoader(family, func(cfg pathfinder.LoaderConfig) (pathfinder.SourceLoader, error) {
+			loader, err := NewArchiveLoader(configString(cfg, "path"), configBool(cfg, "follow_symlinks"))
+			if err != nil {
+				return nil, err
+			}
+			if (family == FormatZip) != (loader.format == FormatZip) {
+				return nil, fmt.Errorf("%s is a %s archive, not %s", loader.archivePath, loader.format, family)
+			}
+			return loader, nil
+		})
+	}
+}
+
+// NewArchiveLoader opens and indexes the archive at archivePath. The format is
+// detected from the file content.
+func NewArchiveLoader(archivePath string, followSymlinks bool) (*ArchiveLoader, error) {
+	if archivePath == "" {
+		return nil, fmt.Errorf("archive path is required")
+	}
+	format, err := detectArchiveFormat(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	loader := &ArchiveLoader{
+		treeLoader:  newTreeLoader(format),
+		archivePath: archivePath,
+		format:      format,
+	}
+	loader.followSymlinks = followSymlinks
+
+	if format == FormatZip {
+		err = loader.indexZip()
+	} else {
+		err = loader.indexTar()
+	}
+	if err != nil {
+		return nil, &LoaderError{
+			Type:    "ArchiveError",
+			Message: "Failed to index archive",
+			Path:    archivePath,
+			Cause:   err,
+		}
+	}
+	return loader, nil
+}
+
+// SourceType returns the loader type
+func (a *ArchiveLoader) SourceType() string {
+	return a.format
+}
+
+// SourceDescription returns a human-readable description
+func (a *ArchiveLoader) SourceDescription() string {
+	return fmt.Sprintf("Archive loader (%s: %s)", a.format, a.archivePath)
+}
+
+// SourcePath returns the loader-native location of a file, e.g. "dist/schemas.tar.gz!/v1/a.json"
+func (a *ArchiveLoader) SourcePath(rel string) string {
+	return a.archivePath + "!/" + rel
+}
+
+// Validate checks that the archive is still readable
+func (a *ArchiveLoader) Validate() error {
+	info, err := os.Stat(a.archivePath)
+	if err != nil {
+		return fmt.Errorf("archive validation failed: %w", err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("archive path must be a file")
+	}
+	return nil
+}
+
+// detectArchiveFormat sniffs the archive format from its magic bytes
+func detectArchiveFormat(archivePath string) (string, error) {
+	file, err := os.Open(archivePath) // #nosec G304 -- archive path is chosen by the caller
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	header := make([]byte, 512)
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", fmt.Errorf("failed to read %s: %w", archivePath, err)
+	}
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")), bytes.HasPrefix(header, []byte("PK\x05\x06")):
+		return FormatZip, nil
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b}):
+		return FormatTarGz, nil
+	case n >= 262 && bytes.HasPrefix(header[257:], []byte("ustar")):
+		return FormatTar, nil
+	}
+	// Old-style (v7) tarballs have no magic; accept them if a header parses
+	if _, err := tar.NewReader(bytes.NewReader(header)).Next(); err == nil {
+		return FormatTar, nil
+	}
+	return "", fmt.Errorf("%s is not a tar, tar.gz or zip archive", archivePath)
+}
+
+// openTar returns a tar reader over the archive and a closer for the underlying file
+func (a *ArchiveLoader) openTar() (*tar.Reader, io.Closer, error) {
+	file, err := os.Open(a.archivePath) // #nosec G304 -- archive path is chosen by the caller
+	if err != nil {
+		return nil, nil, err
+	}
+	if a.format != FormatTarGz {
+		return tar.NewReader(bufio.NewReader(file)), file, nil
+	}
+	gz, err := gzip.NewReader(bufio.NewReader(file))
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, err
+	}
+	return tar.NewReader(gz), file, nil
+}
+
+func (a *ArchiveLoader) indexTar() error {
+	tr, closer, err := a.openTar()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = closer.Close() }()
+
+	var cached int64
+	for ordinal := 0; ; ordinal++ {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeReg, tar.TypeGNUSparse:
+			if hdr.Size <= tarCacheEntryBytes && cached+hdr.Size <= tarCacheBytes {
+				data, readErr := io.ReadAll(io.LimitReader(tr, hdr.Size))
+				if readErr != nil {
+					return readErr
+				}
+				cached += int64(len(data))
+				err = a.addFile(hdr.Name, hdr.Size, func() (io.ReadCloser, error) {
+					return io.NopCloser(bytes.NewReader(data)), nil
+				})
+				break
+			}
+			err = a.addFile(hdr.Name, hdr.Size, func() (io.ReadCloser, error) {
+				return a.openTarEntry(ordinal)
+			})
+		case tar.TypeSymlink:
+			err = a.addLink(hdr.Name, hdr.Linkname, false)
+		case tar.TypeLink:
+			err = a.addLink(hdr.Name, hdr.Linkname, true)
+		default:
+			// Directories, devices and FIFOs carry no readable content
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// openTarEntry rescans the archive up to the entry at ordinal. Tar streams (and
+// gzip in particular) are not seekable, so entries too large to cache during
+// indexing cannot be opened directly.
+func (a *ArchiveLoader) openTarEntry(ordinal int) (io.ReadCloser, error) {
+	tr, closer, err := a.openTar()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i <= ordinal; i++ {
+		if _, err := tr.Next(); err != nil {
+			_ = closer.Close()
+			return nil, fmt.Errorf("archive changed since it was indexed: %w", err)
+		}
+	}
+	return readCloser{Reader: tr, Closer: closer}, nil
+}
+
+func (a *ArchiveLoader) indexZip() error {
+	zr, err := zip.OpenReader(a.archivePath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = zr.Close() }()
+
+	for index, f := range zr.File {
+		mode := f.Mode()
+		switch {
+		case mode.IsRegular():
+			err = a.addFile(f.Name, int64(f.UncompressedSize64), func() (io.ReadCloser, error) {
+				return a.openZipEntry(index)
+			})
+		case mode&os.ModeSymlink != 0:
+			target, readErr := readZipLink(f)
+			if readErr != nil {
+				return readErr
+			}
+			err = a.addLink(f.Name, target, false)
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ArchiveLoader) openZipEntry(index int) (io.ReadCloser, error) {
+	zr, err := zip.OpenReader(a.archivePath)
+	if err != nil {
+		return nil, err
+	}
+	if index >= len(zr.File) {
+		_ = zr.Close()
+		return nil, fmt.Errorf("archive changed since it was indexed")
+	}
+	rc, err := zr.File[index].Open()
+	if err != nil {
+		_ = zr.Close()
+		return nil, err
+	}
+	return readCloser{Reader: rc, Closer: closerFunc(func() error {
+		return errors.Join(rc.Close(), zr.Close())
+	})}, nil
+}
+
+// readZipLink reads a symlink target, which zip stores as the entry content
+func readZipLink(f *zip.File) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = rc.Close() }()
+	target, err := io.ReadAll(io.LimitReader(rc, 4096))
+	if err != nil {
+		return "", err
+	}
+	return string(target), nil
+}
+
+// readCloser pairs a reader with the closer of the resources behind it
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
@@ -0,0 +1,347 @@
+package loaders
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/pathfinder"
+)
+
+type archiveEntry struct {
+	name     string
+	body     string
+	linkname string
+	typeflag byte
+}
+
+var testArchiveEntries = []archiveEntry{
+	{name: "schemas/", typeflag: tar.TypeDir},
+	{name: "schemas/a.json", body: `{"type":"object"}`},
+	{name: "schemas/v1/b.yaml", body: "type: string\n"},
+	{name: "README.md", body: "# release\n"},
+	{name: "schemas/alias.json", linkname: "a.json", typeflag: tar.TypeSymlink},
+	{name: "schemas/escape.json", linkname: "../../etc/passwd", typeflag: tar.TypeSymlink},
+}
+
+func writeTestTar(t *testing.T, path string, gz bool, entries []archiveEntry) {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create archive: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var w io.Writer = file
+	if gz {
+		gzw := gzip.NewWriter(file)
+		defer func() { _ = gzw.Close() }()
+		w = gzw
+	}
+	tw := tar.NewWriter(w)
+	defer func() { _ = tw.Close() }()
+
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0o644, Size: int64(len(e.body)), Typeflag: e.typeflag, Linkname: e.linkname}
+		if e.typeflag == 0 {
+			hdr.Typeflag = tar.TypeReg
+		}
+		if e.typeflag != tar.TypeReg && e.typeflag != 0 {
+			hdr.Size = 0
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("write header %s: %v", e.name, err)
+		}
+		if hdr.Size > 0 {
+			if _, err := tw.Write([]byte(e.body)); err != nil {
+				t.Fatalf("write body %s: %v", e.name, err)
+			}
+		}
+	}
+}
+
+func writeTestZip(t *testing.T, path string, entries []archiveEntry) {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create archive: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	zw := zip.NewWriter(file)
+	defer func() { _ = zw.Close() }()
+	for _, e := range entries {
+		hdr := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		body := e.body
+		switch e.typeflag {
+		case tar.TypeDir:
+			hdr.SetMode(os.ModeDir | 0o755)
+		case tar.TypeSymlink:
+			hdr.SetMode(os.ModeSymlink | 0o777)
+			body = e.linkname
+		default:
+			hdr.SetMode(0o644)
+		}
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatalf("create entry %s: %v", e.name, err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatalf("write entry %s: %v", e.name, err)
+		}
+	}
+}
+
+func readAll(t *testing.T, loader pathfinder.SourceLoader, path string) string {
+	t.Helper()
+	rc, err := loader.Open(path)
+	if err != nil {
+		t.Fatalf("Open(%q) error = %v", path, err)
+	}
+	defer func() { _ = rc.Close() }()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read %q: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestArchiveLoader_Formats(t *testing.T) {
+	dir := t.TempDir()
+	archives := map[string]string{
+		FormatTar:   filepath.Join(dir, "release.tar"),
+		FormatTarGz: filepath.Join(dir, "release.tgz"),
+		FormatZip:   filepath.Join(dir, "release.zip"),
+	}
+	writeTestTar(t, archives[FormatTar], false, testArchiveEntries)
+	writeTestTar(t, archives[FormatTarGz], true, testArchiveEntries)
+	writeTestZip(t, archives[FormatZip], testArchiveEntries)
+
+	for format, path := range archives {
+		t.Run(format, func(t *testing.T) {
+			loader, err := NewArchiveLoader(path, false)
+			if err != nil {
+				t.Fatalf("NewArchiveLoader() error = %v", err)
+			}
+			if loader.SourceType() != format {
+				t.Errorf("SourceType() = %q, want %q", loader.SourceType(), format)
+			}
+			if err := loader.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+
+			files, err := loader.ListFiles("", nil, nil)
+			if err != nil {
+				t.Fatalf("ListFiles() error = %v", err)
+			}
+			want := []string{"README.md", "schemas/a.json", "schemas/v1/b.yaml"}
+			if !reflect.DeepEqual(files, want) {
+				t.Errorf("ListFiles() = %v, want %v", files, want)
+			}
+
+			files, err = loader.ListFiles("schemas", []string{"**/*.yaml"}, nil)
+			if err != nil {
+				t.Fatalf("ListFiles(schemas) error = %v", err)
+			}
+			if !reflect.DeepEqual(files, []string{"v1/b.yaml"}) {
+				t.Errorf("ListFiles(schemas) = %v, want [v1/b.yaml]", files)
+			}
+
+			if got := readAll(t, loader, "schemas/v1/b.yaml"); got != "type: string\n" {
+				t.Errorf("Open() content = %q", got)
+			}
+			if got := readAll(t, loader, "./schemas/a.json"); got != `{"type":"object"}` {
+				t.Errorf("Open() content = %q", got)
+			}
+			if !strings.HasSuffix(loader.SourcePath("schemas/a.json"), "!/schemas/a.json") {
+				t.Errorf("SourcePath() = %q", loader.SourcePath("schemas/a.json"))
+			}
+		})
+	}
+}
+
+func TestArchiveLoader_TarCachesSmallEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "release.tgz")
+	large := strings.Repeat("x", tarCacheEntryBytes+1)
+	writeTestTar(t, path, true, []archiveEntry{
+		{name: "small.json", body: `{"type":"object"}`},
+		{name: "large.txt", body: large},
+	})
+	loader, err := NewArchiveLoader(path, false)
+	if err != nil {
+		t.Fatalf("NewArchiveLoader() error = %v", err)
+	}
+	if got := readAll(t, loader, "large.txt"); got != large {
+		t.Errorf("Open(large.txt) returned %d bytes", len(got))
+	}
+
+	// Small entries are served from the index without reading the archive again
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := readAll(t, loader, "small.json"); got != `{"type":"object"}` {
+		t.Errorf("Open(small.json) content = %q", got)
+	}
+	if _, err := loader.Open("large.txt"); err == nil {
+		t.Errorf("expected large entry to rescan the removed archive")
+	}
+}
+
+func TestArchiveLoader_Safety(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "release.tar.gz")
+	writeTestTar(t, path, true, testArchiveEntries)
+
+	loader, err := NewArchiveLoader(path, false)
+	if err != nil {
+		t.Fatalf("NewArchiveLoader() error = %v", err)
+	}
+
+	for _, p := range []string{"../release.tar.gz", "/etc/passwd", "schemas/../../x"} {
+		if _, err := loader.Open(p); err == nil {
+			t.Errorf("Open(%q) should be denied", p)
+		}
+	}
+	if _, err := loader.ListFiles("../", nil, nil); err == nil {
+		t.Error("ListFiles(../) should be denied")
+	}
+
+	// Symlinks are neither listed nor opened by default
+	if _, err := loader.Open("schemas/alias.json"); err == nil || !strings.Contains(err.Error(), "symlinks are not allowed") {
+		t.Errorf("Open(symlink) error = %v, want symlink denial", err)
+	}
+
+	loader.SetFollowSymlinks(true)
+	if got := readAll(t, loader, "schemas/alias.json"); got != `{"type":"object"}` {
+		t.Errorf("Open(alias) content = %q", got)
+	}
+	if _, err := loader.Open("schemas/escape.json"); err == nil || !strings.Contains(err.Error(), "escapes") {
+		t.Errorf("Open(escaping symlink) error = %v, want escape denial", err)
+	}
+	files, _ := loader.ListFiles("", []string{"schemas/*.json"}, nil)
+	if !reflect.DeepEqual(files, []string{"schemas/a.json", "schemas/alias.json"}) {
+		t.Errorf("ListFiles() with symlinks = %v", files)
+	}
+
+	loader.SetMaxFileSize(4)
+	if _, err := loader.Open("README.md"); err == nil || !strings.Contains(err.Error(), "size limit") {
+		t.Errorf("Open() over size limit error = %v", err)
+	}
+}
+
+func TestArchiveLoader_RejectsUnsafeEntries(t *testing.T) {
+	dir := t.TempDir()
+	for name, entry := range map[string]string{"traversal": "../evil.sh", "absolute": "/etc/cron.d/evil"} {
+		t.Run(name, func(t *testing.T) {
+			tarPath := filepath.Join(dir, name+".tar")
+			writeTestTar(t, tarPath, false, []archiveEntry{{name: "ok.txt", body: "ok"}, {name: entry, body: "evil"}})
+			if _, err := NewArchiveLoader(tarPath, false); err == nil {
+				t.Errorf("NewArchiveLoader(tar with %q) should fail", entry)
+			}
+
+			zipPath := filepath.Join(dir, name+".zip")
+			writeTestZip(t, zipPath, []archiveEntry{{name: "ok.txt", body: "ok"}, {name: entry, body: "evil"}})
+			if _, err := NewArchiveLoader(zipPath, false); err == nil {
+				t.Errorf("NewArchiveLoader(zip with %q) should fail", entry)
+			}
+		})
+	}
+
+	notArchive := filepath.Join(dir, "plain.json")
+	if err := os.WriteFile(notArchive, []byte(`{"a":1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewArchiveLoader(notArchive, false); err == nil {
+		t.Error("NewArchiveLoader(non-archive) should fail")
+	}
+}
+
+func TestArchiveLoader_Registry(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "release.zip")
+	writeTestZip(t, path, testArchiveEntries)
+
+	pf := pathfinder.NewPathFinder()
+	if _, err := pf.CreateLoader("zip", pathfinder.LoaderConfig{Config: map[string]interface{}{"path": path}}); err != nil {
+		t.Errorf("CreateLoader(zip) error = %v", err)
+	}
+	if _, err := pf.CreateLoader("tar", pathfinder.LoaderConfig{Config: map[string]interface{}{"path": path}}); err == nil {
+		t.Error("CreateLoader(tar) on a zip archive should fail")
+	}
+}
+
+// recordingAuditLogger collects audit records for assertions
+type recordingAuditLogger struct {
+	mu      sync.Mutex
+	records []pathfinder.AuditRecord
+}
+
+func (r *recordingAuditLogger) LogOperation(record pathfinder.AuditRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, record)
+	return nil
+}
+
+func (r *recordingAuditLogger) Query(pathfinder.AuditQuery) ([]pathfinder.AuditRecord, error) {
+	return nil, nil
+}
+
+func (r *recordingAuditLogger) Export(pathfinder.ExportFormat) ([]byte, error) { return nil, nil }
+
+func (r *recordingAuditLogger) SetComplianceMode(pathfinder.ComplianceMode) error { return nil }
+
+func (r *recordingAuditLogger) Configure(pathfinder.AuditConfig) error { return nil }
+
+func (r *recordingAuditLogger) waitFor(t *testing.T, n int) []pathfinder.AuditRecord {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		r.mu.Lock()
+		if len(r.records) >= n {
+			out := append([]pathfinder.AuditRecord(nil), r.records...)
+			r.mu.Unlock()
+			return out
+		}
+		r.mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected %d audit records", n)
+	return nil
+}
+
+func TestArchiveLoader_Audit(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "release.tar")
+	writeTestTar(t, path, false, testArchiveEntries)
+
+	loader, err := NewArchiveLoader(path, false)
+	if err != nil {
+		t.Fatalf("NewArchiveLoader() error = %v", err)
+	}
+	audit := &recordingAuditLogger{}
+	loader.SetAuditLogger(audit)
+
+	_ = readAll(t, loader, "README.md")
+	_, _ = loader.Open("../secret")
+
+	records := audit.waitFor(t, 2)
+	statuses := map[string]bool{}
+	for _, record := range records {
+		if record.SourceLoader != FormatTar || record.Operation != pathfinder.OpOpen {
+			t.Errorf("unexpected audit record %+v", record)
+		}
+		statuses[record.Result.Status] = true
+	}
+	if !statuses["success"] || !statuses["denied"] {
+		t.Errorf("expected success and denied audit records, got %+v", records)
+	}
+}
@@ -0,0 +1,151 @@
+package loaders
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fulmenhq/goneat/pkg/pathfinder"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// GitLoader reads files from a git tree at a given ref straight from the object
+// database, without a checkout. Works with bare repositories too.
+type GitLoader struct {
+	treeLoader
+	repoPath string
+	ref      string
+	commit   string
+}
+
+func init() {
+	pathfinder.RegisterLoader("git", func(cfg pathfinder.LoaderConfig) (pathfinder.SourceLoader, error) {
+		return NewGitLoader(configString(cfg, "path"), configString(cfg, "ref"), configBool(cfg, "follow_symlinks"))
+	})
+}
+
+// NewGitLoader opens the repository at repoPath and indexes the tree at ref
+// (HEAD when empty). Any revision git understands is accepted: branches, tags,
+// remote-tracking refs and commit hashes.
+func NewGitLoader(repoPath, ref string, followSymlinks bool) (*GitLoader, error) {
+	if repoPath == "" {
+		repoPath = "."
+	}
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	repo, err := git.PlainOpenWithOptions(repoPath, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, &LoaderError{Type: "GitError", Message: "Failed to open repository", Path: repoPath, Cause: err}
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, &LoaderError{Type: "GitError", Message: fmt.Sprintf("Failed to resolve ref %q", ref), Path: repoPath, Cause: err}
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, &LoaderError{Type: "GitError", Message: "Failed to read commit", Path: repoPath, Cause: err}
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, &LoaderError{Type: "GitError", Message: "Failed to read tree", Path: repoPath, Cause: err}
+	}
+
+	loader := &GitLoader{
+		treeLoader: newTreeLoader("git"),
+		repoPath:   repoPath,
+		ref:        ref,
+		commit:     hash.String(),
+	}
+	loader.followSymlinks = followSymlinks
+	if err := loader.index(repo, tree); err != nil {
+		return nil, &LoaderError{Type: "GitError", Message: "Failed to index tree", Path: repoPath, Cause: err}
+	}
+	return loader, nil
+}
+
+func (g *GitLoader) index(repo *git.Repository, tree *object.Tree) error {
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+
+	for {
+		name, entry, err := walker.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch entry.Mode {
+		case filemode.Regular, filemode.Executable, filemode.Deprecated:
+			blob, err := repo.BlobObject(entry.Hash)
+			if err != nil {
+				return err
+			}
+			if err := g.addFile(name, blob.Size, blob.Reader); err != nil {
+				return err
+			}
+		case filemode.Symlink:
+			blob, err := repo.BlobObject(entry.Hash)
+			if err != nil {
+				return err
+			}
+			target, err := readBlob(blob)
+			if err != nil {
+				return err
+			}
+			if err := g.addLink(name, target, false); err != nil {
+				return err
+			}
+		default:
+			// Directories and submodules carry no readable content
+			continue
+		}
+	}
+}
+
+// SourceType returns the loader type
+func (g *GitLoader) SourceType() string {
+	return "git"
+}
+
+// SourceDescription returns a human-readable description
+func (g *GitLoader) SourceDescription() string {
+	return fmt.Sprintf("Git tree loader (repo: %s, ref: %s, commit: %s)", g.repoPath, g.ref, g.commit)
+}
+
+// SourcePath returns the loader-native location of a file, e.g. "repo@v1.2.0:schemas/a.json"
+func (g *GitLoader) SourcePath(rel string) string {
+	return fmt.Sprintf("%s@%s:%s", g.repoPath, g.ref, rel)
+}
+
+// Commit returns the commit hash the ref resolved to
+func (g *GitLoader) Commit() string {
+	return g.commit
+}
+
+// Validate checks if the loader is properly configured
+func (g *GitLoader) Validate() error {
+	if g.commit == "" {
+		return fmt.Errorf("git ref is not resolved")
+	}
+	return nil
+}
+
+func readBlob(blob *object.Blob) (string, error) {
+	rc, err := blob.Reader()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = rc.Close() }()
+	content, err := io.ReadAll(io.LimitReader(rc, 4096))
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
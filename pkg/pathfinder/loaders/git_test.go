@@ -0,0 +1,130 @@
+package loaders
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// commitFiles writes and commits files; extra paths already on disk (such as
+// symlinks) are staged as-is
+func commitFiles(t *testing.T, repo *git.Repository, root string, files map[string]string, extra ...string) plumbing.Hash {
+	t.Helper()
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+	for rel, content := range files {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := worktree.Add(rel); err != nil {
+			t.Fatalf("add %s: %v", rel, err)
+		}
+	}
+	for _, rel := range extra {
+		if _, err := worktree.Add(rel); err != nil {
+			t.Fatalf("add %s: %v", rel, err)
+		}
+	}
+	hash, err := worktree.Commit("update", &git.CommitOptions{
+		Author: &object.Signature{Name: "goneat", Email: "ci@goneat.dev", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	return hash
+}
+
+func TestGitLoader(t *testing.T) {
+	root := t.TempDir()
+	repo, err := git.PlainInit(root, false)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	first := commitFiles(t, repo, root, map[string]string{
+		"schemas/a.json": `{"v":1}`,
+		"docs/guide.md":  "# guide\n",
+	})
+	if err := os.Symlink("a.json", filepath.Join(root, "schemas", "alias.json")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+	if err := os.Symlink("../../outside.json", filepath.Join(root, "schemas", "escape.json")); err != nil {
+		t.Fatal(err)
+	}
+	commitFiles(t, repo, root, map[string]string{"schemas/a.json": `{"v":2}`}, "schemas/alias.json", "schemas/escape.json")
+
+	t.Run("head", func(t *testing.T) {
+		loader, err := NewGitLoader(root, "", false)
+		if err != nil {
+			t.Fatalf("NewGitLoader() error = %v", err)
+		}
+		files, err := loader.ListFiles("", nil, nil)
+		if err != nil {
+			t.Fatalf("ListFiles() error = %v", err)
+		}
+		if !reflect.DeepEqual(files, []string{"docs/guide.md", "schemas/a.json"}) {
+			t.Errorf("ListFiles() = %v", files)
+		}
+		if got := readAll(t, loader, "schemas/a.json"); got != `{"v":2}` {
+			t.Errorf("Open() at HEAD = %q", got)
+		}
+		if _, err := loader.Open("schemas/alias.json"); err == nil {
+			t.Error("Open(symlink) should be denied without follow_symlinks")
+		}
+		if _, err := loader.Open("../outside.json"); err == nil {
+			t.Error("Open(traversal) should be denied")
+		}
+	})
+
+	t.Run("earlier ref without checkout", func(t *testing.T) {
+		// Dirty the worktree to prove reads come from the object database
+		if err := os.WriteFile(filepath.Join(root, "schemas", "a.json"), []byte("dirty"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		loader, err := NewGitLoader(root, first.String(), false)
+		if err != nil {
+			t.Fatalf("NewGitLoader() error = %v", err)
+		}
+		if got := readAll(t, loader, "schemas/a.json"); got != `{"v":1}` {
+			t.Errorf("Open() at first commit = %q", got)
+		}
+		if loader.Commit() != first.String() {
+			t.Errorf("Commit() = %s, want %s", loader.Commit(), first)
+		}
+		if !strings.Contains(loader.SourcePath("schemas/a.json"), "@"+first.String()+":schemas/a.json") {
+			t.Errorf("SourcePath() = %q", loader.SourcePath("schemas/a.json"))
+		}
+	})
+
+	t.Run("follow symlinks inside the tree", func(t *testing.T) {
+		loader, err := NewGitLoader(root, "HEAD", true)
+		if err != nil {
+			t.Fatalf("NewGitLoader() error = %v", err)
+		}
+		if got := readAll(t, loader, "schemas/alias.json"); got != `{"v":2}` {
+			t.Errorf("Open(alias) = %q", got)
+		}
+		if _, err := loader.Open("schemas/escape.json"); err == nil || !strings.Contains(err.Error(), "escapes") {
+			t.Errorf("Open(escaping symlink) error = %v", err)
+		}
+	})
+
+	t.Run("unknown ref", func(t *testing.T) {
+		if _, err := NewGitLoader(root, "does-not-exist", false); err == nil {
+			t.Error("NewGitLoader(unknown ref) should fail")
+		}
+	})
+}
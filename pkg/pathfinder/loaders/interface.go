@@ -3,7 +3,9 @@ package loaders
 import (
 	"fmt"
 	"io"
+	"path/filepath"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/fulmenhq/goneat/pkg/pathfinder"
 )
 
@@ -61,3 +63,28 @@ type CommonConfig struct {
 	TimeoutSeconds int                    `json:"timeout_seconds,omitempty"`
 	CustomConfig   map[string]interface{} `json:"custom,omitempty"`
 }
+
+// shouldInclude checks if a path should be included based on include and exclude patterns
+func shouldInclude(path string, include, exclude []string) bool {
+	// If no include patterns, include everything except excluded
+	if len(include) == 0 {
+		return !matchesAnyPattern(path, exclude)
+	}
+
+	// If include patterns exist, must match at least one and not match exclude
+	return matchesAnyPattern(path, include) && !matchesAnyPattern(path, exclude)
+}
+
+// matchesAnyPattern checks if path matches any of the given doublestar patterns
+func matchesAnyPattern(path string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := doublestar.Match(pattern, path); matched {
+			return true
+		}
+		// Also try with forward slashes for cross-platform compatibility
+		if matched, _ := doublestar.Match(pattern, filepath.ToSlash(path)); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/bmatcuk/doublestar/v4"
 	"github.com/fulmenhq/goneat/pkg/pathfinder"
 )
 
@@ -276,27 +275,12 @@ func (l *LocalLoader) validateAndCleanPath(path string) (string, error) {
 
 // shouldInclude checks if a path should be included based on patterns
 func (l *LocalLoader) shouldInclude(path string, include, exclude []string) bool {
-	// If no include patterns, include everything except excluded
-	if len(include) == 0 {
-		return !l.matchesAnyPattern(path, exclude)
-	}
-
-	// If include patterns exist, must match at least one and not match exclude
-	return l.matchesAnyPattern(path, include) && !l.matchesAnyPattern(path, exclude)
+	return shouldInclude(path, include, exclude)
 }
 
 // matchesAnyPattern checks if path matches any of the given patterns
 func (l *LocalLoader) matchesAnyPattern(path string, patterns []string) bool {
-	for _, pattern := range patterns {
-		if matched, _ := doublestar.Match(pattern, path); matched {
-			return true
-		}
-		// Also try with forward slashes for cross-platform compatibility
-		if matched, _ := doublestar.Match(pattern, filepath.ToSlash(path)); matched {
-			return true
-		}
-	}
-	return false
+	return matchesAnyPattern(path, patterns)
 }
 
 // logAudit logs an audit event if audit logger is configured
@@ -318,8 +302,7 @@ func (l *LocalLoader) logAudit(operation pathfinder.PathOperation, path string,
 		record.Constraint = string(l.constraint.Type())
 	}
 
-	// Log asynchronously to avoid blocking file operations
-	go func() {
-		_ = l.auditLogger.LogOperation(record)
-	}()
+	// Log synchronously so the record reaches durable sinks before the
+	// logger is closed; sink failures are reported when it is closed.
+	_ = l.auditLogger.LogOperation(record)
 }
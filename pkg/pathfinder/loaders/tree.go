@@ -0,0 +1,285 @@
+package loaders
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/pathfinder"
+)
+
+// maxLinkHops bounds link resolution inside archive and git trees
+const maxLinkHops = 40
+
+var errNotInTree = errors.New("file not found")
+
+// treeEntry is a regular file or link inside an archive or git tree
+type treeEntry struct {
+	size   int64
+	link   bool
+	target string // resolved link target relative to the tree root, "" when it escapes the tree
+	open   func() (io.ReadCloser, error)
+}
+
+// treeLoader implements the SourceLoader operations shared by loaders that read
+// from an indexed tree (archives, git objects) instead of the local filesystem.
+// Entry names are checked with the SafetyValidator when the tree is indexed, and
+// links are only followed when enabled and when they resolve inside the tree.
+type treeLoader struct {
+	sourceType     string
+	entries        map[string]*treeEntry
+	validator      *pathfinder.SafetyValidator
+	auditLogger    pathfinder.AuditLogger
+	maxFileSize    int64
+	followSymlinks bool
+}
+
+func newTreeLoader(sourceType string) treeLoader {
+	return treeLoader{
+		sourceType:     sourceType,
+		entries:        make(map[string]*treeEntry),
+		validator:      pathfinder.NewSafetyValidator(),
+		maxFileSize:    100 * 1024 * 1024, // 100MB default
+		followSymlinks: false,             // Security default
+	}
+}
+
+// addFile indexes a regular file. Unsafe names reject the whole tree.
+func (t *treeLoader) addFile(name string, size int64, open func() (io.ReadCloser, error)) error {
+	clean, err := t.entryName(name)
+	if err != nil {
+		return err
+	}
+	t.entries[clean] = &treeEntry{size: size, open: open}
+	return nil
+}
+
+// addLink indexes a symlink (target relative to the link's directory) or a
+// hardlink (target relative to the tree root)
+func (t *treeLoader) addLink(name, target string, relativeToRoot bool) error {
+	clean, err := t.entryName(name)
+	if err != nil {
+		return err
+	}
+	entry := &treeEntry{link: true}
+	target = filepath.ToSlash(target)
+	if target != "" && !strings.HasPrefix(target, "/") {
+		base := path.Dir(clean)
+		if relativeToRoot {
+			base = "."
+		}
+		resolved := path.Join(base, target)
+		if resolved != ".." && !strings.HasPrefix(resolved, "../") {
+			entry.target = resolved
+		}
+	}
+	t.entries[clean] = entry
+	return nil
+}
+
+func (t *treeLoader) entryName(name string) (string, error) {
+	name = strings.TrimPrefix(filepath.ToSlash(name), "./")
+	if err := t.validator.ValidateEntryPath(name); err != nil {
+		return "", fmt.Errorf("unsafe entry %q: %w", name, err)
+	}
+	return path.Clean(name), nil
+}
+
+// cleanRequestPath validates a caller-supplied path and returns its tree key
+func (t *treeLoader) cleanRequestPath(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	name = filepath.ToSlash(name)
+	if err := t.validator.ValidateEntryPath(name); err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(path.Clean(name), "./"), nil
+}
+
+// resolve follows links from name to a regular file entry
+func (t *treeLoader) resolve(name string) (*treeEntry, error) {
+	for hops := 0; hops <= maxLinkHops; hops++ {
+		entry, ok := t.entries[name]
+		if !ok {
+			return nil, errNotInTree
+		}
+		if !entry.link {
+			return entry, nil
+		}
+		if !t.followSymlinks {
+			return nil, fmt.Errorf("symlinks are not allowed")
+		}
+		if entry.target == "" {
+			return nil, fmt.Errorf("link target escapes the %s source", t.sourceType)
+		}
+		name = entry.target
+	}
+	return nil, fmt.Errorf("too many levels of links")
+}
+
+// Open opens a file inside the tree for reading
+func (t *treeLoader) Open(name string) (io.ReadCloser, error) {
+	start := time.Now()
+
+	clean, err := t.cleanRequestPath(name)
+	var entry *treeEntry
+	if err == nil {
+		entry, err = t.resolve(clean)
+	}
+	if errors.Is(err, errNotInTree) {
+		t.logAudit(pathfinder.OpOpen, name, pathfinder.OperationResult{
+			Status:  "failure",
+			Code:    404,
+			Message: err.Error(),
+		}, time.Since(start))
+		return nil, &LoaderError{
+			Type:    "OpenError",
+			Message: "Failed to open file",
+			Path:    name,
+			Cause:   err,
+		}
+	}
+	if err != nil {
+		t.logAudit(pathfinder.OpOpen, name, pathfinder.OperationResult{
+			Status:  "denied",
+			Code:    403,
+			Message: err.Error(),
+		}, time.Since(start))
+		return nil, err
+	}
+
+	if t.maxFileSize > 0 && entry.size > t.maxFileSize {
+		t.logAudit(pathfinder.OpOpen, name, pathfinder.OperationResult{
+			Status:  "denied",
+			Code:    413,
+			Message: fmt.Sprintf("File size %d exceeds maximum %d", entry.size, t.maxFileSize),
+		}, time.Since(start))
+		return nil, &LoaderError{
+			Type:    "SizeLimitError",
+			Message: "File exceeds size limit",
+			Path:    name,
+		}
+	}
+
+	reader, err := entry.open()
+	if err != nil {
+		t.logAudit(pathfinder.OpOpen, name, pathfinder.OperationResult{
+			Status:  "failure",
+			Code:    500,
+			Message: err.Error(),
+		}, time.Since(start))
+		return nil, &LoaderError{
+			Type:    "OpenError",
+			Message: "Failed to open file",
+			Path:    name,
+			Cause:   err,
+		}
+	}
+
+	t.logAudit(pathfinder.OpOpen, name, pathfinder.OperationResult{
+		Status: "success",
+		Code:   200,
+	}, time.Since(start))
+
+	return reader, nil
+}
+
+// ListFiles lists files under basePath matching the given patterns. Paths are
+// relative to basePath; links are listed only when they can be followed.
+func (t *treeLoader) ListFiles(basePath string, include, exclude []string) ([]string, error) {
+	start := time.Now()
+
+	prefix := ""
+	if basePath != "" && basePath != "." {
+		clean, err := t.cleanRequestPath(basePath)
+		if err != nil {
+			t.logAudit(pathfinder.OpList, basePath, pathfinder.OperationResult{
+				Status:  "denied",
+				Code:    403,
+				Message: err.Error(),
+			}, time.Since(start))
+			return nil, err
+		}
+		if clean != "." {
+			prefix = clean + "/"
+		}
+	}
+
+	files := make([]string, 0, len(t.entries))
+	for name := range t.entries {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if _, err := t.resolve(name); err != nil {
+			continue
+		}
+		rel := strings.TrimPrefix(name, prefix)
+		if shouldInclude(rel, include, exclude) {
+			files = append(files, rel)
+		}
+	}
+	sort.Strings(files)
+
+	t.logAudit(pathfinder.OpList, basePath, pathfinder.OperationResult{
+		Status:  "success",
+		Code:    200,
+		Message: fmt.Sprintf("Found %d files", len(files)),
+	}, time.Since(start))
+
+	return files, nil
+}
+
+// SetAuditLogger sets the audit logger for this loader
+func (t *treeLoader) SetAuditLogger(logger pathfinder.AuditLogger) {
+	t.auditLogger = logger
+}
+
+// SetMaxFileSize sets the maximum file size limit
+func (t *treeLoader) SetMaxFileSize(size int64) {
+	t.maxFileSize = size
+}
+
+// SetFollowSymlinks configures whether links inside the tree are followed
+func (t *treeLoader) SetFollowSymlinks(follow bool) {
+	t.followSymlinks = follow
+}
+
+// logAudit logs an audit event if audit logger is configured
+func (t *treeLoader) logAudit(operation pathfinder.PathOperation, path string, result pathfinder.OperationResult, duration time.Duration) {
+	if t.auditLogger == nil {
+		return
+	}
+
+	record := pathfinder.AuditRecord{
+		Operation:    operation,
+		Path:         path,
+		SourceLoader: t.sourceType,
+		Result:       result,
+		Duration:     duration,
+		Timestamp:    time.Now(),
+	}
+
+	// Log synchronously so the record reaches durable sinks before the
+	// logger is closed; sink failures are reported when it is closed.
+	_ = t.auditLogger.LogOperation(record)
+}
+
+// configString reads a string option from a loader config
+func configString(cfg pathfinder.LoaderConfig, key string) string {
+	if value, ok := cfg.Config[key].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// configBool reads a boolean option from a loader config
+func configBool(cfg pathfinder.LoaderConfig, key string) bool {
+	value, _ := cfg.Config[key].(bool)
+	return value
+}
@@ -66,6 +66,12 @@ type SourceLoader interface {
 	SetAuditLogger(logger AuditLogger)
 }
 
+// SourceLocator is implemented by loaders whose files live somewhere other than
+// a filesystem path (archive members, git tree entries)
+type SourceLocator interface {
+	SourcePath(rel string) string
+}
+
 // PathFinder provides the main library interface
 type PathFinder interface {
 	SafeJoin(base, path string) (string, error)
@@ -317,6 +323,8 @@ type AuditConfig struct {
 	ComplianceMode ComplianceMode `json:"compliance_mode"`
 	RetentionDays  int            `json:"retention_days"`
 	ExportFormats  []ExportFormat `json:"export_formats"`
+	// Sinks receive every record in addition to the in-memory trail
+	Sinks []AuditSink `json:"-"`
 }
 
 // Context-aware versions for future use
@@ -67,6 +67,34 @@ func TestSafetyValidator_SafeJoin(t *testing.T) {
 	}
 }
 
+func TestSafetyValidator_ValidateEntryPath(t *testing.T) {
+	validator := NewSafetyValidator()
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{"nested entry", "schemas/v1/a.json", false},
+		{"empty path", "", true},
+		{"traversal", "../evil.json", true},
+		{"nested traversal", "schemas/../../evil.json", true},
+		{"absolute", "/etc/passwd", true},
+		{"backslash absolute", "\\server\\share", true},
+		{"drive letter", "C:/Windows/win.ini", true},
+		{"nul byte", "a\x00b", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateEntryPath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateEntryPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestRepositoryConstraint(t *testing.T) {
 	// Create a temporary directory structure
 	tmpDir, err := os.MkdirTemp("", "pathfinder_test")
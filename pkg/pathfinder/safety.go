@@ -92,6 +92,26 @@ func (s *SafetyValidator) SafeJoin(base, path string) (string, error) {
 	return joined, nil
 }
 
+// ValidateEntryPath validates a slash-separated path inside an archive or git
+// tree. Entry paths are never resolved against the local filesystem, so only
+// traversal, absolute and drive-qualified names need to be rejected.
+func (s *SafetyValidator) ValidateEntryPath(name string) error {
+	if name == "" {
+		return fmt.Errorf("path cannot be empty")
+	}
+	if err := s.detectTraversal(name); err != nil {
+		return err
+	}
+	if strings.ContainsRune(name, 0) {
+		return fmt.Errorf("path contains NUL byte")
+	}
+	normalized := strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(normalized, "/") || filepath.VolumeName(name) != "" || (len(normalized) > 1 && normalized[1] == ':') {
+		return fmt.Errorf("absolute paths are not allowed: %s", name)
+	}
+	return nil
+}
+
 // cleanPath performs basic path cleaning and normalization
 func (s *SafetyValidator) cleanPath(path string) string {
 	// Use filepath.Clean for cross-platform compatibility
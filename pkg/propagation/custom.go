@@ -0,0 +1,457 @@
+package propagation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/pathfinder"
+	"gopkg.in/yaml.v3"
+)
+
+// CustomTargetType marks a policy target as a regex or structured-path target
+// rather than overrides for a built-in package manager
+const CustomTargetType = "custom"
+
+// VersionLocation is a version string found in a file
+type VersionLocation struct {
+	Target  string
+	File    string
+	Line    int // 1-based; 0 when only the file is known
+	Version string
+}
+
+// CustomTarget propagates the version into arbitrary files matched by its
+// include globs, locating the version with a regex that has a named
+// "version" group or with a structured path (JSONPath, YAML path, TOML key).
+type CustomTarget struct {
+	name   string
+	target PropagationTarget
+	regex  *regexp.Regexp
+	path   []pathSegment
+	engine *pathfinder.DiscoveryEngine
+}
+
+// versionSpan is the byte range of a version value within file content
+type versionSpan struct {
+	start, end int
+	value      string
+}
+
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// NewCustomTarget builds a custom target from its policy entry
+func NewCustomTarget(name string, target PropagationTarget) (*CustomTarget, error) {
+	if len(target.Include) == 0 {
+		return nil, fmt.Errorf("custom target %s: include must list at least one file glob", name)
+	}
+	if (target.Regex == "") == (target.Path == "") {
+		return nil, fmt.Errorf("custom target %s: set exactly one of regex or path", name)
+	}
+
+	custom := &CustomTarget{name: name, target: target, engine: newMatchEngine()}
+	if target.Regex != "" {
+		re, err := regexp.Compile(target.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("custom target %s: invalid regex: %w", name, err)
+		}
+		if re.SubexpIndex("version") < 0 {
+			return nil, fmt.Errorf("custom target %s: regex needs a named (?P<version>...) group", name)
+		}
+		custom.regex = re
+		return custom, nil
+	}
+
+	switch target.Format {
+	case "", "json", "yaml", "toml":
+	default:
+		return nil, fmt.Errorf("custom target %s: invalid format %s (must be json, yaml, or toml)", name, target.Format)
+	}
+	path, err := parseValuePath(target.Path)
+	if err != nil {
+		return nil, fmt.Errorf("custom target %s: %w", name, err)
+	}
+	custom.path = path
+	return custom, nil
+}
+
+// CustomTargets returns the custom targets declared in the policy, sorted by name
+func CustomTargets(policy *VersionPolicy) ([]*CustomTarget, error) {
+	names := make([]string, 0, len(policy.Propagation.Targets))
+	for name, target := range policy.Propagation.Targets {
+		if target.Type == CustomTargetType {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	targets := make([]*CustomTarget, 0, len(names))
+	for _, name := range names {
+		target, err := NewCustomTarget(name, policy.Propagation.Targets[name])
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// Name returns the policy key of this target
+func (c *CustomTarget) Name() string {
+	return c.name
+}
+
+// Detect returns files under root matching the target's include globs
+func (c *CustomTarget) Detect(root string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != root && (info.Name() == ".git" || info.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		if c.engine.MatchesAnyPattern(filepath.ToSlash(relPath), c.target.Include) {
+			files = append(files, relPath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect files for custom target %s: %w", c.name, err)
+	}
+	return files, nil
+}
+
+// Locations returns every version found in file
+func (c *CustomTarget) Locations(file string) ([]VersionLocation, error) {
+	content, spans, err := c.locate(file)
+	if err != nil {
+		return nil, err
+	}
+	locations := make([]VersionLocation, 0, len(spans))
+	for _, span := range spans {
+		locations = append(locations, VersionLocation{
+			Target:  c.name,
+			File:    file,
+			Line:    strings.Count(content[:span.start], "\n") + 1,
+			Version: span.value,
+		})
+	}
+	return locations, nil
+}
+
+// ExtractVersion returns the first version found in file
+func (c *CustomTarget) ExtractVersion(file string) (string, error) {
+	_, spans, err := c.locate(file)
+	if err != nil {
+		return "", err
+	}
+	return spans[0].value, nil
+}
+
+// UpdateVersion rewrites every located version in place. Each occurrence
+// keeps its own "v" prefix style.
+func (c *CustomTarget) UpdateVersion(file, version string) error {
+	content, spans, err := c.locate(file)
+	if err != nil {
+		return err
+	}
+	for i := len(spans) - 1; i >= 0; i-- {
+		span := spans[i]
+		content = content[:span.start] + matchVersionStyle(span.value, version) + content[span.end:]
+	}
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return nil
+}
+
+// ValidateVersion checks that every located version matches, ignoring a "v" prefix
+func (c *CustomTarget) ValidateVersion(file, version string) error {
+	locations, err := c.Locations(file)
+	if err != nil {
+		return err
+	}
+	var mismatches []string
+	for _, loc := range locations {
+		if !sameVersion(loc.Version, version) {
+			mismatches = append(mismatches, fmt.Sprintf("line %d has %s", loc.Line, loc.Version))
+		}
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("version mismatch: expected %s, %s", version, strings.Join(mismatches, ", "))
+	}
+	return nil
+}
+
+func (c *CustomTarget) locate(file string) (string, []versionSpan, error) {
+	data, err := os.ReadFile(filepath.Clean(file)) // #nosec G304 - file comes from policy include globs under the repository
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	content := string(data)
+
+	var spans []versionSpan
+	if c.regex != nil {
+		group := c.regex.SubexpIndex("version")
+		for _, m := range c.regex.FindAllStringSubmatchIndex(content, -1) {
+			if m[2*group] >= 0 {
+				spans = append(spans, versionSpan{start: m[2*group], end: m[2*group+1], value: content[m[2*group]:m[2*group+1]]})
+			}
+		}
+		if len(spans) == 0 {
+			return "", nil, fmt.Errorf("custom target %s: regex matched no version in %s", c.name, file)
+		}
+		return content, spans, nil
+	}
+
+	var span versionSpan
+	switch format := c.format(file); format {
+	case "json":
+		span, err = locateJSON(content, c.path)
+	case "yaml":
+		span, err = locateYAML(content, c.path)
+	case "toml":
+		span, err = locateTOML(content, c.path)
+	default:
+		err = fmt.Errorf("cannot infer format from %s; set format to json, yaml, or toml", filepath.Base(file))
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("custom target %s: %s in %s: %w", c.name, c.target.Path, file, err)
+	}
+	return content, []versionSpan{span}, nil
+}
+
+func (c *CustomTarget) format(file string) string {
+	if c.target.Format != "" {
+		return c.target.Format
+	}
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".toml":
+		return "toml"
+	}
+	return ""
+}
+
+// parseValuePath parses "$.info.version", "info.version" or
+// "servers[0].version" into segments
+func parseValuePath(path string) ([]pathSegment, error) {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if trimmed == "" {
+		return nil, fmt.Errorf("path %q selects no value", path)
+	}
+	var segments []pathSegment
+	for _, part := range strings.Split(trimmed, ".") {
+		key := part
+		var indexes []int
+		if open := strings.IndexByte(part, '['); open >= 0 {
+			key = part[:open]
+			for _, raw := range strings.Split(strings.TrimSuffix(part[open+1:], "]"), "][") {
+				index, err := strconv.Atoi(raw)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("invalid index in path %q", path)
+				}
+				indexes = append(indexes, index)
+			}
+		}
+		if key == "" && len(indexes) == 0 {
+			return nil, fmt.Errorf("empty segment in path %q", path)
+		}
+		if key != "" {
+			segments = append(segments, pathSegment{key: key})
+		}
+		for _, index := range indexes {
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+		}
+	}
+	return segments, nil
+}
+
+var errPathNotFound = errors.New("path not found")
+
+// locateJSON streams the document and returns the span of the string value
+// at path, inside its quotes
+func locateJSON(content string, path []pathSegment) (versionSpan, error) {
+	decoder := json.NewDecoder(strings.NewReader(content))
+	span, found, err := findJSON(decoder, content, path)
+	if err != nil {
+		return versionSpan{}, err
+	}
+	if !found {
+		return versionSpan{}, errPathNotFound
+	}
+	return span, nil
+}
+
+func findJSON(decoder *json.Decoder, content string, path []pathSegment) (versionSpan, bool, error) {
+	before := int(decoder.InputOffset())
+	token, err := decoder.Token()
+	if err != nil {
+		return versionSpan{}, false, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	if len(path) == 0 {
+		value, ok := token.(string)
+		if !ok {
+			return versionSpan{}, false, fmt.Errorf("value is not a string")
+		}
+		end := int(decoder.InputOffset()) - 1
+		start := before + strings.IndexByte(content[before:end], '"') + 1
+		if content[start:end] != value {
+			return versionSpan{}, false, fmt.Errorf("value contains escapes and cannot be updated in place")
+		}
+		return versionSpan{start: start, end: end, value: value}, true, nil
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return versionSpan{}, false, nil
+	}
+	var span versionSpan
+	found := false
+	for i := 0; decoder.More(); i++ {
+		match := false
+		if delim == '{' {
+			key, err := decoder.Token()
+			if err != nil {
+				return versionSpan{}, false, fmt.Errorf("failed to parse JSON: %w", err)
+			}
+			match = !path[0].isIndex && key == path[0].key
+		} else {
+			match = path[0].isIndex && i == path[0].index
+		}
+		if match && !found {
+			if span, found, err = findJSON(decoder, content, path[1:]); err != nil {
+				return versionSpan{}, false, err
+			}
+			continue
+		}
+		var skip json.RawMessage
+		if err := decoder.Decode(&skip); err != nil {
+			return versionSpan{}, false, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+	}
+	if _, err := decoder.Token(); err != nil && !errors.Is(err, io.EOF) {
+		return versionSpan{}, false, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return span, found, nil
+}
+
+// locateYAML finds the scalar at path using node positions, so comments and
+// layout are untouched on update
+func locateYAML(content string, path []pathSegment) (versionSpan, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return versionSpan{}, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return versionSpan{}, errPathNotFound
+	}
+	node := doc.Content[0]
+	for _, segment := range path {
+		var next *yaml.Node
+		switch {
+		case segment.isIndex && node.Kind == yaml.SequenceNode && segment.index < len(node.Content):
+			next = node.Content[segment.index]
+		case !segment.isIndex && node.Kind == yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == segment.key {
+					next = node.Content[i+1]
+					break
+				}
+			}
+		}
+		if next == nil {
+			return versionSpan{}, errPathNotFound
+		}
+		node = next
+	}
+	if node.Kind != yaml.ScalarNode {
+		return versionSpan{}, fmt.Errorf("value is not a scalar")
+	}
+
+	offset := 0
+	for line := 1; line < node.Line; line++ {
+		next := strings.IndexByte(content[offset:], '\n')
+		if next < 0 {
+			return versionSpan{}, errPathNotFound
+		}
+		offset += next + 1
+	}
+	start := offset + node.Column - 1
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		start++
+	}
+	end := start + len(node.Value)
+	if end > len(content) || content[start:end] != node.Value {
+		return versionSpan{}, fmt.Errorf("value cannot be updated in place")
+	}
+	return versionSpan{start: start, end: end, value: node.Value}, nil
+}
+
+// locateTOML finds key = "value" for a dotted key path, either under the
+// matching [table] header or as a dotted key in a parent table
+func locateTOML(content string, path []pathSegment) (versionSpan, error) {
+	keys := make([]string, 0, len(path))
+	for _, segment := range path {
+		if segment.isIndex {
+			return versionSpan{}, fmt.Errorf("array indexes are not supported for TOML")
+		}
+		keys = append(keys, segment.key)
+	}
+
+	for split := len(keys) - 1; split >= 0; split-- {
+		table := strings.Join(keys[:split], ".")
+		pattern := regexp.MustCompile(`^[ \t]*` + regexp.QuoteMeta(strings.Join(keys[split:], ".")) + `[ \t]*=[ \t]*(["'])([^"'\r\n]*)["']`)
+		current := ""
+		offset := 0
+		for _, line := range strings.SplitAfter(content, "\n") {
+			if m := tomlTablePattern.FindStringSubmatch(line); m != nil {
+				current = strings.TrimSpace(m[1])
+			} else if current == table {
+				if m := pattern.FindStringSubmatchIndex(line); m != nil {
+					return versionSpan{start: offset + m[4], end: offset + m[5], value: line[m[4]:m[5]]}, nil
+				}
+			}
+			offset += len(line)
+		}
+	}
+	return versionSpan{}, errPathNotFound
+}
+
+var tomlTablePattern = regexp.MustCompile(`^[ \t]*\[\[?([^\[\]]+)\]\]?[ \t]*(#.*)?\r?\n?$`)
+
+// sameVersion compares versions ignoring a "v" prefix on either side
+func sameVersion(a, b string) bool {
+	return strings.TrimPrefix(a, "v") == strings.TrimPrefix(b, "v")
+}
+
+// matchVersionStyle renders version with a "v" prefix only when existing has one
+func matchVersionStyle(existing, version string) string {
+	version = strings.TrimPrefix(version, "v")
+	if strings.HasPrefix(existing, "v") {
+		return "v" + version
+	}
+	return version
+}
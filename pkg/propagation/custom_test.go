@@ -0,0 +1,186 @@
+package propagation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeCustomTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+func TestCustomTarget_Regex(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "README.md")
+	writeCustomTestFile(t, file, "Install:\n\n    go install example.com/tool@v1.2.3\n\nOr download tool-1.2.2.tar.gz\n")
+
+	target, err := NewCustomTarget("readme", PropagationTarget{
+		Type:    CustomTargetType,
+		Include: []string{"README.md"},
+		Regex:   `(?:@|tool-)(?P<version>v?\d+\.\d+\.\d+)`,
+	})
+	if err != nil {
+		t.Fatalf("NewCustomTarget: %v", err)
+	}
+
+	locations, err := target.Locations(file)
+	if err != nil || len(locations) != 2 {
+		t.Fatalf("Locations = %+v, %v", locations, err)
+	}
+	if locations[1].Line != 5 || locations[1].Version != "1.2.2" {
+		t.Errorf("second location = %+v", locations[1])
+	}
+	if err := target.ValidateVersion(file, "v1.2.3"); err == nil || !strings.Contains(err.Error(), "line 5 has 1.2.2") {
+		t.Errorf("expected line 5 mismatch, got %v", err)
+	}
+
+	if err := target.UpdateVersion(file, "v1.3.0"); err != nil {
+		t.Fatalf("UpdateVersion: %v", err)
+	}
+	data, _ := os.ReadFile(file)
+	if !strings.Contains(string(data), "tool@v1.3.0") || !strings.Contains(string(data), "tool-1.3.0.tar.gz") {
+		t.Errorf("each occurrence should keep its prefix style:\n%s", data)
+	}
+}
+
+func TestCustomTarget_StructuredPaths(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		file, path, content, want string
+	}{
+		{
+			file:    "openapi.json",
+			path:    "$.info.version",
+			content: "{\n  \"openapi\": \"3.1.0\",\n  \"servers\": [{\"version\": \"x\"}],\n  \"info\": {\"title\": \"API\", \"version\": \"1.2.3\"}\n}\n",
+			want:    "{\n  \"openapi\": \"3.1.0\",\n  \"servers\": [{\"version\": \"x\"}],\n  \"info\": {\"title\": \"API\", \"version\": \"2.0.0\"}\n}\n",
+		},
+		{
+			file:    "servers.json",
+			path:    "servers[1].version",
+			content: `{"servers": [{"version": "a"}, {"version": "1.2.3"}]}`,
+			want:    `{"servers": [{"version": "a"}, {"version": "2.0.0"}]}`,
+		},
+		{
+			file:    "openapi.yaml",
+			path:    "$.info.version",
+			content: "openapi: 3.1.0\ninfo:\n  title: API\n  # keep me\n  version: \"1.2.3\" # api version\n",
+			want:    "openapi: 3.1.0\ninfo:\n  title: API\n  # keep me\n  version: \"2.0.0\" # api version\n",
+		},
+		{
+			file:    "config.toml",
+			path:    "tool.app.version",
+			content: "version = \"0.0.1\"\n\n[tool.app]\nname = \"app\"\nversion = '1.2.3' # pinned\n",
+			want:    "version = \"0.0.1\"\n\n[tool.app]\nname = \"app\"\nversion = '2.0.0' # pinned\n",
+		},
+		{
+			file:    "dotted.toml",
+			path:    "package.version",
+			content: "package.name = \"app\"\npackage.version = \"1.2.3\"\n",
+			want:    "package.name = \"app\"\npackage.version = \"2.0.0\"\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.file, func(t *testing.T) {
+			file := filepath.Join(dir, tc.file)
+			writeCustomTestFile(t, file, tc.content)
+			target, err := NewCustomTarget("doc", PropagationTarget{Type: CustomTargetType, Include: []string{tc.file}, Path: tc.path})
+			if err != nil {
+				t.Fatalf("NewCustomTarget: %v", err)
+			}
+			if version, err := target.ExtractVersion(file); err != nil || version != "1.2.3" {
+				t.Fatalf("ExtractVersion = %q, %v", version, err)
+			}
+			if err := target.UpdateVersion(file, "v2.0.0"); err != nil {
+				t.Fatalf("UpdateVersion: %v", err)
+			}
+			if data, _ := os.ReadFile(file); string(data) != tc.want {
+				t.Errorf("updated content:\n%s", data)
+			}
+		})
+	}
+}
+
+func TestNewCustomTarget_Invalid(t *testing.T) {
+	cases := map[string]PropagationTarget{
+		"no include":     {Type: CustomTargetType, Regex: `(?P<version>\d+)`},
+		"no locator":     {Type: CustomTargetType, Include: []string{"x"}},
+		"both locators":  {Type: CustomTargetType, Include: []string{"x"}, Regex: `(?P<version>\d+)`, Path: "a"},
+		"no named group": {Type: CustomTargetType, Include: []string{"x"}, Regex: `v(\d+)`},
+		"bad format":     {Type: CustomTargetType, Include: []string{"x"}, Path: "a", Format: "xml"},
+		"bad path":       {Type: CustomTargetType, Include: []string{"x"}, Path: "a[x]"},
+	}
+	for name, target := range cases {
+		if _, err := NewCustomTarget(name, target); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestPropagate_CustomTargetsStagedWithBackup(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GONEAT_HOME", filepath.Join(dir, ".goneat-home"))
+	oldWd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldWd) }()
+
+	writeCustomTestFile(t, ".goneat/version-policy.yaml", `version:
+  scheme: semver
+propagation:
+  defaults:
+    include: []
+    exclude: []
+    backup:
+      enabled: true
+      retention: 2
+  targets:
+    install-script:
+      type: custom
+      include: ["scripts/*.sh"]
+      regex: 'VERSION="(?P<version>[^"]+)"'
+    openapi:
+      type: custom
+      include: ["api/openapi.yaml"]
+      path: $.info.version
+`)
+	writeCustomTestFile(t, "scripts/install.sh", "#!/bin/sh\nVERSION=\"v1.0.0\"\n")
+	writeCustomTestFile(t, "api/openapi.yaml", "info:\n  version: 1.0.0\n")
+
+	propagator := NewPropagator(NewRegistry())
+	report, err := propagator.CheckConsistency("v1.1.0", "")
+	if err != nil {
+		t.Fatalf("CheckConsistency: %v", err)
+	}
+	if report.Checked != 2 || len(report.Mismatches) != 2 || report.Mismatches[1].File != filepath.Join("api", "openapi.yaml") || report.Mismatches[1].Line != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	result, err := propagator.Propagate(context.Background(), "v1.1.0", PropagateOptions{})
+	if err != nil || !result.Success || len(result.Changes) != 2 {
+		t.Fatalf("Propagate = %+v, %v", result, err)
+	}
+	if data, _ := os.ReadFile("scripts/install.sh"); !strings.Contains(string(data), `VERSION="v1.1.0"`) {
+		t.Errorf("install.sh not updated:\n%s", data)
+	}
+	if data, _ := os.ReadFile("api/openapi.yaml"); string(data) != "info:\n  version: 1.1.0\n" {
+		t.Errorf("openapi.yaml not updated:\n%s", data)
+	}
+	if backups, _ := filepath.Glob("scripts/install.sh.backup.*"); len(backups) != 1 {
+		t.Errorf("expected one backup, got %v", backups)
+	}
+
+	report, err = propagator.CheckConsistency("v1.1.0", "")
+	if err != nil || len(report.Mismatches) != 0 {
+		t.Errorf("after propagate: %+v, %v", report, err)
+	}
+}
@@ -0,0 +1,181 @@
+package managers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/logger"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// CargoManager handles Cargo.toml files, including workspace members that
+// inherit their version from [workspace.package]
+type CargoManager struct{}
+
+// NewCargoManager creates a new Cargo package manager
+func NewCargoManager() *CargoManager {
+	return &CargoManager{}
+}
+
+// Name returns the name of this package manager
+func (m *CargoManager) Name() string {
+	return "Cargo.toml"
+}
+
+// Detect finds Cargo.toml files in the given root directory
+func (m *CargoManager) Detect(root string) ([]string, error) {
+	return detectFiles(root, "Cargo.toml", []string{"target", "vendor"}, func(_, name string) bool {
+		return name == "Cargo.toml"
+	})
+}
+
+type cargoManifest struct {
+	Package *struct {
+		Version interface{} `toml:"version"`
+	} `toml:"package"`
+	Workspace *struct {
+		Package struct {
+			Version string `toml:"version"`
+		} `toml:"package"`
+	} `toml:"workspace"`
+}
+
+func parseCargoManifest(content string) (*cargoManifest, error) {
+	var manifest cargoManifest
+	if err := toml.Unmarshal([]byte(content), &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse Cargo.toml: %w", err)
+	}
+	return &manifest, nil
+}
+
+// inheritsVersion reports whether [package] uses version.workspace = true
+func (c *cargoManifest) inheritsVersion() bool {
+	if c.Package == nil {
+		return false
+	}
+	table, ok := c.Package.Version.(map[string]interface{})
+	return ok && table["workspace"] == true
+}
+
+// ExtractVersion reads the version from a Cargo.toml file. Members with
+// version.workspace = true report the version of their workspace root.
+func (m *CargoManager) ExtractVersion(file string) (string, error) {
+	path, content, err := readManifest(file, "Cargo.toml")
+	if err != nil {
+		return "", err
+	}
+	manifest, err := parseCargoManifest(content)
+	if err != nil {
+		return "", err
+	}
+
+	if manifest.Package != nil {
+		if version, ok := manifest.Package.Version.(string); ok && version != "" {
+			return version, nil
+		}
+	}
+	if manifest.Workspace != nil && manifest.Workspace.Package.Version != "" {
+		return manifest.Workspace.Package.Version, nil
+	}
+	if manifest.inheritsVersion() {
+		return m.workspaceVersion(filepath.Dir(filepath.Dir(path)))
+	}
+	return "", fmt.Errorf("no version field found in [package] or [workspace.package] sections")
+}
+
+// workspaceVersion finds the nearest ancestor Cargo.toml declaring
+// [workspace.package] version, starting at dir
+func (m *CargoManager) workspaceVersion(dir string) (string, error) {
+	for {
+		candidate := filepath.Join(dir, "Cargo.toml")
+		if _, err := os.Stat(candidate); err == nil {
+			_, content, err := readManifest(candidate, "Cargo.toml")
+			if err != nil {
+				return "", err
+			}
+			manifest, err := parseCargoManifest(content)
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", candidate, err)
+			}
+			if manifest.Workspace != nil && manifest.Workspace.Package.Version != "" {
+				return manifest.Workspace.Package.Version, nil
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("version.workspace = true but no ancestor Cargo.toml declares [workspace.package] version")
+		}
+		dir = parent
+	}
+}
+
+// UpdateVersion updates [package] and [workspace.package] versions in a
+// Cargo.toml file. Members inheriting the workspace version are left
+// untouched; they follow the workspace root.
+func (m *CargoManager) UpdateVersion(file, version string) error {
+	path, content, err := readManifest(file, "Cargo.toml")
+	if err != nil {
+		return err
+	}
+
+	updated := false
+	for _, section := range []string{"package", "workspace.package"} {
+		if newContent, ok := replaceTOMLVersion(content, section, plainVersion(version)); ok {
+			content = newContent
+			updated = true
+		}
+	}
+
+	if !updated {
+		manifest, err := parseCargoManifest(content)
+		if err != nil {
+			return err
+		}
+		if manifest.inheritsVersion() {
+			logger.Debug("Cargo.toml inherits workspace version", logger.String("file", path))
+			return nil
+		}
+		return fmt.Errorf("no version field found to update in [package] or [workspace.package] sections")
+	}
+
+	return writeManifest(path, "Cargo.toml", content, version)
+}
+
+var (
+	tomlHeaderPattern  = regexp.MustCompile(`^\s*\[([^\[\]]+)\]\s*(#.*)?$`)
+	tomlVersionPattern = regexp.MustCompile(`^(\s*version\s*=\s*)(["'])[^"']*(["'])(.*)$`)
+)
+
+// replaceTOMLVersion rewrites a literal version = "..." line directly inside
+// section, keeping indentation, quoting and trailing comments
+func replaceTOMLVersion(content, section, version string) (string, bool) {
+	lines := strings.Split(content, "\n")
+	inSection := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "[") {
+			m := tomlHeaderPattern.FindStringSubmatch(line)
+			inSection = m != nil && strings.TrimSpace(m[1]) == section
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		if m := tomlVersionPattern.FindStringSubmatch(line); m != nil {
+			lines[i] = m[1] + m[2] + version + m[3] + m[4]
+			return strings.Join(lines, "\n"), true
+		}
+	}
+	return content, false
+}
+
+// ValidateVersion checks if the version in the file matches the expected version
+func (m *CargoManager) ValidateVersion(file, expectedVersion string) error {
+	actualVersion, err := m.ExtractVersion(file)
+	if err != nil {
+		return err
+	}
+	return checkVersion(actualVersion, expectedVersion)
+}
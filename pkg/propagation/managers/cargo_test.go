@@ -0,0 +1,104 @@
+package managers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	return string(data)
+}
+
+func TestCargoManager_Workspace(t *testing.T) {
+	tmpDir := t.TempDir()
+	root := filepath.Join(tmpDir, "Cargo.toml")
+	member := filepath.Join(tmpDir, "crates", "cli", "Cargo.toml")
+	pinned := filepath.Join(tmpDir, "crates", "legacy", "Cargo.toml")
+	writeTestFile(t, root, `[workspace]
+members = ["crates/*"]
+
+[workspace.package]
+version = "1.2.3" # shared
+edition = "2021"
+`)
+	writeTestFile(t, member, `[package]
+name = "cli"
+version.workspace = true
+
+[dependencies]
+serde = { version = "1.0" }
+`)
+	writeTestFile(t, pinned, `[package]
+name = "legacy"
+version = '0.9.0'
+
+[package.metadata.docs]
+version = "ignored"
+`)
+	writeTestFile(t, filepath.Join(tmpDir, "target", "Cargo.toml"), "")
+
+	manager := NewCargoManager()
+	files, err := manager.Detect(tmpDir)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if len(files) != 3 {
+		t.Errorf("Expected 3 Cargo.toml files outside target/, got %v", files)
+	}
+
+	if version, err := manager.ExtractVersion(member); err != nil || version != "1.2.3" {
+		t.Errorf("Expected inherited version 1.2.3, got %q (%v)", version, err)
+	}
+
+	for _, file := range []string{root, member, pinned} {
+		if err := manager.UpdateVersion(file, "v1.3.0"); err != nil {
+			t.Fatalf("UpdateVersion(%s) failed: %v", file, err)
+		}
+	}
+	if content := readTestFile(t, root); !strings.Contains(content, `version = "1.3.0" # shared`) {
+		t.Errorf("Workspace version not updated in place:\n%s", content)
+	}
+	if content := readTestFile(t, member); !strings.Contains(content, "version.workspace = true") || !strings.Contains(content, `serde = { version = "1.0" }`) {
+		t.Errorf("Member manifest should be untouched:\n%s", content)
+	}
+	content := readTestFile(t, pinned)
+	if !strings.Contains(content, "version = '1.3.0'") || !strings.Contains(content, `version = "ignored"`) {
+		t.Errorf("Only [package] version should change:\n%s", content)
+	}
+
+	for _, file := range []string{root, member, pinned} {
+		if err := manager.ValidateVersion(file, "v1.3.0"); err != nil {
+			t.Errorf("ValidateVersion(%s) failed: %v", file, err)
+		}
+	}
+	if err := manager.ValidateVersion(member, "1.4.0"); err == nil {
+		t.Error("Expected version mismatch for inherited member")
+	}
+}
+
+func TestCargoManager_MissingWorkspaceVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "Cargo.toml")
+	writeTestFile(t, file, "[package]\nname = \"solo\"\nversion = { workspace = true }\n")
+
+	if _, err := NewCargoManager().ExtractVersion(file); err == nil {
+		t.Error("Expected error when no workspace root declares a version")
+	}
+}
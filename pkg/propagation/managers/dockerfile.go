@@ -0,0 +1,107 @@
+package managers
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DockerfileManager handles the OCI image version label in Dockerfiles:
+// LABEL org.opencontainers.image.version="1.2.3"
+type DockerfileManager struct{}
+
+// NewDockerfileManager creates a new Dockerfile manager
+func NewDockerfileManager() *DockerfileManager {
+	return &DockerfileManager{}
+}
+
+// Name returns the name of this package manager
+func (m *DockerfileManager) Name() string {
+	return "Dockerfile"
+}
+
+var ociVersionLabelPattern = regexp.MustCompile(`("?org\.opencontainers\.image\.version"?=)(?:"([^"]*)"|([^\s"\\]+))`)
+
+func isDockerfile(name string) bool {
+	lower := strings.ToLower(name)
+	return lower == "dockerfile" || lower == "containerfile" ||
+		strings.HasPrefix(lower, "dockerfile.") || strings.HasSuffix(lower, ".dockerfile")
+}
+
+// ociVersionLabel returns the first literal version label value. Values
+// taken from build arguments ($VERSION) are not literal.
+func ociVersionLabel(content string) (string, bool) {
+	for _, m := range ociVersionLabelPattern.FindAllStringSubmatch(content, -1) {
+		value := m[2] + m[3]
+		if value != "" && !strings.Contains(value, "$") {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// Detect finds Dockerfiles and Containerfiles with a literal
+// org.opencontainers.image.version label
+func (m *DockerfileManager) Detect(root string) ([]string, error) {
+	return detectFiles(root, "Dockerfile", nil, func(path, name string) bool {
+		if !isDockerfile(name) {
+			return false
+		}
+		_, content, err := readManifest(path, name)
+		if err != nil {
+			return false
+		}
+		_, ok := ociVersionLabel(content)
+		return ok
+	})
+}
+
+// ExtractVersion reads the org.opencontainers.image.version label
+func (m *DockerfileManager) ExtractVersion(file string) (string, error) {
+	_, content, err := readManifest(file, filepath.Base(file))
+	if err != nil {
+		return "", err
+	}
+	version, ok := ociVersionLabel(content)
+	if !ok {
+		return "", fmt.Errorf("no literal org.opencontainers.image.version label found in %s", filepath.Base(file))
+	}
+	return version, nil
+}
+
+// UpdateVersion rewrites literal version labels, keeping quoting and the
+// existing "v" prefix style
+func (m *DockerfileManager) UpdateVersion(file, version string) error {
+	name := filepath.Base(file)
+	path, content, err := readManifest(file, name)
+	if err != nil {
+		return err
+	}
+	if _, ok := ociVersionLabel(content); !ok {
+		return fmt.Errorf("no literal org.opencontainers.image.version label found to update in %s", name)
+	}
+
+	content = ociVersionLabelPattern.ReplaceAllStringFunc(content, func(match string) string {
+		parts := ociVersionLabelPattern.FindStringSubmatch(match)
+		value := parts[2] + parts[3]
+		if value == "" || strings.Contains(value, "$") {
+			return match
+		}
+		newValue := styledVersion(value, version)
+		if parts[2] != "" {
+			newValue = `"` + newValue + `"`
+		}
+		return parts[1] + newValue
+	})
+	return writeManifest(path, name, content, version)
+}
+
+// ValidateVersion checks if the label matches the expected version
+func (m *DockerfileManager) ValidateVersion(file, expectedVersion string) error {
+	actualVersion, err := m.ExtractVersion(file)
+	if err != nil {
+		return err
+	}
+	return checkVersion(actualVersion, expectedVersion)
+}
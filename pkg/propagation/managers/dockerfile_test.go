@@ -0,0 +1,44 @@
+package managers
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDockerfileManager_UpdateVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "Dockerfile")
+	writeTestFile(t, file, `FROM alpine:3.20
+LABEL org.opencontainers.image.title="goneat" \
+      org.opencontainers.image.version="v1.2.3"
+`)
+	writeTestFile(t, filepath.Join(tmpDir, "deploy", "api.Dockerfile"), "FROM scratch\nLABEL org.opencontainers.image.version=1.2.3\n")
+	// Labels filled from build arguments are not propagation targets
+	writeTestFile(t, filepath.Join(tmpDir, "Containerfile"), "ARG VERSION\nLABEL org.opencontainers.image.version=\"${VERSION}\"\n")
+
+	manager := NewDockerfileManager()
+	files, err := manager.Detect(tmpDir)
+	if err != nil || len(files) != 2 {
+		t.Fatalf("Detect = %v, %v", files, err)
+	}
+
+	if err := manager.UpdateVersion(file, "1.3.0"); err != nil {
+		t.Fatalf("UpdateVersion failed: %v", err)
+	}
+	content := readTestFile(t, file)
+	if !strings.Contains(content, `org.opencontainers.image.version="v1.3.0"`) || !strings.Contains(content, `image.title="goneat" \`) {
+		t.Errorf("Unexpected Dockerfile:\n%s", content)
+	}
+	if err := manager.ValidateVersion(file, "1.3.0"); err != nil {
+		t.Errorf("ValidateVersion failed: %v", err)
+	}
+
+	unquoted := filepath.Join(tmpDir, "deploy", "api.Dockerfile")
+	if err := manager.UpdateVersion(unquoted, "v1.3.0"); err != nil {
+		t.Fatalf("UpdateVersion failed: %v", err)
+	}
+	if content := readTestFile(t, unquoted); !strings.Contains(content, "org.opencontainers.image.version=1.3.0\n") {
+		t.Errorf("Unexpected api.Dockerfile:\n%s", content)
+	}
+}
@@ -0,0 +1,95 @@
+package managers
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DotNetManager handles MSBuild *.csproj and Directory.Build.props files
+// that set <Version>
+type DotNetManager struct{}
+
+// NewDotNetManager creates a new .NET project manager
+func NewDotNetManager() *DotNetManager {
+	return &DotNetManager{}
+}
+
+// Name returns the name of this package manager
+func (m *DotNetManager) Name() string {
+	return "csproj"
+}
+
+var msbuildVersionPattern = regexp.MustCompile(`(<Version>[ \t]*)([^<]*?)([ \t]*</Version>)`)
+
+// msbuildVersion returns the first literal <Version> value; MSBuild property
+// expressions such as $(VersionPrefix) are not literal
+func msbuildVersion(content string) (string, bool) {
+	for _, m := range msbuildVersionPattern.FindAllStringSubmatch(content, -1) {
+		if m[2] != "" && !strings.Contains(m[2], "$(") {
+			return m[2], true
+		}
+	}
+	return "", false
+}
+
+// Detect finds *.csproj and Directory.Build.props files that set <Version>.
+// Projects inheriting the version from Directory.Build.props are skipped.
+func (m *DotNetManager) Detect(root string) ([]string, error) {
+	return detectFiles(root, "MSBuild", []string{"bin", "obj"}, func(path, name string) bool {
+		if name != "Directory.Build.props" && !strings.HasSuffix(name, ".csproj") {
+			return false
+		}
+		_, content, err := readManifest(path, name)
+		if err != nil {
+			return false
+		}
+		_, ok := msbuildVersion(content)
+		return ok
+	})
+}
+
+// ExtractVersion reads <Version> from an MSBuild project file
+func (m *DotNetManager) ExtractVersion(file string) (string, error) {
+	_, content, err := readManifest(file, filepath.Base(file))
+	if err != nil {
+		return "", err
+	}
+	version, ok := msbuildVersion(content)
+	if !ok {
+		return "", fmt.Errorf("no literal <Version> found in %s", filepath.Base(file))
+	}
+	return version, nil
+}
+
+// UpdateVersion rewrites every literal <Version> element, including those in
+// conditional property groups
+func (m *DotNetManager) UpdateVersion(file, version string) error {
+	name := filepath.Base(file)
+	path, content, err := readManifest(file, name)
+	if err != nil {
+		return err
+	}
+	if _, ok := msbuildVersion(content); !ok {
+		return fmt.Errorf("no literal <Version> found to update in %s", name)
+	}
+
+	content = msbuildVersionPattern.ReplaceAllStringFunc(content, func(match string) string {
+		parts := msbuildVersionPattern.FindStringSubmatch(match)
+		if parts[2] == "" || strings.Contains(parts[2], "$(") {
+			return match
+		}
+		return parts[1] + plainVersion(version) + parts[3]
+	})
+	return writeManifest(path, name, content, version)
+}
+
+// ValidateVersion checks if the version in the file matches the expected version
+func (m *DotNetManager) ValidateVersion(file, expectedVersion string) error {
+	actualVersion, err := m.ExtractVersion(file)
+	if err != nil {
+		return err
+	}
+	return checkVersion(actualVersion, expectedVersion)
+}
@@ -0,0 +1,47 @@
+package managers
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDotNetManager_UpdateVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	props := filepath.Join(tmpDir, "Directory.Build.props")
+	project := filepath.Join(tmpDir, "src", "Api", "Api.csproj")
+	writeTestFile(t, props, `<Project>
+  <PropertyGroup>
+    <Version>1.2.3</Version>
+    <AssemblyVersion>$(Version)</AssemblyVersion>
+  </PropertyGroup>
+</Project>
+`)
+	writeTestFile(t, project, `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+  </PropertyGroup>
+</Project>
+`)
+	writeTestFile(t, filepath.Join(tmpDir, "src", "Api", "obj", "Api.csproj"), "<Project><PropertyGroup><Version>0.0.1</Version></PropertyGroup></Project>")
+
+	manager := NewDotNetManager()
+	files, err := manager.Detect(tmpDir)
+	if err != nil || len(files) != 1 || files[0] != "Directory.Build.props" {
+		t.Fatalf("Detect = %v, %v", files, err)
+	}
+	if _, err := manager.ExtractVersion(project); err == nil {
+		t.Error("Expected error for project without <Version>")
+	}
+
+	if err := manager.UpdateVersion(props, "v1.3.0"); err != nil {
+		t.Fatalf("UpdateVersion failed: %v", err)
+	}
+	content := readTestFile(t, props)
+	if !strings.Contains(content, "<Version>1.3.0</Version>") || !strings.Contains(content, "<AssemblyVersion>$(Version)</AssemblyVersion>") {
+		t.Errorf("Unexpected Directory.Build.props:\n%s", content)
+	}
+	if err := manager.ValidateVersion(props, "1.3.0"); err != nil {
+		t.Errorf("ValidateVersion failed: %v", err)
+	}
+}
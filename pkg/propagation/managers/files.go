@@ -0,0 +1,99 @@
+package managers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/logger"
+	"github.com/fulmenhq/goneat/pkg/safeio"
+)
+
+// Directories skipped by every manager in addition to its own build outputs
+var vcsSkipDirs = []string{".git", ".svn", ".hg", "node_modules"}
+
+// detectFiles walks root and returns the paths, relative to root, of files
+// accepted by match. Directories named in skipDirs (and VCS metadata) are not
+// descended into.
+func detectFiles(root, kind string, skipDirs []string, match func(path, name string) bool) ([]string, error) {
+	skip := make(map[string]bool, len(skipDirs)+len(vcsSkipDirs))
+	for _, dir := range append(skipDirs, vcsSkipDirs...) {
+		skip[dir] = true
+	}
+
+	var files []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if skip[info.Name()] && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if match(path, info.Name()) {
+			// Convert to relative path from root for consistent handling
+			relPath, err := filepath.Rel(root, path)
+			if err != nil {
+				relPath = path // Fallback to absolute if relative fails
+			}
+			files = append(files, relPath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect %s files: %w", kind, err)
+	}
+
+	logger.Debug("Detected "+kind+" files", logger.Int("count", len(files)))
+	return files, nil
+}
+
+// readManifest resolves file to an absolute path and reads it
+func readManifest(file, kind string) (string, string, error) {
+	// Validate file path to prevent path traversal
+	validatedPath, err := filepath.Abs(file)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve file path: %w", err)
+	}
+
+	data, err := os.ReadFile(validatedPath) // #nosec G304 - path validated with filepath.Abs above
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read %s: %w", kind, err)
+	}
+	return validatedPath, string(data), nil
+}
+
+// writeManifest writes updated content back to a file read by readManifest
+func writeManifest(path, kind, content, version string) error {
+	if err := safeio.WriteFileValidated(path, []byte(content), 0o600); err != nil {
+		return fmt.Errorf("failed to write updated %s: %w", kind, err)
+	}
+	logger.Info("Updated "+kind+" version", logger.String("file", path), logger.String("version", version))
+	return nil
+}
+
+// plainVersion strips the "v" prefix used by VERSION files and git tags;
+// Cargo, Helm, Maven, Gradle and MSBuild all expect bare versions.
+func plainVersion(version string) string {
+	return strings.TrimPrefix(strings.TrimSpace(version), "v")
+}
+
+// styledVersion renders version with or without a "v" prefix to match the
+// existing value, for free-form fields such as Helm appVersion.
+func styledVersion(existing, version string) string {
+	if strings.HasPrefix(existing, "v") {
+		return "v" + plainVersion(version)
+	}
+	return plainVersion(version)
+}
+
+// checkVersion compares versions ignoring a "v" prefix on either side
+func checkVersion(actual, expected string) error {
+	if plainVersion(actual) != plainVersion(expected) {
+		return fmt.Errorf("version mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package managers
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// GradleManager handles build.gradle, build.gradle.kts and gradle.properties
+// files that assign a literal project version
+type GradleManager struct{}
+
+// NewGradleManager creates a new Gradle package manager
+func NewGradleManager() *GradleManager {
+	return &GradleManager{}
+}
+
+// Name returns the name of this package manager
+func (m *GradleManager) Name() string {
+	return "build.gradle"
+}
+
+var (
+	// version = '1.2.3' / version "1.2.3" (Groovy) and version = "1.2.3" (Kotlin DSL)
+	gradleScriptVersionPattern = regexp.MustCompile(`(?m)^([ \t]*version[ \t]*=?[ \t]*)(["'])([^"'\r\n]*)(["'])`)
+	// version=1.2.3 or version: 1.2.3
+	gradlePropertiesVersionPattern = regexp.MustCompile(`(?m)^([ \t]*version[ \t]*[=:][ \t]*)([^\s#!][^\r\n]*?)([ \t]*\r?)$`)
+)
+
+func isGradleFile(name string) bool {
+	return name == "build.gradle" || name == "build.gradle.kts" || name == "gradle.properties"
+}
+
+func gradlePattern(file string) (*regexp.Regexp, int) {
+	if filepath.Base(file) == "gradle.properties" {
+		return gradlePropertiesVersionPattern, 2
+	}
+	return gradleScriptVersionPattern, 3
+}
+
+// gradleVersion returns the first literal version assignment in content
+func gradleVersion(file, content string) (string, bool) {
+	pattern, group := gradlePattern(file)
+	m := pattern.FindStringSubmatch(content)
+	if m == nil || m[group] == "" || strings.Contains(m[group], "$") {
+		return "", false
+	}
+	return m[group], true
+}
+
+// Detect finds Gradle build files and gradle.properties that assign a version
+func (m *GradleManager) Detect(root string) ([]string, error) {
+	return detectFiles(root, "Gradle", []string{"build", ".gradle"}, func(path, name string) bool {
+		if !isGradleFile(name) {
+			return false
+		}
+		_, content, err := readManifest(path, name)
+		if err != nil {
+			return false
+		}
+		_, ok := gradleVersion(path, content)
+		return ok
+	})
+}
+
+// ExtractVersion reads the project version from a Gradle file
+func (m *GradleManager) ExtractVersion(file string) (string, error) {
+	_, content, err := readManifest(file, filepath.Base(file))
+	if err != nil {
+		return "", err
+	}
+	version, ok := gradleVersion(file, content)
+	if !ok {
+		return "", fmt.Errorf("no literal version assignment found in %s", filepath.Base(file))
+	}
+	return version, nil
+}
+
+// UpdateVersion rewrites every literal version assignment, keeping quoting
+// and layout
+func (m *GradleManager) UpdateVersion(file, version string) error {
+	name := filepath.Base(file)
+	path, content, err := readManifest(file, name)
+	if err != nil {
+		return err
+	}
+	if _, ok := gradleVersion(file, content); !ok {
+		return fmt.Errorf("no literal version assignment found to update in %s", name)
+	}
+
+	pattern, group := gradlePattern(file)
+	content = pattern.ReplaceAllStringFunc(content, func(match string) string {
+		parts := pattern.FindStringSubmatch(match)
+		if strings.Contains(parts[group], "$") {
+			return match
+		}
+		parts[group] = plainVersion(version)
+		return strings.Join(parts[1:], "")
+	})
+	return writeManifest(path, name, content, version)
+}
+
+// ValidateVersion checks if the version in the file matches the expected version
+func (m *GradleManager) ValidateVersion(file, expectedVersion string) error {
+	actualVersion, err := m.ExtractVersion(file)
+	if err != nil {
+		return err
+	}
+	return checkVersion(actualVersion, expectedVersion)
+}
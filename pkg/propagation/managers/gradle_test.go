@@ -0,0 +1,53 @@
+package managers
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGradleManager_Files(t *testing.T) {
+	tmpDir := t.TempDir()
+	groovy := filepath.Join(tmpDir, "build.gradle")
+	kts := filepath.Join(tmpDir, "app", "build.gradle.kts")
+	props := filepath.Join(tmpDir, "lib", "gradle.properties")
+	writeTestFile(t, groovy, `plugins {
+    id 'org.springframework.boot' version '3.2.0'
+}
+
+group = 'dev.example'
+version = '1.2.3'
+`)
+	writeTestFile(t, kts, "plugins {\n    kotlin(\"jvm\") version \"1.9.0\"\n}\n\nversion = \"1.2.3\"\n")
+	writeTestFile(t, props, "org.gradle.jvmargs=-Xmx2g\nversion=1.2.3\n")
+	// Computed versions are not propagation targets
+	writeTestFile(t, filepath.Join(tmpDir, "computed", "build.gradle"), "version = \"${rootProject.version}\"\n")
+
+	manager := NewGradleManager()
+	files, err := manager.Detect(tmpDir)
+	if err != nil || len(files) != 3 {
+		t.Fatalf("Detect = %v, %v", files, err)
+	}
+
+	for _, file := range []string{groovy, kts, props} {
+		if version, err := manager.ExtractVersion(file); err != nil || version != "1.2.3" {
+			t.Errorf("ExtractVersion(%s) = %q, %v", file, version, err)
+		}
+		if err := manager.UpdateVersion(file, "v1.4.0"); err != nil {
+			t.Fatalf("UpdateVersion(%s) failed: %v", file, err)
+		}
+		if err := manager.ValidateVersion(file, "1.4.0"); err != nil {
+			t.Errorf("ValidateVersion(%s) failed: %v", file, err)
+		}
+	}
+
+	if content := readTestFile(t, groovy); !strings.Contains(content, "version = '1.4.0'") || !strings.Contains(content, "version '3.2.0'") {
+		t.Errorf("Unexpected build.gradle:\n%s", content)
+	}
+	if content := readTestFile(t, kts); !strings.Contains(content, `version = "1.4.0"`) || !strings.Contains(content, `version "1.9.0"`) {
+		t.Errorf("Unexpected build.gradle.kts:\n%s", content)
+	}
+	if content := readTestFile(t, props); content != "org.gradle.jvmargs=-Xmx2g\nversion=1.4.0\n" {
+		t.Errorf("Unexpected gradle.properties:\n%s", content)
+	}
+}
@@ -0,0 +1,111 @@
+package managers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HelmManager handles Helm Chart.yaml files (version and appVersion)
+type HelmManager struct{}
+
+// NewHelmManager creates a new Helm chart manager
+func NewHelmManager() *HelmManager {
+	return &HelmManager{}
+}
+
+// Name returns the name of this package manager
+func (m *HelmManager) Name() string {
+	return "Chart.yaml"
+}
+
+// Detect finds Chart.yaml files in the given root directory
+func (m *HelmManager) Detect(root string) ([]string, error) {
+	return detectFiles(root, "Chart.yaml", nil, func(_, name string) bool {
+		return name == "Chart.yaml"
+	})
+}
+
+type helmChart struct {
+	Version    string `yaml:"version"`
+	AppVersion string `yaml:"appVersion"`
+}
+
+func (m *HelmManager) readChart(file string) (string, string, *helmChart, error) {
+	path, content, err := readManifest(file, "Chart.yaml")
+	if err != nil {
+		return "", "", nil, err
+	}
+	var chart helmChart
+	if err := yaml.Unmarshal([]byte(content), &chart); err != nil {
+		return "", "", nil, fmt.Errorf("failed to parse Chart.yaml: %w", err)
+	}
+	return path, content, &chart, nil
+}
+
+// ExtractVersion reads the chart version from a Chart.yaml file
+func (m *HelmManager) ExtractVersion(file string) (string, error) {
+	_, _, chart, err := m.readChart(file)
+	if err != nil {
+		return "", err
+	}
+	if chart.Version == "" {
+		return "", fmt.Errorf("no version field found in Chart.yaml")
+	}
+	return chart.Version, nil
+}
+
+// UpdateVersion sets the chart version and, when present, appVersion. The
+// chart version is always a bare SemVer; appVersion keeps its "v" style.
+func (m *HelmManager) UpdateVersion(file, version string) error {
+	path, content, chart, err := m.readChart(file)
+	if err != nil {
+		return err
+	}
+
+	content, ok := replaceYAMLScalar(content, "version", plainVersion(version))
+	if !ok {
+		return fmt.Errorf("no top-level version field found to update in Chart.yaml")
+	}
+	if chart.AppVersion != "" {
+		content, _ = replaceYAMLScalar(content, "appVersion", styledVersion(chart.AppVersion, version))
+	}
+
+	return writeManifest(path, "Chart.yaml", content, version)
+}
+
+// replaceYAMLScalar rewrites a top-level "key: value" line, keeping quoting
+// and trailing comments
+func replaceYAMLScalar(content, key, value string) (string, bool) {
+	pattern := regexp.MustCompile(`^(` + regexp.QuoteMeta(key) + `:[ \t]*)(["']?)[^"'#\s]*(["']?)(.*)$`)
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if m := pattern.FindStringSubmatch(line); m != nil {
+			lines[i] = m[1] + m[2] + value + m[3] + m[4]
+			return strings.Join(lines, "\n"), true
+		}
+	}
+	return content, false
+}
+
+// ValidateVersion checks that version and appVersion match the expected version
+func (m *HelmManager) ValidateVersion(file, expectedVersion string) error {
+	_, _, chart, err := m.readChart(file)
+	if err != nil {
+		return err
+	}
+	if chart.Version == "" {
+		return fmt.Errorf("no version field found in Chart.yaml")
+	}
+	if err := checkVersion(chart.Version, expectedVersion); err != nil {
+		return err
+	}
+	if chart.AppVersion != "" {
+		if err := checkVersion(chart.AppVersion, expectedVersion); err != nil {
+			return fmt.Errorf("appVersion %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package managers
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHelmManager_UpdateVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "charts", "api", "Chart.yaml")
+	writeTestFile(t, file, `apiVersion: v2
+name: api
+version: 0.4.0 # chart version
+appVersion: "v0.4.0"
+dependencies:
+  - name: redis
+    version: 17.0.0
+`)
+
+	manager := NewHelmManager()
+	files, err := manager.Detect(tmpDir)
+	if err != nil || len(files) != 1 {
+		t.Fatalf("Detect = %v, %v", files, err)
+	}
+	if version, err := manager.ExtractVersion(file); err != nil || version != "0.4.0" {
+		t.Fatalf("ExtractVersion = %q, %v", version, err)
+	}
+
+	if err := manager.UpdateVersion(file, "v0.5.0"); err != nil {
+		t.Fatalf("UpdateVersion failed: %v", err)
+	}
+	content := readTestFile(t, file)
+	for _, want := range []string{"version: 0.5.0 # chart version\n", `appVersion: "v0.5.0"`, "    version: 17.0.0"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected %q in updated Chart.yaml:\n%s", want, content)
+		}
+	}
+	if err := manager.ValidateVersion(file, "0.5.0"); err != nil {
+		t.Errorf("ValidateVersion failed: %v", err)
+	}
+}
+
+func TestHelmManager_ValidateAppVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "Chart.yaml")
+	writeTestFile(t, file, "apiVersion: v2\nname: api\nversion: 1.0.0\nappVersion: 0.9.0\n")
+
+	err := NewHelmManager().ValidateVersion(file, "1.0.0")
+	if err == nil || !strings.Contains(err.Error(), "appVersion") {
+		t.Errorf("Expected appVersion mismatch, got %v", err)
+	}
+}
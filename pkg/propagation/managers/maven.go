@@ -0,0 +1,158 @@
+package managers
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// MavenManager handles Maven pom.xml files. The project's own <version> is
+// updated; CI-friendly ${revision}-style versions update the referenced
+// <properties> entry instead.
+type MavenManager struct{}
+
+// NewMavenManager creates a new Maven package manager
+func NewMavenManager() *MavenManager {
+	return &MavenManager{}
+}
+
+// Name returns the name of this package manager
+func (m *MavenManager) Name() string {
+	return "pom.xml"
+}
+
+// Detect finds pom.xml files that declare their own version. Modules that
+// inherit the version from <parent> are skipped.
+func (m *MavenManager) Detect(root string) ([]string, error) {
+	return detectFiles(root, "pom.xml", []string{"target", ".mvn"}, func(path, name string) bool {
+		if name != "pom.xml" {
+			return false
+		}
+		_, content, err := readManifest(path, "pom.xml")
+		if err != nil {
+			return false
+		}
+		_, ok, err := pomVersionElement(content)
+		return err == nil && ok
+	})
+}
+
+// xmlText is the location of an element's text content
+type xmlText struct {
+	start, end int
+	value      string
+}
+
+var mavenPropertyPattern = regexp.MustCompile(`^\$\{([\w.-]+)\}$`)
+
+// pomVersionElement locates the element holding the project version,
+// following a ${property} reference into <properties>
+func pomVersionElement(content string) (xmlText, bool, error) {
+	text, ok, err := xmlElementText(content, "project", "version")
+	if err != nil || !ok {
+		return text, ok, err
+	}
+	if m := mavenPropertyPattern.FindStringSubmatch(text.value); m != nil {
+		prop, ok, err := xmlElementText(content, "project", "properties", m[1])
+		if err != nil {
+			return prop, false, err
+		}
+		if !ok {
+			return prop, false, fmt.Errorf("<version> references ${%s} but <properties> does not define it", m[1])
+		}
+		return prop, true, nil
+	}
+	return text, true, nil
+}
+
+// xmlElementText finds the first element at the given path from the
+// document root and returns the byte range of its text content
+func xmlElementText(content string, path ...string) (xmlText, bool, error) {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	var stack []string
+	capturing := false
+	var text xmlText
+	for {
+		offset := int(decoder.InputOffset())
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return xmlText{}, false, nil
+		}
+		if err != nil {
+			return xmlText{}, false, fmt.Errorf("failed to parse XML: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+			if !capturing && equalPath(stack, path) {
+				capturing = true
+				text.start = int(decoder.InputOffset())
+			}
+		case xml.EndElement:
+			if capturing && equalPath(stack, path) {
+				text.end = offset
+				text.value = strings.TrimSpace(content[text.start:text.end])
+				return text, true, nil
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
+
+func equalPath(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ExtractVersion reads the project version from a pom.xml file
+func (m *MavenManager) ExtractVersion(file string) (string, error) {
+	_, content, err := readManifest(file, "pom.xml")
+	if err != nil {
+		return "", err
+	}
+	text, ok, err := pomVersionElement(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse pom.xml: %w", err)
+	}
+	if !ok || text.value == "" {
+		return "", fmt.Errorf("no <version> found in <project> (inherited from <parent>?)")
+	}
+	return text.value, nil
+}
+
+// UpdateVersion replaces the project version text, leaving the rest of the
+// document byte-for-byte unchanged
+func (m *MavenManager) UpdateVersion(file, version string) error {
+	path, content, err := readManifest(file, "pom.xml")
+	if err != nil {
+		return err
+	}
+	text, ok, err := pomVersionElement(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse pom.xml: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("no <version> found to update in <project>")
+	}
+	content = content[:text.start] + plainVersion(version) + content[text.end:]
+	return writeManifest(path, "pom.xml", content, version)
+}
+
+// ValidateVersion checks if the version in the file matches the expected version
+func (m *MavenManager) ValidateVersion(file, expectedVersion string) error {
+	actualVersion, err := m.ExtractVersion(file)
+	if err != nil {
+		return err
+	}
+	return checkVersion(actualVersion, expectedVersion)
+}
@@ -0,0 +1,76 @@
+package managers
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testPom = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <modelVersion>4.0.0</modelVersion>
+  <parent>
+    <groupId>dev.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>3.1.0</version>
+  </parent>
+  <artifactId>service</artifactId>
+  <version>1.2.3</version>
+  <dependencies>
+    <dependency>
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+      <version>4.13.2</version>
+    </dependency>
+  </dependencies>
+</project>
+`
+
+func TestMavenManager_UpdateVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "pom.xml")
+	writeTestFile(t, file, testPom)
+	// Module inheriting its version from the parent is not detected
+	writeTestFile(t, filepath.Join(tmpDir, "module", "pom.xml"), `<project><parent><version>1.2.3</version></parent><artifactId>module</artifactId></project>`)
+
+	manager := NewMavenManager()
+	files, err := manager.Detect(tmpDir)
+	if err != nil || len(files) != 1 || files[0] != "pom.xml" {
+		t.Fatalf("Detect = %v, %v", files, err)
+	}
+	if version, err := manager.ExtractVersion(file); err != nil || version != "1.2.3" {
+		t.Fatalf("ExtractVersion = %q, %v", version, err)
+	}
+
+	if err := manager.UpdateVersion(file, "v1.3.0"); err != nil {
+		t.Fatalf("UpdateVersion failed: %v", err)
+	}
+	want := strings.Replace(testPom, "<version>1.2.3</version>", "<version>1.3.0</version>", 1)
+	if got := readTestFile(t, file); got != want {
+		t.Errorf("Only the project version should change:\n%s", got)
+	}
+	if err := manager.ValidateVersion(file, "1.3.0"); err != nil {
+		t.Errorf("ValidateVersion failed: %v", err)
+	}
+}
+
+func TestMavenManager_RevisionProperty(t *testing.T) {
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "pom.xml")
+	writeTestFile(t, file, `<project>
+  <version>${revision}</version>
+  <properties>
+    <revision>2.0.0-SNAPSHOT</revision>
+  </properties>
+</project>
+`)
+
+	manager := NewMavenManager()
+	if err := manager.UpdateVersion(file, "2.0.0"); err != nil {
+		t.Fatalf("UpdateVersion failed: %v", err)
+	}
+	content := readTestFile(t, file)
+	if !strings.Contains(content, "<version>${revision}</version>") || !strings.Contains(content, "<revision>2.0.0</revision>") {
+		t.Errorf("Expected revision property update:\n%s", content)
+	}
+}
@@ -48,12 +48,17 @@ type BackupConfig struct {
 	Retention int  `yaml:"retention"` // number of backups to keep
 }
 
-// PropagationTarget defines propagation settings for a specific package manager
+// PropagationTarget defines propagation settings for a specific package manager,
+// or a custom regex/path target when Type is "custom"
 type PropagationTarget struct {
 	Include      []string `yaml:"include,omitempty"`
 	Exclude      []string `yaml:"exclude,omitempty"`
-	Mode         string   `yaml:"mode,omitempty"` // project | poetry | workspace
-	ValidateOnly bool     `yaml:"validate_only"`  // explicit to avoid accidental write attempts
+	Mode         string   `yaml:"mode,omitempty"`   // project | poetry | workspace
+	ValidateOnly bool     `yaml:"validate_only"`    // explicit to avoid accidental write attempts
+	Type         string   `yaml:"type,omitempty"`   // custom for regex/path targets
+	Regex        string   `yaml:"regex,omitempty"`  // custom: pattern with a named (?P<version>...) group
+	Path         string   `yaml:"path,omitempty"`   // custom: JSONPath/YAML path/TOML key, e.g. $.info.version
+	Format       string   `yaml:"format,omitempty"` // custom: json | yaml | toml (default: from file extension)
 }
 
 // WorkspaceConfig defines workspace-specific behavior
@@ -196,6 +201,15 @@ func (pl *PolicyLoader) validatePolicy(policy *VersionPolicy) error {
 		if target.Mode != "" && target.Mode != "project" && target.Mode != "poetry" && target.Mode != "workspace" {
 			return fmt.Errorf("invalid mode for target %s: %s (must be project, poetry, or workspace)", name, target.Mode)
 		}
+		switch target.Type {
+		case "":
+		case CustomTargetType:
+			if _, err := NewCustomTarget(name, target); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("invalid type for target %s: %s (must be custom or omitted)", name, target.Type)
+		}
 	}
 
 	// Validate workspace strategy
@@ -265,6 +279,25 @@ propagation:
   #
   #   go.mod:
   #     validate_only: true   # explicit to avoid accidental write attempts
+  #
+  # Also available (add the name to defaults.include or configure a target):
+  #   Cargo.toml   - [package]/[workspace.package] version; version.workspace members follow the root
+  #   Chart.yaml   - Helm chart version and appVersion
+  #   pom.xml      - project <version> (or the ${revision} property it references)
+  #   build.gradle - version in build.gradle, build.gradle.kts and gradle.properties
+  #   csproj       - <Version> in *.csproj and Directory.Build.props
+  #   Dockerfile   - LABEL org.opencontainers.image.version
+  #
+  # Custom targets update versions anywhere else (README snippets, install.sh, OpenAPI):
+  #   readme-install:
+  #     type: custom
+  #     include: ["README.md", "docs/**/*.md"]
+  #     regex: 'goneat@(?P<version>v?\d+\.\d+\.\d+)'   # named "version" group
+  #
+  #   openapi:
+  #     type: custom
+  #     include: ["api/openapi.yaml"]
+  #     path: $.info.version   # JSONPath/YAML path/TOML key; format inferred from extension
 
 # rules:  # Content validation rules (Phase 3a)
 #   allowed_channels: ["stable", "beta"]
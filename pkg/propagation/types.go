@@ -109,18 +109,20 @@ type FileChange struct {
 
 // NewPropagator creates a new version propagator
 func NewPropagator(registry *Registry) *Propagator {
-	// Initialize pathfinder components for pattern matching (dogfooding our own library)
-	validator := pathfinder.NewSafetyValidator()
-	validator.SetAllowSymlinks(true)
-	engine := pathfinder.NewDiscoveryEngine(validator)
-
 	return &Propagator{
 		registry: registry,
 		policy:   NewPolicyLoader(),
-		engine:   engine,
+		engine:   newMatchEngine(),
 	}
 }
 
+// newMatchEngine initializes pathfinder components for pattern matching (dogfooding our own library)
+func newMatchEngine() *pathfinder.DiscoveryEngine {
+	validator := pathfinder.NewSafetyValidator()
+	validator.SetAllowSymlinks(true)
+	return pathfinder.NewDiscoveryEngine(validator)
+}
+
 // filterManagersByPolicy filters package managers based on policy include/exclude rules
 func (p *Propagator) filterManagersByPolicy(managers []PackageManager, policy *VersionPolicy) []PackageManager {
 	filtered := make([]PackageManager, 0, len(managers))
@@ -252,9 +254,17 @@ func (p *Propagator) filterFilesByPolicy(files []string, managerName string, pol
 		excludePatterns = target.Exclude
 	}
 
-	// If no target-specific includes, use defaults
+	// If no target-specific includes, use defaults. Defaults name package
+	// managers, so listing this manager admits every file it detected (e.g.
+	// "build.gradle" also covers build.gradle.kts and gradle.properties).
 	if len(includePatterns) == 0 {
 		includePatterns = policy.Propagation.Defaults.Include
+		for _, include := range includePatterns {
+			if include == managerName {
+				includePatterns = nil
+				break
+			}
+		}
 	}
 
 	// Always apply default excludes
@@ -344,25 +354,9 @@ func (p *Propagator) Propagate(ctx context.Context, version string, opts Propaga
 
 	logger.Debug("Propagation started", logger.String("version", version))
 
-	targetManagers := p.registry.List()
-
-	// Apply policy-driven filtering when no explicit targets specified
-	if len(opts.Targets) == 0 {
-		targetManagers = p.filterManagersByPolicy(targetManagers, policy)
-	} else {
-		// Explicit targets override policy
-		targetMap := make(map[string]struct{}, len(opts.Targets))
-		for _, target := range opts.Targets {
-			targetMap[strings.ToLower(target)] = struct{}{}
-		}
-
-		filtered := make([]PackageManager, 0, len(targetManagers))
-		for _, manager := range targetManagers {
-			if _, ok := targetMap[strings.ToLower(manager.Name())]; ok {
-				filtered = append(filtered, manager)
-			}
-		}
-		targetManagers = filtered
+	targetManagers, err := p.selectManagers(policy, opts.Targets)
+	if err != nil {
+		return nil, err
 	}
 
 	logger.Debug("Managers selected for propagation", logger.Int("count", len(targetManagers)))
@@ -372,6 +366,18 @@ func (p *Propagator) Propagate(ctx context.Context, version string, opts Propaga
 		Processed: 0,
 	}
 
+	// Custom targets are written through a staging workspace and applied
+	// together, with backups and rollback
+	var staging *StagingWorkspace
+	var staged []FileChange
+	defer func() {
+		if staging != nil {
+			if err := staging.Cleanup(); err != nil {
+				logger.Debug("Failed to clean up staging workspace", logger.String("error", err.Error()))
+			}
+		}
+	}()
+
 	for _, manager := range targetManagers {
 		select {
 		case <-ctx.Done():
@@ -435,13 +441,31 @@ func (p *Propagator) Propagate(ctx context.Context, version string, opts Propaga
 					continue
 				}
 
-				// Skip if already at correct version
-				if oldVersion == version {
+				// Skip if already at correct version; custom targets can hold
+				// several occurrences, so all of them must match
+				_, custom := manager.(*CustomTarget)
+				if (!custom && oldVersion == version) || (custom && manager.ValidateVersion(file, version) == nil) {
 					logger.Debug("File already at correct version", logger.String("file", file))
 					continue
 				}
 
-				if !opts.DryRun {
+				if custom && !opts.DryRun {
+					if staging == nil {
+						if staging, err = NewStagingWorkspace(); err != nil {
+							return nil, err
+						}
+					}
+					if err := stageChange(staging, manager, file, version); err != nil {
+						result.Errors = append(result.Errors, PropagationError{
+							File:    file,
+							Error:   err,
+							Message: fmt.Sprintf("failed to stage version update for %s", file),
+						})
+						result.Success = false
+						continue
+					}
+					staged = append(staged, FileChange{File: file, OldVersion: oldVersion, NewVersion: version})
+				} else if !opts.DryRun {
 					// Update version
 					if err := manager.UpdateVersion(file, version); err != nil {
 						result.Errors = append(result.Errors, PropagationError{
@@ -467,6 +491,127 @@ func (p *Propagator) Propagate(ctx context.Context, version string, opts Propaga
 		}
 	}
 
+	if len(staged) > 0 {
+		backup := policy.Propagation.Defaults.Backup
+		if err := staging.ApplyChanges(staged, opts.Backup || backup.Enabled, backup.Retention); err != nil {
+			result.Errors = append(result.Errors, PropagationError{
+				Error:   err,
+				Message: fmt.Sprintf("failed to apply staged changes: %v", err),
+			})
+			result.Success = false
+		}
+	}
+
 	result.Duration = time.Since(start)
 	return result, nil
 }
+
+// selectManagers returns the registered managers plus the policy's custom
+// targets, narrowed by explicit targets or, without them, by policy
+func (p *Propagator) selectManagers(policy *VersionPolicy, targets []string) ([]PackageManager, error) {
+	targetManagers := p.registry.List()
+	customTargets, err := CustomTargets(policy)
+	if err != nil {
+		return nil, err
+	}
+	for _, custom := range customTargets {
+		targetManagers = append(targetManagers, custom)
+	}
+
+	// Apply policy-driven filtering when no explicit targets specified
+	if len(targets) == 0 {
+		return p.filterManagersByPolicy(targetManagers, policy), nil
+	}
+
+	// Explicit targets override policy
+	targetMap := make(map[string]struct{}, len(targets))
+	for _, target := range targets {
+		targetMap[strings.ToLower(target)] = struct{}{}
+	}
+
+	filtered := make([]PackageManager, 0, len(targetManagers))
+	for _, manager := range targetManagers {
+		if _, ok := targetMap[strings.ToLower(manager.Name())]; ok {
+			filtered = append(filtered, manager)
+		}
+	}
+	return filtered, nil
+}
+
+// stageChange updates a staged copy of file and validates it before the
+// change is applied
+func stageChange(staging *StagingWorkspace, manager PackageManager, file, version string) error {
+	absPath, err := filepath.Abs(file)
+	if err != nil {
+		return fmt.Errorf("failed to resolve file path: %w", err)
+	}
+	stagePath, err := staging.StageFile(absPath)
+	if err != nil {
+		return err
+	}
+	if err := manager.UpdateVersion(stagePath, version); err != nil {
+		return err
+	}
+	return manager.ValidateVersion(stagePath, version)
+}
+
+// ConsistencyReport lists version locations that disagree with VERSION
+type ConsistencyReport struct {
+	Checked    int
+	Mismatches []VersionLocation
+	Errors     []PropagationError
+}
+
+// CheckConsistency compares every version location selected by the policy
+// against version without modifying files or evaluating guards. Custom
+// targets report each occurrence with its line number.
+func (p *Propagator) CheckConsistency(version, policyPath string) (*ConsistencyReport, error) {
+	policy, err := p.policy.LoadPolicy(policyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load version policy: %w", err)
+	}
+	targetManagers, err := p.selectManagers(policy, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ConsistencyReport{}
+	for _, manager := range targetManagers {
+		files, err := manager.Detect(".")
+		if err != nil {
+			report.Errors = append(report.Errors, PropagationError{
+				Error:   err,
+				Message: fmt.Sprintf("failed to detect files for %s: %v", manager.Name(), err),
+			})
+			continue
+		}
+
+		for _, file := range p.filterFilesByPolicy(files, manager.Name(), policy, nil) {
+			if custom, ok := manager.(*CustomTarget); ok {
+				locations, err := custom.Locations(file)
+				if err != nil {
+					report.Errors = append(report.Errors, PropagationError{File: file, Error: err, Message: err.Error()})
+					continue
+				}
+				for _, loc := range locations {
+					report.Checked++
+					if !sameVersion(loc.Version, version) {
+						report.Mismatches = append(report.Mismatches, loc)
+					}
+				}
+				continue
+			}
+
+			report.Checked++
+			if err := manager.ValidateVersion(file, version); err != nil {
+				actual, extractErr := manager.ExtractVersion(file)
+				if extractErr != nil {
+					report.Errors = append(report.Errors, PropagationError{File: file, Error: extractErr, Message: extractErr.Error()})
+					continue
+				}
+				report.Mismatches = append(report.Mismatches, VersionLocation{Target: manager.Name(), File: file, Version: actual})
+			}
+		}
+	}
+	return report, nil
+}
@@ -230,3 +230,22 @@ type testError struct {
 func (e *testError) Error() string {
 	return e.msg
 }
+
+func TestFilterFilesByPolicy_DefaultIncludeNamesManager(t *testing.T) {
+	propagator := NewPropagator(NewRegistry())
+	policy := NewPolicyLoader().createDefaultPolicy()
+	policy.Propagation.Defaults.Include = []string{"package.json", "build.gradle"}
+
+	files := []string{"build.gradle", "app/build.gradle.kts", "gradle.properties", "docs/build.gradle"}
+	got := propagator.filterFilesByPolicy(files, "build.gradle", policy, nil)
+	if len(got) != 3 {
+		t.Errorf("expected all detected Gradle files except docs/, got %v", got)
+	}
+
+	// Target-specific includes still narrow the selection
+	policy.Propagation.Targets["build.gradle"] = PropagationTarget{Include: []string{"gradle.properties"}}
+	got = propagator.filterFilesByPolicy(files, "build.gradle", policy, nil)
+	if len(got) != 1 || got[0] != "gradle.properties" {
+		t.Errorf("expected only gradle.properties, got %v", got)
+	}
+}
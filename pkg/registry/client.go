@@ -3,19 +3,31 @@ package registry
 import (
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
 )
 
 // Metadata for package
 type Metadata struct {
+	// Version is the resolved version; populated by clients that resolve "latest"
+	Version         string
 	PublishDate     time.Time
 	TotalDownloads  int
 	RecentDownloads int
 }
 
+// ErrModuleNotFound indicates the Go module proxy has no such module or version
+// (HTTP 404 or 410). Callers iterating a GOPROXY list fall through on this error.
+var ErrModuleNotFound = errors.New("module not found in proxy")
+
+// DefaultGoProxy is the public Go module proxy.
+const DefaultGoProxy = "https://proxy.golang.org"
+
 // Client interface
 type Client interface {
 	GetMetadata(name, version string) (*Metadata, error)
@@ -27,15 +39,16 @@ type cacheEntry struct {
 	expiry time.Time
 }
 
-// GoClient for pkg.go.dev API
+// GoClient for the Go module proxy protocol (proxy.golang.org by default)
 type GoClient struct {
-	cache   map[string]*cacheEntry
-	mu      sync.RWMutex
-	ttl     time.Duration
-	fetcher HTTPFetcher
+	proxyURL string
+	cache    map[string]*cacheEntry
+	mu       sync.RWMutex
+	ttl      time.Duration
+	fetcher  HTTPFetcher
 }
 
-// NewGoClient creates a GoClient with real HTTP for production use
+// NewGoClient creates a GoClient with real HTTP and the shared disk cache for production use
 func NewGoClient(ttl time.Duration) Client {
 	// Secure HTTP client with timeout and TLS verification
 	client := &http.Client{
@@ -47,18 +60,30 @@ func NewGoClient(ttl time.Duration) Client {
 		},
 	}
 
-	return NewGoClientWithFetcher(ttl, NewRealHTTPFetcher(client))
+	return WithDiskCache("go", NewGoClientWithFetcher(ttl, NewRealHTTPFetcher(client)), SharedDiskCache())
 }
 
 // NewGoClientWithFetcher creates a GoClient with injectable HTTP for testing
 func NewGoClientWithFetcher(ttl time.Duration, fetcher HTTPFetcher) Client {
+	return NewGoClientWithProxy(ttl, DefaultGoProxy, fetcher)
+}
+
+// NewGoClientWithProxy creates a GoClient for a specific GOPROXY base URL
+func NewGoClientWithProxy(ttl time.Duration, proxyURL string, fetcher HTTPFetcher) Client {
+	proxyURL = strings.TrimRight(proxyURL, "/")
+	if proxyURL == "" {
+		proxyURL = DefaultGoProxy
+	}
 	return &GoClient{
-		cache:   make(map[string]*cacheEntry),
-		ttl:     ttl,
-		fetcher: fetcher,
+		proxyURL: proxyURL,
+		cache:    make(map[string]*cacheEntry),
+		ttl:      ttl,
+		fetcher:  fetcher,
 	}
 }
 
+// GetMetadata fetches module info from the proxy. A version of "latest"
+// queries the proxy's @latest endpoint and reports the resolved version.
 func (c *GoClient) GetMetadata(name, version string) (*Metadata, error) {
 	key := fmt.Sprintf("%s@%s", name, version)
 	c.mu.RLock()
@@ -70,13 +95,23 @@ func (c *GoClient) GetMetadata(name, version string) (*Metadata, error) {
 	}
 
 	// Fetch from Go proxy API
-	proxyURL := fmt.Sprintf("https://proxy.golang.org/%s/@v/%s.info", name, version)
+	proxyURL := fmt.Sprintf("%s/%s/@v/%s.info", c.proxyURL, EscapeModulePath(name), EscapeModulePath(version))
+	if version == "latest" {
+		proxyURL = fmt.Sprintf("%s/%s/@latest", c.proxyURL, EscapeModulePath(name))
+	}
 	proxyResp, err := c.fetcher.Get(proxyURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch module info: %w", err)
 	}
 	defer func() { _ = proxyResp.Body.Close() }()
 
+	if proxyResp.StatusCode == http.StatusNotFound || proxyResp.StatusCode == http.StatusGone {
+		return nil, fmt.Errorf("%w: %s@%s (status %d)", ErrModuleNotFound, name, version, proxyResp.StatusCode)
+	}
+	if proxyResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("go proxy returned status %d", proxyResp.StatusCode)
+	}
+
 	var moduleInfo struct {
 		Version string    `json:"Version"`
 		Time    time.Time `json:"Time"`
@@ -91,6 +126,7 @@ func (c *GoClient) GetMetadata(name, version string) (*Metadata, error) {
 	age := time.Since(moduleInfo.Time)
 
 	meta := &Metadata{
+		Version:         moduleInfo.Version,
 		PublishDate:     moduleInfo.Time,
 		TotalDownloads:  1000, // Go proxy doesn't provide download stats
 		RecentDownloads: 100,  // Will need different source for these
@@ -106,6 +142,21 @@ func (c *GoClient) GetMetadata(name, version string) (*Metadata, error) {
 	return meta, nil
 }
 
+// EscapeModulePath applies the module proxy case-encoding: each upper-case
+// letter is replaced by "!" followed by its lower-case form.
+func EscapeModulePath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 // NewClient creates a registry client for the specified language
 func NewClient(lang string, ttl time.Duration) Client {
 	switch lang {
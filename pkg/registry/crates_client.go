@@ -18,7 +18,7 @@ type CratesClient struct {
 	fetcher HTTPFetcher
 }
 
-// NewCratesClient creates a CratesClient with real HTTP for production use
+// NewCratesClient creates a CratesClient with real HTTP and the shared disk cache for production use
 func NewCratesClient(ttl time.Duration) Client {
 	client := &http.Client{
 		Timeout: 30 * time.Second,
@@ -29,7 +29,7 @@ func NewCratesClient(ttl time.Duration) Client {
 		},
 	}
 
-	return NewCratesClientWithFetcher(ttl, NewRealHTTPFetcher(client))
+	return WithDiskCache("crates", NewCratesClientWithFetcher(ttl, NewRealHTTPFetcher(client)), SharedDiskCache())
 }
 
 // NewCratesClientWithFetcher creates a CratesClient with injectable HTTP for testing
@@ -0,0 +1,266 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/config"
+)
+
+// Disk cache TTL strategy:
+//
+// Publish dates for a concrete version never change once released, so versioned
+// lookups are kept for a long time. "latest" lookups move whenever a new release
+// ships and stay short-lived. Entries are shared across goneat runs (and CI jobs
+// that persist the goneat home directory) to avoid re-querying every registry.
+const (
+	// DefaultVersionTTL is how long versioned lookups are served from disk.
+	DefaultVersionTTL = 90 * 24 * time.Hour
+
+	// DefaultLatestTTL is how long "latest" lookups are served from disk.
+	DefaultLatestTTL = time.Hour
+
+	// LatestVersion is the version key used for latest-release lookups.
+	LatestVersion = "latest"
+
+	// OfflineEnvVar enables offline mode when set to a truthy value.
+	OfflineEnvVar = "GONEAT_OFFLINE"
+)
+
+// ErrOfflineCacheMiss is returned in offline mode when a lookup is not cached.
+var ErrOfflineCacheMiss = errors.New("offline cache miss")
+
+// OfflineMissError describes a lookup that could not be served in offline mode.
+type OfflineMissError struct {
+	Ecosystem string
+	Name      string
+	Version   string
+}
+
+func (e *OfflineMissError) Error() string {
+	return fmt.Sprintf("offline mode: no cached %s metadata for %s@%s (run once online to populate the cache)", e.Ecosystem, e.Name, e.Version)
+}
+
+func (e *OfflineMissError) Unwrap() error {
+	return ErrOfflineCacheMiss
+}
+
+// DiskCache is a persistent metadata cache keyed by ecosystem/name/version.
+type DiskCache struct {
+	dir        string
+	versionTTL time.Duration
+	latestTTL  time.Duration
+	offline    bool
+	mu         sync.RWMutex
+}
+
+type diskCacheRecord struct {
+	Ecosystem string          `json:"ecosystem"`
+	Name      string          `json:"name"`
+	Version   string          `json:"version"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// NewDiskCache creates a disk cache rooted at dir with default TTLs.
+func NewDiskCache(dir string) *DiskCache {
+	return &DiskCache{
+		dir:        dir,
+		versionTTL: DefaultVersionTTL,
+		latestTTL:  DefaultLatestTTL,
+		offline:    offlineFromEnv(),
+	}
+}
+
+var (
+	sharedDiskCache     *DiskCache
+	sharedDiskCacheOnce sync.Once
+)
+
+// SharedDiskCache returns the process-wide disk cache under
+// $GONEAT_HOME/cache/registry. Returns nil if the goneat home is unavailable.
+func SharedDiskCache() *DiskCache {
+	sharedDiskCacheOnce.Do(func() {
+		home, err := config.GetGoneatHome()
+		if err != nil {
+			return
+		}
+		sharedDiskCache = NewDiskCache(filepath.Join(home, "cache", "registry"))
+	})
+	return sharedDiskCache
+}
+
+// SetOffline toggles offline mode. In offline mode lookups are served only
+// from the cache (ignoring expiry) and misses return an OfflineMissError.
+func (c *DiskCache) SetOffline(offline bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offline = offline
+}
+
+// Offline reports whether offline mode is enabled.
+func (c *DiskCache) Offline() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.offline
+}
+
+// SetTTLs overrides the versioned and latest TTLs.
+func (c *DiskCache) SetTTLs(versionTTL, latestTTL time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.versionTTL = versionTTL
+	c.latestTTL = latestTTL
+}
+
+// Get loads a cached value into out. It returns false when the entry is
+// missing or expired; expiry is ignored in offline mode.
+func (c *DiskCache) Get(ecosystem, name, version string, out interface{}) (bool, error) {
+	path := c.entryPath(ecosystem, name, version)
+	data, err := os.ReadFile(path) // #nosec G304 - path derived from hashed cache key under goneat home
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var record diskCacheRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		// Corrupt entries are treated as misses and overwritten on next Put
+		return false, nil
+	}
+
+	c.mu.RLock()
+	offline := c.offline
+	ttl := c.versionTTL
+	if version == LatestVersion {
+		ttl = c.latestTTL
+	}
+	c.mu.RUnlock()
+
+	if !offline && time.Since(record.FetchedAt) > ttl {
+		return false, nil
+	}
+	if err := json.Unmarshal(record.Value, out); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Put stores a value for the given key.
+func (c *DiskCache) Put(ecosystem, name, version string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache value: %w", err)
+	}
+	record := diskCacheRecord{
+		Ecosystem: ecosystem,
+		Name:      name,
+		Version:   version,
+		FetchedAt: time.Now().UTC(),
+		Value:     raw,
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache record: %w", err)
+	}
+
+	path := c.entryPath(ecosystem, name, version)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	// Write atomically so concurrent goneat processes never read partial entries
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Clear removes all cached entries.
+func (c *DiskCache) Clear() error {
+	return os.RemoveAll(c.dir)
+}
+
+// Dir returns the cache root directory.
+func (c *DiskCache) Dir() string {
+	return c.dir
+}
+
+func (c *DiskCache) entryPath(ecosystem, name, version string) string {
+	sum := sha256.Sum256([]byte(name + "@" + version))
+	return filepath.Join(c.dir, sanitizeCacheSegment(ecosystem), hex.EncodeToString(sum[:])+".json")
+}
+
+func sanitizeCacheSegment(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return "unknown"
+	}
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+func offlineFromEnv() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(OfflineEnvVar))) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// persistentClient layers a DiskCache in front of a registry Client.
+type persistentClient struct {
+	ecosystem string
+	inner     Client
+	disk      *DiskCache
+}
+
+// WithDiskCache wraps a client so lookups are served from and stored in disk.
+// A nil disk cache returns the client unchanged.
+func WithDiskCache(ecosystem string, inner Client, disk *DiskCache) Client {
+	if disk == nil || inner == nil {
+		return inner
+	}
+	return &persistentClient{ecosystem: ecosystem, inner: inner, disk: disk}
+}
+
+func (c *persistentClient) GetMetadata(name, version string) (*Metadata, error) {
+	var cached Metadata
+	if ok, _ := c.disk.Get(c.ecosystem, name, version, &cached); ok {
+		return &cached, nil
+	}
+	if c.disk.Offline() {
+		return nil, &OfflineMissError{Ecosystem: c.ecosystem, Name: name, Version: version}
+	}
+
+	meta, err := c.inner.GetMetadata(name, version)
+	if err != nil {
+		return nil, err
+	}
+	_ = c.disk.Put(c.ecosystem, name, version, meta) // cache write failures are non-fatal
+	return meta, nil
+}
@@ -0,0 +1,88 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDiskCache_PutGet(t *testing.T) {
+	cache := NewDiskCache(t.TempDir())
+	published := time.Date(2024, 11, 1, 10, 0, 0, 0, time.UTC)
+
+	if err := cache.Put("npm", "@scope/pkg", "1.0.0", &Metadata{PublishDate: published, TotalDownloads: 42}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	var got Metadata
+	ok, err := cache.Get("npm", "@scope/pkg", "1.0.0", &got)
+	if err != nil || !ok {
+		t.Fatalf("expected cache hit, got ok=%v err=%v", ok, err)
+	}
+	if !got.PublishDate.Equal(published) || got.TotalDownloads != 42 {
+		t.Errorf("unexpected cached value: %+v", got)
+	}
+
+	// Ecosystems are isolated
+	if ok, _ := cache.Get("pypi", "@scope/pkg", "1.0.0", &got); ok {
+		t.Error("expected miss for different ecosystem")
+	}
+}
+
+func TestDiskCache_TTLAndOffline(t *testing.T) {
+	cache := NewDiskCache(t.TempDir())
+	cache.SetTTLs(time.Hour, time.Nanosecond)
+
+	if err := cache.Put("go", "example.com/mod", LatestVersion, &Metadata{TotalDownloads: 1}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	var got Metadata
+	if ok, _ := cache.Get("go", "example.com/mod", LatestVersion, &got); ok {
+		t.Error("expected latest entry to expire")
+	}
+
+	// Offline mode serves stale entries
+	cache.SetOffline(true)
+	if ok, _ := cache.Get("go", "example.com/mod", LatestVersion, &got); !ok {
+		t.Error("expected offline mode to serve expired entry")
+	}
+}
+
+type countingClient struct {
+	calls int
+}
+
+func (c *countingClient) GetMetadata(name, version string) (*Metadata, error) {
+	c.calls++
+	return &Metadata{PublishDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), TotalDownloads: 10}, nil
+}
+
+func TestWithDiskCache(t *testing.T) {
+	disk := NewDiskCache(t.TempDir())
+	inner := &countingClient{}
+	client := WithDiskCache("crates", inner, disk)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetMetadata("serde", "1.0.0"); err != nil {
+			t.Fatalf("GetMetadata failed: %v", err)
+		}
+	}
+	if inner.calls != 1 {
+		t.Errorf("expected 1 upstream call, got %d", inner.calls)
+	}
+
+	disk.SetOffline(true)
+	_, err := client.GetMetadata("serde", "2.0.0")
+	if !errors.Is(err, ErrOfflineCacheMiss) {
+		t.Fatalf("expected offline cache miss, got %v", err)
+	}
+	if inner.calls != 1 {
+		t.Errorf("offline mode must not call upstream, got %d calls", inner.calls)
+	}
+
+	if WithDiskCache("crates", inner, nil) != Client(inner) {
+		t.Error("nil disk cache should return the inner client")
+	}
+}
@@ -20,7 +20,7 @@ type NPMClient struct {
 	fetcher      HTTPFetcher
 }
 
-// NewNPMClient creates an NPMClient with real HTTP for production use
+// NewNPMClient creates an NPMClient with real HTTP and the shared disk cache for production use
 func NewNPMClient(ttl time.Duration) Client {
 	client := &http.Client{
 		Timeout: 30 * time.Second,
@@ -31,7 +31,7 @@ func NewNPMClient(ttl time.Duration) Client {
 		},
 	}
 
-	return NewNPMClientWithFetcher(ttl, NewRealHTTPFetcher(client))
+	return WithDiskCache("npm", NewNPMClientWithFetcher(ttl, NewRealHTTPFetcher(client)), SharedDiskCache())
 }
 
 // NewNPMClientWithFetcher creates an NPMClient with injectable HTTP for testing
@@ -19,7 +19,7 @@ type NuGetClient struct {
 	packageBaseURL  string // Cached from service index
 }
 
-// NewNuGetClient creates a NuGetClient with real HTTP for production use
+// NewNuGetClient creates a NuGetClient with real HTTP and the shared disk cache for production use
 func NewNuGetClient(ttl time.Duration) Client {
 	client := &http.Client{
 		Timeout: 30 * time.Second,
@@ -30,7 +30,7 @@ func NewNuGetClient(ttl time.Duration) Client {
 		},
 	}
 
-	return NewNuGetClientWithFetcher(ttl, NewRealHTTPFetcher(client))
+	return WithDiskCache("nuget", NewNuGetClientWithFetcher(ttl, NewRealHTTPFetcher(client)), SharedDiskCache())
 }
 
 // NewNuGetClientWithFetcher creates a NuGetClient with injectable HTTP for testing
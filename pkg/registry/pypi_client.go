@@ -18,7 +18,7 @@ type PyPIClient struct {
 	fetcher HTTPFetcher
 }
 
-// NewPyPIClient creates a PyPIClient with real HTTP for production use
+// NewPyPIClient creates a PyPIClient with real HTTP and the shared disk cache for production use
 func NewPyPIClient(ttl time.Duration) Client {
 	client := &http.Client{
 		Timeout: 30 * time.Second,
@@ -29,7 +29,7 @@ func NewPyPIClient(ttl time.Duration) Client {
 		},
 	}
 
-	return NewPyPIClientWithFetcher(ttl, NewRealHTTPFetcher(client))
+	return WithDiskCache("pypi", NewPyPIClientWithFetcher(ttl, NewRealHTTPFetcher(client)), SharedDiskCache())
 }
 
 // NewPyPIClientWithFetcher creates a PyPIClient with injectable HTTP for testing
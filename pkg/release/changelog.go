@@ -0,0 +1,390 @@
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Keep a Changelog sections in output order. Conventional Commit types map
+// onto them; types without a section (chore, docs, ci, test, style, build)
+// are left out unless the commit is breaking.
+var changelogSections = []struct {
+	title string
+	types []string
+}{
+	{"Breaking Changes", nil},
+	{"Added", []string{"feat"}},
+	{"Changed", []string{"perf", "refactor"}},
+	{"Deprecated", []string{"deprecate", "deprecated"}},
+	{"Removed", []string{"revert", "remove"}},
+	{"Fixed", []string{"fix"}},
+	{"Security", []string{"security", "sec"}},
+}
+
+// ChangelogOptions controls how a changelog section is rendered.
+type ChangelogOptions struct {
+	// Version is the release heading, such as v1.3.0.
+	Version string
+	Date    time.Time
+	// IssueURL is a link template for issue references; {id} is replaced by
+	// the issue number. Empty leaves references unlinked.
+	IssueURL string
+}
+
+// ChangelogEntry is one bullet in a changelog section.
+type ChangelogEntry struct {
+	Scope string
+	Text  string
+}
+
+// ChangelogSection is a titled group of entries (Added, Fixed, ...).
+type ChangelogSection struct {
+	Title   string
+	Entries []ChangelogEntry
+}
+
+// GroupCommits sorts commits into Keep a Changelog sections, grouped by scope
+// within each section. BREAKING CHANGE notes are collected under "Breaking
+// Changes"; a `!` commit without a footer is listed there instead of under
+// its type.
+func GroupCommits(commits []Commit, issueURL string) []ChangelogSection {
+	byTitle := map[string]*ChangelogSection{}
+	sectionFor := map[string]string{}
+	for _, s := range changelogSections {
+		byTitle[s.title] = &ChangelogSection{Title: s.title}
+		for _, t := range s.types {
+			sectionFor[t] = s.title
+		}
+	}
+	for _, c := range commits {
+		refs := issueRefs(c)
+		if c.Breaking {
+			for _, note := range c.Notes {
+				byTitle["Breaking Changes"].Entries = append(byTitle["Breaking Changes"].Entries, ChangelogEntry{Scope: c.Scope, Text: linkIssues(oneLine(note), refs, issueURL)})
+			}
+			// Without a footer the subject is the breaking note itself.
+			if len(c.Notes) == 0 {
+				byTitle["Breaking Changes"].Entries = append(byTitle["Breaking Changes"].Entries, ChangelogEntry{Scope: c.Scope, Text: linkIssues(c.Subject, refs, issueURL)})
+				continue
+			}
+		}
+		if title, ok := sectionFor[c.Type]; ok {
+			byTitle[title].Entries = append(byTitle[title].Entries, ChangelogEntry{Scope: c.Scope, Text: linkIssues(c.Subject, refs, issueURL)})
+		}
+	}
+	var sections []ChangelogSection
+	for _, s := range changelogSections {
+		section := byTitle[s.title]
+		if len(section.Entries) == 0 {
+			continue
+		}
+		// Unscoped entries first, then scopes alphabetically; commit order is
+		// kept within a scope.
+		sort.SliceStable(section.Entries, func(i, j int) bool {
+			return section.Entries[i].Scope < section.Entries[j].Scope
+		})
+		sections = append(sections, *section)
+	}
+	return sections
+}
+
+// NotableCommits returns the commits that produce changelog entries.
+func NotableCommits(commits []Commit) []Commit {
+	var notable []Commit
+	for _, c := range commits {
+		if len(GroupCommits([]Commit{c}, "")) > 0 {
+			notable = append(notable, c)
+		}
+	}
+	return notable
+}
+
+// RenderChangelogSection renders a dated "## [version] - date" section in
+// the Keep a Changelog style used by CHANGELOG.md.
+func RenderChangelogSection(commits []Commit, opts ChangelogOptions) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## [%s] - %s\n", opts.Version, opts.Date.Format("2006-01-02"))
+	for _, section := range GroupCommits(commits, opts.IssueURL) {
+		fmt.Fprintf(&b, "\n### %s\n\n", section.Title)
+		writeEntries(&b, section.Entries)
+	}
+	return b.String()
+}
+
+// RenderReleaseNotes renders a standalone release notes document for
+// RELEASE_NOTES.md or docs/releases/<version>.md.
+func RenderReleaseNotes(project string, commits []Commit, opts ChangelogOptions) string {
+	var b strings.Builder
+	title := opts.Version
+	if project != "" {
+		title = project + " " + opts.Version
+	}
+	fmt.Fprintf(&b, "# %s\n\n**Release Date**: %s\n", title, opts.Date.Format("2006-01-02"))
+	sections := GroupCommits(commits, opts.IssueURL)
+	if len(sections) == 0 {
+		b.WriteString("\nNo user-facing changes.\n")
+	}
+	for _, section := range sections {
+		fmt.Fprintf(&b, "\n## %s\n\n", section.Title)
+		writeEntries(&b, section.Entries)
+	}
+	return b.String()
+}
+
+func writeEntries(b *strings.Builder, entries []ChangelogEntry) {
+	for _, e := range entries {
+		if e.Scope != "" {
+			fmt.Fprintf(b, "- **%s**: %s\n", e.Scope, e.Text)
+		} else {
+			fmt.Fprintf(b, "- %s\n", e.Text)
+		}
+	}
+}
+
+var (
+	issueRefPattern  = regexp.MustCompile(`(^|[\s(,])#(\d+)\b`)
+	issueFooterNames = []string{"Refs", "Ref", "Closes", "Fixes", "Resolves", "Closes-Issue"}
+)
+
+// issueRefs collects issue numbers from reference footers (Refs, Closes,
+// Fixes, ...).
+func issueRefs(c Commit) []string {
+	var refs []string
+	for _, name := range issueFooterNames {
+		for _, m := range issueRefPattern.FindAllStringSubmatch(" "+c.Footers[name], -1) {
+			refs = append(refs, m[2])
+		}
+		// "Refs: 42" style without a hash.
+		for _, part := range strings.Split(c.Footers[name], ",") {
+			if part = strings.TrimSpace(part); part != "" && isDigits(part) {
+				refs = append(refs, part)
+			}
+		}
+	}
+	return refs
+}
+
+// linkIssues links "#123" references in text and appends footer references
+// not already mentioned.
+func linkIssues(text string, refs []string, issueURL string) string {
+	mentioned := map[string]bool{}
+	for _, m := range issueRefPattern.FindAllStringSubmatch(text, -1) {
+		mentioned[m[2]] = true
+	}
+	var extra []string
+	for _, ref := range refs {
+		if !mentioned[ref] {
+			mentioned[ref] = true
+			extra = append(extra, "#"+ref)
+		}
+	}
+	if len(extra) > 0 {
+		text += " (" + strings.Join(extra, ", ") + ")"
+	}
+	if issueURL == "" {
+		return text
+	}
+	return issueRefPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := issueRefPattern.FindStringSubmatch(match)
+		return m[1] + "[#" + m[2] + "](" + strings.ReplaceAll(issueURL, "{id}", m[2]) + ")"
+	})
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+var (
+	changelogHeadingPattern = regexp.MustCompile(`^##\s+\[([^\]]+)\](?:\s*-\s*(\d{4}-\d{2}-\d{2}))?`)
+	issueRemotePattern      = regexp.MustCompile(`^(?:https?://|ssh://)?(?:[^@/]+@)?(github\.com|gitlab\.com)[:/](.+?)(?:\.git)?/?$`)
+)
+
+// InsertChangelogSection inserts section above the newest release in a Keep
+// a Changelog document, below any [Unreleased] section. Entries under
+// [Unreleased] move into the new section, leaving [Unreleased] empty. The
+// new date must not be older than the newest existing release so the dates
+// monotonic check stays green.
+func InsertChangelogSection(content, section, version string, date time.Time) (string, error) {
+	lines := strings.Split(content, "\n")
+	unreleasedAt, insertAt := -1, -1
+	for i, line := range lines {
+		m := changelogHeadingPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if strings.EqualFold(m[1], "unreleased") {
+			unreleasedAt = i
+			continue
+		}
+		if strings.TrimPrefix(m[1], "v") == strings.TrimPrefix(version, "v") {
+			return "", fmt.Errorf("changelog already has a section for %s", m[1])
+		}
+		if m[2] != "" {
+			if latest, err := time.Parse("2006-01-02", m[2]); err == nil && date.Format("2006-01-02") < latest.Format("2006-01-02") {
+				return "", fmt.Errorf("release date %s is older than the latest changelog entry %s (%s)", date.Format("2006-01-02"), m[1], m[2])
+			}
+		}
+		insertAt = i
+		break
+	}
+	section = strings.TrimRight(section, "\n") + "\n"
+	var out, rest []string
+	switch {
+	case unreleasedAt >= 0:
+		end := len(lines)
+		if insertAt > unreleasedAt {
+			end = insertAt
+		}
+		section = mergeChangelogBody(section, lines[unreleasedAt+1:end])
+		out = append(out, lines[:unreleasedAt+1]...)
+		out = append(out, "")
+		rest = lines[end:]
+	case insertAt >= 0:
+		out = append(out, lines[:insertAt]...)
+		rest = lines[insertAt:]
+	default:
+		// No releases yet: append after the existing content.
+		return strings.TrimRight(content, "\n") + "\n\n" + section, nil
+	}
+	out = append(out, strings.Split(section, "\n")...)
+	out = append(out, rest...)
+	return strings.Join(out, "\n"), nil
+}
+
+// changelogBlock is a "### Title" subsection of a release section; lines are
+// its content without surrounding blank lines.
+type changelogBlock struct {
+	title string
+	lines []string
+}
+
+// mergeChangelogBody folds the body of an [Unreleased] section into a
+// rendered release section. Hand-written entries come first within a
+// subsection of the same title; other subsections are added in Keep a
+// Changelog order.
+func mergeChangelogBody(section string, body []string) string {
+	bodyPreamble, bodyBlocks := splitChangelogBlocks(body)
+	if len(bodyPreamble) == 0 && len(bodyBlocks) == 0 {
+		return section
+	}
+	lines := strings.Split(strings.TrimRight(section, "\n"), "\n")
+	heading := lines[0]
+	preamble, blocks := splitChangelogBlocks(lines[1:])
+	preamble = append(bodyPreamble, preamble...)
+	for _, b := range bodyBlocks {
+		merged := false
+		for i := range blocks {
+			if strings.EqualFold(blocks[i].title, b.title) {
+				blocks[i].lines = append(append([]string{}, b.lines...), blocks[i].lines...)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			blocks = append(blocks, b)
+		}
+	}
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return changelogSectionRank(blocks[i].title) < changelogSectionRank(blocks[j].title)
+	})
+
+	var b strings.Builder
+	b.WriteString(heading + "\n")
+	if len(preamble) > 0 {
+		b.WriteString("\n" + strings.Join(preamble, "\n") + "\n")
+	}
+	for _, block := range blocks {
+		fmt.Fprintf(&b, "\n### %s\n", block.title)
+		if len(block.lines) > 0 {
+			b.WriteString("\n" + strings.Join(block.lines, "\n") + "\n")
+		}
+	}
+	return b.String()
+}
+
+// splitChangelogBlocks splits section content into the lines before the
+// first "###" heading and the subsections that follow.
+func splitChangelogBlocks(lines []string) ([]string, []changelogBlock) {
+	var preamble []string
+	var blocks []changelogBlock
+	for _, line := range lines {
+		if title, ok := strings.CutPrefix(line, "### "); ok {
+			blocks = append(blocks, changelogBlock{title: strings.TrimSpace(title)})
+			continue
+		}
+		if len(blocks) == 0 {
+			preamble = append(preamble, line)
+		} else {
+			blocks[len(blocks)-1].lines = append(blocks[len(blocks)-1].lines, line)
+		}
+	}
+	preamble = trimBlankLines(preamble)
+	for i := range blocks {
+		blocks[i].lines = trimBlankLines(blocks[i].lines)
+	}
+	return preamble, blocks
+}
+
+func trimBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func changelogSectionRank(title string) int {
+	for i, s := range changelogSections {
+		if strings.EqualFold(s.title, title) {
+			return i
+		}
+	}
+	return len(changelogSections)
+}
+
+// HasPendingChangelogEntries reports whether the changelog records changes
+// after release lastVersion: bullets under [Unreleased] or a release section
+// above lastVersion.
+func HasPendingChangelogEntries(content, lastVersion string) bool {
+	inUnreleased := false
+	for _, line := range strings.Split(content, "\n") {
+		if m := changelogHeadingPattern.FindStringSubmatch(line); m != nil {
+			if strings.EqualFold(m[1], "unreleased") {
+				inUnreleased = true
+				continue
+			}
+			return lastVersion == "" || strings.TrimPrefix(m[1], "v") != strings.TrimPrefix(lastVersion, "v")
+		}
+		if inUnreleased && strings.HasPrefix(strings.TrimSpace(line), "- ") {
+			return true
+		}
+	}
+	return false
+}
+
+// IssueURLFromRemote derives an issue link template from a GitHub or GitLab
+// remote URL; other hosts return "".
+func IssueURLFromRemote(remote string) string {
+	m := issueRemotePattern.FindStringSubmatch(strings.TrimSpace(remote))
+	if m == nil {
+		return ""
+	}
+	if m[1] == "gitlab.com" {
+		return "https://gitlab.com/" + m[2] + "/-/issues/{id}"
+	}
+	return "https://github.com/" + m[2] + "/issues/{id}"
+}
@@ -0,0 +1,153 @@
+package release
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func parseAll(messages ...string) []Commit {
+	var commits []Commit
+	for _, m := range messages {
+		commits = append(commits, ParseCommitMessage(m))
+	}
+	return commits
+}
+
+func TestRenderChangelogSection(t *testing.T) {
+	commits := parseAll(
+		"feat(cli): add version next (#12)",
+		"fix: handle empty VERSION\n\nCloses #7",
+		"feat: changelog generation",
+		"chore: bump deps",
+		"docs: typo",
+		"perf(schema): cache compiled validators",
+		"refactor(config)!: rename keys\n\nBREAKING CHANGE: `sbom.output` is now `sbom.path`\nRefs: #30",
+		"feat(api)!: drop v1 endpoints",
+		"Merge branch 'main'",
+	)
+	got := RenderChangelogSection(commits, ChangelogOptions{
+		Version:  "v1.3.0",
+		Date:     time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+		IssueURL: "https://github.com/fulmenhq/goneat/issues/{id}",
+	})
+	want := `## [v1.3.0] - 2026-10-18
+
+### Breaking Changes
+
+- **api**: drop v1 endpoints
+- **config**: ` + "`sbom.output` is now `sbom.path`" + ` ([#30](https://github.com/fulmenhq/goneat/issues/30))
+
+### Added
+
+- changelog generation
+- **cli**: add version next ([#12](https://github.com/fulmenhq/goneat/issues/12))
+
+### Changed
+
+- **config**: rename keys ([#30](https://github.com/fulmenhq/goneat/issues/30))
+- **schema**: cache compiled validators
+
+### Fixed
+
+- handle empty VERSION ([#7](https://github.com/fulmenhq/goneat/issues/7))
+`
+	if got != want {
+		t.Fatalf("section mismatch:\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+
+	if notable := NotableCommits(commits); len(notable) != 6 {
+		t.Errorf("notable = %d, want 6", len(notable))
+	}
+	notes := RenderReleaseNotes("goneat", commits[3:5], ChangelogOptions{Version: "v1.3.1", Date: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)})
+	if !strings.HasPrefix(notes, "# goneat v1.3.1\n\n**Release Date**: 2026-10-19\n") || !strings.Contains(notes, "No user-facing changes.") {
+		t.Errorf("release notes = %q", notes)
+	}
+}
+
+const testChangelog = `# Changelog
+
+## [Unreleased]
+
+## [v1.2.0] - 2026-09-01
+
+### Added
+
+- something
+`
+
+func TestInsertChangelogSection(t *testing.T) {
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	section := "## [v1.3.0] - 2026-10-18\n\n### Fixed\n\n- bug\n"
+	got, err := InsertChangelogSection(testChangelog, section, "v1.3.0", date)
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if !strings.Contains(got, "## [Unreleased]\n\n## [v1.3.0] - 2026-10-18\n\n### Fixed\n\n- bug\n\n## [v1.2.0] - 2026-09-01") {
+		t.Fatalf("unexpected placement:\n%s", got)
+	}
+
+	if _, err := InsertChangelogSection(got, section, "1.3.0", date); err == nil {
+		t.Errorf("expected duplicate version error")
+	}
+	if _, err := InsertChangelogSection(testChangelog, section, "v1.3.0", time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("expected non-monotonic date error")
+	}
+	first, err := InsertChangelogSection("# Changelog\n", section, "v1.3.0", date)
+	if err != nil || first != "# Changelog\n\n"+section {
+		t.Errorf("first release = %q, %v", first, err)
+	}
+}
+
+func TestInsertChangelogSection_MovesUnreleasedEntries(t *testing.T) {
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	content := strings.Replace(testChangelog, "## [Unreleased]\n", "## [Unreleased]\n\n### Fixed\n\n- hand-written fix\n\n### Security\n\n- patched dependency\n", 1)
+	section := "## [v1.3.0] - 2026-10-18\n\n### Added\n\n- feature\n\n### Fixed\n\n- bug\n"
+	got, err := InsertChangelogSection(content, section, "v1.3.0", date)
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	want := "## [Unreleased]\n\n## [v1.3.0] - 2026-10-18\n\n### Added\n\n- feature\n\n### Fixed\n\n- hand-written fix\n- bug\n\n### Security\n\n- patched dependency\n\n## [v1.2.0] - 2026-09-01"
+	if !strings.Contains(got, want) {
+		t.Fatalf("unexpected merge:\n%s", got)
+	}
+	if HasPendingChangelogEntries(got, "v1.3.0") {
+		t.Errorf("Unreleased should be empty after the release")
+	}
+
+	first, err := InsertChangelogSection("# Changelog\n\n## [Unreleased]\n\n- early entry\n", "## [v0.1.0] - 2026-10-18\n", "v0.1.0", date)
+	if err != nil || first != "# Changelog\n\n## [Unreleased]\n\n## [v0.1.0] - 2026-10-18\n\n- early entry\n" {
+		t.Errorf("first release = %q, %v", first, err)
+	}
+}
+
+func TestHasPendingChangelogEntries(t *testing.T) {
+	if HasPendingChangelogEntries(testChangelog, "v1.2.0") {
+		t.Errorf("empty Unreleased should not count as pending")
+	}
+	withEntry := strings.Replace(testChangelog, "## [Unreleased]\n", "## [Unreleased]\n\n- new thing\n", 1)
+	if !HasPendingChangelogEntries(withEntry, "v1.2.0") {
+		t.Errorf("Unreleased bullet should count as pending")
+	}
+	if !HasPendingChangelogEntries(testChangelog, "v1.1.0") {
+		t.Errorf("release section newer than the tag should count as pending")
+	}
+	if !HasPendingChangelogEntries(testChangelog, "") {
+		t.Errorf("any release counts when there is no tag")
+	}
+}
+
+func TestIssueURLFromRemote(t *testing.T) {
+	cases := map[string]string{
+		"https://github.com/fulmenhq/goneat.git":  "https://github.com/fulmenhq/goneat/issues/{id}",
+		"git@github.com:fulmenhq/goneat.git":      "https://github.com/fulmenhq/goneat/issues/{id}",
+		"ssh://git@gitlab.com/group/sub/repo.git": "https://gitlab.com/group/sub/repo/-/issues/{id}",
+		"https://git.example.com/team/repo.git":   "",
+		"":                                        "",
+	}
+	for remote, want := range cases {
+		if got := IssueURLFromRemote(remote); got != want {
+			t.Errorf("IssueURLFromRemote(%q) = %q, want %q", remote, got, want)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+// Package release derives release information from git history: Conventional
+// Commits since the last release tag, the version bump they imply and the
+// next version under the project's version policy.
+package release
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Commit is a commit message parsed according to Conventional Commits 1.0.0.
+// Messages that do not follow the convention keep their subject with an
+// empty Type.
+type Commit struct {
+	Hash     string            `json:"hash"`
+	Type     string            `json:"type,omitempty"`
+	Scope    string            `json:"scope,omitempty"`
+	Subject  string            `json:"subject"`
+	Body     string            `json:"body,omitempty"`
+	Breaking bool              `json:"breaking,omitempty"`
+	Notes    []string          `json:"breaking_notes,omitempty"` // BREAKING CHANGE footer texts
+	Footers  map[string]string `json:"footers,omitempty"`
+}
+
+// Conventional reports whether the message had a Conventional Commits header.
+func (c Commit) Conventional() bool {
+	return c.Type != ""
+}
+
+var (
+	headerPattern = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?: +(.+)$`)
+	footerPattern = regexp.MustCompile(`^(BREAKING[ -]CHANGE|[A-Za-z][A-Za-z-]*)(?:: | #)(.*)$`)
+)
+
+// ParseCommitMessage parses a commit message header, body and footers.
+func ParseCommitMessage(message string) Commit {
+	message = strings.ReplaceAll(strings.TrimSpace(message), "\r\n", "\n")
+	header, rest, _ := strings.Cut(message, "\n")
+	commit := Commit{Subject: strings.TrimSpace(header)}
+	if m := headerPattern.FindStringSubmatch(commit.Subject); m != nil {
+		commit.Type = strings.ToLower(m[1])
+		commit.Scope = strings.TrimSpace(m[2])
+		commit.Breaking = m[3] == "!"
+		commit.Subject = strings.TrimSpace(m[4])
+	}
+
+	// Footers form the last paragraph; a footer value may continue on
+	// following lines until the next token.
+	paragraphs := strings.Split(strings.TrimSpace(rest), "\n\n")
+	if last := paragraphs[len(paragraphs)-1]; footerPattern.MatchString(strings.SplitN(last, "\n", 2)[0]) {
+		paragraphs = paragraphs[:len(paragraphs)-1]
+		commit.Footers = map[string]string{}
+		var token string
+		for _, line := range strings.Split(last, "\n") {
+			if m := footerPattern.FindStringSubmatch(line); m != nil {
+				token = m[1]
+				if token == "BREAKING-CHANGE" {
+					token = "BREAKING CHANGE"
+				}
+				if existing, ok := commit.Footers[token]; ok {
+					commit.Footers[token] = existing + ", " + strings.TrimSpace(m[2])
+				} else {
+					commit.Footers[token] = strings.TrimSpace(m[2])
+				}
+				if token == "BREAKING CHANGE" {
+					commit.Notes = append(commit.Notes, strings.TrimSpace(m[2]))
+				}
+				continue
+			}
+			if token != "" {
+				commit.Footers[token] += "\n" + line
+				if token == "BREAKING CHANGE" {
+					commit.Notes[len(commit.Notes)-1] += "\n" + line
+				}
+			}
+		}
+	}
+	commit.Body = strings.TrimSpace(strings.Join(paragraphs, "\n\n"))
+	if len(commit.Notes) > 0 {
+		commit.Breaking = true
+	}
+	return commit
+}
+
+// Tag is a version tag reachable from HEAD.
+type Tag struct {
+	Name    string
+	Version string // tag name without the leading "v"
+	Hash    plumbing.Hash
+}
+
+// History gives access to the commits and version tags of a repository.
+type History struct {
+	repo *git.Repository
+	head *object.Commit
+	// ancestors holds every commit reachable from HEAD, including HEAD.
+	ancestors map[plumbing.Hash]*object.Commit
+	order     []*object.Commit
+}
+
+// OpenHistory opens the repository containing dir and indexes the commits
+// reachable from HEAD.
+func OpenHistory(dir string) (*History, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+	ref, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	head, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+	h := &History{repo: repo, head: head, ancestors: map[plumbing.Hash]*object.Commit{}}
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash, Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk history: %w", err)
+	}
+	err = iter.ForEach(func(c *object.Commit) error {
+		h.ancestors[c.Hash] = c
+		h.order = append(h.order, c)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk history: %w", err)
+	}
+	return h, nil
+}
+
+// Repository returns the underlying go-git repository.
+func (h *History) Repository() *git.Repository {
+	return h.repo
+}
+
+// Tags returns the tags that point at commits reachable from HEAD and whose
+// name is accepted by match (applied to the name without a leading "v").
+func (h *History) Tags(match func(version string) bool) ([]Tag, error) {
+	refs, err := h.repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	var tags []Tag
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		version := strings.TrimPrefix(name, "v")
+		if !match(version) {
+			return nil
+		}
+		hash := ref.Hash()
+		// Annotated tags point at a tag object rather than the commit.
+		if tagObj, err := h.repo.TagObject(hash); err == nil {
+			commit, err := tagObj.Commit()
+			if err != nil {
+				return nil
+			}
+			hash = commit.Hash
+		}
+		if _, ok := h.ancestors[hash]; ok {
+			tags = append(tags, Tag{Name: name, Version: version, Hash: hash})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	return tags, nil
+}
+
+// RemoteURL returns the first URL of the named remote, or "".
+func (h *History) RemoteURL(name string) string {
+	remote, err := h.repo.Remote(name)
+	if err != nil || len(remote.Config().URLs) == 0 {
+		return ""
+	}
+	return remote.Config().URLs[0]
+}
+
+// CommitsSince returns the commits reachable from HEAD but not from the given
+// commit, newest first. A zero hash returns the whole history.
+func (h *History) CommitsSince(since plumbing.Hash) ([]Commit, error) {
+	excluded := map[plumbing.Hash]bool{}
+	if !since.IsZero() {
+		start, ok := h.ancestors[since]
+		if !ok {
+			return nil, fmt.Errorf("commit %s is not an ancestor of HEAD", since)
+		}
+		iter, err := h.repo.Log(&git.LogOptions{From: start.Hash})
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk history: %w", err)
+		}
+		if err := iter.ForEach(func(c *object.Commit) error {
+			excluded[c.Hash] = true
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to walk history: %w", err)
+		}
+	}
+	var commits []Commit
+	for _, c := range h.order {
+		if excluded[c.Hash] {
+			continue
+		}
+		parsed := ParseCommitMessage(c.Message)
+		parsed.Hash = c.Hash.String()
+		commits = append(commits, parsed)
+	}
+	return commits, nil
+}
+
+// ResolveTag returns the commit a tag name (with or without "v") points at.
+func (h *History) ResolveTag(name string) (plumbing.Hash, error) {
+	for _, candidate := range []string{name, "v" + strings.TrimPrefix(name, "v"), strings.TrimPrefix(name, "v")} {
+		hash, err := h.repo.ResolveRevision(plumbing.Revision(candidate))
+		if err == nil {
+			return *hash, nil
+		}
+		if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return plumbing.ZeroHash, fmt.Errorf("failed to resolve %s: %w", name, err)
+		}
+	}
+	return plumbing.ZeroHash, fmt.Errorf("tag %s not found", name)
+}
+
+// CheckStaged returns an error when the index holds changes to paths other
+// than files (relative to the working directory), which a release commit would
+// otherwise pick up. Pass no files to require an empty index.
+func (h *History) CheckStaged(files []string) error {
+	wt, err := h.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to open worktree: %w", err)
+	}
+	rels, err := repoRelativePaths(wt.Filesystem.Root(), files)
+	if err != nil {
+		return err
+	}
+	return checkStaged(wt, rels)
+}
+
+// CommitRelease commits files (paths relative to the working directory) with
+// a "chore(release)" message and creates an annotated tag on that commit.
+// Author and tagger come from the git configuration. It refuses to run when
+// other changes are staged, so only the release files are committed.
+func (h *History) CommitRelease(files []string, tagName string) (plumbing.Hash, error) {
+	wt, err := h.repo.Worktree()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to open worktree: %w", err)
+	}
+	rels, err := repoRelativePaths(wt.Filesystem.Root(), files)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err := checkStaged(wt, rels); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	for _, rel := range rels {
+		if _, err := wt.Add(rel); err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("failed to stage %s: %w", rel, err)
+		}
+	}
+	hash, err := wt.Commit("chore(release): "+tagName, &git.CommitOptions{})
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to commit release: %w", err)
+	}
+	if _, err := h.repo.CreateTag(tagName, hash, &git.CreateTagOptions{Message: "Release " + tagName}); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to create tag %s: %w", tagName, err)
+	}
+	return hash, nil
+}
+
+// repoRelativePaths converts files to slash-separated paths relative to root.
+func repoRelativePaths(root string, files []string) ([]string, error) {
+	rels := make([]string, 0, len(files))
+	for _, file := range files {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", file, err)
+		}
+		rel, err := filepath.Rel(root, abs)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return nil, fmt.Errorf("%s is outside the repository", file)
+		}
+		rels = append(rels, filepath.ToSlash(rel))
+	}
+	return rels, nil
+}
+
+// checkStaged fails when the index has changes to paths other than allowed.
+func checkStaged(wt *git.Worktree, allowed []string) error {
+	status, err := wt.Status()
+	if err != nil {
+		return fmt.Errorf("failed to read worktree status: %w", err)
+	}
+	ok := make(map[string]bool, len(allowed))
+	for _, rel := range allowed {
+		ok[rel] = true
+	}
+	var staged []string
+	for path, st := range status {
+		if st.Staging != git.Unmodified && st.Staging != git.Untracked && !ok[path] {
+			staged = append(staged, path)
+		}
+	}
+	if len(staged) > 0 {
+		sort.Strings(staged)
+		return fmt.Errorf("refusing to create the release commit: changes are staged outside the release files (%s); commit or unstage them first", strings.Join(staged, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package release
+
+import (
+	"testing"
+)
+
+func TestParseCommitMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		typ      string
+		scope    string
+		subject  string
+		breaking bool
+		notes    int
+		footers  map[string]string
+	}{
+		{name: "plain feat", message: "feat: add version next", typ: "feat", subject: "add version next"},
+		{name: "scope", message: "fix(parser): handle CRLF\r\n\r\nLonger body.", typ: "fix", scope: "parser", subject: "handle CRLF"},
+		{name: "bang", message: "refactor(api)!: drop v1 endpoints", typ: "refactor", scope: "api", subject: "drop v1 endpoints", breaking: true},
+		{
+			name:     "breaking footer",
+			message:  "feat: new config format\n\nExplain the change.\n\nBREAKING CHANGE: config keys are renamed\n  see the migration guide\nRefs #42",
+			typ:      "feat",
+			subject:  "new config format",
+			breaking: true,
+			notes:    1,
+			footers:  map[string]string{"Refs": "42"},
+		},
+		{name: "breaking dash footer", message: "fix: x\n\nBREAKING-CHANGE: removed flag", typ: "fix", subject: "x", breaking: true, notes: 1},
+		{name: "not conventional", message: "Merge branch 'main' into feature", subject: "Merge branch 'main' into feature"},
+		{name: "missing space", message: "feat:no space", subject: "feat:no space"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := ParseCommitMessage(tc.message)
+			if c.Type != tc.typ || c.Scope != tc.scope || c.Subject != tc.subject || c.Breaking != tc.breaking {
+				t.Fatalf("parsed = %+v", c)
+			}
+			if len(c.Notes) != tc.notes {
+				t.Errorf("notes = %q", c.Notes)
+			}
+			for k, v := range tc.footers {
+				if c.Footers[k] != v {
+					t.Errorf("footer %s = %q, want %q", k, c.Footers[k], v)
+				}
+			}
+		})
+	}
+
+	c := ParseCommitMessage("feat: a\n\nBody text.\n\nBREAKING CHANGE: keys renamed\nsee guide")
+	if c.Body != "Body text." || c.Notes[0] != "keys renamed\nsee guide" {
+		t.Errorf("body/notes = %q / %q", c.Body, c.Notes)
+	}
+}
@@ -0,0 +1,273 @@
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/versioning"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// Bump is the release increment implied by a set of commits.
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	}
+	return "none"
+}
+
+// Version schemes supported by the version policy.
+const (
+	SchemeSemver = "semver"
+	SchemeCalver = "calver"
+)
+
+// StableChannel is the channel name for releases without a prerelease suffix.
+const StableChannel = "stable"
+
+// InferBump returns the largest bump implied by the commits: breaking changes
+// are major, feat is minor, fix and perf are patch. Other types (chore, docs,
+// ci, ...) and non-conventional messages do not trigger a release.
+func InferBump(commits []Commit) Bump {
+	bump := BumpNone
+	for _, c := range commits {
+		switch {
+		case c.Breaking:
+			return BumpMajor
+		case c.Type == "feat":
+			bump = max(bump, BumpMinor)
+		case c.Type == "fix" || c.Type == "perf":
+			bump = max(bump, BumpPatch)
+		}
+	}
+	return bump
+}
+
+// NextOptions configures next-version inference.
+type NextOptions struct {
+	// Scheme is "semver" (default) or "calver" (YYYY.MM.PATCH).
+	Scheme string
+	// Channel is a prerelease channel such as "beta"; empty or "stable"
+	// produces a release version.
+	Channel string
+	// Current is the version used when no release tag exists yet
+	// (typically the VERSION file).
+	Current string
+	// Since is the release tag to bump from; empty uses the latest stable
+	// release tag reachable from HEAD.
+	Since string
+	// Now is the release date for calver; zero means time.Now().
+	Now time.Time
+}
+
+// Plan describes the next release inferred from history.
+type Plan struct {
+	Scheme  string   `json:"scheme"`
+	Channel string   `json:"channel"`
+	BaseTag string   `json:"base_tag,omitempty"`
+	Current string   `json:"current"`
+	Next    string   `json:"next,omitempty"` // empty when no release is needed
+	TagName string   `json:"tag,omitempty"`
+	Bump    string   `json:"bump"`
+	Commits []Commit `json:"commits"`
+}
+
+var (
+	semverCore = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)$`)
+	calverCore = regexp.MustCompile(`^(\d{4})\.(\d{1,2})\.(\d+)$`)
+)
+
+// PlanNext walks the commits since the last stable release tag reachable from
+// HEAD (or opts.Since) and computes the next version for opts.
+func PlanNext(history *History, opts NextOptions) (*Plan, error) {
+	scheme := opts.Scheme
+	if scheme == "" {
+		scheme = SchemeSemver
+	}
+	if scheme != SchemeSemver && scheme != SchemeCalver {
+		return nil, fmt.Errorf("unsupported version scheme: %s", scheme)
+	}
+	channel := opts.Channel
+	if channel == StableChannel {
+		channel = ""
+	}
+	latest, ok, err := history.LatestRelease(scheme)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Since != "" {
+		hash, err := history.ResolveTag(opts.Since)
+		if err != nil {
+			return nil, err
+		}
+		latest, ok = Tag{Name: opts.Since, Version: strings.TrimPrefix(opts.Since, "v"), Hash: hash}, true
+	}
+	plan := &Plan{Scheme: scheme, Channel: nonEmptyChannel(channel), Current: strings.TrimPrefix(strings.TrimSpace(opts.Current), "v")}
+	since := plumbing.ZeroHash
+	tagPrefix := "v"
+	if scheme == SchemeCalver {
+		tagPrefix = ""
+	}
+	if ok {
+		plan.BaseTag, plan.Current, since = latest.Name, latest.Version, latest.Hash
+		tagPrefix = strings.TrimSuffix(latest.Name, latest.Version)
+	}
+	commits, err := history.CommitsSince(since)
+	if err != nil {
+		return nil, err
+	}
+	plan.Commits = commits
+	bump := InferBump(commits)
+	plan.Bump = bump.String()
+	if bump == BumpNone {
+		return plan, nil
+	}
+
+	core, err := nextCore(scheme, plan.Current, bump, opts.Now)
+	if err != nil {
+		return nil, err
+	}
+	next := core
+	if channel != "" {
+		prePattern := regexp.MustCompile(`^` + regexp.QuoteMeta(core) + `-` + regexp.QuoteMeta(channel) + `\.(\d+)$`)
+		preTags, err := history.Tags(prePattern.MatchString)
+		if err != nil {
+			return nil, err
+		}
+		number := 1
+		if len(preTags) > 0 {
+			sort.Slice(preTags, func(i, j int) bool {
+				return prereleaseNumber(preTags[i].Version) > prereleaseNumber(preTags[j].Version)
+			})
+			// Nothing releasable since the last prerelease on this channel.
+			sincePre, err := history.CommitsSince(preTags[0].Hash)
+			if err != nil {
+				return nil, err
+			}
+			if InferBump(sincePre) == BumpNone {
+				plan.Bump = BumpNone.String()
+				return plan, nil
+			}
+			number = prereleaseNumber(preTags[0].Version) + 1
+		}
+		next = fmt.Sprintf("%s-%s.%d", core, channel, number)
+	}
+	plan.Next = next
+	plan.TagName = tagPrefix + next
+	return plan, nil
+}
+
+func nonEmptyChannel(channel string) string {
+	if channel == "" {
+		return StableChannel
+	}
+	return channel
+}
+
+// nextCore applies bump to the release version current. Before 1.0.0 a
+// breaking change bumps the minor version. Calver releases roll the PATCH
+// counter within a month and reset it to 0 when the month changes.
+func nextCore(scheme, current string, bump Bump, now time.Time) (string, error) {
+	current, _, _ = strings.Cut(current, "-")
+	current, _, _ = strings.Cut(current, "+")
+	if scheme == SchemeCalver {
+		if now.IsZero() {
+			now = time.Now()
+		}
+		month := fmt.Sprintf("%04d.%02d", now.Year(), int(now.Month()))
+		if m := calverCore.FindStringSubmatch(current); m != nil {
+			y, _ := strconv.Atoi(m[1])
+			mo, _ := strconv.Atoi(m[2])
+			if y == now.Year() && mo == int(now.Month()) {
+				patch, _ := strconv.Atoi(m[3])
+				return fmt.Sprintf("%s.%d", month, patch+1), nil
+			}
+		}
+		return month + ".0", nil
+	}
+
+	if current == "" {
+		current = "0.0.0"
+	}
+	v, err := versioning.ParseLenient(current)
+	if err != nil {
+		return "", fmt.Errorf("invalid current version %q: %w", current, err)
+	}
+	if bump == BumpMajor && strings.HasPrefix(current, "0.") {
+		bump = BumpMinor
+	}
+	switch bump {
+	case BumpMajor:
+		v = v.BumpMajor()
+	case BumpMinor:
+		v = v.BumpMinor()
+	default:
+		v = v.BumpPatch()
+	}
+	return v.String(), nil
+}
+
+// LatestRelease returns the highest stable (non-prerelease) version tag
+// reachable from HEAD for scheme.
+func (h *History) LatestRelease(scheme string) (Tag, bool, error) {
+	corePattern := semverCore
+	if scheme == SchemeCalver {
+		corePattern = calverCore
+	}
+	tags, err := h.Tags(corePattern.MatchString)
+	if err != nil {
+		return Tag{}, false, err
+	}
+	latest, ok := highestTag(tags)
+	return latest, ok, nil
+}
+
+func highestTag(tags []Tag) (Tag, bool) {
+	if len(tags) == 0 {
+		return Tag{}, false
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return compareCore(tags[i].Version, tags[j].Version) > 0
+	})
+	return tags[0], true
+}
+
+// compareCore compares dotted numeric versions segment by segment; it serves
+// both semver cores and YYYY.MM.PATCH.
+func compareCore(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, _ := strconv.Atoi(as[i])
+		y, _ := strconv.Atoi(bs[i])
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(as) - len(bs)
+}
+
+func prereleaseNumber(version string) int {
+	n, _ := strconv.Atoi(version[strings.LastIndex(version, ".")+1:])
+	return n
+}
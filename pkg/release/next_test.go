@@ -0,0 +1,286 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+var testSignature = &object.Signature{Name: "goneat", Email: "ci@goneat.dev", When: time.Now()}
+
+type testRepo struct {
+	t    *testing.T
+	dir  string
+	repo *git.Repository
+	n    int
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	return &testRepo{t: t, dir: dir, repo: repo}
+}
+
+func (r *testRepo) commit(message string) plumbing.Hash {
+	r.t.Helper()
+	r.n++
+	if err := os.WriteFile(filepath.Join(r.dir, "file.txt"), []byte(message+string(rune('a'+r.n))), 0o600); err != nil {
+		r.t.Fatalf("write: %v", err)
+	}
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		r.t.Fatalf("worktree: %v", err)
+	}
+	if _, err := wt.Add("file.txt"); err != nil {
+		r.t.Fatalf("add: %v", err)
+	}
+	sig := *testSignature
+	sig.When = sig.When.Add(time.Duration(r.n) * time.Second)
+	hash, err := wt.Commit(message, &git.CommitOptions{Author: &sig})
+	if err != nil {
+		r.t.Fatalf("commit: %v", err)
+	}
+	return hash
+}
+
+func (r *testRepo) tag(name string, hash plumbing.Hash, annotated bool) {
+	r.t.Helper()
+	var opts *git.CreateTagOptions
+	if annotated {
+		opts = &git.CreateTagOptions{Message: "Release " + name, Tagger: testSignature}
+	}
+	if _, err := r.repo.CreateTag(name, hash, opts); err != nil {
+		r.t.Fatalf("tag: %v", err)
+	}
+}
+
+func (r *testRepo) plan(opts NextOptions) *Plan {
+	r.t.Helper()
+	history, err := OpenHistory(r.dir)
+	if err != nil {
+		r.t.Fatalf("OpenHistory: %v", err)
+	}
+	plan, err := PlanNext(history, opts)
+	if err != nil {
+		r.t.Fatalf("PlanNext: %v", err)
+	}
+	return plan
+}
+
+func TestInferBump(t *testing.T) {
+	tests := []struct {
+		messages []string
+		want     Bump
+	}{
+		{[]string{"chore: deps", "docs: readme"}, BumpNone},
+		{[]string{"fix: a", "chore: b"}, BumpPatch},
+		{[]string{"perf: faster"}, BumpPatch},
+		{[]string{"fix: a", "feat: b"}, BumpMinor},
+		{[]string{"feat: a", "chore!: drop go1.21"}, BumpMajor},
+		{[]string{"fix: a\n\nBREAKING CHANGE: removed flag"}, BumpMajor},
+		{[]string{"Update README"}, BumpNone},
+	}
+	for _, tc := range tests {
+		var commits []Commit
+		for _, m := range tc.messages {
+			commits = append(commits, ParseCommitMessage(m))
+		}
+		if got := InferBump(commits); got != tc.want {
+			t.Errorf("InferBump(%q) = %s, want %s", tc.messages, got, tc.want)
+		}
+	}
+}
+
+func TestPlanNext_Semver(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v1.2.3", r.commit("feat: initial"), true)
+	r.commit("fix: bug")
+	r.commit("docs: notes")
+
+	plan := r.plan(NextOptions{})
+	if plan.BaseTag != "v1.2.3" || plan.Bump != "patch" || plan.Next != "1.2.4" || plan.TagName != "v1.2.4" || len(plan.Commits) != 2 {
+		t.Fatalf("plan = %+v", plan)
+	}
+
+	r.commit("feat(cli): new flag")
+	if plan := r.plan(NextOptions{}); plan.Next != "1.3.0" {
+		t.Fatalf("feat: next = %s", plan.Next)
+	}
+	r.commit("feat!: new config")
+	if plan := r.plan(NextOptions{}); plan.Next != "2.0.0" {
+		t.Fatalf("breaking: next = %s", plan.Next)
+	}
+}
+
+func TestPlanNext_Since(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v1.2.0", r.commit("feat: initial"), false)
+	r.commit("feat: search")
+	r.tag("v1.3.0", r.commit("fix: search crash"), false)
+	r.commit("fix: typo")
+
+	plan := r.plan(NextOptions{Since: "v1.2.0"})
+	if plan.BaseTag != "v1.2.0" || plan.Next != "1.3.0" || len(plan.Commits) != 3 {
+		t.Fatalf("plan = %+v", plan)
+	}
+	if plan := r.plan(NextOptions{}); plan.BaseTag != "v1.3.0" || plan.Next != "1.3.1" {
+		t.Fatalf("latest plan = %+v", plan)
+	}
+}
+
+func TestPlanNext_NoReleasableCommits(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v0.4.0", r.commit("feat: initial"), false)
+	r.commit("chore: tidy")
+	plan := r.plan(NextOptions{})
+	if plan.Bump != "none" || plan.Next != "" {
+		t.Fatalf("plan = %+v", plan)
+	}
+}
+
+func TestPlanNext_PreOneZeroAndNoTags(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("feat: initial")
+	r.commit("feat!: rework")
+	// Without tags the VERSION fallback is the base; before 1.0.0 breaking
+	// changes bump minor.
+	plan := r.plan(NextOptions{Current: "v0.5.16"})
+	if plan.BaseTag != "" || plan.Current != "0.5.16" || plan.Next != "0.6.0" || len(plan.Commits) != 2 {
+		t.Fatalf("plan = %+v", plan)
+	}
+}
+
+func TestPlanNext_Channels(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v1.0.0", r.commit("feat: initial"), false)
+	r.commit("feat: a")
+
+	if plan := r.plan(NextOptions{Channel: "beta"}); plan.Next != "1.1.0-beta.1" {
+		t.Fatalf("first beta = %+v", plan)
+	}
+	r.tag("v1.1.0-beta.1", r.commit("fix: b"), true)
+	if plan := r.plan(NextOptions{Channel: "beta"}); plan.Bump != "none" || plan.Next != "" {
+		t.Fatalf("nothing since beta.1 = %+v", plan)
+	}
+	r.commit("fix: c")
+	if plan := r.plan(NextOptions{Channel: "beta"}); plan.Next != "1.1.0-beta.2" {
+		t.Fatalf("second beta = %+v", plan)
+	}
+	if plan := r.plan(NextOptions{Channel: "rc"}); plan.Next != "1.1.0-rc.1" {
+		t.Fatalf("rc = %+v", plan)
+	}
+	// Stable releases are computed from the last stable tag.
+	if plan := r.plan(NextOptions{Channel: "stable"}); plan.Next != "1.1.0" || plan.BaseTag != "v1.0.0" {
+		t.Fatalf("stable = %+v", plan)
+	}
+}
+
+func TestPlanNext_Calver(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("2026.09.3", r.commit("feat: initial"), false)
+	r.commit("fix: a")
+
+	sameMonth := time.Date(2026, time.September, 28, 0, 0, 0, 0, time.UTC)
+	if plan := r.plan(NextOptions{Scheme: SchemeCalver, Now: sameMonth}); plan.Next != "2026.09.4" || plan.TagName != "2026.09.4" {
+		t.Fatalf("same month = %+v", plan)
+	}
+	nextMonth := time.Date(2026, time.October, 2, 0, 0, 0, 0, time.UTC)
+	if plan := r.plan(NextOptions{Scheme: SchemeCalver, Now: nextMonth}); plan.Next != "2026.10.0" {
+		t.Fatalf("rollover = %+v", plan)
+	}
+	if plan := r.plan(NextOptions{Scheme: SchemeCalver, Channel: "rc", Now: nextMonth}); plan.Next != "2026.10.0-rc.1" {
+		t.Fatalf("calver rc = %+v", plan)
+	}
+}
+
+func TestCommitRelease(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("feat: initial")
+	if err := os.WriteFile(filepath.Join(r.dir, "VERSION"), []byte("1.0.0\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := r.repo.Config()
+	if err != nil {
+		t.Fatalf("config: %v", err)
+	}
+	cfg.User.Name, cfg.User.Email = "goneat", "ci@goneat.dev"
+	if err := r.repo.SetConfig(cfg); err != nil {
+		t.Fatalf("set config: %v", err)
+	}
+
+	history, err := OpenHistory(r.dir)
+	if err != nil {
+		t.Fatalf("OpenHistory: %v", err)
+	}
+	hash, err := history.CommitRelease([]string{filepath.Join(r.dir, "VERSION")}, "v1.0.0")
+	if err != nil {
+		t.Fatalf("CommitRelease: %v", err)
+	}
+	ref, err := r.repo.Tag("v1.0.0")
+	if err != nil {
+		t.Fatalf("tag: %v", err)
+	}
+	tagObj, err := r.repo.TagObject(ref.Hash())
+	if err != nil {
+		t.Fatalf("expected annotated tag: %v", err)
+	}
+	if tagObj.Target != hash {
+		t.Errorf("tag target = %s, want %s", tagObj.Target, hash)
+	}
+	commit, _ := r.repo.CommitObject(hash)
+	if commit.Message != "chore(release): v1.0.0" {
+		t.Errorf("message = %q", commit.Message)
+	}
+}
+
+func TestCommitRelease_RefusesOtherStagedChanges(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("feat: initial")
+	for name, content := range map[string]string{"VERSION": "1.0.0\n", "secrets.env": "TOKEN=x\n", "notes.txt": "wip\n"} {
+		if err := os.WriteFile(filepath.Join(r.dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+	if _, err := wt.Add("secrets.env"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	history, err := OpenHistory(r.dir)
+	if err != nil {
+		t.Fatalf("OpenHistory: %v", err)
+	}
+	if err := history.CheckStaged(nil); err == nil || !strings.Contains(err.Error(), "secrets.env") {
+		t.Errorf("CheckStaged() error = %v, want the staged file named", err)
+	}
+	if _, err := history.CommitRelease([]string{filepath.Join(r.dir, "VERSION")}, "v1.0.0"); err == nil || !strings.Contains(err.Error(), "secrets.env") {
+		t.Fatalf("CommitRelease() error = %v, want a refusal naming secrets.env", err)
+	}
+	if _, err := r.repo.Tag("v1.0.0"); err == nil {
+		t.Error("tag created despite the refusal")
+	}
+
+	// Untracked files and staged release files do not block the release.
+	if _, err := wt.Remove("secrets.env"); err != nil {
+		t.Fatalf("unstage: %v", err)
+	}
+	if _, err := wt.Add("VERSION"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := history.CheckStaged([]string{filepath.Join(r.dir, "VERSION")}); err != nil {
+		t.Errorf("CheckStaged(VERSION) error = %v", err)
+	}
+}
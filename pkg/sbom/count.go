@@ -19,3 +19,30 @@ func CountCycloneDXPackagesFromFile(path string) (int, error) {
 	}
 	return len(cdx.Components), nil
 }
+
+// CountPackagesFromFile counts the packages in an SBOM of any supported
+// format.
+func CountPackagesFromFile(path string) (int, error) {
+	doc, _, err := LoadDocument(path)
+	if err != nil {
+		return 0, err
+	}
+	return len(doc.Packages), nil
+}
+
+// LoadDependencyGraphFromFile extracts the dependency graph from an SBOM on
+// disk (CycloneDX or SPDX).
+func LoadDependencyGraphFromFile(path string) (*DependencyGraph, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is an SBOM produced or selected by goneat
+	if err != nil {
+		return nil, fmt.Errorf("read sbom: %w", err)
+	}
+	if DetectFormat(data) == FormatCycloneDXJSON {
+		return extractDependencyGraph(data, FormatCycloneDXJSON)
+	}
+	doc, _, err := ParseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Graph(), nil
+}
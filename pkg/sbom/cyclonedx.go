@@ -0,0 +1,247 @@
+package sbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type cdxBOM struct {
+	BOMFormat    string          `json:"bomFormat"`
+	SpecVersion  string          `json:"specVersion"`
+	SerialNumber string          `json:"serialNumber,omitempty"`
+	Version      int             `json:"version"`
+	Metadata     cdxMetadata     `json:"metadata"`
+	Components   []cdxComponent  `json:"components"`
+	Dependencies []cdxDependency `json:"dependencies,omitempty"`
+}
+
+type cdxMetadata struct {
+	Timestamp string                         `json:"timestamp,omitempty"`
+	Component *cdxComponent                  `json:"component,omitempty"`
+	Tools     map[string][]map[string]string `json:"tools,omitempty"`
+}
+
+type cdxComponent struct {
+	BOMRef     string         `json:"bom-ref,omitempty"`
+	Type       string         `json:"type"`
+	Name       string         `json:"name"`
+	Version    string         `json:"version,omitempty"`
+	PURL       string         `json:"purl,omitempty"`
+	Licenses   []cdxLicense   `json:"licenses,omitempty"`
+	Properties []cdxProperty  `json:"properties,omitempty"`
+	Components []cdxComponent `json:"components,omitempty"`
+}
+
+type cdxLicense struct {
+	License *struct {
+		ID   string `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"license,omitempty"`
+	Expression string `json:"expression,omitempty"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// goneatLicenseConcludedProperty carries the concluded license through
+// CycloneDX 1.5, which has a single license list per component.
+const goneatLicenseConcludedProperty = "goneat:license_concluded"
+
+func decodeCycloneDX(data []byte) (*Document, error) {
+	var bom cdxBOM
+	if err := json.Unmarshal(data, &bom); err != nil {
+		return nil, fmt.Errorf("failed to parse CycloneDX SBOM: %w", err)
+	}
+	doc := &Document{Namespace: bom.SerialNumber}
+	if t, err := time.Parse(time.RFC3339, bom.Metadata.Timestamp); err == nil {
+		doc.Created = t
+	}
+
+	seen := map[string]bool{}
+	for _, c := range bom.Components {
+		p := cdxPackage(c)
+		seen[p.Ref] = true
+		doc.Packages = append(doc.Packages, p)
+	}
+	if mc := bom.Metadata.Component; mc != nil {
+		root := cdxPackage(*mc)
+		doc.Name = root.Name
+		doc.Root = root.Ref
+		if !seen[root.Ref] {
+			doc.Packages = append([]Package{root}, doc.Packages...)
+		}
+	}
+	for _, dep := range bom.Dependencies {
+		for _, child := range dep.DependsOn {
+			doc.Relationships = append(doc.Relationships, Relationship{From: dep.Ref, To: child})
+		}
+	}
+	return doc, nil
+}
+
+func cdxPackage(c cdxComponent) Package {
+	ref := c.BOMRef
+	if ref == "" {
+		ref = fmt.Sprintf("%s@%s", c.Name, c.Version)
+	}
+	p := Package{Ref: ref, Name: c.Name, Version: c.Version, Type: c.Type, PURL: c.PURL}
+	var parts []string
+	for _, l := range c.Licenses {
+		switch {
+		case l.Expression != "":
+			parts = append(parts, l.Expression)
+		case l.License != nil && l.License.ID != "":
+			parts = append(parts, l.License.ID)
+		case l.License != nil && l.License.Name != "":
+			parts = append(parts, l.License.Name)
+		}
+	}
+	if len(parts) == 1 {
+		p.LicenseDeclared = parts[0]
+	} else if len(parts) > 1 {
+		p.LicenseDeclared = "(" + strings.Join(parts, ") AND (") + ")"
+	}
+	for _, prop := range c.Properties {
+		if prop.Name == goneatLicenseConcludedProperty {
+			p.LicenseConcluded = prop.Value
+			continue
+		}
+		p.Properties = append(p.Properties, Property{Name: prop.Name, Value: prop.Value})
+	}
+	return p
+}
+
+func encodeCycloneDX(d *Document) ([]byte, error) {
+	bom := cdxBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Metadata: cdxMetadata{
+			Timestamp: d.created().Format(time.RFC3339),
+			Tools: map[string][]map[string]string{
+				"components": {{"type": "application", "name": nonEmptyString(d.ToolName, "goneat"), "version": nonEmptyString(d.ToolVersion, "unknown")}},
+			},
+		},
+		Components: []cdxComponent{},
+	}
+	if strings.HasPrefix(d.Namespace, "urn:uuid:") {
+		bom.SerialNumber = d.Namespace
+	}
+	for _, p := range d.Packages {
+		c := cdxComponent{BOMRef: p.Ref, Type: nonEmptyString(p.Type, "library"), Name: p.Name, Version: p.Version, PURL: p.PURL}
+		if p.LicenseDeclared != "" {
+			c.Licenses = []cdxLicense{{Expression: p.LicenseDeclared}}
+		}
+		for _, prop := range p.Properties {
+			c.Properties = append(c.Properties, cdxProperty(prop))
+		}
+		if p.LicenseConcluded != "" && p.LicenseConcluded != p.LicenseDeclared {
+			c.Properties = append(c.Properties, cdxProperty{Name: goneatLicenseConcludedProperty, Value: p.LicenseConcluded})
+		}
+		if p.Ref == d.Root {
+			root := c
+			root.Properties = nil
+			bom.Metadata.Component = &root
+		}
+		bom.Components = append(bom.Components, c)
+	}
+
+	var order []string
+	deps := map[string][]string{}
+	for _, r := range d.Relationships {
+		if _, ok := deps[r.From]; !ok {
+			order = append(order, r.From)
+		}
+		deps[r.From] = append(deps[r.From], r.To)
+	}
+	for _, ref := range order {
+		bom.Dependencies = append(bom.Dependencies, cdxDependency{Ref: ref, DependsOn: deps[ref]})
+	}
+
+	data, err := json.MarshalIndent(bom, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal CycloneDX sbom: %w", err)
+	}
+	return data, nil
+}
+
+func nonEmptyString(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+// enrichCycloneDX applies analyzer licenses to a CycloneDX BOM in place, the
+// way ApplyLicenses does for a Document, leaving every other field (hashes,
+// CPEs, external references, generator properties, spec version) untouched.
+func enrichCycloneDX(data []byte, licenses map[string]PackageLicense) ([]byte, error) {
+	if len(licenses) == 0 {
+		return data, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var bom map[string]interface{}
+	if err := dec.Decode(&bom); err != nil {
+		return nil, fmt.Errorf("failed to parse CycloneDX SBOM: %w", err)
+	}
+	if metadata, ok := bom["metadata"].(map[string]interface{}); ok {
+		if component, ok := metadata["component"].(map[string]interface{}); ok {
+			enrichCycloneDXComponent(component, licenses)
+		}
+	}
+	enrichCycloneDXComponents(bom["components"], licenses)
+
+	out, err := json.MarshalIndent(bom, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal CycloneDX sbom: %w", err)
+	}
+	return out, nil
+}
+
+func enrichCycloneDXComponents(v interface{}, licenses map[string]PackageLicense) {
+	components, _ := v.([]interface{})
+	for _, item := range components {
+		if component, ok := item.(map[string]interface{}); ok {
+			enrichCycloneDXComponent(component, licenses)
+			enrichCycloneDXComponents(component["components"], licenses)
+		}
+	}
+}
+
+func enrichCycloneDXComponent(component map[string]interface{}, licenses map[string]PackageLicense) {
+	name, _ := component["name"].(string)
+	version, _ := component["version"].(string)
+	lic, ok := licenses[name+"@"+version]
+	if !ok {
+		lic, ok = licenses[name]
+	}
+	if !ok {
+		return
+	}
+	if lic.Declared != "" {
+		component["licenses"] = []interface{}{map[string]interface{}{"expression": lic.Declared}}
+	}
+	if lic.Concluded == "" || lic.Concluded == lic.Declared {
+		return
+	}
+	properties, _ := component["properties"].([]interface{})
+	kept := properties[:0]
+	for _, prop := range properties {
+		if p, ok := prop.(map[string]interface{}); ok && p["name"] == goneatLicenseConcludedProperty {
+			continue
+		}
+		kept = append(kept, prop)
+	}
+	component["properties"] = append(kept, map[string]interface{}{"name": goneatLicenseConcludedProperty, "value": lic.Concluded})
+}
@@ -0,0 +1,304 @@
+package sbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Supported SBOM formats.
+const (
+	FormatCycloneDXJSON = "cyclonedx-json"
+	FormatSPDXJSON      = "spdx-json"
+	FormatSPDXTagValue  = "spdx-tag-value"
+	FormatSPDX3JSON     = "spdx3-json"
+)
+
+// Formats lists the SBOM formats goneat can read and write.
+var Formats = []string{FormatCycloneDXJSON, FormatSPDXJSON, FormatSPDXTagValue, FormatSPDX3JSON}
+
+// ValidFormat reports whether format is one of Formats.
+func ValidFormat(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// FileExtension returns the conventional file suffix for a format.
+func FileExtension(format string) string {
+	switch format {
+	case FormatSPDXJSON:
+		return ".spdx.json"
+	case FormatSPDXTagValue:
+		return ".spdx"
+	case FormatSPDX3JSON:
+		return ".spdx3.json"
+	}
+	return ".cdx.json"
+}
+
+// Document is a format-neutral SBOM: packages, the package the document
+// describes, and DEPENDS_ON edges. Encoders map it onto CycloneDX or SPDX.
+type Document struct {
+	Name        string
+	Namespace   string
+	Created     time.Time
+	ToolName    string
+	ToolVersion string
+	// Root is the Ref of the described package, if any.
+	Root          string
+	Packages      []Package
+	Relationships []Relationship
+}
+
+// Package is a component of the SBOM.
+type Package struct {
+	Ref     string
+	Name    string
+	Version string
+	// Type is the CycloneDX component type (application, library, ...).
+	Type string
+	PURL string
+	// LicenseDeclared is the license stated by the package authors;
+	// LicenseConcluded the license goneat concluded after analysis. Both are
+	// SPDX expressions or empty when unknown.
+	LicenseDeclared  string
+	LicenseConcluded string
+	Properties       []Property
+}
+
+// Property is a name/value annotation carried through CycloneDX properties.
+type Property struct {
+	Name  string
+	Value string
+}
+
+// Relationship records that From depends on To (both package Refs).
+type Relationship struct {
+	From string
+	To   string
+}
+
+// PackageLicense carries analyzer license results used to enrich an SBOM.
+type PackageLicense struct {
+	Declared  string
+	Concluded string
+}
+
+// DetectFormat identifies an SBOM encoding from its content.
+func DetectFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("SPDXVersion:")) || bytes.Contains(trimmed[:min(len(trimmed), 4096)], []byte("\nSPDXVersion:")) {
+		return FormatSPDXTagValue
+	}
+	if !bytes.HasPrefix(trimmed, []byte("{")) {
+		return ""
+	}
+	var probe struct {
+		BOMFormat   string          `json:"bomFormat"`
+		SPDXVersion string          `json:"spdxVersion"`
+		Context     json.RawMessage `json:"@context"`
+		Graph       json.RawMessage `json:"@graph"`
+	}
+	if err := json.Unmarshal(trimmed, &probe); err != nil {
+		return ""
+	}
+	switch {
+	case strings.EqualFold(probe.BOMFormat, "CycloneDX"):
+		return FormatCycloneDXJSON
+	case probe.SPDXVersion != "":
+		return FormatSPDXJSON
+	case len(probe.Graph) > 0 && bytes.Contains(probe.Context, []byte("spdx")):
+		return FormatSPDX3JSON
+	}
+	return ""
+}
+
+// ParseDocument decodes an SBOM in any supported format.
+func ParseDocument(data []byte) (*Document, string, error) {
+	format := DetectFormat(data)
+	if format == "" {
+		return nil, "", fmt.Errorf("unrecognized SBOM format (supported: %s)", strings.Join(Formats, ", "))
+	}
+	doc, err := decodeDocument(data, format)
+	return doc, format, err
+}
+
+// LoadDocument reads and decodes an SBOM file.
+func LoadDocument(path string) (*Document, string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is an SBOM produced or selected by goneat
+	if err != nil {
+		return nil, "", fmt.Errorf("read sbom: %w", err)
+	}
+	return ParseDocument(data)
+}
+
+func decodeDocument(data []byte, format string) (*Document, error) {
+	switch format {
+	case FormatCycloneDXJSON:
+		return decodeCycloneDX(data)
+	case FormatSPDXJSON:
+		return decodeSPDXJSON(data)
+	case FormatSPDXTagValue:
+		return decodeSPDXTagValue(data)
+	case FormatSPDX3JSON:
+		return decodeSPDX3(data)
+	}
+	return nil, fmt.Errorf("unsupported SBOM format: %s", format)
+}
+
+// Encode renders the document in the requested format.
+func (d *Document) Encode(format string) ([]byte, error) {
+	switch format {
+	case FormatCycloneDXJSON:
+		return encodeCycloneDX(d)
+	case FormatSPDXJSON:
+		return encodeSPDXJSON(d)
+	case FormatSPDXTagValue:
+		return encodeSPDXTagValue(d)
+	case FormatSPDX3JSON:
+		return encodeSPDX3(d)
+	}
+	return nil, fmt.Errorf("unsupported SBOM format %q (supported: %s)", format, strings.Join(Formats, ", "))
+}
+
+// Package returns the package with the given ref.
+func (d *Document) Package(ref string) *Package {
+	for i := range d.Packages {
+		if d.Packages[i].Ref == ref {
+			return &d.Packages[i]
+		}
+	}
+	return nil
+}
+
+// Graph builds the dependency graph. Roots are packages nothing depends on.
+func (d *Document) Graph() *DependencyGraph {
+	if len(d.Packages) == 0 {
+		return nil
+	}
+	graph := &DependencyGraph{Nodes: make(map[string]*DependencyNode, len(d.Packages))}
+	for _, p := range d.Packages {
+		graph.Nodes[p.Ref] = &DependencyNode{Ref: p.Ref, Name: p.Name, Version: p.Version, Type: p.Type, PURL: p.PURL}
+	}
+	candidateRoots := make(map[string]struct{}, len(graph.Nodes))
+	for ref := range graph.Nodes {
+		candidateRoots[ref] = struct{}{}
+	}
+	for _, r := range d.Relationships {
+		node, ok := graph.Nodes[r.From]
+		if !ok {
+			continue
+		}
+		node.Dependencies = append(node.Dependencies, r.To)
+		delete(candidateRoots, r.To)
+	}
+	for ref := range candidateRoots {
+		graph.Roots = append(graph.Roots, ref)
+	}
+	sort.Strings(graph.Roots)
+	return graph
+}
+
+// ApplyLicenses fills license fields from analyzer results keyed by
+// "name@version" (or bare name). Existing declared licenses from the SBOM
+// generator are kept when the analyzer has none.
+func (d *Document) ApplyLicenses(licenses map[string]PackageLicense) {
+	if len(licenses) == 0 {
+		return
+	}
+	for i := range d.Packages {
+		p := &d.Packages[i]
+		lic, ok := licenses[p.Name+"@"+p.Version]
+		if !ok {
+			lic, ok = licenses[p.Name]
+		}
+		if !ok {
+			continue
+		}
+		if lic.Declared != "" {
+			p.LicenseDeclared = lic.Declared
+		}
+		if lic.Concluded != "" {
+			p.LicenseConcluded = lic.Concluded
+		}
+	}
+}
+
+// sortedRelationships returns relationships grouped by source in a stable order.
+func (d *Document) sortedRelationships() []Relationship {
+	rels := append([]Relationship(nil), d.Relationships...)
+	sort.SliceStable(rels, func(i, j int) bool {
+		if rels[i].From != rels[j].From {
+			return rels[i].From < rels[j].From
+		}
+		return rels[i].To < rels[j].To
+	})
+	return rels
+}
+
+func (d *Document) toolString() string {
+	name := d.ToolName
+	if name == "" {
+		name = "goneat"
+	}
+	if d.ToolVersion == "" {
+		return name
+	}
+	return name + "-" + d.ToolVersion
+}
+
+func (d *Document) created() time.Time {
+	if d.Created.IsZero() {
+		return time.Now().UTC()
+	}
+	return d.Created.UTC()
+}
+
+// described returns the refs the document describes: the root package, or
+// every package nothing depends on when no root is set.
+func (d *Document) described() []string {
+	if d.Root != "" && d.Package(d.Root) != nil {
+		return []string{d.Root}
+	}
+	if graph := d.Graph(); graph != nil {
+		return graph.Roots
+	}
+	return nil
+}
+
+func (d *Document) namespace() string {
+	if d.Namespace != "" && !strings.HasPrefix(d.Namespace, "urn:uuid:") {
+		return d.Namespace
+	}
+	return DocumentNamespace(nonEmptyString(d.Name, "goneat-sbom"), "")
+}
+
+// DocumentNamespace derives an SPDX document namespace from a repository
+// identifier (git remote URL or module path) and the project version, e.g.
+// https://github.com/fulmenhq/goneat/spdx/v1.2.3.
+func DocumentNamespace(repo, version string) string {
+	repo = strings.TrimSpace(repo)
+	if rest, ok := strings.CutPrefix(repo, "git@"); ok {
+		repo = strings.Replace(rest, ":", "/", 1)
+	}
+	for _, prefix := range []string{"https://", "http://", "ssh://git@", "ssh://", "git://"} {
+		repo = strings.TrimPrefix(repo, prefix)
+	}
+	repo = strings.Trim(strings.TrimSuffix(repo, ".git"), "/")
+	if version == "" {
+		version = "unversioned"
+	}
+	host, _, _ := strings.Cut(repo, "/")
+	if repo == "" || !strings.Contains(host, ".") {
+		return "https://spdx.org/spdxdocs/" + spdxIDString(nonEmptyString(repo, "goneat-sbom")) + "/" + version
+	}
+	return "https://" + repo + "/spdx/" + version
+}
@@ -21,6 +21,11 @@ type Config struct {
 	Stdout          bool
 	Platform        string
 	ExcludePatterns []string
+	// Namespace overrides the SPDX document namespace (see DocumentNamespace).
+	Namespace string
+	// Licenses enriches package license fields with goneat analyzer results,
+	// keyed by "name@version" or bare name.
+	Licenses map[string]PackageLicense
 }
 
 type Result struct {
@@ -123,8 +128,17 @@ func (s *SyftInvoker) Generate(ctx context.Context, config Config) (*Result, err
 	}
 
 	if config.Format == "" {
-		config.Format = "cyclonedx-json"
+		config.Format = FormatCycloneDXJSON
 	}
+	if !ValidFormat(config.Format) {
+		return nil, fmt.Errorf("unsupported SBOM format %q (supported: %s)", config.Format, strings.Join(Formats, ", "))
+	}
+	// syft always produces CycloneDX; goneat renders other formats itself so
+	// relationships and license fields are consistent with the native
+	// generators. CycloneDX output is kept as syft wrote it and only has
+	// licenses applied in place, so hashes, CPEs and syft metadata survive.
+	convert := config.Format != FormatCycloneDXJSON
+	enrich := !convert && len(config.Licenses) > 0
 
 	toolVersion, err := s.GetVersion(ctx)
 	if err != nil {
@@ -137,7 +151,7 @@ func (s *SyftInvoker) Generate(ctx context.Context, config Config) (*Result, err
 	if !config.Stdout {
 		if config.OutputPath == "" {
 			timestamp := time.Now().Format("20060102-150405")
-			outputPath = filepath.Join("sbom", fmt.Sprintf("goneat-%s.cdx.json", timestamp))
+			outputPath = filepath.Join("sbom", fmt.Sprintf("goneat-%s%s", timestamp, FileExtension(config.Format)))
 		} else {
 			outputPath = config.OutputPath
 		}
@@ -173,8 +187,8 @@ func (s *SyftInvoker) Generate(ctx context.Context, config Config) (*Result, err
 	}
 
 	// Use new --output FORMAT=PATH syntax (or FORMAT for stdout)
-	if config.Stdout {
-		args = append(args, "--output", config.Format)
+	if config.Stdout || convert || enrich {
+		args = append(args, "--output", FormatCycloneDXJSON)
 	} else {
 		args = append(args, "--output", fmt.Sprintf("%s=%s", config.Format, outputPath))
 	}
@@ -185,7 +199,7 @@ func (s *SyftInvoker) Generate(ctx context.Context, config Config) (*Result, err
 	cmd.Stderr = os.Stderr
 
 	var sbomContent json.RawMessage
-	if config.Stdout {
+	if config.Stdout || convert || enrich {
 		output, err := cmd.Output()
 		if err != nil {
 			return nil, fmt.Errorf("syft execution failed: %w", err)
@@ -204,18 +218,56 @@ func (s *SyftInvoker) Generate(ctx context.Context, config Config) (*Result, err
 		sbomContent = content
 	}
 
-	duration := time.Since(startTime)
+	var packageCount int
+	var dependencyGraph *DependencyGraph
+	if convert {
+		doc, err := decodeCycloneDX(sbomContent)
+		if err != nil {
+			return nil, err
+		}
+		doc.ToolName = "syft"
+		doc.ToolVersion = toolVersion
+		if doc.Name == "" {
+			doc.Name = filepath.Base(targetPath)
+		}
+		doc.Namespace = nonEmptyString(config.Namespace, doc.Namespace)
+		doc.ApplyLicenses(config.Licenses)
+		sbomContent, err = doc.Encode(config.Format)
+		if err != nil {
+			return nil, err
+		}
+		if !config.Stdout {
+			if err := os.WriteFile(outputPath, sbomContent, 0o600); err != nil {
+				return nil, fmt.Errorf("failed to write SBOM: %w", err)
+			}
+		}
+		packageCount = len(doc.Packages)
+		dependencyGraph = doc.Graph()
+	} else {
+		if enrich {
+			sbomContent, err = enrichCycloneDX(sbomContent, config.Licenses)
+			if err != nil {
+				return nil, err
+			}
+			if !config.Stdout {
+				if err := os.WriteFile(outputPath, sbomContent, 0o600); err != nil {
+					return nil, fmt.Errorf("failed to write SBOM: %w", err)
+				}
+			}
+		}
+		packageCount, err = extractPackageCount(sbomContent, config.Format)
+		if err != nil {
+			logger.Warn("sbom: failed to extract package count", logger.String("error", err.Error()))
+			packageCount = 0
+		}
 
-	packageCount, err := extractPackageCount(sbomContent, config.Format)
-	if err != nil {
-		logger.Warn("sbom: failed to extract package count", logger.String("error", err.Error()))
-		packageCount = 0
+		dependencyGraph, err = extractDependencyGraph(sbomContent, config.Format)
+		if err != nil {
+			logger.Warn("sbom: failed to extract dependency graph", logger.String("error", err.Error()))
+		}
 	}
 
-	dependencyGraph, err := extractDependencyGraph(sbomContent, config.Format)
-	if err != nil {
-		logger.Warn("sbom: failed to extract dependency graph", logger.String("error", err.Error()))
-	}
+	duration := time.Since(startTime)
 
 	result := &Result{
 		OutputPath:      outputPath,
@@ -0,0 +1,458 @@
+package sbom
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/dependencies/spdx"
+)
+
+const (
+	spdxVersion     = "SPDX-2.3"
+	spdxDataLicense = "CC0-1.0"
+	spdxDocumentID  = "SPDXRef-DOCUMENT"
+	spdxNoAssertion = "NOASSERTION"
+)
+
+type spdxDocument struct {
+	SPDXVersion       string                 `json:"spdxVersion"`
+	DataLicense       string                 `json:"dataLicense"`
+	SPDXID            string                 `json:"SPDXID"`
+	Name              string                 `json:"name"`
+	DocumentNamespace string                 `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo       `json:"creationInfo"`
+	Packages          []spdxPackage          `json:"packages"`
+	Relationships     []spdxRelationship     `json:"relationships,omitempty"`
+	ExtractedLicenses []spdxExtractedLicense `json:"hasExtractedLicensingInfos,omitempty"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name                  string            `json:"name"`
+	SPDXID                string            `json:"SPDXID"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	LicenseConcluded      string            `json:"licenseConcluded"`
+	LicenseDeclared       string            `json:"licenseDeclared"`
+	CopyrightText         string            `json:"copyrightText"`
+	ExternalRefs          []spdxExternalRef `json:"externalRefs,omitempty"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+type spdxExtractedLicense struct {
+	LicenseID     string `json:"licenseId"`
+	ExtractedText string `json:"extractedText"`
+	Name          string `json:"name,omitempty"`
+}
+
+// spdxView is the SPDX 2.3 rendering of a Document shared by the JSON and
+// tag-value encoders.
+func spdxView(d *Document) spdxDocument {
+	ids := newSPDXIDs(d)
+	refs := &licenseRefs{}
+	out := spdxDocument{
+		SPDXVersion:       spdxVersion,
+		DataLicense:       spdxDataLicense,
+		SPDXID:            spdxDocumentID,
+		Name:              nonEmptyString(d.Name, "goneat-sbom"),
+		DocumentNamespace: d.namespace(),
+		CreationInfo: spdxCreationInfo{
+			Created:  d.created().Format(time.RFC3339),
+			Creators: []string{"Tool: " + d.toolString()},
+		},
+		Packages: []spdxPackage{},
+	}
+	for _, p := range d.Packages {
+		sp := spdxPackage{
+			Name:                  p.Name,
+			SPDXID:                ids[p.Ref],
+			VersionInfo:           p.Version,
+			DownloadLocation:      spdxNoAssertion,
+			LicenseConcluded:      refs.expression(p.LicenseConcluded),
+			LicenseDeclared:       refs.expression(p.LicenseDeclared),
+			CopyrightText:         spdxNoAssertion,
+			PrimaryPackagePurpose: spdxPurpose(p.Type),
+		}
+		if p.PURL != "" {
+			sp.ExternalRefs = []spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: p.PURL}}
+		}
+		out.Packages = append(out.Packages, sp)
+	}
+	for _, ref := range d.described() {
+		out.Relationships = append(out.Relationships, spdxRelationship{SPDXElementID: spdxDocumentID, RelationshipType: "DESCRIBES", RelatedSPDXElement: ids[ref]})
+	}
+	for _, r := range d.sortedRelationships() {
+		from, okFrom := ids[r.From]
+		to, okTo := ids[r.To]
+		if !okFrom || !okTo {
+			continue
+		}
+		out.Relationships = append(out.Relationships, spdxRelationship{SPDXElementID: from, RelationshipType: "DEPENDS_ON", RelatedSPDXElement: to})
+	}
+	out.ExtractedLicenses = refs.extracted()
+	return out
+}
+
+func encodeSPDXJSON(d *Document) ([]byte, error) {
+	data, err := json.MarshalIndent(spdxView(d), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal SPDX sbom: %w", err)
+	}
+	return data, nil
+}
+
+func encodeSPDXTagValue(d *Document) ([]byte, error) {
+	doc := spdxView(d)
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "SPDXVersion: %s\n", doc.SPDXVersion)
+	fmt.Fprintf(&b, "DataLicense: %s\n", doc.DataLicense)
+	fmt.Fprintf(&b, "SPDXID: %s\n", doc.SPDXID)
+	fmt.Fprintf(&b, "DocumentName: %s\n", doc.Name)
+	fmt.Fprintf(&b, "DocumentNamespace: %s\n", doc.DocumentNamespace)
+	for _, c := range doc.CreationInfo.Creators {
+		fmt.Fprintf(&b, "Creator: %s\n", c)
+	}
+	fmt.Fprintf(&b, "Created: %s\n", doc.CreationInfo.Created)
+	for _, p := range doc.Packages {
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "PackageName: %s\n", p.Name)
+		fmt.Fprintf(&b, "SPDXID: %s\n", p.SPDXID)
+		if p.VersionInfo != "" {
+			fmt.Fprintf(&b, "PackageVersion: %s\n", p.VersionInfo)
+		}
+		fmt.Fprintf(&b, "PackageDownloadLocation: %s\n", p.DownloadLocation)
+		fmt.Fprintf(&b, "FilesAnalyzed: %t\n", p.FilesAnalyzed)
+		fmt.Fprintf(&b, "PackageLicenseConcluded: %s\n", p.LicenseConcluded)
+		fmt.Fprintf(&b, "PackageLicenseDeclared: %s\n", p.LicenseDeclared)
+		fmt.Fprintf(&b, "PackageCopyrightText: %s\n", p.CopyrightText)
+		for _, ref := range p.ExternalRefs {
+			fmt.Fprintf(&b, "ExternalRef: %s %s %s\n", ref.ReferenceCategory, ref.ReferenceType, ref.ReferenceLocator)
+		}
+		if p.PrimaryPackagePurpose != "" {
+			fmt.Fprintf(&b, "PrimaryPackagePurpose: %s\n", p.PrimaryPackagePurpose)
+		}
+	}
+	if len(doc.Relationships) > 0 {
+		b.WriteString("\n")
+	}
+	for _, r := range doc.Relationships {
+		fmt.Fprintf(&b, "Relationship: %s %s %s\n", r.SPDXElementID, r.RelationshipType, r.RelatedSPDXElement)
+	}
+	for _, l := range doc.ExtractedLicenses {
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "LicenseID: %s\n", l.LicenseID)
+		fmt.Fprintf(&b, "ExtractedText: <text>%s</text>\n", l.ExtractedText)
+		if l.Name != "" {
+			fmt.Fprintf(&b, "LicenseName: %s\n", l.Name)
+		}
+	}
+	return b.Bytes(), nil
+}
+
+func decodeSPDXJSON(data []byte) (*Document, error) {
+	var doc spdxDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse SPDX SBOM: %w", err)
+	}
+	return spdxToDocument(doc), nil
+}
+
+func decodeSPDXTagValue(data []byte) (*Document, error) {
+	var doc spdxDocument
+	var pkg *spdxPackage
+	flush := func() {
+		if pkg != nil {
+			doc.Packages = append(doc.Packages, *pkg)
+			pkg = nil
+		}
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	inText := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Multi-line <text> values (license texts, comments) are skipped.
+		if inText {
+			inText = !strings.Contains(line, "</text>")
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, "<text>") && !strings.Contains(value, "</text>") {
+			inText = true
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "SPDXVersion":
+			doc.SPDXVersion = value
+		case "DocumentName":
+			doc.Name = value
+		case "DocumentNamespace":
+			doc.DocumentNamespace = value
+		case "Created":
+			doc.CreationInfo.Created = value
+		case "Creator":
+			doc.CreationInfo.Creators = append(doc.CreationInfo.Creators, value)
+		case "PackageName":
+			flush()
+			pkg = &spdxPackage{Name: value}
+		case "SPDXID":
+			if pkg != nil {
+				pkg.SPDXID = value
+			} else {
+				doc.SPDXID = value
+			}
+		case "PackageVersion":
+			if pkg != nil {
+				pkg.VersionInfo = value
+			}
+		case "PackageLicenseConcluded":
+			if pkg != nil {
+				pkg.LicenseConcluded = value
+			}
+		case "PackageLicenseDeclared":
+			if pkg != nil {
+				pkg.LicenseDeclared = value
+			}
+		case "PrimaryPackagePurpose":
+			if pkg != nil {
+				pkg.PrimaryPackagePurpose = value
+			}
+		case "ExternalRef":
+			if fields := strings.Fields(value); pkg != nil && len(fields) == 3 {
+				pkg.ExternalRefs = append(pkg.ExternalRefs, spdxExternalRef{ReferenceCategory: fields[0], ReferenceType: fields[1], ReferenceLocator: fields[2]})
+			}
+		case "Relationship":
+			if fields := strings.Fields(value); len(fields) == 3 {
+				doc.Relationships = append(doc.Relationships, spdxRelationship{SPDXElementID: fields[0], RelationshipType: fields[1], RelatedSPDXElement: fields[2]})
+			}
+		case "FileName", "SnippetSPDXID", "LicenseID":
+			// Package sections end where file, snippet or license sections begin.
+			flush()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse SPDX tag-value SBOM: %w", err)
+	}
+	flush()
+	if doc.SPDXVersion == "" {
+		return nil, fmt.Errorf("failed to parse SPDX tag-value SBOM: missing SPDXVersion")
+	}
+	return spdxToDocument(doc), nil
+}
+
+func spdxToDocument(doc spdxDocument) *Document {
+	out := &Document{Name: doc.Name, Namespace: doc.DocumentNamespace}
+	if t, err := time.Parse(time.RFC3339, doc.CreationInfo.Created); err == nil {
+		out.Created = t
+	}
+	for _, c := range doc.CreationInfo.Creators {
+		if tool, ok := strings.CutPrefix(c, "Tool: "); ok {
+			out.ToolName = tool
+			break
+		}
+	}
+	for _, sp := range doc.Packages {
+		p := Package{
+			Ref:              sp.SPDXID,
+			Name:             sp.Name,
+			Version:          sp.VersionInfo,
+			Type:             cdxTypeFromSPDXPurpose(sp.PrimaryPackagePurpose),
+			LicenseDeclared:  spdxLicenseValue(sp.LicenseDeclared),
+			LicenseConcluded: spdxLicenseValue(sp.LicenseConcluded),
+		}
+		for _, ref := range sp.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				p.PURL = ref.ReferenceLocator
+				break
+			}
+		}
+		out.Packages = append(out.Packages, p)
+	}
+	for _, r := range doc.Relationships {
+		switch r.RelationshipType {
+		case "DESCRIBES":
+			if r.SPDXElementID == spdxDocumentID && out.Root == "" {
+				out.Root = r.RelatedSPDXElement
+			}
+		case "DESCRIBED_BY":
+			if r.RelatedSPDXElement == spdxDocumentID && out.Root == "" {
+				out.Root = r.SPDXElementID
+			}
+		case "DEPENDS_ON":
+			out.Relationships = append(out.Relationships, Relationship{From: r.SPDXElementID, To: r.RelatedSPDXElement})
+		case "DEPENDENCY_OF":
+			out.Relationships = append(out.Relationships, Relationship{From: r.RelatedSPDXElement, To: r.SPDXElementID})
+		}
+	}
+	return out
+}
+
+// newSPDXIDs assigns each package a unique SPDX element identifier derived
+// from its ref. Refs that already are SPDX identifiers are kept.
+func newSPDXIDs(d *Document) map[string]string {
+	ids := make(map[string]string, len(d.Packages))
+	used := map[string]bool{spdxDocumentID: true}
+	for _, p := range d.Packages {
+		id := p.Ref
+		if !strings.HasPrefix(id, "SPDXRef-") {
+			id = "SPDXRef-Package-" + spdxIDString(nonEmptyString(p.Ref, p.Name+"-"+p.Version))
+		}
+		base := id
+		for n := 2; used[id]; n++ {
+			id = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[id] = true
+		ids[p.Ref] = id
+	}
+	return ids
+}
+
+// spdxIDString reduces s to the characters SPDX allows in identifiers
+// (letters, digits, '.' and '-').
+func spdxIDString(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.':
+			b.WriteRune(r)
+			dash = false
+		default:
+			if !dash && b.Len() > 0 {
+				b.WriteByte('-')
+				dash = true
+			}
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+var spdxPurposes = map[string]string{
+	"application":      "APPLICATION",
+	"framework":        "FRAMEWORK",
+	"library":          "LIBRARY",
+	"container":        "CONTAINER",
+	"operating-system": "OPERATING-SYSTEM",
+	"device":           "DEVICE",
+	"firmware":         "FIRMWARE",
+	"file":             "FILE",
+}
+
+func spdxPurpose(cdxType string) string {
+	if p, ok := spdxPurposes[cdxType]; ok {
+		return p
+	}
+	return "LIBRARY"
+}
+
+func cdxTypeFromSPDXPurpose(purpose string) string {
+	for cdx, p := range spdxPurposes {
+		if strings.EqualFold(p, purpose) {
+			return cdx
+		}
+	}
+	return "library"
+}
+
+// spdxLicenseValue maps SPDX's NOASSERTION/NONE placeholders to "unknown".
+func spdxLicenseValue(v string) string {
+	switch strings.TrimSpace(v) {
+	case "", spdxNoAssertion, "NONE":
+		return ""
+	}
+	return strings.TrimSpace(v)
+}
+
+// licenseRefs rewrites license expressions so every identifier is either on
+// the SPDX license list or a LicenseRef-, and records the LicenseRef-s it
+// introduced for the hasExtractedLicensingInfos section.
+type licenseRefs struct {
+	refs map[string]string // LicenseRef- id -> original text
+}
+
+func (l *licenseRefs) expression(expr string) string {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return spdxNoAssertion
+	}
+	node, err := spdx.Parse(expr)
+	if err != nil {
+		return l.ref(expr)
+	}
+	return l.rewrite(node)
+}
+
+func (l *licenseRefs) rewrite(n spdx.Node) string {
+	switch v := n.(type) {
+	case spdx.Term:
+		if strings.HasPrefix(v.ID, "LicenseRef-") || spdx.Known(v.ID) {
+			return v.String()
+		}
+		return l.ref(v.String())
+	case spdx.And:
+		return joinLicenseNodes(l, v.Operands, " AND ")
+	case spdx.Or:
+		return joinLicenseNodes(l, v.Operands, " OR ")
+	}
+	return l.ref(n.String())
+}
+
+func joinLicenseNodes(l *licenseRefs, nodes []spdx.Node, sep string) string {
+	parts := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		s := l.rewrite(n)
+		switch n.(type) {
+		case spdx.And, spdx.Or:
+			s = "(" + s + ")"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, sep)
+}
+
+func (l *licenseRefs) ref(text string) string {
+	id := "LicenseRef-" + nonEmptyString(spdxIDString(text), "unknown")
+	if l.refs == nil {
+		l.refs = map[string]string{}
+	}
+	l.refs[id] = text
+	return id
+}
+
+func (l *licenseRefs) extracted() []spdxExtractedLicense {
+	if len(l.refs) == 0 {
+		return nil
+	}
+	out := make([]spdxExtractedLicense, 0, len(l.refs))
+	for id, text := range l.refs {
+		out = append(out, spdxExtractedLicense{LicenseID: id, ExtractedText: text, Name: text})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].LicenseID < out[j].LicenseID })
+	return out
+}
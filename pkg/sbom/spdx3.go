@@ -0,0 +1,252 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	spdx3Context      = "https://spdx.org/rdf/3.0.1/spdx-context.jsonld"
+	spdx3SpecVersion  = "3.0.1"
+	spdx3CreationInfo = "_:creationinfo"
+)
+
+type spdx3Document struct {
+	Context string         `json:"@context"`
+	Graph   []spdx3Element `json:"@graph"`
+}
+
+// spdx3Element is the union of the SPDX 3.0 element shapes goneat reads and
+// writes; unused fields are omitted.
+type spdx3Element struct {
+	Type         string `json:"type"`
+	ID           string `json:"@id,omitempty"`
+	SPDXID       string `json:"spdxId,omitempty"`
+	CreationInfo any    `json:"creationInfo,omitempty"`
+	Name         string `json:"name,omitempty"`
+
+	// CreationInfo
+	SpecVersion  string   `json:"specVersion,omitempty"`
+	Created      string   `json:"created,omitempty"`
+	CreatedBy    []string `json:"createdBy,omitempty"`
+	CreatedUsing []string `json:"createdUsing,omitempty"`
+
+	// SpdxDocument
+	ProfileConformance []string `json:"profileConformance,omitempty"`
+	RootElement        []string `json:"rootElement,omitempty"`
+	Element            []string `json:"element,omitempty"`
+
+	// software_Package
+	PackageVersion     string                    `json:"software_packageVersion,omitempty"`
+	PackageURL         string                    `json:"software_packageUrl,omitempty"`
+	PrimaryPurpose     string                    `json:"software_primaryPurpose,omitempty"`
+	ExternalIdentifier []spdx3ExternalIdentifier `json:"externalIdentifier,omitempty"`
+
+	// simplelicensing_LicenseExpression
+	LicenseExpression string `json:"simplelicensing_licenseExpression,omitempty"`
+
+	// Relationship
+	From             string   `json:"from,omitempty"`
+	To               []string `json:"to,omitempty"`
+	RelationshipType string   `json:"relationshipType,omitempty"`
+}
+
+type spdx3ExternalIdentifier struct {
+	Type                   string `json:"type"`
+	ExternalIdentifierType string `json:"externalIdentifierType"`
+	Identifier             string `json:"identifier"`
+}
+
+func encodeSPDX3(d *Document) ([]byte, error) {
+	ns := d.namespace()
+	ids := newSPDXIDs(d)
+	elementID := func(local string) string { return ns + "#" + local }
+	agentID := elementID("Agent-goneat")
+	toolID := elementID("Tool-" + spdxIDString(d.toolString()))
+	docID := elementID(spdxDocumentID)
+
+	graph := []spdx3Element{
+		{
+			Type:         "CreationInfo",
+			ID:           spdx3CreationInfo,
+			SpecVersion:  spdx3SpecVersion,
+			Created:      d.created().Format(time.RFC3339),
+			CreatedBy:    []string{agentID},
+			CreatedUsing: []string{toolID},
+		},
+		{Type: "Agent", SPDXID: agentID, CreationInfo: spdx3CreationInfo, Name: nonEmptyString(d.ToolName, "goneat")},
+		{Type: "Tool", SPDXID: toolID, CreationInfo: spdx3CreationInfo, Name: d.toolString()},
+	}
+
+	var elements []string
+	var body []spdx3Element
+	relationship := func(from, kind string, to ...string) {
+		id := elementID(fmt.Sprintf("Relationship-%d", len(body)+1))
+		body = append(body, spdx3Element{Type: "Relationship", SPDXID: id, CreationInfo: spdx3CreationInfo, From: from, To: to, RelationshipType: kind})
+		elements = append(elements, id)
+	}
+
+	refs := &licenseRefs{}
+	licenseIDs := map[string]string{}
+	license := func(expr string) string {
+		expr = refs.expression(expr)
+		if expr == spdxNoAssertion {
+			return ""
+		}
+		if id, ok := licenseIDs[expr]; ok {
+			return id
+		}
+		id := elementID(fmt.Sprintf("LicenseExpression-%d", len(licenseIDs)+1))
+		licenseIDs[expr] = id
+		body = append(body, spdx3Element{Type: "simplelicensing_LicenseExpression", SPDXID: id, CreationInfo: spdx3CreationInfo, LicenseExpression: expr})
+		elements = append(elements, id)
+		return id
+	}
+
+	for _, p := range d.Packages {
+		id := elementID(ids[p.Ref])
+		pkg := spdx3Element{
+			Type:           "software_Package",
+			SPDXID:         id,
+			CreationInfo:   spdx3CreationInfo,
+			Name:           p.Name,
+			PackageVersion: p.Version,
+			PackageURL:     p.PURL,
+			PrimaryPurpose: spdx3Purpose(p.Type),
+		}
+		if p.PURL != "" {
+			pkg.ExternalIdentifier = []spdx3ExternalIdentifier{{Type: "ExternalIdentifier", ExternalIdentifierType: "packageUrl", Identifier: p.PURL}}
+		}
+		body = append(body, pkg)
+		elements = append(elements, id)
+		if lic := license(p.LicenseDeclared); lic != "" {
+			relationship(id, "hasDeclaredLicense", lic)
+		}
+		if lic := license(p.LicenseConcluded); lic != "" {
+			relationship(id, "hasConcludedLicense", lic)
+		}
+	}
+
+	var roots []string
+	for _, ref := range d.described() {
+		roots = append(roots, elementID(ids[ref]))
+	}
+	if len(roots) > 0 {
+		relationship(docID, "describes", roots...)
+	}
+	var order []string
+	deps := map[string][]string{}
+	for _, r := range d.sortedRelationships() {
+		from, okFrom := ids[r.From]
+		to, okTo := ids[r.To]
+		if !okFrom || !okTo {
+			continue
+		}
+		if _, seen := deps[from]; !seen {
+			order = append(order, from)
+		}
+		deps[from] = append(deps[from], elementID(to))
+	}
+	for _, from := range order {
+		relationship(elementID(from), "dependsOn", deps[from]...)
+	}
+
+	graph = append(graph, spdx3Element{
+		Type:               "SpdxDocument",
+		SPDXID:             docID,
+		CreationInfo:       spdx3CreationInfo,
+		Name:               nonEmptyString(d.Name, "goneat-sbom"),
+		ProfileConformance: []string{"core", "software", "simpleLicensing"},
+		RootElement:        roots,
+		Element:            elements,
+	})
+	graph = append(graph, body...)
+
+	data, err := json.MarshalIndent(spdx3Document{Context: spdx3Context, Graph: graph}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal SPDX 3.0 sbom: %w", err)
+	}
+	return data, nil
+}
+
+func decodeSPDX3(data []byte) (*Document, error) {
+	var doc spdx3Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse SPDX 3.0 SBOM: %w", err)
+	}
+	out := &Document{}
+	licenses := map[string]string{}
+	for _, el := range doc.Graph {
+		if el.Type == "simplelicensing_LicenseExpression" {
+			licenses[el.SPDXID] = el.LicenseExpression
+		}
+	}
+	index := map[string]int{}
+	for _, el := range doc.Graph {
+		switch el.Type {
+		case "CreationInfo":
+			if t, err := time.Parse(time.RFC3339, el.Created); err == nil {
+				out.Created = t
+			}
+		case "Tool":
+			out.ToolName = el.Name
+		case "SpdxDocument":
+			out.Name = el.Name
+			out.Namespace, _, _ = strings.Cut(el.SPDXID, "#")
+			if len(el.RootElement) > 0 && out.Root == "" {
+				out.Root = el.RootElement[0]
+			}
+		case "software_Package":
+			p := Package{Ref: el.SPDXID, Name: el.Name, Version: el.PackageVersion, Type: cdxTypeFromSPDX3Purpose(el.PrimaryPurpose), PURL: el.PackageURL}
+			for _, ext := range el.ExternalIdentifier {
+				if p.PURL == "" && ext.ExternalIdentifierType == "packageUrl" {
+					p.PURL = ext.Identifier
+				}
+			}
+			index[p.Ref] = len(out.Packages)
+			out.Packages = append(out.Packages, p)
+		}
+	}
+	for _, el := range doc.Graph {
+		if el.Type != "Relationship" {
+			continue
+		}
+		switch el.RelationshipType {
+		case "dependsOn":
+			for _, to := range el.To {
+				out.Relationships = append(out.Relationships, Relationship{From: el.From, To: to})
+			}
+		case "describes":
+			if out.Root == "" && len(el.To) > 0 {
+				out.Root = el.To[0]
+			}
+		case "hasDeclaredLicense", "hasConcludedLicense":
+			i, ok := index[el.From]
+			if !ok || len(el.To) == 0 {
+				continue
+			}
+			if el.RelationshipType == "hasDeclaredLicense" {
+				out.Packages[i].LicenseDeclared = spdxLicenseValue(licenses[el.To[0]])
+			} else {
+				out.Packages[i].LicenseConcluded = spdxLicenseValue(licenses[el.To[0]])
+			}
+		}
+	}
+	return out, nil
+}
+
+func spdx3Purpose(cdxType string) string {
+	if cdxType == "operating-system" {
+		return "operatingSystem"
+	}
+	return strings.ToLower(spdxPurpose(cdxType))
+}
+
+func cdxTypeFromSPDX3Purpose(purpose string) string {
+	if purpose == "operatingSystem" {
+		return "operating-system"
+	}
+	return cdxTypeFromSPDXPurpose(purpose)
+}
@@ -0,0 +1,311 @@
+package apispec
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const fixturesDir = "../../../tests/fixtures/schemas"
+
+func fixture(parts ...string) string {
+	return filepath.Join(append([]string{fixturesDir}, parts...)...)
+}
+
+// ruleLines collects "rule:line" for every issue
+func ruleLines(issues []Issue) map[string]bool {
+	out := make(map[string]bool, len(issues))
+	for _, i := range issues {
+		out[fmt.Sprintf("%s:%d", i.Rule, i.Line)] = true
+	}
+	return out
+}
+
+func expectIssues(t *testing.T, result *Result, want ...string) {
+	t.Helper()
+	got := ruleLines(result.Issues)
+	for _, w := range want {
+		if !got[w] {
+			t.Errorf("missing issue %s", w)
+		}
+	}
+	if len(result.Issues) != len(want) {
+		for _, i := range result.Issues {
+			t.Log(i.String())
+		}
+		t.Fatalf("expected %d issues, got %d", len(want), len(result.Issues))
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		doc  string
+		spec Spec
+		ok   bool
+	}{
+		{"openapi: 3.0.3\n", SpecOpenAPI30, true},
+		{`{"openapi": "3.1.0"}`, SpecOpenAPI31, true},
+		{"asyncapi: '2.6.0'\n", SpecAsyncAPI2, true},
+		{"asyncapi: 3.0.0\n", SpecAsyncAPI3, true},
+		{"swagger: '2.0'\n", "", false},
+		{"openapi: 4.0.0\n", "", true},
+	}
+	for _, tt := range tests {
+		spec, _, ok := Detect([]byte(tt.doc))
+		if spec != tt.spec || ok != tt.ok {
+			t.Errorf("Detect(%q) = %q, %v; want %q, %v", tt.doc, spec, ok, tt.spec, tt.ok)
+		}
+	}
+}
+
+func TestValidate_GoodFixtures(t *testing.T) {
+	t.Parallel()
+
+	for _, path := range []string{
+		fixture("openapi-3", "good", "ping.yaml"),
+		fixture("openapi-3", "good", "split", "api.yaml"),
+		fixture("asyncapi-2", "good", "user-events.yaml"),
+		fixture("asyncapi-3", "good", "user-events.yaml"),
+	} {
+		result, err := ValidateFile(path, Options{})
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if !result.Valid() {
+			for _, i := range result.Issues {
+				t.Log(i.String())
+			}
+			t.Fatalf("%s: expected no issues", path)
+		}
+	}
+}
+
+func TestValidate_OpenAPIIssues(t *testing.T) {
+	t.Parallel()
+
+	result, err := ValidateFile(fixture("openapi-3", "bad", "petstore-issues.yaml"), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Spec != SpecOpenAPI30 || result.Version != "3.0.3" {
+		t.Fatalf("unexpected spec %s %s", result.Spec, result.Version)
+	}
+	expectIssues(t, result,
+		"example:21",        // items[1].id is a string
+		"path-parameter:24", // get /pets/{petId} does not declare petId
+		"operation-id:25",   // listPets repeated
+		"ref:28",            // missing component response
+		"path-parameter:31", // ownerId is not required
+		"structure:38",      // response without description
+		"example:52",        // nullable string example is an integer
+	)
+}
+
+func TestValidate_AsyncAPIIssues(t *testing.T) {
+	t.Parallel()
+
+	v2, err := ValidateFile(fixture("asyncapi-2", "bad", "channel-issues.yaml"), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectIssues(t, v2,
+		"channel-parameter:6", // orderId not declared
+		"channel-parameter:8", // customerId not in channel name
+		"operation-id:17",     // onOrder repeated
+		"example:35",          // total is a string
+	)
+
+	v3, err := ValidateFile(fixture("asyncapi-3", "bad", "missing-action.yaml"), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectIssues(t, v3,
+		"channel-parameter:7", // userId not declared
+		"example:18",          // email format
+		"structure:21",        // operation without action
+	)
+}
+
+func TestValidate_SplitFileRefs(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"/api/openapi.yaml": `openapi: 3.0.3
+info: {title: Split, version: "1"}
+paths:
+  /items:
+    get:
+      responses:
+        '200':
+          $ref: 'responses.yaml#/Items'
+        '404':
+          $ref: 'missing.yaml#/NotFound'
+`,
+		"/api/responses.yaml": `Items:
+  description: Items
+  content:
+    application/json:
+      schema:
+        $ref: 'schemas.yaml#/Item'
+      example:
+        name: 3
+Other:
+  $ref: '#/Nope'
+`,
+		"/api/schemas.yaml": `Item:
+  type: object
+  properties:
+    name: {type: string}
+`,
+	}
+	opts := Options{ReadFile: func(path string) ([]byte, error) {
+		if data, ok := files[filepath.ToSlash(path)]; ok {
+			return []byte(data), nil
+		}
+		return nil, errors.New("not found")
+	}}
+
+	result, err := ValidateFile("/api/openapi.yaml", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byFile := make(map[string][]string)
+	for _, i := range result.Issues {
+		byFile[filepath.ToSlash(i.File)] = append(byFile[filepath.ToSlash(i.File)], fmt.Sprintf("%s:%d", i.Rule, i.Line))
+	}
+	if got := strings.Join(byFile["/api/openapi.yaml"], ","); got != "ref:10" {
+		t.Errorf("openapi.yaml issues = %s, want ref:10", got)
+	}
+	if got := strings.Join(byFile["/api/responses.yaml"], ","); got != "example:8,ref:10" {
+		t.Errorf("responses.yaml issues = %s, want example:8,ref:10", got)
+	}
+}
+
+// TestValidate_ResponseKeys checks the curated schemas against the
+// Responses Object key rules of the official OpenAPI 3.0 and 3.1 schemas
+func TestValidate_ResponseKeys(t *testing.T) {
+	t.Parallel()
+
+	official := regexp.MustCompile(`^[1-5](?:\d{2}|XX)$`)
+	keys := []string{"200", "2XX", "404", "599", "default", "x-note", "abc", "600", "1xx", "20", "2000", "Default"}
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		for _, key := range keys {
+			valid := key == "default" || strings.HasPrefix(key, "x-") || official.MatchString(key)
+			doc := fmt.Sprintf("openapi: %s\ninfo: {title: t, version: \"1\"}\npaths:\n  /a:\n    get:\n      responses:\n        '%s': {description: d}\n", version, key)
+			result, err := Validate("api.yaml", []byte(doc), Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if valid && len(result.Issues) != 0 {
+				t.Errorf("%s: key %q rejected: %v", version, key, result.Issues)
+			}
+			if !valid && (len(result.Issues) != 1 || result.Issues[0].Rule != RuleStructure || result.Issues[0].Line != 7) {
+				t.Errorf("%s: key %q: got %v, want one structure issue", version, key, result.Issues)
+			}
+		}
+	}
+
+	// Path items in other files are outside the structural schema
+	files := map[string]string{
+		"/api/openapi.yaml": "openapi: 3.1.0\ninfo: {title: t, version: \"1\"}\npaths:\n  /a:\n    $ref: 'paths.yaml#/a'\n",
+		"/api/paths.yaml":   "a:\n  get:\n    responses:\n      '200': {description: ok}\n      abc: {description: bad}\n      x-note: {}\n",
+	}
+	opts := Options{ReadFile: func(path string) ([]byte, error) {
+		if data, ok := files[filepath.ToSlash(path)]; ok {
+			return []byte(data), nil
+		}
+		return nil, errors.New("not found")
+	}}
+	result, err := ValidateFile("/api/openapi.yaml", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Issues) != 1 || filepath.ToSlash(result.Issues[0].File) != "/api/paths.yaml" || result.Issues[0].Line != 5 ||
+		result.Issues[0].Pointer != "/a/get/responses/abc" {
+		t.Errorf("referenced path item issues = %v", result.Issues)
+	}
+}
+
+// TestCompileSpecSchema_Draft2020 covers the keywords the official OpenAPI
+// 3.1 schema relies on, which gojsonschema ignores
+func TestCompileSpecSchema_Draft2020(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://example.com/oas/3.1/schema",
+  "type": "object",
+  "required": ["info"],
+  "properties": {
+    "info": {"$ref": "#/$defs/info"},
+    "components": {"type": "object", "additionalProperties": {"$dynamicRef": "#meta"}}
+  },
+  "unevaluatedProperties": false,
+  "$defs": {
+    "info": {"type": "object", "required": ["title"], "properties": {"title": {"type": "string"}}},
+    "schema": {"$dynamicAnchor": "meta", "type": ["object", "boolean"]}
+  }
+}`)
+	sch, err := compileSpecSchema("test/schema.json", data)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	if _, ok := sch.(draft2020Schema); !ok {
+		t.Fatalf("expected the 2020-12 compiler, got %T", sch)
+	}
+	errs, err := sch.validate(map[string]interface{}{
+		"info":       map[string]interface{}{"title": 1},
+		"components": map[string]interface{}{"pet": "not a schema"},
+		"bogus":      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]bool)
+	for _, se := range errs {
+		got[fmt.Sprintf("%s:%t", se.pointer, se.isKey)] = true
+	}
+	for _, want := range []string{"/info/title:false", "/components/pet:false", "/bogus:true"} {
+		if !got[want] {
+			t.Errorf("missing error %s in %+v", want, errs)
+		}
+	}
+	if len(errs) != 3 {
+		t.Errorf("errors = %+v, want 3", errs)
+	}
+}
+
+func TestValidate_JSONDocument(t *testing.T) {
+	t.Parallel()
+
+	doc := "{\n\t\"openapi\": \"3.1.0\",\n\t\"info\": {\"title\": \"JSON\"},\n\t\"paths\": {\n\t\t\"/a\": {\"get\": {\"operationId\": \"a\", \"bogus\": true}}\n\t}\n}\n"
+	result, err := Validate("api.json", []byte(doc), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectIssues(t, result, "structure:3", "structure:5")
+	for _, i := range result.Issues {
+		if i.Line == 5 && i.Pointer != "/paths/~1a/get/bogus" {
+			t.Errorf("unexpected pointer %s", i.Pointer)
+		}
+	}
+}
+
+func TestValidate_UnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	result, err := Validate("api.yaml", []byte("info: {}\nopenapi: 4.0.0\n"), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectIssues(t, result, "version:2")
+
+	if _, err := Validate("plain.yaml", []byte("name: x\n"), Options{}); err == nil {
+		t.Fatal("expected an error for a non-API document")
+	}
+}
@@ -0,0 +1,368 @@
+package apispec
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// maxRefHops bounds $ref chains followed by deref
+const maxRefHops = 32
+
+// errRemoteRef marks references that cannot be resolved offline (URLs, anchors)
+var errRemoteRef = errors.New("remote reference")
+
+// document is a parsed YAML or JSON file that keeps node positions so issues
+// can point at source lines
+type document struct {
+	path string
+	root *yaml.Node
+}
+
+func parseDocument(path string, data []byte) (*document, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	root := &node
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return nil, fmt.Errorf("parse %s: empty document", path)
+		}
+		root = root.Content[0]
+	}
+	return &document{path: filepath.Clean(path), root: root}, nil
+}
+
+// issue builds an Issue positioned at node, or at the nearest existing node
+// along pointer when node is nil
+func (d *document) issue(node *yaml.Node, pointer string, rule Rule, message string) Issue {
+	if node == nil {
+		node = d.locate(pointer)
+	}
+	if pointer == "" {
+		pointer = "/"
+	}
+	return Issue{
+		File:    d.path,
+		Line:    node.Line,
+		Column:  node.Column,
+		Pointer: pointer,
+		Rule:    rule,
+		Message: message,
+	}
+}
+
+// lookup returns the node addressed by a JSON pointer
+func (d *document) lookup(pointer string) (*yaml.Node, bool) {
+	node := d.root
+	for _, token := range schema.SplitPointer(pointer) {
+		next := childAt(node, token)
+		if next == nil {
+			return nil, false
+		}
+		node = next
+	}
+	return node, true
+}
+
+// locate returns the deepest node that exists along pointer
+func (d *document) locate(pointer string) *yaml.Node {
+	node := d.root
+	for _, token := range schema.SplitPointer(pointer) {
+		next := childAt(node, token)
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return node
+}
+
+// keyAt returns the mapping key node for the last token of pointer, falling
+// back to the value; keys give the line a reader expects for a named entry
+func (d *document) keyAt(pointer string) *yaml.Node {
+	tokens := schema.SplitPointer(pointer)
+	if len(tokens) == 0 {
+		return d.root
+	}
+	parent, ok := d.lookup(schema.JoinPointer("", tokens[:len(tokens)-1]...))
+	if ok {
+		if key := mapKey(parent, tokens[len(tokens)-1]); key != nil {
+			return key
+		}
+	}
+	return d.locate(pointer)
+}
+
+func childAt(node *yaml.Node, token string) *yaml.Node {
+	node = resolveAlias(node)
+	switch node.Kind {
+	case yaml.MappingNode:
+		return mapValue(node, token)
+	case yaml.SequenceNode:
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 || i >= len(node.Content) {
+			return nil
+		}
+		return resolveAlias(node.Content[i])
+	}
+	return nil
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+// mapValue returns the value for key in a mapping node
+func mapValue(node *yaml.Node, key string) *yaml.Node {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return resolveAlias(node.Content[i+1])
+		}
+	}
+	return nil
+}
+
+// mapKey returns the key node for key in a mapping node
+func mapKey(node *yaml.Node, key string) *yaml.Node {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+type pair struct {
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// pairs lists the entries of a mapping node in document order
+func pairs(node *yaml.Node) []pair {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	out := make([]pair, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		out = append(out, pair{key: node.Content[i], value: resolveAlias(node.Content[i+1])})
+	}
+	return out
+}
+
+// items lists the elements of a sequence node
+func items(node *yaml.Node) []*yaml.Node {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	out := make([]*yaml.Node, 0, len(node.Content))
+	for _, item := range node.Content {
+		out = append(out, resolveAlias(item))
+	}
+	return out
+}
+
+// scalarString returns the text of a non-null scalar node
+func scalarString(node *yaml.Node) (string, bool) {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.ScalarNode || node.Tag == "!!null" {
+		return "", false
+	}
+	return node.Value, true
+}
+
+// refOf returns the $ref string of a Reference Object
+func refOf(node *yaml.Node) (string, bool) {
+	return scalarString(mapValue(node, "$ref"))
+}
+
+// nodeValue converts a node to plain Go values with string map keys, as
+// produced by encoding/json. Timestamps stay strings so that JSON Schema
+// formats apply to them.
+func nodeValue(node *yaml.Node) interface{} {
+	node = resolveAlias(node)
+	if node == nil {
+		return nil
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) > 0 {
+			return nodeValue(node.Content[0])
+		}
+		return nil
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(node.Content)/2)
+		for _, p := range pairs(node) {
+			if p.key.Value == "<<" && p.key.Tag == "!!merge" {
+				if merged, ok := nodeValue(p.value).(map[string]interface{}); ok {
+					for k, v := range merged {
+						if _, exists := m[k]; !exists {
+							m[k] = v
+						}
+					}
+				}
+				continue
+			}
+			m[p.key.Value] = nodeValue(p.value)
+		}
+		return m
+	case yaml.SequenceNode:
+		out := make([]interface{}, 0, len(node.Content))
+		for _, item := range node.Content {
+			out = append(out, nodeValue(item))
+		}
+		return out
+	case yaml.ScalarNode:
+		switch node.Tag {
+		case "!!null":
+			return nil
+		case "!!bool", "!!int", "!!float":
+			var v interface{}
+			if err := node.Decode(&v); err == nil {
+				return v
+			}
+		}
+		return node.Value
+	}
+	return nil
+}
+
+// load returns the parsed document at path, reading it on first use
+func (v *validator) load(path string) (*document, error) {
+	path = filepath.Clean(path)
+	if doc, ok := v.docs[path]; ok {
+		return doc, nil
+	}
+	data, err := readFile(v.opts, path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseDocument(path, data)
+	if err != nil {
+		return nil, err
+	}
+	v.docs[path] = doc
+	return doc, nil
+}
+
+// resolve follows one $ref from doc. Relative file references are resolved
+// against the directory of doc; URLs and plain-name fragments return
+// errRemoteRef.
+func (v *validator) resolve(doc *document, ref string) (*document, *yaml.Node, string, error) {
+	file, fragment, _ := strings.Cut(ref, "#")
+	if strings.Contains(file, "://") || strings.HasPrefix(file, "urn:") {
+		return nil, nil, "", errRemoteRef
+	}
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		return nil, nil, "", errRemoteRef
+	}
+	target := doc
+	if file != "" {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(doc.path), filepath.FromSlash(file))
+		}
+		loaded, err := v.load(file)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("cannot load %s: %v", file, err)
+		}
+		target = loaded
+	}
+	node, ok := target.lookup(fragment)
+	if !ok {
+		return nil, nil, "", fmt.Errorf("%s does not exist in %s", fragment, target.path)
+	}
+	return target, node, fragment, nil
+}
+
+// deref follows Reference Objects until it reaches a non-reference node.
+// ok is false when a reference cannot be resolved; checkRefs reports those.
+func (v *validator) deref(doc *document, node *yaml.Node, pointer string) (*document, *yaml.Node, string, bool) {
+	for hops := 0; hops < maxRefHops; hops++ {
+		ref, isRef := refOf(node)
+		if !isRef {
+			return doc, node, pointer, true
+		}
+		nextDoc, nextNode, nextPointer, err := v.resolve(doc, ref)
+		if err != nil {
+			return doc, node, pointer, false
+		}
+		doc, node, pointer = nextDoc, nextNode, nextPointer
+	}
+	return doc, node, pointer, false
+}
+
+// once reports whether the node at doc/pointer is seen for the first time
+// by the named pass
+func (v *validator) once(pass string, doc *document, pointer string) bool {
+	key := pass + "\x00" + doc.path + "#" + pointer
+	if v.visited[key] {
+		return false
+	}
+	v.visited[key] = true
+	return true
+}
+
+// checkRefs reports every $ref in the root document, and in the documents
+// it pulls in, that does not resolve
+func (v *validator) checkRefs() {
+	queue := []*document{v.root}
+	seen := map[string]bool{v.root.path: true}
+	for len(queue) > 0 {
+		doc := queue[0]
+		queue = queue[1:]
+		v.walkRefs(doc, doc.root, "")
+		for path, loaded := range v.docs {
+			if !seen[path] {
+				seen[path] = true
+				queue = append(queue, loaded)
+			}
+		}
+	}
+}
+
+func (v *validator) walkRefs(doc *document, node *yaml.Node, pointer string) {
+	node = resolveAlias(node)
+	switch node.Kind {
+	case yaml.MappingNode:
+		if ref, ok := refOf(node); ok {
+			refPointer := schema.JoinPointer(pointer, "$ref")
+			if _, _, _, err := v.resolve(doc, ref); err != nil && !errors.Is(err, errRemoteRef) {
+				key := doc.path + "#" + refPointer
+				if !v.reported[key] {
+					v.reported[key] = true
+					v.add(doc, mapValue(node, "$ref"), refPointer, RuleRef, fmt.Sprintf("unresolved $ref %q: %v", ref, err))
+				}
+			}
+		}
+		for _, p := range pairs(node) {
+			// example values are data, not API description
+			if p.key.Value == "example" {
+				continue
+			}
+			v.walkRefs(doc, p.value, schema.JoinPointer(pointer, p.key.Value))
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			v.walkRefs(doc, item, schema.JoinPointer(pointer, strconv.Itoa(i)))
+		}
+	}
+}
@@ -0,0 +1,422 @@
+package apispec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/schema"
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// maxSchemaDepth bounds schema conversion; deeper or recursive references
+// convert to the empty (accept-all) schema
+const maxSchemaDepth = 64
+
+var (
+	// subschemaKeys hold a single schema
+	subschemaKeys = map[string]bool{
+		"not": true, "items": true, "additionalProperties": true, "additionalItems": true,
+		"contains": true, "propertyNames": true, "if": true, "then": true, "else": true,
+	}
+	// schemaListKeys hold an array of schemas
+	schemaListKeys = map[string]bool{"allOf": true, "anyOf": true, "oneOf": true, "prefixItems": true}
+	// schemaMapKeys hold a map of schemas
+	schemaMapKeys = map[string]bool{"properties": true, "patternProperties": true, "dependentSchemas": true}
+	// droppedSchemaKeys have no meaning once references are inlined, or are
+	// not JSON Schema keywords
+	droppedSchemaKeys = map[string]bool{
+		"$schema": true, "$id": true, "$anchor": true, "$dynamicAnchor": true, "$defs": true, "definitions": true,
+		"discriminator": true, "xml": true, "externalDocs": true, "example": true,
+	}
+	httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+)
+
+// checkExamples validates example values against the schemas they illustrate
+func (v *validator) checkExamples() {
+	root := v.root
+	if v.spec.Family() == "asyncapi" {
+		v.checkAsyncAPIExamples()
+		return
+	}
+
+	for _, section := range []string{"schemas", "parameters", "headers", "requestBodies", "responses"} {
+		base := schema.JoinPointer("/components", section)
+		for _, p := range pairs(mapValue(mapValue(root.root, "components"), section)) {
+			pointer := schema.JoinPointer(base, p.key.Value)
+			switch section {
+			case "schemas":
+				v.walkSchemaExamples(root, p.value, pointer)
+			case "parameters", "headers":
+				v.checkParameterExamples(root, p.value, pointer)
+			case "requestBodies":
+				v.checkContentExamples(root, p.value, pointer)
+			case "responses":
+				v.checkResponseExamples(root, p.value, pointer)
+			}
+		}
+	}
+
+	for _, section := range []string{"paths", "webhooks"} {
+		for _, p := range pairs(mapValue(root.root, section)) {
+			v.checkPathItemExamples(root, p.value, schema.JoinPointer("/"+section, p.key.Value))
+		}
+	}
+}
+
+func (v *validator) checkPathItemExamples(doc *document, node *yaml.Node, pointer string) {
+	doc, node, pointer, ok := v.deref(doc, node, pointer)
+	if !ok || !v.once("examples", doc, pointer) {
+		return
+	}
+	for i, param := range items(mapValue(node, "parameters")) {
+		v.checkParameterExamples(doc, param, schema.JoinPointer(pointer, "parameters", strconv.Itoa(i)))
+	}
+	for _, method := range httpMethods {
+		op := mapValue(node, method)
+		if op == nil {
+			continue
+		}
+		opPointer := schema.JoinPointer(pointer, method)
+		for i, param := range items(mapValue(op, "parameters")) {
+			v.checkParameterExamples(doc, param, schema.JoinPointer(opPointer, "parameters", strconv.Itoa(i)))
+		}
+		if body := mapValue(op, "requestBody"); body != nil {
+			v.checkContentExamples(doc, body, schema.JoinPointer(opPointer, "requestBody"))
+		}
+		for _, p := range pairs(mapValue(op, "responses")) {
+			v.checkResponseExamples(doc, p.value, schema.JoinPointer(opPointer, "responses", p.key.Value))
+		}
+	}
+}
+
+func (v *validator) checkResponseExamples(doc *document, node *yaml.Node, pointer string) {
+	doc, node, pointer, ok := v.deref(doc, node, pointer)
+	if !ok || !v.once("examples", doc, pointer) {
+		return
+	}
+	for _, p := range pairs(mapValue(node, "headers")) {
+		v.checkParameterExamples(doc, p.value, schema.JoinPointer(pointer, "headers", p.key.Value))
+	}
+	v.checkMediaTypes(doc, mapValue(node, "content"), schema.JoinPointer(pointer, "content"))
+}
+
+// checkContentExamples handles Request Body Objects
+func (v *validator) checkContentExamples(doc *document, node *yaml.Node, pointer string) {
+	doc, node, pointer, ok := v.deref(doc, node, pointer)
+	if !ok || !v.once("examples", doc, pointer) {
+		return
+	}
+	v.checkMediaTypes(doc, mapValue(node, "content"), schema.JoinPointer(pointer, "content"))
+}
+
+// checkParameterExamples handles Parameter and Header Objects, which carry
+// either a schema or a single-entry content map
+func (v *validator) checkParameterExamples(doc *document, node *yaml.Node, pointer string) {
+	doc, node, pointer, ok := v.deref(doc, node, pointer)
+	if !ok || !v.once("examples", doc, pointer) {
+		return
+	}
+	v.checkExampleFields(doc, node, pointer)
+	v.checkMediaTypes(doc, mapValue(node, "content"), schema.JoinPointer(pointer, "content"))
+}
+
+func (v *validator) checkMediaTypes(doc *document, content *yaml.Node, pointer string) {
+	for _, p := range pairs(content) {
+		v.checkExampleFields(doc, p.value, schema.JoinPointer(pointer, p.key.Value))
+	}
+}
+
+// checkExampleFields validates the example and examples fields of a
+// Parameter, Header or Media Type Object against its schema
+func (v *validator) checkExampleFields(doc *document, node *yaml.Node, pointer string) {
+	schemaNode := mapValue(node, "schema")
+	if schemaNode == nil {
+		return
+	}
+	schemaPointer := schema.JoinPointer(pointer, "schema")
+	if _, isRef := refOf(schemaNode); !isRef {
+		v.walkSchemaExamples(doc, schemaNode, schemaPointer)
+	}
+	if example := mapValue(node, "example"); example != nil {
+		v.validateExample(doc, schemaNode, schemaPointer, doc, example, schema.JoinPointer(pointer, "example"))
+	}
+	for _, p := range pairs(mapValue(node, "examples")) {
+		exDoc, exNode, exPointer, ok := v.deref(doc, p.value, schema.JoinPointer(pointer, "examples", p.key.Value))
+		if !ok {
+			continue
+		}
+		if value := mapValue(exNode, "value"); value != nil {
+			v.validateExample(doc, schemaNode, schemaPointer, exDoc, value, schema.JoinPointer(exPointer, "value"))
+		}
+	}
+}
+
+// walkSchemaExamples validates example values declared inside a schema,
+// descending into inline subschemas but not across $ref
+func (v *validator) walkSchemaExamples(doc *document, node *yaml.Node, pointer string) {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	if example := mapValue(node, "example"); example != nil {
+		v.validateExample(doc, node, pointer, doc, example, schema.JoinPointer(pointer, "example"))
+	}
+	if v.spec != SpecOpenAPI30 {
+		for i, example := range items(mapValue(node, "examples")) {
+			v.validateExample(doc, node, pointer, doc, example, schema.JoinPointer(pointer, "examples", strconv.Itoa(i)))
+		}
+	}
+	for _, p := range pairs(node) {
+		key := p.key.Value
+		switch {
+		case subschemaKeys[key]:
+			if _, isRef := refOf(p.value); !isRef {
+				v.walkSchemaExamples(doc, p.value, schema.JoinPointer(pointer, key))
+			}
+		case schemaListKeys[key]:
+			for i, item := range items(p.value) {
+				if _, isRef := refOf(item); !isRef {
+					v.walkSchemaExamples(doc, item, schema.JoinPointer(pointer, key, strconv.Itoa(i)))
+				}
+			}
+		case schemaMapKeys[key]:
+			for _, sub := range pairs(p.value) {
+				if _, isRef := refOf(sub.value); !isRef {
+					v.walkSchemaExamples(doc, sub.value, schema.JoinPointer(pointer, key, sub.key.Value))
+				}
+			}
+		}
+	}
+}
+
+// validateExample validates the example at exDoc/exPointer against the
+// schema node at schemaDoc/schemaPointer
+func (v *validator) validateExample(schemaDoc *document, schemaNode *yaml.Node, schemaPointer string, exDoc *document, example *yaml.Node, exPointer string) {
+	converted := v.convertSchema(schemaDoc, schemaNode, make(map[string]bool), 0)
+	sch, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(converted))
+	if err != nil {
+		key := "schema\x00" + schemaDoc.path + "#" + schemaPointer
+		if !v.reported[key] {
+			v.reported[key] = true
+			v.add(schemaDoc, nil, schemaPointer, RuleExample, fmt.Sprintf("schema cannot be used to check examples: %v", err))
+		}
+		return
+	}
+	result, err := sch.Validate(gojsonschema.NewGoLoader(nodeValue(example)))
+	if err != nil {
+		v.add(exDoc, example, exPointer, RuleExample, fmt.Sprintf("example cannot be validated: %v", err))
+		return
+	}
+	for _, se := range schemaErrors(result) {
+		pointer := schema.JoinPointer(exPointer, schema.SplitPointer(se.pointer)...)
+		v.add(exDoc, exDoc.locate(pointer), pointer, RuleExample,
+			fmt.Sprintf("example does not match schema %s: %s", schemaPointer, se.message))
+	}
+}
+
+// convertSchema turns an OpenAPI or AsyncAPI Schema Object into a JSON
+// Schema gojsonschema can compile: references are inlined (recursion is cut
+// to an accept-all schema), OpenAPI 3.0 nullable and boolean exclusive
+// bounds become their JSON Schema equivalents, and 2020-12 prefixItems
+// becomes draft-07 tuple items.
+func (v *validator) convertSchema(doc *document, node *yaml.Node, stack map[string]bool, depth int) interface{} {
+	node = resolveAlias(node)
+	if node == nil {
+		return map[string]interface{}{}
+	}
+	if node.Kind != yaml.MappingNode {
+		return nodeValue(node)
+	}
+	if depth > maxSchemaDepth {
+		return map[string]interface{}{}
+	}
+
+	var resolved interface{}
+	if ref, isRef := refOf(node); isRef {
+		targetDoc, target, targetPointer, err := v.resolve(doc, ref)
+		key := ""
+		if err == nil {
+			key = targetDoc.path + "#" + targetPointer
+		}
+		if err != nil || stack[key] {
+			resolved = map[string]interface{}{}
+		} else {
+			stack[key] = true
+			resolved = v.convertSchema(targetDoc, target, stack, depth+1)
+			delete(stack, key)
+		}
+		// OpenAPI 3.0 ignores siblings of $ref
+		if v.spec == SpecOpenAPI30 || len(node.Content) == 2 {
+			return resolved
+		}
+	}
+
+	out := make(map[string]interface{}, len(node.Content)/2)
+	for _, p := range pairs(node) {
+		key := p.key.Value
+		switch {
+		case key == "$ref" || droppedSchemaKeys[key] || strings.HasPrefix(key, "x-"):
+		case subschemaKeys[key]:
+			out[key] = v.convertSchema(doc, p.value, stack, depth+1)
+		case schemaListKeys[key]:
+			list := make([]interface{}, 0, len(p.value.Content))
+			for _, item := range items(p.value) {
+				list = append(list, v.convertSchema(doc, item, stack, depth+1))
+			}
+			out[key] = list
+		case schemaMapKeys[key]:
+			m := make(map[string]interface{})
+			for _, sub := range pairs(p.value) {
+				m[sub.key.Value] = v.convertSchema(doc, sub.value, stack, depth+1)
+			}
+			out[key] = m
+		default:
+			out[key] = nodeValue(p.value)
+		}
+	}
+
+	if v.spec == SpecOpenAPI30 {
+		convertOpenAPI30Schema(out)
+	}
+	if prefix, ok := out["prefixItems"]; ok {
+		if rest, hasItems := out["items"]; hasItems {
+			out["additionalItems"] = rest
+		}
+		out["items"] = prefix
+		delete(out, "prefixItems")
+	}
+	if resolved != nil {
+		allOf, _ := out["allOf"].([]interface{})
+		out["allOf"] = append(allOf, resolved)
+	}
+	return out
+}
+
+// convertOpenAPI30Schema rewrites OpenAPI 3.0 keywords in place
+func convertOpenAPI30Schema(s map[string]interface{}) {
+	if nullable, _ := s["nullable"].(bool); nullable {
+		if t, ok := s["type"].(string); ok {
+			s["type"] = []interface{}{t, "null"}
+		}
+		if enum, ok := s["enum"].([]interface{}); ok {
+			s["enum"] = append(enum, nil)
+		}
+	}
+	delete(s, "nullable")
+	for _, bound := range [][2]string{{"exclusiveMinimum", "minimum"}, {"exclusiveMaximum", "maximum"}} {
+		exclusive, ok := s[bound[0]].(bool)
+		if !ok {
+			continue
+		}
+		delete(s, bound[0])
+		if limit, hasLimit := s[bound[1]]; exclusive && hasLimit {
+			s[bound[0]] = limit
+			delete(s, bound[1])
+		}
+	}
+}
+
+// checkAsyncAPIExamples validates message examples against message payload
+// and headers schemas, and examples declared in component schemas
+func (v *validator) checkAsyncAPIExamples() {
+	root := v.root
+	components := mapValue(root.root, "components")
+	for _, p := range pairs(mapValue(components, "schemas")) {
+		v.walkSchemaExamples(root, p.value, schema.JoinPointer("/components/schemas", p.key.Value))
+	}
+	for _, p := range pairs(mapValue(components, "messages")) {
+		v.checkMessageExamples(root, p.value, schema.JoinPointer("/components/messages", p.key.Value))
+	}
+
+	if v.spec == SpecAsyncAPI2 {
+		for _, ch := range pairs(mapValue(root.root, "channels")) {
+			chPointer := schema.JoinPointer("/channels", ch.key.Value)
+			for _, action := range []string{"publish", "subscribe"} {
+				message := mapValue(mapValue(ch.value, action), "message")
+				msgPointer := schema.JoinPointer(chPointer, action, "message")
+				if oneOf := mapValue(message, "oneOf"); oneOf != nil {
+					for i, m := range items(oneOf) {
+						v.checkMessageExamples(root, m, schema.JoinPointer(msgPointer, "oneOf", strconv.Itoa(i)))
+					}
+					continue
+				}
+				if message != nil {
+					v.checkMessageExamples(root, message, msgPointer)
+				}
+			}
+		}
+		return
+	}
+
+	channelSets := []struct {
+		node    *yaml.Node
+		pointer string
+	}{
+		{mapValue(root.root, "channels"), "/channels"},
+		{mapValue(components, "channels"), "/components/channels"},
+	}
+	for _, set := range channelSets {
+		for _, ch := range pairs(set.node) {
+			chDoc, chNode, chPointer, ok := v.deref(root, ch.value, schema.JoinPointer(set.pointer, ch.key.Value))
+			if !ok {
+				continue
+			}
+			for _, m := range pairs(mapValue(chNode, "messages")) {
+				v.checkMessageExamples(chDoc, m.value, schema.JoinPointer(chPointer, "messages", m.key.Value))
+			}
+		}
+	}
+}
+
+func (v *validator) checkMessageExamples(doc *document, node *yaml.Node, pointer string) {
+	doc, node, pointer, ok := v.deref(doc, node, pointer)
+	if !ok || !v.once("examples", doc, pointer) {
+		return
+	}
+	payloadDoc, payload, payloadPointer := v.messageSchema(doc, mapValue(node, "payload"), schema.JoinPointer(pointer, "payload"), mapValue(node, "schemaFormat"))
+	headersDoc, headers, headersPointer := v.messageSchema(doc, mapValue(node, "headers"), schema.JoinPointer(pointer, "headers"), nil)
+	if payload != nil {
+		if _, isRef := refOf(payload); !isRef {
+			v.walkSchemaExamples(payloadDoc, payload, payloadPointer)
+		}
+	}
+
+	for i, example := range items(mapValue(node, "examples")) {
+		exPointer := schema.JoinPointer(pointer, "examples", strconv.Itoa(i))
+		if value := mapValue(example, "payload"); value != nil && payload != nil {
+			v.validateExample(payloadDoc, payload, payloadPointer, doc, value, schema.JoinPointer(exPointer, "payload"))
+		}
+		if value := mapValue(example, "headers"); value != nil && headers != nil {
+			v.validateExample(headersDoc, headers, headersPointer, doc, value, schema.JoinPointer(exPointer, "headers"))
+		}
+	}
+}
+
+// messageSchema returns the JSON Schema of a message payload or headers.
+// AsyncAPI 3 multi-format schemas are unwrapped; schemas in other formats
+// (Avro, RAML, Protobuf) yield nil and their examples are not checked.
+func (v *validator) messageSchema(doc *document, node *yaml.Node, pointer string, schemaFormat *yaml.Node) (*document, *yaml.Node, string) {
+	if node == nil {
+		return nil, nil, ""
+	}
+	format, _ := scalarString(schemaFormat)
+	if v.spec == SpecAsyncAPI3 {
+		if inner := mapValue(node, "schema"); inner != nil {
+			if f, ok := scalarString(mapValue(node, "schemaFormat")); ok {
+				format = f
+				node, pointer = inner, schema.JoinPointer(pointer, "schema")
+			}
+		}
+	}
+	if format != "" && !isJSONSchemaFormat(format) {
+		return nil, nil, ""
+	}
+	return doc, node, pointer
+}
+
+func isJSONSchemaFormat(format string) bool {
+	format = strings.ToLower(format)
+	return strings.HasPrefix(format, "application/vnd.aai.asyncapi") || strings.HasPrefix(format, "application/schema+")
+}
@@ -0,0 +1,255 @@
+package apispec
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/fulmenhq/goneat/pkg/schema"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// templatePattern matches {name} expressions in path templates and channel addresses
+	templatePattern = regexp.MustCompile(`\{([^{}]+)\}`)
+	// responseKeyPattern accepts the Responses Object keys of the official
+	// OpenAPI 3.0 and 3.1 schemas: status codes, ranges, default and x- extensions
+	responseKeyPattern = regexp.MustCompile(`^(?:default|[1-5](?:\d{2}|XX)|x-.*)$`)
+)
+
+// location remembers where a named element was first declared
+type location struct {
+	doc     *document
+	node    *yaml.Node
+	pointer string
+}
+
+// describe names the location for an issue raised in doc
+func (l location) describe(doc *document) string {
+	if l.doc == doc {
+		return fmt.Sprintf("%s (line %d)", l.pointer, l.node.Line)
+	}
+	return fmt.Sprintf("%s (%s:%d)", l.pointer, l.doc.path, l.node.Line)
+}
+
+// pathParam is a resolved Parameter Object with in: path
+type pathParam struct {
+	location
+	name     string
+	required bool
+}
+
+// checkOpenAPIOperations enforces operationId uniqueness and path template
+// parameter consistency
+func (v *validator) checkOpenAPIOperations() {
+	root := v.root
+	operationIDs := make(map[string]location)
+	normalized := make(map[string]string)
+
+	for _, section := range []string{"paths", "webhooks"} {
+		for _, p := range pairs(mapValue(root.root, section)) {
+			pointer := schema.JoinPointer("/"+section, p.key.Value)
+			doc, item, itemPointer, ok := v.deref(root, p.value, pointer)
+			if !ok {
+				continue
+			}
+			for _, method := range httpMethods {
+				op := mapValue(item, method)
+				if op == nil {
+					continue
+				}
+				v.checkOperationID(operationIDs, doc, mapValue(op, "operationId"), schema.JoinPointer(itemPointer, method, "operationId"))
+				v.checkResponseKeys(doc, mapValue(op, "responses"), schema.JoinPointer(itemPointer, method, "responses"))
+			}
+			if section == "paths" {
+				v.checkPathTemplate(normalized, p.key, pointer)
+				v.checkPathParameters(p.key.Value, doc, item, itemPointer)
+			}
+		}
+	}
+}
+
+func (v *validator) checkOperationID(seen map[string]location, doc *document, node *yaml.Node, pointer string) {
+	id, ok := scalarString(node)
+	if !ok {
+		return
+	}
+	if first, dup := seen[id]; dup {
+		v.add(doc, node, pointer, RuleOperationID, fmt.Sprintf("operationId %q is already used by %s", id, first.describe(doc)))
+		return
+	}
+	seen[id] = location{doc: doc, node: node, pointer: pointer}
+}
+
+// checkResponseKeys reports Responses Object keys other than status codes,
+// ranges such as 4XX, default and x- extensions. The structural schema
+// covers the root document only, so this checks path items pulled in from
+// other files.
+func (v *validator) checkResponseKeys(doc *document, responses *yaml.Node, pointer string) {
+	if doc == v.root {
+		return
+	}
+	for _, p := range pairs(responses) {
+		if !responseKeyPattern.MatchString(p.key.Value) {
+			v.add(doc, p.key, schema.JoinPointer(pointer, p.key.Value), RuleStructure,
+				fmt.Sprintf("response key %q is not a status code, a range such as 4XX, default or an x- extension", p.key.Value))
+		}
+	}
+}
+
+// checkPathTemplate reports repeated template names and paths that differ
+// only in template names, which servers cannot tell apart
+func (v *validator) checkPathTemplate(normalized map[string]string, key *yaml.Node, pointer string) {
+	path := key.Value
+	names := make(map[string]bool)
+	for _, m := range templatePattern.FindAllStringSubmatch(path, -1) {
+		if names[m[1]] {
+			v.add(v.root, key, pointer, RulePathParameter, fmt.Sprintf("path template %s repeats parameter %q", path, m[1]))
+		}
+		names[m[1]] = true
+	}
+	shape := templatePattern.ReplaceAllString(path, "{}")
+	if other, ok := normalized[shape]; ok && other != path {
+		v.add(v.root, key, pointer, RulePathParameter, fmt.Sprintf("path %s is equivalent to %s", path, other))
+		return
+	}
+	normalized[shape] = path
+}
+
+// checkPathParameters requires every template name to be declared as a
+// path parameter by each operation (directly or on the path item), every
+// declared path parameter to appear in the template, and path parameters
+// to be required
+func (v *validator) checkPathParameters(path string, doc *document, item *yaml.Node, pointer string) {
+	inTemplate := make(map[string]bool)
+	for _, m := range templatePattern.FindAllStringSubmatch(path, -1) {
+		inTemplate[m[1]] = true
+	}
+
+	itemParams := v.pathParams(doc, mapValue(item, "parameters"), schema.JoinPointer(pointer, "parameters"))
+	declared := make(map[string]pathParam, len(itemParams))
+	for _, param := range itemParams {
+		declared[param.name] = param
+		v.checkPathParam(path, inTemplate, param)
+	}
+
+	for _, method := range httpMethods {
+		op := mapValue(item, method)
+		if op == nil {
+			continue
+		}
+		opPointer := schema.JoinPointer(pointer, method)
+		effective := make(map[string]bool, len(declared))
+		for name := range declared {
+			effective[name] = true
+		}
+		for _, param := range v.pathParams(doc, mapValue(op, "parameters"), schema.JoinPointer(opPointer, "parameters")) {
+			effective[param.name] = true
+			v.checkPathParam(path, inTemplate, param)
+		}
+		for _, m := range templatePattern.FindAllStringSubmatch(path, -1) {
+			if !effective[m[1]] {
+				v.add(doc, doc.keyAt(opPointer), opPointer, RulePathParameter,
+					fmt.Sprintf("%s %s does not declare path parameter %q", method, path, m[1]))
+				effective[m[1]] = true
+			}
+		}
+	}
+}
+
+func (v *validator) checkPathParam(path string, inTemplate map[string]bool, param pathParam) {
+	if !v.once("path-parameter", param.doc, param.pointer+"\x00"+path) {
+		return
+	}
+	if !inTemplate[param.name] {
+		v.add(param.doc, param.node, param.pointer, RulePathParameter,
+			fmt.Sprintf("path parameter %q does not appear in path %s", param.name, path))
+	}
+	if !param.required {
+		v.add(param.doc, param.node, param.pointer, RulePathParameter,
+			fmt.Sprintf("path parameter %q must be required: true", param.name))
+	}
+}
+
+// pathParams resolves a parameter list and keeps the in: path entries
+func (v *validator) pathParams(doc *document, list *yaml.Node, pointer string) []pathParam {
+	var out []pathParam
+	for i, item := range items(list) {
+		pDoc, pNode, pPointer, ok := v.deref(doc, item, schema.JoinPointer(pointer, strconv.Itoa(i)))
+		if !ok {
+			continue
+		}
+		if in, _ := scalarString(mapValue(pNode, "in")); in != "path" {
+			continue
+		}
+		name, ok := scalarString(mapValue(pNode, "name"))
+		if !ok {
+			continue
+		}
+		required, _ := nodeValue(mapValue(pNode, "required")).(bool)
+		out = append(out, pathParam{
+			location: location{doc: pDoc, node: pNode, pointer: pPointer},
+			name:     name,
+			required: required,
+		})
+	}
+	return out
+}
+
+// checkAsyncAPIChannels matches channel address templates against channel
+// parameters and, for AsyncAPI 2, enforces operationId uniqueness
+func (v *validator) checkAsyncAPIChannels() {
+	root := v.root
+	operationIDs := make(map[string]location)
+
+	if v.spec == SpecAsyncAPI2 {
+		for _, ch := range pairs(mapValue(root.root, "channels")) {
+			pointer := schema.JoinPointer("/channels", ch.key.Value)
+			v.checkChannelParameters(ch.key.Value, root, ch.key, ch.value, pointer)
+			for _, action := range []string{"publish", "subscribe"} {
+				op := mapValue(ch.value, action)
+				v.checkOperationID(operationIDs, root, mapValue(op, "operationId"), schema.JoinPointer(pointer, action, "operationId"))
+			}
+		}
+		return
+	}
+
+	for _, base := range []string{"/channels", "/components/channels"} {
+		channels, _ := root.lookup(base)
+		for _, ch := range pairs(channels) {
+			doc, node, pointer, ok := v.deref(root, ch.value, schema.JoinPointer(base, ch.key.Value))
+			if !ok || !v.once("channel-parameter", doc, pointer) {
+				continue
+			}
+			addressNode := mapValue(node, "address")
+			address, ok := scalarString(addressNode)
+			if !ok {
+				continue
+			}
+			v.checkChannelParameters(address, doc, addressNode, node, pointer)
+		}
+	}
+}
+
+func (v *validator) checkChannelParameters(address string, doc *document, addressNode, channel *yaml.Node, pointer string) {
+	inAddress := make(map[string]bool)
+	for _, m := range templatePattern.FindAllStringSubmatch(address, -1) {
+		inAddress[m[1]] = true
+	}
+	declared := make(map[string]bool)
+	paramsPointer := schema.JoinPointer(pointer, "parameters")
+	for _, p := range pairs(mapValue(channel, "parameters")) {
+		declared[p.key.Value] = true
+		if !inAddress[p.key.Value] {
+			v.add(doc, p.key, schema.JoinPointer(paramsPointer, p.key.Value), RuleChannelParameter,
+				fmt.Sprintf("channel parameter %q does not appear in address %s", p.key.Value, address))
+		}
+	}
+	for _, m := range templatePattern.FindAllStringSubmatch(address, -1) {
+		if !declared[m[1]] {
+			declared[m[1]] = true
+			v.add(doc, addressNode, pointer, RuleChannelParameter,
+				fmt.Sprintf("address %s uses parameter %q that the channel does not declare", address, m[1]))
+		}
+	}
+}
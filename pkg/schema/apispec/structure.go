@@ -0,0 +1,228 @@
+package apispec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/fulmenhq/goneat/internal/assets"
+	"github.com/fulmenhq/goneat/pkg/schema"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"github.com/xeipuuv/gojsonschema"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// specSchemaDir maps each spec to the directory of its embedded structural
+// schemas. AsyncAPI schemas are pinned to a spec version, so <version>.json
+// is preferred when `make sync-schemas` has vendored it.
+var specSchemaDir = map[Spec]string{
+	SpecOpenAPI30: "embedded_schemas/schemas/meta/openapi-3.0",
+	SpecOpenAPI31: "embedded_schemas/schemas/meta/openapi-3.1",
+	SpecAsyncAPI2: "embedded_schemas/schemas/meta/asyncapi-2",
+	SpecAsyncAPI3: "embedded_schemas/schemas/meta/asyncapi-3",
+}
+
+// structureSchema validates a decoded document and reports errors as JSON pointers
+type structureSchema interface {
+	validate(doc interface{}) ([]schemaError, error)
+}
+
+var (
+	specSchemasMu sync.Mutex
+	specSchemas   = make(map[string]structureSchema)
+)
+
+// specSchema compiles the embedded structural schema for spec and version once
+func specSchema(spec Spec, version string) (structureSchema, error) {
+	dir, ok := specSchemaDir[spec]
+	if !ok {
+		return nil, fmt.Errorf("no embedded schema for %s", spec)
+	}
+	paths := []string{dir + "/schema.json"}
+	if spec.Family() == "asyncapi" {
+		paths = append([]string{dir + "/" + version + ".json"}, paths...)
+	}
+
+	specSchemasMu.Lock()
+	defer specSchemasMu.Unlock()
+	for _, path := range paths {
+		if sch, ok := specSchemas[path]; ok {
+			return sch, nil
+		}
+		data, ok := assets.GetSchema(path)
+		if !ok {
+			continue
+		}
+		sch, err := compileSpecSchema(path, data)
+		if err != nil {
+			return nil, fmt.Errorf("compile embedded schema for %s: %w", spec, err)
+		}
+		specSchemas[path] = sch
+		return sch, nil
+	}
+	return nil, fmt.Errorf("embedded schema for %s not found", spec)
+}
+
+// compileSpecSchema compiles Draft 2019-09 and 2020-12 schemas (the official
+// OpenAPI 3.1 schema) with a compiler that supports $dynamicRef and
+// unevaluatedProperties, and older drafts with gojsonschema.
+func compileSpecSchema(name string, data []byte) (structureSchema, error) {
+	var header struct {
+		Schema string `json:"$schema"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+	if !strings.Contains(header.Schema, "2019-09") && !strings.Contains(header.Schema, "2020-12") {
+		sch, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(data))
+		if err != nil {
+			return nil, err
+		}
+		return draft07Schema{sch}, nil
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	c := jsonschema.NewCompiler()
+	url := "goneat:///" + name
+	if err := c.AddResource(url, doc); err != nil {
+		return nil, err
+	}
+	sch, err := c.Compile(url)
+	if err != nil {
+		return nil, err
+	}
+	return draft2020Schema{sch}, nil
+}
+
+// checkStructure validates the root document against the spec schema
+func (v *validator) checkStructure() {
+	sch, err := specSchema(v.spec, v.version)
+	if err != nil {
+		v.add(v.root, nil, "/", RuleStructure, err.Error())
+		return
+	}
+	errs, err := sch.validate(nodeValue(v.root.root))
+	if err != nil {
+		v.add(v.root, nil, "/", RuleStructure, err.Error())
+		return
+	}
+	for _, se := range errs {
+		node := v.root.locate(se.pointer)
+		if se.isKey {
+			node = v.root.keyAt(se.pointer)
+		}
+		v.add(v.root, node, se.pointer, RuleStructure, se.message)
+	}
+}
+
+type schemaError struct {
+	pointer string
+	message string
+	// isKey marks errors about a mapping key rather than its value
+	isKey bool
+}
+
+type draft07Schema struct {
+	schema *gojsonschema.Schema
+}
+
+func (s draft07Schema) validate(doc interface{}) ([]schemaError, error) {
+	result, err := s.schema.Validate(gojsonschema.NewGoLoader(doc))
+	if err != nil {
+		return nil, err
+	}
+	return schemaErrors(result), nil
+}
+
+// schemaErrors converts gojsonschema errors to JSON pointers. The "then" and
+// "else" wrappers produced by reference-or-object switches are dropped;
+// their nested errors carry the detail.
+func schemaErrors(result *gojsonschema.Result) []schemaError {
+	var out []schemaError
+	seen := make(map[string]bool)
+	for _, re := range result.Errors() {
+		switch re.Type() {
+		case "condition_then", "condition_else":
+			continue
+		}
+		tokens := strings.Split(re.Context().String("\x00"), "\x00")
+		if len(tokens) > 0 {
+			tokens = tokens[1:]
+		}
+		se := schemaError{pointer: schema.JoinPointer("", tokens...), message: re.Description()}
+		if re.Type() == "additional_property_not_allowed" {
+			if prop, ok := re.Details()["property"].(string); ok {
+				se.pointer = schema.JoinPointer(se.pointer, prop)
+				se.isKey = true
+			}
+		}
+		key := se.pointer + "\x00" + se.message
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, se)
+	}
+	return out
+}
+
+var defaultMessagePrinter = message.NewPrinter(language.English)
+
+type draft2020Schema struct {
+	schema *jsonschema.Schema
+}
+
+// validate flattens the error tree to its leaves. Properties rejected by
+// additionalProperties or unevaluatedProperties are reported once per key so
+// that the issue points at the key itself.
+func (s draft2020Schema) validate(doc interface{}) ([]schemaError, error) {
+	err := s.schema.Validate(doc)
+	if err == nil {
+		return nil, nil
+	}
+	var ve *jsonschema.ValidationError
+	if !errors.As(err, &ve) {
+		return nil, err
+	}
+	var out []schemaError
+	seen := make(map[string]bool)
+	add := func(se schemaError) {
+		key := se.pointer + "\x00" + se.message
+		if !seen[key] {
+			seen[key] = true
+			out = append(out, se)
+		}
+	}
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				walk(cause)
+			}
+			return
+		}
+		pointer := schema.JoinPointer("", e.InstanceLocation...)
+		if ap, ok := e.ErrorKind.(*kind.AdditionalProperties); ok {
+			for _, prop := range ap.Properties {
+				add(schemaError{pointer: schema.JoinPointer(pointer, prop), message: "Additional property " + prop + " is not allowed", isKey: true})
+			}
+			return
+		}
+		if _, ok := e.ErrorKind.(*kind.FalseSchema); ok && len(e.InstanceLocation) > 0 {
+			prop := e.InstanceLocation[len(e.InstanceLocation)-1]
+			add(schemaError{pointer: pointer, message: "Additional property " + prop + " is not allowed", isKey: true})
+			return
+		}
+		add(schemaError{pointer: pointer, message: strings.TrimSpace(e.ErrorKind.LocalizedString(defaultMessagePrinter))})
+	}
+	walk(ve)
+	return out, nil
+}
@@ -0,0 +1,146 @@
+// Package compat classifies the differences between two versions of a JSON
+// Schema as breaking or non-breaking and checks them against the semver delta
+// between versioned schema directories (schemas/<area>/vX.Y.Z/).
+//
+// Compatibility is judged from two directions:
+//
+//   - Writers produce documents. A change that rejects data the old schema
+//     accepted (a new required property, a narrowed type) breaks writers.
+//   - Readers consume documents. A change that admits data the old schema
+//     rejected (a widened enum, a removed property) breaks readers.
+//
+// The Policy selects which direction counts as breaking.
+package compat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind identifies the type of a schema change
+type Kind string
+
+const (
+	KindSchemaAdded         Kind = "schema-added"
+	KindSchemaRemoved       Kind = "schema-removed"
+	KindRequiredAdded       Kind = "required-added"
+	KindRequiredRemoved     Kind = "required-removed"
+	KindPropertyAdded       Kind = "property-added"
+	KindPropertyRemoved     Kind = "property-removed"
+	KindDefinitionRemoved   Kind = "definition-removed"
+	KindTypeNarrowed        Kind = "type-narrowed"
+	KindTypeWidened         Kind = "type-widened"
+	KindEnumNarrowed        Kind = "enum-narrowed"
+	KindEnumWidened         Kind = "enum-widened"
+	KindConstChanged        Kind = "const-changed"
+	KindPatternTightened    Kind = "pattern-tightened"
+	KindPatternLoosened     Kind = "pattern-loosened"
+	KindPatternChanged      Kind = "pattern-changed"
+	KindBoundTightened      Kind = "bound-tightened"
+	KindBoundLoosened       Kind = "bound-loosened"
+	KindAdditionalTightened Kind = "additional-properties-tightened"
+	KindAdditionalLoosened  Kind = "additional-properties-loosened"
+	KindCompositionChanged  Kind = "composition-changed"
+	KindRefChanged          Kind = "ref-changed"
+	KindKeywordChanged      Kind = "keyword-changed"
+	KindAnnotationChanged   Kind = "annotation-changed"
+)
+
+// Change is a single classified difference between two schemas
+type Change struct {
+	// Path is the JSON pointer of the changed keyword in the new schema
+	Path          string `json:"path"`
+	Kind          Kind   `json:"kind"`
+	Message       string `json:"message"`
+	BreaksReaders bool   `json:"breaks_readers"`
+	BreaksWriters bool   `json:"breaks_writers"`
+}
+
+// Policy selects which side of a change counts as breaking
+type Policy string
+
+const (
+	// PolicyWriters treats changes that reject previously valid data as breaking (backward compatibility)
+	PolicyWriters Policy = "writers"
+	// PolicyReaders treats changes that admit previously invalid data as breaking (forward compatibility)
+	PolicyReaders Policy = "readers"
+	// PolicyBoth treats either direction as breaking (full compatibility)
+	PolicyBoth Policy = "both"
+)
+
+// ParsePolicy validates a policy name; empty selects PolicyWriters
+func ParsePolicy(name string) (Policy, error) {
+	switch Policy(strings.ToLower(strings.TrimSpace(name))) {
+	case "", PolicyWriters:
+		return PolicyWriters, nil
+	case PolicyReaders:
+		return PolicyReaders, nil
+	case PolicyBoth:
+		return PolicyBoth, nil
+	default:
+		return "", fmt.Errorf("unknown compatibility policy %q (want writers, readers or both)", name)
+	}
+}
+
+// Breaking reports whether the change is breaking under policy
+func (c Change) Breaking(policy Policy) bool {
+	switch policy {
+	case PolicyReaders:
+		return c.BreaksReaders
+	case PolicyBoth:
+		return c.BreaksReaders || c.BreaksWriters
+	default:
+		return c.BreaksWriters
+	}
+}
+
+// RequiredBump returns the smallest version bump that may carry the change
+func (c Change) RequiredBump(policy Policy) Bump {
+	switch {
+	case c.Breaking(policy):
+		return BumpMajor
+	case c.Kind == KindAnnotationChanged || (c.Kind == KindRefChanged && !c.BreaksReaders && !c.BreaksWriters):
+		return BumpPatch
+	default:
+		return BumpMinor
+	}
+}
+
+// Bump is a semantic version increment
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	default:
+		return "none"
+	}
+}
+
+// MarshalText renders the bump by name in JSON output
+func (b Bump) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// RequiredBump returns the smallest version bump that may carry all changes
+func RequiredBump(changes []Change, policy Policy) Bump {
+	bump := BumpNone
+	for _, change := range changes {
+		if b := change.RequiredBump(policy); b > bump {
+			bump = b
+		}
+	}
+	return bump
+}
@@ -0,0 +1,753 @@
+package compat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// maxDepth bounds recursion when one side inlines a recursive $ref target
+const maxDepth = 64
+
+// Options configures how $ref targets are resolved
+type Options struct {
+	// OldIndex resolves absolute $ref URLs in the old schema
+	OldIndex *schema.IDIndex
+	// NewIndex resolves absolute $ref URLs in the new schema
+	NewIndex *schema.IDIndex
+}
+
+// identifierKeywords name a schema rather than constrain it
+var identifierKeywords = map[string]bool{
+	"$id":            true,
+	"id":             true,
+	"$anchor":        true,
+	"$dynamicAnchor": true,
+}
+
+var lowerBounds = map[string]bool{
+	"minimum":          true,
+	"exclusiveMinimum": true,
+	"minLength":        true,
+	"minItems":         true,
+	"minProperties":    true,
+	"minContains":      true,
+}
+
+var upperBounds = map[string]bool{
+	"maximum":          true,
+	"exclusiveMaximum": true,
+	"maxLength":        true,
+	"maxItems":         true,
+	"maxProperties":    true,
+	"maxContains":      true,
+}
+
+// opaqueKeywords constrain data in ways that are not ordered here, so any
+// change to them is treated as breaking both ways. Keywords outside the
+// vocabulary are ignored by validators and compared as annotations.
+var opaqueKeywords = map[string]bool{
+	"not":               true,
+	"if":                true,
+	"then":              true,
+	"else":              true,
+	"dependencies":      true,
+	"dependentRequired": true,
+	"dependentSchemas":  true,
+	"contentEncoding":   true,
+	"contentMediaType":  true,
+	"contentSchema":     true,
+	"$dynamicRef":       true,
+	"$recursiveRef":     true,
+}
+
+// residualKeywords default to "accept anything" when absent
+var residualKeywords = map[string]bool{
+	"additionalProperties":  true,
+	"unevaluatedProperties": true,
+	"additionalItems":       true,
+	"unevaluatedItems":      true,
+	"propertyNames":         true,
+}
+
+// LoadDocument reads a JSON or YAML schema file and decodes it into plain
+// JSON values so documents from either format compare equal
+func LoadDocument(path string) (any, error) {
+	data, err := os.ReadFile(filepath.Clean(path)) // #nosec G304 -- schema paths are chosen by the caller
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema %s: %w", path, err)
+	}
+	var doc any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s as JSON: %w", path, err)
+		}
+		return doc, nil
+	default:
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s as YAML: %w", path, err)
+		}
+	}
+	encoded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalise %s: %w", path, err)
+	}
+	var normalized any
+	if err := json.Unmarshal(encoded, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to normalise %s: %w", path, err)
+	}
+	return normalized, nil
+}
+
+// Diff classifies the differences between two decoded schema documents.
+// Changes are ordered by path.
+func Diff(oldDoc, newDoc any, opts Options) []Change {
+	d := &differ{visited: make(map[string]bool)}
+	d.compare("", oldDoc, newDoc, newDocSide(oldDoc, opts.OldIndex), newDocSide(newDoc, opts.NewIndex), 0)
+	sort.SliceStable(d.changes, func(i, j int) bool {
+		if d.changes[i].Path != d.changes[j].Path {
+			return d.changes[i].Path < d.changes[j].Path
+		}
+		return d.changes[i].Kind < d.changes[j].Kind
+	})
+	return d.changes
+}
+
+// docSide is the document a schema node belongs to, used to resolve $ref
+type docSide struct {
+	root    any
+	base    string
+	index   *schema.IDIndex
+	primary bool
+}
+
+func newDocSide(doc any, index *schema.IDIndex) docSide {
+	return docSide{root: doc, base: documentID(doc), index: index, primary: true}
+}
+
+type differ struct {
+	changes []Change
+	visited map[string]bool
+}
+
+func (d *differ) add(path string, kind Kind, readers, writers bool, format string, args ...any) {
+	if path == "" {
+		path = "/"
+	}
+	d.changes = append(d.changes, Change{
+		Path:          path,
+		Kind:          kind,
+		Message:       fmt.Sprintf(format, args...),
+		BreaksReaders: readers,
+		BreaksWriters: writers,
+	})
+}
+
+func (d *differ) compare(path string, ov, nv any, oldSide, newSide docSide, depth int) {
+	if depth > maxDepth {
+		return
+	}
+	oFalse, nFalse := ov == false, nv == false
+	switch {
+	case oFalse && nFalse:
+		return
+	case nFalse:
+		d.add(path, tightenedKind(path), false, true, "schema now rejects every value")
+		return
+	case oFalse:
+		d.add(path, loosenedKind(path), true, false, "schema now accepts values it rejected")
+		return
+	}
+
+	om, oIsMap := asSchema(ov)
+	nm, nIsMap := asSchema(nv)
+	if !oIsMap || !nIsMap {
+		if canonical(ov) != canonical(nv) {
+			d.add(path, KindKeywordChanged, true, true, "schema changed from %s to %s", canonical(ov), canonical(nv))
+		}
+		return
+	}
+
+	oRef, oHasRef := om["$ref"].(string)
+	nRef, nHasRef := nm["$ref"].(string)
+	switch {
+	case oHasRef && nHasRef:
+		if oRef != nRef || !isLocalRef(oRef) || !oldSide.primary || !newSide.primary {
+			d.compareRefs(path, oRef, nRef, oldSide, newSide, depth)
+		}
+	case oHasRef:
+		// The old schema referenced what the new one defines inline
+		target, side, _, ok := resolveRef(oRef, oldSide)
+		if !ok {
+			d.add(path, KindRefChanged, true, true, "$ref %s replaced inline and could not be resolved", oRef)
+			return
+		}
+		d.add(path, KindRefChanged, false, false, "$ref %s replaced by an inline schema", oRef)
+		d.compare(path, target, nv, side, newSide, depth+1)
+		return
+	case nHasRef:
+		target, side, _, ok := resolveRef(nRef, newSide)
+		if !ok {
+			d.add(path, KindRefChanged, true, true, "inline schema replaced by unresolvable $ref %s", nRef)
+			return
+		}
+		d.add(path, KindRefChanged, false, false, "inline schema replaced by $ref %s", nRef)
+		d.compare(path, ov, target, oldSide, side, depth+1)
+		return
+	}
+
+	d.compareKeywords(path, om, nm, oldSide, newSide, depth)
+}
+
+func (d *differ) compareRefs(path, oRef, nRef string, oldSide, newSide docSide, depth int) {
+	refPath := path + "/$ref"
+	oTarget, oSide, oKey, oOK := resolveRef(oRef, oldSide)
+	nTarget, nSide, nKey, nOK := resolveRef(nRef, newSide)
+	if !oOK || !nOK {
+		if oRef != nRef {
+			d.add(refPath, KindRefChanged, true, true, "$ref target changed from %s to %s and could not be resolved", oRef, nRef)
+		}
+		return
+	}
+	if oRef != nRef {
+		d.add(refPath, KindRefChanged, false, false, "$ref target changed from %s to %s", oRef, nRef)
+	}
+	key := oKey + "|" + nKey
+	if d.visited[key] {
+		return
+	}
+	d.visited[key] = true
+	d.compare(refPath, oTarget, nTarget, oSide, nSide, depth+1)
+}
+
+func (d *differ) compareKeywords(path string, om, nm map[string]any, oldSide, newSide docSide, depth int) {
+	for _, key := range keywordOrder(om, nm) {
+		ov, oOK := om[key]
+		nv, nOK := nm[key]
+		kwPath := schema.JoinPointer(path, key)
+
+		switch {
+		case key == "$ref" || identifierKeywords[key]:
+			continue
+		case key == "type":
+			d.compareType(kwPath, ov, nv, oOK, nOK)
+		case key == "enum":
+			d.compareEnum(kwPath, ov, nv, oOK, nOK)
+		case key == "const":
+			d.comparePresence(kwPath, key, ov, nv, oOK, nOK, KindConstChanged, KindConstChanged, KindConstChanged)
+		case key == "pattern" || key == "format":
+			d.comparePresence(kwPath, key, ov, nv, oOK, nOK, KindPatternTightened, KindPatternLoosened, KindPatternChanged)
+		case key == "multipleOf":
+			d.comparePresence(kwPath, key, ov, nv, oOK, nOK, KindBoundTightened, KindBoundLoosened, KindKeywordChanged)
+		case lowerBounds[key] || upperBounds[key]:
+			d.compareBound(kwPath, key, ov, nv, oOK, nOK, lowerBounds[key])
+		case key == "uniqueItems":
+			o, n := ov == true, nv == true
+			if o != n {
+				if n {
+					d.add(kwPath, KindBoundTightened, false, true, "uniqueItems now required")
+				} else {
+					d.add(kwPath, KindBoundLoosened, true, false, "uniqueItems no longer required")
+				}
+			}
+		case key == "required":
+			d.compareRequired(kwPath, ov, nv)
+		case key == "properties" || key == "patternProperties":
+			d.compareProperties(kwPath, key, ov, nv, isClosed(om), isClosed(nm), oldSide, newSide, depth)
+		case key == "$defs" || key == "definitions":
+			d.compareDefinitions(kwPath, key, ov, nv, oldSide, newSide, depth)
+		case residualKeywords[key]:
+			d.compare(kwPath, orTrue(ov, oOK), orTrue(nv, nOK), oldSide, newSide, depth+1)
+		case key == "items" || key == "prefixItems":
+			d.compareItems(kwPath, key, ov, nv, oOK, nOK, oldSide, newSide, depth)
+		case key == "contains":
+			switch {
+			case !oOK:
+				d.add(kwPath, KindCompositionChanged, false, true, "contains constraint added")
+			case !nOK:
+				d.add(kwPath, KindCompositionChanged, true, false, "contains constraint removed")
+			default:
+				d.compare(kwPath, ov, nv, oldSide, newSide, depth+1)
+			}
+		case key == "allOf":
+			d.compareComposition(kwPath, key, ov, nv, false, oldSide, newSide, depth)
+		case key == "anyOf" || key == "oneOf":
+			d.compareComposition(kwPath, key, ov, nv, true, oldSide, newSide, depth)
+		case key == "$schema":
+			if canonical(ov) != canonical(nv) {
+				d.add(kwPath, KindKeywordChanged, true, true, "$schema changed from %s to %s", canonical(ov), canonical(nv))
+			}
+		case opaqueKeywords[key]:
+			if canonical(ov) != canonical(nv) {
+				d.add(kwPath, KindKeywordChanged, true, true, "%s changed", key)
+			}
+		default:
+			// title, description, examples, default, deprecated and unknown keywords
+			if canonical(ov) != canonical(nv) {
+				d.add(kwPath, KindAnnotationChanged, false, false, "%s changed", key)
+			}
+		}
+	}
+}
+
+func (d *differ) compareType(path string, ov, nv any, oOK, nOK bool) {
+	oTypes, nTypes := typeSet(ov, oOK), typeSet(nv, nOK)
+	switch {
+	case oTypes == nil && nTypes == nil:
+		return
+	case oTypes == nil:
+		d.add(path, KindTypeNarrowed, false, true, "type restricted to %s", strings.Join(nTypes, ", "))
+		return
+	case nTypes == nil:
+		d.add(path, KindTypeWidened, true, false, "type restriction %s removed", strings.Join(oTypes, ", "))
+		return
+	}
+	var removed, added []string
+	for _, t := range oTypes {
+		if !coversType(nTypes, t) {
+			removed = append(removed, t)
+		}
+	}
+	for _, t := range nTypes {
+		if !coversType(oTypes, t) {
+			added = append(added, t)
+		}
+	}
+	if len(removed) > 0 {
+		d.add(path, KindTypeNarrowed, false, true, "type no longer allows %s", strings.Join(removed, ", "))
+	}
+	if len(added) > 0 {
+		d.add(path, KindTypeWidened, true, false, "type now allows %s", strings.Join(added, ", "))
+	}
+}
+
+func (d *differ) compareEnum(path string, ov, nv any, oOK, nOK bool) {
+	switch {
+	case !oOK && !nOK:
+		return
+	case !oOK:
+		d.add(path, KindEnumNarrowed, false, true, "values restricted to an enum")
+		return
+	case !nOK:
+		d.add(path, KindEnumWidened, true, false, "enum restriction removed")
+		return
+	}
+	oValues, nValues := valueSet(ov), valueSet(nv)
+	removed := difference(oValues, nValues)
+	added := difference(nValues, oValues)
+	if len(removed) > 0 {
+		d.add(path, KindEnumNarrowed, false, true, "enum no longer allows %s", strings.Join(removed, ", "))
+	}
+	if len(added) > 0 {
+		d.add(path, KindEnumWidened, true, false, "enum now allows %s", strings.Join(added, ", "))
+	}
+}
+
+// comparePresence classifies keywords whose addition tightens, removal
+// loosens, and any other change cannot be ordered
+func (d *differ) comparePresence(path, key string, ov, nv any, oOK, nOK bool, tightened, loosened, changed Kind) {
+	switch {
+	case !oOK && !nOK:
+	case !oOK:
+		d.add(path, tightened, false, true, "%s %s added", key, canonical(nv))
+	case !nOK:
+		d.add(path, loosened, true, false, "%s %s removed", key, canonical(ov))
+	case canonical(ov) != canonical(nv):
+		d.add(path, changed, true, true, "%s changed from %s to %s", key, canonical(ov), canonical(nv))
+	}
+}
+
+func (d *differ) compareBound(path, key string, ov, nv any, oOK, nOK, lower bool) {
+	oNum, oIsNum := toFloat(ov)
+	nNum, nIsNum := toFloat(nv)
+	if (oOK && !oIsNum) || (nOK && !nIsNum) {
+		// draft-04 boolean exclusiveMinimum/exclusiveMaximum
+		o, n := ov == true, nv == true
+		if o != n {
+			if n {
+				d.add(path, KindBoundTightened, false, true, "%s enabled", key)
+			} else {
+				d.add(path, KindBoundLoosened, true, false, "%s disabled", key)
+			}
+		}
+		return
+	}
+	switch {
+	case !oOK && !nOK:
+	case !oOK:
+		d.add(path, KindBoundTightened, false, true, "%s %s added", key, canonical(nv))
+	case !nOK:
+		d.add(path, KindBoundLoosened, true, false, "%s %s removed", key, canonical(ov))
+	case oNum == nNum:
+	case (nNum > oNum) == lower:
+		d.add(path, KindBoundTightened, false, true, "%s tightened from %s to %s", key, canonical(ov), canonical(nv))
+	default:
+		d.add(path, KindBoundLoosened, true, false, "%s loosened from %s to %s", key, canonical(ov), canonical(nv))
+	}
+}
+
+func (d *differ) compareRequired(path string, ov, nv any) {
+	oNames, nNames := stringSet(ov), stringSet(nv)
+	for _, name := range difference(nNames, oNames) {
+		d.add(path, KindRequiredAdded, false, true, "property %q is now required", name)
+	}
+	for _, name := range difference(oNames, nNames) {
+		d.add(path, KindRequiredRemoved, true, false, "property %q is no longer required", name)
+	}
+}
+
+func (d *differ) compareProperties(path, key string, ov, nv any, oClosed, nClosed bool, oldSide, newSide docSide, depth int) {
+	oProps, _ := ov.(map[string]any)
+	nProps, _ := nv.(map[string]any)
+	label := "property"
+	if key == "patternProperties" {
+		label = "pattern property"
+	}
+	for _, name := range sortedKeys(oProps, nProps) {
+		o, oOK := oProps[name]
+		n, nOK := nProps[name]
+		propPath := schema.JoinPointer(path, name)
+		switch {
+		case oOK && nOK:
+			d.compare(propPath, o, n, oldSide, newSide, depth+1)
+		case oOK:
+			if nClosed {
+				d.add(propPath, KindPropertyRemoved, true, true, "%s %q removed and no longer allowed", label, name)
+			} else {
+				d.add(propPath, KindPropertyRemoved, true, false, "%s %q removed", label, name)
+			}
+		default:
+			if oClosed {
+				d.add(propPath, KindPropertyAdded, true, false, "%s %q added to a closed object", label, name)
+			} else {
+				d.add(propPath, KindPropertyAdded, false, false, "%s %q added", label, name)
+			}
+		}
+	}
+}
+
+func (d *differ) compareDefinitions(path, key string, ov, nv any, oldSide, newSide docSide, depth int) {
+	oDefs, _ := ov.(map[string]any)
+	nDefs, _ := nv.(map[string]any)
+	for _, name := range sortedKeys(oDefs, nDefs) {
+		o, oOK := oDefs[name]
+		n, nOK := nDefs[name]
+		defPath := schema.JoinPointer(path, name)
+		switch {
+		case oOK && nOK:
+			d.compare(defPath, o, n, oldSide, newSide, depth+1)
+		case oOK:
+			d.add(defPath, KindDefinitionRemoved, true, true, "%s %q removed; external $ref targets may break", key, name)
+		}
+	}
+}
+
+func (d *differ) compareItems(path, key string, ov, nv any, oOK, nOK bool, oldSide, newSide docSide, depth int) {
+	oTuple, oIsTuple := ov.([]any)
+	nTuple, nIsTuple := nv.([]any)
+	switch {
+	case !oIsTuple && !nIsTuple:
+		d.compare(path, orTrue(ov, oOK), orTrue(nv, nOK), oldSide, newSide, depth+1)
+	case oIsTuple && nIsTuple:
+		for i := 0; i < len(oTuple) || i < len(nTuple); i++ {
+			itemPath := schema.JoinPointer(path, strconv.Itoa(i))
+			switch {
+			case i < len(oTuple) && i < len(nTuple):
+				d.compare(itemPath, oTuple[i], nTuple[i], oldSide, newSide, depth+1)
+			case i < len(nTuple):
+				d.add(itemPath, KindCompositionChanged, false, true, "%s position %d constrained", key, i)
+			default:
+				d.add(itemPath, KindCompositionChanged, true, false, "%s position %d no longer constrained", key, i)
+			}
+		}
+	case !oOK:
+		d.add(path, KindCompositionChanged, false, true, "%s tuple constraint added", key)
+	case !nOK:
+		d.add(path, KindCompositionChanged, true, false, "%s tuple constraint removed", key)
+	default:
+		d.add(path, KindKeywordChanged, true, true, "%s changed between tuple and single-schema form", key)
+	}
+}
+
+// compareComposition pairs identical members first and then compares the
+// remainder in order. For allOf an added member tightens; for anyOf/oneOf an
+// added option loosens.
+func (d *differ) compareComposition(path, key string, ov, nv any, options bool, oldSide, newSide docSide, depth int) {
+	oMembers, _ := ov.([]any)
+	nMembers, _ := nv.([]any)
+	if ov != nil && oMembers == nil || nv != nil && nMembers == nil {
+		if canonical(ov) != canonical(nv) {
+			d.add(path, KindKeywordChanged, true, true, "%s changed", key)
+		}
+		return
+	}
+
+	matched := make(map[int]bool)
+	var oRest []int
+	for i, o := range oMembers {
+		found := false
+		for j, n := range nMembers {
+			if !matched[j] && canonical(o) == canonical(n) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			oRest = append(oRest, i)
+		}
+	}
+	var nRest []int
+	for j := range nMembers {
+		if !matched[j] {
+			nRest = append(nRest, j)
+		}
+	}
+
+	for len(oRest) > 0 && len(nRest) > 0 {
+		d.compare(schema.JoinPointer(path, strconv.Itoa(nRest[0])), oMembers[oRest[0]], nMembers[nRest[0]], oldSide, newSide, depth+1)
+		oRest, nRest = oRest[1:], nRest[1:]
+	}
+	for _, j := range nRest {
+		if options {
+			d.add(schema.JoinPointer(path, strconv.Itoa(j)), KindCompositionChanged, true, false, "%s option added", key)
+		} else {
+			d.add(schema.JoinPointer(path, strconv.Itoa(j)), KindCompositionChanged, false, true, "%s member added", key)
+		}
+	}
+	for _, i := range oRest {
+		if options {
+			d.add(schema.JoinPointer(path, strconv.Itoa(i)), KindCompositionChanged, false, true, "%s option %d removed", key, i)
+		} else {
+			d.add(schema.JoinPointer(path, strconv.Itoa(i)), KindCompositionChanged, true, false, "%s member %d removed", key, i)
+		}
+	}
+}
+
+// resolveRef returns the schema a $ref points at, the document it lives in
+// and a key identifying the target. Fragment-only refs resolve within the
+// current document; anything else is looked up by $id in the side's index.
+func resolveRef(ref string, side docSide) (any, docSide, string, bool) {
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return nil, side, "", false
+	}
+	abs := refURL
+	if side.base != "" {
+		if base, err := url.Parse(side.base); err == nil {
+			abs = base.ResolveReference(refURL)
+		}
+	}
+	fragment := abs.Fragment
+	docURL := *abs
+	docURL.Fragment = ""
+	docURL.RawFragment = ""
+	docID := docURL.String()
+
+	target := side
+	if docID != "" && docID != side.base {
+		entry, ok := side.index.Get(docID)
+		if !ok {
+			entry, ok = side.index.Get(docID + "#")
+		}
+		if !ok {
+			return nil, side, "", false
+		}
+		var doc any
+		if err := json.Unmarshal(entry.Normalized, &doc); err != nil {
+			return nil, side, "", false
+		}
+		target = docSide{root: doc, base: docID, index: side.index}
+	}
+
+	node, ok := schema.ResolvePointer(target.root, fragment)
+	if !ok {
+		return nil, side, "", false
+	}
+	return node, target, docID + "#" + fragment, true
+}
+
+func documentID(doc any) string {
+	m, ok := doc.(map[string]any)
+	if !ok {
+		return ""
+	}
+	id, _ := m["$id"].(string)
+	if id == "" {
+		id, _ = m["id"].(string)
+	}
+	return strings.TrimSuffix(strings.TrimSpace(id), "#")
+}
+
+func isLocalRef(ref string) bool {
+	return strings.HasPrefix(ref, "#")
+}
+
+// asSchema treats the boolean schema true as the empty schema
+func asSchema(v any) (map[string]any, bool) {
+	if v == true {
+		return map[string]any{}, true
+	}
+	m, ok := v.(map[string]any)
+	return m, ok
+}
+
+func orTrue(v any, ok bool) any {
+	if !ok {
+		return true
+	}
+	return v
+}
+
+// isClosed reports whether an object schema rejects undeclared properties
+func isClosed(m map[string]any) bool {
+	return m["additionalProperties"] == false || m["unevaluatedProperties"] == false
+}
+
+func tightenedKind(path string) Kind {
+	if residualKeywords[lastToken(path)] {
+		return KindAdditionalTightened
+	}
+	return KindTypeNarrowed
+}
+
+func loosenedKind(path string) Kind {
+	if residualKeywords[lastToken(path)] {
+		return KindAdditionalLoosened
+	}
+	return KindTypeWidened
+}
+
+func lastToken(path string) string {
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
+
+// keywordOrder visits definitions before anything that may $ref into them so
+// each definition is compared once, at its own path
+func keywordOrder(om, nm map[string]any) []string {
+	keys := sortedKeys(om, nm)
+	sort.SliceStable(keys, func(i, j int) bool {
+		return isDefinitionsKey(keys[i]) && !isDefinitionsKey(keys[j])
+	})
+	return keys
+}
+
+func isDefinitionsKey(key string) bool {
+	return key == "$defs" || key == "definitions"
+}
+
+func sortedKeys(a, b map[string]any) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	keys := make([]string, 0, len(a)+len(b))
+	for _, m := range []map[string]any{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// typeSet returns the declared types, or nil when any type is allowed
+func typeSet(v any, ok bool) []string {
+	if !ok {
+		return nil
+	}
+	var types []string
+	switch t := v.(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// coversType reports whether types admits every value of type t
+func coversType(types []string, t string) bool {
+	for _, candidate := range types {
+		if candidate == t || (t == "integer" && candidate == "number") {
+			return true
+		}
+	}
+	return false
+}
+
+func valueSet(v any) []string {
+	items, _ := v.([]any)
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		values = append(values, canonical(item))
+	}
+	return values
+}
+
+func stringSet(v any) []string {
+	items, _ := v.([]any)
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// difference returns the members of a missing from b, sorted
+func difference(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, v := range b {
+		in[v] = true
+	}
+	var out []string
+	for _, v := range a {
+		if !in[v] {
+			out = append(out, v)
+			in[v] = true
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// canonical renders a value as JSON with sorted object keys
+func canonical(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
@@ -0,0 +1,207 @@
+package compat
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fulmenhq/goneat/pkg/schema"
+)
+
+func decode(t *testing.T, doc string) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(doc), &v); err != nil {
+		t.Fatalf("decode %s: %v", doc, err)
+	}
+	return v
+}
+
+func TestDiff_Classification(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		old     string
+		new     string
+		kind    Kind
+		readers bool
+		writers bool
+	}{
+		{"required added", `{"required":["a"]}`, `{"required":["a","b"]}`, KindRequiredAdded, false, true},
+		{"required removed", `{"required":["a","b"]}`, `{"required":["a"]}`, KindRequiredRemoved, true, false},
+		{"property removed", `{"properties":{"a":{}}}`, `{"properties":{}}`, KindPropertyRemoved, true, false},
+		{"property removed from closed object", `{"properties":{"a":{}},"additionalProperties":false}`, `{"properties":{},"additionalProperties":false}`, KindPropertyRemoved, true, true},
+		{"property added", `{"properties":{}}`, `{"properties":{"a":{}}}`, KindPropertyAdded, false, false},
+		{"property added to closed object", `{"properties":{},"additionalProperties":false}`, `{"properties":{"a":{}},"additionalProperties":false}`, KindPropertyAdded, true, false},
+		{"type narrowed", `{"type":["string","null"]}`, `{"type":"string"}`, KindTypeNarrowed, false, true},
+		{"type widened", `{"type":"integer"}`, `{"type":"number"}`, KindTypeWidened, true, false},
+		{"type added", `{}`, `{"type":"object"}`, KindTypeNarrowed, false, true},
+		{"enum narrowed", `{"enum":["a","b"]}`, `{"enum":["a"]}`, KindEnumNarrowed, false, true},
+		{"enum widened", `{"enum":["a"]}`, `{"enum":["a","b"]}`, KindEnumWidened, true, false},
+		{"pattern added", `{}`, `{"pattern":"^a"}`, KindPatternTightened, false, true},
+		{"pattern removed", `{"pattern":"^a"}`, `{}`, KindPatternLoosened, true, false},
+		{"pattern changed", `{"pattern":"^a"}`, `{"pattern":"^b"}`, KindPatternChanged, true, true},
+		{"minimum raised", `{"minimum":1}`, `{"minimum":2}`, KindBoundTightened, false, true},
+		{"maxLength raised", `{"maxLength":5}`, `{"maxLength":10}`, KindBoundLoosened, true, false},
+		{"maxItems added", `{}`, `{"maxItems":3}`, KindBoundTightened, false, true},
+		{"additionalProperties closed", `{}`, `{"additionalProperties":false}`, KindAdditionalTightened, false, true},
+		{"additionalProperties opened", `{"additionalProperties":false}`, `{"additionalProperties":true}`, KindAdditionalLoosened, true, false},
+		{"additionalProperties schema narrowed", `{"additionalProperties":{"type":["string","integer"]}}`, `{"additionalProperties":{"type":"string"}}`, KindTypeNarrowed, false, true},
+		{"anyOf option added", `{"anyOf":[{"type":"string"}]}`, `{"anyOf":[{"type":"string"},{"type":"null"}]}`, KindCompositionChanged, true, false},
+		{"allOf member added", `{"allOf":[{"type":"string"}]}`, `{"allOf":[{"type":"string"},{"minLength":1}]}`, KindCompositionChanged, false, true},
+		{"not changed", `{"not":{"required":["a"]}}`, `{"not":{"required":["b"]}}`, KindKeywordChanged, true, true},
+		{"description changed", `{"description":"a"}`, `{"description":"b"}`, KindAnnotationChanged, false, false},
+		{"unknown keyword changed", `{"x-owner":"a"}`, `{"x-owner":"b"}`, KindAnnotationChanged, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			changes := Diff(decode(t, tt.old), decode(t, tt.new), Options{})
+			if len(changes) != 1 {
+				t.Fatalf("expected 1 change, got %+v", changes)
+			}
+			c := changes[0]
+			if c.Kind != tt.kind || c.BreaksReaders != tt.readers || c.BreaksWriters != tt.writers {
+				t.Fatalf("got %s readers=%v writers=%v (%s), want %s readers=%v writers=%v",
+					c.Kind, c.BreaksReaders, c.BreaksWriters, c.Message, tt.kind, tt.readers, tt.writers)
+			}
+		})
+	}
+}
+
+func TestDiff_NoChangesAcrossFormats(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	jsonPath := filepath.Join(tmp, "a.json")
+	yamlPath := filepath.Join(tmp, "a.yaml")
+	if err := os.WriteFile(jsonPath, []byte(`{"type":"object","properties":{"n":{"type":"integer","minimum":1}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(yamlPath, []byte("type: object\nproperties:\n  n:\n    type: integer\n    minimum: 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	oldDoc, err := LoadDocument(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newDoc, err := LoadDocument(yamlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes := Diff(oldDoc, newDoc, Options{}); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestDiff_LocalRefTargetChanged(t *testing.T) {
+	t.Parallel()
+
+	oldDoc := decode(t, `{
+  "$defs": {"name": {"type": "string"}, "label": {"type": "string", "minLength": 3}},
+  "properties": {"n": {"$ref": "#/$defs/name"}}
+}`)
+	newDoc := decode(t, `{
+  "$defs": {"name": {"type": "string"}, "label": {"type": "string", "minLength": 3}},
+  "properties": {"n": {"$ref": "#/$defs/label"}}
+}`)
+
+	changes := Diff(oldDoc, newDoc, Options{})
+	var retarget, tightened bool
+	for _, c := range changes {
+		switch {
+		case c.Kind == KindRefChanged && c.Path == "/properties/n/$ref":
+			retarget = true
+		case c.Kind == KindBoundTightened && c.Path == "/properties/n/$ref/minLength":
+			tightened = true
+		}
+	}
+	if !retarget || !tightened {
+		t.Fatalf("expected ref retarget and tightened bound, got %+v", changes)
+	}
+	if RequiredBump(changes, PolicyWriters) != BumpMajor {
+		t.Fatalf("expected major bump, got %s", RequiredBump(changes, PolicyWriters))
+	}
+}
+
+func TestDiff_ResolvesExternalRefsThroughIndex(t *testing.T) {
+	t.Parallel()
+
+	write := func(dir, content string) string {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "common.json"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	tmp := t.TempDir()
+	oldDir := write(filepath.Join(tmp, "old"), `{"$id":"https://example.invalid/common/v1.json","$defs":{"id":{"type":"string"}}}`)
+	newDir := write(filepath.Join(tmp, "new"), `{"$id":"https://example.invalid/common/v2.json","$defs":{"id":{"type":"string","format":"uuid"}}}`)
+	oldIndex, err := schema.BuildIDIndexFromRefDirs([]string{oldDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	newIndex, err := schema.BuildIDIndexFromRefDirs([]string{newDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldDoc := decode(t, `{"properties":{"id":{"$ref":"https://example.invalid/common/v1.json#/$defs/id"}}}`)
+	newDoc := decode(t, `{"properties":{"id":{"$ref":"https://example.invalid/common/v2.json#/$defs/id"}}}`)
+
+	changes := Diff(oldDoc, newDoc, Options{OldIndex: oldIndex, NewIndex: newIndex})
+	var found bool
+	for _, c := range changes {
+		if c.Kind == KindPatternTightened && c.Path == "/properties/id/$ref/format" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected format tightening through external ref, got %+v", changes)
+	}
+
+	unresolved := Diff(oldDoc, newDoc, Options{})
+	if len(unresolved) != 1 || unresolved[0].Kind != KindRefChanged || !unresolved[0].BreaksWriters {
+		t.Fatalf("expected a single breaking unresolved ref change, got %+v", unresolved)
+	}
+}
+
+func TestDiff_RecursiveRefTerminates(t *testing.T) {
+	t.Parallel()
+
+	oldDoc := decode(t, `{"$defs":{"node":{"properties":{"child":{"$ref":"#/$defs/node"}}}},"$ref":"#/$defs/node"}`)
+	newDoc := decode(t, `{"$defs":{"tree":{"properties":{"child":{"$ref":"#/$defs/tree"}},"required":["child"]}},"$ref":"#/$defs/tree"}`)
+
+	changes := Diff(oldDoc, newDoc, Options{})
+	if RequiredBump(changes, PolicyWriters) != BumpMajor {
+		t.Fatalf("expected major bump, got %+v", changes)
+	}
+}
+
+func TestRequiredBump_Policy(t *testing.T) {
+	t.Parallel()
+
+	changes := Diff(decode(t, `{"enum":["a"]}`), decode(t, `{"enum":["a","b"]}`), Options{})
+	if got := RequiredBump(changes, PolicyWriters); got != BumpMinor {
+		t.Fatalf("writers: expected minor, got %s", got)
+	}
+	if got := RequiredBump(changes, PolicyReaders); got != BumpMajor {
+		t.Fatalf("readers: expected major, got %s", got)
+	}
+	if got := RequiredBump(changes, PolicyBoth); got != BumpMajor {
+		t.Fatalf("both: expected major, got %s", got)
+	}
+
+	annotations := Diff(decode(t, `{"title":"a"}`), decode(t, `{"title":"b"}`), Options{})
+	if got := RequiredBump(annotations, PolicyBoth); got != BumpPatch {
+		t.Fatalf("annotations: expected patch, got %s", got)
+	}
+	if got := RequiredBump(nil, PolicyBoth); got != BumpNone {
+		t.Fatalf("no changes: expected none, got %s", got)
+	}
+}
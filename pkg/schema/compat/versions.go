@@ -0,0 +1,351 @@
+package compat
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/schema"
+	"github.com/fulmenhq/goneat/pkg/versioning"
+)
+
+// versionDirPattern matches the schemas/<area>/vX.Y.Z/ directory convention
+var versionDirPattern = regexp.MustCompile(`^v\d+\.\d+\.\d+$`)
+
+// FileStatus describes how a schema file differs between two versions
+type FileStatus string
+
+const (
+	FileAdded     FileStatus = "added"
+	FileRemoved   FileStatus = "removed"
+	FileChanged   FileStatus = "changed"
+	FileUnchanged FileStatus = "unchanged"
+)
+
+// FileReport holds the changes found in one schema file
+type FileReport struct {
+	Path    string     `json:"path"`
+	Status  FileStatus `json:"status"`
+	Changes []Change   `json:"changes,omitempty"`
+}
+
+// CompareOptions configures Compare
+type CompareOptions struct {
+	// RefDirs are scanned for schemas used to resolve absolute $ref URLs.
+	// When comparing directories, each directory is indexed as well.
+	RefDirs []string
+	// Policy selects which changes are breaking (default PolicyWriters)
+	Policy Policy
+	// OldVersion and NewVersion override the versions read from vX.Y.Z directory names
+	OldVersion string
+	NewVersion string
+}
+
+// Report is the result of comparing two schema files or version directories
+type Report struct {
+	Old        string       `json:"old"`
+	New        string       `json:"new"`
+	OldVersion string       `json:"old_version,omitempty"`
+	NewVersion string       `json:"new_version,omitempty"`
+	Policy     Policy       `json:"policy"`
+	Files      []FileReport `json:"files"`
+	// Required is the smallest bump that may carry the changes
+	Required Bump `json:"required_bump"`
+	// Actual is the bump between OldVersion and NewVersion (BumpNone when unversioned)
+	Actual Bump `json:"actual_bump"`
+	// Compliant is false when the versions are known and Actual is smaller than Required
+	Compliant bool `json:"compliant"`
+}
+
+// Versioned reports whether both versions are known
+func (r *Report) Versioned() bool {
+	return r.OldVersion != "" && r.NewVersion != ""
+}
+
+// Changes returns every change across files, in file order
+func (r *Report) Changes() []Change {
+	var changes []Change
+	for _, file := range r.Files {
+		changes = append(changes, file.Changes...)
+	}
+	return changes
+}
+
+// Compare diffs two schema files or two version directories. Directory
+// contents are paired by relative path; a schema missing from the new
+// directory is breaking, a new schema is additive.
+func Compare(oldPath, newPath string, opts CompareOptions) (*Report, error) {
+	policy, err := ParsePolicy(string(opts.Policy))
+	if err != nil {
+		return nil, err
+	}
+	oldInfo, err := os.Stat(oldPath)
+	if err != nil {
+		return nil, err
+	}
+	newInfo, err := os.Stat(newPath)
+	if err != nil {
+		return nil, err
+	}
+	if oldInfo.IsDir() != newInfo.IsDir() {
+		return nil, fmt.Errorf("cannot compare a file with a directory: %s, %s", oldPath, newPath)
+	}
+
+	report := &Report{
+		Old:        oldPath,
+		New:        newPath,
+		OldVersion: opts.OldVersion,
+		NewVersion: opts.NewVersion,
+		Policy:     policy,
+	}
+	oldVersionDir, newVersionDir := filepath.Dir(oldPath), filepath.Dir(newPath)
+	if oldInfo.IsDir() {
+		oldVersionDir, newVersionDir = oldPath, newPath
+	}
+	if report.OldVersion == "" && report.NewVersion == "" {
+		report.OldVersion, report.NewVersion = versionFromDir(oldVersionDir), versionFromDir(newVersionDir)
+		if report.OldVersion == "" || report.NewVersion == "" {
+			report.OldVersion, report.NewVersion = "", ""
+		}
+	}
+
+	if oldInfo.IsDir() {
+		err = report.compareDirs(oldPath, newPath, opts.RefDirs)
+	} else {
+		err = report.compareFiles(oldPath, newPath, filepath.Base(newPath), opts.RefDirs, opts.RefDirs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report.Required = RequiredBump(report.Changes(), policy)
+	report.Compliant = true
+	if report.Versioned() {
+		actual, err := VersionBump(report.OldVersion, report.NewVersion)
+		if err != nil {
+			return nil, err
+		}
+		report.Actual = actual
+		report.Compliant = actual >= report.Required
+	}
+	return report, nil
+}
+
+func (r *Report) compareDirs(oldDir, newDir string, refDirs []string) error {
+	oldFiles, err := schemaFiles(oldDir)
+	if err != nil {
+		return err
+	}
+	newFiles, err := schemaFiles(newDir)
+	if err != nil {
+		return err
+	}
+	oldRefDirs := append(append([]string{}, refDirs...), oldDir)
+	newRefDirs := append(append([]string{}, refDirs...), newDir)
+
+	paths := make(map[string]bool)
+	for rel := range oldFiles {
+		paths[rel] = true
+	}
+	for rel := range newFiles {
+		paths[rel] = true
+	}
+	sorted := make([]string, 0, len(paths))
+	for rel := range paths {
+		sorted = append(sorted, rel)
+	}
+	sort.Strings(sorted)
+
+	for _, rel := range sorted {
+		_, inOld := oldFiles[rel]
+		_, inNew := newFiles[rel]
+		switch {
+		case inOld && inNew:
+			if err := r.compareFiles(filepath.Join(oldDir, rel), filepath.Join(newDir, rel), rel, oldRefDirs, newRefDirs); err != nil {
+				return err
+			}
+		case inOld:
+			r.Files = append(r.Files, FileReport{Path: rel, Status: FileRemoved, Changes: []Change{{
+				Path:          "/",
+				Kind:          KindSchemaRemoved,
+				Message:       fmt.Sprintf("schema %s removed", rel),
+				BreaksReaders: true,
+				BreaksWriters: true,
+			}}})
+		default:
+			r.Files = append(r.Files, FileReport{Path: rel, Status: FileAdded, Changes: []Change{{
+				Path:    "/",
+				Kind:    KindSchemaAdded,
+				Message: fmt.Sprintf("schema %s added", rel),
+			}}})
+		}
+	}
+	return nil
+}
+
+func (r *Report) compareFiles(oldFile, newFile, rel string, oldRefDirs, newRefDirs []string) error {
+	oldDoc, err := LoadDocument(oldFile)
+	if err != nil {
+		return err
+	}
+	newDoc, err := LoadDocument(newFile)
+	if err != nil {
+		return err
+	}
+	oldIndex, err := schema.BuildIDIndexFromRefDirs(oldRefDirs)
+	if err != nil {
+		return fmt.Errorf("index old schemas: %w", err)
+	}
+	newIndex, err := schema.BuildIDIndexFromRefDirs(newRefDirs)
+	if err != nil {
+		return fmt.Errorf("index new schemas: %w", err)
+	}
+
+	changes := Diff(oldDoc, newDoc, Options{OldIndex: oldIndex, NewIndex: newIndex})
+	status := FileUnchanged
+	if len(changes) > 0 {
+		status = FileChanged
+	}
+	r.Files = append(r.Files, FileReport{Path: filepath.ToSlash(rel), Status: status, Changes: changes})
+	return nil
+}
+
+// schemaFiles lists JSON and YAML files under dir keyed by slash-separated relative path
+func schemaFiles(dir string) (map[string]bool, error) {
+	files := make(map[string]bool)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json", ".yaml", ".yml":
+		default:
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+func versionFromDir(dir string) string {
+	name := filepath.Base(dir)
+	if versionDirPattern.MatchString(name) {
+		return name
+	}
+	return ""
+}
+
+// VersionBump returns the bump from oldVersion to newVersion. Before 1.0.0 a
+// minor bump may break and a patch bump may add, so within major version 0
+// the bump counts one level higher.
+func VersionBump(oldVersion, newVersion string) (Bump, error) {
+	oldV, err := versioning.ParseLenient(oldVersion)
+	if err != nil {
+		return BumpNone, fmt.Errorf("invalid version %q: %w", oldVersion, err)
+	}
+	newV, err := versioning.ParseLenient(newVersion)
+	if err != nil {
+		return BumpNone, fmt.Errorf("invalid version %q: %w", newVersion, err)
+	}
+	cmp, err := versioning.Compare(versioning.SchemeSemverFull, oldVersion, newVersion)
+	if err != nil {
+		return BumpNone, err
+	}
+	if cmp == versioning.ComparisonGreater {
+		return BumpNone, fmt.Errorf("version %s is older than %s", newVersion, oldVersion)
+	}
+
+	var bump Bump
+	switch {
+	case newV.Major() != oldV.Major():
+		bump = BumpMajor
+	case newV.Minor() != oldV.Minor():
+		bump = BumpMinor
+	case newV.Patch() != oldV.Patch():
+		bump = BumpPatch
+	default:
+		return BumpNone, nil
+	}
+	if oldV.Major() == 0 && newV.Major() == 0 {
+		bump++
+	}
+	return bump, nil
+}
+
+// VersionSeries is the ordered set of vX.Y.Z directories under one parent
+type VersionSeries struct {
+	Dir      string
+	Versions []string
+}
+
+// DiscoverVersionSeries finds every directory under root holding two or more
+// vX.Y.Z subdirectories, ordered by path, with versions in semver order
+func DiscoverVersionSeries(root string) ([]VersionSeries, error) {
+	byParent := make(map[string][]string)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if errors.Is(walkErr, fs.ErrNotExist) && path == root {
+				return fs.SkipAll
+			}
+			return walkErr
+		}
+		if d.IsDir() && path != root && versionDirPattern.MatchString(d.Name()) {
+			parent := filepath.Dir(path)
+			byParent[parent] = append(byParent[parent], d.Name())
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan %s: %w", root, err)
+	}
+
+	var series []VersionSeries
+	for parent, versions := range byParent {
+		if len(versions) < 2 {
+			continue
+		}
+		sort.Slice(versions, func(i, j int) bool {
+			cmp, _ := versioning.Compare(versioning.SchemeSemverFull, versions[i], versions[j])
+			return cmp == versioning.ComparisonLess
+		})
+		series = append(series, VersionSeries{Dir: parent, Versions: versions})
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Dir < series[j].Dir })
+	return series, nil
+}
+
+// CheckVersionSeries compares each pair of consecutive versions in a series
+func CheckVersionSeries(series VersionSeries, opts CompareOptions) ([]*Report, error) {
+	var reports []*Report
+	for i := 1; i < len(series.Versions); i++ {
+		pairOpts := opts
+		pairOpts.OldVersion, pairOpts.NewVersion = "", ""
+		report, err := Compare(
+			filepath.Join(series.Dir, series.Versions[i-1]),
+			filepath.Join(series.Dir, series.Versions[i]),
+			pairOpts,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
@@ -0,0 +1,146 @@
+package compat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSchema(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVersionBump(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		old, new string
+		want     Bump
+	}{
+		{"v1.0.0", "v2.0.0", BumpMajor},
+		{"v1.0.0", "v1.1.0", BumpMinor},
+		{"v1.1.0", "v1.1.1", BumpPatch},
+		{"v1.1.0", "v1.1.0", BumpNone},
+		{"v0.1.0", "v0.2.0", BumpMajor},
+		{"v0.1.0", "v0.1.1", BumpMinor},
+	}
+	for _, tt := range tests {
+		got, err := VersionBump(tt.old, tt.new)
+		if err != nil {
+			t.Fatalf("VersionBump(%s, %s): %v", tt.old, tt.new, err)
+		}
+		if got != tt.want {
+			t.Errorf("VersionBump(%s, %s) = %s, want %s", tt.old, tt.new, got, tt.want)
+		}
+	}
+
+	if _, err := VersionBump("v2.0.0", "v1.0.0"); err == nil {
+		t.Fatal("expected error for a downgrade")
+	}
+}
+
+func TestCompare_Directories(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	area := filepath.Join(root, "schemas", "widgets")
+	writeSchema(t, filepath.Join(area, "v1.0.0", "widget.json"), `{"type":"object","properties":{"name":{"type":"string"}}}`)
+	writeSchema(t, filepath.Join(area, "v1.0.0", "legacy.json"), `{"type":"object"}`)
+	writeSchema(t, filepath.Join(area, "v1.1.0", "widget.json"), `{"type":"object","properties":{"name":{"type":"string"}},"required":["name"]}`)
+	writeSchema(t, filepath.Join(area, "v1.1.0", "legacy.json"), `{"type":"object"}`)
+	writeSchema(t, filepath.Join(area, "v1.1.0", "gadget.json"), `{"type":"object"}`)
+
+	report, err := Compare(filepath.Join(area, "v1.0.0"), filepath.Join(area, "v1.1.0"), CompareOptions{})
+	if err != nil {
+		t.Fatalf("Compare: %v", err)
+	}
+	if report.Required != BumpMajor || report.Actual != BumpMinor || report.Compliant {
+		t.Fatalf("expected a non-compliant minor bump, got required=%s actual=%s compliant=%v", report.Required, report.Actual, report.Compliant)
+	}
+	statuses := map[string]FileStatus{}
+	for _, f := range report.Files {
+		statuses[f.Path] = f.Status
+	}
+	if statuses["gadget.json"] != FileAdded || statuses["legacy.json"] != FileUnchanged || statuses["widget.json"] != FileChanged {
+		t.Fatalf("unexpected file statuses: %+v", statuses)
+	}
+
+	files, err := Compare(
+		filepath.Join(area, "v1.0.0", "widget.json"),
+		filepath.Join(area, "v1.1.0", "widget.json"),
+		CompareOptions{Policy: PolicyReaders},
+	)
+	if err != nil {
+		t.Fatalf("Compare files: %v", err)
+	}
+	if files.OldVersion != "v1.0.0" || files.NewVersion != "v1.1.0" {
+		t.Fatalf("expected versions from parent directories, got %s -> %s", files.OldVersion, files.NewVersion)
+	}
+	if !files.Compliant || files.Required != BumpMinor {
+		t.Fatalf("readers policy: expected compliant minor, got required=%s compliant=%v", files.Required, files.Compliant)
+	}
+}
+
+func TestCompare_RemovedSchemaIsBreaking(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeSchema(t, filepath.Join(root, "v1.0.0", "a.json"), `{"type":"string"}`)
+	writeSchema(t, filepath.Join(root, "v1.0.0", "b.json"), `{"type":"string"}`)
+	writeSchema(t, filepath.Join(root, "v2.0.0", "a.json"), `{"type":"string"}`)
+
+	report, err := Compare(filepath.Join(root, "v1.0.0"), filepath.Join(root, "v2.0.0"), CompareOptions{})
+	if err != nil {
+		t.Fatalf("Compare: %v", err)
+	}
+	if report.Required != BumpMajor || !report.Compliant {
+		t.Fatalf("expected a compliant major bump, got required=%s compliant=%v", report.Required, report.Compliant)
+	}
+}
+
+func TestDiscoverVersionSeries(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for _, dir := range []string{"a/v1.0.0", "a/v1.10.0", "a/v1.2.0", "b/v1.0.0", "c/v0"} {
+		writeSchema(t, filepath.Join(root, dir, "s.json"), `{}`)
+	}
+
+	series, err := DiscoverVersionSeries(root)
+	if err != nil {
+		t.Fatalf("DiscoverVersionSeries: %v", err)
+	}
+	if len(series) != 1 {
+		t.Fatalf("expected one series, got %+v", series)
+	}
+	want := []string{"v1.0.0", "v1.2.0", "v1.10.0"}
+	for i, v := range want {
+		if series[0].Versions[i] != v {
+			t.Fatalf("expected %v, got %v", want, series[0].Versions)
+		}
+	}
+
+	reports, err := CheckVersionSeries(series[0], CompareOptions{})
+	if err != nil {
+		t.Fatalf("CheckVersionSeries: %v", err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("expected 2 reports, got %d", len(reports))
+	}
+	for _, r := range reports {
+		if !r.Compliant || r.Required != BumpNone {
+			t.Fatalf("identical versions should be compliant with no bump: %+v", r)
+		}
+	}
+
+	missing, err := DiscoverVersionSeries(filepath.Join(root, "missing"))
+	if err != nil || len(missing) != 0 {
+		t.Fatalf("expected no series for a missing root, got %v, %v", missing, err)
+	}
+}
@@ -0,0 +1,185 @@
+package mapping
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/config"
+	"github.com/fulmenhq/goneat/pkg/schema/signature"
+)
+
+// InferenceCacheFileName is the cache file created under $GONEAT_HOME/cache.
+const InferenceCacheFileName = "schema-inferences.json"
+
+// Inference is one signature detected in a file's content.
+type Inference struct {
+	SignatureID string  `json:"signature_id"`
+	Category    string  `json:"category,omitempty"`
+	Score       float64 `json:"score"`
+}
+
+// InferenceCache persists content-based inferences keyed by file hash so
+// repeated runs skip signature detection for unchanged files. Entries are
+// discarded when the signature manifest changes.
+type InferenceCache struct {
+	path        string
+	fingerprint string
+	mu          sync.Mutex
+	entries     map[string]inferenceCacheEntry
+	dirty       bool
+	hits        int
+	misses      int
+}
+
+type inferenceCacheEntry struct {
+	Inferences []Inference `json:"inferences"`
+	DetectedAt time.Time   `json:"detected_at"`
+}
+
+type inferenceCacheFile struct {
+	Fingerprint string                         `json:"fingerprint"`
+	Entries     map[string]inferenceCacheEntry `json:"entries"`
+}
+
+// DefaultInferenceCachePath returns $GONEAT_HOME/cache/schema-inferences.json.
+func DefaultInferenceCachePath() (string, error) {
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, InferenceCacheFileName), nil
+}
+
+// ManifestFingerprint hashes the signature manifest so cached inferences are
+// invalidated when signatures change.
+func ManifestFingerprint(manifest *signature.Manifest) string {
+	data, _ := json.Marshal(manifest)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadInferenceCache opens the cache at path. A missing, unreadable or
+// outdated cache starts empty.
+func LoadInferenceCache(path, fingerprint string) (*InferenceCache, error) {
+	cache := &InferenceCache{
+		path:        path,
+		fingerprint: fingerprint,
+		entries:     make(map[string]inferenceCacheEntry),
+	}
+	data, err := os.ReadFile(filepath.Clean(path)) // #nosec G304 -- cache path is goneat-owned or chosen by the caller
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return cache, nil
+		}
+		return nil, fmt.Errorf("read inference cache %s: %w", path, err)
+	}
+	var file inferenceCacheFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Fingerprint != fingerprint {
+		// Corrupt or outdated caches are rebuilt on the next Save
+		cache.dirty = true
+		return cache, nil
+	}
+	for key, entry := range file.Entries {
+		cache.entries[key] = entry
+	}
+	return cache, nil
+}
+
+// InferenceKey identifies a file by content hash and extension; signatures
+// may be restricted to certain extensions.
+func InferenceKey(path string, content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]) + strings.ToLower(filepath.Ext(path))
+}
+
+// Get returns the cached inferences for key.
+func (c *InferenceCache) Get(key string) ([]Inference, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if ok {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	return entry.Inferences, ok
+}
+
+// Put records the inferences detected for key.
+func (c *InferenceCache) Put(key string, inferences []Inference) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if inferences == nil {
+		inferences = []Inference{}
+	}
+	c.entries[key] = inferenceCacheEntry{Inferences: inferences, DetectedAt: time.Now().UTC()}
+	c.dirty = true
+}
+
+// Stats returns the cache hits and misses since the cache was loaded.
+func (c *InferenceCache) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// Path returns the cache file location.
+func (c *InferenceCache) Path() string {
+	return c.path
+}
+
+// Save writes the cache if it changed.
+func (c *InferenceCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+	data, err := json.MarshalIndent(inferenceCacheFile{Fingerprint: c.fingerprint, Entries: c.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode inference cache: %w", err)
+	}
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("create inference cache directory: %w", err)
+	}
+	// Write atomically so concurrent goneat processes never read partial caches
+	tmp, err := os.CreateTemp(dir, ".schema-inferences-*")
+	if err != nil {
+		return fmt.Errorf("create inference cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write inference cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write inference cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("write inference cache: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
+
+// inferencesFromMatches converts detector matches, best first.
+func inferencesFromMatches(matches []signature.Match) []Inference {
+	out := make([]Inference, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, Inference{SignatureID: m.Signature.ID, Category: m.Signature.Category, Score: m.Score})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	return out
+}
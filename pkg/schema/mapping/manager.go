@@ -87,7 +87,9 @@ func (m *Manager) Load(opts LoadOptions) (*LoadResult, error) {
 	return result, nil
 }
 
-func (m *Manager) loadRepositoryManifest(opts LoadOptions) (*Manifest, string, []Diagnostic, error) {
+// ManifestPath returns the repository manifest location for opts, rejecting
+// paths outside the repository root.
+func ManifestPath(opts LoadOptions) (string, error) {
 	repoRoot := opts.RepoRoot
 	if repoRoot == "" {
 		repoRoot = "."
@@ -99,11 +101,31 @@ func (m *Manager) loadRepositoryManifest(opts LoadOptions) (*Manifest, string, [
 
 	sanitizedRel, err := safeio.CleanUserPath(manifestRel)
 	if err != nil {
-		return nil, "", nil, fmt.Errorf("invalid manifest path %q: %w", manifestRel, err)
+		return "", fmt.Errorf("invalid manifest path %q: %w", manifestRel, err)
 	}
 
 	manifestPath := filepath.Join(repoRoot, sanitizedRel)
 	if err := ensureWithinRepo(repoRoot, manifestPath); err != nil {
+		return "", err
+	}
+	return manifestPath, nil
+}
+
+// Validate checks manifest data against the embedded manifest schema.
+func (m *Manager) Validate(data []byte) error {
+	validation, err := m.validator.ValidateBytes(data)
+	if err != nil {
+		return err
+	}
+	if !validation.Valid {
+		return fmt.Errorf("failed validation: %s", flattenValidationErrors(validation))
+	}
+	return nil
+}
+
+func (m *Manager) loadRepositoryManifest(opts LoadOptions) (*Manifest, string, []Diagnostic, error) {
+	manifestPath, err := ManifestPath(opts)
+	if err != nil {
 		return nil, "", nil, err
 	}
 
@@ -119,12 +141,8 @@ func (m *Manager) loadRepositoryManifest(opts LoadOptions) (*Manifest, string, [
 		return nil, "", nil, fmt.Errorf("read manifest %s: %w", manifestPath, err)
 	}
 
-	validation, err := m.validator.ValidateBytes(data)
-	if err != nil {
-		return nil, manifestPath, nil, fmt.Errorf("validate manifest %s: %w", manifestPath, err)
-	}
-	if !validation.Valid {
-		return nil, manifestPath, nil, fmt.Errorf("manifest %s failed validation: %s", manifestPath, flattenValidationErrors(validation))
+	if err := m.Validate(data); err != nil {
+		return nil, manifestPath, nil, fmt.Errorf("manifest %s: %w", manifestPath, err)
 	}
 
 	var manifest Manifest
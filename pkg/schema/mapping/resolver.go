@@ -19,10 +19,10 @@ type Resolution struct {
 
 // Metrics captures aggregate statistics while resolving mappings.
 type Metrics struct {
-	FilesEvaluated int
-	Mapped         int
-	Unmapped       int
-	Excluded       int
+	FilesEvaluated int `json:"files_evaluated"`
+	Mapped         int `json:"mapped"`
+	Unmapped       int `json:"unmapped"`
+	Excluded       int `json:"excluded"`
 }
 
 // Resolver applies manifest rules to file paths.
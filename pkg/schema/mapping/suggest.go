@@ -0,0 +1,359 @@
+package mapping
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/schema"
+	"github.com/fulmenhq/goneat/pkg/schema/signature"
+	"gopkg.in/yaml.v3"
+)
+
+// Suggestion proposes a mapping or exclusion rule for unmapped files.
+type Suggestion struct {
+	Mapping     *MappingRule   `json:"mapping,omitempty"`
+	Exclusion   *ExclusionRule `json:"exclusion,omitempty"`
+	SignatureID string         `json:"signature_id"`
+	// Confidence is the lowest detection score among Files
+	Confidence float64  `json:"confidence"`
+	Files      []string `json:"files"`
+}
+
+// Pattern returns the pattern of the proposed rule.
+func (s Suggestion) Pattern() string {
+	if s.Mapping != nil {
+		return s.Mapping.Pattern
+	}
+	if s.Exclusion != nil {
+		return s.Exclusion.Pattern
+	}
+	return ""
+}
+
+// SuggestOptions configures Suggest.
+type SuggestOptions struct {
+	// RepoRoot is the directory Files are relative to.
+	RepoRoot string
+	// Files are repository-relative candidate paths.
+	Files []string
+	// Detector runs content-based inference on unmapped files.
+	Detector *signature.Detector
+	// Cache, when set, serves inferences for unchanged files.
+	Cache *InferenceCache
+}
+
+// SuggestionReport holds ranked suggestions and the resolution metrics of the run.
+type SuggestionReport struct {
+	Suggestions []Suggestion `json:"suggestions"`
+	Metrics     Metrics      `json:"metrics"`
+	// Undetected lists unmapped files no signature matched.
+	Undetected []string `json:"undetected,omitempty"`
+	// BelowConfidence counts suggestions dropped by min_confidence.
+	BelowConfidence int `json:"below_confidence"`
+	// Truncated counts suggestions dropped by max_suggestions.
+	Truncated   int `json:"truncated"`
+	CacheHits   int `json:"cache_hits"`
+	CacheMisses int `json:"cache_misses"`
+}
+
+// Patch returns a manifest fragment holding the suggested rules.
+func (r *SuggestionReport) Patch() Manifest {
+	patch := Manifest{Version: ManifestVersionV1}
+	for _, s := range r.Suggestions {
+		if s.Mapping != nil {
+			patch.Mappings = append(patch.Mappings, *s.Mapping)
+		}
+		if s.Exclusion != nil {
+			patch.Exclusions = append(patch.Exclusions, *s.Exclusion)
+		}
+	}
+	return patch
+}
+
+// inferred is the best detection for one unmapped file.
+type inferred struct {
+	file      string
+	inference Inference
+	// validatable is true when the signature names an embedded schema
+	validatable bool
+}
+
+// Suggest resolves opts.Files against manifest and proposes rules for the
+// files no rule maps. Unmapped files whose best signature names an embedded
+// schema get a MappingRule; other detected documents get an ExclusionRule
+// when config.auto_suggest_exclusions is enabled. Files in one directory that
+// share an extension and a proposal are grouped under a glob. Suggestions
+// below config.min_confidence are dropped and the rest ranked by confidence
+// and capped at config.max_suggestions.
+func Suggest(manifest Manifest, opts SuggestOptions) (*SuggestionReport, error) {
+	cfg := manifest.Config
+	resolver := NewResolver(manifest)
+	report := &SuggestionReport{Suggestions: []Suggestion{}}
+
+	files := append([]string(nil), opts.Files...)
+	for i, f := range files {
+		files[i] = filepath.ToSlash(strings.TrimPrefix(f, "./"))
+	}
+	sort.Strings(files)
+
+	var unmapped []inferred
+	resolved := make(map[string]bool, len(files))
+	for _, file := range files {
+		if _, ok := resolver.Resolve(file); ok {
+			resolved[file] = true
+			continue
+		}
+		inference, ok, err := inferFile(opts, file)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			report.Undetected = append(report.Undetected, file)
+			continue
+		}
+		_, embeddedErr := schema.GetEmbeddedValidator(inference.SignatureID)
+		unmapped = append(unmapped, inferred{file: file, inference: inference, validatable: embeddedErr == nil})
+	}
+	report.Metrics = resolver.Metrics()
+	if opts.Cache != nil {
+		report.CacheHits, report.CacheMisses = opts.Cache.Stats()
+	}
+
+	existing := make(map[string]bool)
+	for _, rule := range manifest.Mappings {
+		existing[rule.Pattern] = true
+	}
+	for _, rule := range manifest.Exclusions {
+		existing[rule.Pattern] = true
+	}
+
+	suggestExclusions := cfg.AutoSuggestExclusions != nil && *cfg.AutoSuggestExclusions
+	minConfidence := 0.0
+	if cfg.MinConfidence != nil {
+		minConfidence = *cfg.MinConfidence
+	}
+
+	for _, s := range groupSuggestions(unmapped, files, resolved) {
+		if s.Exclusion != nil && !suggestExclusions {
+			continue
+		}
+		if existing[s.Pattern()] {
+			continue
+		}
+		if s.Confidence < minConfidence {
+			report.BelowConfidence++
+			continue
+		}
+		report.Suggestions = append(report.Suggestions, s)
+	}
+
+	sort.SliceStable(report.Suggestions, func(i, j int) bool {
+		a, b := report.Suggestions[i], report.Suggestions[j]
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		return a.Pattern() < b.Pattern()
+	})
+	if cfg.MaxSuggestions != nil && *cfg.MaxSuggestions > 0 && len(report.Suggestions) > *cfg.MaxSuggestions {
+		report.Truncated = len(report.Suggestions) - *cfg.MaxSuggestions
+		report.Suggestions = report.Suggestions[:*cfg.MaxSuggestions]
+	}
+	return report, nil
+}
+
+// inferFile returns the best signature detected in file, consulting the
+// cache when config allows
+func inferFile(opts SuggestOptions, file string) (Inference, bool, error) {
+	fullPath := filepath.Join(opts.RepoRoot, filepath.FromSlash(file))
+	content, err := os.ReadFile(filepath.Clean(fullPath)) // #nosec G304 -- candidate files come from repository discovery
+	if err != nil {
+		return Inference{}, false, fmt.Errorf("read %s: %w", file, err)
+	}
+
+	var inferences []Inference
+	key := InferenceKey(file, content)
+	cached := false
+	if opts.Cache != nil {
+		inferences, cached = opts.Cache.Get(key)
+	}
+	if !cached {
+		if opts.Detector != nil {
+			inferences = inferencesFromMatches(opts.Detector.DetectAll(file, content, signature.DetectOptions{}))
+		}
+		if opts.Cache != nil {
+			opts.Cache.Put(key, inferences)
+		}
+	}
+	if len(inferences) == 0 {
+		return Inference{}, false, nil
+	}
+	return inferences[0], true, nil
+}
+
+// groupSuggestions builds one suggestion per file, or one glob per directory
+// and extension when every candidate there is unmapped and gets the same proposal
+func groupSuggestions(unmapped []inferred, files []string, resolved map[string]bool) []Suggestion {
+	type groupKey struct{ dir, ext string }
+	proposals := make(map[groupKey]map[string][]inferred)
+	for _, u := range unmapped {
+		key := groupKey{path.Dir(u.file), strings.ToLower(path.Ext(u.file))}
+		if proposals[key] == nil {
+			proposals[key] = make(map[string][]inferred)
+		}
+		id := proposalID(u)
+		proposals[key][id] = append(proposals[key][id], u)
+	}
+	candidates := make(map[groupKey]int)
+	for _, file := range files {
+		candidates[groupKey{path.Dir(file), strings.ToLower(path.Ext(file))}]++
+	}
+
+	var out []Suggestion
+	for key, byProposal := range proposals {
+		for _, members := range byProposal {
+			if len(byProposal) == 1 && len(members) > 1 && len(members) == candidates[key] {
+				pattern := "*" + key.ext
+				if key.dir != "." {
+					pattern = key.dir + "/" + pattern
+				}
+				out = append(out, newSuggestion(pattern, members))
+				continue
+			}
+			for _, m := range members {
+				out = append(out, newSuggestion(m.file, []inferred{m}))
+			}
+		}
+	}
+	return out
+}
+
+func proposalID(u inferred) string {
+	return fmt.Sprintf("%s|%t", u.inference.SignatureID, u.validatable)
+}
+
+func newSuggestion(pattern string, members []inferred) Suggestion {
+	first := members[0]
+	s := Suggestion{SignatureID: first.inference.SignatureID, Confidence: first.inference.Score}
+	for _, m := range members {
+		s.Files = append(s.Files, m.file)
+		if m.inference.Score < s.Confidence {
+			s.Confidence = m.inference.Score
+		}
+	}
+	if first.validatable {
+		s.Mapping = &MappingRule{
+			Pattern:  pattern,
+			SchemaID: first.inference.SignatureID,
+			Source:   SourceEmbedded,
+		}
+		return s
+	}
+	category := first.inference.Category
+	if category == "" {
+		category = first.inference.SignatureID
+	}
+	s.Exclusion = &ExclusionRule{
+		Pattern: pattern,
+		Reason:  fmt.Sprintf("%s document (%s), not validated as data", category, first.inference.SignatureID),
+		Action:  ExclusionSkip,
+	}
+	return s
+}
+
+// MergeSuggestions appends the suggested rules to a schema mapping manifest,
+// keeping its existing content and comments. Rules whose pattern the
+// manifest already declares are skipped. Empty data starts a new manifest.
+func MergeSuggestions(data []byte, suggestions []Suggestion) ([]byte, int, error) {
+	var doc yaml.Node
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, 0, fmt.Errorf("parse manifest: %w", err)
+		}
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, 0, fmt.Errorf("manifest root must be a mapping")
+	}
+	root := doc.Content[0]
+	if mappingValue(root, "version") == nil {
+		root.Content = append([]*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: "version"},
+			{Kind: yaml.ScalarNode, Value: ManifestVersionV1, Style: yaml.DoubleQuotedStyle},
+		}, root.Content...)
+	}
+
+	var current Manifest
+	if err := root.Decode(&current); err != nil {
+		return nil, 0, fmt.Errorf("decode manifest: %w", err)
+	}
+	declared := make(map[string]bool)
+	for _, rule := range current.Mappings {
+		declared[rule.Pattern] = true
+	}
+	for _, rule := range current.Exclusions {
+		declared[rule.Pattern] = true
+	}
+
+	added := 0
+	for _, s := range suggestions {
+		if declared[s.Pattern()] {
+			continue
+		}
+		var rule any = s.Mapping
+		section := "mappings"
+		if s.Mapping == nil {
+			rule, section = s.Exclusion, "exclusions"
+		}
+		var node yaml.Node
+		if err := node.Encode(rule); err != nil {
+			return nil, 0, fmt.Errorf("encode rule for %s: %w", s.Pattern(), err)
+		}
+		node.HeadComment = fmt.Sprintf("suggested: %s (confidence %.2f, %d file(s))", s.SignatureID, s.Confidence, len(s.Files))
+		seq := ensureSequence(root, section)
+		seq.Content = append(seq.Content, &node)
+		declared[s.Pattern()] = true
+		added++
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, 0, fmt.Errorf("encode manifest: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, 0, fmt.Errorf("encode manifest: %w", err)
+	}
+	return buf.Bytes(), added, nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func ensureSequence(root *yaml.Node, key string) *yaml.Node {
+	if value := mappingValue(root, key); value != nil {
+		if value.Kind != yaml.SequenceNode {
+			// an empty "mappings:" decodes as a null scalar
+			*value = yaml.Node{Kind: yaml.SequenceNode}
+		}
+		// appended rules read better in block style
+		value.Style = 0
+		return value
+	}
+	seq := &yaml.Node{Kind: yaml.SequenceNode}
+	root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, seq)
+	return seq
+}
@@ -0,0 +1,188 @@
+package mapping
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fulmenhq/goneat/pkg/schema/signature"
+	"gopkg.in/yaml.v3"
+)
+
+const draft07Schema = `{"$schema":"http://json-schema.org/draft-07/schema#","type":"object"}`
+
+func writeRepoFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for rel, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func newTestDetector(t *testing.T) (*signature.Detector, *signature.Manifest) {
+	t.Helper()
+	manifest, err := signature.LoadDefaultManifest()
+	if err != nil {
+		t.Fatalf("load signatures: %v", err)
+	}
+	detector, err := signature.NewDetector(manifest)
+	if err != nil {
+		t.Fatalf("init detector: %v", err)
+	}
+	return detector, manifest
+}
+
+func TestSuggestGroupsAndRanks(t *testing.T) {
+	files := map[string]string{
+		"schemas/a.json":   draft07Schema,
+		"schemas/b.json":   strings.Replace(draft07Schema, "object", "string", 1),
+		"mixed/one.json":   draft07Schema,
+		"mixed/other.json": `{"name":"plain data"}`,
+		"api/openapi.yaml": "openapi: 3.0.3\ninfo:\n  title: t\n  version: \"1\"\npaths: {}\n",
+		"config/app.yaml":  "name: mapped\n",
+	}
+	root := writeRepoFiles(t, files)
+	detector, _ := newTestDetector(t)
+
+	manifest := Manifest{
+		Version:  ManifestVersionV1,
+		Config:   ConfigSettings{MinConfidence: floatPtr(0.5), AutoSuggestExclusions: boolPtr(true)},
+		Mappings: []MappingRule{{Pattern: "config/app.yaml", SchemaID: "app", Source: SourceEmbedded}},
+	}
+	var paths []string
+	for rel := range files {
+		paths = append(paths, rel)
+	}
+
+	report, err := Suggest(manifest, SuggestOptions{RepoRoot: root, Files: paths, Detector: detector})
+	if err != nil {
+		t.Fatalf("Suggest: %v", err)
+	}
+	if report.Metrics.Mapped != 1 || report.Metrics.Unmapped != 5 {
+		t.Fatalf("unexpected metrics: %+v", report.Metrics)
+	}
+	if len(report.Undetected) != 1 || report.Undetected[0] != "mixed/other.json" {
+		t.Fatalf("unexpected undetected files: %v", report.Undetected)
+	}
+
+	var patterns []string
+	for _, s := range report.Suggestions {
+		patterns = append(patterns, s.Pattern())
+	}
+	want := []string{"mixed/one.json", "schemas/*.json", "api/openapi.yaml"}
+	if strings.Join(patterns, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected suggestions %v, got %v", want, patterns)
+	}
+	if m := report.Suggestions[1].Mapping; m == nil || m.SchemaID != "json-schema-draft-07" || len(report.Suggestions[1].Files) != 2 {
+		t.Fatalf("expected grouped draft-07 mapping, got %+v", report.Suggestions[1])
+	}
+	if report.Suggestions[2].Exclusion == nil || report.Suggestions[2].Exclusion.Action != ExclusionSkip {
+		t.Fatalf("expected OpenAPI exclusion, got %+v", report.Suggestions[2])
+	}
+
+	// Builtin-like settings drop the exclusion and cap the rest
+	maxSuggestions := 1
+	manifest.Config = ConfigSettings{MinConfidence: floatPtr(0.75), MaxSuggestions: &maxSuggestions}
+	report, err = Suggest(manifest, SuggestOptions{RepoRoot: root, Files: paths, Detector: detector})
+	if err != nil {
+		t.Fatalf("Suggest: %v", err)
+	}
+	if len(report.Suggestions) != 1 || report.Truncated != 1 || report.BelowConfidence != 0 {
+		t.Fatalf("expected one suggestion after truncation, got %+v", report)
+	}
+}
+
+func TestSuggestUsesInferenceCache(t *testing.T) {
+	root := writeRepoFiles(t, map[string]string{"a.json": draft07Schema, "b.json": draft07Schema + "\n"})
+	detector, sigs := newTestDetector(t)
+	cachePath := filepath.Join(t.TempDir(), InferenceCacheFileName)
+	manifest := Manifest{Version: ManifestVersionV1}
+	files := []string{"a.json", "b.json"}
+
+	cache, err := LoadInferenceCache(cachePath, ManifestFingerprint(sigs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := Suggest(manifest, SuggestOptions{RepoRoot: root, Files: files, Detector: detector, Cache: cache})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.CacheHits != 0 || report.CacheMisses != 2 {
+		t.Fatalf("expected cold cache, got %d hits %d misses", report.CacheHits, report.CacheMisses)
+	}
+	if err := cache.Save(); err != nil {
+		t.Fatalf("save cache: %v", err)
+	}
+
+	// A reloaded cache answers without the detector
+	cache, err = LoadInferenceCache(cachePath, ManifestFingerprint(sigs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err = Suggest(manifest, SuggestOptions{RepoRoot: root, Files: files, Cache: cache})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.CacheHits != 2 || len(report.Suggestions) != 1 || report.Suggestions[0].Pattern() != "*.json" {
+		t.Fatalf("expected cached inferences, got %+v", report)
+	}
+
+	// A different signature manifest discards the entries
+	cache, err = LoadInferenceCache(cachePath, "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get(InferenceKey("a.json", []byte(draft07Schema))); ok {
+		t.Fatalf("expected outdated cache to start empty")
+	}
+}
+
+func TestMergeSuggestionsKeepsExistingRules(t *testing.T) {
+	existing := []byte(`# team mappings
+version: "1.0.0"
+mappings:
+  # keep me
+  - pattern: config/app.yaml
+    schema_id: app
+    source: embedded
+`)
+	suggestions := []Suggestion{
+		{Mapping: &MappingRule{Pattern: "config/app.yaml", SchemaID: "other", Source: SourceEmbedded}, SignatureID: "other", Confidence: 1},
+		{Mapping: &MappingRule{Pattern: "schemas/*.json", SchemaID: "json-schema-draft-07", Source: SourceEmbedded}, SignatureID: "json-schema-draft-07", Confidence: 1, Files: []string{"schemas/a.json"}},
+		{Exclusion: &ExclusionRule{Pattern: "api/openapi.yaml", Reason: "api", Action: ExclusionSkip}, SignatureID: "openapi-3", Confidence: 0.7},
+	}
+
+	merged, added, err := MergeSuggestions(existing, suggestions)
+	if err != nil {
+		t.Fatalf("MergeSuggestions: %v", err)
+	}
+	if added != 2 {
+		t.Fatalf("expected 2 rules added, got %d", added)
+	}
+	out := string(merged)
+	for _, want := range []string{"# team mappings", "# keep me", "# suggested: json-schema-draft-07 (confidence 1.00, 1 file(s))", "exclusions:"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in merged manifest:\n%s", want, out)
+		}
+	}
+	var manifest Manifest
+	if err := yaml.Unmarshal(merged, &manifest); err != nil {
+		t.Fatalf("merged manifest does not parse: %v", err)
+	}
+	if len(manifest.Mappings) != 2 || manifest.Mappings[0].SchemaID != "app" || len(manifest.Exclusions) != 1 {
+		t.Fatalf("unexpected merged manifest: %+v", manifest)
+	}
+
+	fresh, added, err := MergeSuggestions(nil, suggestions[1:2])
+	if err != nil || added != 1 || !strings.HasPrefix(string(fresh), `version: "1.0.0"`) {
+		t.Fatalf("expected a new manifest, got %d %v:\n%s", added, err, fresh)
+	}
+}
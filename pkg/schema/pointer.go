@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"strconv"
+	"strings"
+)
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// JoinPointer appends reference tokens to a JSON pointer (RFC 6901), escaping
+// "~" and "/" in each token. The empty pointer addresses the whole document.
+func JoinPointer(pointer string, tokens ...string) string {
+	for _, token := range tokens {
+		pointer += "/" + pointerEscaper.Replace(token)
+	}
+	return pointer
+}
+
+// SplitPointer returns the unescaped reference tokens of a JSON pointer. A
+// leading "#" (the URI fragment form) is ignored.
+func SplitPointer(pointer string) []string {
+	pointer = strings.TrimPrefix(pointer, "#")
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = pointerUnescaper.Replace(token)
+	}
+	return tokens
+}
+
+// ResolvePointer returns the value a JSON pointer addresses in a decoded JSON
+// document (maps and slices). Plain-name fragments are not resolved.
+func ResolvePointer(doc any, pointer string) (any, bool) {
+	pointer = strings.TrimPrefix(pointer, "#")
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+	node := doc
+	for _, token := range SplitPointer(pointer) {
+		switch v := node.(type) {
+		case map[string]any:
+			next, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+			node = next
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			node = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
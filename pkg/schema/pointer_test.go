@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJSONPointers(t *testing.T) {
+	pointer := JoinPointer("", "paths", "/pets/{id}", "a~b")
+	if pointer != "/paths/~1pets~1{id}/a~0b" {
+		t.Fatalf("JoinPointer() = %q", pointer)
+	}
+	if got := SplitPointer("#" + pointer); !reflect.DeepEqual(got, []string{"paths", "/pets/{id}", "a~b"}) {
+		t.Errorf("SplitPointer() = %q", got)
+	}
+	if got := SplitPointer(""); got != nil {
+		t.Errorf("SplitPointer(\"\") = %q, want nil", got)
+	}
+
+	doc := map[string]any{
+		"$defs": map[string]any{"a/b": map[string]any{"enum": []any{"x", "y"}}},
+	}
+	cases := []struct {
+		pointer string
+		want    any
+		ok      bool
+	}{
+		{"", doc, true},
+		{"/$defs/a~1b/enum/1", "y", true},
+		{"#/$defs/a~1b/enum/0", "x", true},
+		{"/$defs/a~1b/enum/2", nil, false},
+		{"/$defs/missing", nil, false},
+		{"anchor", nil, false},
+	}
+	for _, tc := range cases {
+		got, ok := ResolvePointer(doc, tc.pointer)
+		if ok != tc.ok || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ResolvePointer(%q) = %v, %v", tc.pointer, got, ok)
+		}
+	}
+}
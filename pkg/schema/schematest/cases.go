@@ -0,0 +1,277 @@
+package schematest
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// invalidExamplesKeyword holds inline invalid cases in a schema
+const invalidExamplesKeyword = "x-invalid-examples"
+
+var sidecarSuffixes = []string{".expect.yaml", ".expect.yml", ".expect.json"}
+
+// Discover expands paths into schema files. Files are returned as given;
+// directories are searched for schemas that have at least one case.
+func Discover(paths []string) ([]string, error) {
+	var schemas []string
+	seen := make(map[string]bool)
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			schemas = append(schemas, path)
+		}
+	}
+
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, fmt.Errorf("schema path %s: %w", root, err)
+		}
+		if !info.IsDir() {
+			add(root)
+			continue
+		}
+		var found []string
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			if d.IsDir() {
+				if path != root && skipDir(d.Name()) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !isDataFile(path) || isSidecar(path) || inCaseDir(path) {
+				return nil
+			}
+			doc, ok := loadSchema(path)
+			if !ok {
+				return nil
+			}
+			cases, err := Cases(path, doc)
+			if err == nil && len(cases) > 0 {
+				found = append(found, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scan %s: %w", root, err)
+		}
+		sort.Strings(found)
+		for _, path := range found {
+			add(path)
+		}
+	}
+	return schemas, nil
+}
+
+// Cases lists the cases of the schema at path whose decoded document is doc.
+// Cases that cannot be read are returned and fail when run.
+func Cases(path string, doc any) ([]Case, error) {
+	var cases []Case
+	base, err := examplesDir(path)
+	if err != nil {
+		return nil, err
+	}
+	if base != "" {
+		for _, kind := range []Kind{KindValid, KindInvalid} {
+			fileCases, err := caseFiles(base, kind)
+			if err != nil {
+				return nil, err
+			}
+			cases = append(cases, fileCases...)
+		}
+	}
+	return append(cases, inlineCases(path, doc)...), nil
+}
+
+// examplesDir finds the directory holding valid/ and invalid/ for a schema
+func examplesDir(path string) (string, error) {
+	dir := filepath.Dir(path)
+	if own := filepath.Join(dir, "examples", schemaStem(path)); isDir(own) {
+		return own, nil
+	}
+	shared := filepath.Join(dir, "examples")
+	if !isDir(filepath.Join(shared, string(KindValid))) && !isDir(filepath.Join(shared, string(KindInvalid))) {
+		return "", nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		other := filepath.Join(dir, entry.Name())
+		if entry.IsDir() || other == path || !isDataFile(other) || isSidecar(other) {
+			continue
+		}
+		if _, ok := loadSchema(other); ok {
+			// examples/valid and examples/invalid are ambiguous between schemas
+			return "", nil
+		}
+	}
+	return shared, nil
+}
+
+func caseFiles(base string, kind Kind) ([]Case, error) {
+	dir := filepath.Join(base, string(kind))
+	if !isDir(dir) {
+		return nil, nil
+	}
+	var cases []Case
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() || !isDataFile(path) || isSidecar(path) {
+			return nil
+		}
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		c := Case{Name: filepath.ToSlash(rel), Source: path, Kind: kind}
+		c.Data, c.loadErr = loadFile(path)
+		if kind == KindInvalid && c.loadErr == nil {
+			c.Expect, c.expectErr = loadSidecar(path)
+		}
+		cases = append(cases, c)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan %s: %w", dir, err)
+	}
+	return cases, nil
+}
+
+// loadSidecar reads the expectation declared for an invalid case file
+func loadSidecar(casePath string) (*Expectation, error) {
+	stem := strings.TrimSuffix(casePath, filepath.Ext(casePath))
+	for _, suffix := range sidecarSuffixes {
+		data, err := os.ReadFile(filepath.Clean(stem + suffix)) // #nosec G304 -- sidecars sit next to user-selected cases
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", stem+suffix, err)
+		}
+		var expect Expectation
+		if err := yaml.Unmarshal(data, &expect); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", stem+suffix, err)
+		}
+		return &expect, nil
+	}
+	return nil, fmt.Errorf("no expected error path or keyword declared; add %s", filepath.Base(stem+sidecarSuffixes[0]))
+}
+
+func inlineCases(path string, doc any) []Case {
+	root, ok := doc.(map[string]any)
+	if !ok {
+		return nil
+	}
+	var cases []Case
+	if examples, ok := root["examples"].([]any); ok {
+		for i, value := range examples {
+			cases = append(cases, Case{Name: fmt.Sprintf("examples[%d]", i), Source: path, Kind: KindValid, Data: value})
+		}
+	}
+	invalid, _ := root[invalidExamplesKeyword].([]any)
+	for i, entry := range invalid {
+		c := Case{Name: fmt.Sprintf("%s[%d]", invalidExamplesKeyword, i), Source: path, Kind: KindInvalid}
+		m, ok := entry.(map[string]any)
+		value, hasValue := m["value"]
+		if !ok || !hasValue {
+			c.loadErr = fmt.Errorf("%s entries must be objects with a value", invalidExamplesKeyword)
+			cases = append(cases, c)
+			continue
+		}
+		c.Data = value
+		c.Expect = &Expectation{}
+		c.Expect.Path, _ = m["path"].(string)
+		c.Expect.Keyword, _ = m["keyword"].(string)
+		c.Expect.Description, _ = m["description"].(string)
+		cases = append(cases, c)
+	}
+	return cases
+}
+
+// loadSchema decodes path and reports whether it is a schema that may carry
+// cases: an object declaring $schema or inline invalid examples
+func loadSchema(path string) (any, bool) {
+	doc, err := loadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	m, ok := doc.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	_, hasSchema := m["$schema"]
+	_, hasInvalid := m[invalidExamplesKeyword]
+	return doc, hasSchema || hasInvalid
+}
+
+func loadFile(path string) (any, error) {
+	data, err := os.ReadFile(filepath.Clean(path)) // #nosec G304 -- cases are user-selected inputs
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return decode(path, data)
+}
+
+// schemaStem drops the extension and any ".schema" suffix from a schema file name
+func schemaStem(path string) string {
+	name := filepath.Base(path)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	return strings.TrimSuffix(name, ".schema")
+}
+
+// inCaseDir reports whether path sits under examples/.../valid or examples/.../invalid
+func inCaseDir(path string) bool {
+	parts := strings.Split(filepath.ToSlash(filepath.Dir(path)), "/")
+	for i, part := range parts {
+		if part != "examples" {
+			continue
+		}
+		for _, rest := range parts[i+1:] {
+			if rest == string(KindValid) || rest == string(KindInvalid) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func skipDir(name string) bool {
+	return strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor"
+}
+
+func isDataFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+func isSidecar(path string) bool {
+	lower := strings.ToLower(path)
+	for _, suffix := range sidecarSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
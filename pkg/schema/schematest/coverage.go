@@ -0,0 +1,272 @@
+package schematest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/schema"
+)
+
+// CoverageKind names what a coverage item tracks
+type CoverageKind string
+
+const (
+	// CoverageProperty is covered when a case sets the property
+	CoverageProperty CoverageKind = "property"
+	// CoverageOneOf and CoverageAnyOf are covered when a case matches the branch
+	CoverageOneOf CoverageKind = "oneOf"
+	CoverageAnyOf CoverageKind = "anyOf"
+	// CoverageThen and CoverageElse are covered when a case satisfies or fails the if condition
+	CoverageThen CoverageKind = "then"
+	CoverageElse CoverageKind = "else"
+)
+
+// CoverageItem is a property or branch of the schema
+type CoverageItem struct {
+	// Pointer locates the property or branch in the schema; then/else items
+	// point at their if keyword
+	Pointer string       `json:"pointer"`
+	Kind    CoverageKind `json:"kind"`
+	Covered bool         `json:"covered"`
+}
+
+// Coverage reports which properties and branches at least one case exercised.
+// Valid and invalid cases both count.
+type Coverage struct {
+	Items   []CoverageItem `json:"items"`
+	Covered int            `json:"covered"`
+	Total   int            `json:"total"`
+}
+
+// Percent returns the covered share of items, 100 when there are none
+func (c Coverage) Percent() float64 {
+	if c.Total == 0 {
+		return 100
+	}
+	return float64(c.Covered) * 100 / float64(c.Total)
+}
+
+// maxDepth bounds the walk through recursive $ref chains
+const maxDepth = 64
+
+// coverage tracks the items of one schema as cases are visited
+type coverage struct {
+	root        any
+	schemaBytes []byte
+	idx         *schema.IDIndex
+	items       map[string]*CoverageItem
+	// validators caches compiled subschemas by pointer; nil marks a failed compile
+	validators map[string]*schema.Validator
+}
+
+func newCoverage(root any, schemaBytes []byte, idx *schema.IDIndex) *coverage {
+	c := &coverage{
+		root:        root,
+		schemaBytes: schemaBytes,
+		idx:         idx,
+		items:       make(map[string]*CoverageItem),
+		validators:  make(map[string]*schema.Validator),
+	}
+	c.collect(root, "")
+	return c
+}
+
+func itemKey(pointer string, kind CoverageKind) string {
+	return string(kind) + " " + pointer
+}
+
+func (c *coverage) declare(pointer string, kind CoverageKind) {
+	c.items[itemKey(pointer, kind)] = &CoverageItem{Pointer: pointer, Kind: kind}
+}
+
+func (c *coverage) mark(pointer string, kind CoverageKind) {
+	if item, ok := c.items[itemKey(pointer, kind)]; ok {
+		item.Covered = true
+	}
+}
+
+// collect declares the coverage items of the subschemas that walk can reach
+func (c *coverage) collect(node any, pointer string) {
+	m, ok := node.(map[string]any)
+	if !ok {
+		return
+	}
+	for key, value := range m {
+		at := schema.JoinPointer(pointer, key)
+		switch key {
+		case "properties":
+			for name, sub := range asMap(value) {
+				c.declare(schema.JoinPointer(at, name), CoverageProperty)
+				c.collect(sub, schema.JoinPointer(at, name))
+			}
+		case "oneOf", "anyOf":
+			for i, sub := range asList(value) {
+				branch := schema.JoinPointer(at, strconv.Itoa(i))
+				c.declare(branch, CoverageKind(key))
+				c.collect(sub, branch)
+			}
+		case "if":
+			// the condition itself is not data, so only its outcomes are tracked
+			c.declare(at, CoverageThen)
+			c.declare(at, CoverageElse)
+		case "allOf", "prefixItems":
+			for i, sub := range asList(value) {
+				c.collect(sub, schema.JoinPointer(at, strconv.Itoa(i)))
+			}
+		case "items":
+			if list, ok := value.([]any); ok {
+				for i, sub := range list {
+					c.collect(sub, schema.JoinPointer(at, strconv.Itoa(i)))
+				}
+			} else {
+				c.collect(value, at)
+			}
+		case "patternProperties", "definitions", "$defs":
+			for name, sub := range asMap(value) {
+				c.collect(sub, schema.JoinPointer(at, name))
+			}
+		case "additionalProperties", "additionalItems", "then", "else":
+			c.collect(value, at)
+		}
+	}
+}
+
+// visit marks the items a case instance exercises
+func (c *coverage) visit(instance any) {
+	c.walk(c.root, "", instance, 0)
+}
+
+func (c *coverage) walk(node any, pointer string, instance any, depth int) {
+	m, ok := node.(map[string]any)
+	if !ok || depth > maxDepth {
+		return
+	}
+	if ref, ok := m["$ref"].(string); ok && strings.HasPrefix(ref, "#") {
+		// remote references are validated but not walked for coverage
+		target := strings.TrimPrefix(ref, "#")
+		if sub, ok := schema.ResolvePointer(c.root, target); ok {
+			c.walk(sub, target, instance, depth+1)
+		}
+	}
+
+	switch v := instance.(type) {
+	case map[string]any:
+		c.walkObject(m, pointer, v, depth)
+	case []any:
+		c.walkArray(m, pointer, v, depth)
+	}
+
+	for i, sub := range asList(m["allOf"]) {
+		c.walk(sub, schema.JoinPointer(pointer, "allOf", strconv.Itoa(i)), instance, depth+1)
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		for i, sub := range asList(m[key]) {
+			branch := schema.JoinPointer(pointer, key, strconv.Itoa(i))
+			if c.matches(branch, instance) {
+				c.mark(branch, CoverageKind(key))
+				c.walk(sub, branch, instance, depth+1)
+			}
+		}
+	}
+	if _, ok := m["if"]; ok {
+		at := schema.JoinPointer(pointer, "if")
+		if c.matches(at, instance) {
+			c.mark(at, CoverageThen)
+			c.walk(m["then"], schema.JoinPointer(pointer, "then"), instance, depth+1)
+		} else {
+			c.mark(at, CoverageElse)
+			c.walk(m["else"], schema.JoinPointer(pointer, "else"), instance, depth+1)
+		}
+	}
+}
+
+func (c *coverage) walkObject(m map[string]any, pointer string, obj map[string]any, depth int) {
+	properties := asMap(m["properties"])
+	patterns := asMap(m["patternProperties"])
+	for name, value := range obj {
+		matched := false
+		if sub, ok := properties[name]; ok {
+			at := schema.JoinPointer(pointer, "properties", name)
+			c.mark(at, CoverageProperty)
+			c.walk(sub, at, value, depth+1)
+			matched = true
+		}
+		for pattern, sub := range patterns {
+			if re, err := regexp.Compile(pattern); err == nil && re.MatchString(name) {
+				c.walk(sub, schema.JoinPointer(pointer, "patternProperties", pattern), value, depth+1)
+				matched = true
+			}
+		}
+		if !matched {
+			c.walk(m["additionalProperties"], schema.JoinPointer(pointer, "additionalProperties"), value, depth+1)
+		}
+	}
+}
+
+func (c *coverage) walkArray(m map[string]any, pointer string, arr []any, depth int) {
+	tuple := asList(m["prefixItems"])
+	tupleKey := "prefixItems"
+	if list, ok := m["items"].([]any); ok {
+		tuple, tupleKey = list, "items"
+	}
+	for i, value := range arr {
+		switch {
+		case i < len(tuple):
+			c.walk(tuple[i], schema.JoinPointer(pointer, tupleKey, strconv.Itoa(i)), value, depth+1)
+		case tupleKey == "items":
+			c.walk(m["additionalItems"], schema.JoinPointer(pointer, "additionalItems"), value, depth+1)
+		default:
+			c.walk(m["items"], schema.JoinPointer(pointer, "items"), value, depth+1)
+		}
+	}
+}
+
+// matches validates instance against the subschema at pointer
+func (c *coverage) matches(pointer string, instance any) bool {
+	v, ok := c.validators[pointer]
+	if !ok {
+		v, _ = schema.NewSubschemaValidator(c.schemaBytes, pointer, c.idx)
+		c.validators[pointer] = v
+	}
+	if v == nil {
+		return false
+	}
+	res, err := v.Validate(instance)
+	return err == nil && res.Valid
+}
+
+func (c *coverage) report() Coverage {
+	out := Coverage{Items: make([]CoverageItem, 0, len(c.items))}
+	for _, item := range c.items {
+		out.Items = append(out.Items, *item)
+		if item.Covered {
+			out.Covered++
+		}
+	}
+	out.Total = len(out.Items)
+	sort.Slice(out.Items, func(i, j int) bool {
+		if out.Items[i].Pointer != out.Items[j].Pointer {
+			return out.Items[i].Pointer < out.Items[j].Pointer
+		}
+		return out.Items[i].Kind < out.Items[j].Kind
+	})
+	return out
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+func asList(v any) []any {
+	l, _ := v.([]any)
+	return l
+}
+
+// String formats the item as "kind pointer"
+func (i CoverageItem) String() string {
+	return fmt.Sprintf("%s %s", i.Kind, i.Pointer)
+}
@@ -0,0 +1,287 @@
+// Package schematest runs example-driven test cases against JSON Schemas.
+//
+// Cases live next to each schema:
+//
+//   - examples/<stem>/valid/** and examples/<stem>/invalid/**, where <stem> is
+//     the schema file name without its extension and any ".schema" suffix
+//   - examples/valid/** and examples/invalid/** when the schema is the only
+//     one in its directory
+//   - the schema's root "examples" array (valid cases) and its
+//     "x-invalid-examples" array of {value, path, keyword, description}
+//
+// Valid cases must pass. Invalid cases must fail with an error at the
+// declared instance path and/or for the declared keyword; file cases declare
+// them in a sidecar named <case>.expect.yaml (or .yml/.json). Each run also
+// reports which properties and oneOf/anyOf/if branches the cases exercised.
+package schematest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Kind says whether a case must pass or fail validation
+type Kind string
+
+const (
+	KindValid   Kind = "valid"
+	KindInvalid Kind = "invalid"
+)
+
+// Expectation declares how an invalid case must fail
+type Expectation struct {
+	// Path is the JSON pointer of the offending value ("" or "/" for the root)
+	Path string `json:"path,omitempty" yaml:"path"`
+	// Keyword is the failing JSON Schema keyword (e.g. "required", "oneOf")
+	Keyword     string `json:"keyword,omitempty" yaml:"keyword"`
+	Description string `json:"description,omitempty" yaml:"description"`
+}
+
+// Case is a single example instance
+type Case struct {
+	// Name is the case path relative to its examples directory, or
+	// examples[i] / x-invalid-examples[i] for inline cases
+	Name string
+	// Source is the file holding the case
+	Source string
+	Kind   Kind
+	Data   any
+	Expect *Expectation
+	// loadErr records a case that could not be read or parsed
+	loadErr error
+	// expectErr records a missing or unreadable sidecar
+	expectErr error
+}
+
+// CaseResult is the outcome of running one case
+type CaseResult struct {
+	Name    string                   `json:"name"`
+	Source  string                   `json:"source"`
+	Kind    Kind                     `json:"kind"`
+	Passed  bool                     `json:"passed"`
+	Message string                   `json:"message,omitempty"`
+	Expect  *Expectation             `json:"expect,omitempty"`
+	Errors  []schema.ValidationError `json:"errors,omitempty"`
+}
+
+// SchemaReport holds the case results and coverage for one schema
+type SchemaReport struct {
+	Schema   string       `json:"schema"`
+	Cases    []CaseResult `json:"cases"`
+	Passed   int          `json:"passed"`
+	Failed   int          `json:"failed"`
+	Coverage Coverage     `json:"coverage"`
+}
+
+// Report aggregates the schema reports of a run
+type Report struct {
+	Schemas []*SchemaReport `json:"schemas"`
+	Passed  int             `json:"passed"`
+	Failed  int             `json:"failed"`
+}
+
+// OK reports whether every case passed
+func (r *Report) OK() bool {
+	return r.Failed == 0
+}
+
+// Options configures Run
+type Options struct {
+	// RefDirs are directory trees of schemas used to resolve absolute $ref URLs offline
+	RefDirs []string
+}
+
+// Run discovers the schemas under paths and runs their cases. Directories
+// are searched for schemas (files declaring $schema) that have at least one
+// case; files are always run.
+func Run(paths []string, opts Options) (*Report, error) {
+	schemas, err := Discover(paths)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := schema.BuildIDIndexFromRefDirs(opts.RefDirs)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Schemas: []*SchemaReport{}}
+	for _, path := range schemas {
+		sr, err := RunSchema(path, idx)
+		if err != nil {
+			return nil, err
+		}
+		report.Schemas = append(report.Schemas, sr)
+		report.Passed += sr.Passed
+		report.Failed += sr.Failed
+	}
+	return report, nil
+}
+
+// RunSchema runs the cases of the schema at path. idx may be nil.
+func RunSchema(path string, idx *schema.IDIndex) (*SchemaReport, error) {
+	schemaBytes, err := os.ReadFile(filepath.Clean(path)) // #nosec G304 -- schema paths are chosen by the caller
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema %s: %w", path, err)
+	}
+	root, err := decode(path, schemaBytes)
+	if err != nil {
+		return nil, err
+	}
+	validator, err := schema.NewValidatorWithIDIndex(schemaBytes, idx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema %s: %w", path, err)
+	}
+	cases, err := Cases(path, root)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SchemaReport{Schema: path, Cases: []CaseResult{}}
+	tracker := newCoverage(root, schemaBytes, idx)
+	for _, c := range cases {
+		result := runCase(validator, c)
+		if result.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+		report.Cases = append(report.Cases, result)
+		if c.loadErr == nil {
+			tracker.visit(c.Data)
+		}
+	}
+	report.Coverage = tracker.report()
+	return report, nil
+}
+
+func runCase(validator *schema.Validator, c Case) CaseResult {
+	result := CaseResult{Name: c.Name, Source: c.Source, Kind: c.Kind, Expect: c.Expect}
+	if c.loadErr != nil {
+		result.Message = c.loadErr.Error()
+		return result
+	}
+	res, err := validator.Validate(c.Data)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+	result.Errors = res.Errors
+
+	if c.Kind == KindValid {
+		result.Passed = res.Valid
+		if !res.Valid {
+			result.Message = "expected the case to be valid; got " + describeErrors(res.Errors)
+		}
+		return result
+	}
+
+	switch {
+	case res.Valid:
+		result.Message = "expected the case to be invalid, but it passed validation"
+	case c.expectErr != nil:
+		result.Message = c.expectErr.Error()
+	case c.Expect == nil || (c.Expect.Path == "" && c.Expect.Keyword == ""):
+		result.Message = "no expected error path or keyword declared"
+	case !matchesAny(c.Expect, res.Errors):
+		result.Message = fmt.Sprintf("expected %s; got %s", describeExpectation(c.Expect), describeErrors(res.Errors))
+	default:
+		result.Passed = true
+	}
+	return result
+}
+
+// matchesAny reports whether one of errs satisfies the expectation. Errors
+// that name a property (required, additionalProperties, propertyNames) also
+// match the pointer of the object holding it.
+func matchesAny(expect *Expectation, errs []schema.ValidationError) bool {
+	want := normalizePointer(expect.Path)
+	for _, e := range errs {
+		if expect.Keyword != "" && e.Keyword != expect.Keyword {
+			continue
+		}
+		if expect.Path == "" || e.Pointer == want {
+			return true
+		}
+		switch e.Keyword {
+		case "required", "additionalProperties", "propertyNames":
+			if parentPointer(e.Pointer) == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func normalizePointer(p string) string {
+	p = strings.TrimPrefix(strings.TrimSpace(p), "#")
+	if p == "/" {
+		return ""
+	}
+	return p
+}
+
+func parentPointer(p string) string {
+	if i := strings.LastIndex(p, "/"); i >= 0 {
+		return p[:i]
+	}
+	return ""
+}
+
+func describeExpectation(expect *Expectation) string {
+	var parts []string
+	if expect.Keyword != "" {
+		parts = append(parts, fmt.Sprintf("a %s error", expect.Keyword))
+	} else {
+		parts = append(parts, "an error")
+	}
+	if expect.Path != "" {
+		parts = append(parts, "at "+displayPointer(normalizePointer(expect.Path)))
+	}
+	return strings.Join(parts, " ")
+}
+
+func describeErrors(errs []schema.ValidationError) string {
+	if len(errs) == 0 {
+		return "no errors"
+	}
+	parts := make([]string, 0, len(errs))
+	for _, e := range errs {
+		parts = append(parts, fmt.Sprintf("%s at %s", e.Keyword, displayPointer(e.Pointer)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func displayPointer(p string) string {
+	if p == "" {
+		return "/"
+	}
+	return p
+}
+
+// decode parses JSON or YAML and normalises it to plain JSON values
+func decode(path string, data []byte) (any, error) {
+	var doc any
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s as JSON: %w", path, err)
+		}
+		return doc, nil
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s as YAML: %w", path, err)
+	}
+	encoded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalise %s: %w", path, err)
+	}
+	var normalized any
+	if err := json.Unmarshal(encoded, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to normalise %s: %w", path, err)
+	}
+	return normalized, nil
+}
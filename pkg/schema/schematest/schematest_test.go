@@ -0,0 +1,151 @@
+package schematest
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const fixturesDir = "../../../tests/fixtures/schematest"
+
+func caseResults(report *SchemaReport) map[string]CaseResult {
+	out := make(map[string]CaseResult, len(report.Cases))
+	for _, c := range report.Cases {
+		out[c.Name] = c
+	}
+	return out
+}
+
+func TestRun_PassingSuite(t *testing.T) {
+	t.Parallel()
+
+	report, err := Run([]string{filepath.Join(fixturesDir, "passing")}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Schemas) != 1 {
+		t.Fatalf("expected 1 schema, got %d", len(report.Schemas))
+	}
+	sr := report.Schemas[0]
+	for _, c := range sr.Cases {
+		if !c.Passed {
+			t.Errorf("%s: %s", c.Name, c.Message)
+		}
+	}
+	results := caseResults(sr)
+	for _, name := range []string{
+		"valid/admin.yaml",
+		"invalid/missing-name.yaml",
+		"invalid/bad-contact.json",
+		"examples[0]",
+		"x-invalid-examples[0]",
+	} {
+		if _, ok := results[name]; !ok {
+			t.Errorf("missing case %s", name)
+		}
+	}
+	if !report.OK() || report.Passed != 5 {
+		t.Fatalf("expected 5 passing cases, got %d passed, %d failed", report.Passed, report.Failed)
+	}
+}
+
+func TestRun_Coverage(t *testing.T) {
+	t.Parallel()
+
+	sr, err := RunSchema(filepath.Join(fixturesDir, "passing", "user.schema.json"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	covered := make(map[string]bool)
+	for _, item := range sr.Coverage.Items {
+		covered[item.String()] = item.Covered
+	}
+	want := map[string]bool{
+		"property /properties/name":                           true,
+		"property /properties/nickname":                       false,
+		"property /properties/contact":                        true,
+		"oneOf /properties/contact/oneOf/0":                   true,
+		"oneOf /properties/contact/oneOf/1":                   false,
+		"then /if":                                            true,
+		"else /if":                                            true,
+		"property /definitions/emailContact/properties/email": true,
+		"property /definitions/phoneContact/properties/phone": false,
+	}
+	for item, wantCovered := range want {
+		got, ok := covered[item]
+		if !ok {
+			t.Errorf("missing coverage item %s", item)
+			continue
+		}
+		if got != wantCovered {
+			t.Errorf("%s covered = %v, want %v", item, got, wantCovered)
+		}
+	}
+	if sr.Coverage.Covered >= sr.Coverage.Total || sr.Coverage.Percent() <= 0 {
+		t.Fatalf("unexpected coverage %d/%d", sr.Coverage.Covered, sr.Coverage.Total)
+	}
+}
+
+func TestRun_FailingSuite(t *testing.T) {
+	t.Parallel()
+
+	report, err := Run([]string{filepath.Join(fixturesDir, "failing")}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.OK() || len(report.Schemas) != 1 {
+		t.Fatalf("expected one failing schema, got %+v", report)
+	}
+	results := caseResults(report.Schemas[0])
+	want := map[string]string{
+		"valid/too-long.yaml":        "expected the case to be valid",
+		"invalid/no-sidecar.yaml":    "add no-sidecar.expect.yaml",
+		"invalid/wrong-keyword.yaml": "expected a maxLength error at /label; got type at /label",
+		"invalid/passes.yaml":        "it passed validation",
+	}
+	for name, message := range want {
+		c, ok := results[name]
+		if !ok {
+			t.Errorf("missing case %s", name)
+			continue
+		}
+		if c.Passed || !strings.Contains(c.Message, message) {
+			t.Errorf("%s: passed=%v message=%q, want failure containing %q", name, c.Passed, c.Message, message)
+		}
+	}
+	if report.Failed != 4 {
+		t.Fatalf("expected 4 failures, got %d", report.Failed)
+	}
+}
+
+func TestDiscover_SkipsCaseFiles(t *testing.T) {
+	t.Parallel()
+
+	schemas, err := Discover([]string{fixturesDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range schemas {
+		names = append(names, filepath.Base(s))
+	}
+	if got := strings.Join(names, ","); got != "tag.schema.yaml,user.schema.json" {
+		t.Fatalf("Discover = %s", got)
+	}
+}
+
+func TestMatchesAny_PropertyErrorsMatchParent(t *testing.T) {
+	t.Parallel()
+
+	report, err := RunSchema(filepath.Join(fixturesDir, "passing", "user.schema.json"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := caseResults(report)["invalid/missing-name.yaml"]
+	if !matchesAny(&Expectation{Path: "/", Keyword: "required"}, missing.Errors) {
+		t.Error("required error should match the parent object pointer")
+	}
+	if matchesAny(&Expectation{Path: "/role"}, missing.Errors) {
+		t.Error("unexpected match for /role")
+	}
+}
@@ -41,7 +41,12 @@ type ValidationContext struct {
 
 // ValidationError represents a single validation error.
 type ValidationError struct {
-	Path    string            `json:"path,omitempty"`
+	Path string `json:"path,omitempty"`
+	// Pointer is the JSON pointer of the offending value. For required,
+	// additionalProperties and propertyNames errors it names the property.
+	Pointer string `json:"pointer,omitempty"`
+	// Keyword is the JSON Schema keyword that failed (e.g. "required", "oneOf")
+	Keyword string            `json:"keyword,omitempty"`
 	Message string            `json:"message"`
 	Context ValidationContext `json:"context,omitempty"`
 }
@@ -300,6 +305,8 @@ func validateWithCompiled(sch *gojsonschema.Schema, data interface{}) (*Result,
 			improvedMsg := improveErrorMessage(field, originalMsg)
 			res.Errors = append(res.Errors, ValidationError{
 				Path:    field,
+				Pointer: errorPointer(verr),
+				Keyword: errorKeyword(verr.Type()),
 				Message: improvedMsg,
 			})
 		}
@@ -307,6 +314,71 @@ func validateWithCompiled(sch *gojsonschema.Schema, data interface{}) (*Result,
 	return res, nil
 }
 
+// errorKeywords maps gojsonschema error types to the keywords that raise them
+var errorKeywords = map[string]string{
+	"false":                           "false",
+	"required":                        "required",
+	"invalid_type":                    "type",
+	"number_any_of":                   "anyOf",
+	"number_one_of":                   "oneOf",
+	"number_all_of":                   "allOf",
+	"number_not":                      "not",
+	"missing_dependency":              "dependencies",
+	"const":                           "const",
+	"enum":                            "enum",
+	"array_no_additional_items":       "additionalItems",
+	"array_min_items":                 "minItems",
+	"array_max_items":                 "maxItems",
+	"unique":                          "uniqueItems",
+	"contains":                        "contains",
+	"array_min_properties":            "minProperties",
+	"array_max_properties":            "maxProperties",
+	"additional_property_not_allowed": "additionalProperties",
+	"invalid_property_pattern":        "patternProperties",
+	"invalid_property_name":           "propertyNames",
+	"string_gte":                      "minLength",
+	"string_lte":                      "maxLength",
+	"pattern":                         "pattern",
+	"format":                          "format",
+	"multiple_of":                     "multipleOf",
+	"number_gte":                      "minimum",
+	"number_gt":                       "exclusiveMinimum",
+	"number_lte":                      "maximum",
+	"number_lt":                       "exclusiveMaximum",
+	"condition_then":                  "then",
+	"condition_else":                  "else",
+}
+
+func errorKeyword(errorType string) string {
+	if keyword, ok := errorKeywords[errorType]; ok {
+		return keyword
+	}
+	return errorType
+}
+
+// errorPointer builds the JSON pointer of the value an error refers to
+func errorPointer(verr gojsonschema.ResultError) string {
+	var tokens []string
+	if ctx := verr.Context(); ctx != nil {
+		tokens = strings.Split(ctx.String("\x00"), "\x00")
+		if len(tokens) > 0 && tokens[0] == gojsonschema.STRING_CONTEXT_ROOT {
+			tokens = tokens[1:]
+		}
+	}
+	switch verr.Type() {
+	case "required", "additional_property_not_allowed", "invalid_property_name":
+		if property, ok := verr.Details()["property"].(string); ok {
+			tokens = append(tokens, property)
+		}
+	}
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
 // Validate validates data (interface{}) against the named schema (e.g., "goneat-config-v1.0.0").
 func Validate(data interface{}, schemaName string) (*Result, error) {
 	validator, err := GetEmbeddedValidator(schemaName)
@@ -480,7 +552,20 @@ func compileSchemaBytesWithIDIndex(rootSchemaBytes []byte, idx *IDIndex) (*gojso
 	if err != nil {
 		return nil, err
 	}
+	if err := registerIDIndex(schemaLoader, rootID, normalizedRoot, idx); err != nil {
+		return nil, err
+	}
 
+	schema, err := schemaLoader.Compile(gojsonschema.NewBytesLoader(normalizedRoot))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema with id-index: %w", err)
+	}
+	return schema, nil
+}
+
+// registerIDIndex preloads the index entries into schemaLoader, rejecting
+// entries that redefine the root schema's $id with different content
+func registerIDIndex(schemaLoader *gojsonschema.SchemaLoader, rootID string, normalizedRoot []byte, idx *IDIndex) error {
 	type registeredSchema struct {
 		normalized []byte
 		source     string
@@ -491,6 +576,9 @@ func compileSchemaBytesWithIDIndex(rootSchemaBytes []byte, idx *IDIndex) (*gojso
 		registered[rootID] = registeredSchema{normalized: normalizedRoot, source: "root schema"}
 	}
 
+	if idx == nil {
+		return nil
+	}
 	for _, entry := range idx.entries {
 		id := entry.ID
 		normalized := entry.Normalized
@@ -500,20 +588,75 @@ func compileSchemaBytesWithIDIndex(rootSchemaBytes []byte, idx *IDIndex) (*gojso
 			if bytes.Equal(existing.normalized, normalized) {
 				continue
 			}
-			return nil, fmt.Errorf("duplicate schema $id %q: %s differs from %s", id, path, existing.source)
+			return fmt.Errorf("duplicate schema $id %q: %s differs from %s", id, path, existing.source)
 		}
 
 		if err := schemaLoader.AddSchema(id, gojsonschema.NewBytesLoader(normalized)); err != nil {
-			return nil, fmt.Errorf("register schema %s (%s): %w", path, id, err)
+			return fmt.Errorf("register schema %s (%s): %w", path, id, err)
 		}
 		registered[id] = registeredSchema{normalized: normalized, source: path}
 	}
+	return nil
+}
 
-	schema, err := schemaLoader.Compile(gojsonschema.NewBytesLoader(normalizedRoot))
+// NewValidatorWithIDIndex compiles schema bytes once, preloading schemas from
+// an offline $id index, so the schema can validate many documents.
+func NewValidatorWithIDIndex(schemaBytes []byte, idx *IDIndex) (*Validator, error) {
+	if err := ensureSupportedDraft(schemaBytes); err != nil {
+		return nil, err
+	}
+	if idx == nil || idx.Len() == 0 {
+		return NewValidatorFromBytes(schemaBytes)
+	}
+	sch, err := compileSchemaBytesWithIDIndex(schemaBytes, idx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to compile schema with id-index: %w", err)
+		return nil, err
 	}
-	return schema, nil
+	return &Validator{schema: sch}, nil
+}
+
+// subschemaBaseID is the $id given to documents without one so that $ref
+// values inside a subschema can resolve against the whole document.
+const subschemaBaseID = "https://goneat.invalid/subschema-root.json"
+
+// NewSubschemaValidator compiles the subschema found at a JSON pointer within
+// schemaBytes. $ref values inside the subschema resolve against the whole
+// document and, for absolute URLs, against the optional $id index.
+func NewSubschemaValidator(schemaBytes []byte, pointer string, idx *IDIndex) (*Validator, error) {
+	if err := ensureSupportedDraft(schemaBytes); err != nil {
+		return nil, err
+	}
+	rootID, normalizedRoot, err := extractAndNormalizeSchema(schemaBytes, true)
+	if err != nil {
+		return nil, err
+	}
+	if rootID == "" {
+		var doc map[string]any
+		if err := json.Unmarshal(normalizedRoot, &doc); err != nil {
+			return nil, fmt.Errorf("schema root must be an object: %w", err)
+		}
+		doc["$id"] = subschemaBaseID
+		if normalizedRoot, err = json.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("failed to encode schema to JSON: %w", err)
+		}
+		rootID = subschemaBaseID
+	}
+	rootID = strings.TrimSuffix(rootID, "#")
+
+	schemaLoader := gojsonschema.NewSchemaLoader()
+	schemaLoader.AutoDetect = false
+	if err := schemaLoader.AddSchema(rootID, gojsonschema.NewBytesLoader(normalizedRoot)); err != nil {
+		return nil, fmt.Errorf("register root schema: %w", err)
+	}
+	if err := registerIDIndex(schemaLoader, rootID, normalizedRoot, idx); err != nil {
+		return nil, err
+	}
+
+	sch, err := schemaLoader.Compile(gojsonschema.NewGoLoader(map[string]any{"$ref": rootID + "#" + pointer}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile subschema %s: %w", pointer, err)
+	}
+	return &Validator{schema: sch}, nil
 }
 
 func extractAndNormalizeSchema(schemaBytes []byte, stripSchema bool) (string, []byte, error) {
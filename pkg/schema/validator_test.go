@@ -653,3 +653,56 @@ func TestValidateWithOptions(t *testing.T) {
 		}
 	})
 }
+
+func TestValidationError_PointerAndKeyword(t *testing.T) {
+	schemaBytes := []byte(`{
+  "type": "object",
+  "required": ["name"],
+  "additionalProperties": false,
+  "properties": {
+    "name": {"type": "string"},
+    "tags": {"type": "array", "items": {"type": "string", "maxLength": 3}},
+    "a/b": {"type": "integer"}
+  }
+}`)
+	data := map[string]any{
+		"tags":  []any{"ok", "toolong"},
+		"a/b":   "x",
+		"extra": true,
+	}
+	res, err := ValidateFromBytes(schemaBytes, data)
+	if err != nil {
+		t.Fatalf("ValidateFromBytes error: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, e := range res.Errors {
+		got[e.Keyword+" "+e.Pointer] = true
+	}
+	for _, want := range []string{"required /name", "maxLength /tags/1", "type /a~1b", "additionalProperties /extra"} {
+		if !got[want] {
+			t.Errorf("missing error %q in %+v", want, res.Errors)
+		}
+	}
+}
+
+func TestNewSubschemaValidator(t *testing.T) {
+	schemaBytes := []byte(`{
+  "definitions": {"id": {"type": "integer", "minimum": 1}},
+  "properties": {
+    "value": {"oneOf": [{"$ref": "#/definitions/id"}, {"type": "string"}]}
+  }
+}`)
+	v, err := NewSubschemaValidator(schemaBytes, "/properties/value/oneOf/0", nil)
+	if err != nil {
+		t.Fatalf("NewSubschemaValidator error: %v", err)
+	}
+	for value, want := range map[any]bool{3: true, 0: false, "x": false} {
+		res, err := v.Validate(value)
+		if err != nil {
+			t.Fatalf("Validate error: %v", err)
+		}
+		if res.Valid != want {
+			t.Errorf("Validate(%v) = %v, want %v", value, res.Valid, want)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 type ClonedRepo struct {
 	Path   string
 	Cached bool
+	Commit string // Checked-out commit hash
 }
 
 const ssotCacheDirName = "cache/ssot"
@@ -27,6 +28,20 @@ const ssotCacheDirName = "cache/ssot"
 // CloneRepository clones the given GitHub repo/ref (or file:// URL) into the SSOT cache.
 // Subsequent calls reuse the cached clone keyed by repo+ref, fetching latest updates when possible.
 func CloneRepository(repo, ref string) (*ClonedRepo, error) {
+	return cloneRepository(repo, ref, "")
+}
+
+// CloneRepositoryAt behaves like CloneRepository but checks out the given commit
+// instead of the current tip of ref. The cache is still keyed by repo+ref, so pinned
+// syncs reuse the same clone as unpinned ones.
+func CloneRepositoryAt(repo, ref, commit string) (*ClonedRepo, error) {
+	if commit == "" {
+		return nil, errors.New("commit cannot be empty")
+	}
+	return cloneRepository(repo, ref, commit)
+}
+
+func cloneRepository(repo, ref, commit string) (*ClonedRepo, error) {
 	if repo == "" {
 		return nil, errors.New("repo cannot be empty")
 	}
@@ -49,6 +64,9 @@ func CloneRepository(repo, ref string) (*ClonedRepo, error) {
 	}
 
 	hash, err := resolveRefHash(repository, ref)
+	if commit != "" {
+		hash, err = resolveCommitHash(repository, commit)
+	}
 	if err != nil {
 		if !cached {
 			_ = os.RemoveAll(targetPath)
@@ -66,6 +84,7 @@ func CloneRepository(repo, ref string) (*ClonedRepo, error) {
 	return &ClonedRepo{
 		Path:   targetPath,
 		Cached: cached,
+		Commit: hash.String(),
 	}, nil
 }
 
@@ -154,7 +173,13 @@ func hashRepoRef(repo, ref string) string {
 }
 
 func resolveRefHash(repository *git.Repository, ref string) (plumbing.Hash, error) {
-	// Try to resolve using go-git's revision parser first
+	// Prefer the remote-tracking branch: fetches update refs/remotes/origin/<ref>
+	// but never the local branch created by the initial clone
+	if reference, err := repository.Reference(plumbing.NewRemoteReferenceName("origin", ref), true); err == nil {
+		return reference.Hash(), nil
+	}
+
+	// Try to resolve using go-git's revision parser
 	if hash, err := repository.ResolveRevision(plumbing.Revision(ref)); err == nil {
 		return *hash, nil
 	}
@@ -184,6 +209,18 @@ func resolveRefHash(repository *git.Repository, ref string) (plumbing.Hash, erro
 	return plumbing.ZeroHash, fmt.Errorf("ref %s not found", ref)
 }
 
+// resolveCommitHash resolves a full commit hash that must exist in the repository.
+func resolveCommitHash(repository *git.Repository, commit string) (plumbing.Hash, error) {
+	if len(commit) != 40 || !isHex(commit) {
+		return plumbing.ZeroHash, fmt.Errorf("invalid commit hash %q", commit)
+	}
+	hash := plumbing.NewHash(commit)
+	if _, err := repository.CommitObject(hash); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("commit %s not found: %w", commit, err)
+	}
+	return hash, nil
+}
+
 func checkoutHash(repository *git.Repository, hash plumbing.Hash) error {
 	worktree, err := repository.Worktree()
 	if err != nil {
@@ -175,6 +175,9 @@ func mergeConfigs(prod, local *SyncConfig) *SyncConfig {
 	if local.Strategy.OnConflict != "" {
 		merged.Strategy.OnConflict = local.Strategy.OnConflict
 	}
+	if local.Strategy.ConflictStyle != "" {
+		merged.Strategy.ConflictStyle = local.Strategy.ConflictStyle
+	}
 	// For booleans, use local value if local config exists
 	if len(local.Sources) > 0 {
 		merged.Strategy.PruneStale = local.Strategy.PruneStale
@@ -265,6 +268,17 @@ func validateConfig(config *SyncConfig) error {
 		return fmt.Errorf("at least one source is required")
 	}
 
+	switch config.Strategy.OnConflict {
+	case "", ConflictOverwrite, ConflictSkip, ConflictError, ConflictMerge:
+	default:
+		return fmt.Errorf("strategy.on_conflict: unsupported value %q (expected overwrite, skip, error or merge)", config.Strategy.OnConflict)
+	}
+	switch config.Strategy.ConflictStyle {
+	case "", ConflictStyleMarkers, ConflictStyleRej:
+	default:
+		return fmt.Errorf("strategy.conflict_style: unsupported value %q (expected markers or rej)", config.Strategy.ConflictStyle)
+	}
+
 	// Validate each source
 	for i, source := range config.Sources {
 		if source.Name == "" {
@@ -354,6 +368,12 @@ func ValidateSource(config *SyncConfig) error {
 
 // ResolveSource resolves a source to a filesystem path (local or remote clone)
 func ResolveSource(source Source) (*ResolvedSource, error) {
+	return resolveSource(source, "")
+}
+
+// resolveSource resolves a source, checking out commit instead of the tip of
+// source.Ref when commit is set. Local sources are used as-is.
+func resolveSource(source Source, commit string) (*ResolvedSource, error) {
 	useLocal := source.LocalPath != "" && !source.ForceRemote
 
 	if useLocal {
@@ -368,7 +388,13 @@ func ResolveSource(source Source) (*ResolvedSource, error) {
 	}
 
 	if source.Repo != "" && source.Ref != "" {
-		cloned, err := CloneRepository(source.Repo, source.Ref)
+		var cloned *ClonedRepo
+		var err error
+		if commit != "" {
+			cloned, err = CloneRepositoryAt(source.Repo, source.Ref, commit)
+		} else {
+			cloned, err = CloneRepository(source.Repo, source.Ref)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to clone repo %s@%s: %w", source.Repo, source.Ref, err)
 		}
@@ -384,6 +410,7 @@ func ResolveSource(source Source) (*ResolvedSource, error) {
 			RepoRoot: cloned.Path,
 			IsLocal:  false,
 			IsCloned: true,
+			Commit:   cloned.Commit,
 		}, nil
 	}
 
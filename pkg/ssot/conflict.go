@@ -0,0 +1,175 @@
+package ssot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/logger"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// On-conflict strategies for synced files edited locally since the last sync
+const (
+	ConflictOverwrite = "overwrite"
+	ConflictSkip      = "skip"
+	ConflictError     = "error"
+	ConflictMerge     = "merge"
+)
+
+// Conflict styles for on_conflict: merge
+const (
+	ConflictStyleMarkers = "markers"
+	ConflictStyleRej     = "rej"
+)
+
+// baseReader reads the previously synced version of source files from git history
+type baseReader struct {
+	commit *object.Commit
+	root   string
+}
+
+// openBaseReader opens the repository containing repoPath at commit. It returns
+// nil when the source is not a git repository or the commit is unknown.
+func openBaseReader(repoPath, commit string) *baseReader {
+	if repoPath == "" || commit == "" {
+		return nil
+	}
+	repo, err := git.PlainOpenWithOptions(repoPath, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil
+	}
+	c, err := repo.CommitObject(plumbing.NewHash(commit))
+	if err != nil {
+		logger.Debug(fmt.Sprintf("ssot: merge base %s not available in %s: %v", commit, repoPath, err))
+		return nil
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil
+	}
+	root, err := filepath.EvalSymlinks(worktree.Filesystem.Root())
+	if err != nil {
+		return nil
+	}
+	return &baseReader{commit: c, root: root}
+}
+
+// read returns the content of srcPath at the base commit
+func (r *baseReader) read(srcPath string) ([]byte, bool) {
+	if r == nil {
+		return nil, false
+	}
+	abs, err := filepath.Abs(srcPath)
+	if err != nil {
+		return nil, false
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	rel, err := filepath.Rel(r.root, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil, false
+	}
+	file, err := r.commit.File(filepath.ToSlash(rel))
+	if err != nil {
+		return nil, false
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return nil, false
+	}
+	return []byte(content), true
+}
+
+// conflictResolver decides what to write for synced files that were edited locally
+type conflictResolver struct {
+	strategy string
+	style    string
+	label    string            // upstream label for conflict markers
+	base     *baseReader       // previously synced version, nil when unknown
+	digests  map[string]string // previously locked upstream digests by destination
+}
+
+// fileAction is the planned write for one synced file
+type fileAction struct {
+	file     syncFile
+	content  []byte // nil means copy the upstream file
+	keep     bool   // local modifications kept as-is
+	merged   bool
+	conflict bool
+	rejects  string
+}
+
+// resolve plans the write for f. A conflict exists when the local file differs
+// both from the previously synced version and from the new upstream version.
+func (r *conflictResolver) resolve(f syncFile) (fileAction, error) {
+	action := fileAction{file: f}
+
+	// #nosec G304 -- destination paths come from the sync plan
+	local, err := os.ReadFile(f.dst)
+	if errors.Is(err, os.ErrNotExist) {
+		return action, nil
+	}
+	if err != nil {
+		return action, fmt.Errorf("failed to read %s: %w", f.dst, err)
+	}
+	if contentDigest(local) == f.digest {
+		return action, nil
+	}
+
+	base, hasBase := r.base.read(f.src)
+	switch {
+	case hasBase:
+		if bytes.Equal(local, base) {
+			return action, nil
+		}
+	case r.digests[filepath.ToSlash(f.dst)] != "":
+		if contentDigest(local) == r.digests[filepath.ToSlash(f.dst)] {
+			return action, nil
+		}
+	default:
+		// No record of what was synced before; treat the file as upstream-owned
+		return action, nil
+	}
+
+	switch r.strategy {
+	case ConflictSkip:
+		action.keep = true
+		action.content = local
+	case ConflictError:
+		return action, fmt.Errorf("%s has local modifications (on_conflict: error)", f.dst)
+	case ConflictMerge:
+		// #nosec G304 -- source paths come from the sync plan
+		theirs, err := os.ReadFile(f.src)
+		if err != nil {
+			return action, fmt.Errorf("failed to read %s: %w", f.src, err)
+		}
+		result := merge3(splitLines(base), splitLines(local), splitLines(theirs), r.style, "local", r.label)
+		action.content = result.Content
+		action.merged = true
+		action.conflict = result.Conflicts > 0
+		action.rejects = result.Rejects
+	}
+	return action, nil
+}
+
+func contentDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// upstreamLabel names the upstream side in conflict markers, e.g. "crucible@3f1c9e0d5a7b"
+func upstreamLabel(name, commit string) string {
+	if commit == "" {
+		return name
+	}
+	return name + "@" + shortDigest(commit)
+}
@@ -0,0 +1,249 @@
+package ssot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/fulmenhq/goneat/pkg/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// LockFilePath is the default location of the SSOT lock file
+const LockFilePath = ".goneat/ssot.lock"
+
+const lockVersion = "v1"
+
+const lockHeader = "# Generated by goneat ssot. Do not edit by hand; run 'goneat ssot update' to refresh.\n"
+
+// Lock pins every source to a resolved commit and records the digest of each synced file
+type Lock struct {
+	Version string         `yaml:"version"`
+	Sources []LockedSource `yaml:"sources"`
+}
+
+// LockedSource is the lock entry for a single source
+type LockedSource struct {
+	Name   string            `yaml:"name"`
+	Repo   string            `yaml:"repo,omitempty"`
+	Ref    string            `yaml:"ref,omitempty"`
+	Commit string            `yaml:"commit,omitempty"` // Resolved commit hash (empty for non-git local sources)
+	Files  map[string]string `yaml:"files,omitempty"`  // Synced destination path -> SHA-256 hex digest
+}
+
+// FileDrift describes a synced file that no longer matches the lock
+type FileDrift struct {
+	Source string
+	Path   string
+	Status string // "modified" or "missing"
+}
+
+// VerifyReport is the result of checking synced files against the lock
+type VerifyReport struct {
+	Checked int
+	Drift   []FileDrift
+}
+
+// LoadLock reads the lock file. A missing file yields an error satisfying
+// errors.Is(err, os.ErrNotExist).
+func LoadLock(path string) (*Lock, error) {
+	if path == "" {
+		path = LockFilePath
+	}
+	// #nosec G304 -- lock path is fixed or supplied by the caller
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var lock Lock
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if lock.Version != lockVersion {
+		return nil, fmt.Errorf("unsupported lock version %q in %s (expected %s)", lock.Version, path, lockVersion)
+	}
+	return &lock, nil
+}
+
+// WriteLock writes the lock file with sources sorted by name. The file is left
+// untouched when its content would not change.
+func WriteLock(lock *Lock, path string) error {
+	if path == "" {
+		path = LockFilePath
+	}
+	lock.Version = lockVersion
+	sort.Slice(lock.Sources, func(i, j int) bool {
+		return lock.Sources[i].Name < lock.Sources[j].Name
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString(lockHeader)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(lock); err != nil {
+		return fmt.Errorf("failed to marshal lock: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to marshal lock: %w", err)
+	}
+
+	// #nosec G304 -- we only read the file we are about to write
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, buf.Bytes()) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	logger.Info(fmt.Sprintf("SSOT lock written: %s", path))
+	return nil
+}
+
+// Source returns the lock entry for the named source, or nil
+func (l *Lock) Source(name string) *LockedSource {
+	for i := range l.Sources {
+		if l.Sources[i].Name == name {
+			return &l.Sources[i]
+		}
+	}
+	return nil
+}
+
+// set adds or replaces the entry for entry.Name
+func (l *Lock) set(entry LockedSource) {
+	if existing := l.Source(entry.Name); existing != nil {
+		*existing = entry
+		return
+	}
+	l.Sources = append(l.Sources, entry)
+}
+
+// retain drops entries for sources no longer configured
+func (l *Lock) retain(sources []Source) {
+	configured := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		configured[source.Name] = true
+	}
+	kept := l.Sources[:0]
+	for _, entry := range l.Sources {
+		if configured[entry.Name] {
+			kept = append(kept, entry)
+		}
+	}
+	l.Sources = kept
+}
+
+// matches reports whether the entry was locked from the same repo and ref as source.
+// Entries for a changed repo or ref are stale and must be re-resolved.
+func (ls *LockedSource) matches(source Source) bool {
+	return ls.Repo == source.Repo && ls.Ref == source.Ref
+}
+
+// compareDigests checks synced digests against the locked ones. In exact mode the
+// file sets must be identical; otherwise only files present in both are compared.
+func (ls *LockedSource) compareDigests(synced map[string]string, exact bool) error {
+	var problems []string
+	paths := make([]string, 0, len(synced))
+	for path := range synced {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		want, ok := ls.Files[path]
+		if !ok {
+			if exact {
+				problems = append(problems, fmt.Sprintf("%s is not in the lock", path))
+			}
+			continue
+		}
+		if want != synced[path] {
+			problems = append(problems, fmt.Sprintf("%s digest mismatch (locked %s, got %s)", path, shortDigest(want), shortDigest(synced[path])))
+		}
+	}
+	if exact {
+		locked := make([]string, 0, len(ls.Files))
+		for path := range ls.Files {
+			if _, ok := synced[path]; !ok {
+				locked = append(locked, path)
+			}
+		}
+		sort.Strings(locked)
+		for _, path := range locked {
+			problems = append(problems, fmt.Sprintf("%s is locked but no longer synced", path))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	msg := fmt.Sprintf("%d file(s) do not match the lock:", len(problems))
+	for _, p := range problems {
+		msg += "\n  " + p
+	}
+	return errors.New(msg)
+}
+
+// VerifyLock compares the synced files on disk against the digests in lock,
+// reporting files that were edited or deleted since the last sync
+func VerifyLock(lock *Lock) (*VerifyReport, error) {
+	report := &VerifyReport{}
+	for _, source := range lock.Sources {
+		paths := make([]string, 0, len(source.Files))
+		for path := range source.Files {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			report.Checked++
+			digest, err := fileDigest(filepath.FromSlash(path))
+			if os.IsNotExist(err) {
+				report.Drift = append(report.Drift, FileDrift{Source: source.Name, Path: path, Status: "missing"})
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("source %s: %w", source.Name, err)
+			}
+			if digest != source.Files[path] {
+				report.Drift = append(report.Drift, FileDrift{Source: source.Name, Path: path, Status: "modified"})
+			}
+		}
+	}
+	return report, nil
+}
+
+// fileDigest returns the SHA-256 hex digest of a file
+func fileDigest(path string) (string, error) {
+	f, err := os.Open(path) // #nosec G304 - caller validates paths
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func shortDigest(digest string) string {
+	if len(digest) > 12 {
+		return digest[:12]
+	}
+	return digest
+}
@@ -0,0 +1,200 @@
+package ssot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lockTestConfig(repoPath string) *SyncConfig {
+	disabled := false
+	return &SyncConfig{
+		Version: "v1.1.0",
+		Sources: []Source{{
+			Name:         "upstream",
+			Repo:         fmt.Sprintf("file://%s", repoPath),
+			Ref:          "master",
+			SyncPathBase: "lang",
+			Assets: []Asset{{
+				Type:   "doc",
+				Paths:  []string{"**/*"},
+				Subdir: "out",
+			}},
+		}},
+		Strategy:   Strategy{OnConflict: "overwrite", PruneStale: true},
+		Provenance: ProvenanceConfig{Enabled: &disabled},
+	}
+}
+
+func commitTestFile(t *testing.T, repoPath, rel, content string) string {
+	t.Helper()
+
+	repo, err := git.PlainOpen(repoPath)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, rel), []byte(content), 0o640))
+	_, err = worktree.Add(rel)
+	require.NoError(t, err)
+	hash, err := worktree.Commit("update "+rel, &git.CommitOptions{
+		Author: &object.Signature{Name: "goneat", Email: "ci@goneat.dev", When: time.Now()},
+	})
+	require.NoError(t, err)
+	return hash.String()
+}
+
+func readSynced(t *testing.T) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("out", "go", "README.md"))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestPerformSync_WritesAndHonoursLock(t *testing.T) {
+	t.Setenv("GONEAT_HOME", t.TempDir())
+	repoPath := initTestRepo(t)
+	t.Chdir(t.TempDir())
+
+	config := lockTestConfig(repoPath)
+	_, err := PerformSync(SyncOptions{Config: config})
+	require.NoError(t, err)
+
+	lock, err := LoadLock("")
+	require.NoError(t, err)
+	entry := lock.Source("upstream")
+	require.NotNil(t, entry)
+	assert.Len(t, entry.Commit, 40)
+	firstCommit := entry.Commit
+	digest, err := fileDigest(filepath.Join("out", "go", "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"out/go/README.md": digest}, entry.Files)
+
+	// Upstream moves on; a plain sync stays on the locked commit
+	newCommit := commitTestFile(t, repoPath, "lang/go/README.md", "changed upstream")
+	_, err = PerformSync(SyncOptions{Config: config})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", readSynced(t))
+
+	// Update re-resolves the ref and rewrites the lock
+	_, err = PerformSync(SyncOptions{Config: config, Update: true})
+	require.NoError(t, err)
+	assert.Equal(t, "changed upstream", readSynced(t))
+	lock, err = LoadLock("")
+	require.NoError(t, err)
+	assert.Equal(t, newCommit, lock.Source("upstream").Commit)
+	assert.NotEqual(t, firstCommit, newCommit)
+}
+
+func TestPerformSync_Frozen(t *testing.T) {
+	t.Setenv("GONEAT_HOME", t.TempDir())
+	repoPath := initTestRepo(t)
+	t.Chdir(t.TempDir())
+	config := lockTestConfig(repoPath)
+
+	_, err := PerformSync(SyncOptions{Config: config, Frozen: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "frozen sync requires")
+
+	_, err = PerformSync(SyncOptions{Config: config})
+	require.NoError(t, err)
+
+	_, err = PerformSync(SyncOptions{Config: config, Frozen: true})
+	require.NoError(t, err)
+
+	// A tampered digest fails before any file is rewritten
+	lock, err := LoadLock("")
+	require.NoError(t, err)
+	lock.Source("upstream").Files["out/go/README.md"] = "0000"
+	require.NoError(t, WriteLock(lock, ""))
+	require.NoError(t, os.WriteFile(filepath.Join("out", "go", "README.md"), []byte("local edit"), 0o600))
+
+	_, err = PerformSync(SyncOptions{Config: config, Frozen: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "digest mismatch")
+	assert.Equal(t, "local edit", readSynced(t))
+
+	// A changed ref invalidates the lock entry
+	config.Sources[0].Ref = "main"
+	_, err = PerformSync(SyncOptions{Config: config, Frozen: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "goneat ssot update upstream")
+}
+
+func TestPerformSync_VerifyChecksums(t *testing.T) {
+	t.Setenv("GONEAT_HOME", t.TempDir())
+	repoPath := initTestRepo(t)
+	t.Chdir(t.TempDir())
+	config := lockTestConfig(repoPath)
+
+	_, err := PerformSync(SyncOptions{Config: config})
+	require.NoError(t, err)
+
+	lock, err := LoadLock("")
+	require.NoError(t, err)
+	lock.Source("upstream").Files["out/go/README.md"] = "0000"
+	require.NoError(t, WriteLock(lock, ""))
+
+	// Without verify_checksums the lock is simply refreshed
+	_, err = PerformSync(SyncOptions{Config: config})
+	require.NoError(t, err)
+
+	lock, err = LoadLock("")
+	require.NoError(t, err)
+	lock.Source("upstream").Files["out/go/README.md"] = "0000"
+	require.NoError(t, WriteLock(lock, ""))
+
+	config.Strategy.VerifyChecksums = true
+	result, err := PerformSync(SyncOptions{Config: config})
+	require.Error(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Error(), "digest mismatch")
+}
+
+func TestPerformSync_UnknownSource(t *testing.T) {
+	t.Chdir(t.TempDir())
+	config := lockTestConfig(t.TempDir())
+
+	_, err := PerformSync(SyncOptions{Config: config, Update: true, Only: []string{"missing"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown source "missing"`)
+}
+
+func TestVerifyLock(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("out", 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join("out", "a.md"), []byte("a"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join("out", "b.md"), []byte("b"), 0o600))
+	digestA, err := fileDigest(filepath.Join("out", "a.md"))
+	require.NoError(t, err)
+	digestB, err := fileDigest(filepath.Join("out", "b.md"))
+	require.NoError(t, err)
+
+	lock := &Lock{Sources: []LockedSource{{
+		Name: "upstream",
+		Files: map[string]string{
+			"out/a.md": digestA,
+			"out/b.md": digestB,
+			"out/c.md": digestA,
+		},
+	}}}
+	require.NoError(t, WriteLock(lock, ""))
+	require.NoError(t, os.WriteFile(filepath.Join("out", "b.md"), []byte("edited"), 0o600))
+
+	loaded, err := LoadLock("")
+	require.NoError(t, err)
+	report, err := VerifyLock(loaded)
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Checked)
+	assert.Equal(t, []FileDrift{
+		{Source: "upstream", Path: "out/b.md", Status: "modified"},
+		{Source: "upstream", Path: "out/c.md", Status: "missing"},
+	}, report.Drift)
+}
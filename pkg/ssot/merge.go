@@ -0,0 +1,335 @@
+package ssot
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Line-based diffing and diff3-style merging for on_conflict: merge and ssot status.
+// Lines keep their trailing newline so content round-trips byte for byte.
+
+const diffContextLines = 3
+
+// splitLines splits content into lines, each keeping its "\n"
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// commonPairs returns the index pairs of lines common to a and b in order,
+// using Myers' O(ND) algorithm after trimming the shared prefix and suffix
+func commonPairs(a, b []string) [][2]int {
+	var pairs [][2]int
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		pairs = append(pairs, [2]int{prefix, prefix})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	for _, p := range myersPairs(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		pairs = append(pairs, [2]int{p[0] + prefix, p[1] + prefix})
+	}
+	for i := suffix; i > 0; i-- {
+		pairs = append(pairs, [2]int{len(a) - i, len(b) - i})
+	}
+	return pairs
+}
+
+func myersPairs(a, b []string) [][2]int {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return nil
+	}
+
+	maxD := n + m
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	// trace[d] holds v for diagonals -(d-1)..d-1 before step d
+	var trace [][]int
+	final := -1
+
+	for d := 0; d <= maxD && final < 0; d++ {
+		snapshot := make([]int, 0, 2*d+1)
+		if d > 0 {
+			snapshot = append(snapshot, v[offset-(d-1):offset+d]...)
+		}
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				final = d
+				break
+			}
+		}
+	}
+
+	var pairs [][2]int
+	x, y := n, m
+	for d := final; d > 0; d-- {
+		prev := trace[d]
+		at := func(k int) int { return prev[k+d-1] }
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			pairs = append(pairs, [2]int{x, y})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		pairs = append(pairs, [2]int{x, y})
+	}
+
+	for i, j := 0, len(pairs)-1; i < j; i, j = i+1, j-1 {
+		pairs[i], pairs[j] = pairs[j], pairs[i]
+	}
+	return pairs
+}
+
+// unifiedDiff renders a unified diff from a to b, or "" when they are equal
+func unifiedDiff(fromName, toName string, a, b []string) string {
+	type op struct {
+		kind byte // ' ', '-', '+'
+		line string
+	}
+	var ops []op
+	i, j := 0, 0
+	for _, p := range append(commonPairs(a, b), [2]int{len(a), len(b)}) {
+		for ; i < p[0]; i++ {
+			ops = append(ops, op{'-', a[i]})
+		}
+		for ; j < p[1]; j++ {
+			ops = append(ops, op{'+', b[j]})
+		}
+		if i < len(a) && j < len(b) {
+			ops = append(ops, op{' ', a[i]})
+			i++
+			j++
+		}
+	}
+
+	var sb strings.Builder
+	aLine, bLine := 0, 0
+	for start := 0; start < len(ops); {
+		// Find the next change
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+			aLine++
+			bLine++
+		}
+		if first == len(ops) {
+			break
+		}
+
+		// Extend the hunk while changes are within 2*context of each other
+		lead := min(diffContextLines, first-start)
+		end := first
+		for k := first; k < len(ops); k++ {
+			if ops[k].kind != ' ' {
+				end = k + 1
+				continue
+			}
+			if k-end >= 2*diffContextLines {
+				break
+			}
+		}
+		trail := 0
+		for k := end; k < len(ops) && trail < diffContextLines && ops[k].kind == ' '; k++ {
+			trail++
+		}
+
+		hunk := ops[first-lead : end+trail]
+		aStart, bStart := aLine-lead, bLine-lead
+		aCount, bCount := 0, 0
+		for _, o := range hunk {
+			if o.kind != '+' {
+				aCount++
+			}
+			if o.kind != '-' {
+				bCount++
+			}
+		}
+
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(aStart, aCount), hunkRange(bStart, bCount))
+		for _, o := range hunk {
+			writeDiffLine(&sb, o.kind, o.line)
+		}
+
+		// Advance line counters past the emitted changes and trailing context
+		for _, o := range ops[first : end+trail] {
+			if o.kind != '+' {
+				aLine++
+			}
+			if o.kind != '-' {
+				bLine++
+			}
+		}
+		start = end + trail
+	}
+	return sb.String()
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func writeDiffLine(sb *strings.Builder, kind byte, line string) {
+	sb.WriteByte(kind)
+	sb.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		sb.WriteString("\n\\ No newline at end of file\n")
+	}
+}
+
+// mergeResult is the outcome of a three-way merge
+type mergeResult struct {
+	Content   []byte
+	Conflicts int
+	Rejects   string // .rej report (conflict_style: rej only)
+}
+
+// merge3 merges the changes from base to ours and from base to theirs.
+// Regions changed on one side take that side; regions changed identically on
+// both sides are taken once; regions changed differently on both sides are
+// conflicts. With style "rej" conflicting regions keep ours and theirs' hunk is
+// written to the reject report; otherwise git-style markers are inserted.
+func merge3(base, ours, theirs []string, style, oursLabel, theirsLabel string) mergeResult {
+	toOurs := make(map[int]int)
+	for _, p := range commonPairs(base, ours) {
+		toOurs[p[0]] = p[1]
+	}
+	toTheirs := make(map[int]int)
+	for _, p := range commonPairs(base, theirs) {
+		toTheirs[p[0]] = p[1]
+	}
+
+	var out []string
+	var rej strings.Builder
+	conflicts := 0
+	o, a, b := 0, 0, 0
+	for o < len(base) || a < len(ours) || b < len(theirs) {
+		// Stable line: unchanged on both sides
+		if o < len(base) && toOurs[o] == a && toTheirs[o] == b && matched(toOurs, o) && matched(toTheirs, o) {
+			out = append(out, base[o])
+			o, a, b = o+1, a+1, b+1
+			continue
+		}
+
+		// Find the next base line that is stable on both sides
+		nextO, nextA, nextB := len(base), len(ours), len(theirs)
+		for k := o; k < len(base); k++ {
+			ka, okA := toOurs[k]
+			kb, okB := toTheirs[k]
+			if okA && okB && ka >= a && kb >= b {
+				nextO, nextA, nextB = k, ka, kb
+				break
+			}
+		}
+
+		baseChunk := base[o:nextO]
+		oursChunk := ours[a:nextA]
+		theirsChunk := theirs[b:nextB]
+		switch {
+		case equalLines(oursChunk, baseChunk):
+			out = append(out, theirsChunk...)
+		case equalLines(theirsChunk, baseChunk), equalLines(oursChunk, theirsChunk):
+			out = append(out, oursChunk...)
+		default:
+			conflicts++
+			if style == "rej" {
+				if rej.Len() == 0 {
+					fmt.Fprintf(&rej, "--- %s\n+++ %s\n", oursLabel, theirsLabel)
+				}
+				fmt.Fprintf(&rej, "@@ -%s +%s @@\n", hunkRange(o, len(baseChunk)), hunkRange(len(out), len(theirsChunk)))
+				for _, line := range baseChunk {
+					writeDiffLine(&rej, '-', line)
+				}
+				for _, line := range theirsChunk {
+					writeDiffLine(&rej, '+', line)
+				}
+				out = append(out, oursChunk...)
+			} else {
+				out = append(out, "<<<<<<< "+oursLabel+"\n")
+				out = append(out, terminated(oursChunk)...)
+				out = append(out, "=======\n")
+				out = append(out, terminated(theirsChunk)...)
+				out = append(out, ">>>>>>> "+theirsLabel+"\n")
+			}
+		}
+		o, a, b = nextO, nextA, nextB
+	}
+
+	return mergeResult{
+		Content:   []byte(strings.Join(out, "")),
+		Conflicts: conflicts,
+		Rejects:   rej.String(),
+	}
+}
+
+func matched(m map[int]int, k int) bool {
+	_, ok := m[k]
+	return ok
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// terminated ensures the final line ends with a newline so markers start on their own line
+func terminated(lines []string) []string {
+	if len(lines) == 0 || strings.HasSuffix(lines[len(lines)-1], "\n") {
+		return lines
+	}
+	out := append([]string(nil), lines...)
+	out[len(out)-1] += "\n"
+	return out
+}
@@ -0,0 +1,189 @@
+package ssot
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lines(s string) []string {
+	return splitLines([]byte(s))
+}
+
+func TestCommonPairs_MatchesLCSLength(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	alphabet := []string{"a\n", "b\n", "c\n"}
+	randomLines := func() []string {
+		out := make([]string, rng.Intn(12))
+		for i := range out {
+			out[i] = alphabet[rng.Intn(len(alphabet))]
+		}
+		return out
+	}
+
+	for i := 0; i < 200; i++ {
+		a, b := randomLines(), randomLines()
+		pairs := commonPairs(a, b)
+
+		// Pairs must be strictly increasing and refer to equal lines
+		for j, p := range pairs {
+			require.Equal(t, a[p[0]], b[p[1]])
+			if j > 0 {
+				require.Greater(t, p[0], pairs[j-1][0])
+				require.Greater(t, p[1], pairs[j-1][1])
+			}
+		}
+
+		// Myers finds a longest common subsequence
+		lcs := make([][]int, len(a)+1)
+		for x := range lcs {
+			lcs[x] = make([]int, len(b)+1)
+		}
+		for x := len(a) - 1; x >= 0; x-- {
+			for y := len(b) - 1; y >= 0; y-- {
+				if a[x] == b[y] {
+					lcs[x][y] = lcs[x+1][y+1] + 1
+				} else {
+					lcs[x][y] = max(lcs[x+1][y], lcs[x][y+1])
+				}
+			}
+		}
+		require.Len(t, pairs, lcs[0][0], "a=%q b=%q", a, b)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	a := lines("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n")
+	b := lines("1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11")
+
+	want := `--- a/f
++++ b/f
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -8,3 +8,4 @@
+ 8
+ 9
+ 10
++11
+\ No newline at end of file
+`
+	assert.Equal(t, want, unifiedDiff("a/f", "b/f", a, b))
+	assert.Empty(t, unifiedDiff("a/f", "b/f", a, a))
+}
+
+func TestMerge3(t *testing.T) {
+	base := lines("title\none\ntwo\nthree\nfour\nfive\n")
+
+	t.Run("clean", func(t *testing.T) {
+		ours := lines("title (patched)\none\ntwo\nthree\nfour\nfive\n")
+		theirs := lines("title\none\ntwo\nthree\nfour\nfive\nsix\n")
+		result := merge3(base, ours, theirs, ConflictStyleMarkers, "local", "upstream")
+		assert.Equal(t, 0, result.Conflicts)
+		assert.Equal(t, "title (patched)\none\ntwo\nthree\nfour\nfive\nsix\n", string(result.Content))
+	})
+
+	t.Run("same change on both sides", func(t *testing.T) {
+		both := lines("title\none\n2\nthree\nfour\nfive\n")
+		result := merge3(base, both, both, ConflictStyleMarkers, "local", "upstream")
+		assert.Equal(t, 0, result.Conflicts)
+		assert.Equal(t, "title\none\n2\nthree\nfour\nfive\n", string(result.Content))
+	})
+
+	t.Run("conflict markers", func(t *testing.T) {
+		ours := lines("title\none\ntwo (local)\nthree\nfour\nfive\n")
+		theirs := lines("title\none\ntwo (upstream)\nthree\nfour\nfive\n")
+		result := merge3(base, ours, theirs, ConflictStyleMarkers, "local", "crucible@abc")
+		assert.Equal(t, 1, result.Conflicts)
+		assert.Equal(t, "title\none\n<<<<<<< local\ntwo (local)\n=======\ntwo (upstream)\n>>>>>>> crucible@abc\nthree\nfour\nfive\n", string(result.Content))
+		assert.Empty(t, result.Rejects)
+	})
+
+	t.Run("reject report", func(t *testing.T) {
+		ours := lines("title\none\ntwo (local)\nthree\nfour\nfive\n")
+		theirs := lines("title\none\ntwo (upstream)\nthree\nfour\nfive\nsix\n")
+		result := merge3(base, ours, theirs, ConflictStyleRej, "local", "upstream")
+		assert.Equal(t, 1, result.Conflicts)
+		assert.Equal(t, "title\none\ntwo (local)\nthree\nfour\nfive\nsix\n", string(result.Content))
+		assert.Equal(t, "--- local\n+++ upstream\n@@ -3 +3 @@\n-two\n+two (upstream)\n", result.Rejects)
+	})
+}
+
+const mergeTestDoc = "# Guide\n\nintro\n\nstep one\nstep two\nstep three\n\noutro\n"
+
+func TestPerformSync_MergeStrategy(t *testing.T) {
+	t.Setenv("GONEAT_HOME", t.TempDir())
+	repoPath := initTestRepo(t)
+	commitTestFile(t, repoPath, "lang/go/README.md", mergeTestDoc)
+	t.Chdir(t.TempDir())
+
+	config := lockTestConfig(repoPath)
+	config.Strategy.OnConflict = ConflictMerge
+	_, err := PerformSync(SyncOptions{Config: config})
+	require.NoError(t, err)
+
+	// Local patch near the top, upstream change near the bottom
+	require.NoError(t, os.WriteFile(filepath.Join("out", "go", "README.md"), []byte(strings.Replace(mergeTestDoc, "intro", "intro (local note)", 1)), 0o600))
+
+	statuses, err := Status(config, "", nil)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "modified", statuses[0].Status)
+	assert.Contains(t, statuses[0].Diff, "-intro\n+intro (local note)\n")
+
+	commitTestFile(t, repoPath, "lang/go/README.md", strings.Replace(mergeTestDoc, "outro", "outro v2", 1))
+	result, err := PerformSync(SyncOptions{Config: config, Update: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("out", "go", "README.md")}, result.Merged)
+	assert.Equal(t, "# Guide\n\nintro (local note)\n\nstep one\nstep two\nstep three\n\noutro v2\n", readSynced(t))
+
+	// Colliding edits leave conflict markers
+	require.NoError(t, os.WriteFile(filepath.Join("out", "go", "README.md"), []byte(strings.Replace(readSynced(t), "step two", "step two (local)", 1)), 0o600))
+	commitTestFile(t, repoPath, "lang/go/README.md", strings.Replace(mergeTestDoc, "step two", "step two (upstream)", 1))
+	result, err = PerformSync(SyncOptions{Config: config, Update: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("out", "go", "README.md")}, result.Conflicts)
+	merged := readSynced(t)
+	assert.Contains(t, merged, "<<<<<<< local\nstep two (local)\n=======\nstep two (upstream)\n>>>>>>> upstream@")
+	assert.Contains(t, merged, "intro (local note)")
+}
+
+func TestPerformSync_SkipAndErrorStrategies(t *testing.T) {
+	t.Setenv("GONEAT_HOME", t.TempDir())
+	repoPath := initTestRepo(t)
+	t.Chdir(t.TempDir())
+
+	config := lockTestConfig(repoPath)
+	_, err := PerformSync(SyncOptions{Config: config})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join("out", "go", "README.md"), []byte("local"), 0o600))
+	commitTestFile(t, repoPath, "lang/go/README.md", "upstream")
+
+	config.Strategy.OnConflict = ConflictError
+	result, err := PerformSync(SyncOptions{Config: config, Update: true})
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Error(), "has local modifications")
+	assert.Equal(t, "local", readSynced(t))
+
+	config.Strategy.OnConflict = ConflictSkip
+	result, err = PerformSync(SyncOptions{Config: config, Update: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("out", "go", "README.md")}, result.Skipped)
+	assert.Equal(t, "local", readSynced(t))
+
+	config.Strategy.OnConflict = ConflictOverwrite
+	_, err = PerformSync(SyncOptions{Config: config, Update: true})
+	require.NoError(t, err)
+	assert.Equal(t, "upstream", readSynced(t))
+}
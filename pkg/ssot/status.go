@@ -0,0 +1,120 @@
+package ssot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// FileStatus describes a synced file that was changed locally since the last sync
+type FileStatus struct {
+	Source string
+	Path   string
+	Status string // "modified" or "deleted"
+	Diff   string // Unified diff from the synced version to the local file
+}
+
+// Status lists synced files whose content differs from the lock, with a diff
+// against the version that was synced. Only sources named in only are checked
+// (all locked sources when empty). Sources are resolved only when they have
+// local modifications.
+func Status(config *SyncConfig, lockPath string, only []string) ([]FileStatus, error) {
+	lock, err := LoadLock(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := selectSources(config.Sources, only)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []FileStatus
+	for _, source := range sources {
+		locked := lock.Source(source.Name)
+		if locked == nil {
+			continue
+		}
+
+		var changed []FileStatus
+		for path, digest := range locked.Files {
+			local, err := fileDigest(filepath.FromSlash(path))
+			switch {
+			case os.IsNotExist(err):
+				changed = append(changed, FileStatus{Source: source.Name, Path: path, Status: "deleted"})
+			case err != nil:
+				return nil, fmt.Errorf("source %s: %w", source.Name, err)
+			case local != digest:
+				changed = append(changed, FileStatus{Source: source.Name, Path: path, Status: "modified"})
+			}
+		}
+		if len(changed) == 0 {
+			continue
+		}
+		sort.Slice(changed, func(i, j int) bool { return changed[i].Path < changed[j].Path })
+
+		if err := diffAgainstSynced(source, locked, changed); err != nil {
+			return nil, fmt.Errorf("source %s: %w", source.Name, err)
+		}
+		statuses = append(statuses, changed...)
+	}
+	return statuses, nil
+}
+
+// diffAgainstSynced fills in diffs for modified files by reading the synced
+// version at the locked commit
+func diffAgainstSynced(source Source, locked *LockedSource, changed []FileStatus) error {
+	resolved, err := resolveSource(source, pinnedCommit(source, locked))
+	if err != nil {
+		return fmt.Errorf("failed to resolve source: %w", err)
+	}
+	base := openBaseReader(resolved.RepoRoot, locked.Commit)
+
+	srcByDest := make(map[string]string)
+	for _, asset := range source.Assets {
+		if asset.Mode == "link" {
+			continue
+		}
+		files, err := collectAssetFiles(source, asset, resolved.Path)
+		if err != nil {
+			return fmt.Errorf("asset %s: %w", asset.Type, err)
+		}
+		for _, f := range files {
+			srcByDest[filepath.ToSlash(f.dst)] = f.src
+		}
+	}
+
+	for i := range changed {
+		if changed[i].Status != "modified" {
+			continue
+		}
+		path := changed[i].Path
+		src, ok := srcByDest[path]
+		if !ok {
+			continue
+		}
+		synced, ok := base.read(src)
+		if !ok {
+			// #nosec G304 -- source paths come from the sync plan
+			if synced, err = os.ReadFile(src); err != nil {
+				continue
+			}
+		}
+		// #nosec G304 -- path is a synced destination recorded in the lock
+		local, err := os.ReadFile(filepath.FromSlash(path))
+		if err != nil {
+			return err
+		}
+		changed[i].Diff = unifiedDiff("a/"+path, "b/"+path, splitLines(synced), splitLines(local))
+	}
+	return nil
+}
+
+// pinnedCommit returns the commit a source should be checked out at. Local
+// sources cannot be pinned.
+func pinnedCommit(source Source, locked *LockedSource) string {
+	if locked == nil || (source.LocalPath != "" && !source.ForceRemote) {
+		return ""
+	}
+	return locked.Commit
+}
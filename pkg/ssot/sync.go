@@ -1,6 +1,8 @@
 package ssot
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -25,13 +27,62 @@ func PerformSync(opts SyncOptions) (*SyncResult, error) {
 		}
 	}
 
+	lockPath := opts.LockPath
+	if lockPath == "" {
+		lockPath = LockFilePath
+	}
+	lock, err := LoadLock(lockPath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return result, fmt.Errorf("failed to load lock: %w", err)
+		}
+		if opts.Frozen {
+			return result, fmt.Errorf("frozen sync requires %s; run 'goneat ssot update' to create it", lockPath)
+		}
+		lock = &Lock{Version: lockVersion}
+	}
+
+	sources, err := selectSources(config.Sources, opts.Only)
+	if err != nil {
+		return result, err
+	}
+
+	// Provenance from the last sync supplies merge bases for unlocked sources
+	provenancePath := config.Provenance.OutputPath
+	if provenancePath == "" {
+		provenancePath = ".goneat/ssot/provenance.json"
+	}
+	provenance := readProvenance(provenancePath)
+
 	// Process each source
-	for _, source := range config.Sources {
-		if err := syncSource(source, opts, result); err != nil {
+	for _, source := range sources {
+		// Reuse the locked commit unless updating or the source's repo/ref changed
+		locked := lock.Source(source.Name)
+		previous := previousSync(source, locked, provenance)
+		if locked != nil && (opts.Update || !locked.matches(source)) {
+			locked = nil
+		}
+		if opts.Frozen && locked == nil {
+			result.Errors = append(result.Errors, fmt.Errorf("source %s: not locked or repo/ref changed since locking; run 'goneat ssot update %s'", source.Name, source.Name))
+			continue
+		}
+
+		errorsBefore := len(result.Errors)
+		entry, err := syncSource(source, locked, previous, opts, result)
+		if err != nil {
 			result.Errors = append(result.Errors, fmt.Errorf("source %s: %w", source.Name, err))
 			continue
 		}
 		result.Sources = append(result.Sources, source.Name)
+
+		// Only lock sources whose assets all synced cleanly
+		if len(result.Errors) == errorsBefore {
+			lock.set(*entry)
+		}
+	}
+
+	if opts.Frozen && len(result.Errors) > 0 {
+		return result, fmt.Errorf("frozen sync failed: %w", errors.Join(result.Errors...))
 	}
 
 	// Return error if all sources failed
@@ -39,6 +90,16 @@ func PerformSync(opts SyncOptions) (*SyncResult, error) {
 		return result, fmt.Errorf("all sources failed to sync")
 	}
 
+	// Frozen syncs never rewrite the lock
+	if len(result.Sources) > 0 && !opts.DryRun && !opts.Frozen {
+		if len(opts.Only) == 0 {
+			lock.retain(config.Sources)
+		}
+		if err := WriteLock(lock, lockPath); err != nil {
+			return result, fmt.Errorf("failed to write lock: %w", err)
+		}
+	}
+
 	// Write provenance metadata if any sources succeeded
 	if len(result.Sources) > 0 && result.Metadata != nil {
 		provConfig := config.Provenance
@@ -97,30 +158,144 @@ func PerformSync(opts SyncOptions) (*SyncResult, error) {
 	return result, nil
 }
 
-// syncSource syncs a single source (all its assets)
-func syncSource(source Source, opts SyncOptions, result *SyncResult) error {
+// selectSources returns the configured sources named in only (all sources when empty)
+func selectSources(sources []Source, only []string) ([]Source, error) {
+	if len(only) == 0 {
+		return sources, nil
+	}
+	selected := make([]Source, 0, len(only))
+	for _, name := range only {
+		found := false
+		for _, source := range sources {
+			if source.Name == name {
+				selected = append(selected, source)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown source %q", name)
+		}
+	}
+	return selected, nil
+}
+
+// readProvenance loads the aggregate provenance manifest, or nil when absent
+func readProvenance(path string) *Provenance {
+	// #nosec G304 -- provenance path is controlled by repo SSOT config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var provenance Provenance
+	if err := json.Unmarshal(data, &provenance); err != nil {
+		logger.Debug(fmt.Sprintf("ssot: ignoring unreadable provenance %s: %v", path, err))
+		return nil
+	}
+	return &provenance
+}
+
+// previousSync describes what was last synced for source: the lock entry when
+// present, otherwise the commit recorded in provenance metadata
+func previousSync(source Source, locked *LockedSource, provenance *Provenance) *LockedSource {
+	if locked != nil {
+		return locked
+	}
+	if provenance == nil {
+		return nil
+	}
+	for _, meta := range provenance.Sources {
+		if meta.Name == source.Name && meta.Commit != "" {
+			return &LockedSource{Name: source.Name, Commit: meta.Commit}
+		}
+	}
+	return nil
+}
+
+// syncFile is a single file planned for copying
+type syncFile struct {
+	src    string
+	dst    string
+	rel    string
+	digest string // SHA-256 of src
+}
+
+// syncSource syncs a single source (all its assets) and returns its lock entry.
+// When locked is set, remote sources are checked out at the locked commit and,
+// in frozen mode or with strategy.verify_checksums, digests are verified before
+// any file is written. previous is the last synced state and serves as the
+// merge base for locally modified files.
+func syncSource(source Source, locked, previous *LockedSource, opts SyncOptions, result *SyncResult) (*LockedSource, error) {
+	pinned := pinnedCommit(source, locked)
+
 	// Resolve source to filesystem path
-	resolved, err := ResolveSource(source)
+	resolved, err := resolveSource(source, pinned)
 	if err != nil {
-		return fmt.Errorf("failed to resolve source: %w", err)
+		return nil, fmt.Errorf("failed to resolve source: %w", err)
 	}
 
 	if opts.Verbose {
-		logger.Info(fmt.Sprintf("Syncing source: %s from %s", source.Name, resolved.Path))
+		if pinned != "" {
+			logger.Info(fmt.Sprintf("Syncing source: %s from %s (locked at %s)", source.Name, resolved.Path, shortDigest(pinned)))
+		} else {
+			logger.Info(fmt.Sprintf("Syncing source: %s from %s", source.Name, resolved.Path))
+		}
+	}
+
+	entry := &LockedSource{
+		Name:   source.Name,
+		Repo:   source.Repo,
+		Ref:    source.Ref,
+		Commit: resolved.Commit,
+		Files:  make(map[string]string),
+	}
+
+	// Plan copies up front so digests can be verified before touching the tree
+	planned := make([][]syncFile, len(source.Assets))
+	failed := make([]bool, len(source.Assets))
+	for i, asset := range source.Assets {
+		if asset.Mode == "link" {
+			continue
+		}
+		files, err := collectAssetFiles(source, asset, resolved.Path)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("asset %s/%s: %w", source.Name, asset.Type, err))
+			failed[i] = true
+			continue
+		}
+		planned[i] = files
+		for _, f := range files {
+			entry.Files[filepath.ToSlash(f.dst)] = f.digest
+		}
+	}
+
+	if locked != nil && (opts.Frozen || opts.Config.Strategy.VerifyChecksums) {
+		if err := locked.compareDigests(entry.Files, opts.Frozen); err != nil {
+			return nil, err
+		}
+	}
+
+	resolver := &conflictResolver{
+		strategy: opts.Config.Strategy.OnConflict,
+		style:    opts.Config.Strategy.ConflictStyle,
+		label:    upstreamLabel(source.Name, resolved.Commit),
+	}
+	if previous != nil {
+		resolver.base = openBaseReader(resolved.RepoRoot, previous.Commit)
+		resolver.digests = previous.Files
 	}
 
 	// Track outputs for metadata
 	outputs := make(map[string]string)
 
 	// Process each asset
-	for _, asset := range source.Assets {
-		destRoot := asset.Subdir
-		if source.Output != "" {
-			destRoot = filepath.Join(source.Output, asset.Subdir)
+	for i, asset := range source.Assets {
+		outputs[asset.Type] = assetDestRoot(source, asset)
+		if failed[i] {
+			continue
 		}
-		outputs[asset.Type] = destRoot
 
-		if err := syncAsset(source, asset, resolved.Path, opts, result); err != nil {
+		if err := syncAsset(source, asset, planned[i], resolver, resolved.Path, opts, result); err != nil {
 			result.Errors = append(result.Errors, fmt.Errorf("asset %s/%s: %w", source.Name, asset.Type, err))
 			continue
 		}
@@ -135,22 +310,86 @@ func syncSource(source Source, opts SyncOptions, result *SyncResult) error {
 			result.Metadata = &Provenance{Sources: []SourceMetadata{}}
 		}
 		result.Metadata.Sources = append(result.Metadata.Sources, *metadata)
+		if entry.Commit == "" {
+			entry.Commit = metadata.Commit
+		}
 	}
 
-	return nil
+	return entry, nil
+}
+
+// assetDestRoot returns the destination directory (or symlink path) for an asset
+func assetDestRoot(source Source, asset Asset) string {
+	if source.Output != "" {
+		return filepath.Join(source.Output, asset.Subdir)
+	}
+	return asset.Subdir
+}
+
+// collectAssetFiles resolves the files a copy-mode asset would sync
+func collectAssetFiles(source Source, asset Asset, basePath string) ([]syncFile, error) {
+	destRoot := assetDestRoot(source, asset)
+
+	// Determine the effective base path for pattern matching and relative path calculation
+	// If source_path is specified, use it as subdirectory within basePath
+	effectiveBasePath := basePath
+	if asset.SourcePath != "" {
+		effectiveBasePath = filepath.Join(basePath, asset.SourcePath)
+	}
+
+	var files []syncFile
+	seen := make(map[string]bool)
+	for _, pattern := range asset.Paths {
+		// Build full pattern relative to effective base path
+		fullPattern := filepath.Join(effectiveBasePath, pattern)
+
+		// Find matching files
+		matches, err := doublestar.FilepathGlob(fullPattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to glob pattern %s: %w", pattern, err)
+		}
+
+		for _, srcPath := range matches {
+			// Skip directories
+			info, err := os.Stat(srcPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to stat %s: %w", srcPath, err)
+			}
+			if info.IsDir() || seen[srcPath] {
+				continue
+			}
+			seen[srcPath] = true
+
+			// Calculate relative path from effective base path (not the original basePath)
+			relPath, err := filepath.Rel(effectiveBasePath, srcPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to calculate relative path: %w", err)
+			}
+
+			digest, err := fileDigest(srcPath)
+			if err != nil {
+				return nil, err
+			}
+
+			files = append(files, syncFile{
+				src:    srcPath,
+				dst:    filepath.Join(destRoot, relPath),
+				rel:    relPath,
+				digest: digest,
+			})
+		}
+	}
+	return files, nil
 }
 
 // syncAsset syncs a single asset (docs, schemas, etc.)
-func syncAsset(source Source, asset Asset, basePath string, opts SyncOptions, result *SyncResult) error {
+func syncAsset(source Source, asset Asset, files []syncFile, resolver *conflictResolver, basePath string, opts SyncOptions, result *SyncResult) error {
 	mode := asset.Mode
 	if mode == "" {
 		mode = "copy"
 	}
 
-	destRoot := asset.Subdir
-	if source.Output != "" {
-		destRoot = filepath.Join(source.Output, asset.Subdir)
-	}
+	destRoot := assetDestRoot(source, asset)
 
 	if opts.Verbose {
 		switch mode {
@@ -167,7 +406,7 @@ func syncAsset(source Source, asset Asset, basePath string, opts SyncOptions, re
 		case "link":
 			logger.Info(fmt.Sprintf("[DRY RUN] Would link %s to %s", destRoot, filepath.Join(basePath, asset.Link)))
 		default:
-			logger.Info(fmt.Sprintf("[DRY RUN] Would sync %s assets to %s", asset.Type, destRoot))
+			logger.Info(fmt.Sprintf("[DRY RUN] Would sync %d %s file(s) to %s", len(files), asset.Type, destRoot))
 		}
 		return nil
 	}
@@ -176,6 +415,16 @@ func syncAsset(source Source, asset Asset, basePath string, opts SyncOptions, re
 		return createSymlinkAsset(asset, destRoot, basePath, opts)
 	}
 
+	// Decide how to treat local modifications before pruning removes them
+	actions := make([]fileAction, 0, len(files))
+	for _, f := range files {
+		action, err := resolver.resolve(f)
+		if err != nil {
+			return err
+		}
+		actions = append(actions, action)
+	}
+
 	// Remove existing destination if prune_stale is enabled
 	if opts.Config.Strategy.PruneStale {
 		if err := os.RemoveAll(destRoot); err != nil && !os.IsNotExist(err) {
@@ -188,59 +437,45 @@ func syncAsset(source Source, asset Asset, basePath string, opts SyncOptions, re
 		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
-	// Copy files matching glob patterns
+	// Copy, merge or keep each planned file
 	filesCopied := 0
+	for _, action := range actions {
+		f := action.file
 
-	// Determine the effective base path for pattern matching and relative path calculation
-	// If source_path is specified, use it as subdirectory within basePath
-	effectiveBasePath := basePath
-	if asset.SourcePath != "" {
-		effectiveBasePath = filepath.Join(basePath, asset.SourcePath)
-	}
-
-	for _, pattern := range asset.Paths {
-		// Build full pattern relative to effective base path
-		fullPattern := filepath.Join(effectiveBasePath, pattern)
-
-		// Find matching files
-		matches, err := doublestar.FilepathGlob(fullPattern)
-		if err != nil {
-			return fmt.Errorf("failed to glob pattern %s: %w", pattern, err)
+		// Create parent directory
+		if err := os.MkdirAll(filepath.Dir(f.dst), 0750); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", f.dst, err)
 		}
 
-		// Copy each matching file
-		for _, srcPath := range matches {
-			// Skip directories
-			info, err := os.Stat(srcPath)
-			if err != nil {
-				return fmt.Errorf("failed to stat %s: %w", srcPath, err)
-			}
-			if info.IsDir() {
-				continue
-			}
-
-			// Calculate relative path from effective base path (not the original basePath)
-			relPath, err := filepath.Rel(effectiveBasePath, srcPath)
-			if err != nil {
-				return fmt.Errorf("failed to calculate relative path: %w", err)
+		if action.content == nil {
+			// Copy file
+			if err := copyFile(f.src, f.dst); err != nil {
+				return fmt.Errorf("failed to copy %s: %w", f.rel, err)
 			}
-
-			// Calculate destination path
-			dstPath := filepath.Join(destRoot, relPath)
-
-			// Create parent directory
-			if err := os.MkdirAll(filepath.Dir(dstPath), 0750); err != nil {
-				return fmt.Errorf("failed to create directory for %s: %w", dstPath, err)
+			filesCopied++
+			if opts.Verbose {
+				logger.Info(fmt.Sprintf("  Copied: %s", f.rel))
 			}
+			continue
+		}
 
-			// Copy file
-			if err := copyFile(srcPath, dstPath); err != nil {
-				return fmt.Errorf("failed to copy %s: %w", relPath, err)
+		if err := writeResolved(action); err != nil {
+			return err
+		}
+		switch {
+		case action.keep:
+			result.Skipped = append(result.Skipped, f.dst)
+			if opts.Verbose {
+				logger.Info(fmt.Sprintf("  Kept local changes: %s", f.rel))
 			}
-
+		case action.conflict:
+			result.Conflicts = append(result.Conflicts, f.dst)
+			logger.Warn(fmt.Sprintf("Merge conflict in %s", f.dst))
+		default:
+			result.Merged = append(result.Merged, f.dst)
 			filesCopied++
 			if opts.Verbose {
-				logger.Info(fmt.Sprintf("  Copied: %s", relPath))
+				logger.Info(fmt.Sprintf("  Merged: %s", f.rel))
 			}
 		}
 	}
@@ -249,6 +484,31 @@ func syncAsset(source Source, asset Asset, basePath string, opts SyncOptions, re
 	return nil
 }
 
+// writeResolved writes kept or merged content, plus the .rej report for
+// conflicts in conflict_style: rej
+func writeResolved(action fileAction) error {
+	f := action.file
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(f.src); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(f.dst, action.content, mode); err != nil { // #nosec G306 G703 - mirrors upstream file mode
+		return fmt.Errorf("failed to write %s: %w", f.dst, err)
+	}
+
+	rejPath := f.dst + ".rej"
+	if action.rejects == "" {
+		if err := os.Remove(rejPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove stale %s: %w", rejPath, err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(rejPath, []byte(action.rejects), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", rejPath, err)
+	}
+	return nil
+}
+
 func createSymlinkAsset(asset Asset, destPath, basePath string, opts SyncOptions) error {
 	targetPath := filepath.Join(basePath, asset.Link)
 
@@ -37,9 +37,10 @@ type Asset struct {
 
 // Strategy defines sync behavior
 type Strategy struct {
-	OnConflict      string `yaml:"on_conflict"`      // "overwrite", "skip", "error"
-	PruneStale      bool   `yaml:"prune_stale"`      // Remove files not in source
-	VerifyChecksums bool   `yaml:"verify_checksums"` // Verify file integrity
+	OnConflict      string `yaml:"on_conflict"`              // Locally modified files: "overwrite", "skip", "error", "merge"
+	ConflictStyle   string `yaml:"conflict_style,omitempty"` // Merge conflicts: "markers" (default) or "rej"
+	PruneStale      bool   `yaml:"prune_stale"`              // Remove files not in source
+	VerifyChecksums bool   `yaml:"verify_checksums"`         // Verify file integrity
 }
 
 // SyncOptions contains runtime options for sync operation
@@ -47,7 +48,11 @@ type SyncOptions struct {
 	Config        *SyncConfig
 	DryRun        bool
 	Verbose       bool
-	ForceRemoteBy string // Track how force-remote was activated: "flag", "env", "config", "" (v0.3.4+)
+	ForceRemoteBy string   // Track how force-remote was activated: "flag", "env", "config", "" (v0.3.4+)
+	LockPath      string   // Lock file path (default: .goneat/ssot.lock)
+	Frozen        bool     // Sync exactly the locked commits and fail on digest mismatches
+	Update        bool     // Re-resolve refs instead of reusing locked commits
+	Only          []string // Restrict the sync to these source names (default: all)
 }
 
 // SyncResult contains the results of a sync operation
@@ -55,6 +60,9 @@ type SyncResult struct {
 	Sources      []string    // Successfully synced source names
 	FilesCopied  int         // Number of files copied
 	FilesRemoved int         // Number of files removed
+	Merged       []string    // Locally modified files merged cleanly with upstream
+	Conflicts    []string    // Locally modified files left with merge conflicts
+	Skipped      []string    // Locally modified files kept (on_conflict: skip)
 	Errors       []error     // Any non-fatal errors encountered
 	Metadata     *Provenance // Captured provenance metadata (v0.3.0+)
 }
@@ -66,4 +74,5 @@ type ResolvedSource struct {
 	RepoRoot string // Repository root path (for metadata/version detection)
 	IsLocal  bool   // Whether this is a local path (not cloned)
 	IsCloned bool   // Whether this was cloned via go-git
+	Commit   string // Checked-out commit (cloned sources only)
 }
@@ -0,0 +1,308 @@
+// Package supplychain implements offline supply-chain heuristics for resolved
+// dependencies: lookalike (typosquat) names, private package names resolved
+// from public registries (dependency confusion) and npm install scripts.
+package supplychain
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fulmenhq/goneat/pkg/config"
+)
+
+// Ecosystems with a bundled popular-package list and default registry.
+const (
+	EcosystemNPM   = "npm"
+	EcosystemPyPI  = "pypi"
+	EcosystemCargo = "cargo"
+	EcosystemGo    = "golang"
+)
+
+// DefaultPublicRegistries are the registry locations treated as public when
+// the policy does not list its own.
+var DefaultPublicRegistries = []string{
+	"registry.npmjs.org",
+	"registry.yarnpkg.com",
+	"registry.npmmirror.com",
+	"pypi.org",
+	"files.pythonhosted.org",
+	"pypi.python.org",
+	"github.com/rust-lang/crates.io-index",
+	"index.crates.io",
+	"static.crates.io",
+	"proxy.golang.org",
+}
+
+var defaultRegistries = map[string]string{
+	EcosystemNPM:   "https://registry.npmjs.org/",
+	EcosystemPyPI:  "https://pypi.org/simple",
+	EcosystemCargo: "registry+https://github.com/rust-lang/crates.io-index",
+	EcosystemGo:    "https://proxy.golang.org",
+}
+
+// DefaultRegistry returns the registry a package manager uses when nothing
+// else is configured.
+func DefaultRegistry(ecosystem string) string {
+	return defaultRegistries[ecosystem]
+}
+
+// Package is a resolved dependency as recorded in a lockfile.
+type Package struct {
+	Ecosystem string
+	Name      string
+	Version   string
+	// Source is the registry, index or tarball URL the package resolves
+	// from; empty means the ecosystem's default registry.
+	Source string
+	// InstallScripts lists npm lifecycle scripts run on install
+	// (preinstall, install, postinstall).
+	InstallScripts []string
+	// SourcePath is the lockfile the package was read from.
+	SourcePath string
+}
+
+// FindingType classifies a supply-chain finding.
+type FindingType string
+
+const (
+	Typosquat           FindingType = "typosquat"
+	DependencyConfusion FindingType = "dependency_confusion"
+	InstallScript       FindingType = "install_script"
+)
+
+// Finding is a supply-chain heuristic hit for one package.
+type Finding struct {
+	Type     FindingType
+	Severity string
+	Package  Package
+	Message  string
+	// Similar is the popular package a typosquat candidate resembles.
+	Similar string
+}
+
+// Checker evaluates packages against the supply-chain policy.
+type Checker struct {
+	config  config.SupplyChainConfig
+	popular map[string][]string
+}
+
+// NewChecker creates a checker using the bundled popular-package lists.
+func NewChecker(cfg config.SupplyChainConfig) *Checker {
+	return &Checker{config: cfg, popular: popularPackages()}
+}
+
+// Check returns the findings for pkgs, ordered by package and type.
+func (c *Checker) Check(pkgs []Package) []Finding {
+	if !c.config.Enabled {
+		return nil
+	}
+	var findings []Finding
+	for _, pkg := range pkgs {
+		private := c.isPrivate(pkg)
+		if private && c.isPublicSource(pkg) {
+			findings = append(findings, Finding{
+				Type:     DependencyConfusion,
+				Severity: "critical",
+				Package:  pkg,
+				Message:  fmt.Sprintf("%s %s matches a private namespace but resolves from public registry %s", pkg.Ecosystem, pkg.Name, sourceLabel(pkg)),
+			})
+		}
+		if c.config.Typosquat.Enabled && !private {
+			if similar, distance := c.lookalike(pkg); similar != "" {
+				// A single inserted or deleted character on a short name is also
+				// how legitimate neighbours (preact, react) look, so it only
+				// warrants medium. Swaps and substitutions (lodahs) stay high.
+				severity := "high"
+				if name := comparableName(pkg); distance == 1 && len(name) < 8 && len(name) != len(similar) {
+					severity = "medium"
+				}
+				findings = append(findings, Finding{
+					Type:     Typosquat,
+					Severity: severity,
+					Package:  pkg,
+					Similar:  similar,
+					Message:  fmt.Sprintf("%s %s is a lookalike of popular package %s", pkg.Ecosystem, pkg.Name, similar),
+				})
+			}
+		}
+		if c.config.InstallScripts.Enabled && pkg.Ecosystem == EcosystemNPM && len(pkg.InstallScripts) > 0 && !matchesAny(pkg, c.config.InstallScripts.Allow) {
+			severity := c.config.InstallScripts.Severity
+			if severity == "" {
+				severity = "low"
+			}
+			findings = append(findings, Finding{
+				Type:     InstallScript,
+				Severity: severity,
+				Package:  pkg,
+				Message:  fmt.Sprintf("npm %s@%s runs install scripts (%s)", pkg.Name, pkg.Version, strings.Join(pkg.InstallScripts, ", ")),
+			})
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Package.Name != findings[j].Package.Name {
+			return findings[i].Package.Name < findings[j].Package.Name
+		}
+		return findings[i].Type < findings[j].Type
+	})
+	return findings
+}
+
+func (c *Checker) isPrivate(pkg Package) bool {
+	return matchesAny(pkg, c.config.PrivateNamespaces)
+}
+
+func (c *Checker) isPublicSource(pkg Package) bool {
+	source := pkg.Source
+	if source == "" {
+		source = DefaultRegistry(pkg.Ecosystem)
+	}
+	registries := c.config.PublicRegistries
+	if len(registries) == 0 {
+		registries = DefaultPublicRegistries
+	}
+	location := registryLocation(source)
+	for _, registry := range registries {
+		if strings.HasPrefix(location, registryLocation(registry)) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookalike returns the popular package pkg is a near miss of and the edit
+// distance between them. Short names are skipped and names under 8
+// characters allow a single edit, because short popular names sit close
+// together.
+func (c *Checker) lookalike(pkg Package) (string, int) {
+	name := comparableName(pkg)
+	if len(name) < 5 || matchesAny(pkg, c.config.Typosquat.Allow) {
+		return "", 0
+	}
+	maxDistance := c.config.Typosquat.MaxDistance
+	if maxDistance <= 0 {
+		maxDistance = 2
+	}
+	if len(name) < 8 && maxDistance > 1 {
+		maxDistance = 1
+	}
+	best, bestDistance := "", maxDistance+1
+	owner := namespace(name)
+	for _, popular := range c.popular[pkg.Ecosystem] {
+		candidate := strings.ToLower(popular)
+		if candidate == name {
+			return "", 0
+		}
+		// Only the namespace owner can publish under it, so a near miss
+		// within the same namespace (golang.org/x/term vs x/text) is benign.
+		if owner != "" && namespace(candidate) == owner {
+			continue
+		}
+		if d := editDistance(name, candidate, maxDistance); d < bestDistance {
+			best, bestDistance = popular, d
+		}
+	}
+	return best, bestDistance
+}
+
+// namespace returns the publisher-controlled prefix of a name: the npm scope
+// or the host and owner of a Go module path.
+func namespace(name string) string {
+	if strings.HasPrefix(name, "@") {
+		scope, _, _ := strings.Cut(name, "/")
+		return scope
+	}
+	parts := strings.SplitN(name, "/", 3)
+	if len(parts) == 3 && strings.Contains(parts[0], ".") {
+		return parts[0] + "/" + parts[1]
+	}
+	return ""
+}
+
+func comparableName(pkg Package) string {
+	name := strings.ToLower(pkg.Name)
+	if pkg.Ecosystem == EcosystemPyPI {
+		name = strings.NewReplacer("_", "-", ".", "-").Replace(name)
+	}
+	return name
+}
+
+// editDistance is the optimal string alignment distance (Levenshtein plus
+// adjacent transpositions). It returns limit+1 once the distance is known to
+// exceed limit.
+func editDistance(a, b string, limit int) int {
+	if d := len(a) - len(b); d > limit || -d > limit {
+		return limit + 1
+	}
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
+
+// matchesAny applies glob patterns (with @org/* and github.com/org/* prefix
+// matching, as in cooling exceptions) to the package name.
+func matchesAny(pkg Package, patterns []string) bool {
+	name := comparableName(pkg)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if matched, err := filepath.Match(pattern, name); err == nil && matched {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok && strings.HasPrefix(name, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// registryLocation reduces a registry or source URL to host and path for
+// prefix comparison.
+func registryLocation(source string) string {
+	source = strings.ToLower(strings.TrimSpace(source))
+	for _, prefix := range []string{"registry+", "sparse+", "git+"} {
+		source = strings.TrimPrefix(source, prefix)
+	}
+	if _, rest, ok := strings.Cut(source, "://"); ok {
+		source = rest
+	}
+	if at := strings.Index(source, "@"); at >= 0 && at < strings.Index(source+"/", "/") {
+		source = source[at+1:]
+	}
+	return source
+}
+
+func sourceLabel(pkg Package) string {
+	source := pkg.Source
+	if source == "" {
+		source = DefaultRegistry(pkg.Ecosystem)
+	}
+	location := registryLocation(source)
+	if host, _, ok := strings.Cut(location, "/"); ok && pkg.Ecosystem != EcosystemCargo {
+		return host
+	}
+	return location
+}
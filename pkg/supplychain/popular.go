@@ -0,0 +1,45 @@
+package supplychain
+
+import (
+	"bufio"
+	"embed"
+	"strings"
+	"sync"
+)
+
+// The bundled lists hold the most depended-upon package names per ecosystem
+// so typosquat detection works offline.
+//
+//go:embed data/*.txt
+var popularData embed.FS
+
+var (
+	popularOnce sync.Once
+	popularList map[string][]string
+)
+
+func popularPackages() map[string][]string {
+	popularOnce.Do(func() {
+		popularList = map[string][]string{}
+		for _, ecosystem := range []string{EcosystemNPM, EcosystemPyPI, EcosystemCargo, EcosystemGo} {
+			data, err := popularData.ReadFile("data/" + ecosystem + ".txt")
+			if err != nil {
+				continue
+			}
+			scanner := bufio.NewScanner(strings.NewReader(string(data)))
+			for scanner.Scan() {
+				line := strings.TrimSpace(scanner.Text())
+				if line == "" || strings.HasPrefix(line, "#") {
+					continue
+				}
+				popularList[ecosystem] = append(popularList[ecosystem], line)
+			}
+		}
+	})
+	return popularList
+}
+
+// PopularPackages returns the bundled popular-package names for ecosystem.
+func PopularPackages(ecosystem string) []string {
+	return append([]string(nil), popularPackages()[ecosystem]...)
+}
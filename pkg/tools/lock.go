@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LockFileName is the repository-relative location of the tools lockfile.
+const LockFileName = ".goneat/tools.lock"
+
+// LockVersion is the current lockfile format version.
+const LockVersion = 1
+
+// Lock pins the exact tool installations resolved for a repository.
+// It is written by `goneat doctor tools lock` and enforced by `--frozen`.
+// The lock holds no timestamps or machine-specific paths, so regenerating it
+// for unchanged tools produces identical content.
+type Lock struct {
+	Version  int                     `yaml:"version" json:"version"`
+	Platform string                  `yaml:"platform" json:"platform"` // GOOS/GOARCH the lock was generated on
+	Scopes   map[string][]LockedTool `yaml:"scopes" json:"scopes"`
+}
+
+// LockedTool records the resolved installation of a single tool.
+type LockedTool struct {
+	Name           string `yaml:"name" json:"name"`
+	Version        string `yaml:"version" json:"version"`
+	InstallMethod  string `yaml:"install_method" json:"install_method"`
+	BinarySHA256   string `yaml:"binary_sha256,omitempty" json:"binary_sha256,omitempty"`
+	ArtifactSHA256 string `yaml:"artifact_sha256,omitempty" json:"artifact_sha256,omitempty"`
+}
+
+// NewLock returns an empty lock stamped for the current platform.
+func NewLock() *Lock {
+	return &Lock{
+		Version:  LockVersion,
+		Platform: CurrentPlatform(),
+		Scopes:   map[string][]LockedTool{},
+	}
+}
+
+// CurrentPlatform returns the GOOS/GOARCH pair used to stamp lockfiles.
+func CurrentPlatform() string {
+	return runtime.GOOS + "/" + runtime.GOARCH
+}
+
+// Find returns the locked entry for a tool within a scope.
+func (l *Lock) Find(scope, name string) (LockedTool, bool) {
+	for _, entry := range l.Scopes[scope] {
+		if entry.Name == name {
+			return entry, true
+		}
+	}
+	return LockedTool{}, false
+}
+
+// FindAny returns the first locked entry for a tool across all scopes,
+// iterating scopes in sorted order for deterministic results.
+func (l *Lock) FindAny(name string) (LockedTool, bool) {
+	scopes := make([]string, 0, len(l.Scopes))
+	for scope := range l.Scopes {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	for _, scope := range scopes {
+		if entry, ok := l.Find(scope, name); ok {
+			return entry, true
+		}
+	}
+	return LockedTool{}, false
+}
+
+// SamePlatform reports whether the lock was generated on the current platform.
+// Binary digests are only comparable when this is true.
+func (l *Lock) SamePlatform() bool {
+	return l.Platform == CurrentPlatform()
+}
+
+// LoadLock reads and parses a lockfile.
+func LoadLock(path string) (*Lock, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is the repo lockfile location or an explicit CLI argument
+	if err != nil {
+		return nil, err
+	}
+	var lock Lock
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("yaml parse error: %w", err)
+	}
+	if lock.Version != LockVersion {
+		return nil, fmt.Errorf("unsupported tools lock version %d (expected %d)", lock.Version, LockVersion)
+	}
+	if lock.Scopes == nil {
+		lock.Scopes = map[string][]LockedTool{}
+	}
+	return &lock, nil
+}
+
+// WriteLock serializes a lockfile with tools sorted by name in each scope.
+func WriteLock(path string, lock *Lock) error {
+	for scope := range lock.Scopes {
+		entries := lock.Scopes[scope]
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	}
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tools lock: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	header := []byte("# Generated by `goneat doctor tools lock`. Do not edit by hand.\n")
+	return os.WriteFile(path, append(header, data...), 0o600)
+}
+
+// FileSHA256 returns the hex-encoded SHA256 digest of a file.
+func FileSHA256(path string) (string, error) {
+	file, err := os.Open(path) // #nosec G304 - path is a resolved tool binary
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to compute hash: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// ArtifactForVersion returns the artifact for the current platform at the given
+// version, or at the manifest default version when version is empty. A version
+// the manifest does not list is an error, so digests of unknown installs are
+// never taken from another version.
+func (m *ArtifactManifest) ArtifactForVersion(version string) (*Artifact, error) {
+	if m == nil {
+		return nil, fmt.Errorf("no artifacts manifest")
+	}
+	if version == "" {
+		version = m.DefaultVersion
+	}
+	versionArtifacts, ok := m.Versions[version]
+	if !ok {
+		return nil, fmt.Errorf("version %s not found in artifacts manifest", version)
+	}
+	return selectArtifactForPlatform(versionArtifacts)
+}
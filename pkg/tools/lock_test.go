@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteAndLoadLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".goneat", "tools.lock")
+
+	lock := NewLock()
+	lock.Scopes["go"] = []LockedTool{
+		{Name: "gosec", Version: "2.21.0", InstallMethod: "go-install", BinarySHA256: "abc"},
+		{Name: "goimports", Version: "0.25.0", InstallMethod: "go-install"},
+	}
+
+	if err := WriteLock(path, lock); err != nil {
+		t.Fatalf("WriteLock failed: %v", err)
+	}
+
+	loaded, err := LoadLock(path)
+	if err != nil {
+		t.Fatalf("LoadLock failed: %v", err)
+	}
+	if loaded.Platform != CurrentPlatform() || !loaded.SamePlatform() {
+		t.Errorf("expected platform %s, got %s", CurrentPlatform(), loaded.Platform)
+	}
+	entries := loaded.Scopes["go"]
+	if len(entries) != 2 || entries[0].Name != "goimports" {
+		t.Fatalf("expected entries sorted by name, got %+v", entries)
+	}
+	if got, ok := loaded.Find("go", "gosec"); !ok || got.BinarySHA256 != "abc" {
+		t.Errorf("Find returned %+v, %v", got, ok)
+	}
+	if _, ok := loaded.FindAny("gosec"); !ok {
+		t.Error("FindAny should locate gosec across scopes")
+	}
+}
+
+func TestLoadLock_UnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tools.lock")
+	lock := NewLock()
+	lock.Version = 99
+	if err := WriteLock(path, lock); err != nil {
+		t.Fatalf("WriteLock failed: %v", err)
+	}
+	if _, err := LoadLock(path); err == nil {
+		t.Fatal("expected error for unsupported lock version")
+	}
+}
+
+func TestWriteLock_StableForUnchangedTools(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tools.lock")
+	write := func() string {
+		t.Helper()
+		lock := NewLock()
+		lock.Scopes["go"] = []LockedTool{{Name: "gosec", Version: "2.21.0", InstallMethod: "go-install", BinarySHA256: "abc"}}
+		if err := WriteLock(path, lock); err != nil {
+			t.Fatalf("WriteLock failed: %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	first := write()
+	if second := write(); first != second {
+		t.Fatalf("expected identical lock content, got:\n%s\n---\n%s", first, second)
+	}
+}
+
+func TestArtifactForVersion_UnknownVersion(t *testing.T) {
+	artifact := &Artifact{URL: "https://example.com/tool.tar.gz", SHA256: "abc"}
+	all := VersionArtifacts{DarwinAMD64: artifact, DarwinARM64: artifact, LinuxAMD64: artifact, LinuxARM64: artifact, WindowsAMD64: artifact}
+	manifest := &ArtifactManifest{DefaultVersion: "1.0.0", Versions: map[string]VersionArtifacts{"1.0.0": all}}
+
+	if _, err := manifest.ArtifactForVersion(""); err != nil {
+		t.Fatalf("expected default version artifact, got %v", err)
+	}
+	if _, err := manifest.ArtifactForVersion("1.0.0"); err != nil {
+		t.Fatalf("expected artifact for listed version, got %v", err)
+	}
+	if _, err := manifest.ArtifactForVersion("2.0.0"); err == nil {
+		t.Fatal("expected error for a version the manifest does not list")
+	}
+}
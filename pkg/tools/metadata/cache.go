@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/fulmenhq/goneat/pkg/registry"
 )
 
 // Cache TTL Strategy:
@@ -23,6 +25,14 @@ import (
 //   - GitHub releases: Publish date is immutable once released
 //   - Download counts: May lag by 1-2 hours, acceptable for cooling policy
 //   - Version tags: Never change (semantic versioning guarantee)
+//
+// Persistent cache:
+//
+// NewPersistentRegistry layers a registry.DiskCache behind the in-memory cache so
+// publish dates survive across runs and CI jobs. Disk entries are keyed by
+// fetcher name (ecosystem), repo and version; versioned entries live long while
+// "latest" entries use the disk cache's short latest TTL. In offline mode only
+// disk entries are served and misses return *registry.OfflineMissError.
 
 // cacheEntry holds cached metadata with expiry time
 type cacheEntry struct {
@@ -37,6 +47,7 @@ type DefaultRegistry struct {
 	mu       sync.RWMutex
 	ttl      time.Duration
 	stats    CacheStats
+	disk     *registry.DiskCache
 }
 
 // NewRegistry creates a new metadata registry with caching
@@ -49,6 +60,18 @@ func NewRegistry(ttl time.Duration) Registry {
 	}
 }
 
+// NewPersistentRegistry creates a metadata registry backed by a disk cache.
+// A nil disk cache behaves like NewRegistry.
+func NewPersistentRegistry(ttl time.Duration, disk *registry.DiskCache) Registry {
+	return &DefaultRegistry{
+		fetchers: make(map[string]Fetcher),
+		cache:    make(map[string]*cacheEntry),
+		ttl:      ttl,
+		stats:    CacheStats{},
+		disk:     disk,
+	}
+}
+
 // RegisterFetcher adds a metadata fetcher to the registry
 func (r *DefaultRegistry) RegisterFetcher(name string, fetcher Fetcher) {
 	r.mu.Lock()
@@ -99,8 +122,9 @@ func (r *DefaultRegistry) GetMetadata(repo, version string) (*Metadata, error) {
 		return nil, fmt.Errorf("no fetcher available for repository: %s", repo)
 	}
 
-	// Fetch metadata
-	meta, err := fetcher.FetchMetadata(repo, version)
+	meta, err := r.fetchThroughDisk(fetcherName, repo, version, func() (*Metadata, error) {
+		return fetcher.FetchMetadata(repo, version)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch metadata via %s: %w", fetcherName, err)
 	}
@@ -159,8 +183,9 @@ func (r *DefaultRegistry) GetLatestMetadata(repo string) (*Metadata, error) {
 		return nil, fmt.Errorf("no fetcher available for repository: %s", repo)
 	}
 
-	// Fetch latest metadata
-	meta, err := fetcher.FetchLatestMetadata(repo)
+	meta, err := r.fetchThroughDisk(fetcherName, repo, registry.LatestVersion, func() (*Metadata, error) {
+		return fetcher.FetchLatestMetadata(repo)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch latest metadata via %s: %w", fetcherName, err)
 	}
@@ -176,7 +201,34 @@ func (r *DefaultRegistry) GetLatestMetadata(repo string) (*Metadata, error) {
 	return meta, nil
 }
 
-// ClearCache removes all cached entries
+// fetchThroughDisk serves a lookup from the disk cache when available, otherwise
+// fetches it and persists the result. Disk write failures are non-fatal.
+func (r *DefaultRegistry) fetchThroughDisk(ecosystem, repo, version string, fetch func() (*Metadata, error)) (*Metadata, error) {
+	if r.disk == nil {
+		return fetch()
+	}
+
+	var cached Metadata
+	if ok, _ := r.disk.Get(ecosystem, repo, version, &cached); ok {
+		r.mu.Lock()
+		r.stats.DiskHits++
+		r.mu.Unlock()
+		cached.Source = "cache"
+		return &cached, nil
+	}
+	if r.disk.Offline() {
+		return nil, &registry.OfflineMissError{Ecosystem: ecosystem, Name: repo, Version: version}
+	}
+
+	meta, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	_ = r.disk.Put(ecosystem, repo, version, meta)
+	return meta, nil
+}
+
+// ClearCache removes all in-memory cached entries (the disk cache is left intact)
 func (r *DefaultRegistry) ClearCache() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -191,9 +243,10 @@ func (r *DefaultRegistry) CacheStats() CacheStats {
 	defer r.mu.RUnlock()
 
 	return CacheStats{
-		Hits:   r.stats.Hits,
-		Misses: r.stats.Misses,
-		Size:   len(r.cache),
+		Hits:     r.stats.Hits,
+		Misses:   r.stats.Misses,
+		DiskHits: r.stats.DiskHits,
+		Size:     len(r.cache),
 	}
 }
 
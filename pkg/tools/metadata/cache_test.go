@@ -270,3 +270,47 @@ func TestManualMetadata(t *testing.T) {
 	assert.Equal(t, -1, meta.RecentDownloads)
 	assert.Equal(t, "manual", meta.Source)
 }
+
+func TestPersistentRegistry_SharesDiskCacheAcrossRuns(t *testing.T) {
+	disk := registry.NewDiskCache(t.TempDir())
+
+	mock := registry.NewMockHTTPFetcher()
+	mock.AddResponse(
+		"https://api.github.com/repos/anchore/syft/releases/tags/v1.33.0",
+		200,
+		`{"tag_name": "v1.33.0", "published_at": "2024-11-01T10:00:00Z", "assets": []}`,
+	)
+	first := NewPersistentRegistry(time.Hour, disk)
+	first.RegisterFetcher("github", NewGitHubFetcherWithHTTP(mock, "", 30*time.Second))
+
+	meta1, err := first.GetMetadata("anchore/syft", "v1.33.0")
+	require.NoError(t, err)
+	assert.Equal(t, "github", meta1.Source)
+
+	// A fresh registry (new run) with no HTTP responses is served from disk
+	second := NewPersistentRegistry(time.Hour, disk)
+	second.RegisterFetcher("github", NewGitHubFetcherWithHTTP(registry.NewMockHTTPFetcher(), "", 30*time.Second))
+
+	meta2, err := second.GetMetadata("anchore/syft", "v1.33.0")
+	require.NoError(t, err)
+	assert.Equal(t, "cache", meta2.Source)
+	assert.True(t, meta1.PublishDate.Equal(meta2.PublishDate))
+	assert.Equal(t, 1, second.CacheStats().DiskHits)
+}
+
+func TestPersistentRegistry_OfflineMiss(t *testing.T) {
+	disk := registry.NewDiskCache(t.TempDir())
+	disk.SetOffline(true)
+
+	reg := NewPersistentRegistry(time.Hour, disk)
+	reg.RegisterFetcher("github", NewGitHubFetcherWithHTTP(registry.NewMockHTTPFetcher(), "", 30*time.Second))
+
+	_, err := reg.GetLatestMetadata("anchore/syft")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, registry.ErrOfflineCacheMiss)
+
+	var missErr *registry.OfflineMissError
+	require.ErrorAs(t, err, &missErr)
+	assert.Equal(t, "github", missErr.Ecosystem)
+	assert.Equal(t, registry.LatestVersion, missErr.Version)
+}
@@ -13,6 +13,9 @@
 //   - Registry: Coordinates fetchers and provides caching
 //   - Fetcher: Interface for fetching metadata from different sources
 //   - GitHub: Implements fetcher for GitHub Releases API
+//   - PyPI: Implements fetcher for PyPI package releases ("pypi/<package>")
+//   - GoModule: Implements fetcher for Go module proxies ("gomod/<module path>"),
+//     honouring GOPROXY, GONOPROXY and GOPRIVATE
 //
 // # Basic Usage
 //
@@ -76,7 +79,6 @@
 //
 // The Fetcher interface supports additional metadata sources:
 //
-//   - Go modules via proxy.golang.org
 //   - Package managers (Homebrew, Scoop, apt, etc.)
 //   - Custom registries or APIs
 //
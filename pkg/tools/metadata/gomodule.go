@@ -0,0 +1,308 @@
+package metadata
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/registry"
+)
+
+// GoModuleRepoPrefix marks repos handled by GoModuleFetcher, e.g.
+// "gomod/golang.org/x/vuln/cmd/govulncheck". Package paths are accepted;
+// the fetcher walks up to the enclosing module path.
+const GoModuleRepoPrefix = "gomod/"
+
+// GoEnv holds the module proxy settings that govern `go install pkg@version`.
+//
+// GOFLAGS is not consulted: `go install` with an explicit version always
+// resolves in module mode (as with -mod=mod) and ignores the current go.mod,
+// so proxy lookups are made regardless of -mod=readonly or -mod=vendor.
+type GoEnv struct {
+	GOPROXY   string
+	GONOPROXY string
+	GOPRIVATE string
+}
+
+// LoadGoEnv reads GOPROXY/GONOPROXY/GOPRIVATE from the environment, falling
+// back to `go env` (which includes values persisted with `go env -w`).
+func LoadGoEnv() GoEnv {
+	env := GoEnv{
+		GOPROXY:   os.Getenv("GOPROXY"),
+		GONOPROXY: os.Getenv("GONOPROXY"),
+		GOPRIVATE: os.Getenv("GOPRIVATE"),
+	}
+	if env.GOPROXY != "" && env.GONOPROXY != "" && env.GOPRIVATE != "" {
+		return env
+	}
+	out, err := exec.Command("go", "env", "-json", "GOPROXY", "GONOPROXY", "GOPRIVATE").Output() // #nosec G204 - fixed arguments
+	if err != nil {
+		return env
+	}
+	var goEnv map[string]string
+	if json.Unmarshal(out, &goEnv) != nil {
+		return env
+	}
+	if env.GOPROXY == "" {
+		env.GOPROXY = goEnv["GOPROXY"]
+	}
+	if env.GONOPROXY == "" {
+		env.GONOPROXY = goEnv["GONOPROXY"]
+	}
+	if env.GOPRIVATE == "" {
+		env.GOPRIVATE = goEnv["GOPRIVATE"]
+	}
+	return env
+}
+
+// NoProxy reports whether the go command fetches modulePath directly instead
+// of through GOPROXY: it matches GONOPROXY, which defaults to GOPRIVATE.
+func (e GoEnv) NoProxy(modulePath string) bool {
+	patterns := e.GONOPROXY
+	if patterns == "" {
+		patterns = e.GOPRIVATE
+	}
+	return matchGlobPrefix(patterns, modulePath)
+}
+
+// goProxyEntry is one element of the GOPROXY list. fallThroughAll is true when
+// the entry is followed by "|", meaning any error falls through to the next
+// proxy; with "," only not-found errors fall through.
+type goProxyEntry struct {
+	url            string
+	fallThroughAll bool
+}
+
+// parseGoProxy splits a GOPROXY value into entries, defaulting like the go command.
+func parseGoProxy(value string) []goProxyEntry {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		value = registry.DefaultGoProxy + ",direct"
+	}
+	var entries []goProxyEntry
+	for value != "" {
+		idx := strings.IndexAny(value, ",|")
+		item, sep := value, byte(0)
+		if idx >= 0 {
+			item, sep = value[:idx], value[idx]
+			value = value[idx+1:]
+		} else {
+			value = ""
+		}
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		entries = append(entries, goProxyEntry{url: item, fallThroughAll: sep == '|'})
+	}
+	return entries
+}
+
+// matchGlobPrefix reports whether any comma-separated glob pattern matches a
+// prefix of the module path, following GONOPROXY/GOPRIVATE semantics.
+func matchGlobPrefix(patterns, target string) bool {
+	for _, pattern := range strings.Split(patterns, ",") {
+		pattern = strings.TrimSpace(strings.TrimSuffix(pattern, "/"))
+		if pattern == "" {
+			continue
+		}
+		n := strings.Count(pattern, "/")
+		prefix := target
+		for i := 0; i < len(target); i++ {
+			if target[i] == '/' {
+				if n == 0 {
+					prefix = target[:i]
+					break
+				}
+				n--
+			}
+		}
+		if n > 0 {
+			continue // target has fewer elements than the pattern
+		}
+		if matched, _ := path.Match(pattern, prefix); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// GoModuleFetcher fetches release metadata for Go-installed tools from the
+// module proxies configured by GOPROXY, via registry.GoClient.
+type GoModuleFetcher struct {
+	httpFetcher registry.HTTPFetcher
+	env         GoEnv
+	timeout     time.Duration
+	mu          sync.Mutex
+	clients     map[string]registry.Client
+}
+
+// NewGoModuleFetcher creates a Go module fetcher using the current Go environment
+func NewGoModuleFetcher(timeout time.Duration) *GoModuleFetcher {
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12},
+		},
+	}
+	return NewGoModuleFetcherWithHTTP(registry.NewRealHTTPFetcher(client), LoadGoEnv(), timeout)
+}
+
+// NewGoModuleFetcherWithHTTP creates a fetcher with injectable HTTP and Go environment for testing
+func NewGoModuleFetcherWithHTTP(httpFetcher registry.HTTPFetcher, env GoEnv, timeout time.Duration) *GoModuleFetcher {
+	return &GoModuleFetcher{
+		httpFetcher: httpFetcher,
+		env:         env,
+		timeout:     timeout,
+		clients:     make(map[string]registry.Client),
+	}
+}
+
+// SupportsRepo returns true for "gomod/<module-or-package-path>" repos
+func (f *GoModuleFetcher) SupportsRepo(repo string) bool {
+	return normalizeGoModulePath(repo) != ""
+}
+
+// FetchMetadata fetches the publish time of a module version from the proxy
+func (f *GoModuleFetcher) FetchMetadata(repo, version string) (*Metadata, error) {
+	version = strings.TrimSpace(version)
+	if version != "" && !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return f.fetch(repo, version)
+}
+
+// FetchLatestMetadata fetches the proxy's @latest version of a module
+func (f *GoModuleFetcher) FetchLatestMetadata(repo string) (*Metadata, error) {
+	return f.fetch(repo, "latest")
+}
+
+func (f *GoModuleFetcher) fetch(repo, version string) (*Metadata, error) {
+	pkgPath := normalizeGoModulePath(repo)
+	if pkgPath == "" {
+		return nil, fmt.Errorf("unsupported repository format: %s (expected %s<module path>)", repo, GoModuleRepoPrefix)
+	}
+
+	if f.env.NoProxy(pkgPath) {
+		return nil, fmt.Errorf("%w: %s matches GONOPROXY/GOPRIVATE; no proxy metadata available", ErrNotFound, pkgPath)
+	}
+
+	// `go install` accepts package paths; the enclosing module is the longest
+	// prefix the proxy knows about, so walk up until a lookup succeeds.
+	var lastErr error
+	for _, modPath := range goModuleCandidates(pkgPath) {
+		meta, err := f.fetchFromProxies(modPath, version)
+		if err == nil {
+			return meta, nil
+		}
+		lastErr = err
+		if !errors.Is(err, registry.ErrModuleNotFound) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("%w: %s@%s: %v", ErrNotFound, pkgPath, version, lastErr)
+}
+
+// fetchFromProxies queries each GOPROXY entry in order, honouring "," vs "|"
+// fall-through semantics. "direct" entries are skipped because VCS origins do
+// not expose publish metadata; "off" stops the search.
+func (f *GoModuleFetcher) fetchFromProxies(modPath, version string) (*Metadata, error) {
+	var lastErr error
+	for _, entry := range parseGoProxy(f.env.GOPROXY) {
+		switch entry.url {
+		case "off":
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, fmt.Errorf("module lookup disabled by GOPROXY=off")
+		case "direct":
+			continue
+		}
+
+		meta, err := f.clientFor(entry.url).GetMetadata(modPath, version)
+		if err == nil {
+			resolved := version
+			if meta.Version != "" {
+				resolved = meta.Version
+			}
+			return &Metadata{
+				Version:         resolved,
+				PublishDate:     meta.PublishDate,
+				TotalDownloads:  -1, // Go proxy doesn't provide download stats
+				RecentDownloads: -1,
+				Source:          "go-proxy",
+			}, nil
+		}
+		lastErr = err
+		if !entry.fallThroughAll && !errors.Is(err, registry.ErrModuleNotFound) {
+			return nil, &NetworkError{Source: "go-proxy", URL: entry.url, Wrapped: err}
+		}
+	}
+	if lastErr == nil {
+		return nil, fmt.Errorf("no module proxy configured in GOPROXY=%q", f.env.GOPROXY)
+	}
+	return nil, lastErr
+}
+
+func (f *GoModuleFetcher) clientFor(proxyURL string) registry.Client {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	client, ok := f.clients[proxyURL]
+	if !ok {
+		// Caching is handled by the metadata registry; keep the proxy client cache short.
+		client = registry.NewGoClientWithProxy(time.Minute, proxyURL, f.httpFetcher)
+		f.clients[proxyURL] = client
+	}
+	return client
+}
+
+// normalizeGoModulePath strips the gomod/ prefix and any @version suffix.
+func normalizeGoModulePath(repo string) string {
+	repo = strings.TrimSpace(repo)
+	switch {
+	case strings.HasPrefix(repo, GoModuleRepoPrefix):
+		repo = strings.TrimPrefix(repo, GoModuleRepoPrefix)
+	case strings.HasPrefix(repo, "gomod:"):
+		repo = strings.TrimPrefix(repo, "gomod:")
+	default:
+		return ""
+	}
+	if at := strings.Index(repo, "@"); at >= 0 {
+		repo = repo[:at]
+	}
+	repo = strings.Trim(repo, "/")
+	// Module paths start with a domain-like element
+	if first, _, _ := strings.Cut(repo, "/"); !strings.Contains(first, ".") {
+		return ""
+	}
+	return repo
+}
+
+// goModuleCandidates returns the package path and its parents, longest first,
+// stopping at the domain element.
+func goModuleCandidates(pkgPath string) []string {
+	var candidates []string
+	for p := pkgPath; strings.Contains(p, "/"); p = path.Dir(p) {
+		candidates = append(candidates, p)
+	}
+	return candidates
+}
+
+// GoModuleRepoFromInstallPackage converts a `go install` package spec into a
+// GoModuleFetcher repo, e.g. "golang.org/x/vuln/cmd/govulncheck@latest" ->
+// "gomod/golang.org/x/vuln/cmd/govulncheck". Returns "" for invalid input.
+func GoModuleRepoFromInstallPackage(installPackage string) string {
+	repo := normalizeGoModulePath(GoModuleRepoPrefix + installPackage)
+	if repo == "" {
+		return ""
+	}
+	return GoModuleRepoPrefix + repo
+}
@@ -0,0 +1,110 @@
+package metadata
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fulmenhq/goneat/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoModuleFetcher_SupportsRepo(t *testing.T) {
+	f := NewGoModuleFetcherWithHTTP(registry.NewMockHTTPFetcher(), GoEnv{}, time.Second)
+
+	assert.True(t, f.SupportsRepo("gomod/golang.org/x/vuln/cmd/govulncheck"))
+	assert.True(t, f.SupportsRepo("gomod:gopkg.in/yaml.v3"))
+	assert.False(t, f.SupportsRepo("anchore/syft"))
+	assert.False(t, f.SupportsRepo("pypi/yamllint"))
+	assert.False(t, f.SupportsRepo("gomod/notadomain/pkg"))
+}
+
+func TestGoModuleFetcher_FetchMetadata_WalksToModuleRoot(t *testing.T) {
+	mock := registry.NewMockHTTPFetcher()
+	mock.AddResponse(
+		"https://proxy.golang.org/golang.org/x/vuln/@v/v1.1.4.info",
+		200,
+		`{"Version": "v1.1.4", "Time": "2025-01-10T18:00:00Z"}`,
+	)
+	f := NewGoModuleFetcherWithHTTP(mock, GoEnv{}, time.Second)
+
+	// Version without "v" prefix is normalized; package path resolves to module
+	meta, err := f.FetchMetadata("gomod/golang.org/x/vuln/cmd/govulncheck", "1.1.4")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.1.4", meta.Version)
+	assert.Equal(t, "go-proxy", meta.Source)
+	assert.Equal(t, -1, meta.TotalDownloads)
+	assert.True(t, meta.PublishDate.Equal(time.Date(2025, 1, 10, 18, 0, 0, 0, time.UTC)))
+}
+
+func TestGoModuleFetcher_FetchLatestMetadata(t *testing.T) {
+	mock := registry.NewMockHTTPFetcher()
+	mock.AddResponse(
+		"https://proxy.golang.org/github.com/!burnt!sushi/toml/@latest",
+		200,
+		`{"Version": "v1.4.0", "Time": "2024-06-01T00:00:00Z"}`,
+	)
+	f := NewGoModuleFetcherWithHTTP(mock, GoEnv{GOPROXY: "https://proxy.golang.org,direct"}, time.Second)
+
+	meta, err := f.FetchLatestMetadata("gomod/github.com/BurntSushi/toml")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.4.0", meta.Version)
+}
+
+func TestGoModuleFetcher_ProxyListFallThrough(t *testing.T) {
+	mock := registry.NewMockHTTPFetcher()
+	mock.AddError("https://corp.example.com/proxy/golang.org/x/tools/@v/v0.25.0.info", errors.New("connection refused"))
+	mock.AddResponse(
+		"https://proxy.golang.org/golang.org/x/tools/@v/v0.25.0.info",
+		200,
+		`{"Version": "v0.25.0", "Time": "2024-09-01T00:00:00Z"}`,
+	)
+
+	// "|" falls through on any error
+	f := NewGoModuleFetcherWithHTTP(mock, GoEnv{GOPROXY: "https://corp.example.com/proxy|https://proxy.golang.org"}, time.Second)
+	meta, err := f.FetchMetadata("gomod/golang.org/x/tools", "v0.25.0")
+	require.NoError(t, err)
+	assert.Equal(t, "v0.25.0", meta.Version)
+
+	// "," only falls through on not-found
+	mock.AddError("https://corp.example.com/proxy/golang.org/x/tools/@v/v0.25.0.info", errors.New("connection refused"))
+	f = NewGoModuleFetcherWithHTTP(mock, GoEnv{GOPROXY: "https://corp.example.com/proxy,https://proxy.golang.org"}, time.Second)
+	_, err = f.FetchMetadata("gomod/golang.org/x/tools", "v0.25.0")
+	require.Error(t, err)
+	var netErr *NetworkError
+	assert.ErrorAs(t, err, &netErr)
+}
+
+func TestGoModuleFetcher_PrivateAndOff(t *testing.T) {
+	mock := registry.NewMockHTTPFetcher()
+
+	f := NewGoModuleFetcherWithHTTP(mock, GoEnv{GOPRIVATE: "*.corp.example.com,github.com/acme"}, time.Second)
+	_, err := f.FetchMetadata("gomod/github.com/acme/tool/cmd/tool", "v1.0.0")
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "GOPRIVATE")
+
+	f = NewGoModuleFetcherWithHTTP(mock, GoEnv{GOPROXY: "off"}, time.Second)
+	_, err = f.FetchMetadata("gomod/golang.org/x/tools", "v0.25.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GOPROXY=off")
+}
+
+func TestMatchGlobPrefix(t *testing.T) {
+	assert.True(t, matchGlobPrefix("github.com/acme", "github.com/acme/tool/cmd/tool"))
+	assert.True(t, matchGlobPrefix("*.corp.example.com", "git.corp.example.com/team/mod"))
+	assert.False(t, matchGlobPrefix("github.com/acme", "github.com/acmecorp/tool"))
+	assert.False(t, matchGlobPrefix("", "github.com/acme/tool"))
+}
+
+func TestGoModuleRepoFromInstallPackage(t *testing.T) {
+	assert.Equal(t, "gomod/golang.org/x/vuln/cmd/govulncheck", GoModuleRepoFromInstallPackage("golang.org/x/vuln/cmd/govulncheck@latest"))
+	assert.Equal(t, "", GoModuleRepoFromInstallPackage(""))
+}
+
+func TestGoEnv_NoProxy(t *testing.T) {
+	assert.True(t, GoEnv{GOPRIVATE: "github.com/acme"}.NoProxy("github.com/acme/tool"))
+	assert.False(t, GoEnv{GOPRIVATE: "github.com/acme", GONOPROXY: "none"}.NoProxy("github.com/acme/tool"))
+	assert.True(t, GoEnv{GOPRIVATE: "github.com/acme", GONOPROXY: "*.corp.example.com"}.NoProxy("git.corp.example.com/team/mod"))
+	assert.False(t, GoEnv{}.NoProxy("github.com/acme/tool"))
+}
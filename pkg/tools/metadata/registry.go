@@ -94,5 +94,7 @@ type Registry interface {
 type CacheStats struct {
 	Hits   int
 	Misses int
-	Size   int
+	// DiskHits counts in-memory misses served from the persistent disk cache
+	DiskHits int
+	Size     int
 }
@@ -575,6 +575,30 @@ func (v *Version) String() string {
 	return v.raw
 }
 
+// Major returns the major version number
+func (v *Version) Major() int {
+	if v == nil {
+		return 0
+	}
+	return v.major
+}
+
+// Minor returns the minor version number
+func (v *Version) Minor() int {
+	if v == nil {
+		return 0
+	}
+	return v.minor
+}
+
+// Patch returns the patch version number
+func (v *Version) Patch() int {
+	if v == nil {
+		return 0
+	}
+	return v.patch
+}
+
 // BumpMajor increments the major version and resets minor and patch
 func (v *Version) BumpMajor() *Version {
 	if v == nil {
@@ -9,6 +9,7 @@ import (
 
 type Finding struct {
 	ID             string   `json:"id"`
+	Aliases        []string `json:"aliases,omitempty"`
 	Severity       Severity `json:"severity"`
 	SeverityRaw    string   `json:"severity_raw"`
 	PackageNames   []string `json:"package_names"`
@@ -22,6 +23,8 @@ type Finding struct {
 	AdvisoryURLs   []string `json:"advisory_urls,omitempty"`
 	Suppressed     bool     `json:"suppressed"`
 	SuppressReason string   `json:"suppress_reason,omitempty"`
+	VEXStatus      string   `json:"vex_status,omitempty"`
+	VEXDetail      string   `json:"vex_detail,omitempty"`
 	SourceType     string   `json:"source_type,omitempty"`
 	SourcePaths    []string `json:"source_paths,omitempty"`
 }
@@ -83,6 +86,9 @@ type grypeReport struct {
 			DataSource string   `json:"dataSource"`
 			URLs       []string `json:"urls"`
 		} `json:"vulnerability"`
+		RelatedVulnerabilities []struct {
+			ID string `json:"id"`
+		} `json:"relatedVulnerabilities"`
 	} `json:"matches"`
 }
 
@@ -126,6 +132,12 @@ func ParseGrype(raw []byte) ([]Finding, map[Severity]int, error) {
 			f.FixFirstSeen = firstAvailableDate(m.Vulnerability.Fix.Available)
 		}
 
+		for _, related := range m.RelatedVulnerabilities {
+			if alias := strings.TrimSpace(related.ID); alias != "" && alias != id {
+				f.Aliases = append(f.Aliases, alias)
+			}
+		}
+
 		pkg := strings.TrimSpace(m.Artifact.Name)
 		if pkg != "" {
 			f.PackageNames = append(f.PackageNames, pkg)
@@ -144,6 +156,7 @@ func ParseGrype(raw []byte) ([]Finding, map[Severity]int, error) {
 
 	findings := make([]Finding, 0, len(byID))
 	for _, f := range byID {
+		f.Aliases = dedupeStrings(f.Aliases)
 		f.PackageNames = dedupeStrings(f.PackageNames)
 		f.PURLs = dedupeStrings(f.PURLs)
 		f.SourcePaths = dedupeStrings(f.SourcePaths)
@@ -0,0 +1,423 @@
+package vulnerabilities
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// VEX statuses as defined by OpenVEX; CycloneDX analysis states are mapped
+// onto them when loaded.
+const (
+	VEXNotAffected        = "not_affected"
+	VEXAffected           = "affected"
+	VEXFixed              = "fixed"
+	VEXUnderInvestigation = "under_investigation"
+)
+
+// VEXStatement is a single exploitability statement about a vulnerability in
+// a set of packages. An empty Products list applies to every package.
+type VEXStatement struct {
+	Vulnerability   string    `json:"vulnerability"`
+	Aliases         []string  `json:"aliases,omitempty"`
+	Products        []string  `json:"products,omitempty"`
+	Status          string    `json:"status"`
+	Justification   string    `json:"justification,omitempty"`
+	ImpactStatement string    `json:"impact_statement,omitempty"`
+	ActionStatement string    `json:"action_statement,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+	Source          string    `json:"source,omitempty"`
+}
+
+// Suppresses reports whether the statement clears a finding.
+func (s VEXStatement) Suppresses() bool {
+	return s.Status == VEXNotAffected || s.Status == VEXFixed
+}
+
+// Detail renders the status with its justification or impact statement.
+func (s VEXStatement) Detail() string {
+	switch {
+	case s.Justification != "":
+		return s.Status + ": " + s.Justification
+	case s.ImpactStatement != "":
+		return s.Status + ": " + s.ImpactStatement
+	case s.ActionStatement != "":
+		return s.Status + ": " + s.ActionStatement
+	}
+	return s.Status
+}
+
+// LoadVEXDir loads every *.json OpenVEX or CycloneDX VEX document in dir, in
+// file name order. Documents that cannot be read or parsed are skipped and
+// returned as skipped errors so one bad file does not discard the others. A
+// missing directory yields no statements.
+func LoadVEXDir(dir string) ([]VEXStatement, []error, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to read VEX directory: %w", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.EqualFold(filepath.Ext(e.Name()), ".json") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var statements []VEXStatement
+	var skipped []error
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path) // #nosec G304 -- files enumerated from the configured VEX directory
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("failed to read VEX document %s: %w", path, err))
+			continue
+		}
+		parsed, err := ParseVEX(data)
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("invalid VEX document %s: %w", path, err))
+			continue
+		}
+		for i := range parsed {
+			parsed[i].Source = path
+		}
+		statements = append(statements, parsed...)
+	}
+	return statements, skipped, nil
+}
+
+// ParseVEX parses an OpenVEX document or a CycloneDX BOM carrying
+// vulnerability analysis statements.
+func ParseVEX(data []byte) ([]VEXStatement, error) {
+	var probe struct {
+		Context   string `json:"@context"`
+		BOMFormat string `json:"bomFormat"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+	switch {
+	case strings.Contains(probe.Context, "openvex"):
+		return parseOpenVEX(data)
+	case strings.EqualFold(probe.BOMFormat, "CycloneDX"):
+		return parseCycloneDXVEX(data)
+	}
+	return nil, fmt.Errorf("unrecognized VEX format (expected OpenVEX @context or CycloneDX bomFormat)")
+}
+
+type openVEXProduct struct {
+	ID            string `json:"@id"`
+	Subcomponents []struct {
+		ID string `json:"@id"`
+	} `json:"subcomponents,omitempty"`
+}
+
+type openVEXDocument struct {
+	Timestamp  string `json:"timestamp"`
+	Statements []struct {
+		Vulnerability   json.RawMessage `json:"vulnerability"`
+		Products        json.RawMessage `json:"products"`
+		Status          string          `json:"status"`
+		Justification   string          `json:"justification"`
+		ImpactStatement string          `json:"impact_statement"`
+		ActionStatement string          `json:"action_statement"`
+		Timestamp       string          `json:"timestamp"`
+	} `json:"statements"`
+}
+
+func parseOpenVEX(data []byte) ([]VEXStatement, error) {
+	var doc openVEXDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	out := make([]VEXStatement, 0, len(doc.Statements))
+	for i, st := range doc.Statements {
+		stmt := VEXStatement{
+			Status:          strings.ToLower(strings.TrimSpace(st.Status)),
+			Justification:   st.Justification,
+			ImpactStatement: st.ImpactStatement,
+			ActionStatement: st.ActionStatement,
+			Timestamp:       parseVEXTime(st.Timestamp, doc.Timestamp),
+		}
+		if !validVEXStatus(stmt.Status) {
+			return nil, fmt.Errorf("statement %d: unknown status %q", i, st.Status)
+		}
+
+		// v0.2.0 uses {"name", "aliases"}; v0.0.x used a bare string.
+		var vulnName string
+		var vuln struct {
+			Name    string   `json:"name"`
+			ID      string   `json:"@id"`
+			Aliases []string `json:"aliases"`
+		}
+		if err := json.Unmarshal(st.Vulnerability, &vulnName); err == nil {
+			stmt.Vulnerability = vulnName
+		} else if err := json.Unmarshal(st.Vulnerability, &vuln); err == nil {
+			stmt.Vulnerability = vuln.Name
+			if stmt.Vulnerability == "" {
+				stmt.Vulnerability = vuln.ID
+			}
+			stmt.Aliases = vuln.Aliases
+		}
+		if strings.TrimSpace(stmt.Vulnerability) == "" {
+			return nil, fmt.Errorf("statement %d: missing vulnerability", i)
+		}
+
+		// Products are objects (v0.2.0) or strings (v0.0.x). Subcomponents
+		// name the affected packages inside a product.
+		var productIDs []string
+		var products []openVEXProduct
+		if len(st.Products) > 0 {
+			if err := json.Unmarshal(st.Products, &productIDs); err == nil {
+				stmt.Products = append(stmt.Products, productIDs...)
+			} else if err := json.Unmarshal(st.Products, &products); err != nil {
+				return nil, fmt.Errorf("statement %d: invalid products: %w", i, err)
+			}
+		}
+		for _, p := range products {
+			if len(p.Subcomponents) == 0 {
+				stmt.Products = append(stmt.Products, p.ID)
+				continue
+			}
+			for _, sub := range p.Subcomponents {
+				stmt.Products = append(stmt.Products, sub.ID)
+			}
+		}
+		out = append(out, stmt)
+	}
+	return out, nil
+}
+
+type cycloneDXComponent struct {
+	BOMRef     string               `json:"bom-ref"`
+	PURL       string               `json:"purl"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXVEXDocument struct {
+	Metadata struct {
+		Timestamp string              `json:"timestamp"`
+		Component *cycloneDXComponent `json:"component"`
+	} `json:"metadata"`
+	Components      []cycloneDXComponent `json:"components"`
+	Vulnerabilities []struct {
+		ID         string `json:"id"`
+		References []struct {
+			ID string `json:"id"`
+		} `json:"references"`
+		Analysis *struct {
+			State         string   `json:"state"`
+			Justification string   `json:"justification"`
+			Response      []string `json:"response"`
+			Detail        string   `json:"detail"`
+			LastUpdated   string   `json:"lastUpdated"`
+		} `json:"analysis"`
+		Affects []struct {
+			Ref string `json:"ref"`
+		} `json:"affects"`
+	} `json:"vulnerabilities"`
+}
+
+// cycloneDXStates maps CycloneDX impact analysis states to VEX statuses.
+var cycloneDXStates = map[string]string{
+	"not_affected":           VEXNotAffected,
+	"false_positive":         VEXNotAffected,
+	"resolved":               VEXFixed,
+	"resolved_with_pedigree": VEXFixed,
+	"exploitable":            VEXAffected,
+	"in_triage":              VEXUnderInvestigation,
+}
+
+func parseCycloneDXVEX(data []byte) ([]VEXStatement, error) {
+	var doc cycloneDXVEXDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	refs := map[string]string{}
+	var index func([]cycloneDXComponent)
+	index = func(components []cycloneDXComponent) {
+		for _, c := range components {
+			if c.BOMRef != "" && c.PURL != "" {
+				refs[c.BOMRef] = c.PURL
+			}
+			index(c.Components)
+		}
+	}
+	if doc.Metadata.Component != nil {
+		index([]cycloneDXComponent{*doc.Metadata.Component})
+	}
+	index(doc.Components)
+
+	var out []VEXStatement
+	for _, v := range doc.Vulnerabilities {
+		if v.Analysis == nil || strings.TrimSpace(v.ID) == "" {
+			continue
+		}
+		status, ok := cycloneDXStates[strings.ToLower(v.Analysis.State)]
+		if !ok {
+			return nil, fmt.Errorf("vulnerability %s: unknown analysis state %q", v.ID, v.Analysis.State)
+		}
+		stmt := VEXStatement{
+			Vulnerability:   v.ID,
+			Status:          status,
+			Justification:   v.Analysis.Justification,
+			ImpactStatement: v.Analysis.Detail,
+			ActionStatement: strings.Join(v.Analysis.Response, ", "),
+			Timestamp:       parseVEXTime(v.Analysis.LastUpdated, doc.Metadata.Timestamp),
+		}
+		for _, r := range v.References {
+			if r.ID != "" {
+				stmt.Aliases = append(stmt.Aliases, r.ID)
+			}
+		}
+		for _, a := range v.Affects {
+			ref := a.Ref
+			if purl, ok := refs[ref]; ok {
+				ref = purl
+			} else if idx := strings.Index(ref, "#pkg:"); idx >= 0 {
+				// BOM-Link (urn:cdx:<serial>/<version>#<bom-ref>) with a PURL ref.
+				ref = ref[idx+1:]
+			}
+			stmt.Products = append(stmt.Products, ref)
+		}
+		out = append(out, stmt)
+	}
+	return out, nil
+}
+
+// ApplyVEX annotates findings with the latest matching VEX statement per
+// affected package and suppresses findings whose every package is
+// not_affected or fixed. Statements match by vulnerability ID or alias and by
+// PURL (qualifiers ignored; a versionless PURL matches every version). It
+// returns the number of findings newly suppressed.
+func ApplyVEX(findings []Finding, statements []VEXStatement) int {
+	if len(statements) == 0 {
+		return 0
+	}
+	ordered := append([]VEXStatement(nil), statements...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Timestamp.Before(ordered[j].Timestamp) })
+
+	suppressed := 0
+	for i := range findings {
+		f := &findings[i]
+		purls := f.PURLs
+		if len(purls) == 0 {
+			purls = []string{""}
+		}
+		var applied []VEXStatement
+		for _, purl := range purls {
+			var latest *VEXStatement
+			for j := range ordered {
+				if vexMatchesVulnerability(ordered[j], f) && vexMatchesProduct(ordered[j], purl) {
+					latest = &ordered[j]
+				}
+			}
+			if latest != nil {
+				applied = append(applied, *latest)
+			}
+		}
+		if len(applied) == 0 {
+			continue
+		}
+
+		f.VEXStatus = applied[0].Status
+		f.VEXDetail = applied[0].Detail()
+		allClear := len(applied) == len(purls)
+		for _, st := range applied {
+			if !st.Suppresses() {
+				allClear = false
+				f.VEXStatus = st.Status
+				f.VEXDetail = st.Detail()
+			}
+		}
+		if allClear && !f.Suppressed {
+			f.Suppressed = true
+			f.SuppressReason = "vex " + f.VEXDetail
+			suppressed++
+		}
+	}
+	return suppressed
+}
+
+func vexMatchesVulnerability(st VEXStatement, f *Finding) bool {
+	ids := append([]string{st.Vulnerability}, st.Aliases...)
+	for _, id := range ids {
+		if strings.EqualFold(id, f.ID) {
+			return true
+		}
+		for _, alias := range f.Aliases {
+			if strings.EqualFold(id, alias) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func vexMatchesProduct(st VEXStatement, purl string) bool {
+	if len(st.Products) == 0 {
+		return true
+	}
+	if purl == "" {
+		return false
+	}
+	target := normalizePURL(purl)
+	for _, p := range st.Products {
+		candidate := normalizePURL(p)
+		if candidate == target {
+			return true
+		}
+		if !strings.Contains(purlNameSection(candidate), "@") && purlNameSection(target) != "" &&
+			strings.HasPrefix(target, candidate+"@") {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePURL drops qualifiers and subpath and lower-cases the type.
+func normalizePURL(purl string) string {
+	purl = strings.TrimSpace(purl)
+	if idx := strings.IndexAny(purl, "?#"); idx >= 0 {
+		purl = purl[:idx]
+	}
+	if rest, ok := strings.CutPrefix(purl, "pkg:"); ok {
+		if slash := strings.Index(rest, "/"); slash > 0 {
+			purl = "pkg:" + strings.ToLower(rest[:slash]) + rest[slash:]
+		}
+	}
+	return purl
+}
+
+// purlNameSection returns the part after the last '/', which holds the
+// package name and optional @version.
+func purlNameSection(purl string) string {
+	if idx := strings.LastIndex(purl, "/"); idx >= 0 {
+		return purl[idx+1:]
+	}
+	return purl
+}
+
+func validVEXStatus(status string) bool {
+	switch status {
+	case VEXNotAffected, VEXAffected, VEXFixed, VEXUnderInvestigation:
+		return true
+	}
+	return false
+}
+
+func parseVEXTime(values ...string) time.Time {
+	for _, v := range values {
+		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(v)); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,178 @@
+package vulnerabilities
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const openVEXDoc = `{
+  "@context": "https://openvex.dev/ns/v0.2.0",
+  "@id": "https://example.test/vex/1",
+  "author": "security@example.test",
+  "timestamp": "2026-01-01T00:00:00Z",
+  "version": 1,
+  "statements": [
+    {
+      "vulnerability": {"name": "CVE-2099-0001", "aliases": ["GHSA-aaaa-bbbb-cccc"]},
+      "products": [{"@id": "pkg:golang/example.com/app", "subcomponents": [{"@id": "pkg:golang/example.com/lib"}]}],
+      "status": "not_affected",
+      "justification": "vulnerable_code_not_in_execute_path"
+    },
+    {
+      "vulnerability": {"name": "CVE-2099-0002"},
+      "products": [{"@id": "pkg:npm/left-pad@1.0.0"}],
+      "status": "under_investigation"
+    }
+  ]
+}`
+
+const cycloneDXVEXDoc = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [{"bom-ref": "lib-ref", "purl": "pkg:pypi/requests@2.0.0"}],
+  "vulnerabilities": [
+    {
+      "id": "CVE-2099-0003",
+      "analysis": {"state": "resolved", "detail": "patched in vendored copy"},
+      "affects": [{"ref": "lib-ref"}]
+    },
+    {
+      "id": "CVE-2099-0004",
+      "analysis": {"state": "false_positive", "justification": "code_not_present"},
+      "affects": [{"ref": "urn:cdx:1234/1#pkg:pypi/urllib3@1.0.0"}]
+    },
+    {"id": "CVE-2099-0005"}
+  ]
+}`
+
+func TestParseVEX_OpenVEX(t *testing.T) {
+	statements, err := ParseVEX([]byte(openVEXDoc))
+	if err != nil {
+		t.Fatalf("ParseVEX: %v", err)
+	}
+	if len(statements) != 2 {
+		t.Fatalf("statements = %d, want 2", len(statements))
+	}
+	st := statements[0]
+	if st.Vulnerability != "CVE-2099-0001" || st.Status != VEXNotAffected || st.Products[0] != "pkg:golang/example.com/lib" {
+		t.Errorf("unexpected statement: %+v", st)
+	}
+	if st.Timestamp.IsZero() {
+		t.Error("expected document timestamp to be inherited")
+	}
+}
+
+func TestParseVEX_CycloneDX(t *testing.T) {
+	statements, err := ParseVEX([]byte(cycloneDXVEXDoc))
+	if err != nil {
+		t.Fatalf("ParseVEX: %v", err)
+	}
+	if len(statements) != 2 {
+		t.Fatalf("statements = %d, want 2 (entries without analysis skipped)", len(statements))
+	}
+	if statements[0].Status != VEXFixed || statements[0].Products[0] != "pkg:pypi/requests@2.0.0" {
+		t.Errorf("resolved statement = %+v", statements[0])
+	}
+	if statements[1].Status != VEXNotAffected || statements[1].Products[0] != "pkg:pypi/urllib3@1.0.0" {
+		t.Errorf("false_positive statement = %+v", statements[1])
+	}
+}
+
+func TestParseVEX_Rejects(t *testing.T) {
+	for name, doc := range map[string]string{
+		"unknown format": `{"foo": 1}`,
+		"bad status":     `{"@context": "https://openvex.dev/ns/v0.2.0", "statements": [{"vulnerability": {"name": "X"}, "status": "maybe"}]}`,
+	} {
+		if _, err := ParseVEX([]byte(doc)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestApplyVEX(t *testing.T) {
+	statements, err := ParseVEX([]byte(openVEXDoc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	findings := []Finding{
+		// Matched by alias and versionless PURL.
+		{ID: "GHSA-aaaa-bbbb-cccc", Aliases: []string{"CVE-2099-0001"}, PURLs: []string{"pkg:golang/example.com/lib@v1.2.0"}},
+		// Only one of two packages covered: annotated but not suppressed.
+		{ID: "CVE-2099-0001", PURLs: []string{"pkg:golang/example.com/lib@v1.2.0", "pkg:golang/example.com/other@v1.0.0"}},
+		// under_investigation never suppresses.
+		{ID: "CVE-2099-0002", PURLs: []string{"pkg:npm/left-pad@1.0.0?arch=x"}},
+		// Different version of an exact-version product is not matched.
+		{ID: "CVE-2099-0002", PURLs: []string{"pkg:npm/left-pad@2.0.0"}},
+	}
+
+	if n := ApplyVEX(findings, statements); n != 1 {
+		t.Fatalf("suppressed = %d, want 1", n)
+	}
+	if !findings[0].Suppressed || findings[0].SuppressReason != "vex not_affected: vulnerable_code_not_in_execute_path" {
+		t.Errorf("finding 0 = %+v", findings[0])
+	}
+	if findings[1].Suppressed || findings[1].VEXStatus != VEXNotAffected {
+		t.Errorf("finding 1 = %+v", findings[1])
+	}
+	if findings[2].Suppressed || findings[2].VEXStatus != VEXUnderInvestigation {
+		t.Errorf("finding 2 = %+v", findings[2])
+	}
+	if findings[3].VEXStatus != "" {
+		t.Errorf("finding 3 = %+v", findings[3])
+	}
+}
+
+func TestApplyVEX_LatestStatementWins(t *testing.T) {
+	statements := []VEXStatement{
+		{Vulnerability: "CVE-1", Status: VEXNotAffected, Timestamp: mustTime(t, "2026-02-01T00:00:00Z")},
+		{Vulnerability: "CVE-1", Status: VEXAffected, Timestamp: mustTime(t, "2026-01-01T00:00:00Z")},
+	}
+	findings := []Finding{{ID: "CVE-1"}}
+	if n := ApplyVEX(findings, statements); n != 1 || findings[0].VEXStatus != VEXNotAffected {
+		t.Fatalf("expected newer not_affected statement to win: %+v", findings[0])
+	}
+}
+
+func TestLoadVEXDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.openvex.json"), []byte(openVEXDoc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.cdx.json"), []byte(cycloneDXVEXDoc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	statements, skipped, err := LoadVEXDir(dir)
+	if err != nil {
+		t.Fatalf("LoadVEXDir: %v", err)
+	}
+	if len(statements) != 4 || statements[0].Source != filepath.Join(dir, "a.openvex.json") {
+		t.Fatalf("statements = %+v", statements)
+	}
+	// The malformed document is skipped without dropping the valid ones
+	if len(skipped) != 1 || !strings.Contains(skipped[0].Error(), "broken.json") {
+		t.Fatalf("skipped = %v", skipped)
+	}
+
+	missing, _, err := LoadVEXDir(filepath.Join(dir, "missing"))
+	if err != nil || missing != nil {
+		t.Fatalf("missing dir: %v %v", missing, err)
+	}
+}
+
+func mustTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	ts, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
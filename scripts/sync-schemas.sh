@@ -28,4 +28,19 @@ done
 cp "$META_DIR/draft-07/schema.json" "$DEST_DIR/draft-07/schema.json"
 cp "$META_DIR/draft-2020-12/schema.json" "$DEST_DIR/draft-2020-12/schema.json"
 
+# Official API description schemas, pinned to immutable upstream revisions.
+# OpenAPI publishes each schema iteration under a dated URL; AsyncAPI schemas
+# come from asyncapi/spec-json-schemas at the commit tagged v6.8.0.
+OPENAPI_30_SCHEMA="https://spec.openapis.org/oas/3.0/schema/2021-09-28"
+OPENAPI_31_SCHEMA="https://spec.openapis.org/oas/3.1/schema/2022-10-07"
+ASYNCAPI_SCHEMAS_COMMIT="821af9042e2ce4611efc39ce2bb3a975e25746ac"
+ASYNCAPI_SCHEMAS_BASE="https://raw.githubusercontent.com/asyncapi/spec-json-schemas/${ASYNCAPI_SCHEMAS_COMMIT}/schemas"
+
+echo "Fetching OpenAPI and AsyncAPI schemas..."
+curl -fsSL "$OPENAPI_30_SCHEMA" -o "$META_DIR/openapi-3.0/schema.json"
+curl -fsSL "$OPENAPI_31_SCHEMA" -o "$META_DIR/openapi-3.1/schema.json"
+for version in 2.0.0 2.1.0 2.2.0 2.3.0 2.4.0 2.5.0 2.6.0 3.0.0; do
+	curl -fsSL "${ASYNCAPI_SCHEMAS_BASE}/${version}-without-\$id.json" -o "$META_DIR/asyncapi-${version%%.*}/${version}.json"
+done
+
 echo "✅ Synced meta-schemas to $META_DIR and $DEST_DIR"